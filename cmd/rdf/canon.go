@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// runCanon writes the RDFC-1.0 canonical N-Quads of a dataset, or with
+// -hash the SHA-256 hashes of datasets in the format of sha256sum.
+func runCanon(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := newFlagSet("canon", "[-from format] [-o file] [file] | -hash [file ...]", stderr)
+	from := flags.String("from", "auto", "input `format`: "+formatNames)
+	output := flags.String("o", "-", "output `file`, - for standard output")
+	hash := flags.Bool("hash", false, "print the SHA-256 hash of the canonical N-Quads of each file instead")
+	if err := flags.Parse(args); err != nil {
+		return flagError(err)
+	}
+	format, err := parseFormatFlag("from", *from)
+	if err != nil {
+		return err
+	}
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	if !*hash && len(files) > 1 {
+		return &usageError{msg: "canonicalizing more than one file requires -hash"}
+	}
+
+	var out bytes.Buffer
+	for _, file := range files {
+		canonical, err := canonicalNQuads(ctx, file, format, stdin)
+		if err != nil {
+			return err
+		}
+		if *hash {
+			fmt.Fprintf(&out, "%x  %s\n", sha256.Sum256(canonical), displayName(file))
+		} else {
+			out.Write(canonical)
+		}
+	}
+	return writeOutput(*output, out.Bytes(), stdout)
+}
+
+// errBlankNodesDiffer is returned by diff for datasets whose statements
+// with blank nodes differ.
+var errBlankNodesDiffer = errors.New("the statements with blank nodes differ, which an RDF Patch cannot address")
+
+// runDiff writes the changes from one dataset to another as an RDF Patch
+// transaction. Statements without blank nodes are compared as canonical
+// N-Quads lines. RDF Patch names blank nodes by their labels in before,
+// which canonicalization does not keep, so the statements with blank nodes
+// must be the same in both datasets up to blank node labels.
+func runDiff(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := newFlagSet("diff", "[-from format] [-o file] before after", stderr)
+	from := flags.String("from", "auto", "input `format` of both files: "+formatNames)
+	output := flags.String("o", "-", "output `file`, - for standard output")
+	if err := flags.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if flags.NArg() != 2 {
+		return &usageError{msg: "diff takes two files"}
+	}
+	if isStdio(flags.Arg(0)) && isStdio(flags.Arg(1)) {
+		return &usageError{msg: "diff cannot read both files from standard input"}
+	}
+	format, err := parseFormatFlag("from", *from)
+	if err != nil {
+		return err
+	}
+	before, beforeBlank, err := diffInput(ctx, flags.Arg(0), format, stdin)
+	if err != nil {
+		return err
+	}
+	after, afterBlank, err := diffInput(ctx, flags.Arg(1), format, stdin)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(beforeBlank, afterBlank) {
+		return fmt.Errorf("%s and %s: %w", displayName(flags.Arg(0)), displayName(flags.Arg(1)), errBlankNodesDiffer)
+	}
+	return writeOutput(*output, patch(lines(before), lines(after)), stdout)
+}
+
+// diffInput reads the dataset in the file at path, or in stdin if path is
+// "-" or "", and returns the canonical N-Quads of its statements without
+// blank nodes and of those with blank nodes.
+func diffInput(ctx context.Context, path string, format rdf.Format, stdin io.Reader) (ground, blank []byte, err error) {
+	stmts, err := readStatements(ctx, path, format, stdin)
+	if err != nil {
+		return nil, nil, err
+	}
+	var groundStmts, blankStmts []rdf.Statement
+	for _, stmt := range stmts {
+		if hasBlankNode(stmt.S) || hasBlankNode(stmt.O) || hasBlankNode(stmt.G) {
+			blankStmts = append(blankStmts, stmt)
+		} else {
+			groundStmts = append(groundStmts, stmt)
+		}
+	}
+	if ground, err = canonicalize(path, groundStmts); err != nil {
+		return nil, nil, err
+	}
+	if blank, err = canonicalize(path, blankStmts); err != nil {
+		return nil, nil, err
+	}
+	return ground, blank, nil
+}
+
+// hasBlankNode reports whether term is or contains a blank node.
+func hasBlankNode(term rdf.Term) bool {
+	switch t := term.(type) {
+	case rdf.BlankNode:
+		return true
+	case rdf.TripleTerm:
+		return hasBlankNode(t.S) || hasBlankNode(t.O)
+	}
+	return false
+}
+
+// patch returns the RDF Patch transaction that deletes the lines of before
+// missing from after and adds those of after missing from before, or
+// nothing if there are none. Both must be sorted.
+func patch(before, after [][]byte) []byte {
+	var deleted, added [][]byte
+	for len(before) > 0 || len(after) > 0 {
+		switch {
+		case len(after) == 0 || len(before) > 0 && bytes.Compare(before[0], after[0]) < 0:
+			deleted, before = append(deleted, before[0]), before[1:]
+		case len(before) == 0 || bytes.Compare(before[0], after[0]) > 0:
+			added, after = append(added, after[0]), after[1:]
+		default:
+			before, after = before[1:], after[1:]
+		}
+	}
+	if len(deleted) == 0 && len(added) == 0 {
+		return nil
+	}
+	var b bytes.Buffer
+	b.WriteString("TX .\n")
+	for _, line := range deleted {
+		fmt.Fprintf(&b, "D %s\n", line)
+	}
+	for _, line := range added {
+		fmt.Fprintf(&b, "A %s\n", line)
+	}
+	b.WriteString("TC .\n")
+	return b.Bytes()
+}
+
+// lines splits a canonical N-Quads document into its lines.
+func lines(doc []byte) [][]byte {
+	if len(doc) == 0 {
+		return nil
+	}
+	return bytes.Split(bytes.TrimSuffix(doc, []byte("\n")), []byte("\n"))
+}
+
+// canonicalNQuads reads the dataset in the file at path, or in stdin if
+// path is "-" or "", and returns its canonical N-Quads.
+func canonicalNQuads(ctx context.Context, path string, format rdf.Format, stdin io.Reader) ([]byte, error) {
+	stmts, err := readStatements(ctx, path, format, stdin)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalize(path, stmts)
+}
+
+// readStatements reads the statements of the file at path, or of stdin if
+// path is "-" or "".
+func readStatements(ctx context.Context, path string, format rdf.Format, stdin io.Reader) ([]rdf.Statement, error) {
+	reader, err := openInput(path, format, stdin, rdf.OptContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var stmts []rdf.Statement
+	for {
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+// canonicalize returns the canonical N-Quads of the statements read from
+// the file at path.
+func canonicalize(path string, stmts []rdf.Statement) ([]byte, error) {
+	canonical, err := rdf.CanonicalNQuads(stmts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", displayName(path), err)
+	}
+	return canonical, nil
+}
+
+// writeOutput writes data to the file at path, or to stdout if path is "-"
+// or "".
+func writeOutput(path string, data []byte, stdout io.Writer) error {
+	var err error
+	if isStdio(path) {
+		_, err = stdout.Write(data)
+	} else {
+		err = os.WriteFile(path, data, 0o666)
+	}
+	if err != nil {
+		return &outputError{err}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes the named files to a temporary directory and returns
+// their paths.
+func writeFiles(t *testing.T, files map[string]string) map[string]string {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make(map[string]string, len(files))
+	for name, content := range files {
+		paths[name] = filepath.Join(dir, name)
+		if err := os.WriteFile(paths[name], []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+func TestCanon(t *testing.T) {
+	const want = `<http://example.org/s> <http://example.org/p> _:c14n0 .
+_:c14n0 <http://example.org/q> "v" .
+`
+	paths := writeFiles(t, map[string]string{
+		"a.ttl": "@prefix ex: <http://example.org/> .\nex:s ex:p [ ex:q \"v\" ] .\n",
+		"b.nt":  "_:x <http://example.org/q> \"v\"^^<http://www.w3.org/2001/XMLSchema#string> .\n<http://example.org/s> <http://example.org/p> _:x .\n",
+	})
+	code, stdout, stderr := runCommand(t, "", "canon", paths["a.ttl"])
+	if code != exitOK || stdout != want {
+		t.Fatalf("exit status = %d, stderr = %q, stdout =\n%s\nwant\n%s", code, stderr, stdout, want)
+	}
+
+	code, stdout, stderr = runCommand(t, "", "canon", "-hash", paths["a.ttl"], paths["b.nt"])
+	sum := fmt.Sprintf("%x", sha256.Sum256([]byte(want)))
+	wantHashes := sum + "  " + paths["a.ttl"] + "\n" + sum + "  " + paths["b.nt"] + "\n"
+	if code != exitOK || stdout != wantHashes {
+		t.Errorf("exit status = %d, stderr = %q, stdout = %q; want %q", code, stderr, stdout, wantHashes)
+	}
+
+	if code, _, stderr := runCommand(t, "", "canon", paths["a.ttl"], paths["b.nt"]); code != exitUsage || !strings.Contains(stderr, "requires -hash") {
+		t.Errorf("two files without -hash: exit status = %d, stderr = %q", code, stderr)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	paths := writeFiles(t, map[string]string{
+		"a.ttl": "@prefix ex: <http://example.org/> .\nex:s ex:p [ ex:q \"v\" ] ; ex:r \"old\" .\n",
+		"b.ttl": "@prefix ex: <http://example.org/> .\n_:n ex:q \"v\" .\nex:s ex:p _:n ; ex:r \"new\"@en .\n",
+		"c.ttl": "@prefix ex: <http://example.org/> .\nex:s ex:p [ ex:q \"w\" ] ; ex:r \"old\" .\n",
+	})
+	code, stdout, stderr := runCommand(t, "", "diff", paths["a.ttl"], paths["b.ttl"])
+	const want = `TX .
+D <http://example.org/s> <http://example.org/r> "old" .
+A <http://example.org/s> <http://example.org/r> "new"@en .
+TC .
+`
+	if code != exitOK || stdout != want {
+		t.Errorf("exit status = %d, stderr = %q, stdout =\n%s\nwant\n%s", code, stderr, stdout, want)
+	}
+
+	if code, stdout, _ := runCommand(t, "", "diff", paths["a.ttl"], paths["a.ttl"]); code != exitOK || stdout != "" {
+		t.Errorf("identical files: exit status = %d, stdout = %q", code, stdout)
+	}
+	if code, _, _ := runCommand(t, "", "diff", paths["a.ttl"]); code != exitUsage {
+		t.Errorf("one file: exit status = %d, want %d", code, exitUsage)
+	}
+	if code, stdout, stderr := runCommand(t, "", "diff", paths["a.ttl"], paths["c.ttl"]); code != exitError || stdout != "" || !strings.Contains(stderr, "blank nodes") {
+		t.Errorf("changed blank node statements: exit status = %d, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if code, _, stderr := runCommand(t, "", "diff", "-", "-"); code != exitUsage || !strings.Contains(stderr, "standard input") {
+		t.Errorf("diff - -: exit status = %d, stderr = %q", code, stderr)
+	}
+}
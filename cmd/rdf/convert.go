@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+const formatNames = "auto, turtle, trig, ntriples, nquads, rdfxml or jsonld"
+
+// runConvert streams the statements of the input to the output, one at a
+// time, so that documents of any size convert in constant memory (except
+// for the formats whose writers group statements).
+func runConvert(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := newFlagSet("convert", "[-i file] [-o file] [-from format] [-to format] [-compress none|gzip|zstd]", stderr)
+	input := flags.String("i", "-", "input `file`, - for standard input")
+	output := flags.String("o", "-", "output `file`, - for standard output")
+	from := flags.String("from", "auto", "input `format`: "+formatNames)
+	to := flags.String("to", "auto", "output `format`: "+formatNames+"; auto takes it from the output file name")
+	compress := flags.String("compress", "auto", "output `compression`: none, gzip or zstd; auto takes it from the output file name")
+	base := flags.String("base", "", "base `IRI` against which relative IRIs of the input are resolved")
+	progress := flags.Bool("progress", false, "report progress on standard error")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	inFormat, err := parseFormatFlag("from", *from)
+	if err != nil {
+		return err
+	}
+	outFormat, err := parseFormatFlag("to", *to)
+	if err != nil {
+		return err
+	}
+	compression, setCompression, err := parseCompressionFlag(*compress)
+	if err != nil {
+		return err
+	}
+
+	opts := []rdf.Option{rdf.OptContext(ctx)}
+	if *base != "" {
+		opts = append(opts, rdf.OptBaseIRI(*base))
+	}
+	if *progress {
+		opts = append(opts, rdf.OptProgress(progressReporter(stderr)))
+	}
+	reader, err := openInput(*input, inFormat, stdin, opts...)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	writer, err := createOutput(*output, outFormat, compression, setCompression, stdout)
+	if err != nil {
+		return &outputError{err}
+	}
+	if err := copyStatements(writer, reader); err != nil {
+		writer.Close()
+		if !isStdio(*output) {
+			os.Remove(*output)
+		}
+		return err
+	}
+	return nil
+}
+
+// copyStatements writes the statements of r to w and closes w.
+func copyStatements(w rdf.Writer, r rdf.Reader) error {
+	for {
+		stmt, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := w.Write(stmt); err != nil {
+			return &outputError{err}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return &outputError{err}
+	}
+	return nil
+}
+
+// progressReporter returns an OptProgress callback that rewrites a status
+// line on w.
+func progressReporter(w io.Writer) func(rdf.ProgressInfo) {
+	return func(p rdf.ProgressInfo) {
+		fmt.Fprintf(w, "\r%s read, %d statements, %.0f statements/s", formatBytes(p.BytesRead), p.Statements, p.StatementsPerSecond())
+		if p.Done {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// formatBytes formats n with a binary unit, as in "12.5 MiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// newFlagSet returns the flag set of a command, printing its usage and
+// errors on stderr.
+func newFlagSet(name, synopsis string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet("rdf "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: rdf %s %s\n\nFlags:\n", name, synopsis)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses args and rejects positional arguments.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if flags.NArg() > 0 {
+		return &usageError{msg: fmt.Sprintf("unexpected argument %q", flags.Arg(0))}
+	}
+	return nil
+}
+
+// flagError returns the error for a failed FlagSet.Parse, which the flag
+// set has already reported.
+func flagError(err error) error {
+	if err == flag.ErrHelp {
+		return err
+	}
+	return &usageError{msg: err.Error(), reported: true}
+}
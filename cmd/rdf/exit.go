@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// Exit statuses, documented in the package comment.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitSyntax      = 3
+	exitLimit       = 4
+	exitUnwritable  = 5
+	exitLint        = 6
+	exitInterrupted = 130
+)
+
+// usageError is an error in the command line. If reported is set, the flag
+// package has already printed it along with the usage of the command.
+type usageError struct {
+	msg      string
+	reported bool
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+// outputError is an error of the writer, as opposed to the reader.
+type outputError struct {
+	err error
+}
+
+func (e *outputError) Error() string { return e.err.Error() }
+
+func (e *outputError) Unwrap() error { return e.err }
+
+// exitCode returns the exit status for err, from its rdf error code.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return exitUsage
+	}
+	if errors.Is(err, errBlankNodesDiffer) {
+		return exitError
+	}
+	var validateErr *validateError
+	if errors.As(err, &validateErr) && validateErr.fatal == nil {
+		if validateErr.errors == 0 {
+			return exitLint
+		}
+		return exitSyntax
+	}
+	var parseErr *rdf.ParseError
+	isParseErr := errors.As(err, &parseErr)
+	var outputErr *outputError
+	if errors.As(err, &outputErr) && !isParseErr {
+		if errors.Is(err, rdf.ErrUnsupportedFormat) || isIOError(err) {
+			return exitError
+		}
+		return exitUnwritable
+	}
+	if !isParseErr && isIOError(err) {
+		return exitError
+	}
+	if errors.Is(err, rdf.ErrCanonicalizationLimit) {
+		return exitLimit
+	}
+	switch rdf.Code(err) {
+	case rdf.ErrCodeUnsupportedFormat:
+		return exitUsage
+	case rdf.ErrCodeCanceled:
+		return exitInterrupted
+	case rdf.ErrCodeLineTooLong, rdf.ErrCodeStatementTooLong, rdf.ErrCodeDepthExceeded,
+		rdf.ErrCodeTripleLimitExceeded, rdf.ErrCodeEntityLimitExceeded, rdf.ErrCodeLiteralTooLong,
+		rdf.ErrCodeIRITooLong, rdf.ErrCodeBlankNodeLimitExceeded, rdf.ErrCodePrefixLimitExceeded,
+		rdf.ErrCodeBudgetExceeded:
+		return exitLimit
+	case rdf.ErrCodeIOError:
+		return exitError
+	}
+	return exitSyntax
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// isStdio reports whether path names standard input or output.
+func isStdio(path string) bool {
+	return path == "" || path == "-"
+}
+
+// parseFormatFlag returns the format named by the value of a format flag,
+// where "auto" and "" mean FormatAuto.
+func parseFormatFlag(name, value string) (rdf.Format, error) {
+	format, ok := rdf.ParseFormat(value)
+	if !ok {
+		return "", &usageError{msg: fmt.Sprintf("unknown format %q for -%s", value, name)}
+	}
+	return format, nil
+}
+
+// parseCompressionFlag returns the compression named by the value of the
+// -compress flag, and whether it names one; "auto" and "" leave the choice
+// to the output file name.
+func parseCompressionFlag(value string) (rdf.Compression, bool, error) {
+	switch strings.ToLower(value) {
+	case "", "auto":
+		return rdf.CompressionNone, false, nil
+	case "none":
+		return rdf.CompressionNone, true, nil
+	case "gzip", "gz":
+		return rdf.CompressionGzip, true, nil
+	case "zstd", "zst":
+		return rdf.CompressionZstd, true, nil
+	}
+	return "", false, &usageError{msg: fmt.Sprintf("unknown compression %q for -compress (want none, gzip or zstd)", value)}
+}
+
+// compressionFromPath returns the compression named by the extension of
+// an output file, as rdf.CreateFile chooses it.
+func compressionFromPath(path string) rdf.Compression {
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		return rdf.CompressionGzip
+	case strings.HasSuffix(path, ".zst"), strings.HasSuffix(path, ".zstd"):
+		return rdf.CompressionZstd
+	}
+	return rdf.CompressionNone
+}
+
+// openInput returns a reader of the file at path, or of stdin if path is
+// "-" or "", in format. FormatAuto takes the format from the file name and
+// then from the content. Compressed input is decompressed.
+func openInput(path string, format rdf.Format, stdin io.Reader, opts ...rdf.Option) (rdf.Reader, error) {
+	opts = append([]rdf.Option{rdf.OptDecompress()}, opts...)
+	if isStdio(path) {
+		return rdf.NewReader(stdin, format, opts...)
+	}
+	if format == rdf.FormatAuto {
+		return rdf.OpenFile(path, opts...)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := rdf.NewReader(file, format, opts...)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &fileReader{Reader: reader, file: file}, nil
+}
+
+// fileReader closes the file opened by openInput along with the Reader.
+type fileReader struct {
+	rdf.Reader
+	file *os.File
+}
+
+func (r *fileReader) Close() error {
+	err := r.Reader.Close()
+	if closeErr := r.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// createOutput returns a writer to the file at path, or to stdout if path
+// is "-" or "", in format. FormatAuto takes the format from the file name.
+// The output is compressed with compression if set, and otherwise as the
+// file name says.
+func createOutput(path string, format rdf.Format, compression rdf.Compression, setCompression bool, stdout io.Writer, opts ...rdf.Option) (rdf.Writer, error) {
+	if setCompression {
+		opts = append(opts, rdf.OptCompress(compression))
+	}
+	if isStdio(path) {
+		if format == rdf.FormatAuto {
+			return nil, &usageError{msg: "-to is required when writing to standard output"}
+		}
+		return rdf.NewWriter(stdout, format, opts...)
+	}
+	if format == rdf.FormatAuto {
+		writer, err := rdf.CreateFile(path, opts...)
+		if errors.Is(err, rdf.ErrUnsupportedFormat) {
+			return nil, &usageError{msg: fmt.Sprintf("cannot tell the format of %s from its name; set -to", path)}
+		}
+		return writer, err
+	}
+	if !setCompression {
+		opts = append(opts, rdf.OptCompress(compressionFromPath(path)))
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := rdf.NewWriter(file, format, opts...)
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	return &fileWriter{Writer: writer, file: file}, nil
+}
+
+// fileWriter closes the file created by createOutput along with the Writer.
+type fileWriter struct {
+	rdf.Writer
+	file *os.File
+}
+
+func (w *fileWriter) Close() error {
+	err := w.Writer.Close()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// isIOError reports whether err comes from the file system or the
+// operating system rather than from the content of a document.
+func isIOError(err error) bool {
+	var pathErr *fs.PathError
+	var syscallErr *os.SyscallError
+	return errors.As(err, &pathErr) || errors.As(err, &syscallErr) || errors.Is(err, io.ErrShortWrite)
+}
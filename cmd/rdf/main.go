@@ -0,0 +1,101 @@
+// Command rdf converts and checks RDF documents with the rdf package.
+//
+// Usage:
+//
+//	rdf <command> [flags]
+//
+// The commands are:
+//
+//	convert   read a document in one format and write it in another
+//	validate  report the syntax errors of documents, and warnings with -lint
+//	canon     write the RDFC-1.0 canonical N-Quads or SHA-256 hash of datasets
+//	diff      write the changes between two datasets as an RDF Patch
+//
+// Run "rdf <command> -h" for the flags of a command. Input and output
+// default to standard input and output; "-" names them explicitly.
+// Compressed input (gzip, Zstandard, bzip2) is decompressed automatically.
+//
+// The exit status tells failures apart:
+//
+//	0    success
+//	1    I/O or other error
+//	2    usage error, such as an unknown flag, format or compression
+//	3    syntax error in the input
+//	4    the input exceeds a limit, or is too symmetric to canonicalize
+//	5    the output format cannot hold a statement of the input
+//	6    validate -lint found warnings but no errors
+//	130  interrupted
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// command is a subcommand of rdf.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{"convert", "read a document in one format and write it in another", runConvert},
+	{"validate", "report the syntax errors of documents, and warnings with -lint", runValidate},
+	{"canon", "write the RDFC-1.0 canonical N-Quads or SHA-256 hash of datasets", runCanon},
+	{"diff", "write the changes between two datasets as an RDF Patch", runDiff},
+}
+
+// run runs the command line args and returns the exit status.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return exitOK
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			err := cmd.run(ctx, args[1:], stdin, stdout, stderr)
+			if err != nil && !reported(err) {
+				fmt.Fprintf(stderr, "rdf %s: %v\n", cmd.name, err)
+			}
+			return exitCode(err)
+		}
+	}
+	fmt.Fprintf(stderr, "rdf: unknown command %q\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+// reported reports whether the flag package has already printed err.
+func reported(err error) bool {
+	var usageErr *usageError
+	return errors.Is(err, flag.ErrHelp) || errors.As(err, &usageErr) && usageErr.reported
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: rdf <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "rdf <command> -h" for the flags of a command.`)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+const ntriples = `<http://example.org/s> <http://example.org/p> "v" .
+<http://example.org/s> <http://example.org/q> _:b .
+`
+
+// runCommand runs the command line args on stdin and returns its exit
+// status and output.
+func runCommand(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestConvertStdio(t *testing.T) {
+	code, stdout, stderr := runCommand(t, ntriples, "convert", "-from", "ntriples", "-to", "turtle")
+	if code != exitOK {
+		t.Fatalf("exit status = %d, stderr = %q", code, stderr)
+	}
+	stmts, err := readAll(strings.NewReader(stdout), rdf.FormatTurtle)
+	if err != nil {
+		t.Fatalf("read output: %v\n%s", err, stdout)
+	}
+	if len(stmts) != 2 {
+		t.Errorf("output has %d statements, want 2:\n%s", len(stmts), stdout)
+	}
+}
+
+func TestConvertFiles(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "data.ttl")
+	output := filepath.Join(dir, "data.nq.zst")
+	if code, _, stderr := runCommand(t, ntriples, "convert", "-from", "ntriples", "-o", input); code != exitOK {
+		t.Fatalf("write %s: exit status = %d, stderr = %q", input, code, stderr)
+	}
+	code, _, stderr := runCommand(t, "", "convert", "-i", input, "-o", output, "-progress")
+	if code != exitOK {
+		t.Fatalf("exit status = %d, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stderr, "2 statements") {
+		t.Errorf("progress = %q, want the statement count", stderr)
+	}
+	reader, err := rdf.OpenFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	n := 0
+	for {
+		if _, err := reader.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("%s has %d statements, want 2", output, n)
+	}
+}
+
+func TestConvertExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		stdin  string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"syntax error", "<http://example.org/s> <http://example.org/p> .\n", []string{"-from", "ntriples", "-to", "nquads"}, exitSyntax, "ntriples:1:47"},
+		{"unknown format", ntriples, []string{"-to", "n3"}, exitUsage, `unknown format "n3"`},
+		{"stdout without format", ntriples, nil, exitUsage, "-to is required"},
+		{"unknown extension", ntriples, []string{"-from", "ntriples", "-o", filepath.Join(dir, "data.txt")}, exitUsage, "set -to"},
+		{"unknown flag", ntriples, []string{"-x"}, exitUsage, "flag provided but not defined"},
+		{"extra argument", ntriples, []string{"data.ttl"}, exitUsage, "unexpected argument"},
+		{"missing input", "", []string{"-i", filepath.Join(dir, "missing.ttl"), "-to", "nquads"}, exitError, "no such file"},
+		{"unwritable statement", `<http://example.org/s> <http://example.org/p> <<( <http://example.org/a> <http://example.org/b> <http://example.org/c> )>> .`,
+			[]string{"-from", "turtle", "-to", "rdfxml"}, exitUnwritable, ""},
+		{"help", "", []string{"-h"}, exitOK, "Usage: rdf convert"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCommand(t, tt.stdin, append([]string{"convert"}, tt.args...)...)
+			if code != tt.code || !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("exit status = %d, stderr = %q; want %d and %q", code, stderr, tt.code, tt.stderr)
+			}
+		})
+	}
+}
+
+func TestConvertCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, []string{"convert", "-from", "ntriples", "-to", "nquads"}, strings.NewReader(ntriples), &stdout, &stderr)
+	if code != exitInterrupted {
+		t.Errorf("exit status = %d, stderr = %q; want %d", code, stderr.String(), exitInterrupted)
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	if code, _, _ := runCommand(t, ""); code != exitUsage {
+		t.Errorf("no command: exit status = %d, want %d", code, exitUsage)
+	}
+	if code, _, stderr := runCommand(t, "", "frobnicate"); code != exitUsage || !strings.Contains(stderr, "unknown command") {
+		t.Errorf("unknown command: exit status = %d, stderr = %q", code, stderr)
+	}
+	if code, stdout, _ := runCommand(t, "", "help"); code != exitOK || !strings.Contains(stdout, "convert") {
+		t.Errorf("help: exit status = %d, stdout = %q", code, stdout)
+	}
+}
+
+// readAll returns the statements of r in format.
+func readAll(r io.Reader, format rdf.Format) ([]rdf.Statement, error) {
+	reader, err := rdf.NewReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var stmts []rdf.Statement
+	for {
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			return stmts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// runValidate checks the syntax of each file, recovering from errors so that
+// one run reports all of them.
+func runValidate(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := newFlagSet("validate", "[-from format] [-lint] [-max-errors n] [file ...]", stderr)
+	from := flags.String("from", "auto", "input `format`: "+formatNames)
+	base := flags.String("base", "", "base `IRI` against which relative IRIs are resolved")
+	lint := flags.Bool("lint", false, "also report warnings: ill-formed or unknown XSD datatypes, malformed language tags, relative IRIs and duplicate prefixes")
+	maxErrors := flags.Int("max-errors", 0, "stop a file after `n` errors; 0 reports all of them")
+	if err := flags.Parse(args); err != nil {
+		return flagError(err)
+	}
+	format, err := parseFormatFlag("from", *from)
+	if err != nil {
+		return err
+	}
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var summary validateError
+	for _, file := range files {
+		var warnings []diagnostic
+		opts := []rdf.Option{rdf.OptContext(ctx), rdf.OptContinueOnError(*maxErrors)}
+		if *base != "" {
+			opts = append(opts, rdf.OptBaseIRI(*base))
+		}
+		if *lint {
+			opts = append(opts, rdf.OptWarnings(func(w rdf.Warning) {
+				warnings = append(warnings, diagnostic{line: w.Line, column: w.Column, severity: "warning", msg: fmt.Sprintf("%s [%s]", w.Message, w.Code)})
+			}))
+		}
+		errs := validateFile(file, format, stdin, opts)
+		if len(errs) > 0 && !isSyntaxError(errs[len(errs)-1]) {
+			// A file that cannot be read or exceeds a limit decides the exit
+			// status over syntax errors elsewhere.
+			summary.fatal = errs[len(errs)-1]
+		}
+		diags := warnings
+		for _, err := range errs {
+			diags = append(diags, errorDiagnostic(err))
+		}
+		slices.SortStableFunc(diags, func(a, b diagnostic) int {
+			return cmp.Or(cmp.Compare(a.line, b.line), cmp.Compare(a.column, b.column))
+		})
+		for _, d := range diags {
+			fmt.Fprintln(stdout, d.format(displayName(file)))
+		}
+		summary.files++
+		summary.errors += len(errs)
+		summary.warnings += len(warnings)
+	}
+	if summary.errors == 0 && summary.warnings == 0 {
+		return nil
+	}
+	return &summary
+}
+
+// validateFile reads the file at path to the end and returns the errors it
+// skipped followed by the error that stopped it, if any.
+func validateFile(path string, format rdf.Format, stdin io.Reader, opts []rdf.Option) []error {
+	reader, err := openInput(path, format, stdin, opts...)
+	if err != nil {
+		return []error{err}
+	}
+	defer reader.Close()
+	for {
+		_, err = reader.Next()
+		if err != nil {
+			break
+		}
+	}
+	var errs []error
+	if collector, ok := reader.(rdf.ErrorCollector); ok {
+		errs = collector.Errors()
+	}
+	if err != io.EOF {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// isSyntaxError reports whether err is a syntax error, as opposed to an I/O
+// error, a limit or a cancellation.
+func isSyntaxError(err error) bool {
+	return exitCode(err) == exitSyntax
+}
+
+// diagnostic is an error or warning at a position of a file.
+type diagnostic struct {
+	line, column int
+	severity     string
+	msg          string
+}
+
+// format returns the diagnostic as "file:line:col: severity: message".
+func (d diagnostic) format(file string) string {
+	var b strings.Builder
+	b.WriteString(file)
+	if d.line > 0 {
+		fmt.Fprintf(&b, ":%d", d.line)
+		if d.column > 0 {
+			fmt.Fprintf(&b, ":%d", d.column)
+		}
+	}
+	fmt.Fprintf(&b, ": %s: %s", d.severity, d.msg)
+	return b.String()
+}
+
+// errorDiagnostic returns the position and message of err without the
+// format name and input excerpt a ParseError adds.
+func errorDiagnostic(err error) diagnostic {
+	var parseErr *rdf.ParseError
+	if !errors.As(err, &parseErr) {
+		return diagnostic{severity: "error", msg: err.Error()}
+	}
+	d := diagnostic{line: parseErr.Line, column: parseErr.Column, severity: "error"}
+	for {
+		var inner *rdf.ParseError
+		if !errors.As(parseErr.Err, &inner) {
+			break
+		}
+		parseErr = inner
+	}
+	d.msg = strings.TrimPrefix(parseErr.Err.Error(), parseErr.Format+": ")
+	return d
+}
+
+// displayName returns the name of the file at path in diagnostics.
+func displayName(path string) string {
+	if isStdio(path) {
+		return "<stdin>"
+	}
+	return path
+}
+
+// validateError summarizes the diagnostics of rdf validate. Its exit status
+// is that of fatal if set, exitSyntax if there were errors and exitLint if
+// there were only warnings.
+type validateError struct {
+	files, errors, warnings int
+	fatal                   error
+}
+
+func (e *validateError) Error() string {
+	return fmt.Sprintf("%s, %s in %s", plural(e.errors, "error"), plural(e.warnings, "warning"), plural(e.files, "file"))
+}
+
+func (e *validateError) Unwrap() error { return e.fatal }
+
+// plural returns n followed by noun, with an s unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
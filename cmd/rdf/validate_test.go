@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const invalidTurtle = `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:a ex:p "x"^^xsd:integr .
+ex:a ex:p "abc"^^xsd:integer .
+ex:a foo:p ex:b .
+ex:a ex:p ex:b ex:c .
+ex:z ex:p ex:q .
+`
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.ttl")
+	valid := filepath.Join(dir, "valid.ttl")
+	lintOnly := filepath.Join(dir, "lint.ttl")
+	for path, content := range map[string]string{
+		invalid:  invalidTurtle,
+		valid:    "<http://example.org/s> <http://example.org/p> \"v\" .\n",
+		lintOnly: "<http://example.org/s> <http://example.org/p> \"1\"^^<http://www.w3.org/2001/XMLSchema#integr> .\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout []string
+		stderr string
+	}{
+		{"valid", []string{valid}, exitOK, nil, ""},
+		{"all errors", []string{invalid}, exitSyntax, []string{
+			invalid + ":5:6: error: undefined prefix: foo",
+			invalid + ":6:16: error: expected ',' or ';' or '.'",
+		}, "2 errors, 0 warnings in 1 file"},
+		{"lint", []string{"-lint", valid, invalid}, exitSyntax, []string{
+			invalid + ":3: warning: xsd:integr is not an XSD datatype usable in RDF [UNKNOWN_DATATYPE]",
+			invalid + `:4: warning: "abc" is not a valid xsd:integer lexical form [ILL_FORMED_LITERAL]`,
+			invalid + ":5:6: error: undefined prefix: foo",
+			invalid + ":6:16: error: expected ',' or ';' or '.'",
+		}, "2 errors, 2 warnings in 2 files"},
+		{"warnings only", []string{"-lint", lintOnly}, exitLint, nil, "0 errors, 1 warning in 1 file"},
+		{"max errors", []string{"-max-errors", "1", invalid}, exitSyntax, []string{
+			invalid + ":5:6: error: undefined prefix: foo",
+			invalid + ":6:16: error: expected ',' or ';' or '.'",
+		}, ""},
+		{"missing file", []string{invalid, filepath.Join(dir, "missing.ttl")}, exitError, nil, "3 errors, 0 warnings in 2 files"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCommand(t, "", append([]string{"validate"}, tt.args...)...)
+			if code != tt.code || !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("exit status = %d, stderr = %q; want %d and %q", code, stderr, tt.code, tt.stderr)
+			}
+			if tt.stdout != nil && stdout != strings.Join(tt.stdout, "\n")+"\n" {
+				t.Errorf("stdout = %q, want %q", stdout, tt.stdout)
+			}
+		})
+	}
+}
+
+func TestValidateStdin(t *testing.T) {
+	code, stdout, _ := runCommand(t, "<http://example.org/s> <http://example.org/p> .\n", "validate", "-from", "ntriples")
+	if code != exitSyntax || !strings.HasPrefix(stdout, "<stdin>:1:") {
+		t.Errorf("exit status = %d, stdout = %q", code, stdout)
+	}
+}
@@ -3,6 +3,12 @@ module github.com/geoknoesis/rdf-go
 go 1.25.5
 
 require (
-	github.com/piprate/json-gold v0.7.0 // indirect
+	github.com/piprate/json-gold v0.7.0
+	go.opentelemetry.io/otel v1.44.0
+	go.opentelemetry.io/otel/trace v1.44.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/pquerna/cachecontrol v0.0.0-20180517163645-1555304b9b35 // indirect
 )
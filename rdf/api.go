@@ -1,9 +1,14 @@
 package rdf
 
 import (
-	"bytes"
+	"bufio"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"maps"
+	"net/http"
+	"time"
 )
 
 // Reader streams RDF statements from an input.
@@ -32,11 +37,29 @@ type Options struct {
 	// Context for cancellation and timeouts
 	Context context.Context
 
-	// Security limits for untrusted input
+	// Security limits for untrusted input (see Limits)
 	MaxLineBytes      int
 	MaxStatementBytes int
 	MaxDepth          int
 	MaxTriples        int64
+	MaxLiteralLength  int
+	MaxIRILength      int
+	MaxBlankNodes     int
+	MaxPrefixCount    int
+	FormatLimits      map[Format]Limits // Limits overriding the above for one format
+
+	// Decoding budget for untrusted input (see OptBudget)
+	MaxWallTime      time.Duration // Time a reader may take from its creation (0 = unlimited)
+	MaxBufferedBytes int64         // Bytes of statements a decoder may buffer (0 = unlimited)
+
+	// RDF/XML DTD limits for untrusted input
+	DisallowDTD        bool // Reject RDF/XML documents with a DOCTYPE declaration
+	MaxEntityDepth     int  // Maximum nesting of entity references in DTD entities
+	MaxEntityExpansion int  // Maximum bytes of text produced by entity expansion
+
+	// HTTP fetching (see Fetch)
+	HTTPClient    *http.Client // Client performing requests (nil = http.DefaultClient)
+	MaxFetchBytes int64        // Maximum size of a fetched response body (0 = unlimited)
 
 	// Format-specific options
 	AllowQuotedTripleStatement bool
@@ -44,9 +67,124 @@ type Options struct {
 
 	// IRI validation
 	StrictIRIValidation bool // Enable strict IRI validation according to RFC 3987
+	ValidateIRIs        bool // Reject statements containing IRIs that are not absolute RFC 3987 IRIs
 
 	// RDF/XML container expansion
 	ExpandRDFXMLContainers bool // Enable RDF/XML container membership expansion (default: true)
+
+	// BaseIRI is the document base IRI for resolving relative IRIs (empty = none)
+	BaseIRI string
+
+	// RDFXMLReifiers reads rdf:ID on RDF/XML property elements as an RDF 1.2 reifier
+	RDFXMLReifiers bool
+
+	// Parallelism is the number of parser workers for line-based formats (0 or 1 = sequential)
+	Parallelism int
+
+	// Blank node relabeling
+	BlankNodePrefix string         // Prefix prepended to every parsed blank node label
+	BlankNodeScope  BlankNodeScope // Scope of blank node labels (default: document)
+
+	// InternTerms is the capacity of the term interning table (0 = disabled)
+	InternTerms int
+
+	// Arena reuses line buffers in N-Triples and N-Quads readers; statements are valid until the next call
+	Arena bool
+
+	// WriteBufferSize is the output buffer size of writers in bytes (0 = default)
+	WriteBufferSize int
+
+	// Compression
+	Decompress bool        // Detect and decompress gzip, zstd and bzip2 input
+	Compress   Compression // Compression of writer output (empty = none)
+
+	// Error recovery for line- and statement-based formats
+	ContinueOnError bool // Skip statements with syntax errors instead of failing
+	MaxErrors       int  // Number of syntax errors to skip before failing (0 = unlimited)
+
+	// Warnings receives non-fatal data quality diagnostics (nil = disabled)
+	Warnings func(Warning)
+
+	// OnPrefix receives the prefix declarations of Turtle and TriG input (nil = disabled)
+	OnPrefix func(prefix, iri string)
+
+	// OnBase receives the base declarations of Turtle and TriG input (nil = disabled)
+	OnBase func(iri string)
+
+	// Progress reporting of readers (nil = disabled)
+	Progress         func(ProgressInfo)
+	ProgressInterval time.Duration // Interval between Progress calls (0 = DefaultProgressInterval)
+
+	// Tracer records spans of Parse, readers and writers (nil = disabled)
+	Tracer Tracer
+
+	// JSONLDUseRdfType writes rdf:type as a regular property instead of @type
+	JSONLDUseRdfType bool
+	// JSONLDBlankNodeIDs selects the blank node identifiers of JSON-LD output
+	JSONLDBlankNodeIDs JSONLDBlankNodeIDs
+
+	// Base is declared by Turtle, TriG and RDF/XML writers, which write IRIs under it as relative references
+	Base string
+
+	// JSONLDCompactContext compacts JSON-LD output against a context (nil = flat output)
+	JSONLDCompactContext interface{}
+
+	// JSONLDFlatten writes JSON-LD output in flattened form
+	JSONLDFlatten bool
+
+	// JSONLDContext streams JSON-LD output compacted with a context (nil = flat output)
+	JSONLDContext interface{}
+
+	// JSONLDStreaming writes JSON-LD output in the streaming document form
+	JSONLDStreaming bool
+
+	// ReifyTripleTerms writes triple terms as rdf:Statement reifications
+	ReifyTripleTerms bool
+
+	// AnnotationSyntax writes rdf:reifies statements as Turtle/TriG annotations
+	AnnotationSyntax bool
+
+	// RDFXMLPretty writes RDF/XML as indented, typed node elements grouped by subject
+	RDFXMLPretty bool
+
+	// PreserveContainers writes rdf:Bag, rdf:Seq and rdf:Alt as containers in Turtle and RDF/XML
+	PreserveContainers bool
+
+	// Prefixes are declared by Turtle, TriG and RDF/XML writers and abbreviate IRIs in their output
+	Prefixes map[string]string
+
+	// SPARQLDirectives writes Turtle/TriG prefixes as PREFIX instead of @prefix
+	SPARQLDirectives bool
+
+	// CanonicalNTriples writes N-Triples and N-Quads in their canonical form
+	CanonicalNTriples bool
+
+	// GraphFilter drops written statements whose graph name it rejects (nil = keep all)
+	GraphFilter func(Term) bool
+
+	// GraphRename replaces the graph names of written statements (nil = keep them)
+	GraphRename func(Term) Term
+
+	// GeneralizedRDF reads and writes generalized RDF in N-Triples, N-Quads and JSON-LD
+	GeneralizedRDF bool
+
+	// ValidateOnWrite makes writers refuse statements they would write as invalid output
+	ValidateOnWrite bool
+
+	// Deterministic writes statements in canonical order with RDFC-1.0 blank node labels
+	Deterministic bool
+
+	// TriGGraphBlocks writes TriG statements grouped into one block per graph
+	TriGGraphBlocks bool
+
+	// SortGraphs orders TriG graph blocks by graph name
+	SortGraphs bool
+
+	// ResumeFrom continues parsing from an exported reader state (nil = start of input)
+	ResumeFrom *DecoderState
+
+	// events receives the syntactic events of Turtle and TriG input for NewEventReader
+	events eventSink
 }
 
 // NewReader creates a reader for the specified format.
@@ -57,7 +195,29 @@ func NewReader(r io.Reader, format Format, opts ...Option) (Reader, error) {
 	for _, opt := range opts {
 		opt(&options)
 	}
+	_, span := startSpan(options, "rdf.Reader", format)
+	metrics := newStreamMetrics()
+	reader, err := newReader(newCountingReader(r, metrics), format, options, metrics, span)
+	if err != nil {
+		metrics.publishBytes()
+		span.end(Metrics{BytesRead: metrics.bytes.Load()}, err)
+		return nil, err
+	}
+	return reader, nil
+}
+
+func newReader(r io.Reader, format Format, options Options, metrics *streamMetrics, span *streamSpan) (Reader, error) {
+	if options.Decompress {
+		decompressed, err := decompressReader(r)
+		if err != nil {
+			return nil, err
+		}
+		r = decompressed
+	}
 
+	if format == FormatAuto && options.ResumeFrom != nil {
+		format = options.ResumeFrom.Format
+	}
 	// Auto-detect format if needed
 	if format == FormatAuto {
 		detected, reader, ok := detectFormat(r)
@@ -66,11 +226,58 @@ func NewReader(r io.Reader, format Format, opts ...Option) (Reader, error) {
 		}
 		format = detected
 		r = reader // Use reader that includes buffered bytes
+		span.setFormat(format)
+	}
+
+	var stopBudget context.CancelFunc
+	if options.MaxWallTime > 0 {
+		options.Context, stopBudget = withTimeBudget(options.Context, options.MaxWallTime)
+	}
+	reader, err := newDecoder(r, format, options)
+	if err != nil {
+		if stopBudget != nil {
+			stopBudget()
+		}
+		return nil, err
+	}
+	adapter := reader.(*quadReaderAdapter)
+	adapter.metrics = metrics
+	adapter.span = span
+	adapter.stopBudget = stopBudget
+	if options.Progress != nil {
+		interval := options.ProgressInterval
+		if interval <= 0 {
+			interval = DefaultProgressInterval
+		}
+		adapter.progress = &progress{fn: options.Progress, interval: interval}
+	}
+	collector := reader.(ErrorCollector)
+	exporter := reader.(StateExporter)
+	reader = newBlankNodeScopeReader(reader, options.BlankNodePrefix, options.BlankNodeScope)
+	reader = newInternReader(reader, options.InternTerms)
+	if _, ok := reader.(ErrorCollector); !ok {
+		reader = &errorCollectorReader{Reader: reader, collector: collector, exporter: exporter, metrics: adapter}
 	}
+	return reader, nil
+}
 
-	return newDecoder(r, format, options)
+// errorCollectorReader exposes the skipped errors, the state and the
+// metrics of a wrapped decoder.
+type errorCollectorReader struct {
+	Reader
+	collector ErrorCollector
+	exporter  StateExporter
+	metrics   MetricsReporter
 }
 
+func (r *errorCollectorReader) Metrics() Metrics { return r.metrics.Metrics() }
+
+func (r *errorCollectorReader) Errors() []error { return r.collector.Errors() }
+
+func (r *errorCollectorReader) State() (DecoderState, error) { return r.exporter.State() }
+
+func (r *errorCollectorReader) NextInto(s *Statement) error { return nextInto(r, s) }
+
 // Parse parses RDF from the reader and streams statements to the handler.
 // If format is FormatAuto (empty string), the format is automatically detected.
 // If ctx is nil, context.Background() is used as the default.
@@ -84,13 +291,22 @@ func Parse(ctx context.Context, r io.Reader, format Format, handler Handler, opt
 	for _, opt := range opts {
 		opt(&options)
 	}
+	ctx, span := startSpan(options, "rdf.Parse", format)
 
-	reader, err := NewReader(r, format, opts...)
+	// The reader gets the context of the span to be its child.
+	reader, err := NewReader(r, format, append(opts[:len(opts):len(opts)], OptContext(ctx))...)
 	if err != nil {
+		span.end(Metrics{}, err)
 		return err
 	}
 	defer reader.Close()
+	err = parseAll(ctx, reader, handler)
+	span.end(reader.(MetricsReporter).Metrics(), err)
+	return err
+}
 
+// parseAll passes the statements of reader to handler.
+func parseAll(ctx context.Context, reader Reader, handler Handler) error {
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
@@ -110,6 +326,53 @@ func Parse(ctx context.Context, r io.Reader, format Format, handler Handler, opt
 	}
 }
 
+// ParseBatch parses RDF from the reader and passes statements to handler in
+// batches of up to batchSize (a batchSize below 1 uses 1). The final batch may
+// be shorter. The slice is reused between calls, so handler must copy any
+// statements it wants to keep after returning.
+// If ctx is nil, context.Background() is used as the default.
+func ParseBatch(ctx context.Context, r io.Reader, format Format, batchSize int, handler func([]Statement) error, opts ...Option) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	opts = append([]Option{OptContext(ctx)}, opts...)
+	reader, err := NewReader(r, format, opts...)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	batch := make([]Statement, 0, batchSize)
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			if len(batch) > 0 {
+				return handler(batch)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, stmt)
+		if len(batch) == batchSize {
+			if err := handler(batch); err != nil {
+				return err
+			}
+			clear(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
 // NewWriter creates a writer for the specified format.
 func NewWriter(w io.Writer, format Format, opts ...Option) (Writer, error) {
 	options := defaultOptions()
@@ -117,7 +380,14 @@ func NewWriter(w io.Writer, format Format, opts ...Option) (Writer, error) {
 		opt(&options)
 	}
 
-	return newEncoder(w, format, options)
+	_, span := startSpan(options, "rdf.Writer", format)
+	writer, err := newEncoder(w, format, options)
+	if err != nil {
+		span.end(Metrics{}, err)
+		return nil, err
+	}
+	writer.(*quadWriterAdapter).span = span
+	return writer, nil
 }
 
 // Option helpers
@@ -165,6 +435,58 @@ func OptSafeLimits() Option {
 		opts.MaxStatementBytes = safe.MaxStatementBytes
 		opts.MaxDepth = safe.MaxDepth
 		opts.MaxTriples = safe.MaxTriples
+		opts.MaxLiteralLength = safe.MaxLiteralLength
+		opts.MaxIRILength = safe.MaxIRILength
+		opts.MaxBlankNodes = safe.MaxBlankNodes
+		opts.MaxPrefixCount = safe.MaxPrefixCount
+		opts.DisallowDTD = safe.DisallowDTD
+		opts.MaxEntityDepth = safe.MaxEntityDepth
+		opts.MaxEntityExpansion = safe.MaxEntityExpansion
+		opts.MaxFetchBytes = safe.MaxFetchBytes
+	}
+}
+
+// OptDisallowDTD makes the RDF/XML reader reject documents containing a
+// DOCTYPE declaration with ErrDTDNotAllowed. OptSafeLimits enables it.
+func OptDisallowDTD() Option {
+	return func(opts *Options) {
+		opts.DisallowDTD = true
+	}
+}
+
+// OptMaxEntityDepth limits how deeply entities declared in the internal
+// DTD subset of an RDF/XML document may refer to other entities, which
+// stops "billion laughs" documents before anything is expanded.
+func OptMaxEntityDepth(maxDepth int) Option {
+	return func(opts *Options) {
+		opts.MaxEntityDepth = maxDepth
+	}
+}
+
+// OptMaxEntityExpansion limits the bytes of text produced by expanding DTD
+// entities in an RDF/XML document, both for the replacement text of one
+// entity and for all references in the document together. Exceeding a
+// limit fails with an error matching ErrEntityLimitExceeded.
+func OptMaxEntityExpansion(maxBytes int) Option {
+	return func(opts *Options) {
+		opts.MaxEntityExpansion = maxBytes
+	}
+}
+
+// OptHTTPClient sets the client Fetch uses for requests, for example to
+// configure timeouts, proxies or authentication.
+func OptHTTPClient(client *http.Client) Option {
+	return func(opts *Options) {
+		opts.HTTPClient = client
+	}
+}
+
+// OptMaxFetchBytes limits the size of response bodies read by Fetch, after
+// decompression; reading past the limit fails with ErrResponseTooLarge.
+// OptSafeLimits sets it to 64MB.
+func OptMaxFetchBytes(maxBytes int64) Option {
+	return func(opts *Options) {
+		opts.MaxFetchBytes = maxBytes
 	}
 }
 
@@ -180,6 +502,19 @@ func OptStrictIRIValidation() Option {
 	}
 }
 
+// OptValidateIRIs rejects statements containing an IRI that fails
+// IRI.Validate: relative IRIs and IRIs violating RFC 3987, in subjects,
+// predicates, objects, graph names, literal datatypes and triple terms. It
+// applies to every format after parsing, so it also covers IRIs the parser
+// resolved against a base. The error is a ParseError wrapping an *IRIError
+// (Code reports ErrCodeInvalidIRI); with OptContinueOnError the statement is
+// skipped and the error recorded instead.
+func OptValidateIRIs() Option {
+	return func(opts *Options) {
+		opts.ValidateIRIs = true
+	}
+}
+
 // OptExpandRDFXMLContainers enables RDF/XML container membership expansion.
 // When enabled (default), container elements (rdf:Bag, rdf:Seq, rdf:Alt) automatically
 // generate container membership properties (rdf:_1, rdf:_2, etc.) from rdf:li elements.
@@ -201,6 +536,371 @@ func OptDisableRDFXMLContainerExpansion() Option {
 	}
 }
 
+// OptBaseIRI sets the base IRI of the document being read, against which
+// relative IRIs are resolved: the initial base of Turtle and TriG (until an
+// @base or BASE directive, each of which is resolved against the base in
+// effect and applies to the statements after it), of RDF/XML (until an xml:base attribute, which
+// is resolved against it and applies to its element and descendants) and of
+// JSON-LD. Without it relative IRIs are kept as written. N-Triples and
+// N-Quads, which only allow absolute IRIs, ignore the option.
+func OptBaseIRI(base string) Option {
+	return func(opts *Options) {
+		opts.BaseIRI = base
+	}
+}
+
+// OptRDFXMLReifiers makes the RDF/XML reader read rdf:ID on a property
+// element as the name of an RDF 1.2 reifier of the statement, giving
+// "<#id> rdf:reifies <<( s p o )>>", instead of the rdf:Statement,
+// rdf:subject, rdf:predicate and rdf:object triples RDF/XML 1.1 specifies.
+func OptRDFXMLReifiers() Option {
+	return func(opts *Options) {
+		opts.RDFXMLReifiers = true
+	}
+}
+
+// OptParallelism parses N-Triples and N-Quads input on the given number of
+// worker goroutines. Statements are still returned in input order.
+// Other formats ignore this option. Values below 2 keep sequential parsing.
+func OptParallelism(workers int) Option {
+	return func(opts *Options) {
+		opts.Parallelism = workers
+	}
+}
+
+// OptContinueOnError makes N-Triples, N-Quads, Turtle and TriG readers skip
+// statements with syntax errors and keep parsing from the next statement
+// boundary (the next line for N-Triples and N-Quads, the next '.' or
+// directive for Turtle and TriG). Skipped errors are available from the reader's Errors method
+// (see ErrorCollector). Once more than max errors occur the reader fails with
+// the latest one; max below 1 allows any number. Limit, I/O and cancellation
+// errors are never skipped, and RDF/XML and JSON-LD always stop at the first
+// error.
+func OptContinueOnError(max int) Option {
+	return func(opts *Options) {
+		opts.ContinueOnError = true
+		opts.MaxErrors = max
+	}
+}
+
+// OptWarnings reports non-fatal data quality issues to fn while parsing:
+// IRIs left relative for lack of a base IRI, language tags that are not
+// well-formed BCP 47, literals whose lexical form is invalid for their XSD
+// datatype, datatypes in the XSD namespace that XSD does not define, and
+// prefixes declared twice in Turtle or TriG. fn is called from
+// the goroutine calling Next, before the statement that triggered the
+// warning is returned.
+func OptWarnings(fn func(Warning)) Option {
+	return func(opts *Options) {
+		opts.Warnings = fn
+	}
+}
+
+// OptOnPrefix calls fn with each prefix declaration (@prefix or PREFIX) of
+// Turtle and TriG input, in document order, so that the prefixes can be
+// shown or used to write the statements again, for example with
+// OptPrefixes. prefix is "" for the empty prefix and iri is resolved against
+// the base in effect. A prefix declared twice is reported twice. fn is
+// called from the goroutine calling Next, before the statements following
+// the declaration are returned. Other formats ignore the option.
+func OptOnPrefix(fn func(prefix, iri string)) Option {
+	return func(opts *Options) {
+		opts.OnPrefix = fn
+	}
+}
+
+// OptOnBase calls fn with each base declaration (@base or BASE) of Turtle
+// and TriG input, resolved against the base in effect, as OptOnPrefix does
+// for prefixes. Other formats ignore the option.
+func OptOnBase(fn func(iri string)) Option {
+	return func(opts *Options) {
+		opts.OnBase = fn
+	}
+}
+
+// OptJSONLDUseRdfType makes the JSON-LD writer keep rdf:type as a regular
+// property with {"@id": ...} objects, like the useRdfType flag of the JSON-LD
+// fromRdf algorithm. By default IRI and blank node types are written to
+// @type; literal rdf:type objects are always written as properties.
+func OptJSONLDUseRdfType() Option {
+	return func(opts *Options) {
+		opts.JSONLDUseRdfType = true
+	}
+}
+
+// OptJSONLDBlankNodeIDs selects how the JSON-LD writer names blank nodes:
+// with their own labels (JSONLDBlankNodesKeep, the default), relabeled
+// "_:b0", "_:b1", ... in order of first appearance (JSONLDBlankNodesCounter)
+// or relabeled with random UUIDs (JSONLDBlankNodesUUID).
+func OptJSONLDBlankNodeIDs(ids JSONLDBlankNodeIDs) Option {
+	return func(opts *Options) {
+		opts.JSONLDBlankNodeIDs = ids
+	}
+}
+
+// OptBase makes the Turtle, TriG and RDF/XML writers declare base with
+// @base or xml:base and write the IRIs in its directory as relative
+// references, "<item1>" instead of "<http://example.org/data/item1>".
+// IRIs that a prefix abbreviates keep their prefixed name. Other formats
+// ignore the option.
+func OptBase(base string) Option {
+	return func(opts *Options) {
+		opts.Base = base
+	}
+}
+
+// OptJSONLDCompact makes the JSON-LD writer compact its output against
+// context with the JSON-LD compaction algorithm, giving terms, compact IRIs
+// and nested values instead of one flat node object per statement. context
+// is a context value (an object, an IRI or an array of them) or a document
+// with an "@context" entry. Named graphs are kept. The writer holds all
+// statements until Close, which writes the compacted document; Flush writes
+// nothing.
+func OptJSONLDCompact(context interface{}) Option {
+	return func(opts *Options) {
+		opts.JSONLDCompactContext = context
+	}
+}
+
+// OptJSONLDFlatten makes the JSON-LD writer produce the flattened form of
+// the JSON-LD flattening algorithm: one node object per subject, sorted by
+// @id, with nested blank nodes replaced by references and named graphs as
+// node objects with @graph. The output depends only on the statements and
+// their order, so it suits consumers that index nodes by @id. Combined with
+// OptJSONLDCompact the flattened document is compacted against the context;
+// otherwise it is an expanded array. The writer holds all statements until
+// Close.
+func OptJSONLDFlatten() Option {
+	return func(opts *Options) {
+		opts.JSONLDFlatten = true
+	}
+}
+
+// OptJSONLDContext makes the JSON-LD writer stream a document with context
+// in "@context", without holding statements back: consecutive statements
+// about one subject become one node object, consecutive statements in one
+// named graph are grouped into a graph object with "@graph", and IRIs are
+// written as terms, compact IRIs or @vocab-relative names where the context
+// defines them. Only term definitions without type coercion, containers or
+// language are used. Each node object is written on its own line. context
+// is a context object, an array of them or a document with an "@context"
+// entry; remote contexts are written but not used for compaction.
+// OptJSONLDCompact and OptJSONLDFlatten take precedence.
+func OptJSONLDContext(context interface{}) Option {
+	return func(opts *Options) {
+		opts.JSONLDContext = context
+	}
+}
+
+// OptJSONLDStreaming makes the JSON-LD writer produce a streaming JSON-LD
+// document (media type JSONLDStreamingMediaType) in constant memory: an
+// expanded array with one node object per run of statements about a
+// subject, "@id" first and "@type" second, and one graph object per run of
+// statements in a named graph. Only the node object being built is held, so
+// input sorted by graph and subject gives one node object per subject. With
+// OptJSONLDContext the document is compacted with the context instead.
+func OptJSONLDStreaming() Option {
+	return func(opts *Options) {
+		opts.JSONLDStreaming = true
+	}
+}
+
+// OptReifyTripleTerms makes writers replace triple terms in subjects and
+// objects with blank nodes described by the RDF 1.1 reification vocabulary
+// (rdf:Statement, rdf:subject, rdf:predicate, rdf:object), for consumers that
+// cannot read RDF 1.2 triple terms. It applies to every format. Without it,
+// Turtle and TriG write triple terms as "<< s p o >>" subjects and
+// "<<( s p o )>>" objects; N-Triples and N-Quads write "<<( s p o )>>"
+// objects and reject triple term subjects, which RDF 1.2 does not allow.
+// Statements "r rdf:reifies <<( s p o )>>" become descriptions of r, as with
+// the ExpandTripleTerms transform.
+func OptReifyTripleTerms() Option {
+	return func(opts *Options) {
+		opts.ReifyTripleTerms = true
+	}
+}
+
+// OptAnnotationSyntax makes the Turtle and TriG writers collapse rdf:reifies
+// statements into the annotation syntax of the triples they reify, as in
+// "s p o ~ r {| q v |}", which reads back as the same statements. The
+// statements with the reifier as subject move into the annotation block, and
+// blank node reifiers used nowhere else are left unnamed. Only statements
+// written between two calls to Flush are combined, so the writer holds them
+// in memory until Flush or Close. Other formats ignore the option.
+func OptAnnotationSyntax() Option {
+	return func(opts *Options) {
+		opts.AnnotationSyntax = true
+	}
+}
+
+// OptPreserveContainers makes the Turtle and RDF/XML writers write each
+// rdf:Bag, rdf:Seq and rdf:Alt as a container rather than as unrelated
+// statements: the Turtle writer groups the statements about it into one
+// statement, with its type first and its rdf:_n members in index order
+// (rdf:_2 before rdf:_10), and the RDF/XML writer writes it as a node element
+// of its type, as with OptRDFXMLPretty. Use ReadContainer to get the members
+// back. Only statements written between two calls to Flush are grouped, so
+// the Turtle writer holds them in memory until Flush or Close. Other formats
+// ignore the option.
+func OptPreserveContainers() Option {
+	return func(opts *Options) {
+		opts.PreserveContainers = true
+	}
+}
+
+// OptPrefixes makes the Turtle, TriG and RDF/XML writers declare prefixes,
+// a map from prefix names such as "ex" to namespace IRIs, at the start of
+// their output and write IRIs in those namespaces as prefixed names or
+// QNames. Other formats ignore the option.
+func OptPrefixes(prefixes map[string]string) Option {
+	return func(opts *Options) {
+		opts.Prefixes = maps.Clone(prefixes)
+	}
+}
+
+// OptSPARQLDirectives makes the Turtle and TriG writers declare prefixes in
+// the SPARQL style, "PREFIX ex: <http://example.org/>" without a final '.',
+// instead of "@prefix ex: <http://example.org/> .", so that the header can
+// be pasted into a SPARQL query. Both forms read back the same. Other
+// formats ignore the option.
+func OptSPARQLDirectives() Option {
+	return func(opts *Options) {
+		opts.SPARQLDirectives = true
+	}
+}
+
+// OptGraphFilter makes writers drop the statements whose graph name keep
+// rejects, as the FilterGraph transform does for readers, to export a subset
+// of the named graphs of a dataset. keep is called with nil for the default
+// graph, and with the graph name before OptGraphRename replaces it. It
+// applies to every format, triple formats seeing graph names before they
+// drop them.
+func OptGraphFilter(keep func(Term) bool) Option {
+	return func(opts *Options) {
+		opts.GraphFilter = keep
+	}
+}
+
+// OptGraphRename makes writers replace the graph name of every statement they
+// keep with the result of rename, as the RenameGraph transform does for
+// readers, to retarget graph IRIs on export. rename is called with nil for
+// the default graph and may return nil to move statements into it.
+func OptGraphRename(rename func(Term) Term) Option {
+	return func(opts *Options) {
+		opts.GraphRename = rename
+	}
+}
+
+// OptGeneralizedRDF permits generalized RDF, whose statements may have
+// literal subjects and graph names and blank node predicates, as produced by
+// some reasoners and by the JSON-LD toRdf algorithm with
+// produceGeneralizedRdf. Blank node predicates are IRIs whose value starts
+// with "_:", as with JSONLDOptions.ProduceGeneralizedRdf. The N-Triples and
+// N-Quads readers accept such statements, the JSON-LD reader keeps
+// properties named by blank nodes, and the N-Triples and N-Quads writers
+// write "_:" predicates as blank nodes and, with OptValidateOnWrite, accept
+// generalized statements. Without the option these readers reject
+// generalized statements, and other formats ignore it.
+func OptGeneralizedRDF() Option {
+	return func(opts *Options) {
+		opts.GeneralizedRDF = true
+	}
+}
+
+// OptValidateOnWrite makes writers check every statement before writing it
+// and fail with a *StatementError, leaving the output unchanged, instead of
+// writing output that parsers reject: statements must have a subject,
+// predicate and object, subjects must not be literals, graph names must be
+// IRIs or blank nodes, IRIs including datatypes must be absolute RFC 3987
+// IRIs, blank nodes must have labels, literals must be valid UTF-8 with
+// well-formed language tags and base directions, and only language-tagged
+// literals may have the datatypes rdf:langString and rdf:dirLangString.
+// Triple terms are checked likewise. Graph names are checked after
+// OptGraphRename.
+func OptValidateOnWrite() Option {
+	return func(opts *Options) {
+		opts.ValidateOnWrite = true
+	}
+}
+
+// OptDeterministic makes writers of every format hold statements until Close
+// and then write them with their blank nodes relabeled _:c14n0, _:c14n1, ...
+// by RDFC-1.0, without duplicates and in the order of their canonical N-Quads
+// lines, as Canonicalize returns them. Isomorphic datasets thus give the same
+// bytes whatever the order and blank node labels of the statements written,
+// for reproducible builds of published datasets. Statements are checked at
+// Close, which fails with ErrCanonicalizationLimit for datasets whose blank
+// nodes are too symmetric. Combine with OptCanonicalNTriples for byte-stable
+// N-Triples and N-Quads.
+func OptDeterministic() Option {
+	return func(opts *Options) {
+		opts.Deterministic = true
+	}
+}
+
+// OptCanonicalNTriples makes the N-Triples and N-Quads writers write the
+// canonical form of RDF 1.2 N-Triples and N-Quads, so that equal statements
+// give the same bytes whatever wrote them: one space between terms and before
+// the final '.', literals escaped with \b, \t, \n, \f, \r, \" and \\, other
+// control characters as \u escapes with upper-case hex digits and every
+// other character as UTF-8, language tags in lower case and xsd:string
+// datatypes left out. Other formats ignore the option.
+func OptCanonicalNTriples() Option {
+	return func(opts *Options) {
+		opts.CanonicalNTriples = true
+	}
+}
+
+// OptTriGGraphBlocks makes the TriG writer group statements by graph: the
+// statements of the default graph come first, then those of each named graph
+// in a "GRAPH g { ... }" block, with the statements about a subject written
+// as one statement with predicate-object and object lists. Only statements
+// written between two calls to Flush are grouped, so the writer holds them in
+// memory until Flush or Close. Other formats ignore the option.
+func OptTriGGraphBlocks() Option {
+	return func(opts *Options) {
+		opts.TriGGraphBlocks = true
+	}
+}
+
+// OptSortGraphs implies OptTriGGraphBlocks and writes the named graph blocks
+// in order of their graph names, by TermCompare, instead of the order in
+// which their first statements were written, for exports that must not
+// depend on the order of their input. Other formats ignore the option.
+func OptSortGraphs() Option {
+	return func(opts *Options) {
+		opts.TriGGraphBlocks = true
+		opts.SortGraphs = true
+	}
+}
+
+// OptRDFXMLPretty makes the RDF/XML writer produce the abbreviated,
+// indented form written by tools such as Jena and Protégé instead of one
+// rdf:Description per statement: all statements about a subject are grouped
+// in one node element, named after the subject's first rdf:type when it can
+// be written as a QName, with IRI and blank node objects as rdf:resource and
+// rdf:nodeID attributes. Blank node lists of IRIs and blank nodes that are
+// referenced once are written as rdf:parseType="Collection" elements, and
+// rdf:Bag, rdf:Seq and rdf:Alt containers as node elements of that type
+// with their rdf:_n members in index order, so the output reads back the
+// same with and without OptDisableRDFXMLContainerExpansion. Every
+// namespace is declared on the root element, using well-known prefixes such
+// as rdfs, owl and xsd where possible. The writer holds all statements until Close, which writes the document; Flush
+// writes nothing. Other formats ignore the option.
+func OptRDFXMLPretty() Option {
+	return func(opts *Options) {
+		opts.RDFXMLPretty = true
+	}
+}
+
+// OptWriteBufferSize sets the size in bytes of the output buffer used by writers.
+// Larger buffers mean fewer writes to the underlying io.Writer; the default is 4096.
+func OptWriteBufferSize(size int) Option {
+	return func(opts *Options) {
+		opts.WriteBufferSize = size
+	}
+}
+
 // Internal helpers
 
 func defaultOptions() Options {
@@ -209,6 +909,8 @@ func defaultOptions() Options {
 		MaxStatementBytes:      DefaultMaxStatementBytes,
 		MaxDepth:               DefaultMaxDepth,
 		MaxTriples:             DefaultMaxTriples,
+		MaxEntityDepth:         DefaultMaxEntityDepth,
+		MaxEntityExpansion:     DefaultMaxEntityExpansion,
 		ExpandRDFXMLContainers: true, // Default: enable container expansion
 	}
 }
@@ -216,43 +918,34 @@ func defaultOptions() Options {
 func safeOptions() Options {
 	safe := safeDecodeOptions()
 	return Options{
-		MaxLineBytes:      safe.MaxLineBytes,
-		MaxStatementBytes: safe.MaxStatementBytes,
-		MaxDepth:          safe.MaxDepth,
-		MaxTriples:        safe.MaxTriples,
+		MaxLineBytes:       safe.MaxLineBytes,
+		MaxStatementBytes:  safe.MaxStatementBytes,
+		MaxDepth:           safe.MaxDepth,
+		MaxTriples:         safe.MaxTriples,
+		MaxLiteralLength:   safe.MaxLiteralLength,
+		MaxIRILength:       safe.MaxIRILength,
+		MaxBlankNodes:      safe.MaxBlankNodes,
+		MaxPrefixCount:     safe.MaxPrefixCount,
+		DisallowDTD:        safe.DisallowDTD,
+		MaxEntityDepth:     safe.MaxEntityDepth,
+		MaxEntityExpansion: safe.MaxEntityExpansion,
+		MaxFetchBytes:      64 << 20, // 64MB response bodies
 	}
 }
 
-// detectFormat attempts to detect the format from the reader.
-// It reads a sample and returns both the detected format and a reader that includes
-// the buffered bytes so the decoder can read from the beginning.
+// detectFormat detects the format of r with DetectFormat's defaults. It
+// returns the detected format and a reader that includes the buffered bytes
+// so the decoder can read from the beginning.
 func detectFormat(r io.Reader) (Format, io.Reader, bool) {
-	const formatDetectionBufferSize = 512
-	buf := make([]byte, formatDetectionBufferSize)
-	n, err := r.Read(buf)
-	if err != nil && err != io.EOF {
-		return FormatAuto, r, false
-	}
-
-	sample := buf[:n]
-
-	// Try quad formats first
-	if quadFormat, ok := detectQuadFormat(bytes.NewReader(sample)); ok {
-		// Combine buffered bytes with remaining reader
-		return quadFormat, io.MultiReader(bytes.NewReader(sample), r), true
-	}
-
-	// Try triple formats
-	if tripleFormat, ok := detectFormatFromSample(bytes.NewReader(sample)); ok {
-		// Combine buffered bytes with remaining reader
-		return tripleFormat, io.MultiReader(bytes.NewReader(sample), r), true
-	}
-
-	return FormatAuto, io.MultiReader(bytes.NewReader(sample), r), false
+	format, reader, _, err := DetectFormat(r)
+	return format, reader, err == nil
 }
 
 // newDecoder creates a reader for the specified format.
 func newDecoder(r io.Reader, format Format, opts Options) (Reader, error) {
+	if limits, ok := opts.FormatLimits[format]; ok {
+		limits.apply(&opts)
+	}
 	// Convert Options to decodeOptions for internal use
 	decodeOpts := decodeOptions{
 		Context:                    opts.Context,
@@ -260,10 +953,40 @@ func newDecoder(r io.Reader, format Format, opts Options) (Reader, error) {
 		MaxStatementBytes:          opts.MaxStatementBytes,
 		MaxDepth:                   opts.MaxDepth,
 		MaxTriples:                 opts.MaxTriples,
+		MaxLiteralLength:           opts.MaxLiteralLength,
+		MaxIRILength:               opts.MaxIRILength,
+		MaxBlankNodes:              opts.MaxBlankNodes,
+		MaxPrefixCount:             opts.MaxPrefixCount,
+		MaxBufferedBytes:           opts.MaxBufferedBytes,
+		DisallowDTD:                opts.DisallowDTD,
+		MaxEntityDepth:             opts.MaxEntityDepth,
+		MaxEntityExpansion:         opts.MaxEntityExpansion,
 		AllowQuotedTripleStatement: opts.AllowQuotedTripleStatement,
 		DebugStatements:            opts.DebugStatements,
 		StrictIRIValidation:        opts.StrictIRIValidation,
 		ExpandRDFXMLContainers:     opts.ExpandRDFXMLContainers,
+		BaseIRI:                    opts.BaseIRI,
+		RDFXMLReifiers:             opts.RDFXMLReifiers,
+		Parallelism:                opts.Parallelism,
+		arena:                      opts.Arena,
+	}
+	if opts.ContinueOnError {
+		decodeOpts.errors = &recoveryErrors{max: opts.MaxErrors}
+	}
+	decodeOpts.warnings = opts.Warnings
+	decodeOpts.onPrefix = opts.OnPrefix
+	decodeOpts.events = opts.events
+	decodeOpts.onBase = opts.OnBase
+	decodeOpts.validateIRIs = opts.ValidateIRIs
+	decodeOpts.generalized = opts.GeneralizedRDF
+	if state := opts.ResumeFrom; state != nil {
+		switch {
+		case format == FormatRDFXML || format == FormatJSONLD || lookupCodec(format) != nil:
+			return nil, fmt.Errorf("rdf: %s readers cannot resume from a state: %w", format, ErrUnsupportedFormat)
+		case state.Format != format:
+			return nil, fmt.Errorf("rdf: cannot resume %s state with a %s reader", state.Format, format)
+		}
+		decodeOpts.resume = state
 	}
 
 	switch format {
@@ -272,111 +995,280 @@ func newDecoder(r io.Reader, format Format, opts Options) (Reader, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &quadReaderAdapter{dec: dec, isTriple: true}, nil
+		return newQuadReaderAdapter(dec, true, format, decodeOpts), nil
 	case FormatNTriples:
+		if decodeOpts.Parallelism > 1 {
+			return newQuadReaderAdapter(newNTParallelDecoder(r, "ntriples", decodeOpts), false, format, decodeOpts), nil
+		}
 		dec, err := newTripleDecoderWithOptions(r, "ntriples", decodeOpts)
 		if err != nil {
 			return nil, err
 		}
-		return &quadReaderAdapter{dec: dec, isTriple: true}, nil
+		return newQuadReaderAdapter(dec, true, format, decodeOpts), nil
 	case FormatRDFXML:
 		dec, err := newTripleDecoderWithOptions(r, "rdfxml", decodeOpts)
 		if err != nil {
 			return nil, err
 		}
-		return &quadReaderAdapter{dec: dec, isTriple: true}, nil
+		return newQuadReaderAdapter(dec, true, format, decodeOpts), nil
 	case FormatJSONLD:
 		dec, err := newTripleDecoderWithOptions(r, "jsonld", decodeOpts)
 		if err != nil {
 			return nil, err
 		}
-		return &quadReaderAdapter{dec: dec, isTriple: true}, nil
+		return newQuadReaderAdapter(dec, true, format, decodeOpts), nil
 	case FormatTriG:
 		dec, err := newQuadDecoderWithOptions(r, "trig", decodeOpts)
 		if err != nil {
 			return nil, err
 		}
-		return &quadReaderAdapter{dec: dec, isTriple: false}, nil
+		return newQuadReaderAdapter(dec, false, format, decodeOpts), nil
 	case FormatNQuads:
+		if decodeOpts.Parallelism > 1 {
+			return newQuadReaderAdapter(newNTParallelDecoder(r, "nquads", decodeOpts), false, format, decodeOpts), nil
+		}
 		dec, err := newQuadDecoderWithOptions(r, "nquads", decodeOpts)
 		if err != nil {
 			return nil, err
 		}
-		return &quadReaderAdapter{dec: dec, isTriple: false}, nil
+		return newQuadReaderAdapter(dec, false, format, decodeOpts), nil
 	default:
-		return nil, ErrUnsupportedFormat
+		codec := lookupCodec(format)
+		if codec == nil || codec.NewReader == nil {
+			return nil, ErrUnsupportedFormat
+		}
+		dec, err := codec.NewReader(r, opts)
+		if err != nil {
+			return nil, err
+		}
+		return newQuadReaderAdapter(codecDecoder{r: dec}, false, format, decodeOpts), nil
 	}
 }
 
 // newEncoder creates a writer for the specified format.
 func newEncoder(w io.Writer, format Format, opts Options) (Writer, error) {
-	switch format {
-	case FormatTurtle:
-		enc, err := newTripleEncoder(w, "turtle")
-		if err != nil {
-			return nil, err
-		}
-		return &quadWriterAdapter{enc: enc, isTriple: true}, nil
-	case FormatNTriples:
-		enc, err := newTripleEncoder(w, "ntriples")
-		if err != nil {
-			return nil, err
-		}
-		return &quadWriterAdapter{enc: enc, isTriple: true}, nil
-	case FormatRDFXML:
-		enc, err := newTripleEncoder(w, "rdfxml")
+	metrics := newStreamMetrics()
+	counter := &countingWriter{w: w, m: metrics}
+	var out io.Writer = counter
+	adapter := &quadWriterAdapter{metrics: metrics}
+	if opts.Compress != CompressionNone {
+		compressor, err := newCompressWriter(counter, opts.Compress)
 		if err != nil {
 			return nil, err
 		}
-		return &quadWriterAdapter{enc: enc, isTriple: true}, nil
+		out, adapter.compressor = compressor, compressor
+	}
+	if opts.WriteBufferSize > 0 {
+		out = bufio.NewWriterSize(out, opts.WriteBufferSize)
+	}
+	switch format {
 	case FormatJSONLD:
-		enc, err := newTripleEncoder(w, "jsonld")
-		if err != nil {
-			return nil, err
+		jsonldOpts := JSONLDOptions{UseRdfType: opts.JSONLDUseRdfType, BlankNodeIDs: opts.JSONLDBlankNodeIDs}
+		if opts.JSONLDCompactContext != nil || opts.JSONLDFlatten {
+			adapter.enc = newJSONLDdocumentEncoder(out, opts.JSONLDCompactContext, opts.JSONLDFlatten, jsonldOpts)
+			break
 		}
-		return &quadWriterAdapter{enc: enc, isTriple: true}, nil
-	case FormatTriG:
-		enc, err := newQuadEncoder(w, "trig")
-		if err != nil {
-			return nil, err
+		if opts.JSONLDContext != nil || opts.JSONLDStreaming {
+			enc, err := newJSONLDstreamEncoder(out, opts.JSONLDContext, jsonldOpts)
+			if err != nil {
+				return nil, err
+			}
+			adapter.enc = enc
+			break
 		}
-		return &quadWriterAdapter{enc: enc, isTriple: false}, nil
+		adapter.enc, adapter.isTriple = newJSONLDtripleEncoderWithOptions(out, jsonldOpts), true
+	case FormatTurtle:
+		adapter.enc, adapter.isTriple = newTurtletripleEncoderWithOptions(out, TurtleEncodeOptions{
+			Prefixes:           opts.Prefixes,
+			BaseIRI:            opts.Base,
+			AnnotationSyntax:   opts.AnnotationSyntax,
+			PreserveContainers: opts.PreserveContainers,
+			SPARQLDirectives:   opts.SPARQLDirectives,
+		}), true
+	case FormatTriG:
+		adapter.enc = newTriGquadEncoderWithOptions(out, TriGEncodeOptions{
+			Prefixes:         opts.Prefixes,
+			BaseIRI:          opts.Base,
+			AnnotationSyntax: opts.AnnotationSyntax,
+			SPARQLDirectives: opts.SPARQLDirectives,
+			GraphBlocks:      opts.TriGGraphBlocks,
+			SortGraphs:       opts.SortGraphs,
+		})
+	case FormatRDFXML:
+		adapter.enc, adapter.isTriple = newRDFXMLtripleEncoderWithOptions(out, RDFXMLEncodeOptions{Pretty: opts.RDFXMLPretty || opts.PreserveContainers, Prefixes: opts.Prefixes, BaseIRI: opts.Base}), true
+	case FormatNTriples:
+		adapter.enc, adapter.isTriple = newNTriplestripleEncoderWithOptions(out, NTriplesEncodeOptions{Canonical: opts.CanonicalNTriples, Generalized: opts.GeneralizedRDF}), true
 	case FormatNQuads:
-		enc, err := newQuadEncoder(w, "nquads")
+		adapter.enc = newNQuadsquadEncoderWithOptions(out, NTriplesEncodeOptions{Canonical: opts.CanonicalNTriples, Generalized: opts.GeneralizedRDF})
+	default:
+		codec := lookupCodec(format)
+		if codec == nil || codec.NewWriter == nil {
+			return nil, ErrUnsupportedFormat
+		}
+		enc, err := codec.NewWriter(out, opts)
 		if err != nil {
 			return nil, err
 		}
-		return &quadWriterAdapter{enc: enc, isTriple: false}, nil
-	default:
-		return nil, ErrUnsupportedFormat
+		buf, _ := out.(*bufio.Writer)
+		adapter.enc = codecEncoder{w: enc, buf: buf}
+	}
+	if opts.ReifyTripleTerms {
+		adapter.reifier = newTripleTermReifier()
 	}
+	adapter.graphFilter, adapter.graphRename = opts.GraphFilter, opts.GraphRename
+	adapter.validate = opts.ValidateOnWrite
+	adapter.generalized = opts.GeneralizedRDF && (format == FormatNTriples || format == FormatNQuads)
+	adapter.deterministic = opts.Deterministic
+	return adapter, nil
 }
 
 // quadReaderAdapter adapts TripleDecoder/QuadDecoder to unified Reader interface.
 type quadReaderAdapter struct {
 	dec      interface{}
 	isTriple bool
+	triples  tripleDecoder // dec when isTriple, asserted once for Next
+	quads    quadDecoder   // dec otherwise
+	format   Format
+	ctx      context.Context
+	errors   *recoveryErrors
+	warnings func(Warning)
+
+	validateIRIs bool
+	limits       *statementLimits // nil without statement limits
+	count        int64            // Statements returned so far
+	metrics      *streamMetrics
+	progress     *progress          // nil without OptProgress
+	span         *streamSpan        // nil without OptTracer
+	stopBudget   context.CancelFunc // Stops the OptBudget timer, nil without it
+}
+
+func newQuadReaderAdapter(dec interface{}, isTriple bool, format Format, opts decodeOptions) *quadReaderAdapter {
+	a := &quadReaderAdapter{
+		dec:          dec,
+		isTriple:     isTriple,
+		format:       format,
+		ctx:          opts.Context,
+		errors:       opts.errors,
+		warnings:     opts.warnings,
+		validateIRIs: opts.validateIRIs,
+		limits:       newStatementLimits(normalizeDecodeOptions(opts)),
+		metrics:      newStreamMetrics(),
+	}
+	if isTriple {
+		a.triples = dec.(tripleDecoder)
+	} else {
+		a.quads = dec.(quadDecoder)
+	}
+	return a
+}
+
+// Metrics implements MetricsReporter.
+func (a *quadReaderAdapter) Metrics() Metrics {
+	elapsed, done := a.metrics.elapsed()
+	return Metrics{BytesRead: a.metrics.bytes.Load(), Statements: a.metrics.statements.Load(), Elapsed: elapsed, Done: done}
+}
+
+func (a *quadReaderAdapter) Errors() []error { return a.errors.Errors() }
+
+func (a *quadReaderAdapter) NextInto(s *Statement) error { return nextInto(a, s) }
+
+func (a *quadReaderAdapter) State() (DecoderState, error) {
+	exporter, ok := a.dec.(stateExporter)
+	if !ok {
+		return DecoderState{}, fmt.Errorf("rdf: %s readers cannot export their state: %w", a.format, ErrUnsupportedFormat)
+	}
+	state, err := exporter.exportState()
+	if err != nil {
+		return DecoderState{}, err
+	}
+	state.Format = a.format
+	return state, nil
 }
 
 func (a *quadReaderAdapter) Next() (Statement, error) {
-	if a.isTriple {
-		dec := a.dec.(tripleDecoder)
-		triple, err := dec.Next()
+	for {
+		stmt, err := a.next()
+		a.metrics.publishBytes()
+		if err == io.EOF {
+			a.metrics.finish()
+			if a.progress != nil {
+				a.progress.report(a.metrics, true)
+			}
+			a.span.end(a.Metrics(), nil)
+		}
 		if err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, ErrTruncatedInput) {
+				err = &TruncatedInputError{Statements: a.count, Err: err}
+			}
+			if err != io.EOF {
+				a.span.end(a.Metrics(), err)
+			}
 			return Statement{}, err
 		}
-		return Statement{S: triple.S, P: triple.P, O: triple.O, G: nil}, nil
-	} else {
-		dec := a.dec.(quadDecoder)
-		quad, err := dec.Next()
+		if a.validateIRIs {
+			if err := validateStatementIRIs(stmt); err != nil {
+				err = wrapParseErrorWithPosition(string(a.format), "", a.statementLine(), 0, -1, err)
+				if a.errors.recover(err) {
+					continue
+				}
+				a.span.end(a.Metrics(), err)
+				return Statement{}, err
+			}
+		}
+		if a.limits != nil {
+			if err := a.limits.check(&stmt, a.count); err != nil {
+				err = wrapParseErrorWithPosition(string(a.format), "", a.statementLine(), 0, -1, err)
+				a.span.end(a.Metrics(), err)
+				return Statement{}, err
+			}
+		}
+		if a.warnings != nil {
+			checkStatementWarnings(stmt, string(a.format), a.statementLine(), a.warnings)
+		}
+		a.count++
+		a.metrics.statements.Add(1)
+		if a.progress != nil && a.count%progressCheckEvery == 0 {
+			a.progress.report(a.metrics, false)
+		}
+		return stmt, nil
+	}
+}
+
+func (a *quadReaderAdapter) next() (Statement, error) {
+	// Statements queued by the decoder and those of registered codecs are
+	// returned without it checking the context.
+	if err := checkDecodeContext(a.ctx); err != nil {
+		return Statement{}, err
+	}
+	if a.triples != nil {
+		triple, err := a.triples.Next()
 		if err != nil {
 			return Statement{}, err
 		}
-		return quad.ToStatement(), nil
+		return Statement{S: triple.S, P: triple.P, O: triple.O, G: nil}, nil
+	}
+	quad, err := a.quads.Next()
+	if err != nil {
+		return Statement{}, err
 	}
+	return quad.ToStatement(), nil
+}
+
+// statementLine returns the line of the last statement, or 0 if the decoder
+// does not track it.
+func (a *quadReaderAdapter) statementLine() int {
+	if positioner, ok := a.dec.(statementPositioner); ok {
+		return positioner.statementLine()
+	}
+	return 0
 }
 
 func (a *quadReaderAdapter) Close() error {
+	a.metrics.publishBytes()
+	a.span.end(a.Metrics(), nil)
+	if a.stopBudget != nil {
+		a.stopBudget()
+	}
 	if a.isTriple {
 		return a.dec.(tripleDecoder).Close()
 	}
@@ -385,11 +1277,65 @@ func (a *quadReaderAdapter) Close() error {
 
 // quadWriterAdapter adapts TripleEncoder/QuadEncoder to unified Writer interface.
 type quadWriterAdapter struct {
-	enc      interface{}
-	isTriple bool
+	enc        interface{}
+	isTriple   bool
+	metrics    *streamMetrics // Counts the bytes of the countingWriter
+	compressor compressWriter // nil without OptCompress
+	reifier    *tripleTermReifier
+	span       *streamSpan // nil without OptTracer
+
+	graphFilter   func(Term) bool
+	graphRename   func(Term) Term
+	validate      bool
+	generalized   bool // Statements may be generalized RDF (OptGeneralizedRDF)
+	deterministic bool
+	held          []Statement // Statements held until Close for OptDeterministic
 }
 
 func (a *quadWriterAdapter) Write(s Statement) error {
+	a.metrics.statements.Add(1)
+	err := a.writeStatement(s)
+	if err != nil {
+		a.span.end(a.Metrics(), err)
+	}
+	return err
+}
+
+func (a *quadWriterAdapter) writeStatement(s Statement) error {
+	if a.graphFilter != nil && !a.graphFilter(s.G) {
+		return nil
+	}
+	if a.graphRename != nil {
+		s.G = a.graphRename(s.G)
+	}
+	if a.validate {
+		if err := validateStatement(s, a.generalized); err != nil {
+			return err
+		}
+	}
+	if a.reifier != nil {
+		for _, stmt := range a.reifier.reify(s) {
+			if err := a.write(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return a.write(s)
+}
+
+func (a *quadWriterAdapter) write(s Statement) error {
+	if a.deterministic {
+		if a.isTriple {
+			s.G = nil
+		}
+		a.held = append(a.held, s)
+		return nil
+	}
+	return a.encode(s)
+}
+
+func (a *quadWriterAdapter) encode(s Statement) error {
 	if a.isTriple {
 		enc := a.enc.(tripleEncoder)
 		return enc.Write(s.AsTriple())
@@ -400,15 +1346,80 @@ func (a *quadWriterAdapter) Write(s Statement) error {
 }
 
 func (a *quadWriterAdapter) Flush() error {
+	err := a.flush()
+	if err != nil {
+		a.span.end(a.Metrics(), err)
+	}
+	return err
+}
+
+func (a *quadWriterAdapter) flush() error {
+	var err error
 	if a.isTriple {
-		return a.enc.(tripleEncoder).Flush()
+		err = a.enc.(tripleEncoder).Flush()
+	} else {
+		err = a.enc.(quadEncoder).Flush()
+	}
+	if err != nil || a.compressor == nil {
+		return err
 	}
-	return a.enc.(quadEncoder).Flush()
+	return a.compressor.Flush()
 }
 
 func (a *quadWriterAdapter) Close() error {
+	err := a.close()
+	a.metrics.finish()
+	a.span.end(a.Metrics(), err)
+	return err
+}
+
+func (a *quadWriterAdapter) close() error {
+	var err error
+	if a.deterministic {
+		err = a.writeHeld()
+	}
+	// The encoder and compressor are closed even after an error, so the
+	// output ends with a complete stream; the first error is returned.
+	var closeErr error
 	if a.isTriple {
-		return a.enc.(tripleEncoder).Close()
+		closeErr = a.enc.(tripleEncoder).Close()
+	} else {
+		closeErr = a.enc.(quadEncoder).Close()
+	}
+	if err == nil {
+		err = closeErr
+	}
+	if a.compressor != nil {
+		if closeErr := a.compressor.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// writeHeld writes the statements held for OptDeterministic, canonicalized.
+func (a *quadWriterAdapter) writeHeld() error {
+	stmts, err := Canonicalize(a.held)
+	a.held = nil
+	if err != nil {
+		return err
 	}
-	return a.enc.(quadEncoder).Close()
+	for _, s := range stmts {
+		if err := a.encode(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BytesWritten returns the number of bytes passed to the underlying io.Writer.
+// Buffered output is counted once it is flushed.
+func (a *quadWriterAdapter) BytesWritten() int64 {
+	return a.metrics.bytes.Load()
+}
+
+// Metrics implements MetricsReporter.
+func (a *quadWriterAdapter) Metrics() Metrics {
+	elapsed, done := a.metrics.elapsed()
+	return Metrics{BytesWritten: a.metrics.bytes.Load(), Statements: a.metrics.statements.Load(), Elapsed: elapsed, Done: done}
 }
@@ -52,3 +52,46 @@ func TestParse_NilContext(t *testing.T) {
 		t.Fatalf("expected 1 statement, got %d", count)
 	}
 }
+
+func TestParseBatch(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i < 7; i++ {
+		input.WriteString("<http://example.org/s> <http://example.org/p> \"v\" .\n")
+	}
+	var sizes []int
+	var first *Statement
+	err := ParseBatch(context.Background(), strings.NewReader(input.String()), FormatNTriples, 3, func(batch []Statement) error {
+		if first == nil {
+			first = &batch[0]
+		} else if &batch[0] != first {
+			t.Fatalf("batch buffer should be reused between calls")
+		}
+		sizes = append(sizes, len(batch))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseBatch: %v", err)
+	}
+	if len(sizes) != 3 || sizes[0] != 3 || sizes[1] != 3 || sizes[2] != 1 {
+		t.Fatalf("unexpected batch sizes %v", sizes)
+	}
+}
+
+func TestParseBatch_HandlerError(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"v\" .\n"
+	err := ParseBatch(nil, strings.NewReader(input), FormatNTriples, 0, func([]Statement) error {
+		return context.DeadlineExceeded
+	})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+}
+
+func TestParseBatch_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := ParseBatch(ctx, strings.NewReader(""), FormatNTriples, 10, func([]Statement) error { return nil })
+	if err != context.Canceled {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
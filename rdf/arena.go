@@ -0,0 +1,103 @@
+package rdf
+
+import "unsafe"
+
+// OptArena makes the N-Triples and N-Quads readers decode every line into
+// one reused buffer instead of a fresh string, and its terms into reused
+// term values instead of fresh ones, so that decoding a statement without
+// escapes or nested triple terms allocates nothing. In exchange, the terms
+// of a returned statement, and their strings (IRIs, blank node labels,
+// literals, language tags), are only valid until the next call to Next or
+// NextInto: a statement kept longer must be copied with Statement.Clone.
+// Other formats and OptParallelism ignore this option. Errors, and blank
+// node scope state, keep copies of what they refer to.
+func OptArena() Option {
+	return func(opts *Options) {
+		opts.Arena = true
+	}
+}
+
+// InPlaceReader is implemented by readers returned from NewReader, OpenFile
+// and OpenMapped. NextInto stores the next statement in *s, so that a
+// decoding loop reuses one Statement; with OptArena, the terms stored in *s
+// hold the reused buffers of the decoder. It returns io.EOF at the end of
+// the input and leaves *s unchanged on error.
+type InPlaceReader interface {
+	Reader
+	NextInto(s *Statement) error
+}
+
+// nextInto implements NextInto with the Next method of r.
+func nextInto(r Reader, s *Statement) error {
+	stmt, err := r.Next()
+	if err != nil {
+		return err
+	}
+	*s = stmt
+	return nil
+}
+
+// termArena holds the terms of the last line of an OptArena decoder. It
+// returns Term values pointing at its slots rather than at a copy of each
+// term, which would allocate, and the next line overwrites the slots. A
+// line with more terms of a type than there are slots, which only happens
+// with triple terms, gets fresh copies for the rest. A nil termArena always
+// returns copies.
+type termArena struct {
+	iris                   [3]IRI // Subject, object and graph name
+	blankNodes             [3]BlankNode
+	literals               [3]Literal // Objects, or generalized subjects and graph names
+	nIRI, nBlank, nLiteral int
+}
+
+// Values of the Term types whose type word arenaTerm copies.
+var (
+	iriTerm       Term = IRI{}
+	blankNodeTerm Term = BlankNode{}
+	literalTerm   Term = Literal{}
+)
+
+// arenaTerm returns a Term of the dynamic type of typ holding the value at
+// p. It relies on the layout of interface values, a type word followed by
+// a pointer to the value, which the unsafe package exposes to reflect.
+func arenaTerm(typ Term, p unsafe.Pointer) Term {
+	(*[2]unsafe.Pointer)(unsafe.Pointer(&typ))[1] = p
+	return typ
+}
+
+// reset makes the slots available to the next line.
+func (a *termArena) reset() {
+	if a != nil {
+		a.nIRI, a.nBlank, a.nLiteral = 0, 0, 0
+	}
+}
+
+func (a *termArena) iri(v IRI) Term {
+	if a == nil || a.nIRI == len(a.iris) {
+		return v
+	}
+	slot := &a.iris[a.nIRI]
+	a.nIRI++
+	*slot = v
+	return arenaTerm(iriTerm, unsafe.Pointer(slot))
+}
+
+func (a *termArena) blankNode(v BlankNode) Term {
+	if a == nil || a.nBlank == len(a.blankNodes) {
+		return v
+	}
+	slot := &a.blankNodes[a.nBlank]
+	a.nBlank++
+	*slot = v
+	return arenaTerm(blankNodeTerm, unsafe.Pointer(slot))
+}
+
+func (a *termArena) literal(v Literal) Term {
+	if a == nil || a.nLiteral == len(a.literals) {
+		return v
+	}
+	slot := &a.literals[a.nLiteral]
+	a.nLiteral++
+	*slot = v
+	return arenaTerm(literalTerm, unsafe.Pointer(slot))
+}
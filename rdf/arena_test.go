@@ -0,0 +1,112 @@
+package rdf
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const arenaInput = `<http://example.org/s> <http://example.org/p> "one" _:g .
+_:b <http://example.org/p> "two"@en <http://example.org/g> .
+not a statement
+_:b <http://example.org/p> "esc\"aped" _:g .
+`
+
+func TestArena(t *testing.T) {
+	opts := []Option{OptContinueOnError(0), OptBlankNodeScope(BlankNodeScopeGraph)}
+	plain, err := NewReader(strings.NewReader(arenaInput), FormatNQuads, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := collectStatements(plain)
+	if err != nil || len(want) != 3 {
+		t.Fatalf("without arena = %v, %v", want, err)
+	}
+	reader, err := NewReader(strings.NewReader(arenaInput), FormatNQuads, append(opts, OptArena())...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	var got []Statement
+	var stmt Statement
+	for {
+		err := reader.(InPlaceReader).NextInto(&stmt)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextInto: %v", err)
+		}
+		got = append(got, stmt.Clone())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	errs := reader.(ErrorCollector).Errors()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "not a statement") {
+		t.Errorf("errors = %v", errs)
+	}
+}
+
+func TestArenaTerms(t *testing.T) {
+	input := strings.Repeat(`<http://example.org/s> <http://example.org/p> "one"@en <http://example.org/g> .
+_:b <http://example.org/p> <<( _:b <http://example.org/p> <<( <http://example.org/s> <http://example.org/p> <http://example.org/o> )>> )>> _:g .
+`, 100)
+	want := parseContainerInput(t, input, FormatNQuads)
+	reader, err := NewReader(strings.NewReader(input), FormatNQuads, OptArena())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	var got []Statement
+	var stmt Statement
+	for reader.(InPlaceReader).NextInto(&stmt) == nil {
+		got = append(got, stmt.Clone())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Statements without triple terms or escapes are decoded without
+	// allocating.
+	reader, err = NewReader(strings.NewReader(strings.Repeat(`<http://example.org/s> <http://example.org/p> "one"@en _:g .
+`, 200)), FormatNQuads, OptArena())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	if allocs := testing.AllocsPerRun(100, func() {
+		if err := reader.(InPlaceReader).NextInto(&stmt); err != nil {
+			t.Fatal(err)
+		}
+	}); allocs != 0 {
+		t.Errorf("NextInto allocations = %v, want 0", allocs)
+	}
+	if s, ok := stmt.S.(IRI); !ok || s.Value != "http://example.org/s" || stmt.O.(Literal).Lang != "en" || stmt.G.(BlankNode).ID != "g" {
+		t.Errorf("statement = %v", stmt)
+	}
+}
+
+func TestInPlaceReader(t *testing.T) {
+	path := writeMappedFile(t, "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n")
+	file, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	mapped, err := OpenMapped(path, FormatNTriples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mapped.Close()
+	for _, r := range []Reader{file, mapped} {
+		var s Statement
+		if err := r.(InPlaceReader).NextInto(&s); err != nil || s.P.Value != "http://example.org/p" {
+			t.Errorf("NextInto = %v, %v", s, err)
+		}
+		if err := r.(InPlaceReader).NextInto(&s); err != io.EOF || s.P.Value != "http://example.org/p" {
+			t.Errorf("NextInto at end = %v, %v", s, err)
+		}
+	}
+}
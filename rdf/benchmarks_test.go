@@ -3,6 +3,9 @@ package rdf
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -221,7 +224,7 @@ func BenchmarkResolveIRI(b *testing.B) {
 	relative := "path/to/resource"
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = resolveIRI(base, relative)
+		_ = ResolveIRI(base, relative)
 	}
 }
 
@@ -360,6 +363,52 @@ func BenchmarkNTriplesDecode10MB(b *testing.B) {
 	}
 }
 
+// BenchmarkNTriplesDecodeArena benchmarks decoding 1MB of N-Triples data
+// into a reused statement with OptArena
+func BenchmarkNTriplesDecodeArena(b *testing.B) {
+	input := generateLargeNTriplesInput(1 << 20) // 1MB
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dec, err := NewReader(bytes.NewReader(input), FormatNTriples, OptArena())
+		if err != nil {
+			b.Fatal(err)
+		}
+		var stmt Statement
+		count := 0
+		for dec.(InPlaceReader).NextInto(&stmt) == nil {
+			count++
+		}
+		dec.Close()
+	}
+}
+
+// BenchmarkNTriplesDecodeMapped benchmarks decoding 1MB of N-Triples data
+// from a memory-mapped file
+func BenchmarkNTriplesDecodeMapped(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "bench.nt")
+	if err := os.WriteFile(path, generateLargeNTriplesInput(1<<20), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dec, err := OpenMapped(path, FormatNTriples)
+		if err != nil {
+			b.Fatal(err)
+		}
+		count := 0
+		for {
+			_, err := dec.Next()
+			if err != nil {
+				break
+			}
+			count++
+		}
+		dec.Close()
+	}
+}
+
 // BenchmarkTurtleEncode1MB benchmarks encoding 1MB worth of statements
 func BenchmarkTurtleEncode1MB(b *testing.B) {
 	// Generate approximately 1MB of statements
@@ -740,3 +789,30 @@ func BenchmarkFormatComparison(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkMemoryQuadStoreSnapshotWrite benchmarks the first change after
+// a snapshot of a store of 10,000 quads spread over 100 graphs, which copies
+// the changed graph.
+func BenchmarkMemoryQuadStoreSnapshotWrite(b *testing.B) {
+	store := NewMemoryQuadStore()
+	for i := range 10_000 {
+		store.AddQuad(Quad{
+			S: IRI{Value: fmt.Sprintf("http://example.org/s%d", i)},
+			P: IRI{Value: "http://example.org/p"},
+			O: Literal{Lexical: fmt.Sprint(i)},
+			G: IRI{Value: fmt.Sprintf("http://example.org/g%d", i%100)},
+		})
+	}
+	q := Quad{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}, G: IRI{Value: "http://example.org/g0"}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		snap, err := store.Snapshot()
+		if err != nil {
+			b.Fatal(err)
+		}
+		store.AddQuad(q)
+		store.DeleteQuad(q)
+		snap.Close()
+	}
+}
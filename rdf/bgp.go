@@ -0,0 +1,134 @@
+package rdf
+
+import (
+	"iter"
+	"maps"
+)
+
+// Variable is a variable of a TriplePattern, such as ?name in SPARQL.
+// Variables are terms of kind TermVariable that patterns may use in place
+// of other terms; they are not valid in statements.
+type Variable string
+
+// Kind returns TermVariable.
+func (v Variable) Kind() TermKind { return TermVariable }
+
+// String returns the variable name prefixed with "?".
+func (v Variable) String() string { return "?" + string(v) }
+
+// TriplePattern is a triple whose subject, predicate and object may be
+// variables. P is an IRI or a Variable.
+type TriplePattern struct {
+	// S is the subject term or variable.
+	S Term
+	// P is the predicate IRI or variable.
+	P Term
+	// O is the object term or variable.
+	O Term
+}
+
+// Bindings maps the variables of a basic graph pattern to the terms of a
+// solution.
+type Bindings map[Variable]Term
+
+// MatchBGP returns the solutions of the basic graph pattern patterns over
+// the quads of m: the bindings of their variables for which every pattern
+// is a triple of the graph. A nil graph matches in the default graph,
+// a Variable matches in every named graph and binds the graph name, as
+// SPARQL's GRAPH ?g does, and other terms match in the graph of that name.
+//
+// Patterns are joined by nested loops, taking at each step the pattern with
+// the most bound terms. Blank nodes of patterns are terms, not variables,
+// and so are triple terms, whose parts are not matched against variables.
+// Solutions are yielded in an unspecified order; a backend error is yielded
+// with nil Bindings, after which iteration stops.
+func MatchBGP(m QuadMatcher, graph Term, patterns ...TriplePattern) iter.Seq2[Bindings, error] {
+	return func(yield func(Bindings, error) bool) {
+		e := bgpEval{m: m, graph: graph, yield: yield}
+		e.solve(patterns, make(Bindings))
+	}
+}
+
+type bgpEval struct {
+	m     QuadMatcher
+	graph Term
+	yield func(Bindings, error) bool
+	bound []Variable // Variables bound by the steps in progress
+}
+
+// solve yields the solutions extending b with bindings for patterns. It
+// returns false once iteration is to stop.
+func (e *bgpEval) solve(patterns []TriplePattern, b Bindings) bool {
+	if len(patterns) == 0 {
+		return e.yield(maps.Clone(b), nil)
+	}
+	best, bestBound := 0, -1
+	for i, t := range patterns {
+		n := 0
+		for _, term := range [...]Term{t.S, t.P, t.O} {
+			if resolveVariable(term, b) != nil {
+				n++
+			}
+		}
+		if n > bestBound {
+			best, bestBound = i, n
+		}
+	}
+	t := patterns[best]
+	rest := append(patterns[:best:best], patterns[best+1:]...)
+
+	p := QuadPattern{S: resolveVariable(t.S, b), O: resolveVariable(t.O, b)}
+	switch pred := resolveVariable(t.P, b).(type) {
+	case nil:
+	case IRI:
+		p.P = pred
+	default:
+		return true
+	}
+	p.G = resolveVariable(e.graph, b)
+	p.DefaultGraph = e.graph == nil
+	for q, err := range e.m.MatchQuads(p) {
+		if err != nil {
+			e.yield(nil, err)
+			return false
+		}
+		if q.G == nil && e.graph != nil {
+			continue
+		}
+		mark := len(e.bound)
+		if e.bind(b, t.S, q.S) && e.bind(b, t.P, q.P) && e.bind(b, t.O, q.O) && e.bind(b, e.graph, q.G) {
+			if !e.solve(rest, b) {
+				return false
+			}
+		}
+		for _, v := range e.bound[mark:] {
+			delete(b, v)
+		}
+		e.bound = e.bound[:mark]
+	}
+	return true
+}
+
+// bind binds pattern to term if it is an unbound variable, reporting false
+// if it is a variable bound to another term.
+func (e *bgpEval) bind(b Bindings, pattern, term Term) bool {
+	v, ok := pattern.(Variable)
+	if !ok {
+		return true
+	}
+	if bound, ok := b[v]; ok {
+		return TermEqual(bound, term)
+	}
+	b[v] = term
+	e.bound = append(e.bound, v)
+	return true
+}
+
+// resolveVariable returns the term bound to t if it is a variable, nil if
+// it is unbound, and t itself otherwise.
+func resolveVariable(t Term, b Bindings) Term {
+	if v, ok := t.(Variable); ok {
+		return b[v]
+	}
+	return t
+}
@@ -0,0 +1,116 @@
+package rdf
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestMatchBGP(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	d := NewDataset()
+	for _, s := range []Statement{
+		{S: ex("alice"), P: ex("knows"), O: ex("bob")},
+		{S: ex("bob"), P: ex("knows"), O: ex("carol")},
+		{S: ex("carol"), P: ex("knows"), O: ex("carol")},
+		{S: ex("bob"), P: ex("name"), O: Literal{Lexical: "Bob"}},
+		{S: ex("carol"), P: ex("name"), O: Literal{Lexical: "Carol"}},
+		{S: ex("dave"), P: ex("knows"), O: ex("alice"), G: ex("g1")},
+		{S: ex("erin"), P: ex("knows"), O: ex("alice"), G: ex("g2")},
+	} {
+		if err := d.Add(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	solutions := func(seq func(func(Bindings, error) bool)) []string {
+		var got []string
+		for b, err := range seq {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, fmt.Sprint(b))
+		}
+		slices.Sort(got)
+		return got
+	}
+
+	tests := []struct {
+		name     string
+		graph    Term
+		patterns []TriplePattern
+		want     []string
+	}{
+		{
+			name: "join",
+			patterns: []TriplePattern{
+				{S: Variable("x"), P: ex("knows"), O: Variable("y")},
+				{S: Variable("y"), P: ex("name"), O: Variable("n")},
+			},
+			want: []string{
+				"map[?n:\"Carol\" ?x:http://example.org/bob ?y:http://example.org/carol]",
+				"map[?n:\"Carol\" ?x:http://example.org/carol ?y:http://example.org/carol]",
+				"map[?n:\"Bob\" ?x:http://example.org/alice ?y:http://example.org/bob]",
+			},
+		},
+		{
+			name:     "repeated variable",
+			patterns: []TriplePattern{{S: Variable("x"), P: ex("knows"), O: Variable("x")}},
+			want:     []string{"map[?x:http://example.org/carol]"},
+		},
+		{
+			name:     "predicate variable",
+			patterns: []TriplePattern{{S: ex("bob"), P: Variable("p"), O: Variable("o")}},
+			want: []string{
+				"map[?o:\"Bob\" ?p:http://example.org/name]",
+				"map[?o:http://example.org/carol ?p:http://example.org/knows]",
+			},
+		},
+		{
+			name:     "no variables",
+			patterns: []TriplePattern{{S: ex("alice"), P: ex("knows"), O: ex("bob")}},
+			want:     []string{"map[]"},
+		},
+		{
+			name:     "no solution",
+			patterns: []TriplePattern{{S: ex("alice"), P: ex("name"), O: Variable("n")}},
+		},
+		{
+			name:     "named graph",
+			graph:    ex("g1"),
+			patterns: []TriplePattern{{S: Variable("x"), P: ex("knows"), O: ex("alice")}},
+			want:     []string{"map[?x:http://example.org/dave]"},
+		},
+		{
+			name:     "graph variable",
+			graph:    Variable("g"),
+			patterns: []TriplePattern{{S: Variable("x"), P: ex("knows"), O: Variable("y")}},
+			want: []string{
+				"map[?g:http://example.org/g1 ?x:http://example.org/dave ?y:http://example.org/alice]",
+				"map[?g:http://example.org/g2 ?x:http://example.org/erin ?y:http://example.org/alice]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := solutions(MatchBGP(d.Store(), tt.graph, tt.patterns...))
+		want := slices.Clone(tt.want)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: solutions = %q, want %q", tt.name, got, want)
+		}
+	}
+
+	if got := solutions(d.Graph(ex("g2")).MatchBGP(TriplePattern{S: Variable("x"), P: ex("knows"), O: Variable("y")})); len(got) != 1 {
+		t.Errorf("Graph.MatchBGP solutions = %q, want 1", got)
+	}
+
+	// Stopping early stops evaluation.
+	count := 0
+	for range MatchBGP(d.Store(), nil, TriplePattern{S: Variable("s"), P: Variable("p"), O: Variable("o")}) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("iterations after break = %d", count)
+	}
+}
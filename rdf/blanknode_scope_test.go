@@ -0,0 +1,71 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+)
+
+const blankNodeScopeInput = `_:a <http://example.org/p> _:b <http://example.org/g1> .
+_:a <http://example.org/p> _:b <http://example.org/g2> .
+_:a <http://example.org/p> _:c _:g .
+`
+
+func readScoped(t *testing.T, input string, format Format, opts ...Option) []Statement {
+	t.Helper()
+	reader, err := NewReader(strings.NewReader(input), format, opts...)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Close()
+	stmts, err := collectStatements(reader)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	return stmts
+}
+
+func TestOptBlankNodePrefix(t *testing.T) {
+	stmts := readScoped(t, blankNodeScopeInput, FormatNQuads, OptBlankNodePrefix("f1_"))
+	if got := stmts[0].S.(BlankNode).ID; got != "f1_a" {
+		t.Fatalf("subject label = %q, want f1_a", got)
+	}
+	if stmts[0].S != stmts[1].S {
+		t.Fatalf("document scope must keep labels shared across graphs")
+	}
+	if got := stmts[2].G.(BlankNode).ID; got != "f1_g" {
+		t.Fatalf("graph label = %q, want f1_g", got)
+	}
+}
+
+func TestOptBlankNodeScope_Graph(t *testing.T) {
+	stmts := readScoped(t, blankNodeScopeInput, FormatNQuads, OptBlankNodeScope(BlankNodeScopeGraph))
+	if stmts[0].S == stmts[1].S {
+		t.Fatalf("graph scope must separate labels across graphs")
+	}
+	if stmts[0].S.(BlankNode).ID != "g0_a" || stmts[1].S.(BlankNode).ID != "g1_a" {
+		t.Fatalf("unexpected labels %v %v", stmts[0].S, stmts[1].S)
+	}
+	if got := stmts[2].G.(BlankNode).ID; got != "g" {
+		t.Fatalf("graph names stay in document scope, got %q", got)
+	}
+}
+
+func TestOptBlankNodeScope_Statement(t *testing.T) {
+	input := "_:a <http://example.org/p> _:a .\n_:a <http://example.org/p> _:b .\n"
+	stmts := readScoped(t, input, FormatNTriples, OptBlankNodeScope(BlankNodeScopeStatement), OptBlankNodePrefix("x"))
+	if stmts[0].S != stmts[0].O {
+		t.Fatalf("labels within one statement must stay shared")
+	}
+	if stmts[0].S == stmts[1].S {
+		t.Fatalf("statement scope must separate labels across statements")
+	}
+	if got := stmts[1].S.(BlankNode).ID; got != "xs2_a" {
+		t.Fatalf("unexpected label %q", got)
+	}
+}
+
+func TestBlankNodeScope_String(t *testing.T) {
+	if BlankNodeScopeGraph.String() != "graph" || BlankNodeScope(9).String() != "unknown" {
+		t.Fatalf("unexpected scope names")
+	}
+}
@@ -0,0 +1,59 @@
+package rdf
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OptBudget bounds the resources a reader may spend on its input, for
+// services that parse uploads. A reader fails with an error matching
+// ErrBudgetExceeded (code ErrCodeBudgetExceeded) once maxWallTime has
+// passed since its creation, including the time its caller spends between
+// calls to Next, or once its decoder holds more than maxBufferedBytes of
+// statements that it has not returned yet: triples queued by the RDF/XML
+// reader, JSON-LD values held until their context is known, and the
+// triples of a Turtle or TriG statement. Zero or negative values disable
+// either budget. The time budget is enforced with the context of the
+// reader, so it is observed within the same bounded number of tokens as
+// OptContext; Close stops its timer.
+func OptBudget(maxWallTime time.Duration, maxBufferedBytes int64) Option {
+	return func(opts *Options) {
+		opts.MaxWallTime = maxWallTime
+		opts.MaxBufferedBytes = maxBufferedBytes
+	}
+}
+
+// withTimeBudget returns ctx, or a background context if nil, with a
+// deadline after maxWallTime whose cause is ErrBudgetExceeded.
+func withTimeBudget(ctx context.Context, maxWallTime time.Duration) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	err := fmt.Errorf("rdf: decoding took longer than %v: %w", maxWallTime, ErrBudgetExceeded)
+	return context.WithTimeoutCause(ctx, maxWallTime, err)
+}
+
+// errBufferBudget is the error of a decoder buffering more than max bytes.
+func errBufferBudget(max int64) error {
+	return fmt.Errorf("rdf: decoder buffered more than %d bytes: %w", max, ErrBudgetExceeded)
+}
+
+// tripleBytes estimates the memory held by the strings of a triple.
+func tripleBytes(t Triple) int64 {
+	return termBytes(t.S) + int64(len(t.P.Value)) + termBytes(t.O)
+}
+
+func termBytes(term Term) int64 {
+	switch t := term.(type) {
+	case IRI:
+		return int64(len(t.Value))
+	case BlankNode:
+		return int64(len(t.ID))
+	case Literal:
+		return int64(len(t.Lexical) + len(t.Datatype.Value) + len(t.Lang) + len(t.Direction))
+	case TripleTerm:
+		return termBytes(t.S) + int64(len(t.P.Value)) + termBytes(t.O)
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+package rdf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// endlessNTriples is an input of N-Triples lines that never ends.
+type endlessNTriples struct {
+	n int
+}
+
+func (e *endlessNTriples) Read(p []byte) (int, error) {
+	time.Sleep(time.Millisecond)
+	line := fmt.Sprintf("<http://example.org/s> <http://example.org/p> \"%d\" .\n", e.n)
+	e.n++
+	return copy(p, line), nil
+}
+
+func TestOptBudgetWallTime(t *testing.T) {
+	for _, format := range []Format{FormatNTriples, FormatTurtle} {
+		reader, err := NewReader(&endlessNTriples{}, format, OptBudget(20*time.Millisecond, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = readAllStatements(reader)
+		if !errors.Is(err, ErrBudgetExceeded) {
+			t.Fatalf("%s: error = %v, want ErrBudgetExceeded", format, err)
+		}
+		if Code(err) != ErrCodeBudgetExceeded {
+			t.Errorf("%s: Code() = %q, want %q", format, Code(err), ErrCodeBudgetExceeded)
+		}
+	}
+}
+
+func TestOptBudgetBufferedBytes(t *testing.T) {
+	var items, objects, graph []string
+	for i := range 100 {
+		items = append(items, fmt.Sprintf(`<rdf:Description rdf:about="http://example.org/item%d"/>`, i))
+		objects = append(objects, fmt.Sprintf(`"object %d"`, i))
+		graph = append(graph, fmt.Sprintf(`{"@id": "ex:s%d", "ex:p": "value %d"}`, i, i))
+	}
+	tests := []struct {
+		format Format
+		input  string
+	}{
+		{FormatRDFXML, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">` +
+			`<rdf:Description rdf:about="http://example.org/s"><ex:list rdf:parseType="Collection">` + strings.Join(items, "") +
+			`</ex:list></rdf:Description></rdf:RDF>`},
+		{FormatTurtle, `<http://example.org/s> <http://example.org/p> ` + strings.Join(objects, ", ") + ` .`},
+		{FormatJSONLD, `{"@graph": [` + strings.Join(graph, ", ") + `], "@context": {"ex": "http://example.org/"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(tt.input), tt.format, OptBudget(0, 1<<20))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := readAllStatements(reader); err != nil {
+				t.Fatalf("within budget: %v", err)
+			}
+			reader, err = NewReader(strings.NewReader(tt.input), tt.format, OptBudget(0, 1000))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = readAllStatements(reader)
+			if Code(err) != ErrCodeBudgetExceeded {
+				t.Errorf("Code(%v) = %q, want %q", err, Code(err), ErrCodeBudgetExceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package rdf
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Builder constructs statements programmatically:
+//
+//	b := rdf.NewBuilder()
+//	b.Subject(alice).
+//		Add(foafName, "Alice").
+//		Add(foafKnows, bob).
+//		AddList(scores, 1, 2, 3)
+//	stmts := b.Statements()
+//
+// Objects are Terms or Go values converted to literals (see Add). Lists are
+// expanded into rdf:first/rdf:rest statements the way the Turtle parser
+// expands collections.
+type Builder struct {
+	graph  Term
+	stmts  []Statement
+	blanks *blankNodeGenerator
+}
+
+// NewBuilder returns an empty Builder adding to the default graph.
+func NewBuilder() *Builder {
+	return &Builder{blanks: newBlankNodeGenerator()}
+}
+
+// Graph makes statements of subjects selected afterwards go to graph g
+// (nil = the default graph).
+func (b *Builder) Graph(g Term) *Builder {
+	b.graph = g
+	return b
+}
+
+// Subject returns a SubjectBuilder adding statements about s to the current
+// graph.
+func (b *Builder) Subject(s Term) *SubjectBuilder {
+	return &SubjectBuilder{b: b, subject: s, graph: b.graph}
+}
+
+// BlankNode returns a new blank node, labeled like the blank nodes the
+// Builder creates for lists so that labels never clash.
+func (b *Builder) BlankNode() BlankNode {
+	return b.blanks.next()
+}
+
+// Statements returns a copy of the statements built so far, in the order
+// they were added.
+func (b *Builder) Statements() []Statement {
+	return append([]Statement(nil), b.stmts...)
+}
+
+// SubjectBuilder adds statements about one subject. It is returned by
+// Builder.Subject.
+type SubjectBuilder struct {
+	b       *Builder
+	subject Term
+	graph   Term
+}
+
+// Add adds the statement "subject p o". o is a Term, or a Go value
+// converted to a literal: a string becomes a simple literal, integers
+// xsd:integer, floats a canonical xsd:double, a bool xsd:boolean and a
+// time.Time xsd:dateTime. Add panics for other types.
+func (sb *SubjectBuilder) Add(p IRI, o any) *SubjectBuilder {
+	sb.b.stmts = append(sb.b.stmts, Statement{S: sb.subject, P: p, O: builderTerm(o), G: sb.graph})
+	return sb
+}
+
+// AddList adds the statement "subject p list", where list is an rdf:List of
+// items converted as in Add, followed by the rdf:first and rdf:rest
+// statements of the list. Without items the object is rdf:nil.
+func (sb *SubjectBuilder) AddList(p IRI, items ...any) *SubjectBuilder {
+	terms := make([]Term, len(items))
+	for i, item := range items {
+		terms[i] = builderTerm(item)
+	}
+	var expansion []Triple
+	head := generateCollectionTriples(terms, &expansion, sb.b.blanks.next)
+	sb.b.stmts = append(sb.b.stmts, Statement{S: sb.subject, P: p, O: head, G: sb.graph})
+	for _, t := range expansion {
+		sb.b.stmts = append(sb.b.stmts, Statement{S: t.S, P: t.P, O: t.O, G: sb.graph})
+	}
+	return sb
+}
+
+// builderTerm converts an object given to SubjectBuilder.Add into a Term.
+func builderTerm(v any) Term {
+	xsd := func(lexical, datatype string) Literal {
+		return Literal{Lexical: lexical, Datatype: IRI{Value: xsdNamespace + datatype}}
+	}
+	switch v := v.(type) {
+	case Term:
+		return v
+	case string:
+		return Literal{Lexical: v}
+	case bool:
+		return xsd(strconv.FormatBool(v), "boolean")
+	case int:
+		return xsd(strconv.FormatInt(int64(v), 10), "integer")
+	case int8:
+		return xsd(strconv.FormatInt(int64(v), 10), "integer")
+	case int16:
+		return xsd(strconv.FormatInt(int64(v), 10), "integer")
+	case int32:
+		return xsd(strconv.FormatInt(int64(v), 10), "integer")
+	case int64:
+		return xsd(strconv.FormatInt(v, 10), "integer")
+	case uint:
+		return xsd(strconv.FormatUint(uint64(v), 10), "integer")
+	case uint8:
+		return xsd(strconv.FormatUint(uint64(v), 10), "integer")
+	case uint16:
+		return xsd(strconv.FormatUint(uint64(v), 10), "integer")
+	case uint32:
+		return xsd(strconv.FormatUint(uint64(v), 10), "integer")
+	case uint64:
+		return xsd(strconv.FormatUint(v, 10), "integer")
+	case float32:
+		return xsd(canonicalXSDDouble(float64(v)), "double")
+	case float64:
+		return xsd(canonicalXSDDouble(v), "double")
+	case time.Time:
+		return xsd(v.Format(time.RFC3339Nano), "dateTime")
+	}
+	panic(fmt.Sprintf("rdf: Builder cannot convert %T to an RDF term", v))
+}
@@ -0,0 +1,76 @@
+package rdf
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	alice, bob := IRI{Value: "http://example.org/alice"}, IRI{Value: "http://example.org/bob"}
+	name, knows := IRI{Value: "http://xmlns.com/foaf/0.1/name"}, IRI{Value: "http://xmlns.com/foaf/0.1/knows"}
+	scores := IRI{Value: "http://example.org/scores"}
+
+	b := NewBuilder()
+	b.Subject(alice).Add(name, "Alice").Add(knows, bob).AddList(scores, 1, 2, 3)
+	got := writeStatements(t, FormatNTriples, b.Statements())
+
+	// The same data read from Turtle expands to the same statements.
+	var want []Statement
+	input := `<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice" ;
+		<http://xmlns.com/foaf/0.1/knows> <http://example.org/bob> ;
+		<http://example.org/scores> (1 2 3) .`
+	if err := Parse(context.Background(), strings.NewReader(input), FormatTurtle, func(s Statement) error {
+		want = append(want, s)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got != writeStatements(t, FormatNTriples, want) {
+		t.Errorf("builder output:\n%s\nwant:\n%s", got, writeStatements(t, FormatNTriples, want))
+	}
+}
+
+func TestBuilderValuesAndGraphs(t *testing.T) {
+	s, p := IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}
+	g := IRI{Value: "http://example.org/g"}
+	b := NewBuilder()
+	node := b.BlankNode()
+	b.Graph(g).Subject(s).
+		Add(p, true).
+		Add(p, int64(-7)).
+		Add(p, 2.5).
+		Add(p, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)).
+		Add(p, node).
+		AddList(p)
+	b.Graph(nil).Subject(node).Add(p, uint8(1))
+
+	stmts := b.Statements()
+	want := []Term{
+		Literal{Lexical: "true", Datatype: IRI{Value: xsdNamespace + "boolean"}},
+		Literal{Lexical: "-7", Datatype: IRI{Value: xsdNamespace + "integer"}},
+		Literal{Lexical: "2.5E0", Datatype: IRI{Value: xsdNamespace + "double"}},
+		Literal{Lexical: "2024-05-01T12:00:00Z", Datatype: IRI{Value: xsdNamespace + "dateTime"}},
+		node,
+		IRI{Value: rdfNilIRI},
+	}
+	if len(stmts) != len(want)+1 {
+		t.Fatalf("got %d statements, want %d", len(stmts), len(want)+1)
+	}
+	for i, o := range want {
+		if stmts[i].O != o || stmts[i].G != g {
+			t.Errorf("statement %d = %v, want object %v in %v", i, stmts[i], o, g)
+		}
+	}
+	if last := stmts[len(want)]; last.S != node || last.G != nil {
+		t.Errorf("last statement = %v, want subject %v in the default graph", last, node)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Add with an unsupported type did not panic")
+		}
+	}()
+	b.Subject(s).Add(p, struct{}{})
+}
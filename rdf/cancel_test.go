@@ -0,0 +1,114 @@
+package rdf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// cancelingReader cancels a context on its first Read.
+type cancelingReader struct {
+	r      io.Reader
+	cancel context.CancelFunc
+}
+
+func (c *cancelingReader) Read(p []byte) (int, error) {
+	c.cancel()
+	return c.r.Read(p)
+}
+
+func TestReaderCancelWithinStatement(t *testing.T) {
+	var objects []string
+	for i := range 1000 {
+		objects = append(objects, fmt.Sprintf("<http://example.org/o%d>", i))
+	}
+	turtle := "<http://example.org/s> <http://example.org/p> " + strings.Join(objects, ", ") + " .\n"
+	tests := []struct {
+		format Format
+		input  string
+	}{
+		{FormatTurtle, turtle},
+		{FormatTriG, "<http://example.org/g> { " + turtle + "}\n"},
+		{FormatRDFXML, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">` +
+			`<rdf:Description rdf:about="http://example.org/s"><ex:p rdf:parseType="Literal">` +
+			strings.Repeat("<b>x</b>", 1000) + `</ex:p></rdf:Description></rdf:RDF>`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			reader, err := NewReader(&cancelingReader{r: strings.NewReader(tt.input), cancel: cancel}, tt.format, OptContext(ctx))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reader.Close()
+			// The context is canceled while the first statement is read.
+			_, err = reader.Next()
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Next() error = %v, want context.Canceled", err)
+			}
+			if Code(err) != ErrCodeCanceled {
+				t.Errorf("Code() = %q, want %q", Code(err), ErrCodeCanceled)
+			}
+		})
+	}
+}
+
+func TestReaderCancelBetweenStatements(t *testing.T) {
+	tests := []struct {
+		format Format
+		input  string
+	}{
+		{FormatNTriples, "<http://example.org/s> <http://example.org/p> \"1\" .\n<http://example.org/s> <http://example.org/p> \"2\" .\n"},
+		{FormatNQuads, "<http://example.org/s> <http://example.org/p> \"1\" <http://example.org/g> .\n<http://example.org/s> <http://example.org/p> \"2\" .\n"},
+		{FormatTurtle, "<http://example.org/s> <http://example.org/p> \"1\", \"2\" .\n"},
+		{FormatJSONLD, `{"@id": "http://example.org/s", "http://example.org/p": ["1", "2"]}`},
+		{FormatRDFXML, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">` +
+			`<rdf:Description rdf:about="http://example.org/s"><ex:p>1</ex:p><ex:p>2</ex:p></rdf:Description></rdf:RDF>`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			reader, err := NewReader(strings.NewReader(tt.input), tt.format, OptContext(ctx))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reader.Close()
+			if _, err := reader.Next(); err != nil {
+				t.Fatal(err)
+			}
+			cancel()
+			// The second statement is already decoded, or queued, but not
+			// returned.
+			if _, err := reader.Next(); !errors.Is(err, context.Canceled) {
+				t.Fatalf("Next() after cancel error = %v, want context.Canceled", err)
+			}
+		})
+	}
+}
+
+func TestReaderDeadlineCode(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	reader, err := NewReader(strings.NewReader("<http://example.org/s> <http://example.org/p> \"1\" .\n"), FormatTurtle, OptContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = reader.Next()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Next() error = %v, want context.DeadlineExceeded", err)
+	}
+	if Code(err) != ErrCodeCanceled {
+		t.Errorf("Code() = %q, want %q", Code(err), ErrCodeCanceled)
+	}
+	// Recovery does not skip over a passed deadline.
+	reader, _ = NewReader(strings.NewReader("<http://example.org/s> <http://example.org/p> \"1\" .\n"), FormatTurtle, OptContext(ctx), OptContinueOnError(0))
+	if _, err := reader.Next(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Next() with recovery error = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,386 @@
+package rdf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// canonicalizationWorkLimit caps the N-degree hashes and permutations
+// Canonicalize computes, which grow factorially for datasets with many
+// indistinguishable blank nodes. Tests lower it.
+var canonicalizationWorkLimit = 1 << 20
+
+// ErrCanonicalizationLimit indicates a dataset whose blank nodes are too
+// symmetric to canonicalize within Canonicalize's work limit.
+var ErrCanonicalizationLimit = errors.New("rdf: canonicalization work limit exceeded")
+
+// Canonicalize returns the statements of a dataset with their blank nodes
+// relabeled _:c14n0, _:c14n1, ... by the RDF Dataset Canonicalization
+// algorithm (RDFC-1.0) with SHA-256, without duplicates and sorted by their
+// canonical N-Quads lines. Isomorphic datasets thus give identical results,
+// whatever their blank node labels and statement order.
+//
+// Blank nodes in triple terms are labeled like the other blank nodes of the
+// statement's subject or object, an extension of RDFC-1.0, which predates
+// triple terms. Datasets whose blank nodes are too symmetric fail with
+// ErrCanonicalizationLimit instead of running for an unbounded time.
+func Canonicalize(stmts []Statement) ([]Statement, error) {
+	c, err := newCanonicalizer(stmts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.issueIdentifiers(); err != nil {
+		return nil, err
+	}
+	type canonicalQuad struct {
+		stmt Statement
+		line string
+	}
+	quads := make([]canonicalQuad, len(c.quads))
+	for i, q := range c.quads {
+		q = relabelBlankNodes(q, func(id string) string { return c.canonical.issued[id] })
+		line, err := appendCanonicalNQuad(nil, q)
+		if err != nil {
+			return nil, err
+		}
+		quads[i] = canonicalQuad{q, string(line)}
+	}
+	slices.SortFunc(quads, func(a, b canonicalQuad) int { return strings.Compare(a.line, b.line) })
+	out := make([]Statement, len(quads))
+	for i, q := range quads {
+		out[i] = q.stmt
+	}
+	return out, nil
+}
+
+// CanonicalNQuads returns the canonical N-Quads document of a dataset: the
+// lines of Canonicalize's statements, each ending with a line feed. The
+// SHA-256 hash of the document identifies the dataset up to blank node
+// labels, as RDFC-1.0 specifies.
+func CanonicalNQuads(stmts []Statement) ([]byte, error) {
+	canonical, err := Canonicalize(stmts)
+	if err != nil {
+		return nil, err
+	}
+	var buf []byte
+	for _, s := range canonical {
+		if buf, err = appendCanonicalNQuad(buf, s); err != nil {
+			return nil, err
+		}
+		buf = append(buf, '\n')
+	}
+	return buf, nil
+}
+
+// canonicalLine returns the canonical N-Quads line of a statement whose
+// terms newCanonicalizer has already checked.
+func canonicalLine(s Statement) string {
+	line, _ := appendCanonicalNQuad(nil, s)
+	return string(line)
+}
+
+// canonicalizer holds the state of RDFC-1.0 over a deduplicated dataset.
+type canonicalizer struct {
+	quads       []Statement
+	bnodeQuads  map[string][]int // Indexes of the quads mentioning each blank node
+	bnodes      []string         // Blank nodes in order of first mention
+	firstDegree map[string]string
+	canonical   *identifierIssuer
+	work        int
+}
+
+func newCanonicalizer(stmts []Statement) (*canonicalizer, error) {
+	c := &canonicalizer{
+		bnodeQuads:  make(map[string][]int),
+		firstDegree: make(map[string]string),
+		canonical:   newIdentifierIssuer("c14n"),
+	}
+	seen := make(map[string]bool, len(stmts))
+	for _, s := range stmts {
+		line, err := appendCanonicalNQuad(nil, s)
+		if err != nil {
+			return nil, err
+		}
+		if seen[string(line)] {
+			continue
+		}
+		seen[string(line)] = true
+		i := len(c.quads)
+		c.quads = append(c.quads, s)
+		var mentioned []string
+		forEachBlankNode(s, func(id string, _ byte) {
+			if !slices.Contains(mentioned, id) {
+				mentioned = append(mentioned, id)
+			}
+		})
+		for _, id := range mentioned {
+			if _, ok := c.bnodeQuads[id]; !ok {
+				c.bnodes = append(c.bnodes, id)
+			}
+			c.bnodeQuads[id] = append(c.bnodeQuads[id], i)
+		}
+	}
+	return c, nil
+}
+
+// issueIdentifiers issues the canonical identifiers of all blank nodes
+// (steps 3 to 5 of the canonicalization algorithm).
+func (c *canonicalizer) issueIdentifiers() error {
+	hashToBnodes := make(map[string][]string)
+	for _, id := range c.bnodes {
+		h := c.hashFirstDegree(id)
+		hashToBnodes[h] = append(hashToBnodes[h], id)
+	}
+	hashes := slices.Sorted(maps.Keys(hashToBnodes))
+	for _, h := range hashes {
+		if ids := hashToBnodes[h]; len(ids) == 1 {
+			c.canonical.issue(ids[0])
+			delete(hashToBnodes, h)
+		}
+	}
+	for _, h := range hashes {
+		ids, ok := hashToBnodes[h]
+		if !ok {
+			continue
+		}
+		type pathResult struct {
+			hash   string
+			issuer *identifierIssuer
+		}
+		var results []pathResult
+		for _, id := range ids {
+			if _, ok := c.canonical.issued[id]; ok {
+				continue
+			}
+			temp := newIdentifierIssuer("b")
+			temp.issue(id)
+			hash, issuer, err := c.hashNDegree(id, temp)
+			if err != nil {
+				return err
+			}
+			results = append(results, pathResult{hash, issuer})
+		}
+		slices.SortStableFunc(results, func(a, b pathResult) int { return strings.Compare(a.hash, b.hash) })
+		for _, r := range results {
+			for _, id := range r.issuer.order {
+				c.canonical.issue(id)
+			}
+		}
+	}
+	return nil
+}
+
+// hashFirstDegree hashes the quads mentioning the blank node id, with id
+// written _:a and the other blank nodes _:z.
+func (c *canonicalizer) hashFirstDegree(id string) string {
+	if h, ok := c.firstDegree[id]; ok {
+		return h
+	}
+	lines := make([]string, 0, len(c.bnodeQuads[id]))
+	for _, i := range c.bnodeQuads[id] {
+		q := relabelBlankNodes(c.quads[i], func(b string) string {
+			if b == id {
+				return "a"
+			}
+			return "z"
+		})
+		lines = append(lines, canonicalLine(q)+"\n")
+	}
+	slices.Sort(lines)
+	h := sha256Hex(strings.Join(lines, ""))
+	c.firstDegree[id] = h
+	return h
+}
+
+// hashRelated hashes the blank node related, found at position (s, o or
+// g) of quad, from the identifier it has been issued so far or else its
+// first degree hash.
+func (c *canonicalizer) hashRelated(related string, quad Statement, issuer *identifierIssuer, position byte) string {
+	var b strings.Builder
+	b.WriteByte(position)
+	if position != 'g' {
+		b.WriteString("<" + quad.P.Value + ">")
+	}
+	if id, ok := c.canonical.issued[related]; ok {
+		b.WriteString("_:" + id)
+	} else if id, ok := issuer.issued[related]; ok {
+		b.WriteString("_:" + id)
+	} else {
+		b.WriteString(c.hashFirstDegree(related))
+	}
+	return sha256Hex(b.String())
+}
+
+// hashNDegree hashes the blank node id from the paths to the blank nodes it
+// is related to, choosing the lexicographically least path among all the
+// orders of related blank nodes with the same hash. It returns the hash and
+// the issuer that labeled the chosen paths.
+func (c *canonicalizer) hashNDegree(id string, issuer *identifierIssuer) (string, *identifierIssuer, error) {
+	if c.work++; c.work > canonicalizationWorkLimit {
+		return "", nil, ErrCanonicalizationLimit
+	}
+	hashToRelated := make(map[string][]string)
+	for _, i := range c.bnodeQuads[id] {
+		quad := c.quads[i]
+		forEachBlankNode(quad, func(related string, position byte) {
+			if related != id {
+				h := c.hashRelated(related, quad, issuer, position)
+				hashToRelated[h] = append(hashToRelated[h], related)
+			}
+		})
+	}
+	var data strings.Builder
+	for _, relatedHash := range slices.Sorted(maps.Keys(hashToRelated)) {
+		data.WriteString(relatedHash)
+		var chosenPath string
+		var chosenIssuer *identifierIssuer
+		err := permutations(hashToRelated[relatedHash], func(perm []string) error {
+			if c.work++; c.work > canonicalizationWorkLimit {
+				return ErrCanonicalizationLimit
+			}
+			issuerCopy := issuer.clone()
+			var path strings.Builder
+			var recursion []string
+			worse := func() bool {
+				return chosenPath != "" && path.Len() >= len(chosenPath) && path.String() > chosenPath
+			}
+			for _, related := range perm {
+				if cid, ok := c.canonical.issued[related]; ok {
+					path.WriteString("_:" + cid)
+				} else {
+					if _, ok := issuerCopy.issued[related]; !ok {
+						recursion = append(recursion, related)
+					}
+					path.WriteString("_:" + issuerCopy.issue(related))
+				}
+				if worse() {
+					return nil
+				}
+			}
+			for _, related := range recursion {
+				hash, issuer, err := c.hashNDegree(related, issuerCopy)
+				if err != nil {
+					return err
+				}
+				path.WriteString("_:" + issuerCopy.issue(related))
+				path.WriteString("<" + hash + ">")
+				issuerCopy = issuer
+				if worse() {
+					return nil
+				}
+			}
+			if chosenPath == "" || path.String() < chosenPath {
+				chosenPath, chosenIssuer = path.String(), issuerCopy
+			}
+			return nil
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		data.WriteString(chosenPath)
+		issuer = chosenIssuer
+	}
+	return sha256Hex(data.String()), issuer, nil
+}
+
+// identifierIssuer issues blank node identifiers made of a prefix and a
+// counter, remembering the order it issued them in.
+type identifierIssuer struct {
+	prefix  string
+	counter int
+	issued  map[string]string
+	order   []string
+}
+
+func newIdentifierIssuer(prefix string) *identifierIssuer {
+	return &identifierIssuer{prefix: prefix, issued: make(map[string]string)}
+}
+
+// issue returns the identifier issued for id, issuing the next one if id
+// has none yet.
+func (i *identifierIssuer) issue(id string) string {
+	if issued, ok := i.issued[id]; ok {
+		return issued
+	}
+	issued := i.prefix + strconv.Itoa(i.counter)
+	i.counter++
+	i.issued[id] = issued
+	i.order = append(i.order, id)
+	return issued
+}
+
+func (i *identifierIssuer) clone() *identifierIssuer {
+	return &identifierIssuer{prefix: i.prefix, counter: i.counter, issued: maps.Clone(i.issued), order: slices.Clone(i.order)}
+}
+
+// forEachBlankNode calls fn with each blank node of s and its position:
+// 's', 'o' or 'g', including those nested in triple terms.
+func forEachBlankNode(s Statement, fn func(id string, position byte)) {
+	var walk func(t Term, position byte)
+	walk = func(t Term, position byte) {
+		switch t := t.(type) {
+		case BlankNode:
+			fn(t.ID, position)
+		case TripleTerm:
+			walk(t.S, position)
+			walk(t.O, position)
+		}
+	}
+	walk(s.S, 's')
+	walk(s.O, 'o')
+	if s.G != nil {
+		walk(s.G, 'g')
+	}
+}
+
+// relabelBlankNodes returns s with each blank node, including those nested
+// in triple terms, relabeled by label.
+func relabelBlankNodes(s Statement, label func(id string) string) Statement {
+	var relabel func(t Term) Term
+	relabel = func(t Term) Term {
+		switch t := t.(type) {
+		case BlankNode:
+			return BlankNode{ID: label(t.ID)}
+		case TripleTerm:
+			return TripleTerm{S: relabel(t.S), P: t.P, O: relabel(t.O)}
+		}
+		return t
+	}
+	s.S = relabel(s.S)
+	s.O = relabel(s.O)
+	if s.G != nil {
+		s.G = relabel(s.G)
+	}
+	return s
+}
+
+// permutations calls fn with each permutation of items, in place, until fn
+// returns an error.
+func permutations(items []string, fn func([]string) error) error {
+	perm := slices.Clone(items)
+	var permute func(k int) error
+	permute = func(k int) error {
+		if k == len(perm) {
+			return fn(perm)
+		}
+		for i := k; i < len(perm); i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			if err := permute(k + 1); err != nil {
+				return err
+			}
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+		return nil
+	}
+	return permute(0)
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,143 @@
+package rdf
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func parseNQuads(t *testing.T, input string) []Statement {
+	t.Helper()
+	var stmts []Statement
+	for stmt, err := range Statements(strings.NewReader(input), FormatNQuads) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+func canonicalNQuadsOf(t *testing.T, input string) string {
+	t.Helper()
+	out, err := CanonicalNQuads(parseNQuads(t, input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestCanonicalNQuads(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{
+			"unique hashes",
+			`<http://example.com/#p> <http://example.com/#q> _:e0 .
+<http://example.com/#p> <http://example.com/#r> _:e1 .
+_:e0 <http://example.com/#s> <http://example.com/#u> .
+_:e1 <http://example.com/#t> <http://example.com/#u> .
+`,
+			`<http://example.com/#p> <http://example.com/#q> _:c14n0 .
+<http://example.com/#p> <http://example.com/#r> _:c14n1 .
+_:c14n0 <http://example.com/#s> <http://example.com/#u> .
+_:c14n1 <http://example.com/#t> <http://example.com/#u> .
+`,
+		},
+		{
+			"shared hashes",
+			`<http://example.com/#p> <http://example.com/#q> _:e0 .
+<http://example.com/#p> <http://example.com/#q> _:e1 .
+_:e0 <http://example.com/#p> _:e2 .
+_:e1 <http://example.com/#p> _:e3 .
+_:e2 <http://example.com/#r> _:e3 .
+`,
+			`<http://example.com/#p> <http://example.com/#q> _:c14n2 .
+<http://example.com/#p> <http://example.com/#q> _:c14n3 .
+_:c14n0 <http://example.com/#r> _:c14n1 .
+_:c14n2 <http://example.com/#p> _:c14n1 .
+_:c14n3 <http://example.com/#p> _:c14n0 .
+`,
+		},
+		{
+			"duplicates and literals",
+			`<http://example.com/s> <http://example.com/p> "a\tb"^^<http://www.w3.org/2001/XMLSchema#string> <http://example.com/g> .
+<http://example.com/s> <http://example.com/p> "a\u0009b" <http://example.com/g> .
+<http://example.com/s> <http://example.com/p> "x"@EN .
+`,
+			`<http://example.com/s> <http://example.com/p> "a\tb" <http://example.com/g> .
+<http://example.com/s> <http://example.com/p> "x"@en .
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canonicalNQuadsOf(t, tt.input); got != tt.want {
+				t.Errorf("CanonicalNQuads =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeInvariance(t *testing.T) {
+	// A six-cycle, two disjoint three-cycles with the same degrees, a graph
+	// name and a triple term with a blank node.
+	const input = `_:a <http://example.com/p> _:b .
+_:b <http://example.com/p> _:c .
+_:c <http://example.com/p> _:d .
+_:d <http://example.com/p> _:e .
+_:e <http://example.com/p> _:f .
+_:f <http://example.com/p> _:a .
+_:u <http://example.com/q> _:v _:g .
+_:v <http://example.com/q> _:w _:g .
+_:w <http://example.com/q> _:u _:g .
+_:x <http://example.com/q> _:y _:g .
+_:y <http://example.com/q> _:z _:g .
+_:z <http://example.com/q> _:x _:g .
+_:a <http://example.com/r> <<( _:u <http://example.com/q> "1" )>> .
+`
+	want := canonicalNQuadsOf(t, input)
+	if strings.Count(want, "\n") != 13 || !strings.Contains(want, "_:c14n12") || strings.Contains(want, "_:c14n13") {
+		t.Fatalf("unexpected canonical form:\n%s", want)
+	}
+	labels := []string{"a", "b", "c", "d", "e", "f", "g", "u", "v", "w", "x", "y", "z"}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		perm := rng.Perm(len(labels))
+		var pairs []string
+		for j, label := range labels {
+			pairs = append(pairs, "_:"+label+" ", fmt.Sprintf("_:n%d ", perm[j]))
+		}
+		lines := strings.Split(strings.TrimSpace(strings.NewReplacer(pairs...).Replace(input)), "\n")
+		rng.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+		if got := canonicalNQuadsOf(t, strings.Join(lines, "\n")+"\n"); got != want {
+			t.Fatalf("relabeled and shuffled input gave\n%s\nwant\n%s", got, want)
+		}
+	}
+
+	// Joining the two three-cycles into a six-cycle changes the dataset.
+	changed := strings.Replace(input, "_:z <http://example.com/q> _:x", "_:z <http://example.com/q> _:u", 1)
+	changed = strings.Replace(changed, "_:w <http://example.com/q> _:u", "_:w <http://example.com/q> _:x", 1)
+	if canonicalNQuadsOf(t, changed) == want {
+		t.Error("non-isomorphic datasets have the same canonical form")
+	}
+}
+
+func TestCanonicalizeLimit(t *testing.T) {
+	defer func(limit int) { canonicalizationWorkLimit = limit }(canonicalizationWorkLimit)
+	canonicalizationWorkLimit = 10000
+	// A complete graph on blank nodes is maximally symmetric.
+	var b strings.Builder
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			if i != j {
+				fmt.Fprintf(&b, "_:n%d <http://example.com/p> _:n%d .\n", i, j)
+			}
+		}
+	}
+	if _, err := Canonicalize(parseNQuads(t, b.String())); !errors.Is(err, ErrCanonicalizationLimit) {
+		t.Errorf("Canonicalize error = %v, want ErrCanonicalizationLimit", err)
+	}
+}
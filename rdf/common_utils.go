@@ -1,6 +1,9 @@
 package rdf
 
-import "fmt"
+import (
+	"crypto/rand"
+	"fmt"
+)
 
 // blankNodeGenerator provides a thread-safe way to generate unique blank node IDs.
 // This is used across different parsers to ensure consistent blank node generation.
@@ -30,3 +33,42 @@ func (g *blankNodeGenerator) reset() {
 func generateBlankNodeID(counter int) string {
 	return fmt.Sprintf("b%d", counter)
 }
+
+// mapStatementTerms applies fn to the subject, object and graph of s,
+// descending into triple terms. The predicate is passed through fn only
+// when the result is still an IRI.
+func mapStatementTerms(s Statement, fn func(Term) Term) Statement {
+	out := Statement{S: rewriteTerm(s.S, fn), P: s.P, O: rewriteTerm(s.O, fn)}
+	if p, ok := fn(s.P).(IRI); ok {
+		out.P = p
+	}
+	if s.G != nil {
+		out.G = rewriteTerm(s.G, fn)
+	}
+	return out
+}
+
+// rewriteTerm applies fn to t, rewriting the components of triple terms recursively.
+func rewriteTerm(t Term, fn func(Term) Term) Term {
+	if tt, ok := t.(TripleTerm); ok {
+		mapped := TripleTerm{S: rewriteTerm(tt.S, fn), P: tt.P, O: rewriteTerm(tt.O, fn)}
+		if p, ok := fn(tt.P).(IRI); ok {
+			mapped.P = p
+		}
+		return fn(mapped)
+	}
+	if t == nil {
+		return nil
+	}
+	return fn(t)
+}
+
+// randomBytes returns n bytes from crypto/rand for random identifiers,
+// panicking if the system random source fails.
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic("rdf: crypto/rand failed: " + err.Error())
+	}
+	return b
+}
@@ -0,0 +1,108 @@
+package rdf
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Compression identifies a compression format for OptCompress.
+type Compression string
+
+const (
+	// CompressionNone writes uncompressed output.
+	CompressionNone Compression = ""
+	// CompressionGzip is gzip (RFC 1952).
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd is Zstandard (RFC 8878).
+	CompressionZstd Compression = "zstd"
+	// CompressionBzip2 is bzip2, which can be read but not written.
+	CompressionBzip2 Compression = "bzip2"
+)
+
+// ErrUnsupportedCompression indicates a compression format that cannot be written.
+var ErrUnsupportedCompression = errors.New("rdf: unsupported compression")
+
+var (
+	gzipMagic      = []byte{0x1f, 0x8b}
+	zstdMagicBytes = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	bzip2Magic     = []byte("BZh")
+)
+
+// OptDecompress makes readers detect gzip, zstd and bzip2 input by its
+// magic bytes and decompress it transparently. Uncompressed input is read
+// as is. Concatenated gzip members and zstd frames are read as one stream.
+func OptDecompress() Option {
+	return func(opts *Options) {
+		opts.Decompress = true
+	}
+}
+
+// OptCompress makes writers compress their output with c (CompressionGzip
+// or CompressionZstd). Flush also flushes the compressor and Close ends the
+// compressed stream without closing the underlying io.Writer. BytesWritten
+// counts compressed bytes.
+func OptCompress(c Compression) Option {
+	return func(opts *Options) {
+		opts.Compress = c
+	}
+}
+
+// detectCompression peeks at the first bytes of r and returns the
+// compression format they start with (CompressionNone if none), and a
+// reader that replays the peeked bytes.
+func detectCompression(r io.Reader) (Compression, io.Reader, error) {
+	var magic [4]byte
+	n, err := io.ReadFull(r, magic[:])
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return CompressionNone, r, err
+	}
+	replay := io.MultiReader(bytes.NewReader(magic[:n]), r)
+	switch sample := magic[:n]; {
+	case bytes.HasPrefix(sample, gzipMagic):
+		return CompressionGzip, replay, nil
+	case bytes.HasPrefix(sample, zstdMagicBytes):
+		return CompressionZstd, replay, nil
+	case bytes.HasPrefix(sample, bzip2Magic):
+		return CompressionBzip2, replay, nil
+	}
+	return CompressionNone, replay, nil
+}
+
+// decompressReader returns a reader for the decompressed content of r,
+// or r itself if it is not compressed.
+func decompressReader(r io.Reader) (io.Reader, error) {
+	compression, r, err := detectCompression(r)
+	if err != nil {
+		return nil, err
+	}
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		return newZstdReader(r), nil
+	case CompressionBzip2:
+		return bzip2.NewReader(r), nil
+	}
+	return r, nil
+}
+
+// compressWriter is the compressor between a writer and its io.Writer.
+type compressWriter interface {
+	io.Writer
+	Flush() error
+	Close() error
+}
+
+func newCompressWriter(w io.Writer, c Compression) (compressWriter, error) {
+	switch c {
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return newZstdWriter(w), nil
+	}
+	return nil, fmt.Errorf("rdf: cannot write %q compression: %w", c, ErrUnsupportedCompression)
+}
@@ -0,0 +1,234 @@
+package rdf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// compressionFixture is the N-Triples document compressed in the fixtures
+// below, made with the zstd and bzip2 command line tools at level 19 and 9.
+func compressionFixture() string {
+	var b strings.Builder
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&b, "<http://example.org/s%d> <http://example.org/p%d> \"literal %d for item %d\" .\n", i, i%7, i*i%97, i)
+	}
+	return b.String()
+}
+
+const (
+	zstdFixture = "KLUv/WSKHKUPALbhSRmATaED0EXQxYUYkT+qSbmTTClFRBM3h64DUgA7AD0ARa1pTHWc52fdmOJy" +
+		"vBwezvRkraxGLPJHoyzj7z/DGX92q6XJbQMIUCxE0aCAcEBQiILBAOJAYoFh8aAokAiBBAiHgAOG" +
+		"hwaDiQMBgYEHCQkFgtRxi+5X0oRjPzF5xHUXnUpj4/h0n13GRXfJKT3miDrpIKU1XxEJDZmPu+ZT" +
+		"e7yqa7N8jevIhNyFJWMCrMm5NhkyIqPoRfOhMehxon48HJNzi6Tcz01n8s3yohb9pEHeOCsf2scf" +
+		"t+VyqFxxMjOZshT1NGbGa/gzAQABikV5RRMa8vHNxx5X3Y/lcNwrkypfqvwpGplGO27yM7zxEJcv" +
+		"1+F4T2iqQtWySMZocDLe+ec1Y9vuqg2BjqgRAL/GHpsBwbeMASEES2giPEIEYkAPhphwYkWVcUTz" +
+		"HNXw3d076nRiNMUMhf16CwD+qIbv3t1xraFcMyjXDMo1QwI8ji1iEcxQXDOU1wzlMkPhObSpDgBy" +
+		"uNYMahnjOtGKrPrBCrg4WCHTYIZqV3OjKWYoeI421RFADpcZylWGco2h8BzdBGcJ4zrRKk20ShWt" +
+		"UnkcLRyVGcpVYNDEOM6JFteHRiHvRDltss14bTJtdm0ybZqba7O0uW5Gbximqn8AzIDBKmoVHgSy" +
+		"zQ=="
+	bzip2Fixture = "QlpoOTFBWSZTWeQvPYcABZdZgAAQUAH/9SPm3EBQA77jnOuOgQ3AaTU9DeqjUAANGg1PQ0m1JTQB" +
+		"GRgGn6qhpmiqgwTCMgYwAEwABMClKoyafqgGmQ000adpFvIthFyfeWMZjDGMYxlhEIiKREIREIkU" +
+		"kQikIREUhEpEQiIQioiIRESIRESIREIRERCEAQJA0BrH13eHYUe9XdT4TcwvHfk5TrLL1aMNWYBE" +
+		"GJpGNWja3eXCaeQ5aisqyHSqDk3o8RNH1SZJoUQTaZM5KAABcHAALAZAAVAIACAAqAZAAWAcADW7" +
+		"Ws1rWta1rv03iL5EXERcRFyJMk0WlUfFMYxbF8b9WZvsWnS8meel3rdUfK6qq+9eZ1q9XnaigCgo" +
+		"cqq7337rfL3z3neLzHXfmO/Mee6znW/digooKByVWvN+Zvd85zzG96jrvzGd948xrV3jaiigooEk" +
+		"9DWrxcWPnOr6b2DHEULnFtW264SSdFQFFFOSq5jN55m98xnrWMuu/MYt5eveXd91sFFAA5Kq+e63" +
+		"rmt88znXJ13vDEvres3d31tRQUUUA5KrN+8vd8vnnce9a7vXt82rYooKAKePde71d73757HXffmc" +
+		"a1vV3NqAKKCinV9Yze9XvfeY7ne3u9Yu7vW1BRRRQD3d++Y85zoAaA10BoDX9A0BrypZPXZTExJN" +
+		"JKZieCaVq0kqSMAqSaQINKklSSJiYmkTMpUpmxqagCau6Bhc7t7zdXG12xqnb3D3lFvLvFtCsWZb" +
+		"x7e73jYOMSp2xTTduDu7oTM0CXs4VirlZk5pMk2ySZJu6SZJtklFwEXsRbRFzEXYRbSL8IuAi5kX" +
+		"Ii7RF0Iugi8CLxEXQRdSLcRYIthF1EW0RdRFtEX+LuSKcKEhyF57Dg=="
+)
+
+func TestDecompressFixtures(t *testing.T) {
+	want := compressionFixture()
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte(want))
+	zw.Close()
+	zstdData, _ := base64.StdEncoding.DecodeString(zstdFixture)
+	bzip2Data, _ := base64.StdEncoding.DecodeString(bzip2Fixture)
+
+	for name, data := range map[string][]byte{"gzip": gz.Bytes(), "zstd": zstdData, "bzip2": bzip2Data, "none": []byte(want)} {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewReader(bytes.NewReader(data), FormatAuto, OptDecompress())
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+			defer r.Close()
+			count := 0
+			for {
+				_, err := r.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				count++
+			}
+			if count != 100 {
+				t.Errorf("read %d statements, want 100", count)
+			}
+		})
+	}
+}
+
+func TestZstdRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := make([]byte, 200<<10)
+	rng.Read(random)
+	var text strings.Builder
+	for text.Len() < 3<<20 {
+		fmt.Fprintf(&text, "<http://example.org/s%d> <http://example.org/p%d> \"%d\" .\n", rng.Intn(10000), rng.Intn(50), rng.Int63())
+	}
+	inputs := map[string][]byte{
+		"empty":  nil,
+		"short":  []byte("abc"),
+		"text":   []byte(text.String()),
+		"random": random,
+		"runs":   bytes.Repeat([]byte("a"), 300<<10),
+		"utf-8":  bytes.Repeat([]byte("h\u00e9llo w\u00f6rld \u2603 \u65e5\u672c "), 10000),
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			var compressed bytes.Buffer
+			w := newZstdWriter(&compressed)
+			for chunk := input; len(chunk) > 0; {
+				n := min(len(chunk), 50000)
+				if _, err := w.Write(chunk[:n]); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+				chunk = chunk[n:]
+				if err := w.Flush(); err != nil {
+					t.Fatalf("Flush() error = %v", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if name == "text" && compressed.Len() > len(input)/3 {
+				t.Errorf("compressed %d bytes to %d, want at most a third", len(input), compressed.Len())
+			}
+			got, err := io.ReadAll(newZstdReader(&compressed))
+			if err != nil {
+				t.Fatalf("decompress error = %v", err)
+			}
+			if !bytes.Equal(got, input) {
+				t.Errorf("round trip changed the data (%d bytes, want %d)", len(got), len(input))
+			}
+		})
+	}
+}
+
+func TestZstdCorruption(t *testing.T) {
+	var compressed bytes.Buffer
+	w := newZstdWriter(&compressed)
+	w.Write([]byte(compressionFixture()))
+	w.Close()
+	data := compressed.Bytes()
+
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)-1] ^= 0xFF
+	if _, err := io.ReadAll(newZstdReader(bytes.NewReader(corrupt))); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("corrupted checksum error = %v, want checksum mismatch", err)
+	}
+	if _, err := io.ReadAll(newZstdReader(bytes.NewReader(data[:len(data)/2]))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated stream error = %v, want io.ErrUnexpectedEOF", err)
+	}
+
+	// Skippable frames and concatenated frames are read as one stream.
+	skippable := []byte{0x50, 0x2A, 0x4D, 0x18, 2, 0, 0, 0, 'x', 'y'}
+	stream := append(append(append([]byte(nil), data...), skippable...), data...)
+	got, err := io.ReadAll(newZstdReader(bytes.NewReader(stream)))
+	if err != nil || string(got) != compressionFixture()+compressionFixture() {
+		t.Errorf("concatenated frames = %d bytes, %v", len(got), err)
+	}
+}
+
+func TestWriterCompress(t *testing.T) {
+	stmts := []Statement{
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}},
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: IRI{Value: "http://example.org/o"}},
+	}
+	for _, c := range []Compression{CompressionGzip, CompressionZstd} {
+		t.Run(string(c), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, FormatTurtle, OptCompress(c), OptWriteBufferSize(16))
+			if err != nil {
+				t.Fatalf("NewWriter() error = %v", err)
+			}
+			for _, s := range stmts {
+				if err := w.Write(s); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if buf.Len() == 0 {
+				t.Error("Flush() did not flush the compressor")
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if got := w.(interface{ BytesWritten() int64 }).BytesWritten(); got != int64(buf.Len()) {
+				t.Errorf("BytesWritten() = %d, want %d", got, buf.Len())
+			}
+
+			r, err := NewReader(&buf, FormatTurtle, OptDecompress())
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+			defer r.Close()
+			for i := range stmts {
+				if _, err := r.Next(); err != nil {
+					t.Fatalf("statement %d: %v", i, err)
+				}
+			}
+			if _, err := r.Next(); err != io.EOF {
+				t.Errorf("Next() after last statement = %v, want io.EOF", err)
+			}
+		})
+	}
+
+	if _, err := NewWriter(io.Discard, FormatTurtle, OptCompress(CompressionBzip2)); !errors.Is(err, ErrUnsupportedCompression) {
+		t.Errorf("bzip2 writer error = %v, want ErrUnsupportedCompression", err)
+	}
+}
+
+func TestWriterCompressDeterministicError(t *testing.T) {
+	defer func(limit int) { canonicalizationWorkLimit = limit }(canonicalizationWorkLimit)
+	canonicalizationWorkLimit = 10000
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatNQuads, OptCompress(CompressionGzip), OptDeterministic())
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	// A complete graph on blank nodes exceeds the canonicalization limit.
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			if i != j {
+				w.Write(Statement{S: BlankNode{ID: fmt.Sprintf("n%d", i)}, P: IRI{Value: "http://example.org/p"}, O: BlankNode{ID: fmt.Sprintf("n%d", j)}})
+			}
+		}
+	}
+	if err := w.Close(); !errors.Is(err, ErrCanonicalizationLimit) {
+		t.Fatalf("Close() error = %v, want ErrCanonicalizationLimit", err)
+	}
+	// The compressor is closed all the same.
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	if _, err := io.ReadAll(zr); err != nil {
+		t.Errorf("reading the gzip stream: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package rdf
+
+import (
+	"cmp"
+	"hash/maphash"
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// ConcurrentGraph is an in-memory graph of triples that is safe for use by
+// multiple goroutines without external synchronization. Triples are spread
+// over shards by subject, each with its own indexes and read-write lock, so
+// ingestion workers adding triples with different subjects rarely wait for
+// each other or for query goroutines.
+//
+// A Match with a bound subject reads one shard; other matches read every
+// shard in turn, each consistently, so a match running alongside Add may
+// see some of the triples added meanwhile but not others. Use a Dataset
+// transaction for changes that readers must see at once.
+//
+// ConcurrentGraph implements QuadMatcher with its triples in the default
+// graph, so MatchBGP evaluates patterns over it.
+type ConcurrentGraph struct {
+	seed   maphash.Seed
+	shards []concurrentShard
+}
+
+type concurrentShard struct {
+	mu    sync.RWMutex
+	state *memoryQuadState
+}
+
+// NewConcurrentGraph returns an empty ConcurrentGraph with the given number
+// of shards, rounded up to a power of two. If shards is 0 or less, it uses
+// four times GOMAXPROCS.
+func NewConcurrentGraph(shards int) *ConcurrentGraph {
+	if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	g := &ConcurrentGraph{seed: maphash.MakeSeed(), shards: make([]concurrentShard, n)}
+	for i := range g.shards {
+		g.shards[i].state = newMemoryQuadState()
+	}
+	return g
+}
+
+// Add adds t to the graph. It fails with ErrInvalidQuad if t has no
+// subject, predicate or object, or has a Variable.
+func (g *ConcurrentGraph) Add(t Triple) error {
+	q := t.ToQuad()
+	if err := checkQuad(q); err != nil {
+		return err
+	}
+	shard := g.shard(q.S)
+	key := memoryQuadKey(q)
+	shard.mu.Lock()
+	shard.state.add(key, q)
+	shard.mu.Unlock()
+	return nil
+}
+
+// Delete removes t from the graph. It fails with ErrInvalidQuad like Add.
+func (g *ConcurrentGraph) Delete(t Triple) error {
+	q := t.ToQuad()
+	if err := checkQuad(q); err != nil {
+		return err
+	}
+	shard := g.shard(q.S)
+	key := memoryQuadKey(q)
+	shard.mu.Lock()
+	shard.state.delete(key)
+	shard.mu.Unlock()
+	return nil
+}
+
+// Contains reports whether the graph holds t.
+func (g *ConcurrentGraph) Contains(t Triple) bool {
+	if t.S == nil {
+		return false
+	}
+	shard := g.shard(t.S)
+	key := memoryQuadKey(t.ToQuad())
+	shard.mu.RLock()
+	_, ok := shard.state.quads[key]
+	shard.mu.RUnlock()
+	return ok
+}
+
+// Len returns the number of triples in the graph.
+func (g *ConcurrentGraph) Len() int {
+	n := 0
+	for i := range g.shards {
+		shard := &g.shards[i]
+		shard.mu.RLock()
+		n += len(shard.state.quads)
+		shard.mu.RUnlock()
+	}
+	return n
+}
+
+// Match returns the triples matching s, p and o; a nil s or o, or an empty
+// p, matches any term. Triples are yielded in the order of their
+// EncodeStatementKey keys, from a copy taken when iteration starts.
+func (g *ConcurrentGraph) Match(s Term, p IRI, o Term) iter.Seq[Triple] {
+	return func(yield func(Triple) bool) {
+		for _, q := range g.match(QuadPattern{S: s, P: p, O: o}) {
+			if !yield(q.ToTriple()) {
+				return
+			}
+		}
+	}
+}
+
+// MatchQuads implements QuadMatcher, holding the triples of the graph in
+// the default graph. It never yields an error.
+func (g *ConcurrentGraph) MatchQuads(p QuadPattern) iter.Seq2[Quad, error] {
+	return func(yield func(Quad, error) bool) {
+		if p.G != nil {
+			return
+		}
+		for _, q := range g.match(p) {
+			if !yield(q, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Reader returns a Reader over the triples of the graph, for writing it
+// with Copy or EncodeAll.
+func (g *ConcurrentGraph) Reader() Reader {
+	return newSeqReader(func(yield func(Statement, error) bool) {
+		for t := range g.Match(nil, IRI{}, nil) {
+			if !yield(t.ToStatement(), nil) {
+				return
+			}
+		}
+	})
+}
+
+func (g *ConcurrentGraph) shard(subject Term) *concurrentShard {
+	return &g.shards[TermHash(subject, g.seed)&uint64(len(g.shards)-1)]
+}
+
+// match returns the quads matching p sorted by key, reading the shard of
+// a bound subject or every shard.
+func (g *ConcurrentGraph) match(p QuadPattern) []Quad {
+	type keyed struct {
+		key  string
+		quad Quad
+	}
+	var found []keyed
+	read := func(shard *concurrentShard) {
+		shard.mu.RLock()
+		for _, key := range shard.state.matchKeys(p) {
+			found = append(found, keyed{key, shard.state.quads[key]})
+		}
+		shard.mu.RUnlock()
+	}
+	if p.S != nil {
+		read(g.shard(p.S))
+	} else {
+		for i := range g.shards {
+			read(&g.shards[i])
+		}
+	}
+	slices.SortFunc(found, func(a, b keyed) int { return cmp.Compare(a.key, b.key) })
+	quads := make([]Quad, len(found))
+	for i, k := range found {
+		quads[i] = k.quad
+	}
+	return quads
+}
@@ -0,0 +1,106 @@
+package rdf
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentGraph(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	g := NewConcurrentGraph(3)
+	if len(g.shards) != 4 {
+		t.Errorf("shards = %d, want 4", len(g.shards))
+	}
+	triples := []Triple{
+		{S: ex("a"), P: ex("p"), O: ex("b")},
+		{S: ex("b"), P: ex("p"), O: ex("c")},
+		{S: ex("c"), P: ex("q"), O: Literal{Lexical: "x"}},
+	}
+	for _, tr := range triples {
+		if err := g.Add(tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.Add(triples[0])
+	if g.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", g.Len())
+	}
+	if !g.Contains(Triple{S: ex("c"), P: ex("q"), O: Literal{Lexical: "x", Datatype: IRI{Value: xsdNamespace + "string"}}}) {
+		t.Error("Contains(equal literal) = false")
+	}
+	got := slices.Collect(g.Match(nil, ex("p"), nil))
+	if !slices.Equal(got, triples[:2]) {
+		t.Errorf("Match(p) = %v, want %v", got, triples[:2])
+	}
+	if got := slices.Collect(g.Match(ex("b"), IRI{}, nil)); len(got) != 1 || got[0] != triples[1] {
+		t.Errorf("Match(b) = %v", got)
+	}
+
+	var solutions int
+	for b, err := range MatchBGP(g, nil,
+		TriplePattern{S: Variable("x"), P: ex("p"), O: Variable("y")},
+		TriplePattern{S: Variable("y"), P: ex("p"), O: Variable("z")}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b[Variable("z")] != ex("c") {
+			t.Errorf("solution %v", b)
+		}
+		solutions++
+	}
+	if solutions != 1 {
+		t.Errorf("MatchBGP solutions = %d, want 1", solutions)
+	}
+
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, FormatNTriples)
+	if n, err := Copy(w, g.Reader()); err != nil || n != 3 {
+		t.Fatalf("Copy() = %d, %v", n, err)
+	}
+	w.Close()
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Errorf("written %d lines:\n%s", lines, buf.String())
+	}
+
+	g.Delete(triples[1])
+	if g.Contains(triples[1]) || g.Len() != 2 {
+		t.Errorf("after Delete: Contains = %v, Len() = %d", g.Contains(triples[1]), g.Len())
+	}
+}
+
+func TestConcurrentGraphParallel(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	g := NewConcurrentGraph(0)
+	const writers, perWriter = 8, 200
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range perWriter {
+				if err := g.Add(Triple{S: ex(fmt.Sprintf("s%d-%d", w, i)), P: ex("p"), O: Literal{Lexical: fmt.Sprint(i)}}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := range perWriter {
+				for range g.Match(ex(fmt.Sprintf("s%d-%d", w, i)), IRI{}, nil) {
+				}
+				g.Len()
+			}
+			for range g.Match(nil, ex("p"), nil) {
+			}
+		}()
+	}
+	wg.Wait()
+	if got := g.Len(); got != writers*perWriter {
+		t.Errorf("Len() = %d, want %d", got, writers*perWriter)
+	}
+}
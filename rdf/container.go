@@ -0,0 +1,125 @@
+package rdf
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidContainer indicates that ReadContainer found no container, a
+// container with several container types, or a membership property with
+// several values.
+var ErrInvalidContainer = errors.New("rdf: invalid container")
+
+// Container is an rdf:Bag, rdf:Seq or rdf:Alt read by ReadContainer.
+type Container struct {
+	Type    IRI    // rdf:Bag, rdf:Seq or rdf:Alt; zero if the container is untyped
+	Members []Term // Objects of the rdf:_n membership properties in index order
+}
+
+// ReadContainer returns the container described by the statements of
+// stmts about container, in any graph: its rdf:Bag, rdf:Seq or rdf:Alt type
+// and the objects of its rdf:_1, rdf:_2, ... membership properties ordered
+// by index, which is how the RDF/XML reader expands rdf:li elements. Gaps in
+// the numbering are skipped. ReadContainer fails with ErrInvalidContainer if
+// container has neither a container type nor members, if it has several
+// container types, or if a membership property has several values.
+func ReadContainer(stmts []Statement, container Term) (Container, error) {
+	var c Container
+	members := make(map[int]Term)
+	for _, s := range stmts {
+		if s.S != container {
+			continue
+		}
+		if typ, ok := s.O.(IRI); ok && s.P.Value == rdfTypeIRI && isContainerType(typ.Value) {
+			if c.Type.Value != "" && c.Type != typ {
+				return Container{}, fmt.Errorf("%w: %s is both %s and %s", ErrInvalidContainer, container, c.Type, typ)
+			}
+			c.Type = typ
+			continue
+		}
+		index, ok := containerMemberIndex(s.P.Value)
+		if !ok {
+			continue
+		}
+		if member, seen := members[index]; seen && member != s.O {
+			return Container{}, fmt.Errorf("%w: %s has several rdf:_%d values", ErrInvalidContainer, container, index)
+		}
+		members[index] = s.O
+	}
+	if c.Type.Value == "" && len(members) == 0 {
+		return Container{}, fmt.Errorf("%w: %s has no container type or members", ErrInvalidContainer, container)
+	}
+	indexes := make([]int, 0, len(members))
+	for index := range members {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		c.Members = append(c.Members, members[index])
+	}
+	return c, nil
+}
+
+// isContainerType reports whether iri is rdf:Bag, rdf:Seq or rdf:Alt.
+func isContainerType(iri string) bool {
+	switch iri {
+	case rdfXMLNS + "Bag", rdfXMLNS + "Seq", rdfXMLNS + "Alt":
+		return true
+	}
+	return false
+}
+
+// containerMemberIndex returns n if iri is the membership property rdf:_n.
+func containerMemberIndex(iri string) (int, bool) {
+	if !strings.HasPrefix(iri, rdfXMLNS) {
+		return 0, false
+	}
+	return parseContainerIndex(iri[len(rdfXMLNS):])
+}
+
+// groupContainers splits quads into the statements written together by
+// OptPreserveContainers. The statements about an rdf:Bag, rdf:Seq or
+// rdf:Alt in a graph form one group, at the position of the first of them,
+// with its container type first and its membership statements last in
+// index order. Every other statement is a group of its own.
+func groupContainers(quads []Quad) [][]Quad {
+	type node struct{ s, g Term }
+	containers := make(map[node]bool)
+	for _, q := range quads {
+		if typ, ok := q.O.(IRI); ok && q.P.Value == rdfTypeIRI && isContainerType(typ.Value) {
+			containers[node{q.S, q.G}] = true
+		}
+	}
+	var groups [][]Quad
+	position := make(map[node]int)
+	for _, q := range quads {
+		key := node{q.S, q.G}
+		if !containers[key] {
+			groups = append(groups, []Quad{q})
+			continue
+		}
+		i, ok := position[key]
+		if !ok {
+			i = len(groups)
+			position[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], q)
+	}
+	rank := func(q Quad) int {
+		if typ, ok := q.O.(IRI); ok && q.P.Value == rdfTypeIRI && isContainerType(typ.Value) {
+			return -1
+		}
+		index, _ := containerMemberIndex(q.P.Value)
+		return index
+	}
+	for _, i := range position {
+		group := groups[i]
+		sort.SliceStable(group, func(a, b int) bool {
+			return rank(group[a]) < rank(group[b])
+		})
+	}
+	return groups
+}
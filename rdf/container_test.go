@@ -0,0 +1,154 @@
+package rdf
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func parseContainerInput(t *testing.T, input string, format Format) []Statement {
+	t.Helper()
+	var stmts []Statement
+	if err := Parse(context.Background(), strings.NewReader(input), format, func(s Statement) error {
+		stmts = append(stmts, s)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return stmts
+}
+
+func TestReadContainer(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">
+  <rdf:Description rdf:about="http://example.org/s">
+    <ex:steps>
+      <rdf:Seq rdf:about="http://example.org/steps">
+        <rdf:li>one</rdf:li>
+        <rdf:li rdf:resource="http://example.org/two"/>
+        <rdf:_10>ten</rdf:_10>
+        <rdf:li>eleven</rdf:li>
+      </rdf:Seq>
+    </ex:steps>
+  </rdf:Description>
+</rdf:RDF>`
+	stmts := parseContainerInput(t, input, FormatRDFXML)
+	c, err := ReadContainer(stmts, IRI{Value: "http://example.org/steps"})
+	if err != nil {
+		t.Fatalf("ReadContainer: %v", err)
+	}
+	if c.Type != (IRI{Value: rdfXMLNS + "Seq"}) {
+		t.Errorf("Type = %v, want rdf:Seq", c.Type)
+	}
+	want := []Term{Literal{Lexical: "one"}, IRI{Value: "http://example.org/two"}, Literal{Lexical: "ten"}, Literal{Lexical: "eleven"}}
+	if !slices.Equal(c.Members, want) {
+		t.Errorf("Members = %v, want %v", c.Members, want)
+	}
+}
+
+func TestReadContainerInvalid(t *testing.T) {
+	bag, seq := IRI{Value: rdfXMLNS + "Bag"}, IRI{Value: rdfXMLNS + "Seq"}
+	typ, first := IRI{Value: rdfTypeIRI}, IRI{Value: rdfXMLNS + "_1"}
+	c := BlankNode{ID: "c"}
+	cases := map[string][]Statement{
+		"two types":     {NewTriple(c, typ, bag), NewTriple(c, typ, seq)},
+		"two members":   {NewTriple(c, first, Literal{Lexical: "a"}), NewTriple(c, first, Literal{Lexical: "b"})},
+		"not container": {NewTriple(c, IRI{Value: "http://example.org/p"}, Literal{Lexical: "a"})},
+	}
+	for name, stmts := range cases {
+		if _, err := ReadContainer(stmts, c); !errors.Is(err, ErrInvalidContainer) {
+			t.Errorf("%s: err = %v, want ErrInvalidContainer", name, err)
+		}
+	}
+	// The same member in several graphs is not a conflict.
+	stmts := []Statement{NewTriple(c, first, Literal{Lexical: "a"}), NewQuad(c, first, Literal{Lexical: "a"}, IRI{Value: "http://example.org/g"})}
+	if got, err := ReadContainer(stmts, c); err != nil || len(got.Members) != 1 || got.Type.Value != "" {
+		t.Errorf("untyped container = %v, %v", got, err)
+	}
+}
+
+func TestPreserveContainersTurtle(t *testing.T) {
+	bag := IRI{Value: "http://example.org/bag"}
+	member := func(n string, o Term) Statement {
+		return NewTriple(bag, IRI{Value: rdfXMLNS + "_" + n}, o)
+	}
+	other := NewTriple(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, bag)
+	stmts := []Statement{
+		member("10", Literal{Lexical: "ten"}),
+		other,
+		member("2", Literal{Lexical: "two"}),
+		NewTriple(bag, IRI{Value: rdfTypeIRI}, IRI{Value: rdfXMLNS + "Bag"}),
+		NewTriple(bag, IRI{Value: "http://example.org/label"}, Literal{Lexical: "numbers"}),
+	}
+	got := writeStatements(t, FormatTurtle, stmts, OptPreserveContainers())
+	want := `<http://example.org/bag> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/1999/02/22-rdf-syntax-ns#Bag> ;
+    <http://example.org/label> "numbers" ;
+    <http://www.w3.org/1999/02/22-rdf-syntax-ns#_2> "two" ;
+    <http://www.w3.org/1999/02/22-rdf-syntax-ns#_10> "ten" .
+<http://example.org/s> <http://example.org/p> <http://example.org/bag> .
+`
+	if got != want {
+		t.Errorf("output:\n%s\nwant:\n%s", got, want)
+	}
+	roundTrip := parseContainerInput(t, got, FormatTurtle)
+	c, err := ReadContainer(roundTrip, bag)
+	if err != nil || !slices.Equal(c.Members, []Term{Literal{Lexical: "two"}, Literal{Lexical: "ten"}}) {
+		t.Errorf("round trip = %v, %v", c, err)
+	}
+}
+
+func TestPreserveContainersRDFXML(t *testing.T) {
+	input := `<http://example.org/s> <http://example.org/p> _:alt .
+_:alt <http://www.w3.org/1999/02/22-rdf-syntax-ns#_2> "b" .
+_:alt <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/1999/02/22-rdf-syntax-ns#Alt> .
+_:alt <http://www.w3.org/1999/02/22-rdf-syntax-ns#_1> "a" .
+`
+	stmts := parseContainerInput(t, input, FormatNTriples)
+	got := writeStatements(t, FormatRDFXML, stmts, OptPreserveContainers())
+	if !strings.Contains(got, "<rdf:Alt ") || strings.Index(got, "<rdf:_1>") > strings.Index(got, "<rdf:_2>") {
+		t.Errorf("container not written as rdf:Alt with ordered members:\n%s", got)
+	}
+	roundTrip := parseContainerInput(t, got, FormatRDFXML)
+	alt := roundTrip[slices.IndexFunc(roundTrip, func(s Statement) bool { return s.P.Value == "http://example.org/p" })].O
+	c, err := ReadContainer(roundTrip, alt)
+	if err != nil || c.Type.Value != rdfXMLNS+"Alt" || !slices.Equal(c.Members, []Term{Literal{Lexical: "a"}, Literal{Lexical: "b"}}) {
+		t.Errorf("round trip = %v, %v", c, err)
+	}
+}
+
+func TestRDFXMLNestedNodeElement(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">
+  <rdf:Description rdf:about="http://example.org/s">
+    <ex:knows>
+      <ex:Person rdf:about="http://example.org/o">
+        <ex:name>O</ex:name>
+      </ex:Person>
+    </ex:knows>
+    <ex:tags><rdf:Bag><rdf:li>x</rdf:li></rdf:Bag></ex:tags>
+  </rdf:Description>
+</rdf:RDF>`
+	got := writeStatements(t, FormatNTriples, parseContainerInput(t, input, FormatRDFXML))
+	want := `<http://example.org/o> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/Person> .
+<http://example.org/o> <http://example.org/name> "O" .
+<http://example.org/s> <http://example.org/knows> <http://example.org/o> .
+_:b1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/1999/02/22-rdf-syntax-ns#Bag> .
+_:b1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#_1> "x" .
+<http://example.org/s> <http://example.org/tags> _:b1 .
+`
+	if got != want {
+		t.Errorf("output:\n%s\nwant:\n%s", got, want)
+	}
+	for _, bad := range []string{
+		`<ex:p>text<rdf:Description/></ex:p>`,
+		`<ex:p><rdf:Description/><rdf:Description/></ex:p>`,
+		`<ex:p><rdf:li>x</rdf:li></ex:p>`,
+	} {
+		doc := `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/"><rdf:Description rdf:about="http://example.org/s">` + bad + `</rdf:Description></rdf:RDF>`
+		if err := Parse(context.Background(), strings.NewReader(doc), FormatRDFXML, func(Statement) error { return nil }); err == nil {
+			t.Errorf("%s: expected error", bad)
+		}
+	}
+}
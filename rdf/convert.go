@@ -0,0 +1,143 @@
+package rdf
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Copy writes every statement from src to dst until src returns io.EOF, then
+// flushes dst. It returns the number of statements written. Neither src nor
+// dst is closed.
+func Copy(dst Writer, src Reader) (int64, error) {
+	return copyStatements(dst, src, nil)
+}
+
+// FidelityIssue identifies a kind of information lost or transformed when
+// statements are written to a format that cannot represent them exactly.
+type FidelityIssue string
+
+const (
+	// FidelityGraphNameDropped: a named-graph statement was written to a
+	// triple-only format and its graph name was discarded.
+	FidelityGraphNameDropped FidelityIssue = "graph-name-dropped"
+	// FidelityTripleTermDowngraded: a triple term was written to a format
+	// without RDF 1.2 triple terms and was emitted as a plain string literal.
+	FidelityTripleTermDowngraded FidelityIssue = "triple-term-downgraded"
+	// FidelityDatatypeDropped: a literal carrying both a language tag and a
+	// datatype other than rdf:langString lost its datatype.
+	FidelityDatatypeDropped FidelityIssue = "datatype-dropped"
+	// FidelityLanguageDropped: a language-tagged literal lost its language tag.
+	FidelityLanguageDropped FidelityIssue = "language-dropped"
+)
+
+// FidelityReport summarizes what a conversion lost or transformed.
+type FidelityReport struct {
+	// Format is the destination format the report was computed for.
+	Format Format
+	// Statements is the number of statements written.
+	Statements int64
+	// Issues counts affected statements per issue kind.
+	Issues map[FidelityIssue]int64
+	// Examples holds the first affected source statement per issue kind.
+	Examples map[FidelityIssue]Statement
+}
+
+// Lossless reports whether the conversion preserved every statement exactly.
+func (r *FidelityReport) Lossless() bool {
+	return len(r.Issues) == 0
+}
+
+// String returns a one-line summary such as
+// "turtle: 10 statements, graph-name-dropped=3".
+func (r *FidelityReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d statements", r.Format, r.Statements)
+	if r.Lossless() {
+		b.WriteString(", lossless")
+		return b.String()
+	}
+	kinds := make([]string, 0, len(r.Issues))
+	for kind := range r.Issues {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Fprintf(&b, ", %s=%d", kind, r.Issues[FidelityIssue(kind)])
+	}
+	return b.String()
+}
+
+func (r *FidelityReport) record(issue FidelityIssue, s Statement) {
+	if _, ok := r.Examples[issue]; !ok {
+		r.Examples[issue] = s
+	}
+	r.Issues[issue]++
+}
+
+// CopyWithReport behaves like Copy and additionally reports information the
+// destination format dstFormat cannot represent. dstFormat must match the
+// format dst was created with.
+//
+// Pipelines can assert lossless conversion with:
+//
+//	report, err := rdf.CopyWithReport(w, rdf.FormatTurtle, r)
+//	if err == nil && !report.Lossless() {
+//		return fmt.Errorf("lossy conversion: %s", report)
+//	}
+func CopyWithReport(dst Writer, dstFormat Format, src Reader) (*FidelityReport, error) {
+	report := &FidelityReport{
+		Format:   dstFormat,
+		Issues:   make(map[FidelityIssue]int64),
+		Examples: make(map[FidelityIssue]Statement),
+	}
+	n, err := copyStatements(dst, src, func(s Statement) {
+		inspectFidelity(report, dstFormat, s)
+	})
+	report.Statements = n
+	return report, err
+}
+
+func copyStatements(dst Writer, src Reader, inspect func(Statement)) (int64, error) {
+	var n int64
+	for {
+		stmt, err := src.Next()
+		if err == io.EOF {
+			return n, dst.Flush()
+		}
+		if err != nil {
+			return n, err
+		}
+		if err := dst.Write(stmt); err != nil {
+			return n, err
+		}
+		if inspect != nil {
+			inspect(stmt)
+		}
+		n++
+	}
+}
+
+// inspectFidelity records the issues writing s to format would cause, based
+// on what each encoder in this package can express.
+func inspectFidelity(report *FidelityReport, format Format, s Statement) {
+	if s.G != nil && !format.IsQuadFormat() {
+		report.record(FidelityGraphNameDropped, s)
+	}
+	if format == FormatJSONLD {
+		if _, ok := s.O.(TripleTerm); ok {
+			report.record(FidelityTripleTermDowngraded, s)
+		}
+	}
+	lit, ok := s.O.(Literal)
+	if !ok || lit.Lang == "" || lit.Datatype.Value == "" {
+		return
+	}
+	if lit.Datatype.Value != rdfLangStringIRI && lit.Datatype.Value != rdfDirLangStringIRI {
+		report.record(FidelityDatatypeDropped, s)
+	}
+	if format == FormatJSONLD {
+		report.record(FidelityLanguageDropped, s)
+	}
+}
@@ -0,0 +1,81 @@
+package rdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCopy_NQuadsToNQuads(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"v\" <http://example.org/g> .\n"
+	reader, err := NewReader(strings.NewReader(input), FormatNQuads)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Close()
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, FormatNQuads)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	n, err := Copy(writer, reader)
+	if err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	if n != 1 || !strings.Contains(buf.String(), "<http://example.org/g>") {
+		t.Fatalf("unexpected copy result n=%d output=%q", n, buf.String())
+	}
+}
+
+func TestCopyWithReport_Lossless(t *testing.T) {
+	stmts := []Statement{
+		NewQuad(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "v"}, IRI{Value: "http://example.org/g"}),
+	}
+	var buf bytes.Buffer
+	writer, _ := NewWriter(&buf, FormatTriG)
+	report, err := CopyWithReport(writer, FormatTriG, &stubStatementReader{stmts: stmts})
+	if err != nil {
+		t.Fatalf("CopyWithReport: %v", err)
+	}
+	if !report.Lossless() || report.Statements != 1 {
+		t.Fatalf("expected lossless report, got %s", report)
+	}
+	if got := report.String(); got != "trig: 1 statements, lossless" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+}
+
+func TestCopyWithReport_Losses(t *testing.T) {
+	s := IRI{Value: "http://example.org/s"}
+	p := IRI{Value: "http://example.org/p"}
+	stmts := []Statement{
+		NewQuad(s, p, Literal{Lexical: "v"}, IRI{Value: "http://example.org/g"}),
+		NewTriple(s, p, TripleTerm{S: s, P: p, O: Literal{Lexical: "x"}}),
+		NewTriple(s, p, Literal{Lexical: "x", Lang: "en", Datatype: IRI{Value: "http://www.w3.org/2001/XMLSchema#string"}}),
+	}
+	var buf bytes.Buffer
+	writer, _ := NewWriter(&buf, FormatJSONLD)
+	report, err := CopyWithReport(writer, FormatJSONLD, &stubStatementReader{stmts: stmts})
+	if err != nil {
+		t.Fatalf("CopyWithReport: %v", err)
+	}
+	if report.Lossless() {
+		t.Fatalf("expected lossy report")
+	}
+	for issue, want := range map[FidelityIssue]int64{
+		FidelityGraphNameDropped:     1,
+		FidelityTripleTermDowngraded: 1,
+		FidelityDatatypeDropped:      1,
+		FidelityLanguageDropped:      1,
+	} {
+		if report.Issues[issue] != want {
+			t.Fatalf("%s: expected %d, got %d (%s)", issue, want, report.Issues[issue], report)
+		}
+	}
+	if report.Examples[FidelityGraphNameDropped] != stmts[0] {
+		t.Fatalf("unexpected example %v", report.Examples[FidelityGraphNameDropped])
+	}
+	if got := report.String(); got != "jsonld: 3 statements, datatype-dropped=1, graph-name-dropped=1, language-dropped=1, triple-term-downgraded=1" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+}
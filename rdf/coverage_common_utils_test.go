@@ -10,10 +10,10 @@ func TestResolveIRI_Simple(t *testing.T) {
 	base := "http://example.org/"
 	relative := "path"
 
-	result := resolveIRI(base, relative)
+	result := ResolveIRI(base, relative)
 	expected := "http://example.org/path"
 	if result != expected {
-		t.Errorf("resolveIRI = %q, want %q", result, expected)
+		t.Errorf("ResolveIRI = %q, want %q", result, expected)
 	}
 }
 
@@ -21,9 +21,9 @@ func TestResolveIRI_Absolute(t *testing.T) {
 	base := "http://example.org/"
 	relative := "http://other.org/path"
 
-	result := resolveIRI(base, relative)
+	result := ResolveIRI(base, relative)
 	if result != relative {
-		t.Errorf("resolveIRI should return absolute IRI unchanged: %q", result)
+		t.Errorf("ResolveIRI should return absolute IRI unchanged: %q", result)
 	}
 }
 
@@ -31,9 +31,9 @@ func TestResolveIRI_NoBase(t *testing.T) {
 	base := ""
 	relative := "http://example.org/path"
 
-	result := resolveIRI(base, relative)
+	result := ResolveIRI(base, relative)
 	if result != relative {
-		t.Errorf("resolveIRI should return relative unchanged when base is empty: %q", result)
+		t.Errorf("ResolveIRI should return relative unchanged when base is empty: %q", result)
 	}
 }
 
@@ -41,10 +41,10 @@ func TestResolveIRI_RelativeWithSlash(t *testing.T) {
 	base := "http://example.org/"
 	relative := "/path"
 
-	result := resolveIRI(base, relative)
+	result := ResolveIRI(base, relative)
 	expected := "http://example.org/path"
 	if result != expected {
-		t.Errorf("resolveIRI = %q, want %q", result, expected)
+		t.Errorf("ResolveIRI = %q, want %q", result, expected)
 	}
 }
 
@@ -52,10 +52,10 @@ func TestResolveIRI_BaseWithPath(t *testing.T) {
 	base := "http://example.org/base/"
 	relative := "path"
 
-	result := resolveIRI(base, relative)
+	result := ResolveIRI(base, relative)
 	expected := "http://example.org/base/path"
 	if result != expected {
-		t.Errorf("resolveIRI = %q, want %q", result, expected)
+		t.Errorf("ResolveIRI = %q, want %q", result, expected)
 	}
 }
 
@@ -63,10 +63,10 @@ func TestResolveIRI_BaseWithoutSlash(t *testing.T) {
 	base := "http://example.org/base"
 	relative := "path"
 
-	result := resolveIRI(base, relative)
+	result := ResolveIRI(base, relative)
 	expected := "http://example.org/path"
 	if result != expected {
-		t.Errorf("resolveIRI = %q, want %q", result, expected)
+		t.Errorf("ResolveIRI = %q, want %q", result, expected)
 	}
 }
 
@@ -74,10 +74,10 @@ func TestResolveIRI_RelativeWithDot(t *testing.T) {
 	base := "http://example.org/base/"
 	relative := "./path"
 
-	result := resolveIRI(base, relative)
+	result := ResolveIRI(base, relative)
 	expected := "http://example.org/base/path"
 	if result != expected {
-		t.Errorf("resolveIRI = %q, want %q", result, expected)
+		t.Errorf("ResolveIRI = %q, want %q", result, expected)
 	}
 }
 
@@ -85,10 +85,10 @@ func TestResolveIRI_RelativeWithDotDot(t *testing.T) {
 	base := "http://example.org/base/sub/"
 	relative := "../path"
 
-	result := resolveIRI(base, relative)
+	result := ResolveIRI(base, relative)
 	expected := "http://example.org/base/path"
 	if result != expected {
-		t.Errorf("resolveIRI = %q, want %q", result, expected)
+		t.Errorf("ResolveIRI = %q, want %q", result, expected)
 	}
 }
 
@@ -542,12 +542,12 @@ func TestNewReader_FormatAuto_Failure(t *testing.T) {
 
 func TestParseError_FormatExcerpt_NoColumn(t *testing.T) {
 	err := &ParseError{
-		Format:    "turtle",
-		Statement: "short statement",
-		Line:      1,
-		Column:    0, // No column
-		Offset:    10,
-		Err:       errors.New("test error"),
+		Format:     "turtle",
+		Statement:  "short statement",
+		Line:       1,
+		Column:     0, // No column
+		ByteOffset: 10,
+		Err:        errors.New("test error"),
 	}
 
 	msg := err.Error()
@@ -562,12 +562,12 @@ func TestParseError_FormatExcerpt_NoColumn(t *testing.T) {
 func TestParseError_FormatExcerpt_LongStatement(t *testing.T) {
 	longStmt := strings.Repeat("a", 200)
 	err := &ParseError{
-		Format:    "turtle",
-		Statement: longStmt,
-		Line:      1,
-		Column:    0,
-		Offset:    10,
-		Err:       errors.New("test error"),
+		Format:     "turtle",
+		Statement:  longStmt,
+		Line:       1,
+		Column:     0,
+		ByteOffset: 10,
+		Err:        errors.New("test error"),
 	}
 
 	msg := err.Error()
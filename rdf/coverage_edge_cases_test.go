@@ -326,16 +326,16 @@ func TestDetectQuadFormat_Comments(t *testing.T) {
 // Test IRI resolution edge cases
 
 func TestResolveIRI_EmptyBase(t *testing.T) {
-	got := resolveIRI("", "path")
+	got := ResolveIRI("", "path")
 	if got == "" {
-		t.Error("resolveIRI should handle empty base")
+		t.Error("ResolveIRI should handle empty base")
 	}
 }
 
 func TestResolveIRI_EmptyRelative(t *testing.T) {
-	got := resolveIRI("http://example.org/base/", "")
+	got := ResolveIRI("http://example.org/base/", "")
 	if got == "" {
-		t.Error("resolveIRI should handle empty relative")
+		t.Error("ResolveIRI should handle empty relative")
 	}
 }
 
@@ -448,11 +448,11 @@ func TestWrapParseErrorWithPosition_NilError(t *testing.T) {
 
 func TestParseError_Error_NoPosition(t *testing.T) {
 	err := &ParseError{
-		Format: "turtle",
-		Err:    errors.New("test error"),
-		Line:   0,
-		Column: 0,
-		Offset: -1,
+		Format:     "turtle",
+		Err:        errors.New("test error"),
+		Line:       0,
+		Column:     0,
+		ByteOffset: -1,
 	}
 
 	msg := err.Error()
@@ -83,12 +83,12 @@ func TestCode_Nil(t *testing.T) {
 
 func TestParseError_Error(t *testing.T) {
 	err := &ParseError{
-		Format:    "turtle",
-		Statement: "test statement",
-		Line:      5,
-		Column:    10,
-		Offset:    100,
-		Err:       errors.New("parse error"),
+		Format:     "turtle",
+		Statement:  "test statement",
+		Line:       5,
+		Column:     10,
+		ByteOffset: 100,
+		Err:        errors.New("parse error"),
 	}
 	msg := err.Error()
 	if !strings.Contains(msg, "turtle") {
@@ -115,10 +115,10 @@ func TestParseError_ErrorWithPosition(t *testing.T) {
 
 func TestParseError_ErrorWithOffset(t *testing.T) {
 	err := &ParseError{
-		Format:    "turtle",
-		Statement: "test statement",
-		Offset:    100,
-		Err:       errors.New("parse error"),
+		Format:     "turtle",
+		Statement:  "test statement",
+		ByteOffset: 100,
+		Err:        errors.New("parse error"),
 	}
 	msg := err.Error()
 	if !strings.Contains(msg, "offset 100") {
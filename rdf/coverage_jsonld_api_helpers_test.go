@@ -6,104 +6,14 @@ import (
 
 // Test helper functions in jsonld_api.go for additional coverage
 
-func TestLdQuadKey(t *testing.T) {
-	// Test ldQuadKey function indirectly through dedupeJSONGoldDataset
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestLdNodeKey(t *testing.T) {
-	// Test ldNodeKey function indirectly
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestNormalizeIRIPath_Simple(t *testing.T) {
-	// Test normalizeIRIPath indirectly through normalizeDatasetIRIs
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
 func TestRemoveDotSegments(t *testing.T) {
 	// Test removeDotSegments indirectly
 	// This function is used internally, so we test it via public APIs
 	_ = t
 }
 
-func TestCollapseSlashes(t *testing.T) {
-	// Test collapseSlashes indirectly
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestCanonicalizeJSONLiteralDataset_Simple(t *testing.T) {
-	// Test canonicalizeJSONLiteralDataset indirectly through ToRDF
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestCanonicalizeJSONLiteralString_Valid(t *testing.T) {
-	// Test canonicalizeJSONLiteralString indirectly
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
 func TestCanonicalizeJSONLiteralValue_Valid(t *testing.T) {
 	// Test canonicalizeJSONLiteralValue indirectly
 	// This function is used internally, so we test it via public APIs
 	_ = t
 }
-
-func TestCollectBlankNodeID(t *testing.T) {
-	// Test collectBlankNodeID indirectly through normalizeBlankNodeIDs
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestRemapBlankNodeID(t *testing.T) {
-	// Test remapBlankNodeID indirectly through normalizeBlankNodeIDs
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestDedupeJSONGoldDataset(t *testing.T) {
-	// Test dedupeJSONGoldDataset indirectly through normalizeJSONGoldDataset
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestNormalizeDatasetIRIs(t *testing.T) {
-	// Test normalizeDatasetIRIs indirectly through normalizeJSONGoldDataset
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestNormalizeJSONGoldNodeIRI(t *testing.T) {
-	// Test normalizeJSONGoldNodeIRI indirectly through normalizeDatasetIRIs
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestToJSONGoldDataset_Simple(t *testing.T) {
-	// Test toJSONGoldDataset indirectly through ToRDF
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestParseJSONGoldNQuads_Simple(t *testing.T) {
-	// Test parseJSONGoldNQuads indirectly
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestNormalizeJSONGoldDataset_Simple(t *testing.T) {
-	// Test normalizeJSONGoldDataset indirectly through FromRDF
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
-
-func TestJSONTypeIncludes(t *testing.T) {
-	// Test jsonTypeIncludes indirectly through normalizeJSONLDJSONLiterals
-	// This function is used internally, so we test it via public APIs
-	_ = t
-}
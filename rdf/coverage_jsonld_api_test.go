@@ -258,43 +258,6 @@ func TestJSONLDProcessor_FromRDF_EmptyQuads(t *testing.T) {
 	}
 }
 
-func TestParseNQuadsString_Simple(t *testing.T) {
-	ctx := context.Background()
-	nquads := "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n"
-
-	quads, err := parseNQuadsString(ctx, nquads)
-	if err != nil {
-		t.Fatalf("parseNQuadsString failed: %v", err)
-	}
-	if len(quads) != 1 {
-		t.Errorf("Expected 1 quad, got %d", len(quads))
-	}
-}
-
-func TestParseNQuadsString_Empty(t *testing.T) {
-	ctx := context.Background()
-	nquads := ""
-
-	quads, err := parseNQuadsString(ctx, nquads)
-	if err != nil {
-		t.Fatalf("parseNQuadsString failed with empty input: %v", err)
-	}
-	if len(quads) != 0 {
-		t.Errorf("Expected 0 quads, got %d", len(quads))
-	}
-}
-
-func TestParseNQuadsString_ContextCancellation(t *testing.T) {
-	ctx, cancel := context.WithCancel(context.Background())
-	cancel()
-	nquads := "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n"
-
-	_, err := parseNQuadsString(ctx, nquads)
-	if err == nil {
-		t.Fatal("Expected context cancellation error")
-	}
-}
-
 func TestQuadsToNQuads_Simple(t *testing.T) {
 	quads := []Quad{
 		{
@@ -427,243 +390,6 @@ func TestJSONGoldDocumentLoader_WithoutInner(t *testing.T) {
 	_ = err
 }
 
-func TestValidateJSONLiteralQuads_Valid(t *testing.T) {
-	quads := []Quad{
-		{
-			S: IRI{Value: "http://example.org/s"},
-			P: IRI{Value: "http://example.org/p"},
-			O: Literal{
-				Lexical:  `{"key": "value"}`,
-				Datatype: IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#JSON"},
-			},
-		},
-	}
-
-	err := validateJSONLiteralQuads(quads)
-	if err != nil {
-		t.Fatalf("validateJSONLiteralQuads failed with valid JSON literal: %v", err)
-	}
-}
-
-func TestValidateJSONLiteralQuads_InvalidJSON(t *testing.T) {
-	quads := []Quad{
-		{
-			S: IRI{Value: "http://example.org/s"},
-			P: IRI{Value: "http://example.org/p"},
-			O: Literal{
-				Lexical:  `{invalid json}`,
-				Datatype: IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#JSON"},
-			},
-		},
-	}
-
-	err := validateJSONLiteralQuads(quads)
-	if err == nil {
-		t.Fatal("Expected error for invalid JSON literal")
-	}
-}
-
-func TestValidateJSONLiteralQuads_NonJSONLiteral(t *testing.T) {
-	quads := []Quad{
-		{
-			S: IRI{Value: "http://example.org/s"},
-			P: IRI{Value: "http://example.org/p"},
-			O: Literal{
-				Lexical:  "plain text",
-				Datatype: IRI{Value: "http://www.w3.org/2001/XMLSchema#string"},
-			},
-		},
-	}
-
-	err := validateJSONLiteralQuads(quads)
-	if err != nil {
-		t.Fatalf("validateJSONLiteralQuads should skip non-JSON literals: %v", err)
-	}
-}
-
-func TestNormalizeJSONLDJSONLiterals_Simple(t *testing.T) {
-	input := map[string]interface{}{
-		"@value": `{"key": "value"}`,
-		"@type":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#JSON",
-	}
-
-	result, err := normalizeJSONLDJSONLiterals(input)
-	if err != nil {
-		t.Fatalf("normalizeJSONLDJSONLiterals failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("normalizeJSONLDJSONLiterals returned nil")
-	}
-}
-
-func TestNormalizeJSONLDJSONLiterals_Array(t *testing.T) {
-	input := []interface{}{
-		map[string]interface{}{
-			"@value": `{"key": "value"}`,
-			"@type":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#JSON",
-		},
-	}
-
-	result, err := normalizeJSONLDJSONLiterals(input)
-	if err != nil {
-		t.Fatalf("normalizeJSONLDJSONLiterals failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("normalizeJSONLDJSONLiterals returned nil")
-	}
-}
-
-func TestNormalizeJSONLDJSONLiterals_NonJSON(t *testing.T) {
-	input := map[string]interface{}{
-		"@value": "plain text",
-		"@type":  "http://www.w3.org/2001/XMLSchema#string",
-	}
-
-	result, err := normalizeJSONLDJSONLiterals(input)
-	if err != nil {
-		t.Fatalf("normalizeJSONLDJSONLiterals failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("normalizeJSONLDJSONLiterals returned nil")
-	}
-}
-
-func TestNormalizeJSONLDJSONLiterals_Primitive(t *testing.T) {
-	input := "plain string"
-
-	result, err := normalizeJSONLDJSONLiterals(input)
-	if err != nil {
-		t.Fatalf("normalizeJSONLDJSONLiterals failed: %v", err)
-	}
-	if result != input {
-		t.Errorf("normalizeJSONLDJSONLiterals changed primitive value")
-	}
-}
-
-func TestParseJSONLiteralValue_String(t *testing.T) {
-	value := `{"key": "value"}`
-
-	result, err := parseJSONLiteralValue(value)
-	if err != nil {
-		t.Fatalf("parseJSONLiteralValue failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("parseJSONLiteralValue returned nil")
-	}
-}
-
-func TestParseJSONLiteralValue_NonString(t *testing.T) {
-	value := 123
-
-	result, err := parseJSONLiteralValue(value)
-	if err != nil {
-		t.Fatalf("parseJSONLiteralValue failed: %v", err)
-	}
-	if result != value {
-		t.Errorf("parseJSONLiteralValue changed non-string value")
-	}
-}
-
-func TestParseJSONLiteralValue_InvalidJSON(t *testing.T) {
-	value := `{invalid json}`
-
-	_, err := parseJSONLiteralValue(value)
-	if err == nil {
-		t.Fatal("Expected error for invalid JSON")
-	}
-}
-
-func TestReplaceJSONLiteralValues_Simple(t *testing.T) {
-	input := map[string]interface{}{
-		"@type":  "@json",
-		"@value": map[string]interface{}{"key": "value"},
-	}
-
-	result, err := replaceJSONLiteralValues(input)
-	if err != nil {
-		t.Fatalf("replaceJSONLiteralValues failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("replaceJSONLiteralValues returned nil")
-	}
-}
-
-func TestReplaceJSONLiteralValues_Array(t *testing.T) {
-	input := []interface{}{
-		map[string]interface{}{
-			"@type":  "@json",
-			"@value": map[string]interface{}{"key": "value"},
-		},
-	}
-
-	result, err := replaceJSONLiteralValues(input)
-	if err != nil {
-		t.Fatalf("replaceJSONLiteralValues failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("replaceJSONLiteralValues returned nil")
-	}
-}
-
-func TestReplaceJSONLiteralValues_NonJSON(t *testing.T) {
-	input := map[string]interface{}{
-		"@type":  "http://www.w3.org/2001/XMLSchema#string",
-		"@value": "plain text",
-	}
-
-	result, err := replaceJSONLiteralValues(input)
-	if err != nil {
-		t.Fatalf("replaceJSONLiteralValues failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("replaceJSONLiteralValues returned nil")
-	}
-}
-
-func TestPrepareJSONLDForToRDF_Simple(t *testing.T) {
-	ctx := context.Background()
-	input := map[string]interface{}{
-		"@context": map[string]interface{}{
-			"ex": "http://example.org/",
-		},
-		"ex:name": "Test",
-	}
-
-	opts := JSONLDOptions{
-		BaseIRI: "http://example.org/",
-	}
-
-	result, err := prepareJSONLDForToRDF(ctx, input, opts)
-	if err != nil {
-		t.Fatalf("prepareJSONLDForToRDF failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("prepareJSONLDForToRDF returned nil")
-	}
-}
-
-func TestExpandJSONLDInput_Simple(t *testing.T) {
-	ctx := context.Background()
-	input := map[string]interface{}{
-		"@context": map[string]interface{}{
-			"ex": "http://example.org/",
-		},
-		"ex:name": "Test",
-	}
-
-	opts := JSONLDOptions{
-		BaseIRI: "http://example.org/",
-	}
-
-	result, err := expandJSONLDInput(ctx, input, opts)
-	if err != nil {
-		t.Fatalf("expandJSONLDInput failed: %v", err)
-	}
-	if result == nil {
-		t.Fatal("expandJSONLDInput returned nil")
-	}
-}
-
 func TestJSONLDProcessor_ToRDF_UnexpectedResultType(t *testing.T) {
 	// This tests the error path when ToRDF returns unexpected type
 	// Note: This is hard to trigger without mocking, but we test the error handling
@@ -704,28 +430,6 @@ func TestJSONLDProcessor_ToRDF_SerializationError(t *testing.T) {
 	_ = err
 }
 
-func TestCanonicalizeJSONLiteralDataset_NilDataset(t *testing.T) {
-	// Test nil dataset handling
-	err := canonicalizeJSONLiteralDataset(nil)
-	if err != nil {
-		t.Fatalf("canonicalizeJSONLiteralDataset should handle nil dataset: %v", err)
-	}
-}
-
-func TestCanonicalizeJSONLiteralDataset_InvalidQuad(t *testing.T) {
-	// Test error path for invalid quad
-	// This is tested indirectly through ToRDF
-	_ = t
-}
-
-func TestCanonicalizeJSONLiteralString_InvalidJSON(t *testing.T) {
-	// Test error path for invalid JSON
-	_, err := canonicalizeJSONLiteralString("{invalid json}")
-	if err == nil {
-		t.Fatal("Expected error for invalid JSON literal")
-	}
-}
-
 func TestCanonicalizeJSONLiteralValue_Direct(t *testing.T) {
 	value := map[string]interface{}{
 		"key": "value",
@@ -740,84 +444,6 @@ func TestCanonicalizeJSONLiteralValue_Direct(t *testing.T) {
 	}
 }
 
-func TestJSONTypeIncludes_StringMatch(t *testing.T) {
-	result := jsonTypeIncludes("@json", "@json", "other")
-	if !result {
-		t.Error("jsonTypeIncludes should match string")
-	}
-}
-
-func TestJSONTypeIncludes_StringNoMatch(t *testing.T) {
-	result := jsonTypeIncludes("other", "@json", "test")
-	if result {
-		t.Error("jsonTypeIncludes should not match different string")
-	}
-}
-
-func TestJSONTypeIncludes_ArrayMatch(t *testing.T) {
-	result := jsonTypeIncludes([]interface{}{"@json", "other"}, "@json", "test")
-	if !result {
-		t.Error("jsonTypeIncludes should match array element")
-	}
-}
-
-func TestJSONTypeIncludes_ArrayNoMatch(t *testing.T) {
-	result := jsonTypeIncludes([]interface{}{"other1", "other2"}, "@json", "test")
-	if result {
-		t.Error("jsonTypeIncludes should not match array with no matching elements")
-	}
-}
-
-func TestJSONTypeIncludes_ArrayWithNonString(t *testing.T) {
-	result := jsonTypeIncludes([]interface{}{123, "@json"}, "@json", "test")
-	if !result {
-		t.Error("jsonTypeIncludes should match string elements in array")
-	}
-}
-
-func TestJSONTypeIncludes_NonStringNonArray(t *testing.T) {
-	result := jsonTypeIncludes(123, "@json", "test")
-	if result {
-		t.Error("jsonTypeIncludes should return false for non-string, non-array")
-	}
-}
-
-func TestNormalizeIRIPath_NoCleanup(t *testing.T) {
-	// Test path that doesn't need cleanup
-	value := "http://example.org/path"
-	result := normalizeIRIPath(value)
-	if result != value {
-		t.Errorf("normalizeIRIPath changed value that doesn't need cleanup")
-	}
-}
-
-func TestNormalizeIRIPath_InvalidURL(t *testing.T) {
-	// Test invalid URL handling
-	value := "not a valid url"
-	result := normalizeIRIPath(value)
-	if result == "" {
-		t.Error("normalizeIRIPath should return fallback for invalid URL")
-	}
-}
-
-func TestNormalizeIRIPath_WithDotSegments(t *testing.T) {
-	// Test path with dot segments
-	value := "http://example.org/path/./segment/../other"
-	result := normalizeIRIPath(value)
-	if result == "" {
-		t.Error("normalizeIRIPath should handle dot segments")
-	}
-}
-
-func TestNormalizeIRIPath_WithDoubleSlashes(t *testing.T) {
-	// Test path with double slashes
-	value := "http://example.org/path//segment"
-	result := normalizeIRIPath(value)
-	if result == "" {
-		t.Error("normalizeIRIPath should handle double slashes")
-	}
-}
-
 func TestRemoveDotSegments_Simple(t *testing.T) {
 	path := "a/b/./c/../d"
 	result := removeDotSegments(path)
@@ -837,23 +463,7 @@ func TestRemoveDotSegments_Absolute(t *testing.T) {
 func TestRemoveDotSegments_Empty(t *testing.T) {
 	path := ""
 	result := removeDotSegments(path)
-	if result != "/" {
-		t.Error("removeDotSegments should return '/' for empty path")
-	}
-}
-
-func TestCollapseSlashes_Simple(t *testing.T) {
-	path := "a//b///c"
-	result := collapseSlashes(path)
-	if strings.Contains(result, "//") {
-		t.Error("collapseSlashes should remove double slashes")
-	}
-}
-
-func TestCollapseSlashes_NoDoubleSlashes(t *testing.T) {
-	path := "a/b/c"
-	result := collapseSlashes(path)
-	if result != path {
-		t.Error("collapseSlashes should not change path without double slashes")
+	if result != "" {
+		t.Error("removeDotSegments should return an empty path unchanged")
 	}
 }
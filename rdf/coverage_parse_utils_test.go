@@ -152,273 +152,8 @@ func TestCheckDecodeContext_Canceled(t *testing.T) {
 
 // Test directive parsing edge cases
 
-func TestParseAtPrefixDirective_NoTerminator(t *testing.T) {
-	line := "@prefix ex: <http://example.org/>"
-	prefix, iri, ok := parseAtPrefixDirective(line, false)
-	if !ok {
-		t.Error("parseAtPrefixDirective should succeed without terminator when requireTerminator=false")
-	}
-	if prefix != "ex" || iri != "http://example.org/" {
-		t.Errorf("parseAtPrefixDirective = (%q, %q), want ('ex', 'http://example.org/')", prefix, iri)
-	}
-}
-
-func TestParseAtPrefixDirective_EmptyPrefix(t *testing.T) {
-	line := "@prefix : <http://example.org/> ."
-	prefix, iri, ok := parseAtPrefixDirective(line, true)
-	if !ok {
-		t.Error("parseAtPrefixDirective should handle empty prefix")
-	}
-	if prefix != "" || iri != "http://example.org/" {
-		t.Errorf("parseAtPrefixDirective = (%q, %q), want ('', 'http://example.org/')", prefix, iri)
-	}
-}
-
-func TestParseAtPrefixDirective_InvalidPrefix(t *testing.T) {
-	line := "@prefix .invalid: <http://example.org/> ."
-	_, _, ok := parseAtPrefixDirective(line, true)
-	if ok {
-		t.Error("parseAtPrefixDirective should fail for invalid prefix")
-	}
-}
-
-func TestParseAtBaseDirective_WithTerminator(t *testing.T) {
-	line := "@base <http://example.org/> ."
-	base, ok := parseAtBaseDirective(line)
-	if !ok {
-		t.Error("parseAtBaseDirective should succeed")
-	}
-	if base != "http://example.org/" {
-		t.Errorf("parseAtBaseDirective = %q, want 'http://example.org/'", base)
-	}
-}
-
-func TestParseBaseDirective_Invalid(t *testing.T) {
-	tests := []string{
-		"BASE .",                      // No IRI
-		"BASE invalid .",              // Invalid IRI format
-		"@base <http://example.org/>", // Wrong directive
-	}
-
-	for _, line := range tests {
-		_, ok := parseBaseDirective(line)
-		if ok {
-			t.Errorf("parseBaseDirective(%q) should fail", line)
-		}
-	}
-}
-
-func TestParseVersionDirective_Invalid(t *testing.T) {
-	tests := []string{
-		"@version .",                 // No version
-		"@version \"\"\"1.1\"\"\" .", // Long string (invalid)
-		"invalid",                    // Not a version directive
-	}
-
-	for _, line := range tests {
-		ok := parseVersionDirective(line)
-		if ok {
-			t.Errorf("parseVersionDirective(%q) should fail", line)
-		}
-	}
-	// Note: "@version \"\" ." is accepted by parseVersionDirective (it finds matching quotes)
-	// The actual validation happens elsewhere in the parser
-}
-
 // Test turtle_parse_helpers.go functions
 
-func TestStripComment_NoComment(t *testing.T) {
-	line := "no comment here"
-	result := stripComment(line)
-	if result != line {
-		t.Errorf("stripComment(%q) = %q, want %q", line, result, line)
-	}
-}
-
-func TestStripComment_WithComment(t *testing.T) {
-	line := "value # this is a comment"
-	result := stripComment(line)
-	expected := "value "
-	if result != expected {
-		t.Errorf("stripComment(%q) = %q, want %q", line, result, expected)
-	}
-}
-
-func TestStripComment_CommentInString(t *testing.T) {
-	line := `"value # not a comment"`
-	result := stripComment(line)
-	if result != line {
-		t.Errorf("stripComment(%q) should preserve comment in string, got %q", line, result)
-	}
-}
-
-func TestStripComment_CommentInIRI(t *testing.T) {
-	line := `<http://example.org/#fragment> # real comment`
-	result := stripComment(line)
-	expected := `<http://example.org/#fragment> `
-	if result != expected {
-		t.Errorf("stripComment(%q) = %q, want %q", line, result, expected)
-	}
-}
-
-func TestStripComment_EscapedHash(t *testing.T) {
-	line := `value\# not a comment`
-	result := stripComment(line)
-	if result != line {
-		t.Errorf("stripComment(%q) should preserve escaped hash, got %q", line, result)
-	}
-}
-
-func TestTurtleStatementState_Reset(t *testing.T) {
-	state := &turtleStatementState{
-		inString:        true,
-		stringQuote:     '"',
-		longString:      true,
-		inIRI:           true,
-		bracketDepth:    5,
-		parenDepth:      3,
-		annotationDepth: 2,
-	}
-
-	state.reset()
-
-	if state.inString || state.inIRI || state.longString ||
-		state.bracketDepth != 0 || state.parenDepth != 0 || state.annotationDepth != 0 {
-		t.Error("reset() should reset all fields to zero")
-	}
-}
-
-func TestTurtleStatementState_UpdateState_String(t *testing.T) {
-	state := &turtleStatementState{}
-
-	// Start string
-	consumed := state.updateState('"', `"test"`, 0)
-	if !state.inString {
-		t.Error("updateState should set inString=true for opening quote")
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-
-	// End string
-	consumed = state.updateState('"', `"test"`, 4)
-	if state.inString {
-		t.Error("updateState should set inString=false for closing quote")
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-}
-
-func TestTurtleStatementState_UpdateState_LongString(t *testing.T) {
-	state := &turtleStatementState{}
-
-	// Start long string
-	consumed := state.updateState('"', `"""test"""`, 0)
-	if consumed != 2 {
-		t.Errorf("updateState for long string start consumed = %d, want 2", consumed)
-	}
-	if !state.inString || !state.longString {
-		t.Error("updateState should set inString and longString for long string")
-	}
-
-	// Reset for end test
-	state.inString = true
-	state.longString = true
-
-	// End long string - position 7 is the first quote of the closing """ in """test"""
-	// String: """test""" (positions 0-9)
-	// Position 7 is the first " of the closing """
-	consumed = state.updateState('"', `"""test"""`, 7)
-	// updateState returns 2 when it finds the closing """ (consumes 2 more chars)
-	if consumed != 2 {
-		t.Errorf("updateState for long string end consumed = %d, want 2", consumed)
-	}
-	// State should be cleared after processing
-	if state.inString || state.longString {
-		t.Error("updateState should clear inString and longString for long string end")
-	}
-}
-
-func TestTurtleStatementState_UpdateState_IRI(t *testing.T) {
-	state := &turtleStatementState{}
-
-	// Start IRI
-	consumed := state.updateState('<', `<http://example.org/>`, 0)
-	if !state.inIRI {
-		t.Error("updateState should set inIRI=true for opening <")
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-
-	// End IRI
-	consumed = state.updateState('>', `<http://example.org/>`, 20)
-	if state.inIRI {
-		t.Error("updateState should set inIRI=false for closing >")
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-}
-
-func TestTurtleStatementState_UpdateState_Brackets(t *testing.T) {
-	state := &turtleStatementState{}
-
-	// Open bracket
-	consumed := state.updateState('[', `[test]`, 0)
-	if state.bracketDepth != 1 {
-		t.Errorf("updateState bracketDepth = %d, want 1", state.bracketDepth)
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-
-	// Close bracket
-	consumed = state.updateState(']', `[test]`, 5)
-	if state.bracketDepth != 0 {
-		t.Errorf("updateState bracketDepth = %d, want 0", state.bracketDepth)
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-}
-
-func TestTurtleStatementState_UpdateState_Parens(t *testing.T) {
-	state := &turtleStatementState{}
-
-	// Open paren
-	consumed := state.updateState('(', `(test)`, 0)
-	if state.parenDepth != 1 {
-		t.Errorf("updateState parenDepth = %d, want 1", state.parenDepth)
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-
-	// Close paren
-	consumed = state.updateState(')', `(test)`, 5)
-	if state.parenDepth != 0 {
-		t.Errorf("updateState parenDepth = %d, want 0", state.parenDepth)
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-}
-
-func TestTurtleStatementState_UpdateState_Escape(t *testing.T) {
-	state := &turtleStatementState{
-		inString:    true,
-		stringQuote: '"',
-	}
-
-	// Escape character in string
-	consumed := state.updateState('\\', `"test\"`, 5)
-	if consumed != 1 {
-		t.Errorf("updateState for escape consumed = %d, want 1", consumed)
-	}
-}
-
 // Test more UnescapeString edge cases
 
 func TestUnescapeString_Mixed(t *testing.T) {
@@ -476,40 +211,40 @@ func TestUnescapeString_AllSimpleEscapes(t *testing.T) {
 func TestResolveIRI_RelativeWithFragment(t *testing.T) {
 	base := "http://example.org/base/"
 	relative := "#fragment"
-	got := resolveIRI(base, relative)
+	got := ResolveIRI(base, relative)
 	expected := "http://example.org/base/#fragment"
 	if got != expected {
-		t.Errorf("resolveIRI(%q, %q) = %q, want %q", base, relative, got, expected)
+		t.Errorf("ResolveIRI(%q, %q) = %q, want %q", base, relative, got, expected)
 	}
 }
 
 func TestResolveIRI_RelativeWithQuery(t *testing.T) {
 	base := "http://example.org/base/"
 	relative := "?query=value"
-	got := resolveIRI(base, relative)
+	got := ResolveIRI(base, relative)
 	expected := "http://example.org/base/?query=value"
 	if got != expected {
-		t.Errorf("resolveIRI(%q, %q) = %q, want %q", base, relative, got, expected)
+		t.Errorf("ResolveIRI(%q, %q) = %q, want %q", base, relative, got, expected)
 	}
 }
 
 func TestResolveIRI_BaseWithFragment(t *testing.T) {
 	base := "http://example.org/base#frag"
 	relative := "path"
-	got := resolveIRI(base, relative)
+	got := ResolveIRI(base, relative)
 	// Fragment should be removed when resolving
 	if strings.Contains(got, "#frag") {
-		t.Errorf("resolveIRI should remove fragment from base, got %q", got)
+		t.Errorf("ResolveIRI should remove fragment from base, got %q", got)
 	}
 }
 
 func TestResolveIRI_BaseWithQuery(t *testing.T) {
 	base := "http://example.org/base?query=value"
 	relative := "path"
-	got := resolveIRI(base, relative)
+	got := ResolveIRI(base, relative)
 	// Query should be removed when resolving
 	if strings.Contains(got, "?query") {
-		t.Errorf("resolveIRI should remove query from base, got %q", got)
+		t.Errorf("ResolveIRI should remove query from base, got %q", got)
 	}
 }
 
@@ -514,135 +514,3 @@ func TestSecurityLimits_MaxTriples(t *testing.T) {
 }
 
 // Test directive parsing
-
-func TestParseAtPrefixDirective(t *testing.T) {
-	tests := []struct {
-		line   string
-		prefix string
-		iri    string
-		ok     bool
-	}{
-		{"@prefix ex: <http://example.org/> .", "ex", "http://example.org/", true},
-		{"@prefix : <http://example.org/> .", "", "http://example.org/", true},
-		{"@prefix ex: <http://example.org/>", "ex", "http://example.org/", false}, // Missing terminator when requireTerminator=true
-		{"invalid", "", "", false},
-		{"@prefix ex <http://example.org/> .", "", "", false}, // Missing colon
-		{"@prefix ex: http://example.org/> .", "", "", false}, // Missing angle brackets
-	}
-
-	for _, tt := range tests {
-		prefix, iri, ok := parseAtPrefixDirective(tt.line, true)
-		if ok != tt.ok {
-			t.Errorf("parseAtPrefixDirective(%q) ok = %v, want %v", tt.line, ok, tt.ok)
-			continue
-		}
-		if ok {
-			if prefix != tt.prefix {
-				t.Errorf("parseAtPrefixDirective(%q) prefix = %q, want %q", tt.line, prefix, tt.prefix)
-			}
-			if iri != tt.iri {
-				t.Errorf("parseAtPrefixDirective(%q) iri = %q, want %q", tt.line, iri, tt.iri)
-			}
-		}
-	}
-}
-
-func TestParseBarePrefixDirective(t *testing.T) {
-	tests := []struct {
-		line   string
-		prefix string
-		iri    string
-		ok     bool
-	}{
-		{"PREFIX ex: <http://example.org/>", "ex", "http://example.org/", true},
-		{"PREFIX : <http://example.org/>", "", "http://example.org/", true},
-		{"@prefix ex: <http://example.org/>", "", "", false}, // Must not start with @
-		{"invalid", "", "", false},
-	}
-
-	for _, tt := range tests {
-		prefix, iri, ok := parseBarePrefixDirective(tt.line)
-		if ok != tt.ok {
-			t.Errorf("parseBarePrefixDirective(%q) ok = %v, want %v", tt.line, ok, tt.ok)
-			continue
-		}
-		if ok {
-			if prefix != tt.prefix {
-				t.Errorf("parseBarePrefixDirective(%q) prefix = %q, want %q", tt.line, prefix, tt.prefix)
-			}
-			if iri != tt.iri {
-				t.Errorf("parseBarePrefixDirective(%q) iri = %q, want %q", tt.line, iri, tt.iri)
-			}
-		}
-	}
-}
-
-func TestParseAtBaseDirective(t *testing.T) {
-	tests := []struct {
-		line string
-		base string
-		ok   bool
-	}{
-		{"@base <http://example.org/> .", "http://example.org/", true},
-		{"@base <http://example.org/base/>", "http://example.org/base/", true},
-		{"invalid", "", false},
-		{"@base http://example.org/> .", "", false}, // Missing opening bracket
-	}
-
-	for _, tt := range tests {
-		base, ok := parseAtBaseDirective(tt.line)
-		if ok != tt.ok {
-			t.Errorf("parseAtBaseDirective(%q) ok = %v, want %v", tt.line, ok, tt.ok)
-			continue
-		}
-		if ok && base != tt.base {
-			t.Errorf("parseAtBaseDirective(%q) base = %q, want %q", tt.line, base, tt.base)
-		}
-	}
-}
-
-func TestParseBaseDirective(t *testing.T) {
-	tests := []struct {
-		line string
-		base string
-		ok   bool
-	}{
-		{"BASE <http://example.org/>", "http://example.org/", true},
-		{"BASE <http://example.org/base/>", "http://example.org/base/", true},
-		{"@base <http://example.org/>", "", false},  // Must not start with @
-		{"BASE <http://example.org/> .", "", false}, // Must not end with .
-		{"invalid", "", false},
-	}
-
-	for _, tt := range tests {
-		base, ok := parseBaseDirective(tt.line)
-		if ok != tt.ok {
-			t.Errorf("parseBaseDirective(%q) ok = %v, want %v", tt.line, ok, tt.ok)
-			continue
-		}
-		if ok && base != tt.base {
-			t.Errorf("parseBaseDirective(%q) base = %q, want %q", tt.line, base, tt.base)
-		}
-	}
-}
-
-func TestParseVersionDirective(t *testing.T) {
-	tests := []struct {
-		line string
-		ok   bool
-	}{
-		{"@version \"1.1\" .", true},
-		{"VERSION \"1.1\" .", true},
-		{"@version '1.1' .", true},
-		{"@version \"\" .", true},      // parseVersionDirective accepts empty string (finds matching quotes)
-		{"@version \"1.1\"\" .", true}, // parseVersionDirective may accept this (finds matching quotes)
-		{"invalid", false},
-	}
-
-	for _, tt := range tests {
-		ok := parseVersionDirective(tt.line)
-		if ok != tt.ok {
-			t.Errorf("parseVersionDirective(%q) = %v, want %v", tt.line, ok, tt.ok)
-		}
-	}
-}
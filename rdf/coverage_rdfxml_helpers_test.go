@@ -110,7 +110,7 @@ func TestHandleEmptyPropertyElementWithAttributes_InvalidNodeID(t *testing.T) {
 	}
 
 	subject := IRI{Value: "http://example.org/s"}
-	_, err := dec.handleEmptyPropertyElementWithAttributes(el, subject)
+	_, err := dec.handleEmptyPropertyElementWithAttributes(el, subject, "")
 	if err == nil {
 		t.Fatal("Expected error for invalid nodeID")
 	}
@@ -1109,26 +1109,6 @@ func TestGenerateCollectionTriples_Multiple(t *testing.T) {
 
 // Test normalizeTriGStatement
 
-func TestNormalizeTriGStatement(t *testing.T) {
-	tests := []struct {
-		input  string
-		expect string
-	}{
-		{`\.:`, `\. :`},
-		{`\.:ex:`, `\. :ex:`},
-		{`\.: :`, `\. : :`},
-		{`no change`, `no change`},
-	}
-
-	for _, tt := range tests {
-		got := normalizeTriGStatement(tt.input)
-		// Just verify it doesn't crash and produces output
-		if got == "" && tt.input != "" {
-			t.Errorf("normalizeTriGStatement(%q) returned empty", tt.input)
-		}
-	}
-}
-
 // Test isValidXMLName
 
 func TestIsValidXMLName(t *testing.T) {
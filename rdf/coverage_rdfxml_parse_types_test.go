@@ -144,12 +144,9 @@ func TestRDFXMLReadLiteralContent_CharData(t *testing.T) {
 	_, _ = dec.nextToken() // XML declaration
 	_, _ = dec.nextToken() // StartElement
 
-	// Get first token (CharData)
-	tok, _ := dec.nextToken()
-
-	obj, _, _, err := dec.readLiteralContent(start, tok)
+	obj, _, _, err := dec.objectFromPredicate(start)
 	if err != nil {
-		t.Fatalf("readLiteralContent failed: %v", err)
+		t.Fatalf("objectFromPredicate failed: %v", err)
 	}
 	if lit, ok := obj.(Literal); !ok {
 		t.Error("Expected Literal object")
@@ -176,11 +173,9 @@ func TestRDFXMLReadLiteralContent_WithLanguage(t *testing.T) {
 	_, _ = dec.nextToken() // XML declaration
 	_, _ = dec.nextToken() // StartElement
 
-	tok, _ := dec.nextToken()
-
-	obj, _, _, err := dec.readLiteralContent(start, tok)
+	obj, _, _, err := dec.objectFromPredicate(start)
 	if err != nil {
-		t.Fatalf("readLiteralContent failed: %v", err)
+		t.Fatalf("objectFromPredicate failed: %v", err)
 	}
 	if lit, ok := obj.(Literal); !ok {
 		t.Error("Expected Literal object")
@@ -207,11 +202,9 @@ func TestRDFXMLReadLiteralContent_WithDatatype(t *testing.T) {
 	_, _ = dec.nextToken() // XML declaration
 	_, _ = dec.nextToken() // StartElement
 
-	tok, _ := dec.nextToken()
-
-	obj, _, _, err := dec.readLiteralContent(start, tok)
+	obj, _, _, err := dec.objectFromPredicate(start)
 	if err != nil {
-		t.Fatalf("readLiteralContent failed: %v", err)
+		t.Fatalf("objectFromPredicate failed: %v", err)
 	}
 	if lit, ok := obj.(Literal); !ok {
 		t.Error("Expected Literal object")
@@ -107,8 +107,15 @@ func TestRDFXMLNextSkipsNonNodeElements(t *testing.T) {
 func TestRDFXMLNestedPredicateError(t *testing.T) {
 	input := `<?xml version="1.0"?><rdf:RDF xmlns:rdf="` + rdfXMLNS + `"><rdf:Description rdf:about="http://example.org/s"><ex:p xmlns:ex="http://example.org/"><ex:inner>v</ex:inner></ex:p></rdf:Description></rdf:RDF>`
 	dec, _ := NewReader(strings.NewReader(input), FormatRDFXML)
-	if _, err := dec.Next(); err == nil {
-		t.Fatal("expected nested predicate error")
+	// A nested node element may contain only property elements.
+	for {
+		_, err := dec.Next()
+		if err == io.EOF {
+			t.Fatal("expected nested predicate error")
+		}
+		if err != nil {
+			break
+		}
 	}
 }
 
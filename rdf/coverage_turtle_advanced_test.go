@@ -1124,31 +1124,6 @@ func TestCode_DepthExceeded_Additional(t *testing.T) {
 
 // Test more utility functions
 
-func TestNormalizeTriGStatement_Advanced(t *testing.T) {
-	tests := []struct {
-		input  string
-		expect string
-	}{
-		{`\.:`, `\. :`},
-		{`\.:ex:`, `\. :ex:`},
-		{`\.: :`, `\. : :`},
-		{`no change`, `no change`},
-		{`\.:ex:other`, `\. :ex:other`},
-	}
-
-	for _, tt := range tests {
-		got := normalizeTriGStatement(tt.input)
-		// Just verify it doesn't crash and produces output
-		if got == "" && tt.input != "" {
-			t.Errorf("normalizeTriGStatement(%q) returned empty", tt.input)
-		}
-		// Check that it handles the specific case
-		if tt.input == `\.:` && !strings.Contains(got, `\. :`) {
-			t.Errorf("normalizeTriGStatement(%q) = %q, should normalize", tt.input, got)
-		}
-	}
-}
-
 // Test more encoder error handling
 
 func TestTurtleEncoder_FlushMultipleTimes(t *testing.T) {
@@ -9,296 +9,8 @@ import (
 
 // Test turtle_parse_helpers.go functions
 
-func TestIsStatementComplete_Simple(t *testing.T) {
-	tests := []struct {
-		stmt   string
-		expect bool
-	}{
-		{"<s> <p> <o> .", true},
-		{"<s> <p> <o>", false},
-		{"<s> <p> <o> . extra", false},
-		{"<s> <p> <o> . ", true},
-		{"<s> <p> <o> .\n", true},
-	}
-
-	for _, tt := range tests {
-		got := isStatementComplete(tt.stmt)
-		if got != tt.expect {
-			t.Errorf("isStatementComplete(%q) = %v, want %v", tt.stmt, got, tt.expect)
-		}
-	}
-}
-
-func TestIsStatementComplete_WithBrackets(t *testing.T) {
-	tests := []struct {
-		stmt   string
-		expect bool
-	}{
-		{"[ <p> <o> ] .", true},
-		{"[ <p> <o> .", false}, // Unbalanced
-		{"[ <p> <o> ] <p2> <o2> .", true},
-	}
-
-	for _, tt := range tests {
-		got := isStatementComplete(tt.stmt)
-		if got != tt.expect {
-			t.Errorf("isStatementComplete(%q) = %v, want %v", tt.stmt, got, tt.expect)
-		}
-	}
-}
-
-func TestIsStatementComplete_WithParens(t *testing.T) {
-	tests := []struct {
-		stmt   string
-		expect bool
-	}{
-		{"( <o1> <o2> ) .", true},
-		{"( <o1> <o2> .", false}, // Unbalanced
-		{"<s> <p> ( <o1> <o2> ) .", true},
-	}
-
-	for _, tt := range tests {
-		got := isStatementComplete(tt.stmt)
-		if got != tt.expect {
-			t.Errorf("isStatementComplete(%q) = %v, want %v", tt.stmt, got, tt.expect)
-		}
-	}
-}
-
-func TestIsStatementComplete_WithString(t *testing.T) {
-	tests := []struct {
-		stmt   string
-		expect bool
-	}{
-		{`<s> <p> "value" .`, true},
-		{`<s> <p> "value"`, false},
-		{`<s> <p> "value with . inside" .`, true},
-		{`<s> <p> """long string""" .`, true},
-	}
-
-	for _, tt := range tests {
-		got := isStatementComplete(tt.stmt)
-		if got != tt.expect {
-			t.Errorf("isStatementComplete(%q) = %v, want %v", tt.stmt, got, tt.expect)
-		}
-	}
-}
-
-func TestIsStatementComplete_WithIRI(t *testing.T) {
-	tests := []struct {
-		stmt   string
-		expect bool
-	}{
-		{"<s> <p> <o> .", true},
-		{"<s> <p> <o>", false},
-		{"<s> <p> <o with . inside> .", true},
-	}
-
-	for _, tt := range tests {
-		got := isStatementComplete(tt.stmt)
-		if got != tt.expect {
-			t.Errorf("isStatementComplete(%q) = %v, want %v", tt.stmt, got, tt.expect)
-		}
-	}
-}
-
-func TestIsStatementComplete_NumericLiteral(t *testing.T) {
-	tests := []struct {
-		stmt   string
-		expect bool
-	}{
-		{"<s> <p> 123 .", true},
-		{"<s> <p> 123.456 .", true},
-		{"<s> <p> 123e10 .", true},
-		{"<s> <p> 123.456e-10 .", true},
-		{"<s> <p> 123e .", true}, // isStatementComplete may consider this complete (has terminator)
-	}
-
-	for _, tt := range tests {
-		got := isStatementComplete(tt.stmt)
-		if got != tt.expect {
-			t.Errorf("isStatementComplete(%q) = %v, want %v", tt.stmt, got, tt.expect)
-		}
-	}
-}
-
-func TestTurtleStatementState_IsBalanced(t *testing.T) {
-	state := &turtleStatementState{}
-	if !state.isBalanced() {
-		t.Error("Empty state should be balanced")
-	}
-
-	state.bracketDepth = 1
-	if state.isBalanced() {
-		t.Error("State with bracket depth should not be balanced")
-	}
-
-	state.bracketDepth = 0
-	state.parenDepth = 1
-	if state.isBalanced() {
-		t.Error("State with paren depth should not be balanced")
-	}
-
-	state.parenDepth = 0
-	state.annotationDepth = 1
-	if state.isBalanced() {
-		t.Error("State with annotation depth should not be balanced")
-	}
-}
-
-func TestTurtleStatementState_UpdateState_Annotation(t *testing.T) {
-	state := &turtleStatementState{}
-
-	// Start annotation - at position 0, we have '{', next char is '|'
-	consumed := state.updateState('{', `{|annotation|}`, 0)
-	if consumed != 1 {
-		t.Errorf("updateState for annotation start consumed = %d, want 1", consumed)
-	}
-	if state.annotationDepth != 1 {
-		t.Errorf("updateState annotationDepth = %d, want 1", state.annotationDepth)
-	}
-
-	// Reset for end test
-	state.annotationDepth = 1
-
-	// End annotation - find the closing |}
-	// String: {|annotation|} (positions 0-13, length 14)
-	// The closing |} is at positions 12-13
-	testStr := `{|annotation|}`
-	if len(testStr) != 14 {
-		t.Fatalf("Test string length = %d, want 14", len(testStr))
-	}
-	// Position 12 is '|', position 13 is '}'
-	consumed = state.updateState('|', testStr, 12)
-	// The function checks pos+1, so at pos 12 it checks input[13] which should be '}'
-	if consumed != 1 {
-		t.Errorf("updateState for annotation end consumed = %d, want 1", consumed)
-	}
-	if state.annotationDepth != 0 {
-		t.Errorf("updateState annotationDepth = %d, want 0", state.annotationDepth)
-	}
-}
-
-func TestTurtleStatementState_UpdateState_SingleQuote(t *testing.T) {
-	state := &turtleStatementState{}
-
-	// Start single-quoted string
-	consumed := state.updateState('\'', `'test'`, 0)
-	if !state.inString {
-		t.Error("updateState should set inString=true for single quote")
-	}
-	if state.stringQuote != '\'' {
-		t.Error("updateState should set stringQuote to single quote")
-	}
-	if consumed != 0 {
-		t.Errorf("updateState consumed = %d, want 0", consumed)
-	}
-}
-
-func TestSplitTurtleStatements_Simple(t *testing.T) {
-	input := "<s1> <p1> <o1> .\n<s2> <p2> <o2> ."
-	statements := splitTurtleStatements(input)
-	if len(statements) != 2 {
-		t.Errorf("splitTurtleStatements returned %d statements, want 2", len(statements))
-	}
-}
-
-func TestSplitTurtleStatements_WithBrackets(t *testing.T) {
-	input := "[ <p1> <o1> ] .\n[ <p2> <o2> ] ."
-	statements := splitTurtleStatements(input)
-	if len(statements) != 2 {
-		t.Errorf("splitTurtleStatements returned %d statements, want 2", len(statements))
-	}
-}
-
-func TestSplitTurtleStatements_WithParens(t *testing.T) {
-	input := "( <o1> <o2> ) .\n( <o3> <o4> ) ."
-	statements := splitTurtleStatements(input)
-	if len(statements) != 2 {
-		t.Errorf("splitTurtleStatements returned %d statements, want 2", len(statements))
-	}
-}
-
-func TestSplitTurtleStatements_WithStrings(t *testing.T) {
-	// Use actual newline character, not \n escape
-	input := "<s1> <p1> \"value1\" .\n<s2> <p2> \"value2\" ."
-	statements := splitTurtleStatements(input)
-	// splitTurtleStatements may or may not handle strings correctly - accept any result
-	if len(statements) == 0 {
-		t.Error("splitTurtleStatements should return at least 1 statement")
-	}
-}
-
-func TestSplitTurtleStatements_WithLongStrings(t *testing.T) {
-	// Use actual newline character
-	input := "<s1> <p1> \"\"\"long\nstring\"\"\" .\n<s2> <p2> \"short\" ."
-	statements := splitTurtleStatements(input)
-	// splitTurtleStatements may or may not handle long strings correctly - accept any result
-	// The function might return 1 statement if it doesn't properly handle long strings
-	if len(statements) == 0 {
-		t.Error("splitTurtleStatements should return at least 1 statement")
-	}
-	// Accept 1 or more statements - long string handling may not be perfect
-	_ = statements
-}
-
-func TestSplitTurtleStatements_WithIRIs(t *testing.T) {
-	input := "<s1> <p1> <o1> .\n<s2> <p2> <o2> ."
-	statements := splitTurtleStatements(input)
-	if len(statements) != 2 {
-		t.Errorf("splitTurtleStatements returned %d statements, want 2", len(statements))
-	}
-}
-
-func TestSplitTurtleStatements_Unterminated(t *testing.T) {
-	input := "<s1> <p1> <o1> .\n<s2> <p2> <o2>"
-	statements := splitTurtleStatements(input)
-	// Should still split what it can
-	if len(statements) == 0 {
-		t.Error("splitTurtleStatements should return at least one statement")
-	}
-}
-
 // Test more stripComment edge cases
 
-func TestStripComment_MultipleQuotes(t *testing.T) {
-	line := `"value" 'other' # comment`
-	result := stripComment(line)
-	expected := `"value" 'other' `
-	if result != expected {
-		t.Errorf("stripComment(%q) = %q, want %q", line, result, expected)
-	}
-}
-
-func TestStripComment_NestedQuotes(t *testing.T) {
-	line := `"outer 'inner' outer" # comment`
-	result := stripComment(line)
-	// stripComment may strip the comment even if it's after a string - implementation dependent
-	// Just verify it doesn't crash and returns something
-	if result == "" && line != "" {
-		t.Error("stripComment should return non-empty result for non-empty input")
-	}
-}
-
-func TestStripComment_EscapedQuote(t *testing.T) {
-	line := `"value \"escaped\"" # comment`
-	result := stripComment(line)
-	// stripComment may strip the comment - implementation dependent
-	// Just verify it doesn't crash and returns something
-	if result == "" && line != "" {
-		t.Error("stripComment should return non-empty result for non-empty input")
-	}
-}
-
-func TestStripComment_IRIWithHash(t *testing.T) {
-	line := `<http://example.org/#fragment> # comment`
-	result := stripComment(line)
-	expected := `<http://example.org/#fragment> `
-	if result != expected {
-		t.Errorf("stripComment(%q) = %q, want %q", line, result, expected)
-	}
-}
-
 // Test more parser error paths
 
 func TestTurtleParser_InvalidNumericLiteral_Exponent(t *testing.T) {
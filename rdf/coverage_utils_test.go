@@ -283,26 +283,26 @@ func TestResolveIRI(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		got := resolveIRI(tt.base, tt.relative)
+		got := ResolveIRI(tt.base, tt.relative)
 		if got != tt.expect {
-			t.Errorf("resolveIRI(%q, %q) = %q, want %q", tt.base, tt.relative, got, tt.expect)
+			t.Errorf("ResolveIRI(%q, %q) = %q, want %q", tt.base, tt.relative, got, tt.expect)
 		}
 	}
 }
 
 func TestResolveIRI_InvalidBase(t *testing.T) {
 	// Test fallback behavior with invalid base
-	got := resolveIRI("not a valid url", "path")
+	got := ResolveIRI("not a valid url", "path")
 	if got == "" {
-		t.Error("resolveIRI should return fallback result for invalid base")
+		t.Error("ResolveIRI should return fallback result for invalid base")
 	}
 }
 
 func TestResolveIRI_InvalidRelative(t *testing.T) {
 	// Test fallback behavior with invalid relative
-	got := resolveIRI("http://example.org/base/", "not a valid url")
+	got := ResolveIRI("http://example.org/base/", "not a valid url")
 	if got == "" {
-		t.Error("resolveIRI should return fallback result for invalid relative")
+		t.Error("ResolveIRI should return fallback result for invalid relative")
 	}
 }
 
@@ -0,0 +1,295 @@
+package rdf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+)
+
+// ErrReadOnly indicates a change to the statements of a DatasetSnapshot.
+var ErrReadOnly = errors.New("rdf: dataset snapshot is read-only")
+
+// Dataset is an RDF dataset, a default graph and named graphs, whose
+// statements are kept in a QuadStore.
+//
+// A long-running service can both serve and update a dataset: readers take
+// a Snapshot for a consistent view across several calls, while a writer
+// applies a batch of changes in a transaction from Begin, which readers see
+// at once when it commits.
+type Dataset struct {
+	store QuadStore
+}
+
+// NewDataset returns an empty Dataset held in a MemoryQuadStore.
+func NewDataset() *Dataset {
+	return NewDatasetOn(NewMemoryQuadStore())
+}
+
+// NewDatasetOn returns a Dataset reading and writing its statements in
+// store.
+func NewDatasetOn(store QuadStore) *Dataset {
+	return &Dataset{store: store}
+}
+
+// Store returns the QuadStore of the dataset.
+func (d *Dataset) Store() QuadStore {
+	return d.store
+}
+
+// Add adds s to the dataset, in the default graph if s.G is nil.
+func (d *Dataset) Add(s Statement) error {
+	return d.store.AddQuad(s.AsQuad())
+}
+
+// Delete removes s from the dataset.
+func (d *Dataset) Delete(s Statement) error {
+	return d.store.DeleteQuad(s.AsQuad())
+}
+
+// Contains reports whether the dataset holds s.
+func (d *Dataset) Contains(s Statement) (bool, error) {
+	return containsQuad(d.store, s.AsQuad())
+}
+
+// Match returns the statements of the dataset matching p.
+func (d *Dataset) Match(p QuadPattern) iter.Seq2[Statement, error] {
+	return func(yield func(Statement, error) bool) {
+		for q, err := range d.store.MatchQuads(p) {
+			if !yield(q.ToStatement(), err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Load adds the statements of r to the dataset and returns the number of
+// statements read. Statements are cloned before they are added, so r may be
+// an OptArena or OpenMapped reader. It does not close r.
+func (d *Dataset) Load(r Reader) (int64, error) {
+	var n int64
+	for {
+		stmt, err := r.Next()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+		if err := d.Add(stmt.Clone()); err != nil {
+			return n, err
+		}
+	}
+}
+
+// Reader returns a Reader over all statements of the dataset, for writing
+// the dataset with Copy or EncodeAll. Closing it ends the underlying
+// MatchQuads iteration.
+func (d *Dataset) Reader() Reader {
+	return newSeqReader(d.Match(QuadPattern{}))
+}
+
+// Begin starts a transaction on the store of the dataset. The statements
+// of the returned DatasetTx are those of the dataset with the changes of
+// the transaction applied; Commit makes the changes visible to other users
+// of the dataset all at once, and Rollback discards them.
+func (d *Dataset) Begin() (*DatasetTx, error) {
+	tx, err := d.store.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &DatasetTx{Dataset: NewDatasetOn(txQuadStore{tx}), tx: tx}, nil
+}
+
+// Snapshot returns a read-only view of the dataset as it is now, which
+// changes and transactions committed later do not affect. It fails with an
+// error wrapping errors.ErrUnsupported if the store does not implement
+// QuadSnapshotter. The snapshot should be closed when no longer needed.
+func (d *Dataset) Snapshot() (*DatasetSnapshot, error) {
+	s, ok := d.store.(QuadSnapshotter)
+	if !ok {
+		return nil, fmt.Errorf("rdf: snapshot of %T: %w", d.store, errors.ErrUnsupported)
+	}
+	snap, err := s.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &DatasetSnapshot{Dataset: NewDatasetOn(snapshotQuadStore{snap}), snap: snap}, nil
+}
+
+// Graph returns the named graph name of the dataset, or its default graph
+// if name is nil. The graph exists as long as it holds statements.
+func (d *Dataset) Graph(name Term) *Graph {
+	return &Graph{store: d.store, name: name}
+}
+
+// DefaultGraph returns the default graph of the dataset.
+func (d *Dataset) DefaultGraph() *Graph {
+	return d.Graph(nil)
+}
+
+// GraphNames returns the names of the named graphs holding statements, in
+// TermCompare order.
+func (d *Dataset) GraphNames() ([]Term, error) {
+	var names []Term
+	for q, err := range d.store.MatchQuads(QuadPattern{}) {
+		if err != nil {
+			return nil, err
+		}
+		if q.G == nil || (len(names) > 0 && TermEqual(names[len(names)-1], q.G)) {
+			continue
+		}
+		names = append(names, q.G)
+	}
+	slices.SortFunc(names, TermCompare)
+	return slices.CompactFunc(names, TermEqual), nil
+}
+
+// Graph is one graph of a Dataset, reading and writing the triples of its
+// QuadStore with its graph name.
+type Graph struct {
+	store QuadStore
+	name  Term
+}
+
+// Name returns the name of the graph, nil for the default graph.
+func (g *Graph) Name() Term {
+	return g.name
+}
+
+// Add adds t to the graph.
+func (g *Graph) Add(t Triple) error {
+	return g.store.AddQuad(t.ToQuadInGraph(g.name))
+}
+
+// Delete removes t from the graph.
+func (g *Graph) Delete(t Triple) error {
+	return g.store.DeleteQuad(t.ToQuadInGraph(g.name))
+}
+
+// Contains reports whether the graph holds t.
+func (g *Graph) Contains(t Triple) (bool, error) {
+	return containsQuad(g.store, t.ToQuadInGraph(g.name))
+}
+
+// Match returns the triples of the graph matching s, p and o; a nil s or
+// o, or an empty p, matches any term.
+func (g *Graph) Match(s Term, p IRI, o Term) iter.Seq2[Triple, error] {
+	return func(yield func(Triple, error) bool) {
+		for q, err := range g.store.MatchQuads(g.pattern(s, p, o)) {
+			if !yield(q.ToTriple(), err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// MatchBGP returns the solutions of patterns over the graph, as MatchBGP
+// does.
+func (g *Graph) MatchBGP(patterns ...TriplePattern) iter.Seq2[Bindings, error] {
+	return MatchBGP(g.store, g.name, patterns...)
+}
+
+// Reader returns a Reader over the triples of the graph as statements with
+// its graph name. Closing it ends the underlying MatchQuads iteration.
+func (g *Graph) Reader() Reader {
+	return newSeqReader(func(yield func(Statement, error) bool) {
+		for q, err := range g.store.MatchQuads(g.pattern(nil, IRI{}, nil)) {
+			if !yield(q.ToStatement(), err) || err != nil {
+				return
+			}
+		}
+	})
+}
+
+func (g *Graph) pattern(s Term, p IRI, o Term) QuadPattern {
+	return QuadPattern{S: s, P: p, O: o, G: g.name, DefaultGraph: g.name == nil}
+}
+
+// DatasetTx is a transaction of a Dataset. Its embedded Dataset reads and
+// changes the statements within the transaction; its Begin and Snapshot
+// fail.
+type DatasetTx struct {
+	*Dataset
+	tx QuadTx
+}
+
+// Commit applies the changes of the transaction to the dataset.
+func (t *DatasetTx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback discards the changes of the transaction. After Commit it does
+// nothing, so it can be deferred.
+func (t *DatasetTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// DatasetSnapshot is a read-only view of a Dataset. Changes through its
+// embedded Dataset fail with ErrReadOnly.
+type DatasetSnapshot struct {
+	*Dataset
+	snap QuadSnapshot
+}
+
+// Close releases the snapshot.
+func (s *DatasetSnapshot) Close() error {
+	return s.snap.Close()
+}
+
+// txQuadStore is the QuadStore of a DatasetTx.
+type txQuadStore struct {
+	QuadTx
+}
+
+func (txQuadStore) Begin() (QuadTx, error) {
+	return nil, fmt.Errorf("rdf: nested transaction: %w", errors.ErrUnsupported)
+}
+
+// snapshotQuadStore is the QuadStore of a DatasetSnapshot.
+type snapshotQuadStore struct {
+	QuadSnapshot
+}
+
+func (snapshotQuadStore) AddQuad(Quad) error    { return ErrReadOnly }
+func (snapshotQuadStore) DeleteQuad(Quad) error { return ErrReadOnly }
+func (snapshotQuadStore) Begin() (QuadTx, error) {
+	return nil, ErrReadOnly
+}
+
+func containsQuad(m QuadMatcher, q Quad) (bool, error) {
+	p := QuadPattern{S: q.S, P: q.P, O: q.O, G: q.G, DefaultGraph: q.G == nil}
+	for _, err := range m.MatchQuads(p) {
+		return err == nil, err
+	}
+	return false, nil
+}
+
+// seqReader is a Reader over an iterator of statements.
+type seqReader struct {
+	next func() (Statement, error, bool)
+	stop func()
+}
+
+func newSeqReader(seq iter.Seq2[Statement, error]) *seqReader {
+	next, stop := iter.Pull2(seq)
+	return &seqReader{next: next, stop: stop}
+}
+
+func (r *seqReader) Next() (Statement, error) {
+	stmt, err, ok := r.next()
+	if !ok {
+		return Statement{}, io.EOF
+	}
+	if err != nil {
+		r.stop()
+	}
+	return stmt, err
+}
+
+func (r *seqReader) Close() error {
+	r.stop()
+	return nil
+}
@@ -0,0 +1,275 @@
+package rdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// sliceQuadStore is a minimal QuadStore of another package, without
+// indexes or transaction isolation.
+type sliceQuadStore struct {
+	quads []Quad
+	err   error
+}
+
+func (s *sliceQuadStore) AddQuad(q Quad) error {
+	if !slices.ContainsFunc(s.quads, func(x Quad) bool { return quadEqual(x, q) }) {
+		s.quads = append(s.quads, q)
+	}
+	return nil
+}
+
+func (s *sliceQuadStore) DeleteQuad(q Quad) error {
+	s.quads = slices.DeleteFunc(s.quads, func(x Quad) bool { return quadEqual(x, q) })
+	return nil
+}
+
+func (s *sliceQuadStore) MatchQuads(p QuadPattern) iter.Seq2[Quad, error] {
+	return func(yield func(Quad, error) bool) {
+		if s.err != nil {
+			yield(Quad{}, s.err)
+			return
+		}
+		for _, q := range slices.Clone(s.quads) {
+			if p.Matches(q) && !yield(q, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *sliceQuadStore) Begin() (QuadTx, error) {
+	return nil, errors.New("not supported")
+}
+
+func quadEqual(a, b Quad) bool {
+	return TermEqual(a.S, b.S) && a.P == b.P && TermEqual(a.O, b.O) && TermEqual(a.G, b.G)
+}
+
+func TestDataset(t *testing.T) {
+	const input = `<http://example.org/a> <http://example.org/p> "x" .
+<http://example.org/a> <http://example.org/p> "y" <http://example.org/g1> .
+<http://example.org/b> <http://example.org/p> "z" <http://example.org/g2> .
+<http://example.org/b> <http://example.org/p> "w" <http://example.org/g1> .
+`
+	for name, store := range map[string]QuadStore{"memory": NewMemoryQuadStore(), "slice": &sliceQuadStore{}} {
+		t.Run(name, func(t *testing.T) {
+			d := NewDatasetOn(store)
+			r, err := NewReader(strings.NewReader(input), FormatNQuads)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n, err := d.Load(r)
+			r.Close()
+			if err != nil || n != 4 {
+				t.Fatalf("Load() = %d, %v", n, err)
+			}
+
+			names, err := d.GraphNames()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []Term{IRI{Value: "http://example.org/g1"}, IRI{Value: "http://example.org/g2"}}; !slices.Equal(names, want) {
+				t.Errorf("GraphNames() = %v, want %v", names, want)
+			}
+
+			g1 := d.Graph(IRI{Value: "http://example.org/g1"})
+			var objects []string
+			for tr, err := range g1.Match(nil, IRI{Value: "http://example.org/p"}, nil) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				objects = append(objects, tr.O.(Literal).Lexical)
+			}
+			slices.Sort(objects)
+			if want := []string{"w", "y"}; !slices.Equal(objects, want) {
+				t.Errorf("g1 objects = %v, want %v", objects, want)
+			}
+
+			def := d.DefaultGraph()
+			x := Triple{S: IRI{Value: "http://example.org/a"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "x"}}
+			if ok, err := def.Contains(x); !ok || err != nil {
+				t.Errorf("default graph Contains(x) = %v, %v", ok, err)
+			}
+			if ok, _ := g1.Contains(x); ok {
+				t.Error("g1 contains a triple of the default graph")
+			}
+			if err := def.Delete(x); err != nil {
+				t.Fatal(err)
+			}
+			if ok, _ := d.Contains(x.ToStatement()); ok {
+				t.Error("Contains after Delete = true")
+			}
+			if err := def.Add(x); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, FormatNQuads)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n, err := Copy(w, d.Reader()); err != nil || n != 4 {
+				t.Fatalf("Copy() = %d, %v", n, err)
+			}
+			w.Close()
+			got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			want := strings.Split(strings.TrimSpace(input), "\n")
+			slices.Sort(got)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("written dataset = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDatasetLoadArena(t *testing.T) {
+	// Load must copy statements whose strings an OptArena reader reuses.
+	var input strings.Builder
+	var want []string
+	for i := range 20 {
+		line := fmt.Sprintf("<http://example.org/s%d> <http://example.org/p> \"value %d\"@en <http://example.org/g%d> .", i, i, i%3)
+		input.WriteString(line + "\n")
+		want = append(want, line)
+	}
+	slices.Sort(want)
+	for name, store := range map[string]QuadStore{"memory": NewMemoryQuadStore(), "slice": &sliceQuadStore{}} {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewReader(strings.NewReader(input.String()), FormatNQuads, OptArena())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			d := NewDatasetOn(store)
+			if n, err := d.Load(r); n != 20 || err != nil {
+				t.Fatalf("Load() = %d, %v", n, err)
+			}
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, FormatNQuads)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Copy(w, d.Reader()); err != nil {
+				t.Fatal(err)
+			}
+			w.Close()
+			got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			slices.Sort(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("loaded dataset = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDatasetStoreError(t *testing.T) {
+	failure := errors.New("backend down")
+	d := NewDatasetOn(&sliceQuadStore{err: failure})
+	if _, err := d.GraphNames(); !errors.Is(err, failure) {
+		t.Errorf("GraphNames() error = %v", err)
+	}
+	r := d.Graph(nil).Reader()
+	defer r.Close()
+	if _, err := r.Next(); !errors.Is(err, failure) {
+		t.Errorf("Next() error = %v", err)
+	}
+}
+
+func TestDatasetTxAndSnapshot(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	d := NewDataset()
+	d.Add(Statement{S: ex("a"), P: ex("p"), O: Literal{Lexical: "1"}})
+	snap, err := d.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer snap.Close()
+
+	tx, err := d.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	tx.Delete(Statement{S: ex("a"), P: ex("p"), O: Literal{Lexical: "1"}})
+	tx.Graph(ex("g")).Add(Triple{S: ex("a"), P: ex("p"), O: Literal{Lexical: "2"}})
+	if names, _ := tx.GraphNames(); len(names) != 1 {
+		t.Errorf("transaction graph names = %v", names)
+	}
+	if names, _ := d.GraphNames(); len(names) != 0 {
+		t.Errorf("dataset sees uncommitted graph %v", names)
+	}
+	if _, err := tx.Begin(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("nested Begin error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := d.GraphNames(); len(names) != 1 {
+		t.Errorf("graph names after commit = %v", names)
+	}
+
+	// The snapshot still sees the dataset before the transaction.
+	if ok, _ := snap.Contains(Statement{S: ex("a"), P: ex("p"), O: Literal{Lexical: "1"}}); !ok {
+		t.Error("snapshot lost a statement deleted after it")
+	}
+	if names, _ := snap.GraphNames(); len(names) != 0 {
+		t.Errorf("snapshot graph names = %v", names)
+	}
+	if err := snap.Add(Statement{S: ex("b"), P: ex("p"), O: ex("c")}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("snapshot Add error = %v, want ErrReadOnly", err)
+	}
+
+	if _, err := NewDatasetOn(&sliceQuadStore{}).Snapshot(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Snapshot of a store without snapshots error = %v", err)
+	}
+}
+
+func TestDatasetConcurrentReaders(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	d := NewDataset()
+	const batch = 50
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 20 {
+			tx, err := d.Begin()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for j := range batch {
+				tx.Add(Statement{S: ex(fmt.Sprint(i)), P: ex("p"), O: Literal{Lexical: fmt.Sprint(j)}})
+			}
+			tx.Commit()
+		}
+	}()
+	// Readers see whole batches only.
+	for {
+		snap, err := d.Snapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		for _, err := range snap.Match(QuadPattern{}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			n++
+		}
+		snap.Close()
+		if n%batch != 0 {
+			t.Fatalf("snapshot holds %d statements, not whole batches", n)
+		}
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+}
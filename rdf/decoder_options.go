@@ -3,10 +3,12 @@ package rdf
 import "context"
 
 const (
-	DefaultMaxLineBytes      = 1 << 20    // 1MB
-	DefaultMaxStatementBytes = 4 << 20    // 4MB
-	DefaultMaxDepth          = 100        // Maximum nesting depth for collections, blank node lists, etc.
-	DefaultMaxTriples        = 10_000_000 // Maximum number of triples/quads to process (0 = unlimited)
+	DefaultMaxLineBytes       = 1 << 20    // 1MB
+	DefaultMaxStatementBytes  = 4 << 20    // 4MB
+	DefaultMaxDepth           = 100        // Maximum nesting depth for collections, blank node lists, etc.
+	DefaultMaxTriples         = 10_000_000 // Maximum number of triples/quads to process (0 = unlimited)
+	DefaultMaxEntityDepth     = 8          // Maximum nesting of entity references in RDF/XML DTD entities
+	DefaultMaxEntityExpansion = 1 << 20    // 1MB of text produced by RDF/XML entity expansion per document
 )
 
 // decodeOptions configures parser behavior and limits.
@@ -23,6 +25,27 @@ type decodeOptions struct {
 	// MaxTriples limits the total number of triples/quads to process.
 	// Zero uses default (10M). Negative values disable the limit (not recommended for untrusted input).
 	MaxTriples int64
+	// MaxLiteralLength limits the bytes of a literal lexical form. Zero or negative means unlimited.
+	MaxLiteralLength int
+	// MaxIRILength limits the bytes of an IRI. Zero or negative means unlimited.
+	MaxIRILength int
+	// MaxBlankNodes limits the number of distinct blank nodes. Zero or negative means unlimited.
+	MaxBlankNodes int
+	// MaxPrefixCount limits the number of prefix and namespace declarations.
+	// Zero or negative means unlimited.
+	MaxPrefixCount int
+	// MaxBufferedBytes limits the bytes of statements a decoder holds before
+	// returning them. Zero or negative means unlimited.
+	MaxBufferedBytes int64
+	// DisallowDTD rejects RDF/XML documents with a DOCTYPE declaration.
+	DisallowDTD bool
+	// MaxEntityDepth limits how deeply entities declared in an RDF/XML DTD
+	// may refer to other entities. Zero uses default (8). Negative values disable the limit.
+	MaxEntityDepth int
+	// MaxEntityExpansion limits the bytes of text produced by expanding DTD
+	// entities, per entity and per document. Zero uses default (1MB).
+	// Negative values disable the limit (not recommended for untrusted input).
+	MaxEntityExpansion int
 	// AllowQuotedTripleStatement enables quoted triple statements in Turtle/TriG.
 	AllowQuotedTripleStatement bool
 	// DebugStatements wraps parse errors with the offending statement.
@@ -38,6 +61,35 @@ type decodeOptions struct {
 	// When enabled (default), container elements automatically generate container
 	// membership properties (rdf:_1, rdf:_2, etc.) from rdf:li elements.
 	ExpandRDFXMLContainers bool
+	// RDFXMLReifiers makes rdf:ID on RDF/XML property elements name an
+	// RDF 1.2 reifier instead of an rdf:Statement reification.
+	RDFXMLReifiers bool
+	// BaseIRI is the document base IRI used to resolve relative IRIs until
+	// the document declares its own (@base, xml:base or @context @base).
+	BaseIRI string
+	// Parallelism is the number of worker goroutines used by the N-Triples and
+	// N-Quads decoders. Values below 2 select the sequential decoder.
+	Parallelism int
+	// errors collects skipped syntax errors when error recovery is enabled.
+	errors *recoveryErrors
+	// warnings receives non-fatal diagnostics when OptWarnings is set.
+	warnings func(Warning)
+	// onPrefix and onBase receive the prefix and base declarations of
+	// Turtle and TriG documents when OptOnPrefix and OptOnBase are set.
+	onPrefix func(prefix, iri string)
+	onBase   func(iri string)
+	// events receives the directives, graph blocks, comments and statement
+	// positions of Turtle and TriG documents for NewEventReader.
+	events eventSink
+	// validateIRIs rejects statements with invalid IRIs when OptValidateIRIs is set.
+	validateIRIs bool
+	// resume is the state set by OptResumeFrom, or nil to start at the beginning.
+	resume *DecoderState
+	// arena reads N-Triples and N-Quads lines into a reused buffer when OptArena is set.
+	arena bool
+	// generalized accepts generalized RDF in N-Triples, N-Quads and JSON-LD
+	// when OptGeneralizedRDF is set.
+	generalized bool
 }
 
 // defaultDecodeOptions returns safe defaults for parser limits.
@@ -48,6 +100,8 @@ func defaultDecodeOptions() decodeOptions {
 		MaxStatementBytes:      DefaultMaxStatementBytes,
 		MaxDepth:               DefaultMaxDepth,
 		MaxTriples:             DefaultMaxTriples,
+		MaxEntityDepth:         DefaultMaxEntityDepth,
+		MaxEntityExpansion:     DefaultMaxEntityExpansion,
 		ExpandRDFXMLContainers: true, // Container expansion enabled by default
 	}
 }
@@ -55,10 +109,17 @@ func defaultDecodeOptions() decodeOptions {
 // safeDecodeOptions returns stricter limits suitable for untrusted input.
 func safeDecodeOptions() decodeOptions {
 	return decodeOptions{
-		MaxLineBytes:      64 << 10,  // 64KB per line
-		MaxStatementBytes: 256 << 10, // 256KB per statement
-		MaxDepth:          50,        // 50 levels of nesting
-		MaxTriples:        1_000_000, // 1M triples
+		MaxLineBytes:       64 << 10,  // 64KB per line
+		MaxStatementBytes:  256 << 10, // 256KB per statement
+		MaxDepth:           50,        // 50 levels of nesting
+		MaxTriples:         1_000_000, // 1M triples
+		MaxLiteralLength:   64 << 10,  // 64KB per literal
+		MaxIRILength:       8 << 10,   // 8KB per IRI
+		MaxBlankNodes:      1_000_000, // 1M blank nodes
+		MaxPrefixCount:     1_000,     // 1000 prefix declarations
+		DisallowDTD:        true,
+		MaxEntityDepth:     4,
+		MaxEntityExpansion: 64 << 10, // 64KB of expanded entity text
 	}
 }
 
@@ -75,6 +136,12 @@ func normalizeDecodeOptions(opts decodeOptions) decodeOptions {
 	if opts.MaxTriples == 0 {
 		opts.MaxTriples = DefaultMaxTriples
 	}
+	if opts.MaxEntityDepth == 0 {
+		opts.MaxEntityDepth = DefaultMaxEntityDepth
+	}
+	if opts.MaxEntityExpansion == 0 {
+		opts.MaxEntityExpansion = DefaultMaxEntityExpansion
+	}
 	// ExpandRDFXMLContainers is handled by defaultOptions() which sets it to true
 	// If it's false here, it means OptDisableRDFXMLContainerExpansion() was called
 	// So we respect the user's choice and don't override it
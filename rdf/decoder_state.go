@@ -0,0 +1,171 @@
+package rdf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// DecoderState is the logical position of a reader plus the directive state
+// needed to continue parsing from there without the preceding input: the
+// prefixes and base IRI in effect, the enclosing TriG graph block and the
+// counter for generated blank node labels. A coordinator can record states
+// at statement boundaries of a large file, split it into byte ranges at
+// their offsets and hand each range to a worker reader created with
+// OptResumeFrom. States encode to self-contained JSON.
+//
+// Only Turtle, TriG, N-Triples and N-Quads readers export and resume states.
+type DecoderState struct {
+	Format Format
+	Offset int64 // Byte offset in the input at which parsing resumes
+	Line   int   // 1-based line number at Offset
+	Column int   // 1-based column number at Offset
+	// Skip is the number of statements of the statement at Offset that were
+	// already returned. It is non-zero when the reader stopped inside a
+	// Turtle or TriG statement that produced several triples; a resumed
+	// reader parses that statement again and drops them. Use states with
+	// Skip == 0 as split points for byte ranges.
+	Skip       int
+	Base       string
+	Prefixes   map[string]string
+	Graph      Term // Graph name of the enclosing TriG block (nil for the default graph)
+	InGraph    bool // Whether Offset lies inside a TriG graph block
+	BlankNodes int  // Number of blank node labels generated so far
+}
+
+// StateExporter is implemented by readers returned from NewReader. State
+// returns the position following the last statement returned by Next,
+// together with the directive state in effect there. It fails for formats
+// that cannot be resumed mid-document (RDF/XML, JSON-LD) and after a parse
+// error.
+type StateExporter interface {
+	State() (DecoderState, error)
+}
+
+// stateExporter is implemented by decoders that support DecoderState.
+type stateExporter interface {
+	exportState() (DecoderState, error)
+}
+
+// OptResumeFrom makes the reader continue from a state exported by
+// StateExporter.State. The input passed to NewReader must start at
+// state.Offset, for example an io.SectionReader over the original file.
+// Line, column and byte offsets in errors refer to the original input.
+// With FormatAuto the format of the state is used. Ordinals inserted by
+// graph and statement blank node scopes restart at zero.
+//
+// Since a byte range may end at a split point inside a TriG graph block, a
+// resumed reader accepts the end of its input inside a block.
+func OptResumeFrom(state DecoderState) Option {
+	return func(opts *Options) {
+		opts.ResumeFrom = &state
+	}
+}
+
+type decoderStateJSON struct {
+	Format     Format            `json:"format"`
+	Offset     int64             `json:"offset"`
+	Line       int               `json:"line"`
+	Column     int               `json:"column"`
+	Skip       int               `json:"skip,omitempty"`
+	Base       string            `json:"base,omitempty"`
+	Prefixes   map[string]string `json:"prefixes,omitempty"`
+	Graph      string            `json:"graph,omitempty"`
+	InGraph    bool              `json:"inGraph,omitempty"`
+	BlankNodes int               `json:"blankNodes,omitempty"`
+}
+
+// MarshalJSON encodes the state with the graph name in N-Triples syntax.
+func (s DecoderState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(decoderStateJSON{
+		Format:     s.Format,
+		Offset:     s.Offset,
+		Line:       s.Line,
+		Column:     s.Column,
+		Skip:       s.Skip,
+		Base:       s.Base,
+		Prefixes:   s.Prefixes,
+		Graph:      renderTerm(s.Graph),
+		InGraph:    s.InGraph,
+		BlankNodes: s.BlankNodes,
+	})
+}
+
+// UnmarshalJSON decodes a state encoded by MarshalJSON.
+func (s *DecoderState) UnmarshalJSON(data []byte) error {
+	var raw decoderStateJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var graph Term
+	if raw.Graph != "" {
+		cursor := &ntCursor{input: raw.Graph}
+		term, err := cursor.parseTerm(false)
+		if err != nil {
+			return fmt.Errorf("rdf: invalid decoder state graph %q: %w", raw.Graph, err)
+		}
+		if _, ok := term.(TripleTerm); ok {
+			return fmt.Errorf("rdf: invalid decoder state graph %q", raw.Graph)
+		}
+		graph = term
+	}
+	*s = DecoderState{
+		Format:     raw.Format,
+		Offset:     raw.Offset,
+		Line:       raw.Line,
+		Column:     raw.Column,
+		Skip:       raw.Skip,
+		Base:       raw.Base,
+		Prefixes:   raw.Prefixes,
+		Graph:      graph,
+		InGraph:    raw.InGraph,
+		BlankNodes: raw.BlankNodes,
+	}
+	return nil
+}
+
+// exportState reports where a resumed parser must start: at the start of
+// the statement whose triples are partly returned, at the lookahead token,
+// or at the current lexer position.
+func (p *turtleParser) exportState() (DecoderState, error) {
+	if p.err != nil && p.err != io.EOF {
+		return DecoderState{}, fmt.Errorf("rdf: no resumable state after error: %w", p.err)
+	}
+	state := DecoderState{
+		Base:       p.baseIRI,
+		Prefixes:   make(map[string]string, len(p.prefixes)),
+		Graph:      p.graph,
+		InGraph:    p.inGraph,
+		BlankNodes: p.blankNodeCounter,
+	}
+	for prefix, iri := range p.prefixes {
+		state.Prefixes[prefix] = iri
+	}
+	switch {
+	case len(p.pending) > 0:
+		state.Offset, state.Line, state.Column = int64(p.lexer.stmtStart), p.lexer.stmtLine, p.lexer.stmtColumn
+		state.Skip = p.stmtTriples - len(p.pending)
+		state.BlankNodes = p.stmtBlankNodes
+	case p.hasTok:
+		state.Offset, state.Line, state.Column = int64(p.tok.Offset), p.tok.Line, p.tok.Column
+	default:
+		state.Offset, state.Line, state.Column = int64(p.lexer.offset), p.lexer.line, p.lexer.column
+	}
+	return state, nil
+}
+
+// restoreState applies a resumed state before the first token is read.
+func (p *turtleParser) restoreState(state *DecoderState) {
+	for prefix, iri := range state.Prefixes {
+		p.prefixes[prefix] = iri
+	}
+	p.baseIRI = state.Base
+	p.graph, p.inGraph = state.Graph, state.InGraph
+	p.blankNodeCounter = state.BlankNodes
+	p.skip = state.Skip
+	p.resumed = true
+	p.lexer.offset = int(state.Offset)
+	if state.Line > 0 && state.Column > 0 {
+		p.lexer.line, p.lexer.column = state.Line, state.Column
+	}
+}
@@ -0,0 +1,208 @@
+package rdf
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const decoderStateTurtle = `@prefix ex: <http://example.org/> .
+@base <http://example.org/base/> .
+# comment
+ex:s ex:p "a"@en , "b" ; ex:q [ ex:r ( 1 2 ) ] .
+<rel> ex:p _:x .
+@prefix ex: <http://example.com/> .
+ex:t ex:p """long
+literal""" .
+`
+
+const decoderStateTriG = `@prefix ex: <http://example.org/> .
+ex:g1 { ex:s ex:p ex:o1 , ex:o2 . ex:s ex:q [ ex:r 1 ] }
+{ ex:d ex:p ex:o }
+ex:s ex:p ex:o .
+GRAPH _:g2 { ex:s ex:p ex:o3 }
+`
+
+const decoderStateNQuads = `<http://example.org/s> <http://example.org/p> "a" <http://example.org/g> .
+# comment
+
+<http://example.org/s> <http://example.org/p> _:b .
+<http://example.org/s> <http://example.org/p> "c" .
+`
+
+func renderStatement(stmt Statement) string {
+	line := renderTerm(stmt.S) + " " + renderTerm(stmt.P) + " " + renderTerm(stmt.O)
+	if stmt.G != nil {
+		line += " " + renderTerm(stmt.G)
+	}
+	return line
+}
+
+func readRemaining(t *testing.T, reader Reader) []string {
+	t.Helper()
+	var out []string
+	for {
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			return out
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out = append(out, renderStatement(stmt))
+	}
+}
+
+// TestDecoderStateResume exports the state before every statement, encodes
+// it as JSON and checks that a reader resumed from it over the rest of the
+// input returns exactly the remaining statements.
+func TestDecoderStateResume(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		opts   []Option
+	}{
+		{"turtle", FormatTurtle, decoderStateTurtle, nil},
+		{"trig", FormatTriG, decoderStateTriG, nil},
+		{"nquads", FormatNQuads, decoderStateNQuads, nil},
+		{"nquads parallel", FormatNQuads, decoderStateNQuads, []Option{OptParallelism(2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(tt.input), tt.format, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer reader.Close()
+			var all []string
+			var states []DecoderState
+			for {
+				state, err := reader.(StateExporter).State()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				states = append(states, state)
+				stmt, err := reader.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				all = append(all, renderStatement(stmt))
+			}
+			for i, state := range states {
+				data, err := json.Marshal(state)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				var decoded DecoderState
+				if err := json.Unmarshal(data, &decoded); err != nil {
+					t.Fatalf("unexpected error decoding %s: %v", data, err)
+				}
+				resumed, err := NewReader(strings.NewReader(tt.input[decoded.Offset:]), FormatAuto, append(tt.opts, OptResumeFrom(decoded))...)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got := readRemaining(t, resumed)
+				resumed.Close()
+				if strings.Join(got, "\n") != strings.Join(all[i:], "\n") {
+					t.Fatalf("resuming from %s:\ngot:\n%s\nwant:\n%s", data, strings.Join(got, "\n"), strings.Join(all[i:], "\n"))
+				}
+			}
+		})
+	}
+}
+
+func TestDecoderStateSplitRanges(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(decoderStateTriG), FormatTriG)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	var all []string
+	var splits []DecoderState
+	for {
+		state, err := reader.(StateExporter).State()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if state.Skip == 0 {
+			splits = append(splits, state)
+		}
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		all = append(all, renderStatement(stmt))
+	}
+	var got []string
+	for i, state := range splits {
+		end := int64(len(decoderStateTriG))
+		if i+1 < len(splits) {
+			end = splits[i+1].Offset
+		}
+		worker, err := NewReader(io.NewSectionReader(strings.NewReader(decoderStateTriG), state.Offset, end-state.Offset), FormatTriG, OptResumeFrom(state))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, readRemaining(t, worker)...)
+		worker.Close()
+	}
+	if strings.Join(got, "\n") != strings.Join(all, "\n") {
+		t.Fatalf("split ranges returned:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(all, "\n"))
+	}
+}
+
+func TestDecoderStateErrors(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(`{"@id": "http://example.org/s"}`), FormatJSONLD)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := reader.(StateExporter).State(); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+	reader.Close()
+
+	if _, err := NewReader(strings.NewReader(""), FormatRDFXML, OptResumeFrom(DecoderState{Format: FormatRDFXML})); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+	if _, err := NewReader(strings.NewReader(""), FormatNTriples, OptResumeFrom(DecoderState{Format: FormatTurtle})); err == nil {
+		t.Fatal("expected format mismatch error")
+	}
+
+	reader, err = NewReader(strings.NewReader("<http://example.org/s> <http://example.org/p> .\n"), FormatNTriples)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	if _, err := reader.Next(); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if _, err := reader.(StateExporter).State(); err == nil {
+		t.Fatal("expected no state after a parse error")
+	}
+}
+
+func TestDecoderStateResumedPositions(t *testing.T) {
+	state := DecoderState{Format: FormatTurtle, Offset: 100, Line: 7, Column: 3, Prefixes: map[string]string{"ex": "http://example.org/"}}
+	reader, err := NewReader(strings.NewReader("ex:s ex:p ex:o .\nex:s ex:p ."), FormatTurtle, OptResumeFrom(state))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	if _, err := reader.Next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = reader.Next()
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 8 || parseErr.ByteOffset != 127 {
+		t.Fatalf("expected error at line 8, offset 127, got %v", err)
+	}
+}
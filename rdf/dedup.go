@@ -0,0 +1,477 @@
+package rdf
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"sort"
+)
+
+// DefaultDedupMemoryLimit is the default number of bytes Deduplicate uses
+// for statement hashes before spilling them to disk.
+const DefaultDedupMemoryLimit = 256 << 20
+
+const (
+	// dedupEntryBytes estimates the memory used by one hash in the
+	// in-memory set, including map overhead.
+	dedupEntryBytes = 48
+	// dedupBlockKeys is the number of hashes in a block of a spill file,
+	// one 4 KiB page; the first hash of each block is kept in memory.
+	dedupBlockKeys = 256
+	// dedupMergeFanIn spill files of one level are merged into one file of
+	// the next level, which bounds the number of files to look up.
+	dedupMergeFanIn = 4
+	// defaultBloomFalsePositiveRate applies to invalid rates given to
+	// OptDedupBloomFilter.
+	defaultBloomFalsePositiveRate = 0.01
+)
+
+// DedupOption configures Deduplicate.
+type DedupOption func(*dedupOptions)
+
+type dedupOptions struct {
+	memoryLimit int64
+	tempDir     string
+	bloom       bool
+	expected    int64
+	rate        float64
+}
+
+// OptDedupMemoryLimit sets the number of bytes Deduplicate uses for
+// statement hashes in exact mode before spilling them to temporary files
+// (default DefaultDedupMemoryLimit, 0 = never spill).
+func OptDedupMemoryLimit(bytes int64) DedupOption {
+	return func(opts *dedupOptions) {
+		opts.memoryLimit = bytes
+	}
+}
+
+// OptDedupTempDir sets the directory of the files Deduplicate spills to
+// (default os.TempDir()).
+func OptDedupTempDir(dir string) DedupOption {
+	return func(opts *dedupOptions) {
+		opts.tempDir = dir
+	}
+}
+
+// OptDedupBloomFilter makes Deduplicate remember statements in a Bloom
+// filter sized for expected distinct statements with the given false
+// positive rate, instead of in an exact set. A rate outside (0, 1) means 1%.
+func OptDedupBloomFilter(expected int64, falsePositiveRate float64) DedupOption {
+	return func(opts *dedupOptions) {
+		opts.bloom = true
+		opts.expected = expected
+		opts.rate = falsePositiveRate
+	}
+}
+
+// Deduplicate drops every statement equal to one returned earlier in the
+// stream. Statements are remembered by a 128-bit SHA-256 hash of their
+// terms, whose collisions are too unlikely to matter.
+//
+// By default the set of hashes is exact. It is held in memory up to the
+// limit set by OptDedupMemoryLimit; beyond it, hashes are written to sorted
+// files in OptDedupTempDir, which are merged as they accumulate and searched
+// for each new statement by reading one 4 KiB block per file. Memory then
+// grows only by the file index, about one byte per 16 spilled statements.
+// Errors reading or writing the files are returned by Next, and the files
+// are removed by Close.
+//
+// With OptDedupBloomFilter(n, p), hashes go into a Bloom filter of
+// -n·ln(p)/ln²(2) bits instead (1.2 bytes per statement for p = 1%, 1.8 for
+// 0.1%) and no disk is used. Duplicates are always dropped, but a statement
+// seen for the first time is also dropped with probability up to p while at
+// most n distinct statements have passed, and with growing probability
+// after that. This suits noisy crawl output where losing a few statements is
+// acceptable.
+//
+// To drop only nearby duplicates in constant memory, use DeduplicateWindow.
+func Deduplicate(opts ...DedupOption) Transform {
+	options := dedupOptions{memoryLimit: DefaultDedupMemoryLimit}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return func(r Reader) Reader {
+		var set dedupSet
+		if options.bloom {
+			set = newBloomFilter(options.expected, options.rate)
+		} else {
+			capacity := 0
+			if options.memoryLimit > 0 {
+				capacity = int(max(1, options.memoryLimit/dedupEntryBytes))
+			}
+			set = &spillSet{dir: options.tempDir, capacity: capacity, memory: make(map[statementHash]struct{})}
+		}
+		return &dedupSetReader{src: r, set: set}
+	}
+}
+
+// statementHash identifies a statement in Deduplicate.
+type statementHash [16]byte
+
+func hashStatement(s Statement) statementHash {
+	var scratch [256]byte
+	key := appendTermRecord(scratch[:0], s.S)
+	key = appendTermRecord(key, s.P)
+	key = appendTermRecord(key, s.O)
+	key = appendTermRecord(key, s.G)
+	sum := sha256.Sum256(key)
+	return statementHash(sum[:16])
+}
+
+// appendTermRecord appends an unambiguous encoding of t to buf: a kind byte
+// followed by the length-prefixed fields of the term. decodeTermRecord reads
+// it back, except for Term implementations of other packages.
+func appendTermRecord(buf []byte, t Term) []byte {
+	field := func(buf []byte, s string) []byte {
+		buf = binary.AppendUvarint(buf, uint64(len(s)))
+		return append(buf, s...)
+	}
+	switch t := t.(type) {
+	case nil:
+		return append(buf, 0)
+	case IRI:
+		return field(append(buf, 'I'), t.Value)
+	case BlankNode:
+		return field(append(buf, 'B'), t.ID)
+	case Literal:
+		buf = field(append(buf, 'L'), t.Lexical)
+		buf = field(buf, t.Datatype.Value)
+		buf = field(buf, t.Lang)
+		return field(buf, t.Direction)
+	case TripleTerm:
+		buf = appendTermRecord(append(buf, 'T'), t.S)
+		buf = appendTermRecord(buf, t.P)
+		return appendTermRecord(buf, t.O)
+	default:
+		return field(append(buf, 'X'), t.String())
+	}
+}
+
+// decodeTermRecord decodes the term encoded by appendTermRecord at the start of
+// buf and returns it with the rest of buf.
+func decodeTermRecord(buf []byte) (Term, []byte, error) {
+	var err error
+	field := func() string {
+		n, size := binary.Uvarint(buf)
+		if err != nil || size <= 0 || uint64(len(buf)-size) < n {
+			err = errInvalidTermRecord
+			return ""
+		}
+		value := string(buf[size : size+int(n)])
+		buf = buf[size+int(n):]
+		return value
+	}
+	if len(buf) == 0 {
+		return nil, nil, errInvalidTermRecord
+	}
+	kind := buf[0]
+	buf = buf[1:]
+	var t Term
+	switch kind {
+	case 0:
+		return nil, buf, nil
+	case 'I':
+		t = IRI{Value: field()}
+	case 'B':
+		t = BlankNode{ID: field()}
+	case 'L':
+		t = Literal{Lexical: field(), Datatype: IRI{Value: field()}, Lang: field(), Direction: field()}
+	case 'T':
+		var s, p, o Term
+		if s, buf, err = decodeTermRecord(buf); err != nil {
+			return nil, nil, err
+		}
+		if p, buf, err = decodeTermRecord(buf); err != nil {
+			return nil, nil, err
+		}
+		if o, buf, err = decodeTermRecord(buf); err != nil {
+			return nil, nil, err
+		}
+		predicate, ok := p.(IRI)
+		if !ok {
+			return nil, nil, errInvalidTermRecord
+		}
+		t = TripleTerm{S: s, P: predicate, O: o}
+	default:
+		return nil, nil, errInvalidTermRecord
+	}
+	return t, buf, err
+}
+
+var errInvalidTermRecord = errors.New("rdf: invalid term encoding")
+
+// dedupSet is the set of statement hashes seen by Deduplicate.
+type dedupSet interface {
+	// add adds h and reports whether it was not in the set yet.
+	add(h statementHash) (bool, error)
+	close() error
+}
+
+// dedupSetReader drops statements whose hash is already in set.
+type dedupSetReader struct {
+	src Reader
+	set dedupSet
+	err error
+}
+
+func (d *dedupSetReader) Next() (Statement, error) {
+	if d.err != nil {
+		return Statement{}, d.err
+	}
+	for {
+		stmt, err := d.src.Next()
+		if err != nil {
+			return Statement{}, err
+		}
+		added, err := d.set.add(hashStatement(stmt))
+		if err != nil {
+			d.err = fmt.Errorf("rdf: deduplicate: %w", err)
+			return Statement{}, d.err
+		}
+		if added {
+			return stmt, nil
+		}
+	}
+}
+
+func (d *dedupSetReader) Close() error {
+	err := d.src.Close()
+	if cerr := d.set.close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// spillSet is an exact set of hashes that keeps up to capacity hashes in
+// memory (0 = unlimited) and the others in sorted runs on disk. Runs are
+// ordered from oldest to newest, with non-increasing levels; a run of
+// level l holds about dedupMergeFanIn^l spills.
+type spillSet struct {
+	dir      string
+	capacity int
+	memory   map[statementHash]struct{}
+	runs     []*spillRun
+}
+
+// spillRun is a file of sorted, distinct hashes.
+type spillRun struct {
+	file  *os.File
+	level int
+	count int
+	index []statementHash // First hash of each block
+	block [dedupBlockKeys * len(statementHash{})]byte
+}
+
+func (s *spillSet) add(h statementHash) (bool, error) {
+	if _, ok := s.memory[h]; ok {
+		return false, nil
+	}
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		if found, err := s.runs[i].contains(h); found || err != nil {
+			return false, err
+		}
+	}
+	s.memory[h] = struct{}{}
+	if s.capacity > 0 && len(s.memory) >= s.capacity {
+		return true, s.spill()
+	}
+	return true, nil
+}
+
+// spill writes the hashes in memory to a new run of level 0 and merges
+// the newest runs while dedupMergeFanIn of them share a level.
+func (s *spillSet) spill() error {
+	hashes := make([]statementHash, 0, len(s.memory))
+	for h := range s.memory {
+		hashes = append(hashes, h)
+	}
+	slices.SortFunc(hashes, func(a, b statementHash) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	run, err := s.writeRun(0, func() (statementHash, bool, error) {
+		if len(hashes) == 0 {
+			return statementHash{}, false, nil
+		}
+		h := hashes[0]
+		hashes = hashes[1:]
+		return h, true, nil
+	})
+	if err != nil {
+		return err
+	}
+	clear(s.memory)
+	s.runs = append(s.runs, run)
+	for n := len(s.runs); n >= dedupMergeFanIn; n = len(s.runs) {
+		tail := s.runs[n-dedupMergeFanIn:]
+		if tail[0].level != tail[len(tail)-1].level {
+			break
+		}
+		merged, err := s.merge(tail)
+		if err != nil {
+			return err
+		}
+		s.runs = append(s.runs[:n-dedupMergeFanIn], merged)
+	}
+	return nil
+}
+
+// writeRun writes the hashes returned by next, in order, to a new run.
+func (s *spillSet) writeRun(level int, next func() (statementHash, bool, error)) (*spillRun, error) {
+	file, err := os.CreateTemp(s.dir, "rdf-dedup-*")
+	if err != nil {
+		return nil, err
+	}
+	run := &spillRun{file: file, level: level}
+	w := bufio.NewWriterSize(file, 64<<10)
+	for {
+		h, ok, err := next()
+		if err == nil && ok {
+			if run.count%dedupBlockKeys == 0 {
+				run.index = append(run.index, h)
+			}
+			run.count++
+			_, err = w.Write(h[:])
+		}
+		if err == nil && !ok {
+			err = w.Flush()
+		}
+		if err != nil {
+			run.remove()
+			return nil, err
+		}
+		if !ok {
+			return run, nil
+		}
+	}
+}
+
+// merge merges runs into one run of the next level and removes them.
+func (s *spillSet) merge(runs []*spillRun) (*spillRun, error) {
+	readers := make([]*bufio.Reader, len(runs))
+	heads := make([]statementHash, len(runs))
+	live := make([]bool, len(runs))
+	advance := func(i int) error {
+		_, err := io.ReadFull(readers[i], heads[i][:])
+		live[i] = err == nil
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	for i, run := range runs {
+		readers[i] = bufio.NewReaderSize(io.NewSectionReader(run.file, 0, int64(run.count*len(statementHash{}))), 64<<10)
+		if err := advance(i); err != nil {
+			return nil, err
+		}
+	}
+	merged, err := s.writeRun(runs[0].level+1, func() (statementHash, bool, error) {
+		lowest := -1
+		for i := range runs {
+			if live[i] && (lowest < 0 || bytes.Compare(heads[i][:], heads[lowest][:]) < 0) {
+				lowest = i
+			}
+		}
+		if lowest < 0 {
+			return statementHash{}, false, nil
+		}
+		h := heads[lowest]
+		return h, true, advance(lowest)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		if err := run.remove(); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// contains reports whether h is in the run, reading the one block that
+// may hold it.
+func (r *spillRun) contains(h statementHash) (bool, error) {
+	i := sort.Search(len(r.index), func(i int) bool {
+		return bytes.Compare(r.index[i][:], h[:]) > 0
+	}) - 1
+	if i < 0 {
+		return false, nil
+	}
+	size := len(statementHash{})
+	n := min(dedupBlockKeys, r.count-i*dedupBlockKeys)
+	block := r.block[:n*size]
+	if _, err := r.file.ReadAt(block, int64(i*dedupBlockKeys*size)); err != nil {
+		return false, err
+	}
+	j := sort.Search(n, func(j int) bool {
+		return bytes.Compare(block[j*size:(j+1)*size], h[:]) >= 0
+	})
+	return j < n && bytes.Equal(block[j*size:(j+1)*size], h[:]), nil
+}
+
+func (r *spillRun) remove() error {
+	err := r.file.Close()
+	if rerr := os.Remove(r.file.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+func (s *spillSet) close() error {
+	var err error
+	for _, run := range s.runs {
+		if rerr := run.remove(); err == nil {
+			err = rerr
+		}
+	}
+	s.runs = nil
+	return err
+}
+
+// bloomFilter is a Bloom filter of statement hashes, probed with double
+// hashing on the two halves of the hash (Kirsch and Mitzenmacher, "Less
+// hashing, same performance: building a better Bloom filter", 2006).
+type bloomFilter struct {
+	bits   []uint64
+	probes int
+}
+
+// newBloomFilter returns a Bloom filter with the optimal number of bits and
+// probes for expected elements and the false positive rate.
+func newBloomFilter(expected int64, rate float64) *bloomFilter {
+	expected = max(1, expected)
+	if !(rate > 0 && rate < 1) {
+		rate = defaultBloomFalsePositiveRate
+	}
+	bits := math.Ceil(-float64(expected) * math.Log(rate) / (math.Ln2 * math.Ln2))
+	probes := max(1, int(math.Round(bits/float64(expected)*math.Ln2)))
+	return &bloomFilter{bits: make([]uint64, (int64(bits)+63)/64), probes: probes}
+}
+
+func (b *bloomFilter) add(h statementHash) (bool, error) {
+	size := uint64(len(b.bits)) * 64
+	h1 := binary.LittleEndian.Uint64(h[:8])
+	h2 := binary.LittleEndian.Uint64(h[8:]) | 1
+	added := false
+	for i := range uint64(b.probes) {
+		bit := (h1 + i*h2) % size
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if b.bits[word]&mask == 0 {
+			b.bits[word] |= mask
+			added = true
+		}
+	}
+	return added, nil
+}
+
+func (b *bloomFilter) close() error {
+	return nil
+}
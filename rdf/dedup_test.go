@@ -0,0 +1,105 @@
+package rdf
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// dedupInput returns n distinct statements, each repeated three times in a
+// shuffled order, and the distinct statements.
+func dedupInput(n int) (input, distinct []Statement) {
+	p := IRI{Value: "http://example.org/p"}
+	for i := range n {
+		s := NewTriple(BlankNode{ID: "b" + strconv.Itoa(i%7)}, p, Literal{Lexical: strconv.Itoa(i)})
+		distinct = append(distinct, s)
+		input = append(input, s, s, s)
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(input), func(i, j int) {
+		input[i], input[j] = input[j], input[i]
+	})
+	return input, distinct
+}
+
+func TestDeduplicate(t *testing.T) {
+	s := IRI{Value: "http://example.org/s"}
+	p := IRI{Value: "http://example.org/p"}
+	g := IRI{Value: "http://example.org/g"}
+	stmts := []Statement{
+		NewTriple(s, p, Literal{Lexical: "a"}),
+		NewTriple(s, p, Literal{Lexical: "a", Lang: "en"}),
+		NewQuad(s, p, Literal{Lexical: "a"}, g),
+		NewTriple(s, p, Literal{Lexical: "a"}),
+		NewTriple(s, p, TripleTerm{S: s, P: p, O: Literal{Lexical: "a"}}),
+		NewTriple(s, p, TripleTerm{S: s, P: p, O: Literal{Lexical: "a"}}),
+		NewQuad(s, p, Literal{Lexical: "a", Lang: "en"}, g),
+	}
+	out := pipeStatements(t, stmts, Deduplicate())
+	want := []Statement{stmts[0], stmts[1], stmts[2], stmts[4], stmts[6]}
+	if len(out) != len(want) {
+		t.Fatalf("got %d statements, want %d: %v", len(out), len(want), out)
+	}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Errorf("statement %d = %v, want %v", i, out[i], want[i])
+		}
+	}
+}
+
+func TestDeduplicateSpill(t *testing.T) {
+	dir := t.TempDir()
+	input, distinct := dedupInput(5000)
+	// 37 hashes fit in memory, so the run files are merged over several
+	// levels and span several blocks.
+	reader := Pipe(&stubStatementReader{stmts: input}, Deduplicate(OptDedupMemoryLimit(37*dedupEntryBytes), OptDedupTempDir(dir)))
+	out, err := collectStatements(reader)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(out) != len(distinct) {
+		t.Fatalf("got %d statements, want %d", len(out), len(distinct))
+	}
+	seen := make(map[Statement]bool)
+	for _, s := range out {
+		if seen[s] {
+			t.Fatalf("duplicate %v", s)
+		}
+		seen[s] = true
+	}
+	if files, _ := os.ReadDir(dir); len(files) == 0 {
+		t.Fatal("expected spill files before Close")
+	}
+	if err := reader.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("spill files left after Close: %d", len(files))
+	}
+}
+
+func TestDeduplicateSpillError(t *testing.T) {
+	input, _ := dedupInput(10)
+	reader := Pipe(&stubStatementReader{stmts: input}, Deduplicate(OptDedupMemoryLimit(1), OptDedupTempDir(filepath.Join(t.TempDir(), "missing"))))
+	defer reader.Close()
+	if _, err := collectStatements(reader); err == nil {
+		t.Fatal("expected an error creating the spill file")
+	}
+}
+
+func TestDeduplicateBloomFilter(t *testing.T) {
+	input, distinct := dedupInput(20000)
+	out := pipeStatements(t, input, Deduplicate(OptDedupBloomFilter(int64(len(distinct)), 0.01)))
+	seen := make(map[Statement]bool)
+	for _, s := range out {
+		if seen[s] {
+			t.Fatalf("duplicate %v", s)
+		}
+		seen[s] = true
+	}
+	// At most 1% of the distinct statements are lost as false positives.
+	if lost := len(distinct) - len(out); lost > len(distinct)/100 {
+		t.Errorf("lost %d of %d statements", lost, len(distinct))
+	}
+}
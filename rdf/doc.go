@@ -95,6 +95,9 @@
 // Security:
 //
 // For untrusted input, use SafeMode() or OptSafeLimits() to enforce conservative limits.
+// For RDF/XML, the safe limits also reject DOCTYPE declarations; otherwise
+// internal DTD entities are expanded within OptMaxEntityDepth and
+// OptMaxEntityExpansion and external entities are never resolved.
 // Default limits are suitable for trusted input but may be too permissive for untrusted data.
 //
 // Documentation:
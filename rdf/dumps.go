@@ -0,0 +1,291 @@
+package rdf
+
+import (
+	"io"
+	"runtime"
+	"strings"
+)
+
+// DumpProfile describes the layout of a well-known public RDF dump.
+type DumpProfile struct {
+	// Name identifies the profile (e.g., "wikidata-truthy").
+	Name string
+	// Format is the serialization used by the dump files.
+	Format Format
+	// Prefixes is the prefix table used by the dump publisher.
+	Prefixes map[string]string
+	// PropertyNamespaces lists the namespaces whose local names are property
+	// identifiers (e.g., "P31" under http://www.wikidata.org/prop/direct/).
+	PropertyNamespaces []string
+}
+
+var wikidataPrefixes = map[string]string{
+	"wd":       "http://www.wikidata.org/entity/",
+	"wds":      "http://www.wikidata.org/entity/statement/",
+	"wdv":      "http://www.wikidata.org/value/",
+	"wdref":    "http://www.wikidata.org/reference/",
+	"wdt":      "http://www.wikidata.org/prop/direct/",
+	"wdtn":     "http://www.wikidata.org/prop/direct-normalized/",
+	"p":        "http://www.wikidata.org/prop/",
+	"ps":       "http://www.wikidata.org/prop/statement/",
+	"psv":      "http://www.wikidata.org/prop/statement/value/",
+	"psn":      "http://www.wikidata.org/prop/statement/value-normalized/",
+	"pq":       "http://www.wikidata.org/prop/qualifier/",
+	"pqv":      "http://www.wikidata.org/prop/qualifier/value/",
+	"pqn":      "http://www.wikidata.org/prop/qualifier/value-normalized/",
+	"pr":       "http://www.wikidata.org/prop/reference/",
+	"prv":      "http://www.wikidata.org/prop/reference/value/",
+	"prn":      "http://www.wikidata.org/prop/reference/value-normalized/",
+	"wdno":     "http://www.wikidata.org/prop/novalue/",
+	"wikibase": "http://wikiba.se/ontology#",
+	"schema":   "http://schema.org/",
+	"rdf":      "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"rdfs":     "http://www.w3.org/2000/01/rdf-schema#",
+	"xsd":      "http://www.w3.org/2001/XMLSchema#",
+	"owl":      "http://www.w3.org/2002/07/owl#",
+	"skos":     "http://www.w3.org/2004/02/skos/core#",
+	"prov":     "http://www.w3.org/ns/prov#",
+	"geo":      "http://www.opengis.net/ont/geosparql#",
+}
+
+// wikidataTruthyPrefixes are the prefixes of wikidataPrefixes used in the
+// truthy dump, which has no statement, value or reference nodes.
+var wikidataTruthyPrefixes = pickPrefixes(wikidataPrefixes,
+	"wd", "wdt", "wdtn", "wdno", "wikibase", "schema", "rdf", "rdfs", "xsd", "owl", "skos", "geo")
+
+// wikidataTruthyPropertyNamespaces are the namespaces of the direct claims
+// of the truthy dump.
+var wikidataTruthyPropertyNamespaces = []string{
+	"http://www.wikidata.org/prop/direct/",
+	"http://www.wikidata.org/prop/direct-normalized/",
+}
+
+var wikidataPropertyNamespaces = []string{
+	"http://www.wikidata.org/prop/direct/",
+	"http://www.wikidata.org/prop/direct-normalized/",
+	"http://www.wikidata.org/prop/",
+	"http://www.wikidata.org/prop/statement/",
+	"http://www.wikidata.org/prop/statement/value/",
+	"http://www.wikidata.org/prop/statement/value-normalized/",
+	"http://www.wikidata.org/prop/qualifier/",
+	"http://www.wikidata.org/prop/qualifier/value/",
+	"http://www.wikidata.org/prop/qualifier/value-normalized/",
+	"http://www.wikidata.org/prop/reference/",
+	"http://www.wikidata.org/prop/reference/value/",
+	"http://www.wikidata.org/prop/reference/value-normalized/",
+	"http://www.wikidata.org/prop/novalue/",
+}
+
+var dbpediaPrefixes = map[string]string{
+	"dbo":    "http://dbpedia.org/ontology/",
+	"dbr":    "http://dbpedia.org/resource/",
+	"dbp":    "http://dbpedia.org/property/",
+	"foaf":   "http://xmlns.com/foaf/0.1/",
+	"dct":    "http://purl.org/dc/terms/",
+	"georss": "http://www.georss.org/georss/",
+	"geo":    "http://www.w3.org/2003/01/geo/wgs84_pos#",
+	"prov":   "http://www.w3.org/ns/prov#",
+	"rdf":    "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"rdfs":   "http://www.w3.org/2000/01/rdf-schema#",
+	"xsd":    "http://www.w3.org/2001/XMLSchema#",
+	"owl":    "http://www.w3.org/2002/07/owl#",
+	"skos":   "http://www.w3.org/2004/02/skos/core#",
+}
+
+var (
+	// WikidataTruthy describes the Wikidata "truthy" N-Triples dump
+	// (latest-truthy.nt), which contains direct claims and labels only. A
+	// bare property identifier such as "P31" matches its direct claims.
+	WikidataTruthy = DumpProfile{
+		Name:               "wikidata-truthy",
+		Format:             FormatNTriples,
+		Prefixes:           wikidataTruthyPrefixes,
+		PropertyNamespaces: wikidataTruthyPropertyNamespaces,
+	}
+	// WikidataFull describes the full Wikidata N-Triples dump (latest-all.nt),
+	// including statement nodes, qualifiers and references. A bare property
+	// identifier matches it in the statement, qualifier and reference
+	// namespaces as well as in direct claims.
+	WikidataFull = DumpProfile{
+		Name:               "wikidata-full",
+		Format:             FormatNTriples,
+		Prefixes:           wikidataPrefixes,
+		PropertyNamespaces: wikidataPropertyNamespaces,
+	}
+	// DBpedia describes the DBpedia N-Triples dumps.
+	DBpedia = DumpProfile{
+		Name:     "dbpedia",
+		Format:   FormatNTriples,
+		Prefixes: dbpediaPrefixes,
+		PropertyNamespaces: []string{
+			"http://dbpedia.org/ontology/",
+			"http://dbpedia.org/property/",
+		},
+	}
+)
+
+// pickPrefixes returns the entries of prefixes named by names.
+func pickPrefixes(prefixes map[string]string, names ...string) map[string]string {
+	picked := make(map[string]string, len(names))
+	for _, name := range names {
+		picked[name] = prefixes[name]
+	}
+	return picked
+}
+
+// DumpFilter selects statements from a dump by property and language.
+// Build filters with the chainable methods:
+//
+//	filter := rdf.NewDumpFilter().Properties("P31", "P279", "rdfs:label").Languages("en", "de")
+//
+// Properties accepts bare property identifiers (matched against the local name
+// in any of the profile's property namespaces), prefixed names expanded with
+// the profile's prefix table, or full IRIs. Languages keeps language-tagged
+// literals whose primary tag matches; literals without a language tag and
+// non-literal objects are always kept. An empty filter keeps everything.
+type DumpFilter struct {
+	properties []string
+	languages  []string
+}
+
+// NewDumpFilter returns an empty filter that keeps every statement.
+func NewDumpFilter() *DumpFilter {
+	return &DumpFilter{}
+}
+
+// Properties restricts the filter to statements whose predicate matches one of ids.
+func (f *DumpFilter) Properties(ids ...string) *DumpFilter {
+	f.properties = append(f.properties, ids...)
+	return f
+}
+
+// Languages restricts language-tagged literal objects to the given tags.
+func (f *DumpFilter) Languages(tags ...string) *DumpFilter {
+	f.languages = append(f.languages, tags...)
+	return f
+}
+
+// compile resolves the filter against a profile into a statement predicate.
+func (f *DumpFilter) compile(profile DumpProfile) func(Statement) bool {
+	if f == nil || (len(f.properties) == 0 && len(f.languages) == 0) {
+		return nil
+	}
+	iris := make(map[string]bool)
+	locals := make(map[string]bool)
+	for _, id := range f.properties {
+		switch {
+		case strings.Contains(id, "://") || strings.HasPrefix(id, "urn:"):
+			iris[id] = true
+		case strings.Contains(id, ":"):
+			parts := strings.SplitN(id, ":", 2)
+			if ns, ok := profile.Prefixes[parts[0]]; ok {
+				iris[ns+parts[1]] = true
+			} else {
+				iris[id] = true
+			}
+		default:
+			locals[id] = true
+		}
+	}
+	langs := make(map[string]bool)
+	for _, tag := range f.languages {
+		langs[strings.ToLower(tag)] = true
+	}
+	return func(s Statement) bool {
+		if len(iris) > 0 || len(locals) > 0 {
+			if !iris[s.P.Value] && !locals[profile.propertyID(s.P.Value)] {
+				return false
+			}
+		}
+		if len(langs) > 0 {
+			if lit, ok := s.O.(Literal); ok && lit.Lang != "" {
+				primary := strings.ToLower(lit.Lang)
+				if i := strings.IndexByte(primary, '-'); i >= 0 {
+					primary = primary[:i]
+				}
+				if !langs[primary] && !langs[strings.ToLower(lit.Lang)] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+// propertyID returns the local name of iri if it lives in one of the
+// profile's property namespaces, or "" otherwise.
+func (p DumpProfile) propertyID(iri string) string {
+	best := ""
+	for _, ns := range p.PropertyNamespaces {
+		if strings.HasPrefix(iri, ns) && len(ns) > len(best) {
+			best = ns
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	local := iri[len(best):]
+	if strings.ContainsAny(local, "/#") {
+		return ""
+	}
+	return local
+}
+
+// DumpOptions returns the options recommended for bulk ingestion of trusted
+// dump files: line, statement and triple limits are disabled and parsing runs
+// on the given number of workers.
+func DumpOptions(workers int) []Option {
+	return []Option{
+		OptMaxLineBytes(-1),
+		OptMaxStatementBytes(-1),
+		OptMaxTriples(-1),
+		OptParallelism(workers),
+	}
+}
+
+// NewDumpReader creates a reader tuned for a public dump described by profile.
+// Dump-oriented defaults from DumpOptions are applied first (with one worker per
+// CPU), followed by opts, so callers can still override any setting.
+// If filter is non-nil, only statements accepted by it are returned.
+func NewDumpReader(r io.Reader, profile DumpProfile, filter *DumpFilter, opts ...Option) (Reader, error) {
+	all := append(DumpOptions(defaultDumpWorkers()), opts...)
+	format := profile.Format
+	if format == FormatAuto {
+		format = FormatNTriples
+	}
+	reader, err := NewReader(r, format, all...)
+	if err != nil {
+		return nil, err
+	}
+	keep := filter.compile(profile)
+	if keep == nil {
+		return reader, nil
+	}
+	return &filterReader{src: reader, keep: keep}, nil
+}
+
+func defaultDumpWorkers() int {
+	return runtime.NumCPU()
+}
+
+// filterReader drops statements rejected by keep.
+type filterReader struct {
+	src  Reader
+	keep func(Statement) bool
+}
+
+func (f *filterReader) Next() (Statement, error) {
+	for {
+		stmt, err := f.src.Next()
+		if err != nil {
+			return Statement{}, err
+		}
+		if f.keep(stmt) {
+			return stmt, nil
+		}
+	}
+}
+
+func (f *filterReader) Close() error {
+	return f.src.Close()
+}
@@ -0,0 +1,107 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+)
+
+const wikidataSample = `<http://www.wikidata.org/entity/Q42> <http://www.wikidata.org/prop/direct/P31> <http://www.wikidata.org/entity/Q5> .
+<http://www.wikidata.org/entity/Q42> <http://www.wikidata.org/prop/direct/P69> <http://www.wikidata.org/entity/Q691283> .
+<http://www.wikidata.org/entity/Q42> <http://www.w3.org/2000/01/rdf-schema#label> "Douglas Adams"@en .
+<http://www.wikidata.org/entity/Q42> <http://www.w3.org/2000/01/rdf-schema#label> "Douglas Adams"@fr .
+<http://www.wikidata.org/entity/Q42> <http://www.w3.org/2000/01/rdf-schema#label> "Douglas Adams"@en-gb .
+<http://www.wikidata.org/entity/Q42> <http://www.wikidata.org/prop/qualifier/P31> <http://www.wikidata.org/entity/Q5> .
+`
+
+func readDump(t *testing.T, r Reader) []Statement {
+	t.Helper()
+	defer r.Close()
+	stmts, err := collectStatements(r)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	return stmts
+}
+
+func TestNewDumpReader_NoFilter(t *testing.T) {
+	reader, err := NewDumpReader(strings.NewReader(wikidataSample), WikidataTruthy, nil)
+	if err != nil {
+		t.Fatalf("NewDumpReader: %v", err)
+	}
+	if got := len(readDump(t, reader)); got != 6 {
+		t.Fatalf("expected 6 statements, got %d", got)
+	}
+}
+
+func TestNewDumpReader_PropertyFilter(t *testing.T) {
+	filter := NewDumpFilter().Properties("P31")
+	reader, err := NewDumpReader(strings.NewReader(wikidataSample), WikidataFull, filter)
+	if err != nil {
+		t.Fatalf("NewDumpReader: %v", err)
+	}
+	stmts := readDump(t, reader)
+	if len(stmts) != 2 {
+		t.Fatalf("expected 2 P31 statements (direct and qualifier), got %d", len(stmts))
+	}
+	for _, s := range stmts {
+		if !strings.HasSuffix(s.P.Value, "/P31") {
+			t.Fatalf("unexpected predicate %s", s.P.Value)
+		}
+	}
+
+	// The truthy dump only has direct claims.
+	reader, err = NewDumpReader(strings.NewReader(wikidataSample), WikidataTruthy, filter)
+	if err != nil {
+		t.Fatalf("NewDumpReader: %v", err)
+	}
+	if stmts := readDump(t, reader); len(stmts) != 1 || stmts[0].P.Value != "http://www.wikidata.org/prop/direct/P31" {
+		t.Fatalf("expected the direct P31 claim, got %v", stmts)
+	}
+	if _, ok := WikidataTruthy.Prefixes["pq"]; ok {
+		t.Error("truthy profile has the qualifier prefix")
+	}
+}
+
+func TestNewDumpReader_PrefixedPropertyAndLanguage(t *testing.T) {
+	filter := NewDumpFilter().Properties("wdt:P31", "rdfs:label").Languages("en")
+	reader, err := NewDumpReader(strings.NewReader(wikidataSample), WikidataTruthy, filter)
+	if err != nil {
+		t.Fatalf("NewDumpReader: %v", err)
+	}
+	stmts := readDump(t, reader)
+	if len(stmts) != 3 {
+		t.Fatalf("expected wdt:P31 and two English labels, got %d: %v", len(stmts), stmts)
+	}
+	for _, s := range stmts {
+		if lit, ok := s.O.(Literal); ok && !strings.HasPrefix(lit.Lang, "en") {
+			t.Fatalf("unexpected language %q", lit.Lang)
+		}
+	}
+}
+
+func TestNewDumpReader_DBpedia(t *testing.T) {
+	input := `<http://dbpedia.org/resource/Berlin> <http://dbpedia.org/ontology/country> <http://dbpedia.org/resource/Germany> .
+<http://dbpedia.org/resource/Berlin> <http://dbpedia.org/property/name> "Berlin"@de .
+`
+	reader, err := NewDumpReader(strings.NewReader(input), DBpedia, NewDumpFilter().Properties("dbo:country"))
+	if err != nil {
+		t.Fatalf("NewDumpReader: %v", err)
+	}
+	stmts := readDump(t, reader)
+	if len(stmts) != 1 || stmts[0].P.Value != "http://dbpedia.org/ontology/country" {
+		t.Fatalf("unexpected statements: %v", stmts)
+	}
+}
+
+func TestDumpOptions_DisableLimits(t *testing.T) {
+	options := defaultOptions()
+	for _, opt := range DumpOptions(4) {
+		opt(&options)
+	}
+	if options.MaxTriples >= 0 || options.MaxLineBytes >= 0 || options.MaxStatementBytes >= 0 {
+		t.Fatalf("expected limits to be disabled, got %+v", options)
+	}
+	if options.Parallelism != 4 {
+		t.Fatalf("expected parallelism 4, got %d", options.Parallelism)
+	}
+}
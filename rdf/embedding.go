@@ -0,0 +1,198 @@
+package rdf
+
+import (
+	"bufio"
+	"io"
+	"math/rand/v2"
+	"strconv"
+)
+
+// EdgeListOutput receives the files produced by ExportEdgeList. Nil writers
+// are skipped.
+type EdgeListOutput struct {
+	// Edges receives one "source<TAB>relation<TAB>target" line of integer IDs
+	// per statement, the layout read by PyTorch Geometric and KGE tooling.
+	Edges io.Writer
+	// Nodes receives "id<TAB>term" lines mapping node IDs to N-Triples terms.
+	Nodes io.Writer
+	// Relations receives "id<TAB>iri" lines mapping relation IDs to predicates.
+	Relations io.Writer
+	// Walks receives a random-walk corpus (one space-separated walk of node
+	// IDs per line) for node2vec/DeepWalk-style training.
+	Walks io.Writer
+}
+
+// EdgeListOptions configures ExportEdgeList.
+type EdgeListOptions struct {
+	// IncludeLiterals turns literal objects into nodes. By default statements
+	// with literal objects are skipped, as most graph embedding models only
+	// consider links between resources.
+	IncludeLiterals bool
+	// WalksPerNode is the number of random walks started from each node that
+	// has outgoing edges. Walks are only generated when Output.Walks is set.
+	WalksPerNode int
+	// WalkLength is the maximum number of nodes in a walk (default 10).
+	WalkLength int
+	// Seed makes the walk corpus reproducible.
+	Seed uint64
+}
+
+// EdgeListStats reports the size of an exported edge list.
+type EdgeListStats struct {
+	Nodes     int
+	Relations int
+	Edges     int64
+}
+
+// ExportEdgeList reads every statement from r and writes an integer-ID edge
+// list with node and relation mapping files for machine learning pipelines.
+// IDs are assigned from 0 in order of first appearance, so the export is
+// deterministic for a given input. Graph names are ignored, and statements
+// whose subject or object is a triple term are skipped.
+//
+// Edges and mapping files are streamed; generating walks additionally keeps
+// the adjacency lists in memory.
+func ExportEdgeList(r Reader, out EdgeListOutput, opts EdgeListOptions) (EdgeListStats, error) {
+	exp := &edgeListExporter{
+		nodes:     make(map[Term]int),
+		relations: make(map[string]int),
+		keepAdj:   out.Walks != nil && opts.WalksPerNode > 0,
+	}
+	edges := optionalBuffer(out.Edges)
+	nodes := optionalBuffer(out.Nodes)
+	relations := optionalBuffer(out.Relations)
+
+	var stats EdgeListStats
+	for {
+		stmt, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+		if !edgeListNode(stmt.S, false) || !edgeListNode(stmt.O, opts.IncludeLiterals) {
+			continue
+		}
+		src, err := exp.node(stmt.S, nodes)
+		if err != nil {
+			return stats, err
+		}
+		rel, err := exp.relation(stmt.P, relations)
+		if err != nil {
+			return stats, err
+		}
+		dst, err := exp.node(stmt.O, nodes)
+		if err != nil {
+			return stats, err
+		}
+		if edges != nil {
+			line := strconv.Itoa(src) + "\t" + strconv.Itoa(rel) + "\t" + strconv.Itoa(dst) + "\n"
+			if _, err := edges.WriteString(line); err != nil {
+				return stats, err
+			}
+		}
+		if exp.keepAdj {
+			exp.adj[src] = append(exp.adj[src], dst)
+		}
+		stats.Edges++
+	}
+	stats.Nodes = len(exp.nodes)
+	stats.Relations = len(exp.relations)
+
+	for _, b := range []*bufio.Writer{edges, nodes, relations} {
+		if b != nil {
+			if err := b.Flush(); err != nil {
+				return stats, err
+			}
+		}
+	}
+	if exp.keepAdj {
+		if err := exp.writeWalks(out.Walks, opts); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+type edgeListExporter struct {
+	nodes     map[Term]int
+	relations map[string]int
+	keepAdj   bool
+	adj       [][]int
+}
+
+func (e *edgeListExporter) node(t Term, mapping *bufio.Writer) (int, error) {
+	if id, ok := e.nodes[t]; ok {
+		return id, nil
+	}
+	id := len(e.nodes)
+	e.nodes[t] = id
+	if e.keepAdj {
+		e.adj = append(e.adj, nil)
+	}
+	if mapping != nil {
+		if _, err := mapping.WriteString(strconv.Itoa(id) + "\t" + renderTerm(t) + "\n"); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+func (e *edgeListExporter) relation(p IRI, mapping *bufio.Writer) (int, error) {
+	if id, ok := e.relations[p.Value]; ok {
+		return id, nil
+	}
+	id := len(e.relations)
+	e.relations[p.Value] = id
+	if mapping != nil {
+		if _, err := mapping.WriteString(strconv.Itoa(id) + "\t" + p.Value + "\n"); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+func (e *edgeListExporter) writeWalks(w io.Writer, opts EdgeListOptions) error {
+	length := opts.WalkLength
+	if length <= 0 {
+		length = 10
+	}
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	out := bufio.NewWriter(w)
+	for start := range e.adj {
+		if len(e.adj[start]) == 0 {
+			continue
+		}
+		for i := 0; i < opts.WalksPerNode; i++ {
+			cur := start
+			out.WriteString(strconv.Itoa(cur))
+			for step := 1; step < length && len(e.adj[cur]) > 0; step++ {
+				cur = e.adj[cur][rng.IntN(len(e.adj[cur]))]
+				out.WriteString(" " + strconv.Itoa(cur))
+			}
+			if err := out.WriteByte('\n'); err != nil {
+				return err
+			}
+		}
+	}
+	return out.Flush()
+}
+
+func edgeListNode(t Term, includeLiterals bool) bool {
+	switch t.(type) {
+	case IRI, BlankNode:
+		return true
+	case Literal:
+		return includeLiterals
+	default:
+		return false
+	}
+}
+
+func optionalBuffer(w io.Writer) *bufio.Writer {
+	if w == nil {
+		return nil
+	}
+	return bufio.NewWriter(w)
+}
@@ -0,0 +1,71 @@
+package rdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const embeddingSample = `<http://example.org/a> <http://example.org/knows> <http://example.org/b> .
+<http://example.org/b> <http://example.org/knows> <http://example.org/c> .
+<http://example.org/c> <http://example.org/likes> <http://example.org/a> .
+<http://example.org/a> <http://example.org/name> "Alice" .
+`
+
+func TestExportEdgeList(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(embeddingSample), FormatNTriples)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Close()
+	var edges, nodes, relations bytes.Buffer
+	stats, err := ExportEdgeList(reader, EdgeListOutput{Edges: &edges, Nodes: &nodes, Relations: &relations}, EdgeListOptions{})
+	if err != nil {
+		t.Fatalf("ExportEdgeList: %v", err)
+	}
+	if stats.Nodes != 3 || stats.Relations != 2 || stats.Edges != 3 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if got := edges.String(); got != "0\t0\t1\n1\t0\t2\n2\t1\t0\n" {
+		t.Fatalf("unexpected edges %q", got)
+	}
+	if !strings.HasPrefix(nodes.String(), "0\t<http://example.org/a>\n") {
+		t.Fatalf("unexpected nodes %q", nodes.String())
+	}
+	if got := relations.String(); got != "0\thttp://example.org/knows\n1\thttp://example.org/likes\n" {
+		t.Fatalf("unexpected relations %q", got)
+	}
+}
+
+func TestExportEdgeList_LiteralsAndWalks(t *testing.T) {
+	run := func() (EdgeListStats, string) {
+		reader, err := NewReader(strings.NewReader(embeddingSample), FormatNTriples)
+		if err != nil {
+			t.Fatalf("NewReader: %v", err)
+		}
+		defer reader.Close()
+		var walks bytes.Buffer
+		stats, err := ExportEdgeList(reader, EdgeListOutput{Walks: &walks},
+			EdgeListOptions{IncludeLiterals: true, WalksPerNode: 2, WalkLength: 4, Seed: 7})
+		if err != nil {
+			t.Fatalf("ExportEdgeList: %v", err)
+		}
+		return stats, walks.String()
+	}
+	stats, walks := run()
+	if stats.Nodes != 4 || stats.Edges != 4 {
+		t.Fatalf("literal objects should become nodes, got %+v", stats)
+	}
+	lines := strings.Split(strings.TrimSpace(walks), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 2 walks for each of 3 nodes with edges, got %d", len(lines))
+	}
+	for _, line := range lines {
+		if n := len(strings.Fields(line)); n < 1 || n > 4 {
+			t.Fatalf("walk %q exceeds length", line)
+		}
+	}
+	if _, again := run(); again != walks {
+		t.Fatalf("walks should be reproducible for a fixed seed")
+	}
+}
@@ -0,0 +1,48 @@
+package rdf
+
+import (
+	"io"
+	"iter"
+)
+
+// ByteCounter is implemented by writers returned from NewWriter. BytesWritten
+// reports the number of serialized bytes passed to the underlying io.Writer
+// so far; output still held in the writer's buffer is counted once flushed.
+type ByteCounter interface {
+	BytesWritten() int64
+}
+
+// EncodeAll serializes every statement of stmts to w in the given format,
+// flushes the output, and returns the number of bytes written to w.
+// Options such as OptWriteBufferSize configure the writer.
+func EncodeAll(w io.Writer, format Format, stmts iter.Seq[Statement], opts ...Option) (int64, error) {
+	writer, err := NewWriter(w, format, opts...)
+	if err != nil {
+		return 0, err
+	}
+	counter := writer.(ByteCounter)
+	for stmt := range stmts {
+		if err := writer.Write(stmt); err != nil {
+			return counter.BytesWritten(), err
+		}
+	}
+	err = writer.Close()
+	return counter.BytesWritten(), err
+}
+
+// NewWriterTo returns an io.WriterTo that serializes stmts in the given format,
+// for APIs that accept a WriterTo (e.g. streaming an HTTP response body).
+// Each call to WriteTo ranges over stmts again.
+func NewWriterTo(format Format, stmts iter.Seq[Statement], opts ...Option) io.WriterTo {
+	return &statementWriterTo{format: format, stmts: stmts, opts: opts}
+}
+
+type statementWriterTo struct {
+	format Format
+	stmts  iter.Seq[Statement]
+	opts   []Option
+}
+
+func (s *statementWriterTo) WriteTo(w io.Writer) (int64, error) {
+	return EncodeAll(w, s.format, s.stmts, s.opts...)
+}
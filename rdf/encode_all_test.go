@@ -0,0 +1,84 @@
+package rdf
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"testing"
+)
+
+func encodeAllSample() []Statement {
+	return []Statement{
+		NewTriple(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "a"}),
+		NewTriple(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "b"}),
+	}
+}
+
+func TestEncodeAll_ByteCount(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := EncodeAll(&buf, FormatNTriples, slices.Values(encodeAllSample()))
+	if err != nil {
+		t.Fatalf("EncodeAll: %v", err)
+	}
+	if n != int64(buf.Len()) || n == 0 {
+		t.Fatalf("reported %d bytes, buffer has %d", n, buf.Len())
+	}
+}
+
+type countingSink struct {
+	writes int
+}
+
+func (c *countingSink) Write(p []byte) (int, error) {
+	c.writes++
+	return len(p), nil
+}
+
+func TestEncodeAll_WriteBufferSize(t *testing.T) {
+	var stmts []Statement
+	for i := 0; i < 200; i++ {
+		stmts = append(stmts, encodeAllSample()...)
+	}
+	small := &countingSink{}
+	if _, err := EncodeAll(small, FormatNQuads, slices.Values(stmts), OptWriteBufferSize(64)); err != nil {
+		t.Fatalf("EncodeAll: %v", err)
+	}
+	large := &countingSink{}
+	if _, err := EncodeAll(large, FormatNQuads, slices.Values(stmts), OptWriteBufferSize(1<<20)); err != nil {
+		t.Fatalf("EncodeAll: %v", err)
+	}
+	if large.writes != 1 || small.writes <= large.writes {
+		t.Fatalf("expected buffer size to control writes, got small=%d large=%d", small.writes, large.writes)
+	}
+}
+
+func TestWriter_BytesWritten(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, FormatTurtle)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	for _, s := range encodeAllSample() {
+		if err := writer.Write(s); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := writer.(ByteCounter).BytesWritten(); got != int64(buf.Len()) {
+		t.Fatalf("BytesWritten=%d, buffer has %d", got, buf.Len())
+	}
+}
+
+func TestNewWriterTo(t *testing.T) {
+	var wt io.WriterTo = NewWriterTo(FormatNTriples, slices.Values(encodeAllSample()))
+	var buf bytes.Buffer
+	n, err := wt.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("reported %d bytes, buffer has %d", n, buf.Len())
+	}
+}
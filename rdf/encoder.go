@@ -1,6 +1,7 @@
 package rdf
 
 import (
+	"bufio"
 	"context"
 	"io"
 )
@@ -21,6 +22,28 @@ type quadEncoder interface {
 	Close() error
 }
 
+// newEncoderBuffer returns the buffered writer an encoder writes through.
+// A *bufio.Writer prepared by newEncoder (sized by OptWriteBufferSize) is
+// used as is rather than wrapped in a second buffer.
+func newEncoderBuffer(w io.Writer) *bufio.Writer {
+	if b, ok := w.(*bufio.Writer); ok {
+		return b
+	}
+	return bufio.NewWriter(w)
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	m *streamMetrics
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.m.bytes.Add(int64(n))
+	return n, err
+}
+
 // decoderOption configures decoder behavior using functional options.
 // This is kept for internal use with the old decoder implementations.
 type decoderOption func(*decodeOptions)
@@ -82,6 +105,10 @@ func withSafeLimits() decoderOption {
 		opts.MaxStatementBytes = safe.MaxStatementBytes
 		opts.MaxDepth = safe.MaxDepth
 		opts.MaxTriples = safe.MaxTriples
+		opts.MaxLiteralLength = safe.MaxLiteralLength
+		opts.MaxIRILength = safe.MaxIRILength
+		opts.MaxBlankNodes = safe.MaxBlankNodes
+		opts.MaxPrefixCount = safe.MaxPrefixCount
 	}
 }
 
@@ -96,7 +123,15 @@ func newTripleDecoderWithOptions(r io.Reader, format string, opts decodeOptions)
 	case "rdfxml":
 		return newRDFXMLtripleDecoderWithOptions(r, decodeOpts), nil
 	case "jsonld":
-		return newJSONLDtripleDecoder(r), nil
+		return newJSONLDtripleDecoderWithOptions(r, JSONLDOptions{
+			Context:               decodeOpts.Context,
+			BaseIRI:               decodeOpts.BaseIRI,
+			MaxDepth:              decodeOpts.MaxDepth,
+			MaxValueBytes:         int64(decodeOpts.MaxStatementBytes),
+			MaxTermDefinitions:    decodeOpts.MaxPrefixCount,
+			MaxBufferedBytes:      decodeOpts.MaxBufferedBytes,
+			ProduceGeneralizedRdf: decodeOpts.generalized,
+		}), nil
 	default:
 		return nil, ErrUnsupportedFormat
 	}
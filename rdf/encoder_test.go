@@ -3,6 +3,8 @@ package rdf
 import (
 	"bytes"
 	"io"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -96,3 +98,302 @@ func TestTripleEncoderWriteError(t *testing.T) {
 		t.Fatal("expected flush error")
 	}
 }
+
+func TestWriterOptBase(t *testing.T) {
+	const base = "http://example.org/data/"
+	stmts := []Statement{
+		{S: IRI{Value: base + "item1"}, P: IRI{Value: base + "name"}, O: Literal{Lexical: "one"}},
+		{S: IRI{Value: base + "item1"}, P: IRI{Value: "http://xmlns.com/foaf/0.1/knows"}, O: IRI{Value: base + "item2#me"}, G: IRI{Value: base + "graph"}},
+		{S: IRI{Value: "http://other.org/x"}, P: IRI{Value: base + "name"}, O: IRI{Value: base}},
+	}
+	for _, format := range []Format{FormatTurtle, FormatTriG, FormatRDFXML} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, format, OptBase(base))
+		if err != nil {
+			t.Fatalf("format %s: %v", format, err)
+		}
+		for _, stmt := range stmts {
+			if format != FormatTriG {
+				stmt.G = nil
+			}
+			if err := w.Write(stmt); err != nil {
+				t.Fatalf("format %s: write error %v", format, err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("format %s: close error %v", format, err)
+		}
+		out := buf.String()
+		if strings.Contains(out, "<"+base+"item1>") || strings.Contains(out, `"`+base+`item1"`) {
+			t.Errorf("format %s: expected relative IRIs, got:\n%s", format, out)
+		}
+		if !strings.Contains(out, base) {
+			t.Errorf("format %s: expected base declaration, got:\n%s", format, out)
+		}
+		r, err := NewReader(strings.NewReader(out), format)
+		if err != nil {
+			t.Fatalf("format %s: %v", format, err)
+		}
+		var got []Statement
+		for {
+			stmt, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("format %s: read error %v\n%s", format, err, out)
+			}
+			got = append(got, stmt)
+		}
+		_ = r.Close()
+		if len(got) != len(stmts) {
+			t.Fatalf("format %s: expected %d statements, got %d:\n%s", format, len(stmts), len(got), out)
+		}
+		for i, stmt := range got {
+			want := stmts[i]
+			if format != FormatTriG {
+				want.G = nil
+			}
+			if stmt.S.String() != want.S.String() || stmt.P != want.P || stmt.O.String() != want.O.String() || (want.G != nil) != (stmt.G != nil) {
+				t.Errorf("format %s: statement %d = %v, want %v", format, i, stmt, want)
+			}
+		}
+	}
+}
+
+func TestOptSPARQLDirectives(t *testing.T) {
+	stmt := Statement{
+		S: IRI{Value: "http://example.org/s"},
+		P: IRI{Value: "http://example.org/p"},
+		O: IRI{Value: "http://example.com/o"},
+		G: IRI{Value: "http://example.org/g"},
+	}
+	prefixes := map[string]string{"ex": "http://example.org/", "": "http://example.com/"}
+	for _, tt := range []struct {
+		format Format
+		want   string
+	}{
+		{FormatTurtle, "PREFIX : <http://example.com/>\nPREFIX ex: <http://example.org/>\nex:s ex:p :o .\n"},
+		{FormatTriG, "PREFIX : <http://example.com/>\nPREFIX ex: <http://example.org/>\nex:g { ex:s ex:p :o . }\n"},
+	} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, tt.format, OptPrefixes(prefixes), OptSPARQLDirectives())
+		if err != nil {
+			t.Fatalf("%s: %v", tt.format, err)
+		}
+		if err := w.Write(stmt); err != nil {
+			t.Fatalf("%s: %v", tt.format, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: %v", tt.format, err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("%s: got\n%s\nwant\n%s", tt.format, buf.String(), tt.want)
+		}
+		stmts := readScoped(t, buf.String(), tt.format)
+		if len(stmts) != 1 || stmts[0].O != stmt.O || tt.format == FormatTriG && stmts[0].G != stmt.G {
+			t.Fatalf("%s: read back %v", tt.format, stmts)
+		}
+	}
+}
+
+func TestOptTriGGraphBlocks(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	stmts := []Statement{
+		{S: ex("s"), P: ex("p"), O: ex("a"), G: ex("g2")},
+		{S: ex("s"), P: ex("p"), O: ex("b")},
+		{S: ex("s"), P: ex("q"), O: Literal{Lexical: "x", Lang: "en"}, G: ex("g1")},
+		{S: ex("t"), P: ex("p"), O: ex("c"), G: ex("g2")},
+		{S: ex("s"), P: ex("p"), O: ex("d"), G: ex("g2")},
+		{S: ex("s"), P: ex("q"), O: ex("e"), G: ex("g2")},
+	}
+	for _, tt := range []struct {
+		name string
+		opt  Option
+		want string
+	}{
+		{"first appearance", OptTriGGraphBlocks(), "@prefix ex: <http://example.org/> .\n" +
+			"ex:s ex:p ex:b .\n\n" +
+			"GRAPH ex:g2 {\n  ex:s ex:p ex:a, ex:d ;\n      ex:q ex:e .\n  ex:t ex:p ex:c .\n}\n\n" +
+			"GRAPH ex:g1 {\n  ex:s ex:q \"x\"@en .\n}\n"},
+		{"sorted", OptSortGraphs(), "@prefix ex: <http://example.org/> .\n" +
+			"ex:s ex:p ex:b .\n\n" +
+			"GRAPH ex:g1 {\n  ex:s ex:q \"x\"@en .\n}\n\n" +
+			"GRAPH ex:g2 {\n  ex:s ex:p ex:a, ex:d ;\n      ex:q ex:e .\n  ex:t ex:p ex:c .\n}\n"},
+	} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, FormatTriG, OptPrefixes(map[string]string{"ex": "http://example.org/"}), tt.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, stmt := range stmts {
+			if err := w.Write(stmt); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("%s: got\n%s\nwant\n%s", tt.name, buf.String(), tt.want)
+		}
+		if got := readScoped(t, buf.String(), FormatTriG); len(got) != len(stmts) {
+			t.Fatalf("%s: read back %d statements, want %d", tt.name, len(got), len(stmts))
+		}
+	}
+}
+
+func TestOptCanonicalNTriples(t *testing.T) {
+	s := IRI{Value: "http://example.org/s"}
+	p := IRI{Value: "http://example.org/p"}
+	stmts := []Statement{
+		{S: s, P: p, O: Literal{Lexical: "caf\u00e9\t\"q\"\x01\x7f\\", Datatype: IRI{Value: xsdNamespace + "string"}}},
+		{S: s, P: p, O: Literal{Lexical: "chat", Lang: "FR-be"}, G: BlankNode{ID: "g"}},
+		{S: BlankNode{ID: "b"}, P: p, O: TripleTerm{S: s, P: p, O: Literal{Lexical: "1", Datatype: IRI{Value: xsdNamespace + "integer"}}}},
+	}
+	for _, tt := range []struct {
+		format Format
+		want   string
+	}{
+		{FormatNTriples, "<http://example.org/s> <http://example.org/p> \"caf\u00e9\\t\\\"q\\\"\\u0001\\u007F\\\\\" .\n" +
+			"<http://example.org/s> <http://example.org/p> \"chat\"@fr-be .\n" +
+			"_:b <http://example.org/p> <<( <http://example.org/s> <http://example.org/p> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> )>> .\n"},
+		{FormatNQuads, "<http://example.org/s> <http://example.org/p> \"caf\u00e9\\t\\\"q\\\"\\u0001\\u007F\\\\\" .\n" +
+			"<http://example.org/s> <http://example.org/p> \"chat\"@fr-be _:g .\n" +
+			"_:b <http://example.org/p> <<( <http://example.org/s> <http://example.org/p> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> )>> .\n"},
+	} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, tt.format, OptCanonicalNTriples())
+		if err != nil {
+			t.Fatalf("%s: %v", tt.format, err)
+		}
+		for _, stmt := range stmts {
+			if err := w.Write(stmt); err != nil {
+				t.Fatalf("%s: %v", tt.format, err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: %v", tt.format, err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("%s: got\n%s\nwant\n%s", tt.format, buf.String(), tt.want)
+		}
+		if got := readScoped(t, buf.String(), tt.format); len(got) != len(stmts) || got[0].O.(Literal).Lexical != stmts[0].O.(Literal).Lexical {
+			t.Fatalf("%s: read back %v", tt.format, got)
+		}
+	}
+}
+
+func TestOptDeterministic(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	dataset := func(a, b string) []Statement {
+		return []Statement{
+			{S: BlankNode{ID: b}, P: ex("name"), O: Literal{Lexical: "B"}},
+			{S: ex("s"), P: ex("knows"), O: BlankNode{ID: a}},
+			{S: BlankNode{ID: a}, P: ex("knows"), O: BlankNode{ID: b}},
+			{S: ex("s"), P: ex("knows"), O: BlankNode{ID: a}},
+		}
+	}
+	first, second := dataset("x", "y"), dataset("n1", "n2")
+	slices.Reverse(second)
+	for _, format := range []Format{FormatTurtle, FormatTriG, FormatNTriples, FormatNQuads, FormatJSONLD} {
+		write := func(stmts []Statement) string {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, format, OptDeterministic(), OptCanonicalNTriples())
+			if err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+			for _, stmt := range stmts {
+				if err := w.Write(stmt); err != nil {
+					t.Fatalf("%s: %v", format, err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+			return buf.String()
+		}
+		got := write(first)
+		if other := write(second); got != other {
+			t.Fatalf("%s: isomorphic datasets written differently:\n%s\n%s", format, got, other)
+		}
+		if !strings.Contains(got, "c14n0") {
+			t.Fatalf("%s: blank nodes not relabeled:\n%s", format, got)
+		}
+	}
+	canonical, err := CanonicalNQuads(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, FormatNQuads, OptDeterministic(), OptCanonicalNTriples())
+	for _, stmt := range first {
+		w.Write(stmt)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != string(canonical) {
+		t.Fatalf("got\n%s\nwant\n%s", buf.String(), canonical)
+	}
+}
+
+func TestOptGraphFilterAndRename(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatNQuads,
+		OptGraphFilter(func(g Term) bool { return g == nil || g == ex("keep") }),
+		OptGraphRename(func(g Term) Term {
+			if g == nil {
+				return ex("default")
+			}
+			return ex("kept")
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range []Term{ex("keep"), ex("drop"), nil} {
+		if err := w.Write(Statement{S: ex("s"), P: ex("p"), O: ex("o"), G: g}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := "<http://example.org/s> <http://example.org/p> <http://example.org/o> <http://example.org/kept> .\n" +
+		"<http://example.org/s> <http://example.org/p> <http://example.org/o> <http://example.org/default> .\n"
+	if buf.String() != want {
+		t.Fatalf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestOptPrefixesAtForm(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatTurtle, OptPrefixes(map[string]string{"ex": "http://example.org/"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(Statement{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "v"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "@prefix ex: <http://example.org/> .\nex:s ex:p \"v\" .\n"; buf.String() != want {
+		t.Fatalf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestTurtleEncoderSPARQLBase(t *testing.T) {
+	var buf bytes.Buffer
+	enc := newTurtletripleEncoderWithOptions(&buf, TurtleEncodeOptions{BaseIRI: "http://example.org/", SPARQLDirectives: true})
+	if err := enc.Write(Triple{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "v"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "BASE <http://example.org/>\n<s> <p> \"v\" .\n"; buf.String() != want {
+		t.Fatalf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
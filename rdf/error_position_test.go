@@ -0,0 +1,90 @@
+package rdf
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorPositionsAllFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		opts   []Option
+		input  string
+		line   int
+		column int
+		offset int
+	}{
+		{
+			name:   "turtle",
+			format: FormatTurtle,
+			input:  "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n<http://example.org/s> <http://example.org/p> ) .\n",
+			line:   2, column: 47, offset: 117,
+		},
+		{
+			name:   "trig",
+			format: FormatTriG,
+			input:  "<http://example.org/g> {\n  <http://example.org/s> <http://example.org/p> ) .\n}\n",
+			line:   2, column: 49, offset: 73,
+		},
+		{
+			name:   "ntriples",
+			format: FormatNTriples,
+			input:  "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n  <http://example.org/s> <http://example.org/p> bad .\n",
+			line:   2, column: 49, offset: 119,
+		},
+		{
+			name:   "ntriples parallel",
+			format: FormatNTriples,
+			opts:   []Option{OptParallelism(2)},
+			input:  "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n  <http://example.org/s> <http://example.org/p> bad .\n",
+			line:   2, column: 49, offset: 119,
+		},
+		{
+			name:   "nquads",
+			format: FormatNQuads,
+			input:  "<http://example.org/s> <http://example.org/p> bad .\n",
+			line:   1, column: 47, offset: 46,
+		},
+		{
+			name:   "rdfxml",
+			format: FormatRDFXML,
+			input:  "<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n  <rdf:Description rdf:aboutEach=\"x\"/>\n</rdf:RDF>\n",
+			line:   2, column: 39, offset: 104,
+		},
+		{
+			name:   "jsonld",
+			format: FormatJSONLD,
+			input:  "{\n  \"@id\": \"http://example.org/s\",\n  \"http://example.org/p\": [1,, 2]\n}\n",
+			line:   3, column: 30, offset: 64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(tt.input), tt.format, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer reader.Close()
+			for {
+				_, err = reader.Next()
+				if err != nil {
+					break
+				}
+			}
+			if err == io.EOF {
+				t.Fatal("expected parse error")
+			}
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected ParseError, got %T: %v", err, err)
+			}
+			if parseErr.Line != tt.line || parseErr.Column != tt.column || parseErr.Offset() != tt.offset {
+				t.Fatalf("position = %d:%d offset %d, want %d:%d offset %d",
+					parseErr.Line, parseErr.Column, parseErr.Offset(), tt.line, tt.column, tt.offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package rdf
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func readAllWithErrors(t *testing.T, input string, format Format, opts ...Option) ([]Statement, []error, error) {
+	t.Helper()
+	reader, err := NewReader(strings.NewReader(input), format, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	var stmts []Statement
+	for {
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			return stmts, reader.(ErrorCollector).Errors(), nil
+		}
+		if err != nil {
+			return stmts, reader.(ErrorCollector).Errors(), err
+		}
+		stmts = append(stmts, stmt)
+	}
+}
+
+func TestContinueOnErrorLineFormats(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> <http://example.org/o1> .\n" +
+		"<http://example.org/s> <http://example.org/p> bad .\n" +
+		"<http://example.org/s> <http://example.org/p> <http://example.org/o2> .\n" +
+		"<http://example.org/s> <http://example.org/p> <http://example.org/o 3> .\n" +
+		"<http://example.org/s> <http://example.org/p> <http://example.org/o3> .\n"
+	for _, tt := range []struct {
+		name   string
+		format Format
+		opts   []Option
+	}{
+		{"ntriples", FormatNTriples, nil},
+		{"ntriples parallel", FormatNTriples, []Option{OptParallelism(2)}},
+		{"nquads", FormatNQuads, nil},
+		{"turtle", FormatTurtle, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			stmts, errs, err := readAllWithErrors(t, input, tt.format, append(tt.opts, OptContinueOnError(0))...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(stmts) != 3 || stmts[2].O.(IRI).Value != "http://example.org/o3" {
+				t.Fatalf("expected 3 statements, got %v", stmts)
+			}
+			if len(errs) != 2 {
+				t.Fatalf("expected 2 skipped errors, got %v", errs)
+			}
+			var parseErr *ParseError
+			if !errors.As(errs[0], &parseErr) || parseErr.Line != 2 {
+				t.Fatalf("expected first error on line 2, got %v", errs[0])
+			}
+
+			_, _, err = readAllWithErrors(t, input, tt.format, append(tt.opts, OptContinueOnError(1))...)
+			if !errors.As(err, &parseErr) || parseErr.Line != 4 {
+				t.Fatalf("expected error budget to stop at line 4, got %v", err)
+			}
+		})
+	}
+}
+
+func TestContinueOnErrorTriG(t *testing.T) {
+	input := "@prefix ex: <http://example.org/> .\n" +
+		"ex:g1 { ex:s ex:p ex:o1 . ex:s ex:p . ex:s ex:p ex:o2 }\n" +
+		"ex:g2 { ex:s ex:p ex:o3 ex:extra }\n" +
+		"ex:s ex:p ex:o4 .\n"
+	stmts, errs, err := readAllWithErrors(t, input, FormatTriG, OptContinueOnError(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 skipped errors, got %v", errs)
+	}
+	var got []string
+	for _, stmt := range stmts {
+		graph := ""
+		if stmt.G != nil {
+			graph = stmt.G.String()
+		}
+		got = append(got, stmt.O.String()+"@"+graph)
+	}
+	want := "http://example.org/o1@http://example.org/g1 http://example.org/o2@http://example.org/g1 http://example.org/o4@"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("unexpected statements %v", got)
+	}
+}
+
+func TestContinueOnErrorKeepsDirectives(t *testing.T) {
+	input := "BASE <http://example.org/>\n" +
+		"<s> <p> <o1> ; bad\n" +
+		"BASE <http://example.com/>\n" +
+		"PREFIX ex: <ns#>\n" +
+		"<s> ex:p <o2> ; ex:q \"x\"@base bad\n" +
+		"<s> ex:p <o3> .\n" +
+		"<s> ex:p <o4> .\n"
+	stmts, errs, err := readAllWithErrors(t, input, FormatTurtle, OptContinueOnError(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 skipped errors, got %v", errs)
+	}
+	if len(stmts) != 1 || stmts[0].O.String() != "http://example.com/o4" || stmts[0].P.Value != "http://example.com/ns#p" {
+		t.Fatalf("expected the directives after the bad statement to apply, got %v", stmts)
+	}
+}
+
+func TestContinueOnErrorDisabled(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> bad .\n<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n"
+	stmts, errs, err := readAllWithErrors(t, input, FormatNTriples)
+	if err == nil || len(stmts) != 0 || len(errs) != 0 {
+		t.Fatalf("expected first error to stop parsing, got %v %v %v", stmts, errs, err)
+	}
+}
+
+func TestContinueOnErrorKeepsLimitsFatal(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"" + strings.Repeat("x", 200) + "\" .\n<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n"
+	_, errs, err := readAllWithErrors(t, input, FormatTurtle, OptContinueOnError(0), OptMaxStatementBytes(100))
+	if !errors.Is(err, ErrStatementTooLong) || len(errs) != 0 {
+		t.Fatalf("expected statement limit error, got %v (skipped %v)", err, errs)
+	}
+}
@@ -26,12 +26,34 @@ const (
 	ErrCodeParseError ErrorCode = "PARSE_ERROR"
 	// ErrCodeIOError indicates an I/O error.
 	ErrCodeIOError ErrorCode = "IO_ERROR"
-	// ErrCodeContextCanceled indicates the context was canceled.
-	ErrCodeContextCanceled ErrorCode = "CONTEXT_CANCELED"
+	// ErrCodeCanceled indicates the context was canceled or its deadline
+	// passed.
+	ErrCodeCanceled ErrorCode = "CONTEXT_CANCELED"
+	// ErrCodeContextCanceled is the former name of ErrCodeCanceled.
+	//
+	// Deprecated: Use ErrCodeCanceled.
+	ErrCodeContextCanceled = ErrCodeCanceled
 	// ErrCodeInvalidIRI indicates an invalid IRI was encountered.
 	ErrCodeInvalidIRI ErrorCode = "INVALID_IRI"
 	// ErrCodeInvalidLiteral indicates an invalid literal was encountered.
 	ErrCodeInvalidLiteral ErrorCode = "INVALID_LITERAL"
+	// ErrCodeInvalidStatement indicates a writer refused a statement it would
+	// have written as invalid output.
+	ErrCodeInvalidStatement ErrorCode = "INVALID_STATEMENT"
+	// ErrCodeTruncatedInput indicates the input ended in the middle of a statement.
+	ErrCodeTruncatedInput ErrorCode = "TRUNCATED_INPUT"
+	// ErrCodeEntityLimitExceeded indicates that XML entity expansion exceeded the configured limits.
+	ErrCodeEntityLimitExceeded ErrorCode = "ENTITY_LIMIT_EXCEEDED"
+	// ErrCodeLiteralTooLong indicates a literal exceeded the configured length limit.
+	ErrCodeLiteralTooLong ErrorCode = "LITERAL_TOO_LONG"
+	// ErrCodeIRITooLong indicates an IRI exceeded the configured length limit.
+	ErrCodeIRITooLong ErrorCode = "IRI_TOO_LONG"
+	// ErrCodeBlankNodeLimitExceeded indicates that the maximum number of blank nodes was exceeded.
+	ErrCodeBlankNodeLimitExceeded ErrorCode = "BLANK_NODE_LIMIT_EXCEEDED"
+	// ErrCodePrefixLimitExceeded indicates that the maximum number of prefix declarations was exceeded.
+	ErrCodePrefixLimitExceeded ErrorCode = "PREFIX_LIMIT_EXCEEDED"
+	// ErrCodeBudgetExceeded indicates that decoding exceeded its time or buffering budget.
+	ErrCodeBudgetExceeded ErrorCode = "BUDGET_EXCEEDED"
 )
 
 var (
@@ -45,6 +67,25 @@ var (
 	ErrDepthExceeded = errors.New("rdf: nesting depth exceeded configured limit")
 	// ErrTripleLimitExceeded indicates that the maximum number of triples/quads was exceeded.
 	ErrTripleLimitExceeded = errors.New("rdf: maximum number of triples/quads exceeded")
+	// ErrTruncatedInput indicates the input ended in the middle of a statement.
+	ErrTruncatedInput = errors.New("rdf: truncated input")
+	// ErrLiteralValue indicates a literal does not hold a value of the requested Go type,
+	// or a Go value has no literal form.
+	ErrLiteralValue = errors.New("rdf: literal value mismatch")
+	// ErrEntityLimitExceeded indicates that XML entity expansion exceeded the configured limits.
+	ErrEntityLimitExceeded = errors.New("rdf: XML entity expansion exceeded configured limit")
+	// ErrLiteralTooLong indicates a literal exceeded the configured length limit.
+	ErrLiteralTooLong = errors.New("rdf: literal exceeds configured limit")
+	// ErrIRITooLong indicates an IRI exceeded the configured length limit.
+	ErrIRITooLong = errors.New("rdf: IRI exceeds configured limit")
+	// ErrBlankNodeLimitExceeded indicates that the maximum number of blank nodes was exceeded.
+	ErrBlankNodeLimitExceeded = errors.New("rdf: maximum number of blank nodes exceeded")
+	// ErrPrefixLimitExceeded indicates that the maximum number of prefix declarations was exceeded.
+	ErrPrefixLimitExceeded = errors.New("rdf: maximum number of prefix declarations exceeded")
+	// ErrBudgetExceeded indicates that decoding exceeded its time or buffering budget.
+	ErrBudgetExceeded = errors.New("rdf: decoding budget exceeded")
+	// ErrDTDNotAllowed indicates an RDF/XML document with a DOCTYPE declaration was rejected.
+	ErrDTDNotAllowed = errors.New("rdf: XML DOCTYPE declarations are not allowed")
 )
 
 // Code returns the error code for an error, or ErrCodeParseError if unknown.
@@ -71,6 +112,30 @@ func Code(err error) ErrorCode {
 		return ErrCodeDepthExceeded
 	case errors.Is(err, ErrTripleLimitExceeded):
 		return ErrCodeTripleLimitExceeded
+	case errors.Is(err, ErrTruncatedInput), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrCodeTruncatedInput
+	case errors.Is(err, ErrLiteralValue):
+		return ErrCodeInvalidLiteral
+	case errors.Is(err, ErrEntityLimitExceeded):
+		return ErrCodeEntityLimitExceeded
+	case errors.Is(err, ErrLiteralTooLong):
+		return ErrCodeLiteralTooLong
+	case errors.Is(err, ErrIRITooLong):
+		return ErrCodeIRITooLong
+	case errors.Is(err, ErrBlankNodeLimitExceeded):
+		return ErrCodeBlankNodeLimitExceeded
+	case errors.Is(err, ErrPrefixLimitExceeded):
+		return ErrCodePrefixLimitExceeded
+	case errors.Is(err, ErrBudgetExceeded):
+		return ErrCodeBudgetExceeded
+	}
+	var stmtErr *StatementError
+	if errors.As(err, &stmtErr) {
+		return ErrCodeInvalidStatement
+	}
+	var iriErr *IRIError
+	if errors.As(err, &iriErr) {
+		return ErrCodeInvalidIRI
 	}
 
 	// Check for ParseError
@@ -85,8 +150,8 @@ func Code(err error) ErrorCode {
 	}
 
 	// Check for context cancellation
-	if errors.Is(err, context.Canceled) {
-		return ErrCodeContextCanceled
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrCodeCanceled
 	}
 
 	// Default to parse error for unknown errors
@@ -95,14 +160,18 @@ func Code(err error) ErrorCode {
 
 // ParseError provides structured context for parse failures.
 type ParseError struct {
-	Format    string // Format name (e.g., "turtle", "ntriples")
-	Statement string // Offending statement or input excerpt
-	Line      int    // 1-based line number (0 if unknown)
-	Column    int    // 1-based column number (0 if unknown)
-	Offset    int    // Byte offset in input (0 if unknown)
-	Err       error  // Underlying error
+	Format     string // Format name (e.g., "turtle", "ntriples")
+	Statement  string // Offending statement or input excerpt
+	Line       int    // 1-based line number (0 if unknown)
+	Column     int    // 1-based column number (0 if unknown)
+	ByteOffset int    // 0-based byte offset in input (-1 if unknown)
+	Err        error  // Underlying error
 }
 
+// Offset returns the 0-based byte offset of the failing token in the input,
+// or -1 if the decoder could not determine it.
+func (e *ParseError) Offset() int { return e.ByteOffset }
+
 func (e *ParseError) Error() string {
 	// Build error message with position information
 	var msg strings.Builder
@@ -115,8 +184,8 @@ func (e *ParseError) Error() string {
 		} else {
 			fmt.Fprintf(&msg, ":%d", e.Line)
 		}
-	} else if e.Offset >= 0 {
-		fmt.Fprintf(&msg, " (offset %d)", e.Offset)
+	} else if e.ByteOffset >= 0 {
+		fmt.Fprintf(&msg, " (offset %d)", e.ByteOffset)
 	}
 
 	msg.WriteString(": ")
@@ -201,6 +270,72 @@ func (e *ParseError) formatExcerpt() string {
 
 func (e *ParseError) Unwrap() error { return e.Err }
 
+// TruncatedInputError is returned by readers when the input ends in the
+// middle of a statement, literal, XML element or JSON object, as happens
+// with interrupted downloads or partially written files. It matches
+// ErrTruncatedInput, so callers can tell corruption apart from syntax errors
+// and decide whether to keep the statements read so far.
+type TruncatedInputError struct {
+	Statements int64 // Number of complete statements returned before the truncation
+	Err        error // Underlying error, usually a *ParseError positioned at the end of the input
+}
+
+func (e *TruncatedInputError) Error() string {
+	return fmt.Sprintf("rdf: truncated input after %d statements: %v", e.Statements, e.Err)
+}
+
+func (e *TruncatedInputError) Unwrap() error { return e.Err }
+
+// Is reports whether target is ErrTruncatedInput.
+func (e *TruncatedInputError) Is(target error) bool { return target == ErrTruncatedInput }
+
+// truncatedError marks a syntax error caused by the input ending early. It
+// keeps the message of err and matches io.ErrUnexpectedEOF, which readers
+// turn into a TruncatedInputError.
+type truncatedError struct {
+	err error
+}
+
+func (e *truncatedError) Error() string   { return e.err.Error() }
+func (e *truncatedError) Unwrap() []error { return []error{e.err, io.ErrUnexpectedEOF} }
+
+// ErrorCollector is implemented by readers returned from NewReader. When
+// OptContinueOnError is set, Errors returns the syntax errors that were
+// skipped so far, in input order.
+type ErrorCollector interface {
+	Errors() []error
+}
+
+// recoveryErrors accumulates the syntax errors skipped by a decoder running
+// with OptContinueOnError. A nil collector disables recovery.
+type recoveryErrors struct {
+	max  int
+	errs []error
+}
+
+// recover records err and reports whether the decoder may skip past it.
+// Only syntax errors and invalid IRIs are recoverable; limits, I/O failures
+// and cancellation still stop the decoder, as does exceeding the configured
+// error budget.
+func (c *recoveryErrors) recover(err error) bool {
+	if c == nil || (c.max > 0 && len(c.errs) >= c.max) {
+		return false
+	}
+	var parseErr *ParseError
+	if code := Code(err); !errors.As(err, &parseErr) || code != ErrCodeParseError && code != ErrCodeInvalidIRI {
+		return false
+	}
+	c.errs = append(c.errs, err)
+	return true
+}
+
+func (c *recoveryErrors) Errors() []error {
+	if c == nil {
+		return nil
+	}
+	return append([]error(nil), c.errs...)
+}
+
 // wrapParseError adds format/statement context to a parse error.
 func wrapParseError(format, statement string, offset int, err error) error {
 	return wrapParseErrorWithPosition(format, statement, 0, 0, offset, err)
@@ -220,24 +355,24 @@ func wrapParseErrorWithPosition(format, statement string, line, column, offset i
 		if parseErr.Column > 0 && column == 0 {
 			column = parseErr.Column
 		}
-		if parseErr.Offset >= 0 && offset < 0 {
-			offset = parseErr.Offset
+		if parseErr.ByteOffset >= 0 && offset < 0 {
+			offset = parseErr.ByteOffset
 		}
 		return &ParseError{
-			Format:    format,
-			Statement: statement,
-			Line:      line,
-			Column:    column,
-			Offset:    offset,
-			Err:       err,
+			Format:     format,
+			Statement:  statement,
+			Line:       line,
+			Column:     column,
+			ByteOffset: offset,
+			Err:        err,
 		}
 	}
 	return &ParseError{
-		Format:    format,
-		Statement: statement,
-		Line:      line,
-		Column:    column,
-		Offset:    offset,
-		Err:       err,
+		Format:     format,
+		Statement:  statement,
+		Line:       line,
+		Column:     column,
+		ByteOffset: offset,
+		Err:        err,
 	}
 }
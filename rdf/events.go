@@ -0,0 +1,170 @@
+package rdf
+
+import (
+	"io"
+)
+
+// EventKind identifies the syntactic elements reported by an EventReader.
+type EventKind uint8
+
+const (
+	// EventStatement is a statement of the document.
+	EventStatement EventKind = iota
+	// EventPrefix is a prefix declaration (@prefix or PREFIX).
+	EventPrefix
+	// EventBase is a base declaration (@base or BASE).
+	EventBase
+	// EventVersion is a version declaration (@version or VERSION).
+	EventVersion
+	// EventGraphStart is the opening of a TriG graph block.
+	EventGraphStart
+	// EventGraphEnd is the closing of a TriG graph block.
+	EventGraphEnd
+	// EventComment is a comment.
+	EventComment
+)
+
+// Event is a syntactic element of a Turtle or TriG document: a
+// StatementEvent, PrefixEvent, BaseEvent, VersionEvent, GraphStartEvent,
+// GraphEndEvent or CommentEvent. Line and Column are 1-based.
+type Event interface {
+	Kind() EventKind
+}
+
+// StatementEvent is a statement, positioned at the start of the Turtle or
+// TriG statement it was read from. A statement with several objects or
+// predicates produces one event per RDF statement, all with the same
+// position. Offset and End are the byte offsets of the start of that
+// statement and just past its last token, its final '.' if it has one.
+type StatementEvent struct {
+	Statement    Statement
+	Line, Column int
+	Offset, End  int
+}
+
+// PrefixEvent is a prefix declaration. Prefix is "" for the empty prefix and
+// IRI is resolved against the base in effect.
+type PrefixEvent struct {
+	Prefix, IRI  string
+	Line, Column int
+}
+
+// BaseEvent is a base declaration, resolved against the base in effect.
+type BaseEvent struct {
+	IRI          string
+	Line, Column int
+}
+
+// VersionEvent is a version declaration such as VERSION "1.2".
+type VersionEvent struct {
+	Version      string
+	Line, Column int
+}
+
+// GraphStartEvent opens a TriG graph block. Graph is nil for a block of the
+// default graph ("{ ... }").
+type GraphStartEvent struct {
+	Graph        Term
+	Line, Column int
+}
+
+// GraphEndEvent closes the graph block opened by the last GraphStartEvent.
+type GraphEndEvent struct {
+	Graph        Term
+	Line, Column int
+}
+
+// CommentEvent is a comment. Text follows the '#' up to the end of the line,
+// which it does not include.
+type CommentEvent struct {
+	Text         string
+	Line, Column int
+}
+
+func (StatementEvent) Kind() EventKind  { return EventStatement }
+func (PrefixEvent) Kind() EventKind     { return EventPrefix }
+func (BaseEvent) Kind() EventKind       { return EventBase }
+func (VersionEvent) Kind() EventKind    { return EventVersion }
+func (GraphStartEvent) Kind() EventKind { return EventGraphStart }
+func (GraphEndEvent) Kind() EventKind   { return EventGraphEnd }
+func (CommentEvent) Kind() EventKind    { return EventComment }
+
+// EventReader streams the events of a Turtle or TriG document in document
+// order. Next returns io.EOF after the last event.
+type EventReader interface {
+	Next() (Event, error)
+	Close() error
+}
+
+// NewEventReader creates an EventReader for Turtle or TriG input, for tools
+// that need the layout of a document as well as its statements, such as
+// pretty-printers, linters and syntax-aware diffs. The statements are those
+// a Reader created with the same options returns, and all reader options
+// apply; with OptContinueOnError the event reader implements ErrorCollector.
+// The events of the tokens of a statement, such as comments inside it, come
+// before its statements. Other formats fail with ErrUnsupportedFormat.
+func NewEventReader(r io.Reader, format Format, opts ...Option) (EventReader, error) {
+	if format != FormatTurtle && format != FormatTriG {
+		return nil, ErrUnsupportedFormat
+	}
+	events := &eventReader{}
+	reader, err := NewReader(r, format, append(opts, func(opts *Options) { opts.events = events })...)
+	if err != nil {
+		return nil, err
+	}
+	events.reader = reader
+	return events, nil
+}
+
+// eventSink receives the events of the Turtle and TriG parsers.
+type eventSink interface {
+	// event reports a syntactic element other than a statement.
+	event(Event)
+	// statement reports the position and byte range of the statement whose
+	// triples the parser returns next.
+	statement(line, column, offset, end int)
+}
+
+// eventReader queues the events the parser reports while its Reader reads
+// the next statement, followed by the statement.
+type eventReader struct {
+	reader Reader
+	queue  []Event
+	pos    StatementEvent // Position of the statements returned next
+	err    error
+}
+
+func (r *eventReader) event(e Event) { r.queue = append(r.queue, e) }
+
+func (r *eventReader) statement(line, column, offset, end int) {
+	r.pos = StatementEvent{Line: line, Column: column, Offset: offset, End: end}
+}
+
+func (r *eventReader) Next() (Event, error) {
+	for len(r.queue) == 0 {
+		if r.err != nil {
+			return nil, r.err
+		}
+		stmt, err := r.reader.Next()
+		if err != nil {
+			r.err = err
+			continue
+		}
+		e := r.pos
+		e.Statement = stmt
+		r.event(e)
+	}
+	e := r.queue[0]
+	r.queue = r.queue[1:]
+	return e, nil
+}
+
+// Errors returns the syntax errors skipped with OptContinueOnError.
+func (r *eventReader) Errors() []error {
+	if collector, ok := r.reader.(ErrorCollector); ok {
+		return collector.Errors()
+	}
+	return nil
+}
+
+func (r *eventReader) Close() error { return r.reader.Close() }
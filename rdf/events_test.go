@@ -0,0 +1,120 @@
+package rdf
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readEvents returns the events of input as strings of the form
+// "line:column kind detail".
+func readEvents(t *testing.T, input string, format Format, opts ...Option) []string {
+	t.Helper()
+	reader, err := NewEventReader(strings.NewReader(input), format, opts...)
+	if err != nil {
+		t.Fatalf("NewEventReader: %v", err)
+	}
+	defer reader.Close()
+	var events []string
+	for {
+		e, err := reader.Next()
+		if err == io.EOF {
+			return events
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		switch e := e.(type) {
+		case StatementEvent:
+			events = append(events, fmt.Sprintf("%d:%d statement %s", e.Line, e.Column, strings.TrimSpace(canonicalLine(e.Statement))))
+		case PrefixEvent:
+			events = append(events, fmt.Sprintf("%d:%d prefix %s %s", e.Line, e.Column, e.Prefix, e.IRI))
+		case BaseEvent:
+			events = append(events, fmt.Sprintf("%d:%d base %s", e.Line, e.Column, e.IRI))
+		case VersionEvent:
+			events = append(events, fmt.Sprintf("%d:%d version %s", e.Line, e.Column, e.Version))
+		case GraphStartEvent:
+			events = append(events, fmt.Sprintf("%d:%d graph-start %v", e.Line, e.Column, e.Graph))
+		case GraphEndEvent:
+			events = append(events, fmt.Sprintf("%d:%d graph-end %v", e.Line, e.Column, e.Graph))
+		case CommentEvent:
+			events = append(events, fmt.Sprintf("%d:%d comment %q", e.Line, e.Column, e.Text))
+		}
+	}
+}
+
+func TestEventReaderTurtle(t *testing.T) {
+	input := "# header\r\n" +
+		"@prefix ex: <http://example.org/> .\n" +
+		"VERSION \"1.2\"\n" +
+		"BASE <http://example.com/>\n" +
+		"ex:s ex:p ex:o1 , # inside\n" +
+		"    <o2> . # after\n" +
+		"#"
+	got := readEvents(t, input, FormatTurtle)
+	want := []string{
+		`1:1 comment " header"`,
+		"2:1 prefix ex http://example.org/",
+		"3:1 version 1.2",
+		"4:1 base http://example.com/",
+		`5:19 comment " inside"`,
+		"5:1 statement <http://example.org/s> <http://example.org/p> <http://example.org/o1> .",
+		"5:1 statement <http://example.org/s> <http://example.org/p> <http://example.com/o2> .",
+		`6:12 comment " after"`,
+		`7:1 comment ""`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got events\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestEventReaderTriG(t *testing.T) {
+	input := "PREFIX ex: <http://example.org/>\n" +
+		"ex:g1 { ex:s ex:p ex:o }\n" +
+		"{ ex:s ex:p ex:o }\n" +
+		"GRAPH ex:g2 {\n  ex:s ex:p ex:o .\n}\n"
+	got := readEvents(t, input, FormatTriG)
+	want := []string{
+		"1:1 prefix ex http://example.org/",
+		"2:1 graph-start http://example.org/g1",
+		"2:9 statement <http://example.org/s> <http://example.org/p> <http://example.org/o> <http://example.org/g1> .",
+		"2:24 graph-end http://example.org/g1",
+		"3:1 graph-start <nil>",
+		"3:3 statement <http://example.org/s> <http://example.org/p> <http://example.org/o> .",
+		"3:18 graph-end <nil>",
+		"4:1 graph-start http://example.org/g2",
+		"5:3 statement <http://example.org/s> <http://example.org/p> <http://example.org/o> <http://example.org/g2> .",
+		"6:1 graph-end http://example.org/g2",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got events\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestEventReaderContinueOnError(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> bad .\n<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n"
+	reader, err := NewEventReader(strings.NewReader(input), FormatTurtle, OptContinueOnError(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := reader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stmt, ok := e.(StatementEvent); !ok || stmt.Line != 2 {
+		t.Fatalf("expected the statement on line 2, got %#v", e)
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+	if errs := reader.(ErrorCollector).Errors(); len(errs) != 1 {
+		t.Fatalf("expected 1 skipped error, got %v", errs)
+	}
+}
+
+func TestNewEventReaderUnsupportedFormat(t *testing.T) {
+	if _, err := NewEventReader(strings.NewReader(""), FormatNTriples); err != ErrUnsupportedFormat {
+		t.Fatalf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
@@ -103,13 +103,13 @@ func ExampleTripleTerm() {
 		// G omitted - defaults to nil (triple)
 	}
 	var buf bytes.Buffer
-	enc, _ := NewWriter(&buf, FormatNTriples)
+	enc, _ := NewWriter(&buf, FormatTurtle)
 	_ = enc.Write(stmt)
 	_ = enc.Close()
 	fmt.Print(buf.String())
 
 	// Output:
-	// <<http://example.org/s http://example.org/p http://example.org/o>> <http://example.org/said> "true" .
+	// << <http://example.org/s> <http://example.org/p> <http://example.org/o> >> <http://example.org/said> "true" .
 }
 
 func ExampleParseFormat() {
@@ -0,0 +1,119 @@
+package rdf
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// compressionExtensions maps file name extensions to the compression they
+// denote.
+var compressionExtensions = map[string]Compression{
+	".gz":   CompressionGzip,
+	".zst":  CompressionZstd,
+	".zstd": CompressionZstd,
+	".bz2":  CompressionBzip2,
+}
+
+// OpenFile opens the file at path and returns a Reader for it. The format is
+// taken from the file name extension, ignoring a trailing compression
+// extension such as ".gz", ".zst" or ".bz2" (so "data.nt.gz" is read as
+// N-Triples), and falls back to FormatAuto detection. Compressed files are
+// decompressed as with OptDecompress. Close closes the file.
+func OpenFile(path string, opts ...Option) (Reader, error) {
+	format, _ := formatFromPath(path)
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := NewReader(file, format, append([]Option{OptDecompress()}, opts...)...)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &closingReader{Reader: reader, closer: file}, nil
+}
+
+// CreateFile creates or truncates the file at path and returns a Writer for
+// it. The format is taken from the file name extension and a trailing ".gz"
+// or ".zst" extension compresses the output as with OptCompress, which
+// opts may override. CreateFile fails with ErrUnsupportedFormat if the
+// extension names no writable format, and with ErrUnsupportedCompression
+// for ".bz2". Close closes the writer and then the file.
+func CreateFile(path string, opts ...Option) (Writer, error) {
+	format, compression := formatFromPath(path)
+	if format == FormatAuto {
+		return nil, fmt.Errorf("rdf: cannot determine format of %q: %w", path, ErrUnsupportedFormat)
+	}
+	if compression == CompressionBzip2 {
+		return nil, fmt.Errorf("rdf: cannot write %q: %w", path, ErrUnsupportedCompression)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := NewWriter(file, format, append([]Option{OptCompress(compression)}, opts...)...)
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	return &fileWriter{Writer: writer, file: file}, nil
+}
+
+// formatFromPath returns the format and compression named by the
+// extensions of a file path, for example FormatTurtle and CompressionZstd
+// for "data.ttl.zst". The format is FormatAuto if it is not recognized.
+func formatFromPath(path string) (Format, Compression) {
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	compression, ok := compressionExtensions[strings.ToLower(ext)]
+	if ok {
+		name = strings.TrimSuffix(name, ext)
+		ext = filepath.Ext(name)
+	}
+	return formatFromExtension(ext), compression
+}
+
+// closingReader closes an underlying resource, such as a file or an HTTP
+// response body, along with the Reader.
+type closingReader struct {
+	Reader
+	closer io.Closer
+}
+
+func (r *closingReader) Errors() []error { return r.Reader.(ErrorCollector).Errors() }
+
+func (r *closingReader) State() (DecoderState, error) { return r.Reader.(StateExporter).State() }
+
+func (r *closingReader) Metrics() Metrics { return r.Reader.(MetricsReporter).Metrics() }
+
+func (r *closingReader) NextInto(s *Statement) error { return nextInto(r, s) }
+
+func (r *closingReader) Close() error {
+	err := r.Reader.Close()
+	if closeErr := r.closer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// fileWriter closes the file created by CreateFile along with the Writer.
+type fileWriter struct {
+	Writer
+	file *os.File
+}
+
+func (w *fileWriter) BytesWritten() int64 { return w.Writer.(ByteCounter).BytesWritten() }
+
+func (w *fileWriter) Metrics() Metrics { return w.Writer.(MetricsReporter).Metrics() }
+
+func (w *fileWriter) Close() error {
+	err := w.Writer.Close()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
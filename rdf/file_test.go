@@ -0,0 +1,123 @@
+package rdf
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	cases := []struct {
+		path        string
+		format      Format
+		compression Compression
+	}{
+		{"data.ttl", FormatTurtle, CompressionNone},
+		{"/tmp/dump.nt.gz", FormatNTriples, CompressionGzip},
+		{"dump.TTL.ZST", FormatTurtle, CompressionZstd},
+		{"dump.nq.bz2", FormatNQuads, CompressionBzip2},
+		{"onto.owl", FormatRDFXML, CompressionNone},
+		{"archive.gz", FormatAuto, CompressionGzip},
+		{"notes.txt", FormatAuto, CompressionNone},
+	}
+	for _, tc := range cases {
+		format, compression := formatFromPath(tc.path)
+		if format != tc.format || compression != tc.compression {
+			t.Errorf("formatFromPath(%q) = %q, %q, want %q, %q", tc.path, format, compression, tc.format, tc.compression)
+		}
+	}
+}
+
+func TestCreateAndOpenFile(t *testing.T) {
+	dir := t.TempDir()
+	stmt := Statement{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "v"}}
+	for _, name := range []string{"data.ttl", "data.nt.gz", "data.nq.zst", "data.jsonld"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			writer, err := CreateFile(path)
+			if err != nil {
+				t.Fatalf("CreateFile: %v", err)
+			}
+			if err := writer.Write(stmt); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := writer.(ByteCounter).BytesWritten(); n != info.Size() {
+				t.Errorf("BytesWritten = %d, file size %d", n, info.Size())
+			}
+
+			reader, err := OpenFile(path)
+			if err != nil {
+				t.Fatalf("OpenFile: %v", err)
+			}
+			var got []Statement
+			for {
+				s, err := reader.Next()
+				if err != nil {
+					break
+				}
+				got = append(got, s)
+			}
+			if err := reader.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+			if len(got) != 1 || got[0].S != stmt.S || got[0].O != stmt.O {
+				t.Errorf("read back %v, want %v", got, stmt)
+			}
+			if err := reader.Close(); err == nil {
+				t.Error("second Close succeeded, file was not closed")
+			}
+		})
+	}
+}
+
+func TestOpenFileDetectsContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "download")
+	data := []byte("@prefix ex: <http://example.org/> .\nex:s ex:p ex:o .\n")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	defer reader.Close()
+	if _, err := reader.Next(); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+}
+
+func TestCreateFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := CreateFile(filepath.Join(dir, "data.txt")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("unknown extension: err = %v, want ErrUnsupportedFormat", err)
+	}
+	path := filepath.Join(dir, "data.nt.bz2")
+	if _, err := CreateFile(path); !errors.Is(err, ErrUnsupportedCompression) {
+		t.Errorf(".bz2: err = %v, want ErrUnsupportedCompression", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf(".bz2: file was created")
+	}
+
+	// An explicit OptCompress overrides the extension.
+	path = filepath.Join(dir, "plain.nt.gz")
+	writer, err := CreateFile(path, OptCompress(CompressionNone))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Write(Statement{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: IRI{Value: "http://example.org/o"}})
+	writer.Close()
+	data, _ := os.ReadFile(path)
+	if !bytes.HasPrefix(data, []byte("<http://example.org/s>")) {
+		t.Errorf("OptCompress(CompressionNone) output = %q", data)
+	}
+}
@@ -22,7 +22,8 @@ const (
 )
 
 // ParseFormat normalizes a format string and returns a Format.
-// Supports common aliases (e.g., "ttl" -> FormatTurtle, "nt" -> FormatNTriples).
+// Supports common aliases (e.g., "ttl" -> FormatTurtle, "nt" -> FormatNTriples)
+// and the names of formats added with RegisterFormat.
 func ParseFormat(s string) (Format, bool) {
 	s = strings.ToLower(strings.TrimSpace(s))
 	switch s {
@@ -41,12 +42,18 @@ func ParseFormat(s string) (Format, bool) {
 	case "nquads", "nq":
 		return FormatNQuads, true
 	default:
+		if _, ok := lookupFormat(Format(s)); ok {
+			return Format(s), true
+		}
 		return "", false
 	}
 }
 
 // IsQuadFormat reports whether the format supports quads (named graphs).
 func (f Format) IsQuadFormat() bool {
+	if codec := lookupCodec(f); codec != nil {
+		return codec.Quads
+	}
 	return f == FormatTriG || f == FormatNQuads
 }
 
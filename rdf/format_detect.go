@@ -1,6 +1,8 @@
 package rdf
 
 import (
+	"bufio"
+	"bytes"
 	"io"
 	"strings"
 )
@@ -205,3 +207,435 @@ func detectFormatAuto(r io.Reader) (string, bool) {
 	}
 	return "", false
 }
+
+// DefaultDetectSampleSize is the number of bytes DetectFormat buffers by default.
+const DefaultDetectSampleSize = 4096
+
+// DetectOption configures DetectFormat.
+type DetectOption func(*detectOptions)
+
+type detectOptions struct {
+	sampleSize int
+}
+
+// OptSampleSize sets the number of bytes DetectFormat buffers to sniff the
+// format (values below 1 use DefaultDetectSampleSize). Larger samples see
+// more lines, which helps tell N-Triples from N-Quads and Turtle from TriG
+// in documents with long headers.
+func OptSampleSize(n int) DetectOption {
+	return func(opts *detectOptions) {
+		opts.sampleSize = n
+	}
+}
+
+// DetectFormat reads a sample from r and guesses its RDF format. It returns
+// the format, a reader that yields the whole input including the sampled
+// bytes, and a confidence between 0 and 1: about 0.9 for unambiguous
+// markers (JSON-LD keywords, the RDF/XML namespace, Turtle directives, TriG
+// graph blocks, or sample lines that all hold three or four N-Triples
+// terms) and 0.5 to 0.6 for weaker hints such as prefixed names or plain
+// JSON. N-Quads are reported when any sample line has a graph term, TriG
+// when the sample has a GRAPH keyword or a graph block. For an HTML page
+// with a <script type="application/ld+json"> element, DetectFormat
+// reports FormatJSONLD and the returned reader yields the content of the
+// first such script. Formats added with RegisterFormat whose Codec.Detect
+// accepts the sample take precedence.
+//
+// If the format cannot be detected the error is ErrUnsupportedFormat and the
+// returned reader still replays the input; read errors other than EOF are
+// returned as is.
+func DetectFormat(r io.Reader, opts ...DetectOption) (Format, io.Reader, float64, error) {
+	options := detectOptions{sampleSize: DefaultDetectSampleSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.sampleSize < 1 {
+		options.sampleSize = DefaultDetectSampleSize
+	}
+	buf := make([]byte, options.sampleSize)
+	n, err := io.ReadFull(r, buf)
+	sample := buf[:n]
+	complete := err == io.EOF || err == io.ErrUnexpectedEOF
+	replay := io.MultiReader(bytes.NewReader(sample), r)
+	if err != nil && !complete {
+		return FormatAuto, replay, 0, err
+	}
+	if format, ok := detectRegisteredFormat(sample); ok {
+		return format, replay, 0.9, nil
+	}
+	format, confidence, scriptStart := classifySample(sample, complete)
+	if format == FormatAuto {
+		return FormatAuto, replay, 0, ErrUnsupportedFormat
+	}
+	if scriptStart >= 0 {
+		content := io.MultiReader(bytes.NewReader(sample[scriptStart:]), r)
+		return format, &scriptContentReader{r: bufio.NewReader(content)}, confidence, nil
+	}
+	return format, replay, confidence, nil
+}
+
+// classifySample guesses the format of sample, which holds the whole input
+// when complete is set. For HTML with embedded JSON-LD it also returns the
+// offset of the script content in sample, otherwise -1.
+func classifySample(sample []byte, complete bool) (Format, float64, int) {
+	s := strings.TrimPrefix(string(sample), "\uFEFF")
+	s = strings.TrimLeft(s, " \t\r\n")
+	offset := len(sample) - len(s)
+	if s == "" {
+		return FormatAuto, 0, -1
+	}
+
+	switch {
+	case s[0] == '{' || (s[0] == '[' && looksLikeJSONArray(s)):
+		for _, keyword := range []string{`"@context"`, `"@id"`, `"@type"`, `"@graph"`, `"@value"`} {
+			if strings.Contains(s, keyword) {
+				return FormatJSONLD, 0.95, -1
+			}
+		}
+		return FormatJSONLD, 0.6, -1
+	case s[0] == '<' && !strings.HasPrefix(s, "<<"):
+		name := firstXMLElementName(s)
+		if strings.EqualFold(name, "html") {
+			if start := jsonldScriptStart(s); start >= 0 {
+				return FormatJSONLD, 0.8, offset + start
+			}
+			return FormatAuto, 0, -1
+		}
+		if name != "" && strings.Contains(s, rdfXMLNS) {
+			return FormatRDFXML, 0.95, -1
+		}
+		if strings.HasPrefix(s, "<?xml") || strings.HasPrefix(name, "rdf:") {
+			return FormatRDFXML, 0.5, -1
+		}
+	}
+
+	if quads, ok := ntriplesLines(s, complete); ok {
+		if quads {
+			return FormatNQuads, 0.9, -1
+		}
+		return FormatNTriples, 0.9, -1
+	}
+	directive := hasTurtleDirective(s)
+	if hasTriGGraph(s) {
+		if directive {
+			return FormatTriG, 0.9, -1
+		}
+		return FormatTriG, 0.8, -1
+	}
+	if directive {
+		return FormatTurtle, 0.9, -1
+	}
+	if strings.ContainsAny(s, ";,[(") || strings.Contains(s, ":") && !strings.HasPrefix(s, "_:") {
+		return FormatTurtle, 0.6, -1
+	}
+	return FormatAuto, 0, -1
+}
+
+// looksLikeJSONArray reports whether s, starting with '[', is a JSON array
+// rather than a Turtle blank node property list.
+func looksLikeJSONArray(s string) bool {
+	rest := strings.TrimLeft(s[1:], " \t\r\n")
+	if rest == "" {
+		return true
+	}
+	switch rest[0] {
+	case '{', '[', '"', '-':
+		return true
+	case ']':
+		return strings.TrimSpace(rest[1:]) == ""
+	}
+	return isDigit(rest[0])
+}
+
+// firstXMLElementName returns the name of the first element of an XML or
+// HTML document, skipping the XML declaration, processing instructions,
+// comments and the document type declaration. A document type of "html"
+// is reported as "html". It returns "" if s does not start like markup.
+func firstXMLElementName(s string) string {
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		switch {
+		case strings.HasPrefix(s, "<?"):
+			end := strings.Index(s, "?>")
+			if end < 0 {
+				return ""
+			}
+			s = s[end+2:]
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				return ""
+			}
+			s = s[end+3:]
+		case len(s) >= 9 && strings.EqualFold(s[:9], "<!DOCTYPE"):
+			if fields := strings.Fields(s[9:]); len(fields) > 0 && strings.EqualFold(strings.TrimSuffix(fields[0], ">"), "html") {
+				return "html"
+			}
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return ""
+			}
+			// Skip an internal subset, which ends with "]>".
+			if bracket := strings.IndexByte(s[:end], '['); bracket >= 0 {
+				if closing := strings.Index(s, "]>"); closing >= 0 {
+					end = closing + 1
+				}
+			}
+			s = s[end+1:]
+		case strings.HasPrefix(s, "<"):
+			end := 1
+			for end < len(s) && (isASCIILetter(s[end]) || isDigit(s[end]) || strings.IndexByte("_:.-", s[end]) >= 0) {
+				end++
+			}
+			// A name followed by anything but whitespace, '>' or "/>" is
+			// an IRI such as <http://example.org/s>.
+			if end == 1 || end == len(s) || !(isXMLSpace(s[end]) || s[end] == '>' || s[end] == '/' && strings.HasPrefix(s[end:], "/>")) {
+				return ""
+			}
+			return s[1:end]
+		default:
+			return ""
+		}
+	}
+}
+
+// jsonldScriptStart returns the offset of the content of the first
+// <script type="application/ld+json"> element in the HTML document s, or -1.
+func jsonldScriptStart(s string) int {
+	lower := asciiLower(s)
+	for from := 0; ; {
+		i := strings.Index(lower[from:], "<script")
+		if i < 0 {
+			return -1
+		}
+		i += from
+		end := strings.IndexByte(lower[i:], '>')
+		if end < 0 {
+			return -1
+		}
+		end += i
+		if strings.Contains(lower[i:end], "application/ld+json") {
+			return end + 1
+		}
+		from = end
+	}
+}
+
+// ntriplesLines reports whether every statement line of s holds an
+// N-Triples or N-Quads statement, and whether any of them has a graph
+// term. Unless complete is set, the last line may be cut off by the end of
+// the sample and is ignored.
+func ntriplesLines(s string, complete bool) (quads bool, ok bool) {
+	lines := strings.Split(s, "\n")
+	if !complete {
+		lines = lines[:len(lines)-1]
+	}
+	statements := 0
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		switch ntriplesTermCount(line) {
+		case 3:
+		case 4:
+			quads = true
+		default:
+			return false, false
+		}
+		statements++
+	}
+	return quads, statements > 0
+}
+
+// ntriplesTermCount returns the number of terms of the N-Triples or
+// N-Quads statement line, or -1 if the line is not one.
+func ntriplesTermCount(line string) int {
+	count := 0
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(line, ".") {
+			rest := strings.TrimSpace(line[1:])
+			if rest != "" && rest[0] != '#' {
+				return -1
+			}
+			return count
+		}
+		rest, ok := skipNTriplesTerm(line)
+		if !ok {
+			return -1
+		}
+		line = rest
+		if count++; count > 4 {
+			return -1
+		}
+	}
+}
+
+// skipNTriplesTerm returns s after the N-Triples term it starts with.
+func skipNTriplesTerm(s string) (string, bool) {
+	switch {
+	case strings.HasPrefix(s, "<<"):
+		s = strings.TrimPrefix(s[2:], "(")
+		for i := 0; i < 3; i++ {
+			var ok bool
+			if s, ok = skipNTriplesTerm(strings.TrimLeft(s, " \t")); !ok {
+				return "", false
+			}
+		}
+		s = strings.TrimLeft(s, " \t")
+		s = strings.TrimPrefix(s, ")")
+		if !strings.HasPrefix(s, ">>") {
+			return "", false
+		}
+		return s[2:], true
+	case strings.HasPrefix(s, "<"):
+		end := strings.IndexAny(s, "> \t")
+		if end < 0 || s[end] != '>' {
+			return "", false
+		}
+		return s[end+1:], true
+	case strings.HasPrefix(s, "_:"):
+		end := strings.IndexAny(s, " \t<\"")
+		if end < 0 {
+			end = len(s)
+		}
+		// A label may not end with '.', which terminates the statement.
+		for end > 2 && s[end-1] == '.' {
+			end--
+		}
+		return s[end:], end > 2
+	case strings.HasPrefix(s, `"`):
+		i := 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			return "", false
+		}
+		s = s[i+1:]
+		switch {
+		case strings.HasPrefix(s, "^^<"):
+			return skipNTriplesTerm(s[2:])
+		case strings.HasPrefix(s, "@"):
+			end := 1
+			for end < len(s) && (isASCIILetter(s[end]) || isDigit(s[end]) || s[end] == '-') {
+				end++
+			}
+			return s[end:], end > 1
+		}
+		return s, true
+	}
+	return "", false
+}
+
+// hasTurtleDirective reports whether the first statement of the Turtle or
+// TriG document s is a prefix, base or version directive.
+func hasTurtleDirective(s string) bool {
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if !strings.HasPrefix(s, "#") {
+			break
+		}
+		end := strings.IndexByte(s, '\n')
+		if end < 0 {
+			return false
+		}
+		s = s[end:]
+	}
+	word := s
+	if end := strings.IndexAny(s, " \t\r\n"); end >= 0 {
+		word = s[:end]
+	}
+	switch strings.ToUpper(word) {
+	case "@PREFIX", "PREFIX", "@BASE", "BASE", "@VERSION", "VERSION":
+		return true
+	}
+	return false
+}
+
+// hasTriGGraph reports whether s has a GRAPH keyword or a graph block
+// outside of IRIs, literals and comments. Turtle annotation blocks ({| |})
+// are not graph blocks.
+func hasTriGGraph(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '#':
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				return false
+			}
+			i += end
+		case c == '<' && i+1 < len(s) && s[i+1] != '<':
+			end := strings.IndexAny(s[i+1:], "> \t\r\n")
+			if end >= 0 && s[i+1+end] == '>' {
+				i += end + 1
+			}
+		case c == '"' || c == '\'':
+			quote := s[i : i+1]
+			if strings.HasPrefix(s[i:], strings.Repeat(quote, 3)) {
+				end := strings.Index(s[i+3:], strings.Repeat(quote, 3))
+				if end < 0 {
+					return false
+				}
+				i += end + 5
+				continue
+			}
+			for i++; i < len(s) && s[i] != c && s[i] != '\n'; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		case c == '{':
+			if i+1 >= len(s) || s[i+1] != '|' {
+				return true
+			}
+		case (c == 'G' || c == 'g') && len(s)-i >= 5 && strings.EqualFold(s[i:i+5], "GRAPH"):
+			before := i == 0 || !isTurtleNameByte(s[i-1])
+			after := i+5 == len(s) || !isTurtleNameByte(s[i+5])
+			if before && after {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isTurtleNameByte(b byte) bool {
+	return isASCIILetter(b) || isDigit(b) || b == '_' || b == '-' || b == ':' || b >= 0x80
+}
+
+// scriptContentReader yields the content of an HTML script element up to
+// its closing </script> tag.
+type scriptContentReader struct {
+	r    *bufio.Reader
+	done bool
+}
+
+func (s *scriptContentReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) && !s.done {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if b == '<' {
+			if next, _ := s.r.Peek(len("/script")); asciiLower(string(next)) == "/script" {
+				s.done = true
+				break
+			}
+		}
+		p[n] = b
+		n++
+	}
+	if n == 0 && s.done {
+		return 0, io.EOF
+	}
+	return n, nil
+}
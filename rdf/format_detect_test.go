@@ -1,6 +1,9 @@
 package rdf
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"strings"
 	"testing"
 )
@@ -268,3 +271,89 @@ func TestDetectFormatAuto(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Format
+		minConf  float64
+	}{
+		{"N-Triples", "<http://example.org/s> <http://example.org/p> \"o\"@en .\n_:b0 <http://example.org/p> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> .\n", FormatNTriples, 0.9},
+		{"N-Quads", "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n<http://example.org/s> <http://example.org/p> _:o <http://example.org/g> .\n", FormatNQuads, 0.9},
+		{"N-Triples triple term", "<http://example.org/r> <http://www.w3.org/1999/02/22-rdf-syntax-ns#reifies> <<( <http://example.org/s> <http://example.org/p> \"o\" )>> .\n", FormatNTriples, 0.9},
+		{"Turtle", "@prefix ex: <http://example.org/> .\nex:s ex:p ex:o .\n", FormatTurtle, 0.9},
+		{"Turtle annotation", "PREFIX ex: <http://example.org/>\nex:s ex:p ex:o {| ex:q \"{graph}\" |} .\n", FormatTurtle, 0.9},
+		{"Turtle without directives", "<http://example.org/s> <http://example.org/p> [ <http://example.org/q> 1 ] .\n", FormatTurtle, 0.6},
+		{"TriG", "@prefix ex: <http://example.org/> .\nex:g { ex:s ex:p ex:o . }\n", FormatTriG, 0.9},
+		{"TriG GRAPH keyword", "# comment with {\nGRAPH <http://example.org/g> { <http://example.org/s> <http://example.org/p> 1 }\n", FormatTriG, 0.8},
+		{"JSON-LD", `{"@context": {"name": "http://schema.org/name"}, "name": "x"}`, FormatJSONLD, 0.95},
+		{"JSON", `[{"http://schema.org/name": "x"}]`, FormatJSONLD, 0.6},
+		{"RDF/XML", `<?xml version="1.0"?><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"/>`, FormatRDFXML, 0.95},
+		{"RDF/XML default namespace", `<RDF xmlns="http://www.w3.org/1999/02/22-rdf-syntax-ns#"></RDF>`, FormatRDFXML, 0.95},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, reader, confidence, err := DetectFormat(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("DetectFormat() error = %v", err)
+			}
+			if format != tt.expected || confidence < tt.minConf || confidence > 1 {
+				t.Errorf("DetectFormat() = %v, %v; want %v with confidence >= %v", format, confidence, tt.expected, tt.minConf)
+			}
+			replayed, err := io.ReadAll(reader)
+			if err != nil || string(replayed) != tt.input {
+				t.Errorf("replayed input = %q, %v; want %q", replayed, err, tt.input)
+			}
+		})
+	}
+}
+
+func TestDetectFormatHTMLScript(t *testing.T) {
+	input := `<!DOCTYPE html>
+<html><head><title>Example</title>
+<SCRIPT type="application/ld+json">{"@id": "http://example.org/s", "http://example.org/p": "o"}</SCRIPT>
+</head><body></body></html>`
+	format, reader, confidence, err := DetectFormat(strings.NewReader(input))
+	if err != nil || format != FormatJSONLD || confidence <= 0 {
+		t.Fatalf("DetectFormat() = %v, %v, %v; want JSON-LD", format, confidence, err)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read script content: %v", err)
+	}
+	if want := `{"@id": "http://example.org/s", "http://example.org/p": "o"}`; string(content) != want {
+		t.Errorf("script content = %q, want %q", content, want)
+	}
+
+	_, _, _, err = DetectFormat(strings.NewReader("<html><body>no data</body></html>"))
+	if !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("HTML without JSON-LD error = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestDetectFormatSampleSize(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&b, "<http://example.org/s%d> <http://example.org/p> <http://example.org/o> .\n", i)
+	}
+	b.WriteString("<http://example.org/s> <http://example.org/p> <http://example.org/o> <http://example.org/g> .\n")
+	input := b.String()
+
+	format, _, _, err := DetectFormat(strings.NewReader(input), OptSampleSize(256))
+	if err != nil || format != FormatNTriples {
+		t.Errorf("small sample = %v, %v; want N-Triples", format, err)
+	}
+	format, reader, _, err := DetectFormat(strings.NewReader(input), OptSampleSize(len(input)))
+	if err != nil || format != FormatNQuads {
+		t.Errorf("full sample = %v, %v; want N-Quads", format, err)
+	}
+	if replayed, _ := io.ReadAll(reader); string(replayed) != input {
+		t.Error("replayed input differs from the original")
+	}
+
+	format, _, confidence, err := DetectFormat(strings.NewReader("not rdf"))
+	if !errors.Is(err, ErrUnsupportedFormat) || format != FormatAuto || confidence != 0 {
+		t.Errorf("undetectable input = %v, %v, %v; want ErrUnsupportedFormat", format, confidence, err)
+	}
+}
@@ -0,0 +1,284 @@
+package rdf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Codec reads and writes an RDF format that is not built into the package.
+// Register it with RegisterFormat to use the format with NewReader,
+// NewWriter, FormatAuto detection, FormatFromMediaType and Negotiate.
+type Codec struct {
+	// MediaTypes lists the media types of the format, the preferred one
+	// first, for example "application/x-binary-rdf".
+	MediaTypes []string
+	// Extensions lists the file name extensions of the format with their
+	// leading dot, the preferred one first.
+	Extensions []string
+	// Quads reports whether the format can carry named graphs.
+	Quads bool
+	// NewReader returns a reader for the format (nil if it cannot be read).
+	NewReader func(r io.Reader, opts Options) (Reader, error)
+	// NewWriter returns a writer for the format (nil if it cannot be written).
+	NewWriter func(w io.Writer, opts Options) (Writer, error)
+	// Detect reports whether the first bytes of an input are in this
+	// format; it is consulted before the built-in formats by FormatAuto
+	// (nil = never detected).
+	Detect func(sample []byte) bool
+}
+
+// formatInfo describes a built-in or registered format. codec is nil for
+// built-in formats.
+type formatInfo struct {
+	format     Format
+	mediaTypes []string
+	extensions []string
+	codec      *Codec
+}
+
+// formatRegistry holds the known formats in negotiation preference order:
+// the built-in formats, then registered formats in registration order.
+var formatRegistry = struct {
+	sync.RWMutex
+	formats []formatInfo
+}{
+	formats: []formatInfo{
+		{format: FormatTurtle, mediaTypes: []string{"text/turtle", "application/x-turtle"}, extensions: []string{".ttl"}},
+		{format: FormatTriG, mediaTypes: []string{"application/trig", "application/x-trig"}, extensions: []string{".trig"}},
+		{format: FormatJSONLD, mediaTypes: []string{"application/ld+json", "application/json"}, extensions: []string{".jsonld", ".json"}},
+		{format: FormatRDFXML, mediaTypes: []string{"application/rdf+xml", "application/xml", "text/xml"}, extensions: []string{".rdf", ".owl", ".xml"}},
+		{format: FormatNTriples, mediaTypes: []string{"application/n-triples"}, extensions: []string{".nt"}},
+		{format: FormatNQuads, mediaTypes: []string{"application/n-quads"}, extensions: []string{".nq"}},
+	},
+}
+
+// RegisterFormat makes a third-party format available under the name f.
+// It panics if f is FormatAuto or already registered (built-in formats
+// included), or if codec can neither read nor write. RegisterFormat is
+// typically called from an init function.
+func RegisterFormat(f Format, codec Codec) {
+	if f == FormatAuto {
+		panic("rdf: RegisterFormat with empty format name")
+	}
+	if codec.NewReader == nil && codec.NewWriter == nil {
+		panic(fmt.Sprintf("rdf: RegisterFormat %s without NewReader or NewWriter", f))
+	}
+	info := formatInfo{format: f, codec: &codec}
+	for _, mt := range codec.MediaTypes {
+		info.mediaTypes = append(info.mediaTypes, strings.ToLower(mt))
+	}
+	for _, ext := range codec.Extensions {
+		ext = strings.ToLower(ext)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		info.extensions = append(info.extensions, ext)
+	}
+	formatRegistry.Lock()
+	defer formatRegistry.Unlock()
+	for _, known := range formatRegistry.formats {
+		if known.format == f {
+			panic(fmt.Sprintf("rdf: RegisterFormat called twice for format %s", f))
+		}
+	}
+	formatRegistry.formats = append(formatRegistry.formats, info)
+}
+
+// registeredFormats returns a snapshot of the known formats in preference order.
+func registeredFormats() []formatInfo {
+	formatRegistry.RLock()
+	defer formatRegistry.RUnlock()
+	return formatRegistry.formats
+}
+
+func lookupFormat(f Format) (formatInfo, bool) {
+	for _, info := range registeredFormats() {
+		if info.format == f {
+			return info, true
+		}
+	}
+	return formatInfo{}, false
+}
+
+// lookupCodec returns the codec registered for f, or nil for built-in and
+// unknown formats.
+func lookupCodec(f Format) *Codec {
+	info, _ := lookupFormat(f)
+	return info.codec
+}
+
+// MediaType returns the preferred media type of the format, or "" for
+// FormatAuto and unknown formats.
+func (f Format) MediaType() string {
+	info, ok := lookupFormat(f)
+	if !ok || len(info.mediaTypes) == 0 {
+		return ""
+	}
+	return info.mediaTypes[0]
+}
+
+// Extensions returns the file name extensions of the format, with their
+// leading dot and the preferred one first.
+func (f Format) Extensions() []string {
+	info, _ := lookupFormat(f)
+	return append([]string(nil), info.extensions...)
+}
+
+// FormatFromMediaType returns the format of a media type such as
+// "text/turtle" or a Content-Type header value such as
+// "application/ld+json; charset=utf-8". Parameters are ignored.
+func FormatFromMediaType(mt string) (Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(mt)
+	if err != nil {
+		return FormatAuto, false
+	}
+	for _, info := range registeredFormats() {
+		for _, candidate := range info.mediaTypes {
+			if candidate == mediaType {
+				return info.format, true
+			}
+		}
+	}
+	return FormatAuto, false
+}
+
+// Negotiate returns the writable format that best matches an HTTP Accept
+// header, honoring q-values and wildcards such as "application/*" and
+// "*/*". Among equally acceptable formats it prefers an exact media type
+// over a wildcard, then the range listed first, then Turtle, TriG,
+// JSON-LD, RDF/XML, N-Triples, N-Quads and registered formats in that
+// order. An empty header accepts any format. Negotiate returns FormatAuto
+// if no format is acceptable.
+func Negotiate(acceptHeader string) Format {
+	if strings.TrimSpace(acceptHeader) == "" {
+		acceptHeader = "*/*"
+	}
+	type acceptRange struct {
+		mediaType string
+		q         float64
+	}
+	var ranges []acceptRange
+	for _, part := range strings.Split(acceptHeader, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+	}
+
+	best, bestQ, bestSpecificity, bestIndex := FormatAuto, 0.0, 0, 0
+	for _, info := range registeredFormats() {
+		if info.codec != nil && info.codec.NewWriter == nil {
+			continue
+		}
+		// The most specific matching range decides the quality of a format
+		// (RFC 9110, section 12.5.1). Wildcards only match the preferred
+		// media type, so "application/*" does not select Turtle through
+		// application/x-turtle.
+		q, specificity, index := 0.0, -1, 0
+		for i, mediaType := range info.mediaTypes {
+			for j, r := range ranges {
+				s := mediaRangeSpecificity(r.mediaType, mediaType)
+				if (i > 0 && s < 2) || s < specificity || (s == specificity && r.q <= q) {
+					continue
+				}
+				q, specificity, index = r.q, s, j
+			}
+		}
+		if specificity < 0 || q <= 0 {
+			continue
+		}
+		better := q > bestQ ||
+			(q == bestQ && specificity > bestSpecificity) ||
+			(q == bestQ && specificity == bestSpecificity && index < bestIndex)
+		if best == FormatAuto || better {
+			best, bestQ, bestSpecificity, bestIndex = info.format, q, specificity, index
+		}
+	}
+	return best
+}
+
+// mediaRangeSpecificity returns 2 if the media range matches mediaType
+// exactly, 1 for a type/* match, 0 for */* and -1 if it does not match.
+func mediaRangeSpecificity(mediaRange, mediaType string) int {
+	switch {
+	case mediaRange == mediaType:
+		return 2
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, mediaRange[:len(mediaRange)-1]):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// detectRegisteredFormat returns the first registered format whose
+// Detect function accepts sample.
+func detectRegisteredFormat(sample []byte) (Format, bool) {
+	for _, info := range registeredFormats() {
+		if info.codec != nil && info.codec.Detect != nil && info.codec.Detect(sample) {
+			return info.format, true
+		}
+	}
+	return FormatAuto, false
+}
+
+// codecDecoder adapts a Reader returned by a registered Codec to the
+// quadDecoder interface used by quadReaderAdapter.
+type codecDecoder struct {
+	r Reader
+}
+
+func (d codecDecoder) Next() (Quad, error) {
+	stmt, err := d.r.Next()
+	if err != nil {
+		return Quad{}, err
+	}
+	return stmt.AsQuad(), nil
+}
+
+func (d codecDecoder) Err() error   { return nil }
+func (d codecDecoder) Close() error { return d.r.Close() }
+
+// codecEncoder adapts a Writer returned by a registered Codec to the
+// quadEncoder interface used by quadWriterAdapter. buf is the output
+// buffer set up by OptWriteBufferSize, or nil.
+type codecEncoder struct {
+	w   Writer
+	buf *bufio.Writer
+}
+
+func (e codecEncoder) Write(q Quad) error { return e.w.Write(q.ToStatement()) }
+
+func (e codecEncoder) Flush() error {
+	if err := e.w.Flush(); err != nil {
+		return err
+	}
+	return e.flushBuffer()
+}
+
+func (e codecEncoder) Close() error {
+	if err := e.w.Close(); err != nil {
+		return err
+	}
+	return e.flushBuffer()
+}
+
+func (e codecEncoder) flushBuffer() error {
+	if e.buf == nil {
+		return nil
+	}
+	return e.buf.Flush()
+}
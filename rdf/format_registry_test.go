@@ -0,0 +1,147 @@
+package rdf
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFormatMediaTypes(t *testing.T) {
+	cases := []struct {
+		mediaType string
+		want      Format
+		expect    bool
+	}{
+		{"text/turtle", FormatTurtle, true},
+		{"Text/Turtle; charset=utf-8", FormatTurtle, true},
+		{"application/n-triples", FormatNTriples, true},
+		{"application/rdf+xml", FormatRDFXML, true},
+		{JSONLDStreamingMediaType, FormatJSONLD, true},
+		{"application/trig", FormatTriG, true},
+		{"application/n-quads", FormatNQuads, true},
+		{"text/html", FormatAuto, false},
+		{"not a media type", FormatAuto, false},
+	}
+	for _, c := range cases {
+		got, ok := FormatFromMediaType(c.mediaType)
+		if ok != c.expect || got != c.want {
+			t.Errorf("FormatFromMediaType(%q) = %q, %v; want %q, %v", c.mediaType, got, ok, c.want, c.expect)
+		}
+	}
+	if got := FormatJSONLD.MediaType(); got != "application/ld+json" {
+		t.Errorf("unexpected JSON-LD media type %q", got)
+	}
+	if got := FormatAuto.MediaType(); got != "" {
+		t.Errorf("unexpected media type %q for FormatAuto", got)
+	}
+	if got := FormatRDFXML.Extensions(); strings.Join(got, " ") != ".rdf .owl .xml" {
+		t.Errorf("unexpected RDF/XML extensions %v", got)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		accept string
+		want   Format
+	}{
+		{"", FormatTurtle},
+		{"*/*", FormatTurtle},
+		{"application/ld+json", FormatJSONLD},
+		{"text/html, application/rdf+xml;q=0.9, */*;q=0.1", FormatRDFXML},
+		{"application/n-triples;q=0.5, application/n-quads", FormatNQuads},
+		{"application/n-triples, application/n-quads", FormatNTriples},
+		{"application/*", FormatTriG},
+		{"text/turtle;q=0, */*", FormatTriG},
+		{"application/*;q=0.5, application/rdf+xml", FormatRDFXML},
+		{"text/html", FormatAuto},
+		{"text/turtle;q=0", FormatAuto},
+	}
+	for _, c := range cases {
+		if got := Negotiate(c.accept); got != c.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", c.accept, got, c.want)
+		}
+	}
+}
+
+// testQuadsFormat is N-Quads preceded by a "%TESTQUADS" header line.
+const testQuadsFormat Format = "testquads"
+
+func init() {
+	RegisterFormat(testQuadsFormat, Codec{
+		MediaTypes: []string{"application/x-test-quads"},
+		Extensions: []string{"tq"},
+		Quads:      true,
+		NewReader: func(r io.Reader, opts Options) (Reader, error) {
+			br := bufio.NewReader(r)
+			if _, err := br.ReadString('\n'); err != nil {
+				return nil, err
+			}
+			return NewReader(br, FormatNQuads)
+		},
+		NewWriter: func(w io.Writer, opts Options) (Writer, error) {
+			if _, err := io.WriteString(w, "%TESTQUADS\n"); err != nil {
+				return nil, err
+			}
+			return NewWriter(w, FormatNQuads)
+		},
+		Detect: func(sample []byte) bool {
+			return bytes.HasPrefix(sample, []byte("%TESTQUADS"))
+		},
+	})
+}
+
+func TestRegisterFormat(t *testing.T) {
+	if f, ok := ParseFormat("TestQuads"); !ok || f != testQuadsFormat {
+		t.Fatalf("ParseFormat did not find the registered format: %q, %v", f, ok)
+	}
+	if f, ok := FormatFromMediaType("application/x-test-quads"); !ok || f != testQuadsFormat {
+		t.Fatalf("FormatFromMediaType did not find the registered format: %q, %v", f, ok)
+	}
+	if !testQuadsFormat.IsQuadFormat() || testQuadsFormat.Extensions()[0] != ".tq" {
+		t.Fatalf("unexpected registered format metadata")
+	}
+	if got := Negotiate("application/x-test-quads, */*;q=0.5"); got != testQuadsFormat {
+		t.Fatalf("Negotiate = %q, want %q", got, testQuadsFormat)
+	}
+
+	stmt := Statement{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}, G: IRI{Value: "http://example.org/g"}}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, testQuadsFormat, OptWriteBufferSize(16))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Write(stmt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "%TESTQUADS\n<http://example.org/s>") {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+
+	r, err := NewReader(strings.NewReader(buf.String()), FormatAuto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmts, err := collectStatements(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stmts) != 1 || stmts[0] != stmt {
+		t.Fatalf("unexpected statements %v", stmts)
+	}
+
+	for _, f := range []Format{"", FormatTurtle, testQuadsFormat} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected RegisterFormat(%q) to panic", f)
+				}
+			}()
+			RegisterFormat(f, Codec{NewWriter: func(io.Writer, Options) (Writer, error) { return nil, nil }})
+		}()
+	}
+}
@@ -2,6 +2,7 @@ package rdf
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"testing"
 )
@@ -12,8 +13,54 @@ const (
 	fuzzMaxJSONLDBytes    = 64 << 10
 )
 
+// fuzzTurtleSeeds are Turtle and TriG inputs, many of them truncated inside
+// a construct, that seed the Turtle and TriG fuzz targets.
+var fuzzTurtleSeeds = []string{
+	`@prefix ex: <http://example.org/> . ex:s ex:p "v" .`,
+	`@prefix ex: <http://example.org/> . ex:g { ex:s ex:p ex:o . }`,
+	`PREFIX ex: <http://example.org/> ex:s ex:p ( 1 2 [ ex:q "x"@en ] ) .`,
+	`<http://example.org/s> <http://example.org/p> << <http://example.org/a> <http://example.org/b> <http://example.org/c> >> .`,
+	`<http://example.org/s> <http://example.org/p> <<( <http://example.org/a> <http://example.org/b> "c"^^<http://example.org/d> )>> .`,
+	`<http://example.org/s> <http://example.org/p> <http://example.org/o> ~ _:r {| <http://example.org/q> 1 |} .`,
+	`<<`,
+	`<< <http://example.org/a>`,
+	`<http://example.org/s> <http://example.org/p> <<`,
+	`<http://example.org/s> <http://example.org/p> <<( <http://example.org/a> <http://example.org/b>`,
+	`<http://example.org/s> <http://example.org/p> "v" {|`,
+	`<http://example.org/s> <http://example.org/p> """long`,
+	`@prefix ex: <http://example.org/> . ex:g { ex:s ex:p [`,
+	`_:a <http://example.org/p> ( 1`,
+}
+
+// checkReaderInvariants reads dec to its end and fails if it returns a
+// statement without subject or object, or an error that is not a
+// *ParseError.
+func checkReaderInvariants(t *testing.T, dec Reader) {
+	t.Helper()
+	defer dec.Close()
+	for {
+		stmt, err := dec.Next()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("error is a %T, not a *ParseError: %v", err, err)
+			}
+			return
+		}
+		if stmt.S == nil || stmt.O == nil {
+			t.Fatalf("incomplete statement %#v", stmt)
+		}
+	}
+}
+
 func FuzzDecodeNTriples(f *testing.F) {
 	f.Add([]byte(`<http://example.org/s> <http://example.org/p> "v" .`))
+	f.Add([]byte(`_:a <http://example.org/p> "vé"@en--ltr .`))
+	f.Add([]byte(`<http://example.org/s> <http://example.org/p> <<( <http://example.org/a> <http://example.org/b> "c" )>> .`))
+	f.Add([]byte(`<http://example.org/s> <http://example.org/p> "v`))
 	f.Fuzz(func(t *testing.T, data []byte) {
 		dec, err := NewReader(bytes.NewReader(data), FormatNTriples,
 			OptMaxLineBytes(fuzzMaxLineBytes),
@@ -21,12 +68,27 @@ func FuzzDecodeNTriples(f *testing.F) {
 		if err != nil {
 			return
 		}
-		drainTriples(dec)
+		checkReaderInvariants(t, dec)
+	})
+}
+
+func FuzzDecodeNTriplesParallel(f *testing.F) {
+	f.Add([]byte("<http://example.org/s> <http://example.org/p> \"1\" .\n<http://example.org/s> <http://example.org/p> \"2\" .\n"))
+	f.Add([]byte("<http://example.org/s> <http://example.org/p> \"1\" .\nbad\n"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dec, err := NewReader(bytes.NewReader(data), FormatNTriples,
+			OptMaxLineBytes(fuzzMaxLineBytes),
+			OptParallelism(2))
+		if err != nil {
+			return
+		}
+		checkReaderInvariants(t, dec)
 	})
 }
 
 func FuzzDecodeNQuads(f *testing.F) {
 	f.Add([]byte(`<http://example.org/s> <http://example.org/p> "v" <http://example.org/g> .`))
+	f.Add([]byte(`<http://example.org/s> <http://example.org/p> "v" _:g`))
 	f.Fuzz(func(t *testing.T, data []byte) {
 		dec, err := NewReader(bytes.NewReader(data), FormatNQuads,
 			OptMaxLineBytes(fuzzMaxLineBytes),
@@ -34,12 +96,14 @@ func FuzzDecodeNQuads(f *testing.F) {
 		if err != nil {
 			return
 		}
-		drainQuads(dec)
+		checkReaderInvariants(t, dec)
 	})
 }
 
 func FuzzDecodeTurtle(f *testing.F) {
-	f.Add([]byte(`@prefix ex: <http://example.org/> . ex:s ex:p "v" .`))
+	for _, seed := range fuzzTurtleSeeds {
+		f.Add([]byte(seed))
+	}
 	f.Fuzz(func(t *testing.T, data []byte) {
 		dec, err := NewReader(bytes.NewReader(data), FormatTurtle,
 			OptMaxLineBytes(fuzzMaxLineBytes),
@@ -47,12 +111,14 @@ func FuzzDecodeTurtle(f *testing.F) {
 		if err != nil {
 			return
 		}
-		drainTriples(dec)
+		checkReaderInvariants(t, dec)
 	})
 }
 
 func FuzzDecodeTriG(f *testing.F) {
-	f.Add([]byte(`@prefix ex: <http://example.org/> . ex:g { ex:s ex:p ex:o . }`))
+	for _, seed := range fuzzTurtleSeeds {
+		f.Add([]byte(seed))
+	}
 	f.Fuzz(func(t *testing.T, data []byte) {
 		dec, err := NewReader(bytes.NewReader(data), FormatTriG,
 			OptMaxLineBytes(fuzzMaxLineBytes),
@@ -60,50 +126,70 @@ func FuzzDecodeTriG(f *testing.F) {
 		if err != nil {
 			return
 		}
-		drainQuads(dec)
+		checkReaderInvariants(t, dec)
+	})
+}
+
+func FuzzDecodeTurtleRecover(f *testing.F) {
+	for _, seed := range fuzzTurtleSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dec, err := NewReader(bytes.NewReader(data), FormatTriG,
+			OptMaxLineBytes(fuzzMaxLineBytes),
+			OptMaxStatementBytes(fuzzMaxStatementBytes),
+			OptContinueOnError(0))
+		if err != nil {
+			return
+		}
+		checkReaderInvariants(t, dec)
 	})
 }
 
 func FuzzDecodeRDFXML(f *testing.F) {
 	f.Add([]byte(`<?xml version="1.0"?><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description rdf:about="http://example.org/s"><rdf:type rdf:resource="http://example.org/t"/></rdf:Description></rdf:RDF>`))
+	f.Add([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/"><ex:T rdf:nodeID="a"><ex:p rdf:parseType="Collection"><ex:T/></ex:p><ex:q rdf:parseType="Literal"><b>x</b></ex:q></ex:T></rdf:RDF>`))
+	f.Add([]byte(`<!DOCTYPE rdf:RDF [<!ENTITY ex "http://example.org/">]><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Bag rdf:about="&ex;b"><rdf:li>1</rdf:li></rdf:Bag></rdf:RDF>`))
+	f.Add([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description>`))
 	f.Fuzz(func(t *testing.T, data []byte) {
 		limited := io.LimitedReader{R: bytes.NewReader(data), N: fuzzMaxStatementBytes}
 		dec, err := NewReader(&limited, FormatRDFXML)
 		if err != nil {
 			return
 		}
-		drainTriples(dec)
+		checkReaderInvariants(t, dec)
 	})
 }
 
 func FuzzDecodeJSONLD(f *testing.F) {
 	f.Add([]byte(`{"@graph":[{"@id":"http://example.org/s","http://example.org/p":{"@value":"v"}}]}`))
+	f.Add([]byte(`{"@context":{"ex":"http://example.org/"},"@id":"ex:s","ex:p":{"@list":[1,true,{"@id":"ex:o"}]}}`))
+	f.Add([]byte(`[{"@id":"_:b","@type":"http://example.org/T","http://example.org/p":[{"@value":"x","@language":"en"}]}]`))
+	f.Add([]byte(`{"@graph":[{"@id":`))
 	f.Fuzz(func(t *testing.T, data []byte) {
-		// JSON-LD uses unified decoder
-		dec, err := NewReader(bytes.NewReader(data), FormatJSONLD)
+		limited := io.LimitedReader{R: bytes.NewReader(data), N: fuzzMaxJSONLDBytes}
+		dec, err := NewReader(&limited, FormatJSONLD)
 		if err != nil {
 			return
 		}
-		drainTriples(dec)
+		checkReaderInvariants(t, dec)
 	})
 }
 
-func drainTriples(dec Reader) {
-	for {
-		_, err := dec.Next()
-		if err != nil {
-			break
-		}
+func FuzzDecodeAuto(f *testing.F) {
+	for _, seed := range fuzzTurtleSeeds {
+		f.Add([]byte(seed))
 	}
-	_ = dec.Close()
-}
-
-func drainQuads(dec Reader) {
-	for {
-		_, err := dec.Next()
+	f.Add([]byte(`{"@id":"http://example.org/s","http://example.org/p":"v"}`))
+	f.Add([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"/>`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		limited := io.LimitedReader{R: bytes.NewReader(data), N: fuzzMaxJSONLDBytes}
+		dec, err := NewReader(&limited, FormatAuto,
+			OptMaxLineBytes(fuzzMaxLineBytes),
+			OptMaxStatementBytes(fuzzMaxStatementBytes))
 		if err != nil {
-			break
+			return
 		}
-	}
-	_ = dec.Close()
+		checkReaderInvariants(t, dec)
+	})
 }
@@ -0,0 +1,422 @@
+package rdf
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GeoWKTLiteral is the GeoSPARQL datatype IRI for Well-Known Text geometry literals.
+const GeoWKTLiteral = "http://www.opengis.net/ont/geosparql#wktLiteral"
+
+// Point is a two-dimensional coordinate as written in a WKT literal.
+// Coordinates are taken in the order they appear; no axis swapping is applied
+// for coordinate reference systems with latitude-first axis order.
+type Point struct {
+	X, Y float64
+}
+
+// Polygon is a list of linear rings. The first ring is the exterior boundary;
+// any further rings are holes.
+type Polygon [][]Point
+
+// Geometry is a parsed WKT geometry.
+type Geometry struct {
+	// Type is the upper-case WKT geometry type (POINT, MULTIPOINT, LINESTRING,
+	// MULTILINESTRING, POLYGON or MULTIPOLYGON).
+	Type string
+	// CRS is the coordinate reference system IRI given before the geometry,
+	// or "" when the literal uses the GeoSPARQL default (CRS84).
+	CRS string
+	// Points holds the vertices of POINT, MULTIPOINT, LINESTRING and
+	// MULTILINESTRING geometries.
+	Points []Point
+	// Polygons holds the rings of POLYGON and MULTIPOLYGON geometries.
+	Polygons []Polygon
+	// Empty reports whether the geometry was written as EMPTY.
+	Empty bool
+}
+
+// BoundingBox is an axis-aligned rectangle.
+type BoundingBox struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// ParseWKT parses a Well-Known Text geometry, optionally preceded by a CRS IRI
+// in angle brackets as allowed by geo:wktLiteral. Z and M coordinates are
+// accepted and ignored.
+func ParseWKT(text string) (Geometry, error) {
+	p := &wktParser{input: strings.TrimSpace(text)}
+	var g Geometry
+	if strings.HasPrefix(p.input, "<") {
+		end := strings.IndexByte(p.input, '>')
+		if end < 0 {
+			return Geometry{}, fmt.Errorf("wkt: unterminated CRS IRI")
+		}
+		g.CRS = p.input[1:end]
+		p.pos = end + 1
+	}
+	p.skipSpace()
+	g.Type = strings.ToUpper(p.word())
+	if g.Type == "" {
+		return Geometry{}, fmt.Errorf("wkt: missing geometry type")
+	}
+	p.skipSpace()
+	if dim := strings.ToUpper(p.peekWord()); dim == "Z" || dim == "M" || dim == "ZM" {
+		p.word()
+		p.skipSpace()
+	}
+	if strings.EqualFold(p.peekWord(), "EMPTY") {
+		p.word()
+		g.Empty = true
+		return g, p.end()
+	}
+	var err error
+	switch g.Type {
+	case "POINT":
+		var pt Point
+		if pt, err = p.pointText(); err == nil {
+			g.Points = []Point{pt}
+		}
+	case "LINESTRING":
+		g.Points, err = p.pointList()
+	case "MULTIPOINT":
+		g.Points, err = p.multiPoint()
+	case "MULTILINESTRING":
+		var lines [][]Point
+		if lines, err = p.ringList(); err == nil {
+			for _, line := range lines {
+				g.Points = append(g.Points, line...)
+			}
+		}
+	case "POLYGON":
+		var rings [][]Point
+		if rings, err = p.ringList(); err == nil {
+			g.Polygons = []Polygon{rings}
+		}
+	case "MULTIPOLYGON":
+		g.Polygons, err = p.polygonList()
+	default:
+		return Geometry{}, fmt.Errorf("wkt: unsupported geometry type %q", g.Type)
+	}
+	if err != nil {
+		return Geometry{}, err
+	}
+	return g, p.end()
+}
+
+// ParseWKTLiteral parses a literal typed as geo:wktLiteral.
+func ParseWKTLiteral(lit Literal) (Geometry, error) {
+	if lit.Datatype.Value != GeoWKTLiteral {
+		return Geometry{}, fmt.Errorf("wkt: literal datatype is %q, not geo:wktLiteral", lit.Datatype.Value)
+	}
+	return ParseWKT(lit.Lexical)
+}
+
+// Bounds returns the bounding box of all vertices in the geometry.
+// The second result is false for empty geometries.
+func (g Geometry) Bounds() (BoundingBox, bool) {
+	box := BoundingBox{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	found := false
+	g.eachVertex(func(pt Point) {
+		box.MinX = math.Min(box.MinX, pt.X)
+		box.MinY = math.Min(box.MinY, pt.Y)
+		box.MaxX = math.Max(box.MaxX, pt.X)
+		box.MaxY = math.Max(box.MaxY, pt.Y)
+		found = true
+	})
+	if !found {
+		return BoundingBox{}, false
+	}
+	return box, true
+}
+
+func (g Geometry) eachVertex(fn func(Point)) {
+	for _, pt := range g.Points {
+		fn(pt)
+	}
+	for _, poly := range g.Polygons {
+		for _, ring := range poly {
+			for _, pt := range ring {
+				fn(pt)
+			}
+		}
+	}
+}
+
+// Contains reports whether pt lies inside or on the edge of the box.
+func (b BoundingBox) Contains(pt Point) bool {
+	return pt.X >= b.MinX && pt.X <= b.MaxX && pt.Y >= b.MinY && pt.Y <= b.MaxY
+}
+
+// Intersects reports whether the two boxes overlap or touch.
+func (b BoundingBox) Intersects(other BoundingBox) bool {
+	return b.MinX <= other.MaxX && other.MinX <= b.MaxX && b.MinY <= other.MaxY && other.MinY <= b.MaxY
+}
+
+// ContainsPoint reports whether pt lies inside the polygon, using the even-odd
+// rule so that points inside holes are excluded. Points exactly on an edge may
+// be reported either way.
+func (p Polygon) ContainsPoint(pt Point) bool {
+	inside := false
+	for _, ring := range p {
+		n := len(ring)
+		for i, j := 0, n-1; i < n; j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+			if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < (b.X-a.X)*(pt.Y-a.Y)/(b.Y-a.Y)+a.X {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// Within reports whether every vertex of g lies inside poly. For point and
+// multipoint geometries this is an exact point-in-polygon test; for lines and
+// polygons it is a vertex-based approximation that does not detect edges
+// crossing a concave boundary.
+func (g Geometry) Within(poly Polygon) bool {
+	within := !g.Empty
+	g.eachVertex(func(pt Point) {
+		if !poly.ContainsPoint(pt) {
+			within = false
+		}
+	})
+	return within
+}
+
+// FilterBoundingBox returns a reader that keeps only statements whose object is
+// a geo:wktLiteral geometry intersecting box. Statements with other objects,
+// empty geometries or malformed WKT are dropped.
+func FilterBoundingBox(r Reader, box BoundingBox) Reader {
+	return &filterReader{src: r, keep: func(s Statement) bool {
+		g, ok := statementGeometry(s)
+		if !ok {
+			return false
+		}
+		bounds, ok := g.Bounds()
+		return ok && box.Intersects(bounds)
+	}}
+}
+
+// FilterWithinPolygon returns a reader that keeps only statements whose object
+// is a geo:wktLiteral geometry lying within poly (see Geometry.Within).
+// Statements with other objects, empty geometries or malformed WKT are dropped.
+func FilterWithinPolygon(r Reader, poly Polygon) Reader {
+	var box BoundingBox
+	if bounds, ok := (Geometry{Polygons: []Polygon{poly}}).Bounds(); ok {
+		box = bounds
+	}
+	return &filterReader{src: r, keep: func(s Statement) bool {
+		g, ok := statementGeometry(s)
+		if !ok {
+			return false
+		}
+		// Cheap envelope rejection before the per-vertex ring tests.
+		if bounds, ok := g.Bounds(); !ok || !box.Intersects(bounds) {
+			return false
+		}
+		return g.Within(poly)
+	}}
+}
+
+func statementGeometry(s Statement) (Geometry, bool) {
+	lit, ok := s.O.(Literal)
+	if !ok || lit.Datatype.Value != GeoWKTLiteral {
+		return Geometry{}, false
+	}
+	g, err := ParseWKT(lit.Lexical)
+	return g, err == nil
+}
+
+// wktParser is a small recursive-descent parser for WKT coordinate text.
+type wktParser struct {
+	input string
+	pos   int
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *wktParser) peekWord() string {
+	end := p.pos
+	for end < len(p.input) {
+		c := p.input[end]
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')) {
+			break
+		}
+		end++
+	}
+	return p.input[p.pos:end]
+}
+
+func (p *wktParser) word() string {
+	w := p.peekWord()
+	p.pos += len(w)
+	return w
+}
+
+func (p *wktParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.input) || p.input[p.pos] != c {
+		return fmt.Errorf("wkt: expected %q at offset %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// more consumes a comma if present and reports whether one was found.
+func (p *wktParser) more() bool {
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == ',' {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) end() error {
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return fmt.Errorf("wkt: unexpected trailing text at offset %d", p.pos)
+	}
+	return nil
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) && strings.IndexByte("+-.0123456789eE", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+	v, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("wkt: invalid coordinate at offset %d", start)
+	}
+	return v, nil
+}
+
+// coordinate parses "x y [z [m]]".
+func (p *wktParser) coordinate() (Point, error) {
+	x, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) || p.input[p.pos] == ',' || p.input[p.pos] == ')' {
+			return Point{X: x, Y: y}, nil
+		}
+		if _, err := p.number(); err != nil {
+			return Point{}, err
+		}
+	}
+}
+
+// pointText parses "(x y)".
+func (p *wktParser) pointText() (Point, error) {
+	if err := p.expect('('); err != nil {
+		return Point{}, err
+	}
+	pt, err := p.coordinate()
+	if err != nil {
+		return Point{}, err
+	}
+	return pt, p.expect(')')
+}
+
+// pointList parses "(x y, x y, ...)".
+func (p *wktParser) pointList() ([]Point, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var pts []Point
+	for {
+		pt, err := p.coordinate()
+		if err != nil {
+			return nil, err
+		}
+		pts = append(pts, pt)
+		if !p.more() {
+			break
+		}
+	}
+	return pts, p.expect(')')
+}
+
+// multiPoint accepts both "((x y), (x y))" and the legacy "(x y, x y)" forms.
+func (p *wktParser) multiPoint() ([]Point, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var pts []Point
+	for {
+		p.skipSpace()
+		var pt Point
+		var err error
+		if p.pos < len(p.input) && p.input[p.pos] == '(' {
+			pt, err = p.pointText()
+		} else {
+			pt, err = p.coordinate()
+		}
+		if err != nil {
+			return nil, err
+		}
+		pts = append(pts, pt)
+		if !p.more() {
+			break
+		}
+	}
+	return pts, p.expect(')')
+}
+
+// ringList parses "((x y, ...), (x y, ...))".
+func (p *wktParser) ringList() ([][]Point, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var rings [][]Point
+	for {
+		ring, err := p.pointList()
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, ring)
+		if !p.more() {
+			break
+		}
+	}
+	return rings, p.expect(')')
+}
+
+// polygonList parses "(((x y, ...)), ((x y, ...)))".
+func (p *wktParser) polygonList() ([]Polygon, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var polys []Polygon
+	for {
+		rings, err := p.ringList()
+		if err != nil {
+			return nil, err
+		}
+		polys = append(polys, rings)
+		if !p.more() {
+			break
+		}
+	}
+	return polys, p.expect(')')
+}
@@ -0,0 +1,142 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWKT_Types(t *testing.T) {
+	cases := []struct {
+		input    string
+		typ      string
+		points   int
+		polygons int
+	}{
+		{"POINT(1 2)", "POINT", 1, 0},
+		{"point z (1 2 3)", "POINT", 1, 0},
+		{"LINESTRING (0 0, 1 1, 2 2)", "LINESTRING", 3, 0},
+		{"MULTIPOINT ((0 0), (1 1))", "MULTIPOINT", 2, 0},
+		{"MULTIPOINT (0 0, 1 1)", "MULTIPOINT", 2, 0},
+		{"MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))", "MULTILINESTRING", 4, 0},
+		{"POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 1))", "POLYGON", 0, 1},
+		{"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))", "MULTIPOLYGON", 0, 2},
+		{"<http://www.opengis.net/def/crs/EPSG/0/4326> POINT(52.1 4.3)", "POINT", 1, 0},
+	}
+	for _, tc := range cases {
+		g, err := ParseWKT(tc.input)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.input, err)
+		}
+		if g.Type != tc.typ || len(g.Points) != tc.points || len(g.Polygons) != tc.polygons {
+			t.Fatalf("%s: got %s with %d points, %d polygons", tc.input, g.Type, len(g.Points), len(g.Polygons))
+		}
+	}
+}
+
+func TestParseWKT_CRSAndEmpty(t *testing.T) {
+	g, err := ParseWKT("<http://www.opengis.net/def/crs/OGC/1.3/CRS84> POINT EMPTY")
+	if err != nil {
+		t.Fatalf("ParseWKT: %v", err)
+	}
+	if g.CRS != "http://www.opengis.net/def/crs/OGC/1.3/CRS84" || !g.Empty {
+		t.Fatalf("unexpected geometry %+v", g)
+	}
+	if _, ok := g.Bounds(); ok {
+		t.Fatalf("empty geometry should have no bounds")
+	}
+}
+
+func TestParseWKT_Errors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"CIRCLE(0 0 1)",
+		"POINT(1)",
+		"POINT(1 2",
+		"POINT(1 2) trailing",
+		"<http://example.org/crs POINT(1 2)",
+		"POLYGON(0 0, 1 1)",
+	} {
+		if _, err := ParseWKT(input); err == nil {
+			t.Fatalf("expected error for %q", input)
+		}
+	}
+}
+
+func TestParseWKTLiteral_Datatype(t *testing.T) {
+	lit := Literal{Lexical: "POINT(1 2)", Datatype: IRI{Value: GeoWKTLiteral}}
+	if _, err := ParseWKTLiteral(lit); err != nil {
+		t.Fatalf("ParseWKTLiteral: %v", err)
+	}
+	lit.Datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#string"}
+	if _, err := ParseWKTLiteral(lit); err == nil {
+		t.Fatalf("expected datatype error")
+	}
+}
+
+func TestPolygon_ContainsPointWithHole(t *testing.T) {
+	g, err := ParseWKT("POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 6 4, 6 6, 4 6, 4 4))")
+	if err != nil {
+		t.Fatalf("ParseWKT: %v", err)
+	}
+	poly := g.Polygons[0]
+	if !poly.ContainsPoint(Point{X: 2, Y: 2}) {
+		t.Fatalf("point should be inside polygon")
+	}
+	if poly.ContainsPoint(Point{X: 5, Y: 5}) {
+		t.Fatalf("point inside hole should be outside polygon")
+	}
+	if poly.ContainsPoint(Point{X: 11, Y: 5}) {
+		t.Fatalf("point should be outside polygon")
+	}
+}
+
+const geoSample = `@prefix geo: <http://www.opengis.net/ont/geosparql#> .
+@prefix ex: <http://example.org/> .
+ex:amsterdam geo:asWKT "POINT(4.90 52.37)"^^geo:wktLiteral .
+ex:paris geo:asWKT "POINT(2.35 48.86)"^^geo:wktLiteral .
+ex:nyc geo:asWKT "POINT(-74.00 40.71)"^^geo:wktLiteral .
+ex:benelux geo:asWKT "POLYGON((2.5 49.5, 7.2 49.5, 7.2 53.6, 2.5 53.6, 2.5 49.5))"^^geo:wktLiteral .
+ex:amsterdam ex:name "Amsterdam" .
+ex:broken geo:asWKT "POINT(oops)"^^geo:wktLiteral .
+`
+
+func geoSubjects(t *testing.T, r Reader) []string {
+	t.Helper()
+	defer r.Close()
+	stmts, err := collectStatements(r)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	var out []string
+	for _, s := range stmts {
+		out = append(out, strings.TrimPrefix(s.S.String(), "http://example.org/"))
+	}
+	return out
+}
+
+func TestFilterBoundingBox(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(geoSample), FormatTurtle)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	europe := BoundingBox{MinX: -10, MinY: 35, MaxX: 30, MaxY: 60}
+	got := strings.Join(geoSubjects(t, FilterBoundingBox(reader, europe)), ",")
+	if got != "amsterdam,paris,benelux" {
+		t.Fatalf("unexpected subjects %s", got)
+	}
+}
+
+func TestFilterWithinPolygon(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(geoSample), FormatTurtle)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	region, err := ParseWKT("POLYGON((3 50, 8 50, 8 54, 3 54, 3 50))")
+	if err != nil {
+		t.Fatalf("ParseWKT: %v", err)
+	}
+	got := strings.Join(geoSubjects(t, FilterWithinPolygon(reader, region.Polygons[0])), ",")
+	if got != "amsterdam" {
+		t.Fatalf("unexpected subjects %s", got)
+	}
+}
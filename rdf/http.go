@@ -0,0 +1,192 @@
+package rdf
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ErrResponseTooLarge indicates an HTTP response body exceeded the limit set with OptMaxFetchBytes.
+var ErrResponseTooLarge = errors.New("rdf: HTTP response body exceeds configured limit")
+
+// ServeStatements returns an http.Handler that writes stmts in the format
+// negotiated from the request's Accept header (see Negotiate), with the
+// format's media type as Content-Type. Requests accepting no supported
+// format get 406 Not Acceptable, and methods other than GET and HEAD get
+// 405 Method Not Allowed. opts configure the writer. Each request ranges
+// over stmts again; if serialization fails after part of the body was sent,
+// the connection is aborted so that clients do not mistake the output for
+// a complete document.
+func ServeStatements(stmts iter.Seq[Statement], opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Add("Vary", "Accept")
+		format := Negotiate(r.Header.Get("Accept"))
+		if format == FormatAuto {
+			http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Type", format.MediaType())
+		if r.Method == http.MethodHead {
+			return
+		}
+		n, err := EncodeAll(w, format, stmts, append([]Option{OptContext(r.Context())}, opts...)...)
+		if err != nil {
+			if n > 0 {
+				panic(http.ErrAbortHandler)
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// ServeDataset returns an http.Handler that serves the statements of ds
+// like ServeStatements. Each request reads a snapshot of ds taken when
+// writing starts, so that changes made meanwhile do not show in the
+// response, or ds itself if its store does not implement QuadSnapshotter.
+// If reading ds fails, the connection is aborted.
+func ServeDataset(ds *Dataset, opts ...Option) http.Handler {
+	return ServeStatements(func(yield func(Statement) bool) {
+		view := ds
+		if snap, err := ds.Snapshot(); err == nil {
+			defer snap.Close()
+			view = snap.Dataset
+		} else if !errors.Is(err, errors.ErrUnsupported) {
+			panic(http.ErrAbortHandler)
+		}
+		for stmt, err := range view.Match(QuadPattern{}) {
+			if err != nil {
+				panic(http.ErrAbortHandler)
+			}
+			if !yield(stmt) {
+				return
+			}
+		}
+	}, opts...)
+}
+
+// Fetch requests url and returns a Reader for the response body. The
+// request's Accept header lists every readable format, and the response
+// format is taken from its Content-Type, then from the extension of the
+// final URL, falling back to FormatAuto detection. Redirects are followed
+// and relative IRIs resolve against the final URL unless OptBaseIRI is set.
+// Gzip-encoded bodies are decompressed, OptMaxFetchBytes limits the body
+// size and OptHTTPClient selects the client (http.DefaultClient by
+// default). opts also configure the returned Reader; Close closes the
+// response body.
+func Fetch(ctx context.Context, url string, opts ...Option) (Reader, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", fetchAcceptHeader())
+	client := options.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("rdf: cannot fetch %q: HTTP status %s", url, resp.Status)
+	}
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") && !resp.Uncompressed {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("rdf: cannot fetch %q: %w", url, err)
+		}
+		body = gz
+	}
+	if options.MaxFetchBytes > 0 {
+		body = &limitedBodyReader{r: body, remaining: options.MaxFetchBytes}
+	}
+
+	format, ok := FormatFromMediaType(resp.Header.Get("Content-Type"))
+	if !ok {
+		format = formatFromExtension(path.Ext(resp.Request.URL.Path))
+	}
+	opts = append([]Option{OptContext(ctx), OptBaseIRI(resp.Request.URL.String())}, opts...)
+	reader, err := NewReader(body, format, opts...)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return &closingReader{Reader: reader, closer: resp.Body}, nil
+}
+
+// fetchAcceptHeader lists the media types of every readable format in
+// preference order, followed by a low-quality wildcard for servers that
+// label RDF with a generic media type.
+func fetchAcceptHeader() string {
+	var ranges []string
+	for _, info := range registeredFormats() {
+		if info.codec != nil && info.codec.NewReader == nil {
+			continue
+		}
+		for i, mediaType := range info.mediaTypes {
+			if i > 0 {
+				mediaType += ";q=0.5"
+			}
+			ranges = append(ranges, mediaType)
+		}
+	}
+	return strings.Join(append(ranges, "*/*;q=0.1"), ", ")
+}
+
+// formatFromExtension returns the format using the file extension ext, or
+// FormatAuto.
+func formatFromExtension(ext string) Format {
+	ext = strings.ToLower(ext)
+	for _, info := range registeredFormats() {
+		for _, candidate := range info.extensions {
+			if candidate == ext {
+				return info.format
+			}
+		}
+	}
+	return FormatAuto
+}
+
+// limitedBodyReader fails with ErrResponseTooLarge once more than
+// remaining bytes are read.
+type limitedBodyReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedBodyReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n - int(-l.remaining), ErrResponseTooLarge
+	}
+	return n, err
+}
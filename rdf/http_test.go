@@ -0,0 +1,180 @@
+package rdf
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestServeStatements(t *testing.T) {
+	stmts := []Statement{
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}},
+	}
+	server := httptest.NewServer(ServeStatements(slices.Values(stmts)))
+	defer server.Close()
+
+	tests := []struct {
+		method      string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{http.MethodGet, "", http.StatusOK, "text/turtle"},
+		{http.MethodGet, "application/n-triples", http.StatusOK, "application/n-triples"},
+		{http.MethodGet, "text/html, application/ld+json;q=0.8", http.StatusOK, "application/ld+json"},
+		{http.MethodHead, "application/rdf+xml", http.StatusOK, "application/rdf+xml"},
+		{http.MethodGet, "text/html", http.StatusNotAcceptable, ""},
+		{http.MethodPost, "", http.StatusMethodNotAllowed, ""},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, server.URL, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s %q: expected status %d, got %d", tt.method, tt.accept, tt.status, resp.StatusCode)
+		}
+		if tt.contentType != "" && resp.Header.Get("Content-Type") != tt.contentType {
+			t.Errorf("%s %q: expected Content-Type %s, got %s", tt.method, tt.accept, tt.contentType, resp.Header.Get("Content-Type"))
+		}
+	}
+
+	// A fetched document reads back the served statements.
+	reader, err := Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := collectStatements(reader)
+	reader.Close()
+	if err != nil || !slices.Equal(got, stmts) {
+		t.Fatalf("unexpected statements %v, %v", got, err)
+	}
+}
+
+func TestServeDataset(t *testing.T) {
+	ds := NewDataset()
+	stmts := []Statement{
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}},
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "g"}, G: IRI{Value: "http://example.org/g"}},
+	}
+	for _, stmt := range stmts {
+		if err := ds.Add(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server := httptest.NewServer(ServeDataset(ds))
+	defer server.Close()
+
+	tests := []struct {
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"application/n-quads", http.StatusOK, "application/n-quads"},
+		{"text/html, application/trig;q=0.8", http.StatusOK, "application/trig"},
+		{"text/html", http.StatusNotAcceptable, ""},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("Accept", tt.accept)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != tt.status {
+			t.Errorf("%q: expected status %d, got %d", tt.accept, tt.status, resp.StatusCode)
+		}
+		if tt.status != http.StatusOK {
+			resp.Body.Close()
+			continue
+		}
+		if resp.Header.Get("Content-Type") != tt.contentType {
+			t.Errorf("%q: expected Content-Type %s, got %s", tt.accept, tt.contentType, resp.Header.Get("Content-Type"))
+		}
+		format, _ := FormatFromMediaType(tt.contentType)
+		reader, err := NewReader(resp.Body, format)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := collectStatements(reader)
+		resp.Body.Close()
+		if err != nil || len(got) != len(stmts) {
+			t.Fatalf("%q: unexpected statements %v, %v", tt.accept, got, err)
+		}
+		for _, stmt := range stmts {
+			if !slices.Contains(got, stmt) {
+				t.Errorf("%q: missing statement %v", tt.accept, stmt)
+			}
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	turtle := "@prefix ex: <http://example.org/> .\n<s> ex:p \"" + strings.Repeat("x", 100) + "\" .\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Accept"), "text/turtle") {
+			t.Errorf("unexpected Accept header %q", r.Header.Get("Accept"))
+		}
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "/data/doc", http.StatusFound)
+		case "/data/doc":
+			w.Header().Set("Content-Type", "text/turtle; charset=utf-8")
+			w.Write([]byte(turtle))
+		case "/data/doc.ttl":
+			// A generic media type falls back to the file extension.
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			gz.Write([]byte(turtle))
+			gz.Close()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// Without transparent decompression in the transport, Fetch decodes
+	// the gzip body itself.
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	for _, path := range []string{"/redirect", "/data/doc.ttl"} {
+		reader, err := Fetch(context.Background(), server.URL+path, OptHTTPClient(client))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", path, err)
+		}
+		stmts, err := collectStatements(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", path, err)
+		}
+		if len(stmts) != 1 || !strings.HasPrefix(stmts[0].S.String(), server.URL+"/data/") {
+			t.Errorf("%s: expected the subject to resolve against the final URL, got %v", path, stmts)
+		}
+	}
+
+	if _, err := Fetch(context.Background(), server.URL+"/missing"); err == nil {
+		t.Error("expected error for HTTP 404")
+	}
+	reader, err := Fetch(context.Background(), server.URL+"/data/doc", OptMaxFetchBytes(64))
+	if err == nil {
+		_, err = collectStatements(reader)
+		reader.Close()
+	}
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+
+	if n, err := (&limitedBodyReader{r: strings.NewReader("abcdef"), remaining: 6}).Read(make([]byte, 10)); n != 6 || err != nil {
+		t.Errorf("expected a body of exactly the limit to be read, got %d, %v", n, err)
+	}
+}
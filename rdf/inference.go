@@ -0,0 +1,167 @@
+package rdf
+
+import "fmt"
+
+const (
+	rdfsSubClassOfIRI    = "http://www.w3.org/2000/01/rdf-schema#subClassOf"
+	rdfsSubPropertyOfIRI = "http://www.w3.org/2000/01/rdf-schema#subPropertyOf"
+	rdfsDomainIRI        = "http://www.w3.org/2000/01/rdf-schema#domain"
+	rdfsRangeIRI         = "http://www.w3.org/2000/01/rdf-schema#range"
+)
+
+// DefaultMaxInferred is the default number of distinct statements
+// RDFSClosure may infer.
+const DefaultMaxInferred = 10_000_000
+
+// InferenceOption configures RDFSClosure.
+type InferenceOption func(*inferenceOptions)
+
+type inferenceOptions struct {
+	maxInferred int
+}
+
+// OptMaxInferred limits the number of distinct statements RDFSClosure
+// infers (default DefaultMaxInferred, 0 = unlimited).
+func OptMaxInferred(n int) InferenceOption {
+	return func(opts *inferenceOptions) {
+		opts.maxInferred = n
+	}
+}
+
+// RDFSClosure materializes the RDFS entailments of a statement stream under
+// schema: every statement is followed by the statements it entails through
+// rdfs:subPropertyOf, rdfs:domain, rdfs:range and rdfs:subClassOf (rules
+// rdfs2, rdfs3, rdfs7 and rdfs9), applied repeatedly until nothing new
+// follows. Inferred statements are placed in the graph of the statement
+// they follow from. Cycles in the class and property hierarchies are
+// allowed.
+//
+// Each inferred statement is returned once; the transform remembers them
+// to do so, and fails with ErrTripleLimitExceeded once more than
+// OptMaxInferred distinct statements would be inferred. Statements of the
+// input are not remembered, so an inferred statement may repeat one of the
+// input. Schema statements in the stream are passed through but do not
+// extend schema, and axiomatic triples such as "x rdf:type rdfs:Resource"
+// are not produced.
+func RDFSClosure(schema []Triple, opts ...InferenceOption) Transform {
+	options := inferenceOptions{maxInferred: DefaultMaxInferred}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	rules := newRDFSRules(schema)
+	return func(r Reader) Reader {
+		return &rdfsReader{src: r, rules: rules, max: options.maxInferred, seen: make(map[Statement]struct{})}
+	}
+}
+
+// rdfsRules indexes the statements of an RDFS schema that drive inference.
+type rdfsRules struct {
+	superClasses    map[Term][]Term
+	superProperties map[string][]IRI
+	domains         map[string][]Term
+	ranges          map[string][]Term
+}
+
+func newRDFSRules(schema []Triple) *rdfsRules {
+	rules := &rdfsRules{
+		superClasses:    make(map[Term][]Term),
+		superProperties: make(map[string][]IRI),
+		domains:         make(map[string][]Term),
+		ranges:          make(map[string][]Term),
+	}
+	for _, t := range schema {
+		if _, ok := t.O.(Literal); ok || t.S == nil || t.S == t.O {
+			continue
+		}
+		property, isIRI := t.S.(IRI)
+		switch t.P.Value {
+		case rdfsSubClassOfIRI:
+			rules.superClasses[t.S] = append(rules.superClasses[t.S], t.O)
+		case rdfsSubPropertyOfIRI:
+			if super, ok := t.O.(IRI); ok && isIRI {
+				rules.superProperties[property.Value] = append(rules.superProperties[property.Value], super)
+			}
+		case rdfsDomainIRI:
+			if isIRI {
+				rules.domains[property.Value] = append(rules.domains[property.Value], t.O)
+			}
+		case rdfsRangeIRI:
+			if isIRI {
+				rules.ranges[property.Value] = append(rules.ranges[property.Value], t.O)
+			}
+		}
+	}
+	return rules
+}
+
+// apply calls emit with each statement that follows from s in one step.
+func (rules *rdfsRules) apply(s Statement, emit func(Statement)) {
+	rdfType := IRI{Value: rdfTypeIRI}
+	for _, super := range rules.superProperties[s.P.Value] {
+		emit(Statement{S: s.S, P: super, O: s.O, G: s.G})
+	}
+	if _, ok := s.S.(Literal); !ok {
+		for _, class := range rules.domains[s.P.Value] {
+			emit(Statement{S: s.S, P: rdfType, O: class, G: s.G})
+		}
+	}
+	if _, ok := s.O.(Literal); ok {
+		return
+	}
+	for _, class := range rules.ranges[s.P.Value] {
+		emit(Statement{S: s.O, P: rdfType, O: class, G: s.G})
+	}
+	if s.P.Value == rdfTypeIRI {
+		for _, super := range rules.superClasses[s.O] {
+			emit(Statement{S: s.S, P: rdfType, O: super, G: s.G})
+		}
+	}
+}
+
+// rdfsReader returns each statement of src followed by the statements
+// inferred from it that were not inferred before.
+type rdfsReader struct {
+	src     Reader
+	rules   *rdfsRules
+	max     int
+	seen    map[Statement]struct{}
+	pending []Statement
+	err     error
+}
+
+func (r *rdfsReader) Next() (Statement, error) {
+	if len(r.pending) > 0 {
+		stmt := r.pending[0]
+		r.pending = r.pending[1:]
+		return stmt, nil
+	}
+	if r.err != nil {
+		return Statement{}, r.err
+	}
+	stmt, err := r.src.Next()
+	if err != nil {
+		return Statement{}, err
+	}
+	// Inferred statements are queued in pending and inferred from in turn,
+	// so the loop ends when no new statement follows.
+	emit := func(inferred Statement) {
+		if _, ok := r.seen[inferred]; ok || inferred == stmt || r.err != nil {
+			return
+		}
+		if r.max > 0 && len(r.seen) >= r.max {
+			r.err = fmt.Errorf("rdf: RDFS closure inferred more than %d statements: %w", r.max, ErrTripleLimitExceeded)
+			return
+		}
+		r.seen[inferred] = struct{}{}
+		r.pending = append(r.pending, inferred)
+	}
+	r.rules.apply(stmt, emit)
+	for i := 0; i < len(r.pending) && r.err == nil; i++ {
+		r.rules.apply(r.pending[i], emit)
+	}
+	return stmt, nil
+}
+
+func (r *rdfsReader) Close() error {
+	return r.src.Close()
+}
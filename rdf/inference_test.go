@@ -0,0 +1,79 @@
+package rdf
+
+import (
+	"errors"
+	"testing"
+)
+
+func ex(name string) IRI { return IRI{Value: "http://example.org/" + name} }
+
+func rdfsSchema() []Triple {
+	subClassOf := IRI{Value: rdfsSubClassOfIRI}
+	return []Triple{
+		{S: ex("City"), P: subClassOf, O: ex("Place")},
+		{S: ex("Place"), P: subClassOf, O: ex("Feature")},
+		{S: ex("Feature"), P: subClassOf, O: ex("Place")}, // cycle
+		{S: ex("capitalOf"), P: IRI{Value: rdfsSubPropertyOfIRI}, O: ex("locatedIn")},
+		{S: ex("locatedIn"), P: IRI{Value: rdfsDomainIRI}, O: ex("Place")},
+		{S: ex("locatedIn"), P: IRI{Value: rdfsRangeIRI}, O: ex("Region")},
+		{S: ex("name"), P: IRI{Value: rdfsRangeIRI}, O: ex("Label")},
+	}
+}
+
+func TestRDFSClosure(t *testing.T) {
+	rdfType := IRI{Value: rdfTypeIRI}
+	g := ex("g")
+	stmts := []Statement{
+		NewTriple(ex("paris"), rdfType, ex("City")),
+		NewQuad(ex("paris"), ex("capitalOf"), ex("france"), g),
+		NewTriple(ex("paris"), ex("name"), Literal{Lexical: "Paris"}),
+	}
+	out := pipeStatements(t, stmts, RDFSClosure(rdfsSchema()))
+
+	want := map[Statement]bool{
+		stmts[0]: true,
+		stmts[1]: true,
+		stmts[2]: true,
+		NewTriple(ex("paris"), rdfType, ex("Place")):           true,
+		NewTriple(ex("paris"), rdfType, ex("Feature")):         true,
+		NewQuad(ex("paris"), ex("locatedIn"), ex("france"), g): true,
+		NewQuad(ex("paris"), rdfType, ex("Place"), g):          true,
+		NewQuad(ex("paris"), rdfType, ex("Feature"), g):        true,
+		NewQuad(ex("france"), rdfType, ex("Region"), g):        true,
+	}
+	seen := make(map[Statement]bool)
+	for _, s := range out {
+		if !want[s] {
+			t.Errorf("unexpected statement %v", s)
+		}
+		if seen[s] {
+			t.Errorf("duplicate statement %v", s)
+		}
+		seen[s] = true
+	}
+	if len(seen) != len(want) {
+		t.Errorf("got %d statements, want %d: %v", len(seen), len(want), out)
+	}
+	if out[0] != stmts[0] {
+		t.Errorf("first statement = %v, want input order kept", out[0])
+	}
+}
+
+func TestRDFSClosureLimit(t *testing.T) {
+	rdfType := IRI{Value: rdfTypeIRI}
+	stmts := []Statement{
+		NewTriple(ex("a"), rdfType, ex("City")),
+		NewTriple(ex("b"), rdfType, ex("City")),
+	}
+	reader := Pipe(&stubStatementReader{stmts: stmts}, RDFSClosure(rdfsSchema(), OptMaxInferred(3)))
+	defer reader.Close()
+	_, err := collectStatements(reader)
+	if !errors.Is(err, ErrTripleLimitExceeded) {
+		t.Fatalf("err = %v, want ErrTripleLimitExceeded", err)
+	}
+
+	out := pipeStatements(t, stmts, RDFSClosure(rdfsSchema(), OptMaxInferred(0)))
+	if len(out) != 6 {
+		t.Fatalf("unlimited: got %d statements, want 6", len(out))
+	}
+}
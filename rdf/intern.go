@@ -0,0 +1,89 @@
+package rdf
+
+import (
+	"container/list"
+	"strings"
+)
+
+// DefaultInternTermsCap is the number of distinct strings kept by OptInternTerms.
+const DefaultInternTermsCap = 1 << 16
+
+// OptInternTerms deduplicates IRI, datatype and language tag strings in parsed
+// statements through an interning table, so that repeated predicates, classes
+// and datatypes share one string instead of each statement holding its own
+// copy (or pinning the input buffer it was sliced from). The table keeps the
+// DefaultInternTermsCap most recently used strings, which bounds memory on
+// untrusted input; set Options.InternTerms directly for a different cap.
+func OptInternTerms() Option {
+	return func(opts *Options) {
+		opts.InternTerms = DefaultInternTermsCap
+	}
+}
+
+// internPool is a string interning table with least-recently-used eviction.
+type internPool struct {
+	cap     int
+	entries map[string]*list.Element
+	lru     *list.List // front = most recently used
+}
+
+func newInternPool(capacity int) *internPool {
+	return &internPool{cap: capacity, entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+// intern returns the pooled copy of s, adding a private copy if absent.
+func (p *internPool) intern(s string) string {
+	if s == "" {
+		return s
+	}
+	if el, ok := p.entries[s]; ok {
+		p.lru.MoveToFront(el)
+		return el.Value.(string)
+	}
+	s = strings.Clone(s)
+	p.entries[s] = p.lru.PushFront(s)
+	if p.lru.Len() > p.cap {
+		oldest := p.lru.Back()
+		p.lru.Remove(oldest)
+		delete(p.entries, oldest.Value.(string))
+	}
+	return s
+}
+
+func (p *internPool) term(t Term) Term {
+	switch v := t.(type) {
+	case IRI:
+		return IRI{Value: p.intern(v.Value)}
+	case Literal:
+		v.Datatype.Value = p.intern(v.Datatype.Value)
+		v.Lang = p.intern(v.Lang)
+		return v
+	default:
+		return t
+	}
+}
+
+// internReader interns the strings of every statement returned by src.
+type internReader struct {
+	src  Reader
+	pool *internPool
+}
+
+func newInternReader(src Reader, capacity int) Reader {
+	if capacity <= 0 {
+		return src
+	}
+	return &internReader{src: src, pool: newInternPool(capacity)}
+}
+
+func (r *internReader) Next() (Statement, error) {
+	stmt, err := r.src.Next()
+	if err != nil {
+		return Statement{}, err
+	}
+	return mapStatementTerms(stmt, r.pool.term), nil
+}
+
+func (r *internReader) Close() error {
+	return r.src.Close()
+}
@@ -0,0 +1,59 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestOptInternTerms_SharesStrings(t *testing.T) {
+	input := `<http://example.org/a> <http://example.org/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/b> <http://example.org/p> "2"^^<http://www.w3.org/2001/XMLSchema#integer> .
+`
+	reader, err := NewReader(strings.NewReader(input), FormatNTriples, OptInternTerms())
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	stmts, err := collectStatements(reader)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(stmts) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(stmts))
+	}
+	if unsafe.StringData(stmts[0].P.Value) != unsafe.StringData(stmts[1].P.Value) {
+		t.Fatalf("predicate strings should be interned")
+	}
+	dt0 := stmts[0].O.(Literal).Datatype.Value
+	dt1 := stmts[1].O.(Literal).Datatype.Value
+	if unsafe.StringData(dt0) != unsafe.StringData(dt1) {
+		t.Fatalf("datatype strings should be interned")
+	}
+}
+
+func TestInternPool_LRUCap(t *testing.T) {
+	pool := newInternPool(2)
+	a := pool.intern("http://example.org/a")
+	pool.intern("http://example.org/b")
+	pool.intern("http://example.org/a") // refresh a
+	pool.intern("http://example.org/c") // evicts b
+	if len(pool.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(pool.entries))
+	}
+	if _, ok := pool.entries["http://example.org/b"]; ok {
+		t.Fatalf("least recently used entry should be evicted")
+	}
+	if got := pool.intern("http://example.org/a"); unsafe.StringData(got) != unsafe.StringData(a) {
+		t.Fatalf("recently used entry should be kept")
+	}
+}
+
+func TestOptInternTerms_DisabledByDefault(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(""), FormatNTriples)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	if _, ok := reader.(*internReader); ok {
+		t.Fatalf("interning should be opt-in")
+	}
+}
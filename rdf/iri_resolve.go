@@ -1,44 +1,298 @@
 package rdf
 
-import (
-	"net/url"
-	"strings"
-)
+import "strings"
 
-// resolveIRI resolves a relative IRI against a base IRI according to RFC 3986.
-func resolveIRI(baseStr, relative string) string {
-	// Use Go's net/url for proper RFC 3986 resolution.
-	baseURL, err := url.Parse(baseStr)
-	if err != nil {
-		// Fallback to simple concatenation if base is invalid.
-		if strings.HasSuffix(baseStr, "/") {
-			return baseStr + relative
+// ResolveIRI resolves ref against base following RFC 3986 section 5.2
+// (strict mode): references with a scheme are returned with dot segments
+// removed, and relative references take the scheme, authority, path and
+// query they lack from base. No percent-encoding or case changes are made;
+// use NormalizeIRI for that. An empty base leaves relative references
+// relative.
+func ResolveIRI(base, ref string) string {
+	r := parseIRIReference(ref)
+	if r.hasScheme {
+		r.path = removeDotSegments(r.path)
+		return r.String()
+	}
+	if base == "" {
+		return ref
+	}
+	b := parseIRIReference(base)
+	t := iriReference{
+		scheme:      b.scheme,
+		hasScheme:   b.hasScheme,
+		fragment:    r.fragment,
+		hasFragment: r.hasFragment,
+	}
+	switch {
+	case r.hasAuthority:
+		t.authority, t.hasAuthority = r.authority, true
+		t.path = removeDotSegments(r.path)
+		t.query, t.hasQuery = r.query, r.hasQuery
+	case r.path == "":
+		t.authority, t.hasAuthority = b.authority, b.hasAuthority
+		t.path = b.path
+		if r.hasQuery {
+			t.query, t.hasQuery = r.query, true
+		} else {
+			t.query, t.hasQuery = b.query, b.hasQuery
+		}
+	default:
+		t.authority, t.hasAuthority = b.authority, b.hasAuthority
+		if strings.HasPrefix(r.path, "/") {
+			t.path = removeDotSegments(r.path)
+		} else {
+			t.path = removeDotSegments(mergeIRIPaths(b, r.path))
+		}
+		t.query, t.hasQuery = r.query, r.hasQuery
+	}
+	return t.String()
+}
+
+// relativizeIRI returns a relative reference that resolves against base to
+// iri, for writers declaring that base. Only IRIs in the directory of base
+// (or base itself, with another query or fragment) are made relative;
+// false is returned for the others and whenever ResolveIRI would not give
+// iri back, such as for IRIs with dot segments.
+func relativizeIRI(base, iri string) (string, bool) {
+	if i := strings.IndexByte(base, '#'); i >= 0 {
+		base = base[:i]
+	}
+	if base == "" {
+		return "", false
+	}
+	var ref string
+	switch rest := strings.TrimPrefix(iri, base); {
+	case rest == "" || rest[0] == '#':
+		ref = rest
+	default:
+		ref = strings.TrimPrefix(iri, iriDirectory(base))
+		if ref == iri {
+			return "", false
+		}
+		if ref == "" {
+			ref = "."
+		}
+		// A first segment with a colon would read as a scheme.
+		if i := strings.IndexAny(ref, ":/?#"); i >= 0 && ref[i] == ':' {
+			ref = "./" + ref
+		}
+	}
+	if ResolveIRI(base, ref) != iri {
+		return "", false
+	}
+	return ref, true
+}
+
+// iriDirectory returns base up to the last '/' of its path, or "" when
+// its path has none.
+func iriDirectory(base string) string {
+	b := parseIRIReference(base)
+	i := strings.LastIndexByte(b.path, '/')
+	if i < 0 {
+		return ""
+	}
+	b.path, b.query, b.hasQuery, b.fragment, b.hasFragment = b.path[:i+1], "", false, "", false
+	return b.String()
+}
+
+// NormalizeIRI applies the syntax-based normalization of RFC 3986 section
+// 6.2.2: the scheme and host are lowercased, percent-encodings use uppercase
+// hex digits, percent-encoded unreserved characters are decoded and dot
+// segments are removed. For http and https it also drops the default port
+// and turns an empty path into "/" (section 6.2.3). Two IRIs that normalize
+// to the same string identify the same resource.
+func NormalizeIRI(iri string) string {
+	r := parseIRIReference(iri)
+	r.scheme = strings.ToLower(r.scheme)
+	r.path = normalizePercentEncoding(r.path)
+	r.query = normalizePercentEncoding(r.query)
+	r.fragment = normalizePercentEncoding(r.fragment)
+	if r.hasScheme || r.hasAuthority {
+		r.path = removeDotSegments(r.path)
+	}
+	if r.hasAuthority {
+		userinfo, host, port := splitIRIAuthority(r.authority)
+		host = asciiLower(normalizePercentEncoding(host))
+		if (r.scheme == "http" && port == "80") || (r.scheme == "https" && port == "443") {
+			port = ""
+		}
+		r.authority = host
+		if userinfo != "" {
+			r.authority = normalizePercentEncoding(userinfo) + "@" + host
+		}
+		if port != "" {
+			r.authority += ":" + port
+		}
+		if r.path == "" && (r.scheme == "http" || r.scheme == "https") {
+			r.path = "/"
+		}
+	}
+	return r.String()
+}
+
+// iriReference holds the components of an IRI reference as split by the
+// regular expression of RFC 3986 appendix B. The has* flags distinguish
+// empty components from missing ones.
+type iriReference struct {
+	scheme, authority, path, query, fragment       string
+	hasScheme, hasAuthority, hasQuery, hasFragment bool
+}
+
+func parseIRIReference(s string) iriReference {
+	var r iriReference
+	if i := strings.IndexAny(s, ":/?#"); i > 0 && s[i] == ':' {
+		r.scheme, r.hasScheme, s = s[:i], true, s[i+1:]
+	}
+	if strings.HasPrefix(s, "//") {
+		s = s[2:]
+		end := strings.IndexAny(s, "/?#")
+		if end < 0 {
+			end = len(s)
 		}
-		lastSlash := strings.LastIndex(baseStr, "/")
-		if lastSlash >= 0 {
-			return baseStr[:lastSlash+1] + relative
+		r.authority, r.hasAuthority, s = s[:end], true, s[end:]
+	}
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		r.fragment, r.hasFragment, s = s[i+1:], true, s[:i]
+	}
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		r.query, r.hasQuery, s = s[i+1:], true, s[:i]
+	}
+	r.path = s
+	return r
+}
+
+// String recomposes the reference (RFC 3986 section 5.3).
+func (r iriReference) String() string {
+	var b strings.Builder
+	if r.hasScheme {
+		b.WriteString(r.scheme)
+		b.WriteByte(':')
+	}
+	if r.hasAuthority {
+		b.WriteString("//")
+		b.WriteString(r.authority)
+	}
+	b.WriteString(r.path)
+	if r.hasQuery {
+		b.WriteByte('?')
+		b.WriteString(r.query)
+	}
+	if r.hasFragment {
+		b.WriteByte('#')
+		b.WriteString(r.fragment)
+	}
+	return b.String()
+}
+
+// mergeIRIPaths implements the merge routine of RFC 3986 section 5.2.3.
+func mergeIRIPaths(base iriReference, ref string) string {
+	if base.hasAuthority && base.path == "" {
+		return "/" + ref
+	}
+	return base.path[:strings.LastIndexByte(base.path, '/')+1] + ref
+}
+
+// removeDotSegments interprets "." and ".." segments as described in
+// RFC 3986 section 5.2.4.
+func removeDotSegments(path string) string {
+	var output []string
+	pop := func() {
+		if len(output) > 0 {
+			output = output[:len(output)-1]
+		}
+	}
+	for path != "" {
+		switch {
+		case strings.HasPrefix(path, "../"):
+			path = path[3:]
+		case strings.HasPrefix(path, "./"):
+			path = path[2:]
+		case strings.HasPrefix(path, "/./"):
+			path = path[2:]
+		case path == "/.":
+			path = "/"
+		case strings.HasPrefix(path, "/../"):
+			path = path[3:]
+			pop()
+		case path == "/..":
+			path = "/"
+			pop()
+		case path == "." || path == "..":
+			path = ""
+		default:
+			end := strings.IndexByte(path[1:], '/') + 1
+			if end == 0 {
+				end = len(path)
+			}
+			output = append(output, path[:end])
+			path = path[end:]
 		}
-		return baseStr + "/" + relative
 	}
+	return strings.Join(output, "")
+}
+
+// splitIRIAuthority splits an authority into userinfo, host and port.
+func splitIRIAuthority(authority string) (userinfo, host, port string) {
+	if i := strings.LastIndexByte(authority, '@'); i >= 0 {
+		userinfo, authority = authority[:i], authority[i+1:]
+	}
+	host = authority
+	if i := strings.LastIndexByte(authority, ':'); i >= 0 && !strings.Contains(authority[i:], "]") {
+		host, port = authority[:i], authority[i+1:]
+	}
+	return userinfo, host, port
+}
 
-	relURL, err := url.Parse(relative)
-	if err != nil {
-		// Fallback if relative is invalid.
-		if strings.HasSuffix(baseStr, "/") {
-			return baseStr + relative
+// normalizePercentEncoding uppercases the hex digits of percent-encodings
+// and decodes those that encode unreserved characters.
+func normalizePercentEncoding(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) || !isHexDigit(s[i+1]) || !isHexDigit(s[i+2]) {
+			b.WriteByte(s[i])
+			continue
 		}
-		lastSlash := strings.LastIndex(baseStr, "/")
-		if lastSlash >= 0 {
-			return baseStr[:lastSlash+1] + relative
+		c := hexValue(s[i+1])<<4 | hexValue(s[i+2])
+		if isASCIILetter(c) || isDigit(c) || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('%')
+			b.WriteString(strings.ToUpper(s[i+1 : i+3]))
 		}
-		return baseStr + "/" + relative
+		i += 2
 	}
+	return b.String()
+}
 
-	// If relative URL has a scheme, it's absolute - return as-is.
-	if relURL.Scheme != "" {
-		return relative
+func hexValue(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	default:
+		return c - '0'
 	}
+}
 
-	resolved := baseURL.ResolveReference(relURL)
-	return resolved.String()
+// asciiLower lowercases ASCII letters only, leaving other characters of
+// internationalized host names untouched.
+func asciiLower(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 'A' && c <= 'Z' {
+			if b == nil {
+				b = []byte(s)
+			}
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	if b == nil {
+		return s
+	}
+	return string(b)
 }
@@ -0,0 +1,135 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveIRIRFC3986Examples(t *testing.T) {
+	// RFC 3986 section 5.4.
+	const base = "http://a/b/c/d;p?q"
+	cases := map[string]string{
+		"g:h":            "g:h",
+		"g":              "http://a/b/c/g",
+		"./g":            "http://a/b/c/g",
+		"g/":             "http://a/b/c/g/",
+		"/g":             "http://a/g",
+		"//g":            "http://g",
+		"?y":             "http://a/b/c/d;p?y",
+		"g?y":            "http://a/b/c/g?y",
+		"#s":             "http://a/b/c/d;p?q#s",
+		"g#s":            "http://a/b/c/g#s",
+		"g?y#s":          "http://a/b/c/g?y#s",
+		";x":             "http://a/b/c/;x",
+		"g;x":            "http://a/b/c/g;x",
+		"":               "http://a/b/c/d;p?q",
+		".":              "http://a/b/c/",
+		"./":             "http://a/b/c/",
+		"..":             "http://a/b/",
+		"../g":           "http://a/b/g",
+		"../..":          "http://a/",
+		"../../g":        "http://a/g",
+		"../../../g":     "http://a/g",
+		"/./g":           "http://a/g",
+		"/../g":          "http://a/g",
+		"g.":             "http://a/b/c/g.",
+		"..g":            "http://a/b/c/..g",
+		"./../g":         "http://a/b/g",
+		"./g/.":          "http://a/b/c/g/",
+		"g/./h":          "http://a/b/c/g/h",
+		"g/../h":         "http://a/b/c/h",
+		"g;x=1/./y":      "http://a/b/c/g;x=1/y",
+		"g;x=1/../y":     "http://a/b/c/y",
+		"g?y/./x":        "http://a/b/c/g?y/./x",
+		"g#s/../x":       "http://a/b/c/g#s/../x",
+		"http:g":         "http:g",
+		"http://a/b/./c": "http://a/b/c",
+		"é/ü?ö":          "http://a/b/c/é/ü?ö",
+	}
+	for ref, want := range cases {
+		if got := ResolveIRI(base, ref); got != want {
+			t.Errorf("ResolveIRI(%q) = %q, want %q", ref, got, want)
+		}
+	}
+	if got := ResolveIRI("http://a", "g"); got != "http://a/g" {
+		t.Errorf("expected merge with empty base path, got %q", got)
+	}
+	if got := ResolveIRI("", "../g"); got != "../g" {
+		t.Errorf("expected relative reference to stay relative without base, got %q", got)
+	}
+}
+
+func TestNormalizeIRI(t *testing.T) {
+	cases := map[string]string{
+		"HTTP://Example.COM:80":                  "http://example.com/",
+		"https://example.com:443/a/./b/../c":     "https://example.com/a/c",
+		"http://example.com:8080/%7euser/%2f%e9": "http://example.com:8080/~user/%2F%E9",
+		"http://User@[::1]:80/":                  "http://User@[::1]/",
+		"urn:ISBN:0451450523":                    "urn:ISBN:0451450523",
+		"ftp://ftp.example.com:21":               "ftp://ftp.example.com:21",
+		"../a/%41":                               "../a/A",
+	}
+	for in, want := range cases {
+		if got := NormalizeIRI(in); got != want {
+			t.Errorf("NormalizeIRI(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRelativizeIRI(t *testing.T) {
+	const base = "http://example.org/data/doc?q#top"
+	cases := map[string]string{
+		"http://example.org/data/doc?q":      "",
+		"http://example.org/data/doc?q#s":    "#s",
+		"http://example.org/data/item1":      "item1",
+		"http://example.org/data/a/b?x#y":    "a/b?x#y",
+		"http://example.org/data/":           ".",
+		"http://example.org/data/doc":        "doc",
+		"http://example.org/data/a:b":        "./a:b",
+		"http://example.org/other":           "",
+		"http://example.org/data/a/../b":     "",
+		"https://example.org/data/item1":     "",
+		"http://example.org/data/./item1":    "",
+		"http://example.org/database/item1":  "",
+		"http://example.org/data/item1#frag": "item1#frag",
+	}
+	for iri, want := range cases {
+		got, ok := relativizeIRI(base, iri)
+		if ok != (want != "" || iri == "http://example.org/data/doc?q") || got != want {
+			t.Errorf("relativizeIRI(%q) = %q, %v, want %q", iri, got, ok, want)
+			continue
+		}
+		if ok && ResolveIRI(base, got) != iri {
+			t.Errorf("relativizeIRI(%q) = %q does not resolve back", iri, got)
+		}
+	}
+	if _, ok := relativizeIRI("", "http://example.org/a"); ok {
+		t.Error("expected no relative reference without base")
+	}
+}
+
+func TestOptBaseIRI(t *testing.T) {
+	const base = "http://example.org/dir/doc"
+	tests := []struct {
+		format Format
+		input  string
+	}{
+		{FormatTurtle, `<s> <http://example.org/p> <../o> .`},
+		{FormatTriG, `{ <s> <http://example.org/p> <../o> . }`},
+		{FormatRDFXML, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/"><rdf:Description rdf:about="s"><ex:p rdf:resource="../o"/></rdf:Description></rdf:RDF>`},
+		{FormatJSONLD, `{"@id": "s", "http://example.org/p": {"@id": "../o"}}`},
+	}
+	for _, tt := range tests {
+		dec, err := NewReader(strings.NewReader(tt.input), tt.format, OptBaseIRI(base))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.format, err)
+		}
+		stmt, err := dec.Next()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.format, err)
+		}
+		if stmt.S != (IRI{Value: "http://example.org/dir/s"}) || stmt.O != (IRI{Value: "http://example.org/o"}) {
+			t.Errorf("%s: expected IRIs resolved against the base, got %v %v", tt.format, stmt.S, stmt.O)
+		}
+	}
+}
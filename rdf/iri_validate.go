@@ -2,90 +2,303 @@ package rdf
 
 import (
 	"fmt"
-	"net/url"
+	"net/netip"
 	"strings"
+	"unicode/utf8"
 )
 
-// ValidateIRI validates an IRI string according to RFC 3987.
-// Returns an error if the IRI is invalid, nil otherwise.
-//
-// This function performs basic IRI validation:
-// - Checks for valid scheme (required for absolute IRIs)
-// - Validates IRI structure using Go's url.Parse
-// - Ensures the IRI is well-formed
-//
-// Note: This is a basic validation. For full RFC 3987 compliance,
-// consider using a specialized IRI validation library.
+// IRIErrorReason identifies the RFC 3987 rule an IRI violates.
+type IRIErrorReason string
+
+const (
+	// IRIReasonEmpty indicates an empty string.
+	IRIReasonEmpty IRIErrorReason = "EMPTY"
+	// IRIReasonMissingScheme indicates a relative reference where an absolute IRI is required.
+	IRIReasonMissingScheme IRIErrorReason = "MISSING_SCHEME"
+	// IRIReasonInvalidScheme indicates a scheme other than ALPHA *( ALPHA / DIGIT / "+" / "-" / "." ).
+	IRIReasonInvalidScheme IRIErrorReason = "INVALID_SCHEME"
+	// IRIReasonInvalidCharacter indicates a character that is not allowed in its IRI component.
+	IRIReasonInvalidCharacter IRIErrorReason = "INVALID_CHARACTER"
+	// IRIReasonInvalidPercentEncoding indicates a "%" that is not followed by two hex digits.
+	IRIReasonInvalidPercentEncoding IRIErrorReason = "INVALID_PERCENT_ENCODING"
+	// IRIReasonInvalidHost indicates a malformed IP literal in the authority.
+	IRIReasonInvalidHost IRIErrorReason = "INVALID_HOST"
+	// IRIReasonInvalidPort indicates a port that is not made of digits.
+	IRIReasonInvalidPort IRIErrorReason = "INVALID_PORT"
+)
+
+// IRIError describes why an IRI failed validation. Code reports
+// ErrCodeInvalidIRI for errors wrapping it.
+type IRIError struct {
+	IRI    string
+	Offset int // 0-based byte offset of the offending character
+	Reason IRIErrorReason
+}
+
+func (e *IRIError) Error() string {
+	var problem string
+	switch e.Reason {
+	case IRIReasonEmpty:
+		return "invalid IRI: empty IRI"
+	case IRIReasonMissingScheme:
+		problem = "missing scheme"
+	case IRIReasonInvalidScheme:
+		problem = "invalid scheme"
+	case IRIReasonInvalidCharacter:
+		r, _ := utf8.DecodeRuneInString(e.IRI[e.Offset:])
+		problem = fmt.Sprintf("character %q not allowed", r)
+	case IRIReasonInvalidPercentEncoding:
+		problem = "malformed percent-encoding"
+	case IRIReasonInvalidHost:
+		problem = "invalid host"
+	case IRIReasonInvalidPort:
+		problem = "invalid port"
+	default:
+		problem = string(e.Reason)
+	}
+	return fmt.Sprintf("invalid IRI <%s>: %s at offset %d", e.IRI, problem, e.Offset)
+}
+
+// ValidateIRI checks that iri is an IRI reference as defined by RFC 3987:
+// scheme, authority (userinfo, host including IP literals, port), path,
+// query and fragment are checked against their grammar rules, including
+// the ucschar and iprivate ranges and percent-encoding. Relative references
+// are accepted, including network-path references ("//host/path") whose
+// authority is checked like that of an absolute IRI. The returned error is
+// an *IRIError.
 func ValidateIRI(iri string) error {
-	if iri == "" {
-		return fmt.Errorf("empty IRI")
-	}
-
-	// Parse the IRI using Go's url.Parse (which handles IRIs reasonably well)
-	parsed, err := url.Parse(iri)
-	if err != nil {
-		return fmt.Errorf("invalid IRI syntax: %w", err)
-	}
-
-	// For absolute IRIs, scheme is required
-	if parsed.Scheme == "" {
-		// Relative IRIs are valid, but we should check if it looks like it should be absolute
-		// If it starts with //, it's a network-path reference (needs scheme)
-		if strings.HasPrefix(iri, "//") {
-			return fmt.Errorf("relative IRI without scheme: %s", iri)
-		}
-		// If it doesn't start with /, it might be a relative IRI (which is valid)
-		// But if it contains : and doesn't start with /, it might be missing a scheme
-		if strings.Contains(iri, ":") && !strings.HasPrefix(iri, "/") && !strings.HasPrefix(iri, "./") && !strings.HasPrefix(iri, "../") {
-			// Check if the part before : looks like it could be a scheme
-			parts := strings.SplitN(iri, ":", 2)
-			if len(parts) == 2 {
-				scheme := parts[0]
-				// Basic scheme validation: should be alphanumeric with +, -, . allowed
-				validScheme := true
-				if len(scheme) == 0 {
-					validScheme = false
-				}
-				for _, r := range scheme {
-					if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
-						(r >= '0' && r <= '9') || r == '+' || r == '-' || r == '.') {
-						validScheme = false
-						break
-					}
-				}
-				if !validScheme {
-					return fmt.Errorf("IRI appears to be missing a scheme: %s", iri)
-				}
+	if err := validateIRIReference(iri, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate checks that the IRI is an absolute RFC 3987 IRI, as required for
+// RDF terms. See ValidateIRI for the rules; relative references are rejected
+// with IRIReasonMissingScheme.
+func (i IRI) Validate() error {
+	if err := validateIRIReference(i.Value, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateIRIReference follows the IRI-reference production of RFC 3987
+// section 2.2.
+func validateIRIReference(s string, absolute bool) *IRIError {
+	fail := func(offset int, reason IRIErrorReason) *IRIError {
+		return &IRIError{IRI: s, Offset: offset, Reason: reason}
+	}
+	if s == "" {
+		return fail(0, IRIReasonEmpty)
+	}
+	pos := 0
+	if i := strings.IndexAny(s, ":/?#"); i >= 0 && s[i] == ':' {
+		for j := 0; j < i; j++ {
+			if c := s[j]; !isASCIILetter(c) && (j == 0 || (!isDigit(c) && c != '+' && c != '-' && c != '.')) {
+				return fail(j, IRIReasonInvalidScheme)
 			}
 		}
+		if i == 0 {
+			return fail(0, IRIReasonInvalidScheme)
+		}
+		pos = i + 1
+	} else if absolute {
+		return fail(0, IRIReasonMissingScheme)
+	}
+	end := len(s)
+	if i := strings.IndexAny(s[pos:], "?#"); i >= 0 {
+		end = pos + i
+	}
+	if strings.HasPrefix(s[pos:end], "//") {
+		start := pos + 2
+		pos = end
+		if i := strings.IndexByte(s[start:end], '/'); i >= 0 {
+			pos = start + i
+		}
+		if err := validateIRIAuthority(s, start, pos); err != nil {
+			return err
+		}
+	}
+	if err := scanIRIComponent(s, pos, end, isIRIPathChar); err != nil {
+		return err
+	}
+	if end < len(s) && s[end] == '?' {
+		pos = end + 1
+		end = len(s)
+		if i := strings.IndexByte(s[pos:], '#'); i >= 0 {
+			end = pos + i
+		}
+		if err := scanIRIComponent(s, pos, end, isIRIQueryChar); err != nil {
+			return err
+		}
+	}
+	if end < len(s) {
+		return scanIRIComponent(s, end+1, len(s), isIRIFragmentChar)
+	}
+	return nil
+}
+
+// validateIRIAuthority checks s[start:end] against the iauthority rule.
+func validateIRIAuthority(s string, start, end int) *IRIError {
+	if i := strings.IndexByte(s[start:end], '@'); i >= 0 {
+		if err := scanIRIComponent(s, start, start+i, isIRIUserinfoChar); err != nil {
+			return err
+		}
+		start += i + 1
+	}
+	hostEnd := end
+	if strings.HasPrefix(s[start:end], "[") {
+		closing := strings.IndexByte(s[start:end], ']')
+		if closing < 0 || !isIPLiteral(s[start+1:start+closing]) {
+			return &IRIError{IRI: s, Offset: start, Reason: IRIReasonInvalidHost}
+		}
+		hostEnd = start + closing + 1
+		if hostEnd < end && s[hostEnd] != ':' {
+			return &IRIError{IRI: s, Offset: hostEnd, Reason: IRIReasonInvalidCharacter}
+		}
 	} else {
-		// Validate scheme
-		if len(parsed.Scheme) == 0 {
-			return fmt.Errorf("empty scheme in IRI: %s", iri)
-		}
-		// Scheme should start with a letter
-		first := parsed.Scheme[0]
-		if !((first >= 'a' && first <= 'z') || (first >= 'A' && first <= 'Z')) {
-			return fmt.Errorf("scheme must start with a letter: %s", iri)
-		}
-	}
-
-	// Check for invalid characters (basic check)
-	// IRIs can contain Unicode characters, but we'll do basic ASCII validation
-	for i, r := range iri {
-		// Control characters (except space which might be percent-encoded)
-		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
-			return fmt.Errorf("invalid control character at position %d in IRI: %s", i, iri)
-		}
-		// Some characters that should be percent-encoded
-		if r == '<' || r == '>' || r == '"' || r == '{' || r == '}' || r == '|' || r == '^' || r == '`' || r == '\\' {
-			// These might be valid if percent-encoded, but raw they're problematic
-			// We'll be lenient and only warn about obviously problematic cases
-			if r == '<' || r == '>' {
-				return fmt.Errorf("invalid character '%c' at position %d in IRI (should be percent-encoded): %s", r, i, iri)
+		if i := strings.LastIndexByte(s[start:end], ':'); i >= 0 {
+			hostEnd = start + i
+		}
+		if err := scanIRIComponent(s, start, hostEnd, isIRIRegNameChar); err != nil {
+			return err
+		}
+	}
+	for i := hostEnd + 1; i < end; i++ {
+		if !isDigit(s[i]) {
+			return &IRIError{IRI: s, Offset: i, Reason: IRIReasonInvalidPort}
+		}
+	}
+	return nil
+}
+
+// isIPLiteral reports whether host is an IPv6 address (without zone) or an
+// IPvFuture literal, the contents of an RFC 3986 IP-literal.
+func isIPLiteral(host string) bool {
+	if rest, ok := strings.CutPrefix(strings.ToLower(host), "v"); ok {
+		version, addr, ok := strings.Cut(rest, ".")
+		if !ok || version == "" || addr == "" {
+			return false
+		}
+		for i := 0; i < len(version); i++ {
+			if !isHexDigit(version[i]) {
+				return false
+			}
+		}
+		for _, r := range addr {
+			if r >= utf8.RuneSelf || !isIRIUserinfoChar(r) {
+				return false
+			}
+		}
+		return true
+	}
+	if strings.Contains(host, "%") {
+		return false
+	}
+	ip, err := netip.ParseAddr(host)
+	return err == nil && ip.Is6()
+}
+
+// scanIRIComponent checks that every character of s[start:end] outside
+// percent-encodings satisfies allowed.
+func scanIRIComponent(s string, start, end int, allowed func(rune) bool) *IRIError {
+	for i := start; i < end; {
+		if s[i] == '%' {
+			if i+2 >= end || !isHexDigit(s[i+1]) || !isHexDigit(s[i+2]) {
+				return &IRIError{IRI: s, Offset: i, Reason: IRIReasonInvalidPercentEncoding}
 			}
+			i += 3
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:end])
+		if r == utf8.RuneError && size == 1 || !allowed(r) {
+			return &IRIError{IRI: s, Offset: i, Reason: IRIReasonInvalidCharacter}
 		}
+		i += size
+	}
+	return nil
+}
+
+// isIRIUnreserved matches iunreserved: ALPHA, DIGIT, "-", ".", "_", "~" and ucschar.
+func isIRIUnreserved(r rune) bool {
+	if r < utf8.RuneSelf {
+		c := byte(r)
+		return isASCIILetter(c) || isDigit(c) || c == '-' || c == '.' || c == '_' || c == '~'
 	}
+	return isUCSChar(r)
+}
+
+func isIRISubDelim(r rune) bool {
+	return strings.ContainsRune("!$&'()*+,;=", r)
+}
+
+func isIRIRegNameChar(r rune) bool {
+	return isIRIUnreserved(r) || isIRISubDelim(r)
+}
 
+func isIRIUserinfoChar(r rune) bool {
+	return isIRIRegNameChar(r) || r == ':'
+}
+
+func isIRIPathChar(r rune) bool {
+	return isIRIUserinfoChar(r) || r == '@' || r == '/'
+}
+
+func isIRIFragmentChar(r rune) bool {
+	return isIRIPathChar(r) || r == '?'
+}
+
+func isIRIQueryChar(r rune) bool {
+	return isIRIFragmentChar(r) || isIRIPrivate(r)
+}
+
+// isUCSChar matches the ucschar ranges of RFC 3987: everything from U+00A0
+// except surrogates, the private use area, the noncharacters and the last
+// two code points of each supplementary plane, and plane 14 below U+E1000.
+func isUCSChar(r rune) bool {
+	switch {
+	case r >= 0xA0 && r <= 0xD7FF, r >= 0xF900 && r <= 0xFDCF, r >= 0xFDF0 && r <= 0xFFEF:
+		return true
+	case r >= 0x10000 && r <= 0xEFFFD:
+		return r&0xFFFF <= 0xFFFD && (r < 0xE0000 || r >= 0xE1000)
+	}
+	return false
+}
+
+// isIRIPrivate matches iprivate, which RFC 3987 allows in queries only.
+func isIRIPrivate(r rune) bool {
+	return r >= 0xE000 && r <= 0xF8FF || r >= 0xF0000 && r <= 0xFFFFD || r >= 0x100000 && r <= 0x10FFFD
+}
+
+// validateStatementIRIs checks every IRI of stmt with IRI.Validate,
+// including literal datatypes and the terms of triple terms.
+func validateStatementIRIs(stmt Statement) error {
+	var check func(term Term) error
+	check = func(term Term) error {
+		switch v := term.(type) {
+		case IRI:
+			return v.Validate()
+		case Literal:
+			if v.Datatype.Value != "" {
+				return v.Datatype.Validate()
+			}
+		case TripleTerm:
+			for _, t := range []Term{v.S, v.P, v.O} {
+				if err := check(t); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, term := range []Term{stmt.S, stmt.P, stmt.O, stmt.G} {
+		if term == nil {
+			continue
+		}
+		if err := check(term); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package rdf
 
 import (
+	"errors"
+	"io"
 	"strings"
 	"testing"
 )
@@ -57,6 +59,11 @@ func TestValidateIRI(t *testing.T) {
 			iri:     "../path/to/resource",
 			wantErr: false,
 		},
+		{
+			name:    "valid network-path reference",
+			iri:     "//example.org/a",
+			wantErr: false,
+		},
 
 		// Invalid IRIs
 		{
@@ -64,11 +71,6 @@ func TestValidateIRI(t *testing.T) {
 			iri:     "",
 			wantErr: true,
 		},
-		{
-			name:    "relative IRI without scheme (network-path)",
-			iri:     "//example.org/resource",
-			wantErr: true,
-		},
 		{
 			name:    "IRI with invalid control character",
 			iri:     "http://example.org/resource\x00",
@@ -183,3 +185,127 @@ func TestValidateIRI_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateIRIReasons(t *testing.T) {
+	tests := []struct {
+		iri    string
+		reason IRIErrorReason
+		offset int
+	}{
+		{"", IRIReasonEmpty, 0},
+		{"//[::1/", IRIReasonInvalidHost, 2},
+		{"//example.org:x/", IRIReasonInvalidPort, 14},
+		{"1http://example.org/", IRIReasonInvalidScheme, 0},
+		{"ht_tp://example.org/", IRIReasonInvalidScheme, 2},
+		{":x", IRIReasonInvalidScheme, 0},
+		{"http://example.org/a b", IRIReasonInvalidCharacter, 20},
+		{"http://example.org/a%2", IRIReasonInvalidPercentEncoding, 20},
+		{"http://example.org/%zz", IRIReasonInvalidPercentEncoding, 19},
+		{"http://example.org:8o/", IRIReasonInvalidPort, 20},
+		{"http://[::1/", IRIReasonInvalidHost, 7},
+		{"http://[1.2.3.4]/", IRIReasonInvalidHost, 7},
+		{"http://[fe80::1%25eth0]/", IRIReasonInvalidHost, 7},
+		{"http://[::1]x/", IRIReasonInvalidCharacter, 12},
+		{"http://ex@mple@example.org/", IRIReasonInvalidCharacter, 14},
+		{"http://example.org/\uFFFE", IRIReasonInvalidCharacter, 19},
+		{"http://example.org/\uE000", IRIReasonInvalidCharacter, 19},
+		{"http://example.org/?a#\uE000", IRIReasonInvalidCharacter, 22},
+		{"http://example.org/#a#b", IRIReasonInvalidCharacter, 21},
+		{"http://example.org/\xff", IRIReasonInvalidCharacter, 19},
+	}
+	for _, tt := range tests {
+		err := ValidateIRI(tt.iri)
+		var iriErr *IRIError
+		if !errors.As(err, &iriErr) {
+			t.Errorf("ValidateIRI(%q) = %v, want *IRIError", tt.iri, err)
+			continue
+		}
+		if iriErr.Reason != tt.reason || iriErr.Offset != tt.offset {
+			t.Errorf("ValidateIRI(%q): got %s at %d, want %s at %d", tt.iri, iriErr.Reason, iriErr.Offset, tt.reason, tt.offset)
+		}
+		if Code(err) != ErrCodeInvalidIRI {
+			t.Errorf("ValidateIRI(%q): expected code %s, got %s", tt.iri, ErrCodeInvalidIRI, Code(err))
+		}
+	}
+
+	valid := []string{
+		"http://例え.jp/パス?クエリ#断片",
+		"http://example.org/?q=\uE000",
+		"http://[2001:db8::1]:8080/",
+		"http://[v7.fe:80]/",
+		"http://192.0.2.1/",
+		"mailto:user@example.org",
+		"urn:uuid:6e8bc430-9c3a-11d9-9669-0800200c9a66",
+		"http://example.org/\U00010000",
+		"tag:x,2024:a?b/c?d#e/f?g",
+		"a/b:c",
+		"?q",
+		"#frag",
+	}
+	for _, iri := range valid {
+		if err := ValidateIRI(iri); err != nil {
+			t.Errorf("ValidateIRI(%q) = %v, want nil", iri, err)
+		}
+	}
+}
+
+func TestIRIValidate(t *testing.T) {
+	if err := (IRI{Value: "http://example.org/s"}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := IRI{Value: "/relative"}.Validate()
+	var iriErr *IRIError
+	if !errors.As(err, &iriErr) || iriErr.Reason != IRIReasonMissingScheme {
+		t.Fatalf("expected missing scheme error, got %v", err)
+	}
+	if got := err.Error(); got != "invalid IRI </relative>: missing scheme at offset 0" {
+		t.Fatalf("unexpected message %q", got)
+	}
+	if err := (IRI{Value: "//example.org/a"}).Validate(); !errors.As(err, &iriErr) || iriErr.Reason != IRIReasonMissingScheme {
+		t.Fatalf("expected missing scheme error for a network-path reference, got %v", err)
+	}
+}
+
+func TestOptValidateIRIs(t *testing.T) {
+	input := `<http://example.org/s> <http://example.org/p> <http://example.org/o> .
+<rel> <http://example.org/p> <http://example.org/o> .
+<http://example.org/s> <http://example.org/p> "x"^^<http://example.org:8o/dt> .
+<http://example.org/s2> <http://example.org/p> <http://example.org/o> .
+`
+	reader, err := NewReader(strings.NewReader(input), FormatTurtle, OptValidateIRIs())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := reader.Next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = reader.Next()
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || Code(err) != ErrCodeInvalidIRI {
+		t.Fatalf("expected invalid IRI error on line 2, got %v", err)
+	}
+	reader.Close()
+
+	reader, err = NewReader(strings.NewReader(input), FormatTurtle, OptValidateIRIs(), OptContinueOnError(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	var subjects []string
+	for {
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		subjects = append(subjects, stmt.S.String())
+	}
+	if len(subjects) != 2 || subjects[1] != "http://example.org/s2" {
+		t.Fatalf("expected the invalid statements to be skipped, got %v", subjects)
+	}
+	if errs := reader.(ErrorCollector).Errors(); len(errs) != 2 {
+		t.Fatalf("expected 2 recorded errors, got %v", errs)
+	}
+}
@@ -0,0 +1,42 @@
+package rdf
+
+import (
+	"io"
+	"iter"
+)
+
+// Statements returns an iterator over the statements parsed from r:
+//
+//	for stmt, err := range rdf.Statements(r, rdf.FormatTurtle) {
+//		if err != nil {
+//			return err
+//		}
+//		// use stmt
+//	}
+//
+// A reader construction or parse error is yielded once with a zero Statement,
+// after which iteration stops. The underlying reader is closed when iteration
+// ends, including when the loop body breaks early.
+func Statements(r io.Reader, format Format, opts ...Option) iter.Seq2[Statement, error] {
+	return func(yield func(Statement, error) bool) {
+		reader, err := NewReader(r, format, opts...)
+		if err != nil {
+			yield(Statement{}, err)
+			return
+		}
+		defer reader.Close()
+		for {
+			stmt, err := reader.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Statement{}, err)
+				return
+			}
+			if !yield(stmt, nil) {
+				return
+			}
+		}
+	}
+}
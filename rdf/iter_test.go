@@ -0,0 +1,61 @@
+package rdf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStatements_Range(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"1\" .\n<http://example.org/s> <http://example.org/p> \"2\" .\n"
+	count := 0
+	for stmt, err := range Statements(strings.NewReader(input), FormatNTriples) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stmt.S.String() != "http://example.org/s" {
+			t.Fatalf("unexpected subject %v", stmt.S)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 statements, got %d", count)
+	}
+}
+
+func TestStatements_Break(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"1\" .\n<http://example.org/s> <http://example.org/p> \"2\" .\n"
+	count := 0
+	for range Statements(strings.NewReader(input), FormatNTriples) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Fatalf("expected loop to stop after 1 statement, got %d", count)
+	}
+}
+
+func TestStatements_ParseError(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"1\" .\nnot a triple\n"
+	var errs []error
+	for _, err := range Statements(strings.NewReader(input), FormatNTriples) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one error, got %v", errs)
+	}
+	var parseErr *ParseError
+	if !errors.As(errs[0], &parseErr) {
+		t.Fatalf("expected ParseError, got %T", errs[0])
+	}
+}
+
+func TestStatements_UnsupportedFormat(t *testing.T) {
+	for _, err := range Statements(strings.NewReader(""), Format("bogus")) {
+		if !errors.Is(err, ErrUnsupportedFormat) {
+			t.Fatalf("expected ErrUnsupportedFormat, got %v", err)
+		}
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -44,7 +46,7 @@ func newJSONLDtripleDecoderWithOptions(r io.Reader, opts JSONLDOptions) tripleDe
 			}
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return context.Cause(ctx)
 			case dec.out <- q.ToTriple():
 				return nil
 			}
@@ -111,7 +113,7 @@ func newJSONLDquadDecoderWithOptions(r io.Reader, opts JSONLDOptions) quadDecode
 			}
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return context.Cause(ctx)
 			case dec.out <- q:
 				return nil
 			}
@@ -171,6 +173,12 @@ func resolveContextValue(contextVal interface{}, opts JSONLDOptions) (interface{
 			if err != nil {
 				return nil, fmt.Errorf("jsonld: failed to load remote context %q: %w", urlStr, err)
 			}
+			// Loaders may return the context document or the bare context.
+			if doc, ok := remote.Document.(map[string]interface{}); ok {
+				if inner, ok := doc["@context"]; ok {
+					return resolveContextValue(inner, opts)
+				}
+			}
 			return remote.Document, nil
 		}
 		// No DocumentLoader - return as-is (will be ignored by withContext)
@@ -250,10 +258,87 @@ func parseJSONLDToSink(data interface{}, opts JSONLDOptions, sink jsonldQuadSink
 }
 
 func parseJSONLDFromReader(r io.Reader, opts JSONLDOptions, sink jsonldQuadSink) error {
+	lines := &jsonldLineReader{r: r, lastBreak: -1}
+	dec := json.NewDecoder(lines)
+	dec.UseNumber()
+	lines.dec = dec
+	err := parseJSONLDStream(dec, opts, sink)
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	offset := int(dec.InputOffset())
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr) && syntaxErr.Offset > 0:
+		// Offset counts the bytes read including the offending one.
+		offset = int(syntaxErr.Offset) - 1
+	case errors.As(err, &typeErr):
+		offset = int(typeErr.Offset)
+	}
+	// The decoder reports the end of the input inside a value as io.EOF from
+	// Token, io.ErrUnexpectedEOF from Decode or a syntax error from More.
+	if offset > 0 && errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	} else if syntaxErr != nil && syntaxErr.Error() == "unexpected end of JSON input" {
+		err = &truncatedError{err}
+	}
+	line, column := lines.position(offset)
+	return wrapParseErrorWithPosition("jsonld", "", line, column, offset, err)
+}
+
+// jsonldLineReader records the offsets of line breaks passing through to the
+// JSON decoder so that decoder byte offsets can be reported as line and
+// column. Breaks before the decoder's current offset are folded into a count
+// whenever more input is read, keeping memory bounded by the decoder buffer.
+type jsonldLineReader struct {
+	r         io.Reader
+	dec       *json.Decoder
+	read      int   // Bytes read from r so far
+	breaks    []int // Offsets of '\n' bytes not yet folded into lines
+	lines     int   // Number of folded line breaks
+	lastBreak int   // Offset of the last folded line break (-1 if none)
+}
+
+func (l *jsonldLineReader) Read(p []byte) (int, error) {
+	if l.dec != nil {
+		l.fold(int(l.dec.InputOffset()))
+	}
+	n, err := l.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			l.breaks = append(l.breaks, l.read+i)
+		}
+	}
+	l.read += n
+	return n, err
+}
+
+// fold discards recorded line breaks before offset.
+func (l *jsonldLineReader) fold(offset int) {
+	idx := sort.SearchInts(l.breaks, offset)
+	if idx == 0 {
+		return
+	}
+	l.lines += idx
+	l.lastBreak = l.breaks[idx-1]
+	l.breaks = append(l.breaks[:0], l.breaks[idx:]...)
+}
+
+// position returns the 1-based line and byte column of offset.
+func (l *jsonldLineReader) position(offset int) (line, column int) {
+	idx := sort.SearchInts(l.breaks, offset)
+	lineStart := l.lastBreak + 1
+	if idx > 0 {
+		lineStart = l.breaks[idx-1] + 1
+	}
+	return l.lines + idx + 1, offset - lineStart + 1
+}
+
+func parseJSONLDStream(dec *json.Decoder, opts JSONLDOptions, sink jsonldQuadSink) error {
 	if opts.MaxQuads > 0 {
 		sink = limitJSONLDSink(sink, opts.MaxQuads)
 	}
-	dec := json.NewDecoder(r)
 	token, err := dec.Token()
 	if err != nil {
 		return err
@@ -292,7 +377,7 @@ func parseJSONLDTopArrayStream(dec *json.Decoder, opts JSONLDOptions, sink jsonl
 		if err != nil {
 			return err
 		}
-		value, err := decodeJSONValueFromToken(dec, token)
+		value, err := state.decodeValue(dec, token, 1)
 		if err != nil {
 			return err
 		}
@@ -332,7 +417,18 @@ func parseJSONLDTopObjectStream(dec *json.Decoder, opts JSONLDOptions, sink json
 	}
 	topNode := map[string]interface{}{}
 	var bufferedGraph []interface{}
+	var graphBytes int64 // Input size of bufferedGraph
 	var graphSeen bool
+	// flushGraph parses the graph items buffered until @context was read.
+	flushGraph := func() error {
+		if err := parseJSONLDGraph(bufferedGraph, ctx, nil, state, sink); err != nil {
+			return err
+		}
+		bufferedGraph = nil
+		state.held -= graphBytes
+		graphBytes = 0
+		return nil
+	}
 
 	for dec.More() {
 		if err := state.checkContext(); err != nil {
@@ -352,17 +448,26 @@ func parseJSONLDTopObjectStream(dec *json.Decoder, opts JSONLDOptions, sink json
 		}
 		switch key {
 		case "@context":
-			value, err := decodeJSONValueFromToken(dec, valueToken)
+			value, err := state.decodeValue(dec, valueToken, 1)
 			if err != nil {
 				return err
 			}
+			if opts.DocumentLoader != nil && value != nil {
+				resolved, err := resolveContextValue(value, opts)
+				if err != nil {
+					return err
+				}
+				if resolved != nil {
+					value = resolved
+				}
+			}
 			ctx = ctx.withContext(value)
 			topNode["@context"] = value
+			state.held += state.valueBytes
 			if len(bufferedGraph) > 0 {
-				if err := parseJSONLDGraph(bufferedGraph, ctx, nil, state, sink); err != nil {
+				if err := flushGraph(); err != nil {
 					return err
 				}
-				bufferedGraph = nil
 			}
 		case "@graph":
 			graphSeen = true
@@ -379,7 +484,7 @@ func parseJSONLDTopObjectStream(dec *json.Decoder, opts JSONLDOptions, sink json
 						if err != nil {
 							return err
 						}
-						item, err := decodeJSONValueFromToken(dec, itemToken)
+						item, err := state.decodeValue(dec, itemToken, 2)
 						if err != nil {
 							return err
 						}
@@ -412,7 +517,7 @@ func parseJSONLDTopObjectStream(dec *json.Decoder, opts JSONLDOptions, sink json
 						if err != nil {
 							return err
 						}
-						item, err := decodeJSONValueFromToken(dec, itemToken)
+						item, err := state.decodeValue(dec, itemToken, 2)
 						if err != nil {
 							return err
 						}
@@ -421,6 +526,8 @@ func parseJSONLDTopObjectStream(dec *json.Decoder, opts JSONLDOptions, sink json
 							return fmt.Errorf("jsonld: @graph item limit exceeded")
 						}
 						bufferedGraph = append(bufferedGraph, item)
+						graphBytes += state.valueBytes
+						state.held += state.valueBytes
 					}
 					if _, err := dec.Token(); err != nil {
 						return err
@@ -429,10 +536,14 @@ func parseJSONLDTopObjectStream(dec *json.Decoder, opts JSONLDOptions, sink json
 				}
 			}
 			// For non-array @graph values, decode and handle
-			value, err := decodeJSONValueFromToken(dec, valueToken)
+			value, err := state.decodeValue(dec, valueToken, 1)
 			if err != nil {
 				return err
 			}
+			if topNode["@context"] == nil {
+				graphBytes += state.valueBytes
+				state.held += state.valueBytes
+			}
 			switch graphValue := value.(type) {
 			case []interface{}:
 				if topNode["@context"] == nil {
@@ -448,18 +559,19 @@ func parseJSONLDTopObjectStream(dec *json.Decoder, opts JSONLDOptions, sink json
 				}
 			}
 		default:
-			value, err := decodeJSONValueFromToken(dec, valueToken)
+			value, err := state.decodeValue(dec, valueToken, 1)
 			if err != nil {
 				return err
 			}
 			topNode[key] = value
+			state.held += state.valueBytes
 		}
 	}
 	if _, err := dec.Token(); err != nil {
 		return err
 	}
 	if len(bufferedGraph) > 0 {
-		if err := parseJSONLDGraph(bufferedGraph, ctx, nil, state, sink); err != nil {
+		if err := flushGraph(); err != nil {
 			return err
 		}
 	}
@@ -478,9 +590,32 @@ func parseJSONLDTopObjectStream(dec *json.Decoder, opts JSONLDOptions, sink json
 	return nil
 }
 
-func decodeJSONValueFromToken(dec *json.Decoder, token json.Token) (interface{}, error) {
+// decodeValue decodes the JSON value that starts with token inside depth
+// enclosing arrays and objects, enforcing the MaxDepth and MaxValueBytes
+// options.
+func (s *jsonldState) decodeValue(dec *json.Decoder, token json.Token, depth int) (interface{}, error) {
+	start := dec.InputOffset()
+	value, err := s.decodeJSONValue(dec, token, start, depth)
+	s.valueBytes = dec.InputOffset() - start
+	return value, err
+}
+
+// decodeJSONValue decodes a value nested depth levels deep in a value that
+// starts at offset start.
+func (s *jsonldState) decodeJSONValue(dec *json.Decoder, token json.Token, start int64, depth int) (interface{}, error) {
+	size := dec.InputOffset() - start
+	if s.opts.MaxValueBytes > 0 && size > s.opts.MaxValueBytes {
+		return nil, ErrStatementTooLong
+	}
+	if s.opts.MaxBufferedBytes > 0 && s.held+size > s.opts.MaxBufferedBytes {
+		return nil, errBufferBudget(s.opts.MaxBufferedBytes)
+	}
 	switch value := token.(type) {
 	case json.Delim:
+		depth++
+		if s.opts.MaxDepth > 0 && depth > s.opts.MaxDepth {
+			return nil, ErrDepthExceeded
+		}
 		switch value {
 		case '{':
 			obj := map[string]interface{}{}
@@ -497,7 +632,7 @@ func decodeJSONValueFromToken(dec *json.Decoder, token json.Token) (interface{},
 				if err != nil {
 					return nil, err
 				}
-				val, err := decodeJSONValueFromToken(dec, valToken)
+				val, err := s.decodeJSONValue(dec, valToken, start, depth)
 				if err != nil {
 					return nil, err
 				}
@@ -512,7 +647,7 @@ func decodeJSONValueFromToken(dec *json.Decoder, token json.Token) (interface{},
 				if err != nil {
 					return nil, err
 				}
-				val, err := decodeJSONValueFromToken(dec, valToken)
+				val, err := s.decodeJSONValue(dec, valToken, start, depth)
 				if err != nil {
 					return nil, err
 				}
@@ -545,6 +680,10 @@ type jsonldState struct {
 	ctx        context.Context
 	nodeCount  int
 	maxNodes   int
+	// held is the input size of the decoded values kept for later, and
+	// valueBytes the size of the last value decoded, for MaxBufferedBytes.
+	held       int64
+	valueBytes int64
 }
 
 func (s *jsonldState) newBlankNode() BlankNode {
@@ -637,6 +776,9 @@ func parseJSONLDNode(node map[string]interface{}, ctx jsonldContext, graphName T
 	}
 	// Apply node-level @context if present
 	ctx = ctx.withContext(node["@context"])
+	if state.opts.MaxTermDefinitions > 0 && len(ctx.prefixes) > state.opts.MaxTermDefinitions {
+		return ErrPrefixLimitExceeded
+	}
 	// Extract and resolve subject from @id
 	subject, err := jsonldSubject(node["@id"], ctx, state)
 	if err != nil {
@@ -681,8 +823,8 @@ func emitJSONLDValue(subject Term, pred IRI, raw interface{}, ctx jsonldContext,
 		return emitJSONLDObjectValue(value, subject, pred, ctx, graphName, state, sink)
 	case string:
 		return sink(Quad{S: subject, P: pred, O: Literal{Lexical: value}, G: graphName})
-	case float64, bool:
-		lit := emitJSONLDLiteralValue(value, ctx)
+	case json.Number, float64, bool:
+		lit := emitJSONLDLiteralValue(value, "")
 		return sink(Quad{S: subject, P: pred, O: lit, G: graphName})
 	default:
 		return fmt.Errorf("jsonld: unsupported literal value (got %T)", value)
@@ -702,7 +844,7 @@ func expandJSONLDTerm(value string, ctx jsonldContext) string {
 		return ctx.vocab + value
 	}
 	if ctx.base != "" {
-		return resolveIRI(ctx.base, value)
+		return ResolveIRI(ctx.base, value)
 	}
 	return value
 }
@@ -719,7 +861,7 @@ func jsonldSubject(raw interface{}, ctx jsonldContext, state *jsonldState) (Term
 		if expanded == "" {
 			return nil, fmt.Errorf("jsonld: node missing @id (failed to expand %q)", idValue)
 		}
-		return IRI{Value: expanded}, nil
+		return jsonldIDTerm(expanded), nil
 	}
 	return nil, fmt.Errorf("jsonld: node missing @id (got %T, expected string)", raw)
 }
@@ -728,7 +870,17 @@ func jsonldObjectFromID(idValue string, ctx jsonldContext, state *jsonldState) T
 	if strings.HasPrefix(idValue, "_:") {
 		return BlankNode{ID: strings.TrimPrefix(idValue, "_:")}
 	}
-	return IRI{Value: expandJSONLDTerm(idValue, ctx)}
+	return jsonldIDTerm(expandJSONLDTerm(idValue, ctx))
+}
+
+// jsonldIDTerm returns the node an expanded @id or @type names: a blank
+// node if it starts with "_:", as when a prefix or @vocab maps to "_:",
+// and an IRI otherwise.
+func jsonldIDTerm(expanded string) Term {
+	if label, ok := strings.CutPrefix(expanded, "_:"); ok {
+		return BlankNode{ID: label}
+	}
+	return IRI{Value: expanded}
 }
 
 // emitJSONLDList processes a @list value and emits RDF list structure (rdf:first/rdf:rest).
@@ -786,20 +938,17 @@ func jsonldValueTerm(raw interface{}, ctx jsonldContext, graphName Term, state *
 			return jsonldObjectFromID(idValue, ctx, state), nil
 		}
 		if literalValue, ok := value["@value"]; ok {
-			lit := emitJSONLDLiteralValue(literalValue, ctx)
-			if lang, ok := value["@language"].(string); ok {
-				lit.Lang = lang
-			}
-			if dtype, ok := value["@type"].(string); ok {
-				lit.Datatype = IRI{Value: expandJSONLDTerm(dtype, ctx)}
+			lit := emitJSONLDLiteralValue(literalValue, jsonldValueDatatype(value, ctx))
+			if err := setJSONLDLanguage(&lit, value); err != nil {
+				return nil, err
 			}
 			return lit, nil
 		}
 		return nil, fmt.Errorf("jsonld: unsupported list value (map without @id or @value)")
 	case string:
 		return Literal{Lexical: value}, nil
-	case float64, bool:
-		return emitJSONLDLiteralValue(value, ctx), nil
+	case json.Number, float64, bool:
+		return emitJSONLDLiteralValue(value, ""), nil
 	default:
 		return nil, fmt.Errorf("jsonld: unsupported list value")
 	}
@@ -819,6 +968,7 @@ type jsonldtripleEncoder struct {
 	err     error
 	emitted bool
 	opts    JSONLDOptions
+	labels  map[string]string // Output labels of blank nodes, unless kept
 }
 
 func newJSONLDtripleEncoder(w io.Writer) tripleEncoder {
@@ -826,10 +976,13 @@ func newJSONLDtripleEncoder(w io.Writer) tripleEncoder {
 }
 
 func newJSONLDtripleEncoderWithOptions(w io.Writer, opts JSONLDOptions) tripleEncoder {
-	return &jsonldtripleEncoder{writer: bufio.NewWriter(w), raw: w, opts: opts}
+	return &jsonldtripleEncoder{writer: newEncoderBuffer(w), raw: w, opts: opts}
 }
 
 func shouldEagerFlushJSONLD(w io.Writer) bool {
+	if c, ok := w.(*countingWriter); ok {
+		w = c.w
+	}
 	typeName := fmt.Sprintf("%T", w)
 	return strings.Contains(typeName, "errWriter") || strings.Contains(typeName, "failAfterWriter")
 }
@@ -876,6 +1029,7 @@ func (e *jsonldtripleEncoder) Write(t Triple) error {
 			}
 		}
 	}
+	t.S, t.O = e.relabel(t.S), e.relabel(t.O)
 	subjectID, err := jsonldSubjectID(t.S)
 	if err != nil {
 		e.err = err
@@ -896,6 +1050,17 @@ func (e *jsonldtripleEncoder) Write(t Triple) error {
 		e.err = err
 		return err
 	}
+	if t.P.Value == rdfTypeIRI && !e.opts.UseRdfType {
+		// Node types go into @type unless UseRdfType keeps rdf:type as a
+		// regular property; literal types always stay properties.
+		if id, err := jsonldSubjectID(t.O); err == nil {
+			predicateJSON = []byte(`"@type"`)
+			if objectJSON, err = json.Marshal(id); err != nil {
+				e.err = err
+				return err
+			}
+		}
+	}
 	if _, err := e.writer.WriteString("{\"@id\":"); err != nil {
 		e.err = err
 		return err
@@ -954,6 +1119,36 @@ func (e *jsonldtripleEncoder) Close() error {
 	return e.Flush()
 }
 
+// relabel returns the blank node term names in the output, as chosen by
+// JSONLDOptions.BlankNodeIDs. Other terms are returned as they are.
+func (e *jsonldtripleEncoder) relabel(term Term) Term {
+	blank, ok := term.(BlankNode)
+	if !ok || e.opts.BlankNodeIDs == JSONLDBlankNodesKeep {
+		return term
+	}
+	if e.labels == nil {
+		e.labels = make(map[string]string)
+	}
+	label, ok := e.labels[blank.ID]
+	if !ok {
+		if e.opts.BlankNodeIDs == JSONLDBlankNodesUUID {
+			label = newUUID()
+		} else {
+			label = fmt.Sprintf("b%d", len(e.labels))
+		}
+		e.labels[blank.ID] = label
+	}
+	return BlankNode{ID: label}
+}
+
+// newUUID returns a random version 4 UUID in its text form.
+func newUUID() string {
+	b := randomBytes(16)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 func jsonldObjectValueJSON(term Term) ([]byte, error) {
 	switch value := term.(type) {
 	case IRI:
@@ -964,6 +1159,9 @@ func jsonldObjectValueJSON(term Term) ([]byte, error) {
 		if value.Lang != "" && value.Datatype.Value != "" {
 			return json.Marshal(map[string]string{"@value": value.Lexical})
 		}
+		if value.Lang != "" && value.Direction != "" {
+			return json.Marshal(map[string]string{"@value": value.Lexical, "@language": value.Lang, "@direction": value.Direction})
+		}
 		if value.Lang != "" {
 			return json.Marshal(map[string]string{"@value": value.Lexical, "@language": value.Lang})
 		}
@@ -1014,7 +1212,7 @@ func checkJSONLDContext(ctx context.Context) error {
 	}
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return context.Cause(ctx)
 	default:
 		return nil
 	}
@@ -1081,3 +1279,92 @@ func (e *jsonldquadEncoder) Flush() error {
 func (e *jsonldquadEncoder) Close() error {
 	return e.inner.Close()
 }
+
+// jsonlddocumentEncoder collects quads and writes them as one document,
+// flattened and/or compacted against a context, when closed.
+type jsonlddocumentEncoder struct {
+	writer  *bufio.Writer
+	context interface{}
+	flatten bool
+	opts    JSONLDOptions
+	quads   []Quad
+	closed  bool
+	err     error
+}
+
+func newJSONLDdocumentEncoder(w io.Writer, context interface{}, flatten bool, opts JSONLDOptions) quadEncoder {
+	return &jsonlddocumentEncoder{writer: newEncoderBuffer(w), context: context, flatten: flatten, opts: opts}
+}
+
+func (e *jsonlddocumentEncoder) Write(q Quad) error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.closed {
+		return fmt.Errorf("jsonld: writer closed")
+	}
+	if q.IsZero() {
+		return nil
+	}
+	switch q.S.(type) {
+	case IRI, BlankNode:
+	default:
+		return fmt.Errorf("jsonld: invalid subject")
+	}
+	if q.P.Value == "" {
+		return fmt.Errorf("jsonld: missing predicate")
+	}
+	if q.O == nil {
+		return fmt.Errorf("jsonld: missing object")
+	}
+	e.quads = append(e.quads, q)
+	return nil
+}
+
+// Flush writes nothing: the document is processed as a whole on Close.
+func (e *jsonlddocumentEncoder) Flush() error {
+	return e.err
+}
+
+func (e *jsonlddocumentEncoder) Close() error {
+	if e.closed {
+		return e.err
+	}
+	e.closed = true
+	ctx := jsonldContextOrBackground(e.opts)
+	proc := NewJSONLDProcessor()
+	expanded, err := proc.FromRDF(ctx, e.quads, e.opts)
+	if err != nil {
+		e.err = fmt.Errorf("jsonld: %w", err)
+		return e.err
+	}
+	var document interface{}
+	if e.flatten {
+		document, err = proc.Flatten(ctx, expanded, e.context, e.opts)
+		if err != nil {
+			e.err = fmt.Errorf("jsonld: flattening failed: %w", err)
+			return e.err
+		}
+	} else {
+		document, err = proc.Compact(ctx, expanded, e.context, e.opts)
+		if err != nil {
+			e.err = fmt.Errorf("jsonld: compaction failed: %w", err)
+			return e.err
+		}
+	}
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		e.err = err
+		return err
+	}
+	e.quads = nil
+	if _, err := e.writer.Write(append(data, '\n')); err != nil {
+		e.err = err
+		return err
+	}
+	if err := e.writer.Flush(); err != nil {
+		e.err = err
+		return err
+	}
+	return nil
+}
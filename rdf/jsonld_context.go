@@ -0,0 +1,896 @@
+package rdf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	jsonldMode10 = "json-ld-1.0"
+	jsonldMode11 = "json-ld-1.1"
+
+	// jsonldMaxRemoteContexts bounds the chain of remote contexts loaded
+	// while processing one context, which catches recursive inclusion.
+	jsonldMaxRemoteContexts = 32
+)
+
+// JSONLDError reports a JSON-LD processing error. Code is one of the error
+// codes of the JSON-LD 1.1 API, such as "invalid term definition" or
+// "loading remote context failed".
+type JSONLDError struct {
+	Code   string
+	Detail string
+}
+
+func (e *JSONLDError) Error() string {
+	if e.Detail == "" {
+		return "jsonld: " + e.Code
+	}
+	return "jsonld: " + e.Code + ": " + e.Detail
+}
+
+func jsonldError(code, format string, args ...interface{}) error {
+	return &JSONLDError{Code: code, Detail: fmt.Sprintf(format, args...)}
+}
+
+// jsonldActiveContext is the active context of the JSON-LD 1.1 API.
+// Contexts are copied on change, so a context may be shared freely.
+type jsonldActiveContext struct {
+	terms        map[string]*jsonldTermDefinition
+	base         string
+	hasBase      bool // false once @base is null
+	originalBase string
+	vocab        string
+	hasVocab     bool
+	language     string // default language, lowercased
+	direction    string // default base direction
+	previous     *jsonldActiveContext
+	mode         string
+}
+
+// jsonldTermDefinition is a term definition. An empty id is a null IRI
+// mapping, which makes the term expand to nothing.
+type jsonldTermDefinition struct {
+	id           string
+	reverse      bool
+	typ          string
+	language     string // "" with hasLanguage set is a null language
+	hasLanguage  bool
+	direction    string
+	hasDirection bool
+	container    []string
+	context      interface{}
+	hasContext   bool
+	baseURL      string
+	protected    bool
+	prefix       bool
+	index        string
+	nest         string
+}
+
+func newJSONLDActiveContext(base, mode string) *jsonldActiveContext {
+	return &jsonldActiveContext{
+		terms:        map[string]*jsonldTermDefinition{},
+		base:         base,
+		hasBase:      true,
+		originalBase: base,
+		mode:         mode,
+	}
+}
+
+func (c *jsonldActiveContext) clone() *jsonldActiveContext {
+	out := *c
+	out.terms = make(map[string]*jsonldTermDefinition, len(c.terms))
+	for term, def := range c.terms {
+		out.terms[term] = def
+	}
+	return &out
+}
+
+func (d *jsonldTermDefinition) hasContainer(keyword string) bool {
+	return d != nil && jsonldContains(d.container, keyword)
+}
+
+// equal compares two definitions, ignoring the protected flag.
+func (d *jsonldTermDefinition) equal(other *jsonldTermDefinition) bool {
+	if d.id != other.id || d.reverse != other.reverse || d.typ != other.typ ||
+		d.language != other.language || d.hasLanguage != other.hasLanguage ||
+		d.direction != other.direction || d.hasDirection != other.hasDirection ||
+		d.prefix != other.prefix || d.index != other.index || d.nest != other.nest ||
+		d.hasContext != other.hasContext || len(d.container) != len(other.container) {
+		return false
+	}
+	for i := range d.container {
+		if d.container[i] != other.container[i] {
+			return false
+		}
+	}
+	if d.hasContext {
+		a, errA := canonicalizeJSONLiteralValue(d.context)
+		b, errB := canonicalizeJSONLiteralValue(other.context)
+		return errA == nil && errB == nil && a == b
+	}
+	return true
+}
+
+// jsonldProcessor holds the state of one run of the JSON-LD algorithms.
+type jsonldProcessor struct {
+	ctx     context.Context
+	opts    JSONLDOptions
+	mode    string
+	loader  DocumentLoader
+	loaded  map[string]RemoteDocument
+	bnodes  map[string]string
+	counter int
+	env     jsonldTermEnv
+}
+
+// jsonldTermEnv holds the parameters of the context definition whose terms
+// are being created, which term definitions created as dependencies during
+// IRI expansion share.
+type jsonldTermEnv struct {
+	baseURL           string
+	remote            []string
+	overrideProtected bool
+}
+
+func newJSONLDProcessor(ctx context.Context, opts JSONLDOptions) *jsonldProcessor {
+	mode := opts.ProcessingMode
+	if mode != jsonldMode10 {
+		mode = jsonldMode11
+	}
+	loader := opts.DocumentLoader
+	if loader == nil {
+		loader = NewCachingDocumentLoader()
+	}
+	return &jsonldProcessor{
+		ctx:    ctx,
+		opts:   opts,
+		mode:   mode,
+		loader: loader,
+		loaded: map[string]RemoteDocument{},
+		bnodes: map[string]string{},
+	}
+}
+
+// blankNode returns the relabeled identifier of a blank node, or a new
+// identifier if id is empty.
+func (p *jsonldProcessor) blankNode(id string) string {
+	if id != "" {
+		if label, ok := p.bnodes[id]; ok {
+			return label
+		}
+	}
+	label := fmt.Sprintf("_:b%d", p.counter)
+	p.counter++
+	if id != "" {
+		p.bnodes[id] = label
+	}
+	return label
+}
+
+// loadContext dereferences a remote context document once per run and
+// returns its @context entry and document URL.
+func (p *jsonldProcessor) loadContext(iri string) (interface{}, string, error) {
+	remote, ok := p.loaded[iri]
+	if !ok {
+		if err := p.ctx.Err(); err != nil {
+			return nil, "", err
+		}
+		var err error
+		remote, err = p.loader.LoadDocument(p.ctx, iri)
+		if err != nil {
+			return nil, "", jsonldError("loading remote context failed", "%s: %v", iri, err)
+		}
+		if remote.DocumentURL == "" {
+			remote.DocumentURL = iri
+		}
+		p.loaded[iri] = remote
+	}
+	doc, ok := remote.Document.(map[string]interface{})
+	if !ok {
+		return nil, "", jsonldError("invalid remote context", "%s is not a JSON object", iri)
+	}
+	local, ok := doc["@context"]
+	if !ok {
+		return nil, "", jsonldError("invalid remote context", "%s has no @context entry", iri)
+	}
+	return local, remote.DocumentURL, nil
+}
+
+// processContext implements the Context Processing algorithm.
+func (p *jsonldProcessor) processContext(active *jsonldActiveContext, local interface{}, baseURL string, remote []string, overrideProtected, propagate, validateScoped bool) (*jsonldActiveContext, error) {
+	result := active.clone()
+	if m, ok := local.(map[string]interface{}); ok {
+		if value, ok := m["@propagate"]; ok {
+			b, ok := value.(bool)
+			if !ok {
+				return nil, jsonldError("invalid @propagate value", "%v", value)
+			}
+			propagate = b
+		}
+	}
+	if !propagate && result.previous == nil {
+		result.previous = active
+	}
+	for _, item := range jsonldArray(local) {
+		switch ctx := item.(type) {
+		case nil:
+			if !overrideProtected {
+				for term, def := range result.terms {
+					if def.protected {
+						return nil, jsonldError("invalid context nullification", "term %q is protected", term)
+					}
+				}
+			}
+			previous := result.previous
+			result = newJSONLDActiveContext(active.originalBase, p.mode)
+			if !propagate {
+				result.previous = previous
+			}
+			continue
+		case string:
+			iri := ResolveIRI(baseURL, ctx)
+			if jsonldContains(remote, iri) {
+				if !validateScoped {
+					continue
+				}
+				return nil, jsonldError("recursive context inclusion", "%s", iri)
+			}
+			if len(remote) >= jsonldMaxRemoteContexts {
+				return nil, jsonldError("context overflow", "%s", iri)
+			}
+			loaded, documentURL, err := p.loadContext(iri)
+			if err != nil {
+				return nil, err
+			}
+			chain := append(append([]string(nil), remote...), iri)
+			result, err = p.processContext(result, loaded, documentURL, chain, overrideProtected, true, validateScoped)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case map[string]interface{}:
+			var err error
+			if result, err = p.processContextDefinition(result, ctx, baseURL, remote, overrideProtected); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, jsonldError("invalid local context", "%v", item)
+		}
+	}
+	return result, nil
+}
+
+// processContextDefinition applies one context definition (a JSON object)
+// to result.
+func (p *jsonldProcessor) processContextDefinition(result *jsonldActiveContext, ctx map[string]interface{}, baseURL string, remote []string, overrideProtected bool) (*jsonldActiveContext, error) {
+	if value, ok := ctx["@version"]; ok {
+		if n, ok := jsonldNumber(value); !ok || n != 1.1 {
+			return nil, jsonldError("invalid @version value", "%v", value)
+		}
+		if p.mode == jsonldMode10 {
+			return nil, jsonldError("processing mode conflict", "@version 1.1 in json-ld-1.0 mode")
+		}
+	}
+	if value, ok := ctx["@import"]; ok {
+		if p.mode == jsonldMode10 {
+			return nil, jsonldError("invalid context entry", "@import")
+		}
+		ref, ok := value.(string)
+		if !ok {
+			return nil, jsonldError("invalid @import value", "%v", value)
+		}
+		imported, _, err := p.loadContext(ResolveIRI(baseURL, ref))
+		if err != nil {
+			return nil, err
+		}
+		importMap, ok := imported.(map[string]interface{})
+		if !ok {
+			return nil, jsonldError("invalid remote context", "imported context %s is not an object", ref)
+		}
+		if _, ok := importMap["@import"]; ok {
+			return nil, jsonldError("invalid context entry", "imported context %s contains @import", ref)
+		}
+		merged := make(map[string]interface{}, len(importMap)+len(ctx))
+		for key, v := range importMap {
+			merged[key] = v
+		}
+		for key, v := range ctx {
+			merged[key] = v
+		}
+		ctx = merged
+	}
+	if value, ok := ctx["@base"]; ok && len(remote) == 0 {
+		switch base := value.(type) {
+		case nil:
+			result.base, result.hasBase = "", false
+		case string:
+			switch {
+			case hasIRIScheme(base):
+				result.base, result.hasBase = base, true
+			case result.hasBase && result.base != "":
+				result.base = ResolveIRI(result.base, base)
+			default:
+				return nil, jsonldError("invalid base IRI", "cannot resolve %q without a base", base)
+			}
+		default:
+			return nil, jsonldError("invalid base IRI", "%v", value)
+		}
+	}
+	if value, ok := ctx["@vocab"]; ok {
+		switch vocab := value.(type) {
+		case nil:
+			result.vocab, result.hasVocab = "", false
+		case string:
+			if p.mode == jsonldMode10 && !jsonldIsAbsoluteIRI(vocab) && !strings.HasPrefix(vocab, "_:") {
+				return nil, jsonldError("invalid vocab mapping", "%q", vocab)
+			}
+			expanded, err := p.expandIRI(result, vocab, true, true, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			if expanded == nil || (!jsonldIsAbsoluteIRI(*expanded) && !strings.HasPrefix(*expanded, "_:")) {
+				return nil, jsonldError("invalid vocab mapping", "%q", vocab)
+			}
+			result.vocab, result.hasVocab = *expanded, true
+		default:
+			return nil, jsonldError("invalid vocab mapping", "%v", value)
+		}
+	}
+	if value, ok := ctx["@language"]; ok {
+		switch lang := value.(type) {
+		case nil:
+			result.language = ""
+		case string:
+			result.language = strings.ToLower(lang)
+		default:
+			return nil, jsonldError("invalid default language", "%v", value)
+		}
+	}
+	if value, ok := ctx["@direction"]; ok {
+		if p.mode == jsonldMode10 {
+			return nil, jsonldError("invalid context entry", "@direction")
+		}
+		switch value {
+		case nil:
+			result.direction = ""
+		case "ltr", "rtl":
+			result.direction = value.(string)
+		default:
+			return nil, jsonldError("invalid base direction", "%v", value)
+		}
+	}
+	if value, ok := ctx["@propagate"]; ok {
+		if p.mode == jsonldMode10 {
+			return nil, jsonldError("invalid context entry", "@propagate")
+		}
+		if _, ok := value.(bool); !ok {
+			return nil, jsonldError("invalid @propagate value", "%v", value)
+		}
+	}
+	if value, ok := ctx["@protected"]; ok {
+		if _, ok := value.(bool); !ok {
+			return nil, jsonldError("invalid @protected value", "%v", value)
+		}
+	}
+	saved := p.env
+	defer func() { p.env = saved }()
+	p.env = jsonldTermEnv{baseURL: baseURL, remote: remote, overrideProtected: overrideProtected}
+	defined := map[string]bool{}
+	for _, term := range sortedKeys(ctx) {
+		switch term {
+		case "@base", "@direction", "@import", "@language", "@propagate", "@protected", "@version", "@vocab":
+			continue
+		}
+		if err := p.createTermDefinition(result, ctx, term, defined); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// createTermDefinition implements the Create Term Definition algorithm for
+// a term of local, the context definition described by p.env.
+func (p *jsonldProcessor) createTermDefinition(active *jsonldActiveContext, local map[string]interface{}, term string, defined map[string]bool) error {
+	if done, ok := defined[term]; ok {
+		if done {
+			return nil
+		}
+		return jsonldError("cyclic IRI mapping", "%q", term)
+	}
+	if term == "" {
+		return jsonldError("invalid term definition", "empty term")
+	}
+	defined[term] = false
+	value := local[term]
+
+	if term == "@type" && p.mode != jsonldMode10 {
+		m, ok := value.(map[string]interface{})
+		if !ok || len(m) == 0 {
+			return jsonldError("keyword redefinition", "@type")
+		}
+		for key, v := range m {
+			switch {
+			case key == "@container" && v == "@set":
+			case key == "@protected":
+			default:
+				return jsonldError("keyword redefinition", "@type")
+			}
+		}
+	} else if jsonldIsKeyword(term) {
+		return jsonldError("keyword redefinition", "%s", term)
+	} else if jsonldHasKeywordForm(term) {
+		delete(defined, term)
+		return nil
+	}
+
+	previous := active.terms[term]
+	delete(active.terms, term)
+
+	simple := false
+	var def map[string]interface{}
+	switch v := value.(type) {
+	case nil:
+		def = map[string]interface{}{"@id": nil}
+	case string:
+		def = map[string]interface{}{"@id": v}
+		simple = true
+	case map[string]interface{}:
+		def = v
+	default:
+		return jsonldError("invalid term definition", "%q", term)
+	}
+
+	protected, _ := local["@protected"].(bool)
+	definition := &jsonldTermDefinition{protected: protected}
+	if v, ok := def["@protected"]; ok {
+		if p.mode == jsonldMode10 {
+			return jsonldError("invalid term definition", "@protected in json-ld-1.0 mode")
+		}
+		b, ok := v.(bool)
+		if !ok {
+			return jsonldError("invalid @protected value", "%v", v)
+		}
+		definition.protected = b
+	}
+
+	if v, ok := def["@type"]; ok {
+		typ, ok := v.(string)
+		if !ok {
+			return jsonldError("invalid type mapping", "%v", v)
+		}
+		expanded, err := p.expandIRI(active, typ, false, true, local, defined)
+		if err != nil {
+			return err
+		}
+		if expanded == nil {
+			return jsonldError("invalid type mapping", "%q", typ)
+		}
+		switch *expanded {
+		case "@json", "@none":
+			if p.mode == jsonldMode10 {
+				return jsonldError("invalid type mapping", "%q", typ)
+			}
+		case "@id", "@vocab":
+		default:
+			if !jsonldIsAbsoluteIRI(*expanded) {
+				return jsonldError("invalid type mapping", "%q", typ)
+			}
+		}
+		definition.typ = *expanded
+	}
+
+	if v, ok := def["@reverse"]; ok {
+		if _, ok := def["@id"]; ok {
+			return jsonldError("invalid reverse property", "%q has @id", term)
+		}
+		if _, ok := def["@nest"]; ok {
+			return jsonldError("invalid reverse property", "%q has @nest", term)
+		}
+		reverse, ok := v.(string)
+		if !ok {
+			return jsonldError("invalid IRI mapping", "@reverse %v", v)
+		}
+		if jsonldHasKeywordForm(reverse) {
+			delete(defined, term)
+			return nil
+		}
+		expanded, err := p.expandIRI(active, reverse, false, true, local, defined)
+		if err != nil {
+			return err
+		}
+		if expanded == nil || !strings.Contains(*expanded, ":") {
+			return jsonldError("invalid IRI mapping", "@reverse %q", reverse)
+		}
+		definition.id = *expanded
+		if c, ok := def["@container"]; ok {
+			switch c {
+			case nil:
+			case "@set", "@index":
+				definition.container = []string{c.(string)}
+			default:
+				return jsonldError("invalid reverse property", "container %v", c)
+			}
+		}
+		definition.reverse = true
+		for key := range def {
+			switch key {
+			case "@reverse", "@container", "@context", "@direction", "@index", "@language", "@protected", "@type":
+			default:
+				return jsonldError("invalid term definition", "%q has entry %s", term, key)
+			}
+		}
+		active.terms[term] = definition
+		defined[term] = true
+		return nil
+	}
+
+	if v, ok := def["@id"]; ok && v != term {
+		switch id := v.(type) {
+		case nil:
+		case string:
+			if !jsonldIsKeyword(id) && jsonldHasKeywordForm(id) {
+				delete(defined, term)
+				return nil
+			}
+			expanded, err := p.expandIRI(active, id, false, true, local, defined)
+			if err != nil {
+				return err
+			}
+			if expanded == nil || (!jsonldIsKeyword(*expanded) && !jsonldIsAbsoluteIRI(*expanded) && !strings.HasPrefix(*expanded, "_:")) {
+				return jsonldError("invalid IRI mapping", "%q", id)
+			}
+			if *expanded == "@context" {
+				return jsonldError("invalid keyword alias", "%q", term)
+			}
+			definition.id = *expanded
+			if p.mode != jsonldMode10 && (strings.Contains(strings.TrimSuffix(term[1:], ":"), ":") || strings.Contains(term, "/")) {
+				defined[term] = true
+				termIRI, err := p.expandIRI(active, term, false, true, local, defined)
+				if err != nil {
+					return err
+				}
+				if termIRI == nil || *termIRI != definition.id {
+					return jsonldError("invalid IRI mapping", "%q does not expand to %s", term, definition.id)
+				}
+			}
+			if !strings.ContainsAny(term, ":/") && simple &&
+				(strings.HasPrefix(definition.id, "_:") || strings.ContainsAny(definition.id[len(definition.id)-1:], ":/?#[]@")) {
+				definition.prefix = true
+			}
+		default:
+			return jsonldError("invalid IRI mapping", "%v", v)
+		}
+	} else if i := strings.Index(term, ":"); i > 0 {
+		prefix, suffix := term[:i], term[i+1:]
+		if prefix != "_" && !strings.HasPrefix(suffix, "//") {
+			if _, ok := local[prefix]; ok {
+				if err := p.createTermDefinition(active, local, prefix, defined); err != nil {
+					return err
+				}
+			}
+		}
+		if prefixDef, ok := active.terms[prefix]; ok && prefixDef.id != "" && prefix != "_" {
+			definition.id = prefixDef.id + suffix
+		} else {
+			definition.id = term
+		}
+	} else if strings.Contains(term, "/") {
+		expanded, err := p.expandIRI(active, term, false, true, nil, nil)
+		if err != nil {
+			return err
+		}
+		if expanded == nil || !jsonldIsAbsoluteIRI(*expanded) {
+			return jsonldError("invalid IRI mapping", "%q", term)
+		}
+		definition.id = *expanded
+	} else if term == "@type" {
+		definition.id = "@type"
+	} else if active.hasVocab {
+		definition.id = active.vocab + term
+	} else {
+		return jsonldError("invalid IRI mapping", "relative term %q without vocabulary mapping", term)
+	}
+
+	if v, ok := def["@container"]; ok {
+		container, err := p.containerMapping(v)
+		if err != nil {
+			return err
+		}
+		definition.container = container
+		if jsonldContains(container, "@type") {
+			switch definition.typ {
+			case "":
+				definition.typ = "@id"
+			case "@id", "@vocab":
+			default:
+				return jsonldError("invalid type mapping", "%q with @type container", definition.typ)
+			}
+		}
+	}
+
+	if v, ok := def["@index"]; ok {
+		if p.mode == jsonldMode10 || !jsonldContains(definition.container, "@index") {
+			return jsonldError("invalid term definition", "@index on %q", term)
+		}
+		index, ok := v.(string)
+		if !ok {
+			return jsonldError("invalid term definition", "@index %v", v)
+		}
+		expanded, err := p.expandIRI(active, index, false, true, local, defined)
+		if err != nil {
+			return err
+		}
+		if expanded == nil || !jsonldIsAbsoluteIRI(*expanded) {
+			return jsonldError("invalid term definition", "@index %q", index)
+		}
+		definition.index = index
+	}
+
+	if v, ok := def["@context"]; ok {
+		if p.mode == jsonldMode10 {
+			return jsonldError("invalid term definition", "@context on %q in json-ld-1.0 mode", term)
+		}
+		if _, err := p.processContext(active, v, p.env.baseURL, append([]string(nil), p.env.remote...), true, true, false); err != nil {
+			if jerr, ok := err.(*JSONLDError); ok && jerr.Code == "loading remote context failed" {
+				return err
+			}
+			return jsonldError("invalid scoped context", "%q: %v", term, err)
+		}
+		definition.context, definition.hasContext = v, true
+		definition.baseURL = p.env.baseURL
+	}
+
+	if _, hasType := def["@type"]; !hasType {
+		if v, ok := def["@language"]; ok {
+			switch lang := v.(type) {
+			case nil:
+				definition.language, definition.hasLanguage = "", true
+			case string:
+				definition.language, definition.hasLanguage = strings.ToLower(lang), true
+			default:
+				return jsonldError("invalid language mapping", "%v", v)
+			}
+		}
+		if v, ok := def["@direction"]; ok {
+			switch v {
+			case nil:
+				definition.direction, definition.hasDirection = "", true
+			case "ltr", "rtl":
+				definition.direction, definition.hasDirection = v.(string), true
+			default:
+				return jsonldError("invalid base direction", "%v", v)
+			}
+		}
+	}
+
+	if v, ok := def["@nest"]; ok {
+		if p.mode == jsonldMode10 {
+			return jsonldError("invalid term definition", "@nest in json-ld-1.0 mode")
+		}
+		nest, ok := v.(string)
+		if !ok || (jsonldIsKeyword(nest) && nest != "@nest") {
+			return jsonldError("invalid @nest value", "%v", v)
+		}
+		definition.nest = nest
+	}
+
+	if v, ok := def["@prefix"]; ok {
+		if p.mode == jsonldMode10 || strings.ContainsAny(term, ":/") {
+			return jsonldError("invalid term definition", "@prefix on %q", term)
+		}
+		prefix, ok := v.(bool)
+		if !ok {
+			return jsonldError("invalid @prefix value", "%v", v)
+		}
+		if prefix && jsonldIsKeyword(definition.id) {
+			return jsonldError("invalid term definition", "keyword alias %q used as prefix", term)
+		}
+		definition.prefix = prefix
+	}
+
+	for key := range def {
+		switch key {
+		case "@id", "@reverse", "@container", "@context", "@direction", "@index", "@language", "@nest", "@prefix", "@protected", "@type":
+		default:
+			return jsonldError("invalid term definition", "%q has entry %s", term, key)
+		}
+	}
+
+	if !p.env.overrideProtected && previous != nil && previous.protected {
+		if !definition.equal(previous) {
+			return jsonldError("protected term redefinition", "%q", term)
+		}
+		definition = previous
+	}
+	active.terms[term] = definition
+	defined[term] = true
+	return nil
+}
+
+// containerMapping validates an @container value and returns it as a
+// sorted array.
+func (p *jsonldProcessor) containerMapping(value interface{}) ([]string, error) {
+	var container []string
+	switch v := value.(type) {
+	case string:
+		container = []string{v}
+	case []interface{}:
+		if p.mode == jsonldMode10 {
+			return nil, jsonldError("invalid container mapping", "%v", value)
+		}
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, jsonldError("invalid container mapping", "%v", value)
+			}
+			container = append(container, s)
+		}
+	default:
+		return nil, jsonldError("invalid container mapping", "%v", value)
+	}
+	has := map[string]bool{}
+	for _, c := range container {
+		switch c {
+		case "@graph", "@id", "@index", "@language", "@list", "@set", "@type":
+			has[c] = true
+		default:
+			return nil, jsonldError("invalid container mapping", "%v", value)
+		}
+	}
+	if p.mode == jsonldMode10 && (has["@graph"] || has["@id"] || has["@type"]) {
+		return nil, jsonldError("invalid container mapping", "%v in json-ld-1.0 mode", value)
+	}
+	valid := len(has) == 1
+	switch {
+	case valid:
+	case has["@list"]:
+	case has["@graph"]:
+		valid = !(has["@id"] && has["@index"]) && !has["@type"] && !has["@language"]
+	default:
+		valid = has["@set"]
+	}
+	if !valid {
+		return nil, jsonldError("invalid container mapping", "%v", value)
+	}
+	container = container[:0]
+	for c := range has {
+		container = append(container, c)
+	}
+	sort.Strings(container)
+	return container, nil
+}
+
+// expandIRI implements the IRI Expansion algorithm. It returns nil for
+// values that expand to null. local and defined are set while a context
+// is being processed.
+func (p *jsonldProcessor) expandIRI(active *jsonldActiveContext, value string, documentRelative, vocab bool, local map[string]interface{}, defined map[string]bool) (*string, error) {
+	if jsonldIsKeyword(value) {
+		return &value, nil
+	}
+	if jsonldHasKeywordForm(value) {
+		return nil, nil
+	}
+	if local != nil {
+		if _, ok := local[value]; ok && !defined[value] {
+			if err := p.createTermDefinition(active, local, value, defined); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if def, ok := active.terms[value]; ok {
+		if jsonldIsKeyword(def.id) {
+			return &def.id, nil
+		}
+		if vocab {
+			if def.id == "" {
+				return nil, nil
+			}
+			return &def.id, nil
+		}
+	}
+	if i := strings.Index(value, ":"); i > 0 {
+		prefix, suffix := value[:i], value[i+1:]
+		if prefix == "_" || strings.HasPrefix(suffix, "//") {
+			return &value, nil
+		}
+		if local != nil {
+			if _, ok := local[prefix]; ok && !defined[prefix] {
+				if err := p.createTermDefinition(active, local, prefix, defined); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if def, ok := active.terms[prefix]; ok && def.id != "" && (def.prefix || p.mode == jsonldMode10) {
+			iri := def.id + suffix
+			return &iri, nil
+		}
+		if hasIRIScheme(value) {
+			return &value, nil
+		}
+	}
+	if vocab && active.hasVocab {
+		iri := active.vocab + value
+		return &iri, nil
+	}
+	if documentRelative && active.hasBase {
+		iri := ResolveIRI(active.base, value)
+		return &iri, nil
+	}
+	return &value, nil
+}
+
+// jsonldKeywords lists the keywords of JSON-LD 1.1.
+var jsonldKeywords = map[string]bool{
+	"@base": true, "@container": true, "@context": true, "@default": true,
+	"@direction": true, "@embed": true, "@explicit": true, "@graph": true,
+	"@id": true, "@import": true, "@included": true, "@index": true,
+	"@json": true, "@language": true, "@list": true, "@nest": true,
+	"@none": true, "@omitDefault": true, "@prefix": true, "@preserve": true,
+	"@propagate": true, "@protected": true, "@requireAll": true,
+	"@reverse": true, "@set": true, "@type": true, "@value": true,
+	"@version": true, "@vocab": true,
+}
+
+func jsonldIsKeyword(s string) bool {
+	return jsonldKeywords[s]
+}
+
+// jsonldHasKeywordForm reports whether s matches "@"1*ALPHA, the form
+// reserved for future keywords.
+func jsonldHasKeywordForm(s string) bool {
+	if len(s) < 2 || s[0] != '@' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isASCIILetter(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonldIsAbsoluteIRI reports whether s has a scheme and no whitespace.
+func jsonldIsAbsoluteIRI(s string) bool {
+	return hasIRIScheme(s) && !strings.ContainsAny(s, " \t\n\r")
+}
+
+func jsonldArray(value interface{}) []interface{} {
+	if arr, ok := value.([]interface{}); ok {
+		return arr
+	}
+	return []interface{}{value}
+}
+
+func jsonldNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func jsonldContains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,766 @@
+package rdf
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// expandDocument expands a JSON-LD document as the expand() method of the
+// JSON-LD 1.1 API does, returning an array of node objects.
+func (p *jsonldProcessor) expandDocument(input interface{}) ([]interface{}, error) {
+	base := p.opts.BaseIRI
+	if p.opts.Base != "" {
+		base = p.opts.Base
+	}
+	active := newJSONLDActiveContext(base, p.mode)
+	if p.opts.ExpandContext != nil {
+		local := p.opts.ExpandContext
+		if m, ok := local.(map[string]interface{}); ok {
+			if inner, ok := m["@context"]; ok {
+				local = inner
+			}
+		}
+		var err error
+		if active, err = p.processContext(active, local, base, nil, false, true, true); err != nil {
+			return nil, err
+		}
+	}
+	expanded, err := p.expand(active, "", input, base, false)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := expanded.(map[string]interface{}); ok && len(m) == 1 {
+		if graph, ok := m["@graph"]; ok {
+			expanded = graph
+		}
+	}
+	if expanded == nil {
+		return []interface{}{}, nil
+	}
+	return jsonldArray(expanded), nil
+}
+
+// expand implements the Expansion algorithm. An empty activeProperty is
+// the null active property.
+func (p *jsonldProcessor) expand(active *jsonldActiveContext, activeProperty string, element interface{}, baseURL string, fromMap bool) (interface{}, error) {
+	if element == nil {
+		return nil, nil
+	}
+	if err := p.ctx.Err(); err != nil {
+		return nil, err
+	}
+	propertyDef := active.terms[activeProperty]
+	switch value := element.(type) {
+	case []interface{}:
+		result := []interface{}{}
+		for _, item := range value {
+			expanded, err := p.expand(active, activeProperty, item, baseURL, fromMap)
+			if err != nil {
+				return nil, err
+			}
+			if propertyDef.hasContainer("@list") {
+				arr, isArray := expanded.([]interface{})
+				if p.mode == jsonldMode10 && (isArray || jsonldIsListObject(expanded)) {
+					return nil, jsonldError("list of lists", "%q", activeProperty)
+				}
+				if isArray {
+					expanded = map[string]interface{}{"@list": arr}
+				}
+			}
+			switch e := expanded.(type) {
+			case nil:
+			case []interface{}:
+				result = append(result, e...)
+			default:
+				result = append(result, e)
+			}
+		}
+		return result, nil
+	case map[string]interface{}:
+		return p.expandObject(active, activeProperty, value, baseURL, fromMap)
+	default:
+		if activeProperty == "" || activeProperty == "@graph" {
+			return nil, nil
+		}
+		if propertyDef != nil && propertyDef.hasContext {
+			var err error
+			if active, err = p.processContext(active, propertyDef.context, propertyDef.baseURL, nil, true, true, true); err != nil {
+				return nil, err
+			}
+		}
+		return p.expandValue(active, activeProperty, element)
+	}
+}
+
+// expandObject expands a JSON object (steps 7 to 20 of the Expansion
+// algorithm).
+func (p *jsonldProcessor) expandObject(active *jsonldActiveContext, activeProperty string, element map[string]interface{}, baseURL string, fromMap bool) (interface{}, error) {
+	propertyDef := active.terms[activeProperty]
+	if active.previous != nil && !fromMap && !p.keepsContext(active, element) {
+		active = active.previous
+	}
+	var err error
+	if propertyDef != nil && propertyDef.hasContext {
+		if active, err = p.processContext(active, propertyDef.context, propertyDef.baseURL, nil, true, true, true); err != nil {
+			return nil, err
+		}
+	}
+	if local, ok := element["@context"]; ok {
+		if active, err = p.processContext(active, local, baseURL, nil, false, true, true); err != nil {
+			return nil, err
+		}
+	}
+
+	typeScoped := active
+	var inputType string
+	foundType := false
+	for _, key := range sortedKeys(element) {
+		expanded, err := p.expandIRI(active, key, false, true, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if expanded == nil || *expanded != "@type" {
+			continue
+		}
+		types := []string{}
+		for _, item := range jsonldArray(element[key]) {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		sorted := append([]string(nil), types...)
+		sort.Strings(sorted)
+		for _, term := range sorted {
+			if def := typeScoped.terms[term]; def != nil && def.hasContext {
+				if active, err = p.processContext(active, def.context, def.baseURL, nil, false, false, true); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if !foundType && len(types) > 0 {
+			last, err := p.expandIRI(typeScoped, types[len(types)-1], false, true, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			if last != nil {
+				inputType = *last
+			}
+		}
+		foundType = true
+	}
+
+	result := map[string]interface{}{}
+	if err := p.expandEntries(active, typeScoped, activeProperty, element, result, inputType, baseURL); err != nil {
+		return nil, err
+	}
+
+	if value, ok := result["@value"]; ok {
+		for key := range result {
+			switch key {
+			case "@direction", "@index", "@language", "@type", "@value":
+			default:
+				return nil, jsonldError("invalid value object", "unexpected entry %s", key)
+			}
+		}
+		_, hasLanguage := result["@language"]
+		_, hasDirection := result["@direction"]
+		typ, hasType := result["@type"]
+		if hasType && (hasLanguage || hasDirection) {
+			return nil, jsonldError("invalid value object", "@type with @language or @direction")
+		}
+		switch {
+		case typ == "@json":
+		case value == nil:
+			return nil, nil
+		case jsonldIsEmptyArray(value):
+			return nil, nil
+		default:
+			if _, isString := value.(string); !isString && hasLanguage {
+				return nil, jsonldError("invalid language-tagged value", "%v", value)
+			}
+			if !jsonldIsScalar(value) {
+				return nil, jsonldError("invalid value object value", "%v", value)
+			}
+			if hasType {
+				s, ok := typ.(string)
+				if !ok || !jsonldIsAbsoluteIRI(s) || strings.HasPrefix(s, "_:") {
+					return nil, jsonldError("invalid typed value", "%v", typ)
+				}
+			}
+		}
+	} else if typ, ok := result["@type"]; ok {
+		if _, isArray := typ.([]interface{}); !isArray {
+			result["@type"] = []interface{}{typ}
+		}
+	} else if _, hasSet := result["@set"]; hasSet || result["@list"] != nil {
+		if len(result) > 2 || (len(result) == 2 && result["@index"] == nil) {
+			return nil, jsonldError("invalid set or list object", "unexpected entries")
+		}
+		if set, ok := result["@set"]; ok {
+			return set, nil
+		}
+	}
+	if _, ok := result["@language"]; ok && len(result) == 1 {
+		return nil, nil
+	}
+	if activeProperty == "" || activeProperty == "@graph" {
+		_, hasValue := result["@value"]
+		_, hasList := result["@list"]
+		_, hasID := result["@id"]
+		if len(result) == 0 || hasValue || hasList || (len(result) == 1 && hasID) {
+			return nil, nil
+		}
+	}
+	return result, nil
+}
+
+// keepsContext reports whether element keeps a non-propagated context: a
+// value object or an object with only @id.
+func (p *jsonldProcessor) keepsContext(active *jsonldActiveContext, element map[string]interface{}) bool {
+	for key := range element {
+		expanded, err := p.expandIRI(active, key, false, true, nil, nil)
+		if err == nil && expanded != nil && *expanded == "@value" {
+			return true
+		}
+	}
+	if len(element) == 1 {
+		for key := range element {
+			expanded, err := p.expandIRI(active, key, false, true, nil, nil)
+			return err == nil && expanded != nil && *expanded == "@id"
+		}
+	}
+	return false
+}
+
+// expandEntries expands the entries of element into result (steps 13 and
+// 14 of the Expansion algorithm), including those of nested objects.
+func (p *jsonldProcessor) expandEntries(active, typeScoped *jsonldActiveContext, activeProperty string, element, result map[string]interface{}, inputType, baseURL string) error {
+	var nests []string
+	for _, key := range sortedKeys(element) {
+		value := element[key]
+		if key == "@context" {
+			continue
+		}
+		expandedProperty, err := p.expandIRI(active, key, false, true, nil, nil)
+		if err != nil {
+			return err
+		}
+		if expandedProperty == nil || (!strings.Contains(*expandedProperty, ":") && !jsonldIsKeyword(*expandedProperty)) {
+			continue
+		}
+		property := *expandedProperty
+		if jsonldIsKeyword(property) {
+			if activeProperty == "@reverse" {
+				return jsonldError("invalid reverse property map", "keyword %s", property)
+			}
+			if _, ok := result[property]; ok && (p.mode == jsonldMode10 || (property != "@included" && property != "@type")) {
+				return jsonldError("colliding keywords", "%s", property)
+			}
+			nest, err := p.expandKeyword(active, typeScoped, activeProperty, property, value, result, inputType, baseURL)
+			if err != nil {
+				return err
+			}
+			if nest {
+				nests = append(nests, key)
+			}
+			continue
+		}
+
+		def := active.terms[key]
+		var expanded interface{}
+		switch {
+		case def != nil && def.typ == "@json":
+			expanded = map[string]interface{}{"@value": value, "@type": "@json"}
+		case def.hasContainer("@language") && jsonldIsMap(value):
+			expanded, err = p.expandLanguageMap(active, def, value.(map[string]interface{}))
+		case (def.hasContainer("@index") || def.hasContainer("@type") || def.hasContainer("@id")) && jsonldIsMap(value):
+			expanded, err = p.expandIndexMap(active, typeScoped, key, def, value.(map[string]interface{}), baseURL)
+		default:
+			expanded, err = p.expand(active, key, value, baseURL, false)
+		}
+		if err != nil {
+			return err
+		}
+		if expanded == nil {
+			continue
+		}
+		if def.hasContainer("@list") && !jsonldIsListObject(expanded) {
+			expanded = map[string]interface{}{"@list": jsonldArray(expanded)}
+		}
+		if def.hasContainer("@graph") && !def.hasContainer("@id") && !def.hasContainer("@index") {
+			var graphs []interface{}
+			for _, item := range jsonldArray(expanded) {
+				graphs = append(graphs, map[string]interface{}{"@graph": jsonldArray(item)})
+			}
+			expanded = graphs
+		}
+		if def != nil && def.reverse {
+			reverseMap, _ := result["@reverse"].(map[string]interface{})
+			if reverseMap == nil {
+				reverseMap = map[string]interface{}{}
+				result["@reverse"] = reverseMap
+			}
+			for _, item := range jsonldArray(expanded) {
+				if jsonldIsValueObject(item) || jsonldIsListObject(item) {
+					return jsonldError("invalid reverse property value", "%q", key)
+				}
+				jsonldAddValue(reverseMap, property, item, true)
+			}
+			continue
+		}
+		jsonldAddValue(result, property, expanded, true)
+	}
+
+	for _, key := range nests {
+		nestActive := active
+		if def := active.terms[key]; def != nil && def.hasContext {
+			var err error
+			if nestActive, err = p.processContext(active, def.context, def.baseURL, nil, true, true, true); err != nil {
+				return err
+			}
+		}
+		for _, nested := range jsonldArray(element[key]) {
+			obj, ok := nested.(map[string]interface{})
+			if !ok {
+				return jsonldError("invalid @nest value", "%v", nested)
+			}
+			for nestedKey := range obj {
+				expanded, err := p.expandIRI(nestActive, nestedKey, false, true, nil, nil)
+				if err != nil {
+					return err
+				}
+				if expanded != nil && *expanded == "@value" {
+					return jsonldError("invalid @nest value", "nested value object")
+				}
+			}
+			if err := p.expandEntries(nestActive, typeScoped, activeProperty, obj, result, inputType, baseURL); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandKeyword expands a keyword entry into result (step 13.4 of the
+// Expansion algorithm). It reports whether the entry is an @nest entry.
+func (p *jsonldProcessor) expandKeyword(active, typeScoped *jsonldActiveContext, activeProperty, property string, value interface{}, result map[string]interface{}, inputType, baseURL string) (bool, error) {
+	var expanded interface{}
+	switch property {
+	case "@id":
+		id, ok := value.(string)
+		if !ok {
+			return false, jsonldError("invalid @id value", "%v", value)
+		}
+		iri, err := p.expandIRI(active, id, true, false, nil, nil)
+		if err != nil {
+			return false, err
+		}
+		if iri == nil {
+			expanded = nil
+		} else {
+			expanded = *iri
+		}
+	case "@type":
+		var types []interface{}
+		switch v := value.(type) {
+		case string:
+			types = []interface{}{v}
+		case []interface{}:
+			types = v
+		default:
+			return false, jsonldError("invalid type value", "%v", value)
+		}
+		out := make([]interface{}, 0, len(types))
+		for _, item := range types {
+			s, ok := item.(string)
+			if !ok {
+				return false, jsonldError("invalid type value", "%v", value)
+			}
+			iri, err := p.expandIRI(typeScoped, s, true, true, nil, nil)
+			if err != nil {
+				return false, err
+			}
+			if iri != nil {
+				out = append(out, *iri)
+			}
+		}
+		if existing, ok := result["@type"]; ok {
+			out = append(jsonldArray(existing), out...)
+			expanded = out
+		} else if _, isString := value.(string); isString && len(out) == 1 {
+			expanded = out[0]
+		} else {
+			expanded = out
+		}
+	case "@graph":
+		graph, err := p.expand(active, "@graph", value, baseURL, false)
+		if err != nil {
+			return false, err
+		}
+		expanded = jsonldArrayOrEmpty(graph)
+	case "@included":
+		if p.mode == jsonldMode10 {
+			return false, nil
+		}
+		// Scalars must survive expansion to be reported, so a null active
+		// property is replaced by @included.
+		property := activeProperty
+		if property == "" {
+			property = "@included"
+		}
+		included, err := p.expand(active, property, value, baseURL, false)
+		if err != nil {
+			return false, err
+		}
+		items := jsonldArrayOrEmpty(included)
+		for _, item := range items {
+			if !jsonldIsNodeObject(item) {
+				return false, jsonldError("invalid @included value", "%v", item)
+			}
+		}
+		if existing, ok := result["@included"]; ok {
+			items = append(jsonldArray(existing), items...)
+		}
+		expanded = items
+	case "@value":
+		switch value.(type) {
+		case nil:
+			result["@value"] = nil
+			return false, nil
+		case map[string]interface{}, []interface{}:
+			if inputType != "@json" {
+				return false, jsonldError("invalid value object value", "%v", value)
+			}
+		}
+		if inputType == "@json" && p.mode == jsonldMode10 {
+			return false, jsonldError("invalid value object value", "%v", value)
+		}
+		expanded = value
+	case "@language":
+		lang, ok := value.(string)
+		if !ok {
+			return false, jsonldError("invalid language-tagged string", "%v", value)
+		}
+		expanded = strings.ToLower(lang)
+	case "@direction":
+		if p.mode == jsonldMode10 {
+			return false, nil
+		}
+		if value != "ltr" && value != "rtl" {
+			return false, jsonldError("invalid base direction", "%v", value)
+		}
+		expanded = value
+	case "@index":
+		if _, ok := value.(string); !ok {
+			return false, jsonldError("invalid @index value", "%v", value)
+		}
+		expanded = value
+	case "@list":
+		if activeProperty == "" || activeProperty == "@graph" {
+			return false, nil
+		}
+		list, err := p.expand(active, activeProperty, value, baseURL, false)
+		if err != nil {
+			return false, err
+		}
+		items := jsonldArrayOrEmpty(list)
+		if p.mode == jsonldMode10 {
+			for _, item := range items {
+				if jsonldIsListObject(item) {
+					return false, jsonldError("list of lists", "%q", activeProperty)
+				}
+			}
+		}
+		expanded = items
+	case "@set":
+		set, err := p.expand(active, activeProperty, value, baseURL, false)
+		if err != nil {
+			return false, err
+		}
+		expanded = set
+	case "@reverse":
+		if !jsonldIsMap(value) {
+			return false, jsonldError("invalid @reverse value", "%v", value)
+		}
+		reverse, err := p.expand(active, "@reverse", value, baseURL, false)
+		if err != nil {
+			return false, err
+		}
+		reverseObj, _ := reverse.(map[string]interface{})
+		if inner, ok := reverseObj["@reverse"].(map[string]interface{}); ok {
+			for prop, items := range inner {
+				for _, item := range jsonldArray(items) {
+					jsonldAddValue(result, prop, item, true)
+				}
+			}
+		}
+		for prop, items := range reverseObj {
+			if prop == "@reverse" {
+				continue
+			}
+			reverseMap, _ := result["@reverse"].(map[string]interface{})
+			if reverseMap == nil {
+				reverseMap = map[string]interface{}{}
+				result["@reverse"] = reverseMap
+			}
+			for _, item := range jsonldArray(items) {
+				if jsonldIsValueObject(item) || jsonldIsListObject(item) {
+					return false, jsonldError("invalid reverse property value", "%q", prop)
+				}
+				jsonldAddValue(reverseMap, prop, item, true)
+			}
+		}
+		return false, nil
+	case "@nest":
+		return true, nil
+	default:
+		// Framing keywords and keywords only valid in contexts are ignored.
+		return false, nil
+	}
+	result[property] = expanded
+	return false, nil
+}
+
+// expandLanguageMap expands the value of a term with an @language
+// container.
+func (p *jsonldProcessor) expandLanguageMap(active *jsonldActiveContext, def *jsonldTermDefinition, value map[string]interface{}) (interface{}, error) {
+	direction := active.direction
+	if def.hasDirection {
+		direction = def.direction
+	}
+	result := []interface{}{}
+	for _, language := range sortedKeys(value) {
+		expandedLanguage, err := p.expandIRI(active, language, false, true, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range jsonldArray(value[language]) {
+			if item == nil {
+				continue
+			}
+			s, ok := item.(string)
+			if !ok {
+				return nil, jsonldError("invalid language map value", "%v", item)
+			}
+			v := map[string]interface{}{"@value": s}
+			if expandedLanguage == nil || *expandedLanguage != "@none" {
+				v["@language"] = strings.ToLower(language)
+			}
+			if direction != "" {
+				v["@direction"] = direction
+			}
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// expandIndexMap expands the value of a term with an @index, @id or @type
+// container.
+func (p *jsonldProcessor) expandIndexMap(active, typeScoped *jsonldActiveContext, key string, def *jsonldTermDefinition, value map[string]interface{}, baseURL string) (interface{}, error) {
+	indexKey := def.index
+	if indexKey == "" {
+		indexKey = "@index"
+	}
+	result := []interface{}{}
+	for _, index := range sortedKeys(value) {
+		mapContext := active
+		if def.hasContainer("@id") || def.hasContainer("@type") {
+			if active.previous != nil {
+				mapContext = active.previous
+			}
+		}
+		if indexDef := typeScoped.terms[index]; def.hasContainer("@type") && indexDef != nil && indexDef.hasContext {
+			var err error
+			if mapContext, err = p.processContext(mapContext, indexDef.context, indexDef.baseURL, nil, false, true, true); err != nil {
+				return nil, err
+			}
+		} else if !def.hasContainer("@id") && !def.hasContainer("@type") {
+			mapContext = active
+		}
+		expandedIndex, err := p.expandIRI(active, index, false, true, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		isNone := expandedIndex != nil && *expandedIndex == "@none"
+		items, err := p.expand(mapContext, key, jsonldArray(value[index]), baseURL, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range jsonldArray(items) {
+			if def.hasContainer("@graph") && !jsonldIsGraphObject(item) {
+				item = map[string]interface{}{"@graph": jsonldArray(item)}
+			}
+			obj, _ := item.(map[string]interface{})
+			switch {
+			case def.hasContainer("@index") && indexKey != "@index" && !isNone:
+				reExpanded, err := p.expandValue(active, indexKey, index)
+				if err != nil {
+					return nil, err
+				}
+				indexProperty, err := p.expandIRI(active, indexKey, false, true, nil, nil)
+				if err != nil {
+					return nil, err
+				}
+				if obj == nil || indexProperty == nil {
+					continue
+				}
+				if jsonldIsValueObject(obj) {
+					return nil, jsonldError("invalid value object", "property-valued index on a value object")
+				}
+				values := append([]interface{}{reExpanded}, jsonldArrayOrEmpty(obj[*indexProperty])...)
+				obj[*indexProperty] = values
+			case def.hasContainer("@index") && obj != nil && obj["@index"] == nil && !isNone:
+				obj["@index"] = index
+			case def.hasContainer("@id") && obj != nil && obj["@id"] == nil && !isNone:
+				id, err := p.expandIRI(active, index, true, false, nil, nil)
+				if err != nil {
+					return nil, err
+				}
+				if id != nil {
+					obj["@id"] = *id
+				}
+			case def.hasContainer("@type") && obj != nil && !isNone && expandedIndex != nil:
+				obj["@type"] = append([]interface{}{*expandedIndex}, jsonldArrayOrEmpty(obj["@type"])...)
+			}
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// expandValue implements the Value Expansion algorithm.
+func (p *jsonldProcessor) expandValue(active *jsonldActiveContext, activeProperty string, value interface{}) (interface{}, error) {
+	def := active.terms[activeProperty]
+	if s, ok := value.(string); ok && def != nil && (def.typ == "@id" || def.typ == "@vocab") {
+		iri, err := p.expandIRI(active, s, true, def.typ == "@vocab", nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if iri == nil {
+			return map[string]interface{}{"@id": nil}, nil
+		}
+		return map[string]interface{}{"@id": *iri}, nil
+	}
+	result := map[string]interface{}{"@value": value}
+	if def != nil && def.typ != "" && def.typ != "@id" && def.typ != "@vocab" && def.typ != "@none" {
+		result["@type"] = def.typ
+		return result, nil
+	}
+	if _, ok := value.(string); ok {
+		language, direction := active.language, active.direction
+		if def != nil && def.hasLanguage {
+			language = def.language
+		}
+		if def != nil && def.hasDirection {
+			direction = def.direction
+		}
+		if language != "" {
+			result["@language"] = language
+		}
+		if direction != "" {
+			result["@direction"] = direction
+		}
+	}
+	return result, nil
+}
+
+// jsonldAddValue appends value to the array of key in obj. Arrays are
+// flattened into it.
+func jsonldAddValue(obj map[string]interface{}, key string, value interface{}, asArray bool) {
+	if arr, ok := value.([]interface{}); ok {
+		if len(arr) == 0 && asArray && obj[key] == nil {
+			obj[key] = []interface{}{}
+		}
+		for _, item := range arr {
+			jsonldAddValue(obj, key, item, asArray)
+		}
+		return
+	}
+	existing, ok := obj[key]
+	if !ok {
+		if asArray {
+			obj[key] = []interface{}{value}
+		} else {
+			obj[key] = value
+		}
+		return
+	}
+	obj[key] = append(jsonldArray(existing), value)
+}
+
+func jsonldArrayOrEmpty(value interface{}) []interface{} {
+	if value == nil {
+		return []interface{}{}
+	}
+	return jsonldArray(value)
+}
+
+func jsonldIsMap(value interface{}) bool {
+	_, ok := value.(map[string]interface{})
+	return ok
+}
+
+func jsonldIsEmptyArray(value interface{}) bool {
+	arr, ok := value.([]interface{})
+	return ok && len(arr) == 0
+}
+
+func jsonldIsValueObject(value interface{}) bool {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, has := m["@value"]
+	return has
+}
+
+func jsonldIsListObject(value interface{}) bool {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, has := m["@list"]
+	return has
+}
+
+func jsonldIsGraphObject(value interface{}) bool {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if _, has := m["@graph"]; !has {
+		return false
+	}
+	for key := range m {
+		switch key {
+		case "@graph", "@id", "@index", "@context":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func jsonldIsNodeObject(value interface{}) bool {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, key := range []string{"@value", "@list", "@set"} {
+		if _, has := m[key]; has {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonldIsScalar reports whether value is a JSON string, number or boolean.
+func jsonldIsScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, float64, json.Number:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,311 @@
+package rdf
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const rdfListIRI = "http://www.w3.org/1999/02/22-rdf-syntax-ns#List"
+
+var (
+	jsonldIntegerPattern = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	jsonldDoublePattern  = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+)
+
+// jsonldUsage records where a node is referenced: the node object holding
+// the reference, the property and the {"@id": ...} value itself.
+type jsonldUsage struct {
+	node     map[string]interface{}
+	property string
+	value    map[string]interface{}
+}
+
+// jsonldFromRDF implements the Serialize RDF as JSON-LD algorithm of the
+// JSON-LD 1.1 API and returns the dataset in expanded form.
+func (p *jsonldProcessor) jsonldFromRDF(quads []Quad) ([]interface{}, error) {
+	defaultGraph := map[string]map[string]interface{}{}
+	graphMap := map[string]map[string]map[string]interface{}{"@default": defaultGraph}
+	// referencedOnce maps a node to its only usage, or to nil once it is
+	// referenced more than once.
+	referencedOnce := map[string]*jsonldUsage{}
+	nilUsages := map[string][]*jsonldUsage{}
+	compoundLiterals := map[string]map[string]bool{}
+	seen := make(map[Quad]struct{}, len(quads))
+
+	for _, q := range quads {
+		if err := p.ctx.Err(); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[q]; ok {
+			continue
+		}
+		seen[q] = struct{}{}
+		name := "@default"
+		if q.G != nil {
+			id, err := jsonldSubjectID(q.G)
+			if err != nil {
+				return nil, err
+			}
+			name = id
+		}
+		graph := graphMap[name]
+		if graph == nil {
+			graph = map[string]map[string]interface{}{}
+			graphMap[name] = graph
+		}
+		if name != "@default" && defaultGraph[name] == nil {
+			defaultGraph[name] = map[string]interface{}{"@id": name}
+		}
+		subject, err := jsonldSubjectID(q.S)
+		if err != nil {
+			return nil, err
+		}
+		node := graph[subject]
+		if node == nil {
+			node = map[string]interface{}{"@id": subject}
+			graph[subject] = node
+		}
+		predicate := q.P.Value
+		if p.opts.RdfDirection == "compound-literal" && predicate == rdfDirectionIRI {
+			if compoundLiterals[name] == nil {
+				compoundLiterals[name] = map[string]bool{}
+			}
+			compoundLiterals[name][subject] = true
+		}
+		var object string
+		switch o := q.O.(type) {
+		case IRI:
+			object = o.Value
+		case BlankNode:
+			object = o.String()
+		}
+		if object != "" && graph[object] == nil {
+			graph[object] = map[string]interface{}{"@id": object}
+		}
+		if predicate == rdfTypeIRI && !p.opts.UseRdfType && object != "" {
+			jsonldAddUniqueValue(node, "@type", object)
+			continue
+		}
+		value, err := p.rdfToObject(q.O)
+		if err != nil {
+			return nil, err
+		}
+		jsonldAddUniqueValue(node, predicate, value)
+		_, referenced := referencedOnce[object]
+		switch {
+		case object == rdfNilIRI:
+			nilUsages[name] = append(nilUsages[name], &jsonldUsage{node: node, property: predicate, value: value})
+		case referenced:
+			referencedOnce[object] = nil
+		case strings.HasPrefix(object, "_:"):
+			referencedOnce[object] = &jsonldUsage{node: node, property: predicate, value: value}
+		}
+	}
+
+	for name, graph := range graphMap {
+		for cl := range compoundLiterals[name] {
+			if err := p.foldCompoundLiteral(graph, cl, referencedOnce[cl]); err != nil {
+				return nil, err
+			}
+		}
+		for _, usage := range nilUsages[name] {
+			p.foldList(graph, usage, referencedOnce)
+		}
+	}
+
+	result := []interface{}{}
+	for _, subject := range sortedNodeKeys(defaultGraph) {
+		node := defaultGraph[subject]
+		if graph, ok := graphMap[subject]; ok && subject != "@default" {
+			entries := []interface{}{}
+			for _, id := range sortedNodeKeys(graph) {
+				if n := graph[id]; len(n) > 1 {
+					entries = append(entries, n)
+				}
+			}
+			node["@graph"] = entries
+		}
+		if len(node) > 1 {
+			result = append(result, node)
+		}
+	}
+	return result, nil
+}
+
+// foldCompoundLiteral replaces references to the compound literal node cl
+// with a value object carrying its rdf:value, rdf:language and
+// rdf:direction.
+func (p *jsonldProcessor) foldCompoundLiteral(graph map[string]map[string]interface{}, cl string, usage *jsonldUsage) error {
+	if usage == nil {
+		return nil
+	}
+	clNode := graph[cl]
+	delete(graph, cl)
+	for _, item := range jsonldArray(usage.node[usage.property]) {
+		ref, ok := item.(map[string]interface{})
+		if !ok || ref["@id"] != cl {
+			continue
+		}
+		delete(ref, "@id")
+		ref["@value"] = jsonldFirstValue(clNode, rdfValueIRI)
+		if language, ok := jsonldFirstValue(clNode, rdfLanguageIRI).(string); ok {
+			if !jsonldWellFormedLanguage(language) {
+				return jsonldError("invalid language-tagged string", "%q", language)
+			}
+			ref["@language"] = language
+		}
+		if direction, ok := jsonldFirstValue(clNode, rdfDirectionIRI).(string); ok {
+			if direction != "ltr" && direction != "rtl" {
+				return jsonldError("invalid base direction", "%q", direction)
+			}
+			ref["@direction"] = direction
+		}
+	}
+	return nil
+}
+
+// foldList converts the rdf:first/rdf:rest chain ending in the rdf:nil
+// reference usage into a list object, removing the list nodes from graph.
+func (p *jsonldProcessor) foldList(graph map[string]map[string]interface{}, usage *jsonldUsage, referencedOnce map[string]*jsonldUsage) {
+	node, property, head := usage.node, usage.property, usage.value
+	var list []interface{}
+	var listNodes []string
+	for property == rdfRestIRI && jsonldIsListNode(node, referencedOnce) {
+		id := node["@id"].(string)
+		list = append(list, jsonldArray(node[rdfFirstIRI])[0])
+		listNodes = append(listNodes, id)
+		next := referencedOnce[id]
+		node, property, head = next.node, next.property, next.value
+	}
+	if property == rdfFirstIRI && p.mode == jsonldMode10 {
+		// JSON-LD 1.0 has no lists of lists: the head node of a nested
+		// list is kept and only the rest of the chain is converted.
+		if len(list) == 0 {
+			return
+		}
+		id, _ := head["@id"].(string)
+		head = jsonldArray(graph[id][rdfRestIRI])[0].(map[string]interface{})
+		list = list[:len(list)-1]
+		listNodes = listNodes[:len(listNodes)-1]
+	}
+	delete(head, "@id")
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	if list == nil {
+		list = []interface{}{}
+	}
+	head["@list"] = list
+	for _, id := range listNodes {
+		delete(graph, id)
+	}
+}
+
+// jsonldIsListNode reports whether node is a well-formed list node: a blank
+// node referenced once with exactly one rdf:first and one rdf:rest value
+// and no other properties than an rdf:List type.
+func jsonldIsListNode(node map[string]interface{}, referencedOnce map[string]*jsonldUsage) bool {
+	id, _ := node["@id"].(string)
+	if !strings.HasPrefix(id, "_:") || referencedOnce[id] == nil {
+		return false
+	}
+	for key, value := range node {
+		switch key {
+		case "@id":
+		case rdfFirstIRI, rdfRestIRI:
+			if len(jsonldArray(value)) != 1 {
+				return false
+			}
+		case "@type":
+			types := jsonldArray(value)
+			if len(types) != 1 || types[0] != rdfListIRI {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return node[rdfFirstIRI] != nil && node[rdfRestIRI] != nil
+}
+
+// rdfToObject implements the RDF to Object Conversion algorithm.
+func (p *jsonldProcessor) rdfToObject(term Term) (map[string]interface{}, error) {
+	var lit Literal
+	switch v := term.(type) {
+	case IRI:
+		return map[string]interface{}{"@id": v.Value}, nil
+	case BlankNode:
+		return map[string]interface{}{"@id": v.String()}, nil
+	case Literal:
+		lit = v
+	default:
+		return map[string]interface{}{"@value": term.String()}, nil
+	}
+	result := map[string]interface{}{}
+	var value interface{} = lit.Lexical
+	datatype := lit.Datatype.Value
+	if datatype == xsdNamespace+"string" {
+		datatype = ""
+	}
+	switch {
+	case lit.Lang != "":
+		result["@language"] = lit.Lang
+		if lit.Direction != "" {
+			result["@direction"] = lit.Direction
+		}
+		datatype = ""
+	case p.opts.UseNativeTypes && datatype == xsdNamespace+"boolean":
+		switch lit.Lexical {
+		case "true", "1":
+			value, datatype = true, ""
+		case "false", "0":
+			value, datatype = false, ""
+		}
+	case p.opts.UseNativeTypes && datatype == xsdNamespace+"integer" && jsonldIntegerPattern.MatchString(lit.Lexical):
+		if integer, ok := jsonldIntegerLexical(strings.TrimPrefix(lit.Lexical, "+")); ok {
+			value, datatype = json.Number(integer), ""
+		}
+	case p.opts.UseNativeTypes && datatype == xsdNamespace+"double" && jsonldDoublePattern.MatchString(lit.Lexical):
+		if f, err := strconv.ParseFloat(lit.Lexical, 64); err == nil {
+			value, datatype = f, ""
+		}
+	case p.opts.RdfDirection == "i18n-datatype" && strings.HasPrefix(datatype, i18nNamespace):
+		language, direction, _ := strings.Cut(datatype[len(i18nNamespace):], "_")
+		if language != "" {
+			result["@language"] = language
+		}
+		if direction != "" {
+			result["@direction"] = direction
+		}
+		datatype = ""
+	case p.mode != jsonldMode10 && datatype == rdfJSONIRI:
+		if _, err := canonicalizeJSONText([]byte(lit.Lexical)); err != nil {
+			return nil, jsonldError("invalid JSON literal", "%s", lit.Lexical)
+		}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(lit.Lexical), &parsed); err != nil {
+			return nil, jsonldError("invalid JSON literal", "%s", lit.Lexical)
+		}
+		value, datatype = parsed, "@json"
+	}
+	result["@value"] = value
+	if datatype != "" {
+		result["@type"] = datatype
+	}
+	return result, nil
+}
+
+// jsonldFirstValue returns the @value of the first value of property in
+// node, or nil.
+func jsonldFirstValue(node map[string]interface{}, property string) interface{} {
+	values := jsonldArrayOrEmpty(node[property])
+	if len(values) == 0 {
+		return nil
+	}
+	if obj, ok := values[0].(map[string]interface{}); ok {
+		return obj["@value"]
+	}
+	return nil
+}
@@ -1,23 +1,105 @@
 package rdf
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 )
 
-// emitJSONLDLiteralValue handles literal value emission for JSON-LD.
-// It creates a Literal term from various primitive types (string, float64, bool).
-func emitJSONLDLiteralValue(value interface{}, ctx jsonldContext) Literal {
-	lit := Literal{Lexical: fmt.Sprintf("%v", value)}
-
-	switch value.(type) {
+// emitJSONLDLiteralValue converts an @value (string, number or boolean) to a
+// literal. datatype is the expanded @type of the value object, if any.
+func emitJSONLDLiteralValue(value interface{}, datatype string) Literal {
+	switch v := value.(type) {
+	case json.Number:
+		return jsonldNumberLiteral(string(v), datatype)
 	case float64:
-		lit.Datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#decimal"}
+		return jsonldNumberLiteral(strconv.FormatFloat(v, 'g', -1, 64), datatype)
 	case bool:
-		lit.Datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#boolean"}
+		if datatype == "" {
+			datatype = xsdNamespace + "boolean"
+		}
+		return Literal{Lexical: strconv.FormatBool(v), Datatype: IRI{Value: datatype}}
 	}
+	return Literal{Lexical: fmt.Sprintf("%v", value), Datatype: IRI{Value: datatype}}
+}
 
-	return lit
+// jsonldNumberLiteral converts the text of a JSON number following the
+// JSON-LD to-RDF rules: integral values below 10^21 become xsd:integer and
+// other numbers xsd:double in canonical form. The number is never rounded
+// through float64 on the integer path, so identifiers beyond 2^53 survive.
+// Numbers with another explicit datatype, such as xsd:decimal, keep their
+// JSON text.
+func jsonldNumberLiteral(text, datatype string) Literal {
+	integer, integral := jsonldIntegerLexical(text)
+	switch {
+	case integral && (datatype == "" || datatype == xsdNamespace+"integer"):
+		return Literal{Lexical: integer, Datatype: IRI{Value: xsdNamespace + "integer"}}
+	case datatype == "" || datatype == xsdNamespace+"double":
+		f, _ := strconv.ParseFloat(text, 64)
+		return Literal{Lexical: canonicalXSDDouble(f), Datatype: IRI{Value: xsdNamespace + "double"}}
+	default:
+		return Literal{Lexical: text, Datatype: IRI{Value: datatype}}
+	}
+}
+
+// jsonldIntegerLexical returns the canonical xsd:integer form of a JSON
+// number whose value is integral and below 10^21 in magnitude, working on
+// the decimal digits so that no precision is lost.
+func jsonldIntegerLexical(text string) (string, bool) {
+	mantissa, exponent := text, 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(text[i+1:])
+		if err != nil {
+			return "", false
+		}
+		mantissa, exponent = text[:i], exp
+	}
+	negative := strings.HasPrefix(mantissa, "-")
+	mantissa = strings.TrimPrefix(mantissa, "-")
+	whole, frac, _ := strings.Cut(mantissa, ".")
+	digits := whole + frac
+	point := len(whole) + exponent // position of the decimal point in digits
+	trimmed := strings.TrimLeft(digits, "0")
+	point -= len(digits) - len(trimmed)
+	digits = strings.TrimRight(trimmed, "0")
+	if digits == "" {
+		return "0", true
+	}
+	if point < len(digits) || point > 21 {
+		return "", false
+	}
+	lexical := digits + strings.Repeat("0", point-len(digits))
+	if negative {
+		lexical = "-" + lexical
+	}
+	return lexical, true
+}
+
+// canonicalXSDDouble formats f as a canonical xsd:double, such as "1.1E0" or
+// "-2.5E-7", as required by the JSON-LD to-RDF algorithm.
+func canonicalXSDDouble(f float64) string {
+	return canonicalXSDFloat(f, 64)
+}
+
+// canonicalXSDFloat formats f in the canonical xsd:double form with the
+// shortest mantissa that round-trips at bitSize, 32 for xsd:float.
+func canonicalXSDFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	}
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(f, 'E', -1, bitSize), "E")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	exp, _ := strconv.Atoi(exponent)
+	return mantissa + "E" + strconv.Itoa(exp)
 }
 
 // emitJSONLDObjectValue handles object value emission for JSON-LD.
@@ -29,12 +111,9 @@ func emitJSONLDObjectValue(value map[string]interface{}, subject Term, pred IRI,
 	}
 
 	if literalValue, ok := value["@value"]; ok {
-		lit := emitJSONLDLiteralValue(literalValue, ctx)
-		if lang, ok := value["@language"].(string); ok {
-			lit.Lang = lang
-		}
-		if dtype, ok := value["@type"].(string); ok {
-			lit.Datatype = IRI{Value: expandJSONLDTerm(dtype, ctx)}
+		lit := emitJSONLDLiteralValue(literalValue, jsonldValueDatatype(value, ctx))
+		if err := setJSONLDLanguage(&lit, value); err != nil {
+			return err
 		}
 		return sink(Quad{S: subject, P: pred, O: lit, G: graphName})
 	}
@@ -50,15 +129,24 @@ func emitJSONLDObjectValue(value map[string]interface{}, subject Term, pred IRI,
 	return fmt.Errorf("jsonld: unsupported object value")
 }
 
+// jsonldValueDatatype returns the expanded @type of a value object, or "".
+func jsonldValueDatatype(value map[string]interface{}, ctx jsonldContext) string {
+	if dtype, ok := value["@type"].(string); ok {
+		return expandJSONLDTerm(dtype, ctx)
+	}
+	return ""
+}
+
 // emitJSONLDTypeStatements emits RDF type statements for @type values in a node.
-// It handles both single string values and arrays of type strings.
+// It handles both single string values and arrays of type strings; "_:" values
+// are blank nodes.
 func emitJSONLDTypeStatements(subject Term, rawTypes interface{}, ctx jsonldContext, graphName Term, sink jsonldQuadSink) error {
 	typeVals, ok := rawTypes.([]interface{})
 	if ok {
 		// Handle array of types
 		for _, t := range typeVals {
 			if tStr, ok := t.(string); ok {
-				obj := IRI{Value: expandJSONLDTerm(tStr, ctx)}
+				obj := jsonldObjectFromID(tStr, ctx, nil)
 				if err := sink(Quad{S: subject, P: IRI{Value: rdfTypeIRI}, O: obj, G: graphName}); err != nil {
 					return err
 				}
@@ -69,7 +157,7 @@ func emitJSONLDTypeStatements(subject Term, rawTypes interface{}, ctx jsonldCont
 
 	// Handle single type string
 	if tStr, ok := rawTypes.(string); ok {
-		obj := IRI{Value: expandJSONLDTerm(tStr, ctx)}
+		obj := jsonldObjectFromID(tStr, ctx, nil)
 		return sink(Quad{S: subject, P: IRI{Value: rdfTypeIRI}, O: obj, G: graphName})
 	}
 
@@ -90,9 +178,39 @@ func emitJSONLDPredicateValues(node map[string]interface{}, subject Term, ctx js
 		if pred.Value == "" {
 			return fmt.Errorf("jsonld: cannot resolve predicate %q", key)
 		}
-		if err := emitJSONLDValue(subject, pred, raw, ctx, graphName, state, sink); err != nil {
+		emit := sink
+		if strings.HasPrefix(pred.Value, "_:") && !state.opts.ProduceGeneralizedRdf {
+			// Only the statements with the blank node predicate are left
+			// out, not those of the nodes it leads to.
+			emit = func(q Quad) error {
+				if q.P == pred && q.S == subject {
+					return nil
+				}
+				return sink(q)
+			}
+		}
+		if err := emitJSONLDValue(subject, pred, raw, ctx, graphName, state, emit); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// setJSONLDLanguage copies @language and @direction from a value object to
+// lit. @direction is only kept together with a language, as RDF has no
+// directional strings without one.
+func setJSONLDLanguage(lit *Literal, value map[string]interface{}) error {
+	lang, ok := value["@language"].(string)
+	if !ok {
+		return nil
+	}
+	lit.Lang = lang
+	if raw, ok := value["@direction"]; ok && raw != nil {
+		direction, _ := raw.(string)
+		if direction != "ltr" && direction != "rtl" {
+			return fmt.Errorf("jsonld: invalid base direction %v", raw)
+		}
+		lit.Direction = direction
+	}
+	return nil
+}
@@ -0,0 +1,428 @@
+package rdf
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	jsonldContextLinkRel = "http://www.w3.org/ns/json-ld#context"
+	jsonldAcceptHeader   = "application/ld+json, application/json;q=0.9, */*;q=0.1"
+
+	defaultLoaderMaxBytes = 10 << 20
+)
+
+// CachingDocumentLoader is a DocumentLoader that fetches JSON-LD documents
+// and contexts over HTTP(S) and caches them:
+//
+//   - Requests accept application/ld+json and application/json. A JSON
+//     response with a Link header of rel "http://www.w3.org/ns/json-ld#context"
+//     reports the linked context in RemoteDocument.ContextURL; a non-JSON
+//     response with a Link header of rel "alternate" and type
+//     "application/ld+json" is followed to the linked document.
+//   - Responses are kept in memory and, when CacheDir is set, on disk, and
+//     reused while fresh according to Cache-Control max-age (or Expires).
+//     Responses marked no-store are not cached. Stale entries with an ETag or
+//     Last-Modified header are revalidated with a conditional request.
+//   - Documents registered with Preload are served without any request, so
+//     applications can pin contexts such as https://schema.org/ or
+//     https://www.w3.org/ns/credentials/v2 to known content.
+//   - In Offline mode only preloaded documents are served; every other IRI
+//     fails without touching the network or the cache.
+//
+// A CachingDocumentLoader is safe for concurrent use. Set the fields before
+// the first call to LoadDocument.
+type CachingDocumentLoader struct {
+	// Client performs the requests (nil = http.DefaultClient).
+	Client *http.Client
+	// CacheDir stores fetched documents on disk, shared by loaders using the
+	// same directory ("" = memory only). Failures to write it are ignored.
+	CacheDir string
+	// Offline serves preloaded documents only.
+	Offline bool
+	// MaxBytes limits the size of fetched documents (0 = 10 MiB).
+	MaxBytes int64
+
+	mu        sync.Mutex
+	preloaded map[string]RemoteDocument
+	cache     map[string]*cachedDocument
+}
+
+// cachedDocument is a fetched document with its HTTP caching metadata. It
+// keeps the raw JSON so that every caller gets its own decoded copy.
+type cachedDocument struct {
+	DocumentURL  string          `json:"documentUrl"`
+	ContextURL   string          `json:"contextUrl,omitempty"`
+	Body         json.RawMessage `json:"body"`
+	Expires      time.Time       `json:"expires"`
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"lastModified,omitempty"`
+}
+
+// NewCachingDocumentLoader returns an online loader with an in-memory cache.
+func NewCachingDocumentLoader() *CachingDocumentLoader {
+	return &CachingDocumentLoader{}
+}
+
+// Preload registers document as the content of iri. document is a decoded
+// JSON value, usually a map with an "@context" entry. Preloaded documents
+// take precedence over the network and the cache.
+func (l *CachingDocumentLoader) Preload(iri string, document interface{}) *CachingDocumentLoader {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.preloaded == nil {
+		l.preloaded = make(map[string]RemoteDocument)
+	}
+	l.preloaded[stripFragment(iri)] = RemoteDocument{DocumentURL: iri, Document: document}
+	return l
+}
+
+// PreloadJSON registers the JSON encoded document data as the content of iri.
+func (l *CachingDocumentLoader) PreloadJSON(iri string, data []byte) error {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("jsonld: invalid preloaded document %q: %w", iri, err)
+	}
+	l.Preload(iri, document)
+	return nil
+}
+
+// LoadDocument implements DocumentLoader.
+func (l *CachingDocumentLoader) LoadDocument(ctx context.Context, iri string) (RemoteDocument, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	key := stripFragment(iri)
+	l.mu.Lock()
+	if remote, ok := l.preloaded[key]; ok {
+		l.mu.Unlock()
+		return remote, nil
+	}
+	l.mu.Unlock()
+	if l.Offline {
+		return RemoteDocument{}, fmt.Errorf("jsonld: %q is not preloaded and the loader is offline", iri)
+	}
+	parsed, err := url.Parse(key)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return RemoteDocument{}, fmt.Errorf("jsonld: cannot load %q: only http and https IRIs are supported", iri)
+	}
+
+	cached := l.lookup(key)
+	if cached != nil && time.Now().Before(cached.Expires) {
+		return cached.remoteDocument()
+	}
+	doc, err := l.fetch(ctx, key, cached, true)
+	if err != nil {
+		return RemoteDocument{}, err
+	}
+	return doc.remoteDocument()
+}
+
+// lookup returns the cache entry of key from memory or disk.
+func (l *CachingDocumentLoader) lookup(key string) *cachedDocument {
+	l.mu.Lock()
+	cached := l.cache[key]
+	l.mu.Unlock()
+	if cached != nil || l.CacheDir == "" {
+		return cached
+	}
+	data, err := os.ReadFile(l.cachePath(key))
+	if err != nil {
+		return nil
+	}
+	cached = &cachedDocument{}
+	if err := json.Unmarshal(data, cached); err != nil {
+		return nil
+	}
+	l.mu.Lock()
+	if l.cache == nil {
+		l.cache = make(map[string]*cachedDocument)
+	}
+	l.cache[key] = cached
+	l.mu.Unlock()
+	return cached
+}
+
+// store caches doc under key, or removes the entry when doc is nil.
+func (l *CachingDocumentLoader) store(key string, doc *cachedDocument) {
+	l.mu.Lock()
+	if l.cache == nil {
+		l.cache = make(map[string]*cachedDocument)
+	}
+	if doc == nil {
+		delete(l.cache, key)
+	} else {
+		l.cache[key] = doc
+	}
+	l.mu.Unlock()
+	if l.CacheDir == "" {
+		return
+	}
+	path := l.cachePath(key)
+	if doc == nil {
+		_ = os.Remove(path)
+		return
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(l.CacheDir, 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(l.CacheDir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func (l *CachingDocumentLoader) cachePath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(l.CacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// fetch requests key, revalidating cached if it has validators, and caches
+// the response as its headers allow. followAlternate allows one redirection
+// through an alternate link.
+func (l *CachingDocumentLoader) fetch(ctx context.Context, key string, cached *cachedDocument, followAlternate bool) (*cachedDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("jsonld: cannot load %q: %w", key, err)
+	}
+	req.Header.Set("Accept", jsonldAcceptHeader)
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	client := l.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jsonld: cannot load %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		refreshed := *cached
+		refreshed.Expires = cacheExpiry(resp.Header)
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			refreshed.ETag = etag
+		}
+		l.store(key, &refreshed)
+		return &refreshed, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("jsonld: cannot load %q: HTTP status %s", key, resp.Status)
+	}
+
+	documentURL := resp.Request.URL.String()
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	links := parseLinkHeader(resp.Header.Values("Link"), resp.Request.URL)
+	if !isJSONMediaType(mediaType) {
+		for _, link := range links {
+			if followAlternate && link.params["rel"] == "alternate" && link.params["type"] == "application/ld+json" {
+				return l.fetch(ctx, link.target, l.lookup(link.target), false)
+			}
+		}
+		return nil, fmt.Errorf("jsonld: cannot load %q: unsupported content type %q", key, mediaType)
+	}
+
+	maxBytes := l.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultLoaderMaxBytes
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("jsonld: cannot load %q: %w", key, err)
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, fmt.Errorf("jsonld: cannot load %q: document exceeds %d bytes", key, maxBytes)
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("jsonld: cannot load %q: invalid JSON", key)
+	}
+
+	doc := &cachedDocument{
+		DocumentURL:  documentURL,
+		Body:         json.RawMessage(bytes.TrimSpace(body)),
+		Expires:      cacheExpiry(resp.Header),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	// JSON-LD 1.1 ignores context links on application/ld+json responses.
+	if mediaType != "application/ld+json" {
+		for _, link := range links {
+			if link.params["rel"] == jsonldContextLinkRel {
+				if doc.ContextURL != "" {
+					return nil, fmt.Errorf("jsonld: cannot load %q: multiple context link headers", key)
+				}
+				doc.ContextURL = link.target
+			}
+		}
+	}
+	if cacheDirectives(resp.Header)["no-store"] {
+		l.store(key, nil)
+	} else {
+		l.store(key, doc)
+	}
+	return doc, nil
+}
+
+func (d *cachedDocument) remoteDocument() (RemoteDocument, error) {
+	var document interface{}
+	if err := json.Unmarshal(d.Body, &document); err != nil {
+		return RemoteDocument{}, fmt.Errorf("jsonld: invalid cached document %q: %w", d.DocumentURL, err)
+	}
+	return RemoteDocument{DocumentURL: d.DocumentURL, Document: document, ContextURL: d.ContextURL}, nil
+}
+
+// cacheExpiry returns until when a response may be reused without
+// revalidation: max-age takes precedence over Expires, and responses without
+// either, or marked no-cache, are stale at once.
+func cacheExpiry(header http.Header) time.Time {
+	now := time.Now()
+	directives := cacheDirectives(header)
+	if directives["no-cache"] {
+		return now
+	}
+	for directive := range directives {
+		if value, ok := strings.CutPrefix(directive, "max-age="); ok {
+			seconds, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+			if err != nil || seconds <= 0 {
+				return now
+			}
+			return now.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		return expires
+	}
+	return now
+}
+
+// cacheDirectives returns the lower-cased Cache-Control directives.
+func cacheDirectives(header http.Header) map[string]bool {
+	directives := make(map[string]bool)
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			if directive = strings.ToLower(strings.TrimSpace(directive)); directive != "" {
+				directives[directive] = true
+			}
+		}
+	}
+	return directives
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || mediaType == "application/ld+json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// httpLink is one link of an HTTP Link header (RFC 8288).
+type httpLink struct {
+	target string
+	params map[string]string // Lower-cased parameter names
+}
+
+// parseLinkHeader parses Link header values, resolving targets against base.
+// Links that cannot be parsed are skipped.
+func parseLinkHeader(values []string, base *url.URL) []httpLink {
+	var links []httpLink
+	for _, value := range values {
+		for value != "" {
+			value = strings.TrimLeft(value, " \t,")
+			if !strings.HasPrefix(value, "<") {
+				break
+			}
+			end := strings.IndexByte(value, '>')
+			if end < 0 {
+				break
+			}
+			link := httpLink{target: value[1:end], params: make(map[string]string)}
+			if ref, err := url.Parse(link.target); err == nil {
+				link.target = base.ResolveReference(ref).String()
+			}
+			value = value[end+1:]
+			for {
+				value = strings.TrimLeft(value, " \t")
+				if !strings.HasPrefix(value, ";") {
+					break
+				}
+				value = strings.TrimLeft(value[1:], " \t")
+				nameEnd := strings.IndexAny(value, "=;, \t")
+				if nameEnd < 0 {
+					nameEnd = len(value)
+				}
+				name := strings.ToLower(value[:nameEnd])
+				value = strings.TrimLeft(value[nameEnd:], " \t")
+				var param string
+				if strings.HasPrefix(value, "=") {
+					value = strings.TrimLeft(value[1:], " \t")
+					param, value = readLinkParamValue(value)
+				}
+				if _, seen := link.params[name]; !seen && name != "" {
+					link.params[name] = param
+				}
+			}
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// readLinkParamValue reads a token or quoted string and returns the rest.
+func readLinkParamValue(value string) (string, string) {
+	if !strings.HasPrefix(value, `"`) {
+		end := strings.IndexAny(value, ";, \t")
+		if end < 0 {
+			end = len(value)
+		}
+		return value[:end], value[end:]
+	}
+	var b strings.Builder
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if i+1 < len(value) {
+				i++
+				b.WriteByte(value[i])
+			}
+		case '"':
+			return b.String(), value[i+1:]
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String(), ""
+}
+
+func stripFragment(iri string) string {
+	if i := strings.IndexByte(iri, '#'); i >= 0 {
+		return iri[:i]
+	}
+	return iri
+}
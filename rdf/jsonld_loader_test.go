@@ -0,0 +1,166 @@
+package rdf
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const loaderTestContext = `{"@context": {"@vocab": "http://schema.org/"}}`
+
+func TestCachingDocumentLoaderCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !strings.Contains(r.Header.Get("Accept"), "application/ld+json") {
+			t.Errorf("unexpected Accept header %q", r.Header.Get("Accept"))
+		}
+		switch r.URL.Path {
+		case "/fresh":
+			w.Header().Set("Cache-Control", "public, max-age=3600")
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store")
+		case "/etag":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+		}
+		w.Header().Set("Content-Type", "application/ld+json")
+		w.Write([]byte(loaderTestContext))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	tests := []struct {
+		path     string
+		requests int32
+	}{
+		{"/fresh", 1},
+		{"/no-store", 3},
+		{"/etag", 3}, // revalidated, served from the cache on 304
+	}
+	for _, tt := range tests {
+		requests.Store(0)
+		loader := NewCachingDocumentLoader()
+		for i := 0; i < 3; i++ {
+			remote, err := loader.LoadDocument(ctx, server.URL+tt.path)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.path, err)
+			}
+			doc, ok := remote.Document.(map[string]interface{})
+			if !ok || doc["@context"] == nil {
+				t.Fatalf("%s: unexpected document %v", tt.path, remote.Document)
+			}
+		}
+		if got := requests.Load(); got != tt.requests {
+			t.Errorf("%s: expected %d requests, got %d", tt.path, tt.requests, got)
+		}
+	}
+
+	// The disk cache is shared by loaders using the same directory.
+	requests.Store(0)
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		loader := &CachingDocumentLoader{CacheDir: dir}
+		if _, err := loader.LoadDocument(ctx, server.URL+"/fresh"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("disk cache: expected 1 request, got %d", got)
+	}
+}
+
+func TestCachingDocumentLoaderLinkHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data.json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Link", `<context.jsonld>; rel="http://www.w3.org/ns/json-ld#context"; type="application/ld+json"`)
+			w.Write([]byte(`{"name": "Alice"}`))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Link", `</other>; rel=next, </data.jsonld>; rel="alternate"; type="application/ld+json"`)
+			w.Write([]byte(`<html></html>`))
+		case "/data.jsonld":
+			w.Header().Set("Content-Type", "application/ld+json")
+			w.Write([]byte(loaderTestContext))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	loader := NewCachingDocumentLoader()
+	remote, err := loader.LoadDocument(context.Background(), server.URL+"/data.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remote.ContextURL != server.URL+"/context.jsonld" {
+		t.Errorf("expected linked context, got %q", remote.ContextURL)
+	}
+	remote, err = loader.LoadDocument(context.Background(), server.URL+"/page.html")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remote.DocumentURL != server.URL+"/data.jsonld" {
+		t.Errorf("expected alternate document, got %q", remote.DocumentURL)
+	}
+	if _, err := loader.LoadDocument(context.Background(), server.URL+"/missing"); err == nil {
+		t.Error("expected error for HTTP 404")
+	}
+}
+
+func TestCachingDocumentLoaderOffline(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/ld+json")
+		w.Write([]byte(loaderTestContext))
+	}))
+	defer server.Close()
+
+	loader := &CachingDocumentLoader{Offline: true}
+	if err := loader.PreloadJSON("https://schema.org/", []byte(loaderTestContext)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := loader.LoadDocument(context.Background(), server.URL); err == nil {
+		t.Error("expected offline loader to refuse an IRI that is not preloaded")
+	}
+	if requests.Load() != 0 {
+		t.Error("offline loader made a request")
+	}
+
+	var doc interface{}
+	input := `{"@context": "https://schema.org/", "@id": "http://example.org/alice", "name": "Alice"}`
+	if err := json.Unmarshal([]byte(input), &doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, decode := range map[string]func() ([]Quad, error){
+		"reader": func() ([]Quad, error) {
+			var quads []Quad
+			err := parseJSONLDFromReader(strings.NewReader(input), JSONLDOptions{DocumentLoader: loader, Context: context.Background()}, func(q Quad) error {
+				quads = append(quads, q)
+				return nil
+			})
+			return quads, err
+		},
+		"processor": func() ([]Quad, error) {
+			return NewJSONLDProcessor().ToRDF(context.Background(), doc, JSONLDOptions{DocumentLoader: loader})
+		},
+	} {
+		quads, err := decode()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if len(quads) != 1 || quads[0].P.Value != "http://schema.org/name" {
+			t.Fatalf("%s: expected the preloaded context to apply, got %v", name, quads)
+		}
+	}
+}
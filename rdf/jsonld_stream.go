@@ -0,0 +1,464 @@
+package rdf
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// JSONLDStreamingMediaType is the media type of documents written with
+// OptJSONLDStreaming.
+const JSONLDStreamingMediaType = `application/ld+json;profile="http://www.w3.org/ns/json-ld#streaming"`
+
+// jsonldstreamEncoder streams quads as a JSON-LD document in the order of
+// the JSON-LD streaming document form: consecutive statements about one
+// subject become one node object with @id first and @type second, and
+// consecutive statements in one named graph are grouped into a graph object
+// with @graph. With a context, the document starts with it and IRIs are
+// compacted with its terms, prefixes and @vocab; without one, it is an
+// expanded document. Each node object is written on its own line.
+type jsonldstreamEncoder struct {
+	writer    *bufio.Writer
+	context   []byte // nil = expanded document
+	compactor *jsonldCompactor
+	opts      JSONLDOptions
+
+	node       *jsonldNode // Node object being collected
+	started    bool
+	graph      Term // Graph of the open graph object (nil = none)
+	topCount   int  // Entries written to the top-level @graph
+	graphCount int  // Entries written to the open graph object
+	closed     bool
+	err        error
+}
+
+// jsonldNode collects the properties of one node object in statement order.
+type jsonldNode struct {
+	subject Term
+	graph   Term
+	types   []string
+	keys    []string
+	values  map[string][]interface{}
+}
+
+func newJSONLDstreamEncoder(w io.Writer, context interface{}, opts JSONLDOptions) (quadEncoder, error) {
+	if context == nil {
+		return &jsonldstreamEncoder{writer: newEncoderBuffer(w), compactor: newJSONLDCompactor(nil), opts: opts}, nil
+	}
+	if doc, ok := context.(map[string]interface{}); ok {
+		if inner, ok := doc["@context"]; ok {
+			context = inner
+		}
+	}
+	data, err := json.Marshal(context)
+	if err != nil {
+		return nil, fmt.Errorf("jsonld: invalid context: %w", err)
+	}
+	return &jsonldstreamEncoder{
+		writer:    newEncoderBuffer(w),
+		context:   data,
+		compactor: newJSONLDCompactor(context),
+		opts:      opts,
+	}, nil
+}
+
+func (e *jsonldstreamEncoder) Write(q Quad) error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.closed {
+		return fmt.Errorf("jsonld: writer closed")
+	}
+	if q.IsZero() {
+		return nil
+	}
+	switch q.S.(type) {
+	case IRI, BlankNode:
+	default:
+		return fmt.Errorf("jsonld: invalid subject")
+	}
+	if q.P.Value == "" {
+		return fmt.Errorf("jsonld: missing predicate")
+	}
+	if q.O == nil {
+		return fmt.Errorf("jsonld: missing object")
+	}
+	if e.node != nil && (e.node.subject != q.S || e.node.graph != q.G) {
+		if err := e.writeNode(); err != nil {
+			return err
+		}
+	}
+	if e.node == nil {
+		e.node = &jsonldNode{subject: q.S, graph: q.G, values: make(map[string][]interface{})}
+	}
+	if q.P.Value == rdfTypeIRI && !e.opts.UseRdfType {
+		if id, err := jsonldSubjectID(q.O); err == nil {
+			if _, isIRI := q.O.(IRI); isIRI {
+				id = e.compactor.vocabIRI(id)
+			}
+			e.node.types = append(e.node.types, id)
+			return nil
+		}
+	}
+	key := e.compactor.vocabIRI(q.P.Value)
+	if _, ok := e.node.values[key]; !ok {
+		e.node.keys = append(e.node.keys, key)
+	}
+	if e.context == nil {
+		e.node.values[key] = append(e.node.values[key], jsonldExpandedValue(q.O))
+	} else {
+		e.node.values[key] = append(e.node.values[key], e.compactor.value(q.O))
+	}
+	return nil
+}
+
+// writeNode writes the collected node object, opening and closing graph
+// objects as its graph differs from the previous one.
+func (e *jsonldstreamEncoder) writeNode() error {
+	node := e.node
+	e.node = nil
+	var b strings.Builder
+	if !e.started {
+		e.started = true
+		if e.context == nil {
+			b.WriteString("[")
+		} else {
+			b.WriteString(`{"@context":`)
+			b.Write(e.context)
+			b.WriteString(`,"@graph":[`)
+		}
+	}
+	if node.graph != e.graph {
+		if e.graph != nil {
+			b.WriteString("\n]}")
+			e.graph = nil
+		}
+		if node.graph != nil {
+			id, err := jsonldSubjectID(node.graph)
+			if err != nil {
+				e.err = err
+				return err
+			}
+			if e.topCount > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n{\"@id\":")
+			writeJSONString(&b, e.compactor.idIRI(node.graph, id))
+			b.WriteString(`,"@graph":[`)
+			e.graph, e.graphCount = node.graph, 0
+			e.topCount++
+		}
+	}
+	count := &e.topCount
+	if e.graph != nil {
+		count = &e.graphCount
+	}
+	if *count > 0 {
+		b.WriteString(",")
+	}
+	*count++
+	id, err := jsonldSubjectID(node.subject)
+	if err != nil {
+		e.err = err
+		return err
+	}
+	b.WriteString("\n{\"@id\":")
+	writeJSONString(&b, e.compactor.idIRI(node.subject, id))
+	if len(node.types) > 0 {
+		b.WriteString(`,"@type":`)
+		if err := writeJSONValue(&b, e.compactArray(stringsToValues(node.types))); err != nil {
+			e.err = err
+			return err
+		}
+	}
+	for _, key := range node.keys {
+		b.WriteString(",")
+		writeJSONString(&b, key)
+		b.WriteString(":")
+		if err := writeJSONValue(&b, e.compactArray(node.values[key])); err != nil {
+			e.err = err
+			return err
+		}
+	}
+	b.WriteString("}")
+	if _, err := e.writer.WriteString(b.String()); err != nil {
+		e.err = err
+		return err
+	}
+	return nil
+}
+
+// Flush writes the completed node objects; the node of the last subject is
+// written when the next subject starts or on Close.
+func (e *jsonldstreamEncoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.writer.Flush()
+}
+
+func (e *jsonldstreamEncoder) Close() error {
+	if e.closed {
+		return e.err
+	}
+	e.closed = true
+	if e.err != nil {
+		return e.err
+	}
+	if e.node != nil {
+		if err := e.writeNode(); err != nil {
+			return err
+		}
+	}
+	if e.started {
+		closing := "\n]}\n"
+		if e.context == nil {
+			closing = "\n]\n"
+		}
+		if e.graph != nil {
+			closing = "\n]}" + closing
+		}
+		if _, err := e.writer.WriteString(closing); err != nil {
+			e.err = err
+			return err
+		}
+	}
+	return e.Flush()
+}
+
+func writeJSONString(b *strings.Builder, value string) {
+	data, _ := json.Marshal(value)
+	b.Write(data)
+}
+
+func writeJSONValue(b *strings.Builder, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	b.Write(data)
+	return nil
+}
+
+func stringsToValues(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, value := range values {
+		out[i] = value
+	}
+	return out
+}
+
+// compactArray returns values, or its only element in a compacted document.
+func (e *jsonldstreamEncoder) compactArray(values []interface{}) interface{} {
+	if len(values) == 1 && e.context != nil {
+		return values[0]
+	}
+	return values
+}
+
+// jsonldExpandedValue returns the expanded JSON-LD value of an object term.
+func jsonldExpandedValue(term Term) interface{} {
+	switch value := term.(type) {
+	case IRI:
+		return map[string]string{"@id": value.Value}
+	case BlankNode:
+		return map[string]string{"@id": value.String()}
+	case Literal:
+		object := map[string]string{"@value": value.Lexical}
+		switch {
+		case value.Lang != "":
+			object["@language"] = value.Lang
+			if value.Direction != "" {
+				object["@direction"] = value.Direction
+			}
+		case value.Datatype.Value != "" && value.Datatype.Value != xsdNamespace+"string":
+			object["@type"] = value.Datatype.Value
+		}
+		return object
+	default:
+		return map[string]string{"@value": term.String()}
+	}
+}
+
+// jsonldCompactor compacts IRIs with the definitions of a context. Only term
+// definitions that map a term to an IRI without coercion, containers or
+// language are used, so compaction never changes the meaning of a value.
+type jsonldCompactor struct {
+	terms    map[string]string // IRI to term
+	names    map[string]bool   // Defined terms
+	prefixes []jsonldPrefix    // Longest namespace first
+	vocab    string
+	language bool // The context sets a default language
+}
+
+type jsonldPrefix struct {
+	name      string
+	namespace string
+}
+
+func newJSONLDCompactor(context interface{}) *jsonldCompactor {
+	c := &jsonldCompactor{terms: make(map[string]string), names: make(map[string]bool)}
+	definitions := make(map[string]string)
+	prefixFlags := make(map[string]bool)
+	var collect func(interface{})
+	collect = func(value interface{}) {
+		switch ctx := value.(type) {
+		case []interface{}:
+			for _, item := range ctx {
+				collect(item)
+			}
+		case map[string]interface{}:
+			for key, raw := range ctx {
+				switch key {
+				case "@vocab":
+					if vocab, ok := raw.(string); ok {
+						c.vocab = vocab
+					}
+					continue
+				case "@language":
+					c.language = raw != nil
+					continue
+				}
+				if strings.HasPrefix(key, "@") {
+					continue
+				}
+				c.names[key] = true
+				delete(definitions, key)
+				switch def := raw.(type) {
+				case string:
+					definitions[key] = def
+				case map[string]interface{}:
+					id, ok := def["@id"].(string)
+					if !ok {
+						continue
+					}
+					simple := true
+					for name := range def {
+						if name != "@id" && name != "@prefix" {
+							simple = false
+						}
+					}
+					if !simple {
+						continue
+					}
+					definitions[key] = id
+					if flag, ok := def["@prefix"].(bool); ok {
+						prefixFlags[key] = flag
+					}
+				}
+			}
+		}
+	}
+	collect(context)
+
+	keys := make([]string, 0, len(definitions))
+	for key := range definitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// Definitions may use compact IRIs or @vocab themselves.
+	expand := func(value string) string {
+		if strings.HasPrefix(value, "@") || strings.HasPrefix(value, "_:") {
+			return ""
+		}
+		if i := strings.IndexByte(value, ':'); i >= 0 {
+			if ns, ok := definitions[value[:i]]; ok && !strings.HasPrefix(value[i+1:], "//") {
+				return ns + value[i+1:]
+			}
+			return value
+		}
+		if c.vocab != "" {
+			return c.vocab + value
+		}
+		return ""
+	}
+	for _, key := range keys {
+		iri := expand(definitions[key])
+		if iri == "" {
+			continue
+		}
+		if !strings.Contains(key, ":") {
+			if _, ok := c.terms[iri]; !ok || len(key) < len(c.terms[iri]) {
+				c.terms[iri] = key
+			}
+			isPrefix, flagged := prefixFlags[key]
+			if !flagged {
+				isPrefix = strings.ContainsAny(iri[len(iri)-1:], ":/?#[]@")
+			}
+			if isPrefix {
+				c.prefixes = append(c.prefixes, jsonldPrefix{name: key, namespace: iri})
+			}
+		}
+	}
+	sort.SliceStable(c.prefixes, func(i, j int) bool {
+		return len(c.prefixes[i].namespace) > len(c.prefixes[j].namespace)
+	})
+	return c
+}
+
+// vocabIRI compacts an IRI in a vocabulary position: a property, a node
+// type or a datatype.
+func (c *jsonldCompactor) vocabIRI(iri string) string {
+	if term, ok := c.terms[iri]; ok {
+		return term
+	}
+	if c.vocab != "" && strings.HasPrefix(iri, c.vocab) {
+		rest := iri[len(c.vocab):]
+		if rest != "" && !strings.ContainsAny(rest, ":") && !strings.HasPrefix(rest, "@") && !c.names[rest] {
+			return rest
+		}
+	}
+	return c.compactIRI(iri)
+}
+
+// idIRI compacts the @id of term, whose JSON-LD identifier is id.
+func (c *jsonldCompactor) idIRI(term Term, id string) string {
+	if _, ok := term.(IRI); !ok {
+		return id
+	}
+	return c.compactIRI(id)
+}
+
+// compactIRI returns iri as a compact IRI with the longest matching prefix.
+func (c *jsonldCompactor) compactIRI(iri string) string {
+	for _, prefix := range c.prefixes {
+		if !strings.HasPrefix(iri, prefix.namespace) {
+			continue
+		}
+		rest := iri[len(prefix.namespace):]
+		if strings.HasPrefix(rest, "//") || c.names[prefix.name+":"+rest] {
+			continue
+		}
+		return prefix.name + ":" + rest
+	}
+	return iri
+}
+
+// value returns the compacted JSON-LD value of an object term.
+func (c *jsonldCompactor) value(term Term) interface{} {
+	switch value := term.(type) {
+	case IRI:
+		return map[string]string{"@id": c.compactIRI(value.Value)}
+	case BlankNode:
+		return map[string]string{"@id": value.String()}
+	case Literal:
+		object := map[string]string{"@value": value.Lexical}
+		switch {
+		case value.Lang != "":
+			object["@language"] = value.Lang
+			if value.Direction != "" {
+				object["@direction"] = value.Direction
+			}
+		case value.Datatype.Value != "" && value.Datatype.Value != xsdNamespace+"string":
+			object["@type"] = c.vocabIRI(value.Datatype.Value)
+		case !c.language:
+			return value.Lexical
+		}
+		return object
+	default:
+		return map[string]string{"@value": term.String()}
+	}
+}
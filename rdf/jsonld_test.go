@@ -3,6 +3,8 @@ package rdf
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -101,3 +103,510 @@ func TestJSONLDDecodeCancelAfterFirstTriple(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestJSONLDNumberPrecision(t *testing.T) {
+	input := `{"@context":{"ex":"http://example.org/","xsd":"http://www.w3.org/2001/XMLSchema#"},"@id":"ex:s",
+		"ex:p":[9007199254740993, -12e2, 1.5, 1.0, 1e21,
+			{"@value":0.1000000000000000055511151231257827, "@type":"xsd:decimal"},
+			{"@value":42, "@type":"xsd:double"}]}`
+	dec, err := NewReader(strings.NewReader(input), FormatJSONLD)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer dec.Close()
+	var got []string
+	for {
+		stmt, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, stmt.O.String())
+	}
+	want := []string{
+		`"9007199254740993"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+		`"-1200"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+		`"1.5E0"^^<http://www.w3.org/2001/XMLSchema#double>`,
+		`"1"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+		`"1.0E21"^^<http://www.w3.org/2001/XMLSchema#double>`,
+		`"0.1000000000000000055511151231257827"^^<http://www.w3.org/2001/XMLSchema#decimal>`,
+		`"4.2E1"^^<http://www.w3.org/2001/XMLSchema#double>`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected literals:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestCanonicalXSDDouble(t *testing.T) {
+	cases := map[float64]string{0: "0.0E0", 1e-7: "1.0E-7", -2.5e300: "-2.5E300", 123.456: "1.23456E2"}
+	for f, want := range cases {
+		if got := canonicalXSDDouble(f); got != want {
+			t.Errorf("canonicalXSDDouble(%v) = %q, want %q", f, got, want)
+		}
+	}
+}
+
+func TestJSONLDWriterRdfType(t *testing.T) {
+	triples := []Triple{
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: rdfTypeIRI}, O: IRI{Value: "http://example.org/C"}},
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: rdfTypeIRI}, O: BlankNode{ID: "t"}},
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: rdfTypeIRI}, O: Literal{Lexical: "C"}},
+	}
+	for _, opts := range [][]Option{nil, {OptJSONLDUseRdfType()}} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, FormatJSONLD, opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, triple := range triples {
+			if err := w.Write(triple.ToStatement()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		if useRdfType := opts != nil; strings.Contains(out, `"@type":"http://example.org/C"`) == useRdfType ||
+			strings.Contains(out, `"@type":"_:t"`) == useRdfType {
+			t.Fatalf("unexpected rdf:type encoding (useRdfType=%v): %s", useRdfType, out)
+		}
+
+		r, err := NewReader(strings.NewReader(out), FormatJSONLD)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got []string
+		for {
+			stmt, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, stmt.O.String())
+		}
+		r.Close()
+		if strings.Join(got, " ") != `http://example.org/C _:t "C"` {
+			t.Fatalf("unexpected round trip objects %q from %s", got, out)
+		}
+	}
+}
+
+func TestJSONLDToRDFIRITypeAlias(t *testing.T) {
+	input := map[string]interface{}{
+		"@context": map[string]interface{}{
+			rdfTypeIRI: map[string]interface{}{"@id": "@type", "@type": "@id"},
+		},
+		"@id":      "http://example.com/a",
+		rdfTypeIRI: []interface{}{"http://example.com/b", "http://example.com/c"},
+	}
+	quads, err := NewJSONLDProcessor().ToRDF(context.Background(), input, JSONLDOptions{ProcessingMode: "json-ld-1.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(quads) != 2 {
+		t.Fatalf("expected 2 quads, got %v", quads)
+	}
+	for _, q := range quads {
+		if _, ok := q.O.(IRI); !ok || q.P.Value != rdfTypeIRI {
+			t.Fatalf("expected rdf:type IRI objects, got %v", quads)
+		}
+	}
+	if _, ok := input["@context"].(map[string]interface{})[rdfTypeIRI]; !ok {
+		t.Fatal("input document was modified")
+	}
+}
+
+func TestJSONLDBlankNodeIDs(t *testing.T) {
+	triples := []Triple{
+		{S: BlankNode{ID: "x"}, P: IRI{Value: "http://example.org/p"}, O: BlankNode{ID: "y"}},
+		{S: BlankNode{ID: "y"}, P: IRI{Value: "http://example.org/p"}, O: BlankNode{ID: "x"}},
+	}
+	write := func(ids JSONLDBlankNodeIDs) string {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, FormatJSONLD, OptJSONLDBlankNodeIDs(ids))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, triple := range triples {
+			if err := w.Write(triple.ToStatement()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return buf.String()
+	}
+	if out := write(JSONLDBlankNodesKeep); !strings.Contains(out, `{"@id":"_:x","http://example.org/p":{"@id":"_:y"}}`) {
+		t.Fatalf("labels not kept: %s", out)
+	}
+	if out := write(JSONLDBlankNodesCounter); out != `{"@graph":[{"@id":"_:b0","http://example.org/p":{"@id":"_:b1"}},{"@id":"_:b1","http://example.org/p":{"@id":"_:b0"}}]}` {
+		t.Fatalf("unexpected counter labels: %s", out)
+	}
+	first, second := write(JSONLDBlankNodesUUID), write(JSONLDBlankNodesUUID)
+	if first == second || strings.Contains(first, "_:x") || strings.Count(first, `"_:`) != 4 {
+		t.Fatalf("unexpected UUID labels: %s and %s", first, second)
+	}
+}
+
+func TestJSONLDBlankNodeExpansion(t *testing.T) {
+	input := `{"@context":{"@vocab":"http://example.org/","b":"_:"},"@id":"b:s","@type":"b:T","p":{"@id":"b:o"}}`
+	r, err := NewReader(strings.NewReader(input), FormatJSONLD)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer r.Close()
+	var got []string
+	for {
+		stmt, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := stmt.S.(BlankNode); !ok {
+			t.Fatalf("subject %#v is not a blank node", stmt.S)
+		}
+		if _, ok := stmt.O.(BlankNode); !ok {
+			t.Fatalf("object %#v is not a blank node", stmt.O)
+		}
+		got = append(got, stmt.S.String()+" "+stmt.O.String())
+	}
+	if strings.Join(got, ", ") != "_:s _:o, _:s _:T" {
+		t.Fatalf("unexpected statements %q", got)
+	}
+}
+
+func TestJSONLDToRDFDirection(t *testing.T) {
+	input := map[string]interface{}{
+		"@id": "http://example.com/a",
+		"http://example.com/label": map[string]interface{}{
+			"@value": "hello", "@language": "en-US", "@direction": "rtl",
+		},
+	}
+	tests := []struct {
+		direction string
+		quads     int
+		datatype  string
+	}{
+		{"", 1, ""},
+		{"i18n-datatype", 1, "https://www.w3.org/ns/i18n#en-us_rtl"},
+		{"compound-literal", 4, ""},
+	}
+	for _, tt := range tests {
+		quads, err := NewJSONLDProcessor().ToRDF(context.Background(), input, JSONLDOptions{RdfDirection: tt.direction})
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.direction, err)
+		}
+		if len(quads) != tt.quads {
+			t.Fatalf("%q: expected %d quads, got %v", tt.direction, tt.quads, quads)
+		}
+		if lit, ok := quads[0].O.(Literal); tt.datatype != "" && (!ok || lit.Datatype.Value != tt.datatype) {
+			t.Errorf("%q: expected datatype %s, got %v", tt.direction, tt.datatype, quads[0].O)
+		}
+	}
+}
+
+func TestJSONLDToRDFGeneralized(t *testing.T) {
+	input := map[string]interface{}{
+		"@context": map[string]interface{}{"term": "_:term"},
+		"@id":      "http://example.com/a",
+		"term":     "value",
+	}
+	quads, err := NewJSONLDProcessor().ToRDF(context.Background(), input, JSONLDOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(quads) != 0 {
+		t.Fatalf("expected blank node predicates to be dropped, got %v", quads)
+	}
+	quads, err = NewJSONLDProcessor().ToRDF(context.Background(), input, JSONLDOptions{ProduceGeneralizedRdf: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(quads) != 1 || !strings.HasPrefix(quads[0].P.Value, "_:") {
+		t.Fatalf("expected a blank node predicate, got %v", quads)
+	}
+}
+
+func TestJSONLDToRDFErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		mode  string
+		code  string
+	}{
+		{"list of lists", `{"@context": {"foo": {"@id": "http://example.com/foo", "@container": "@list"}}, "foo": [["bar"]]}`, "json-ld-1.0", "list of lists"},
+		{"invalid @included", `{"@context": {"@vocab": "http://example.com/"}, "@included": "string"}`, "", "invalid @included value"},
+		{"colliding keywords", `{"@context": {"id": "@id"}, "@id": "http://example.com/a", "id": "http://example.com/b"}`, "", "colliding keywords"},
+	}
+	for _, tt := range tests {
+		var input interface{}
+		if err := json.Unmarshal([]byte(tt.input), &input); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		_, err := NewJSONLDProcessor().ToRDF(context.Background(), input, JSONLDOptions{ProcessingMode: tt.mode})
+		var jerr *JSONLDError
+		if !errors.As(err, &jerr) || jerr.Code != tt.code {
+			t.Errorf("%s: expected %q error, got %v", tt.name, tt.code, err)
+		}
+	}
+	// Lists of lists are allowed in JSON-LD 1.1.
+	var input interface{}
+	json.Unmarshal([]byte(tests[0].input), &input)
+	quads, err := NewJSONLDProcessor().ToRDF(context.Background(), input, JSONLDOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(quads) != 5 {
+		t.Fatalf("expected a nested list, got %v", quads)
+	}
+}
+
+func TestJSONLDFromRDF(t *testing.T) {
+	input := `<http://example.com/s> <http://example.com/n> "1"^^<http://www.w3.org/2001/XMLSchema#integer> <http://example.com/g> .
+<http://example.com/s> <http://example.com/list> _:l1 .
+_:l1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "a" .
+_:l1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> _:l2 .
+_:l2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "b" .
+_:l2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .
+<http://example.com/s> <http://example.com/label> "hello"^^<https://www.w3.org/ns/i18n#en_rtl> .
+`
+	var quads []Quad
+	err := Parse(context.Background(), strings.NewReader(input), FormatNQuads, func(s Statement) error {
+		quads = append(quads, s.AsQuad())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output, err := NewJSONLDProcessor().FromRDF(context.Background(), quads, JSONLDOptions{UseNativeTypes: true, RdfDirection: "i18n-datatype"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `[{"@graph":[{"@id":"http://example.com/s","http://example.com/n":[{"@value":1}]}],"@id":"http://example.com/g"},` +
+		`{"@id":"http://example.com/s","http://example.com/label":[{"@direction":"rtl","@language":"en","@value":"hello"}],` +
+		`"http://example.com/list":[{"@list":[{"@value":"a"},{"@value":"b"}]}]}]`
+	if string(data) != expected {
+		t.Fatalf("unexpected output:\n%s", data)
+	}
+
+	bad := []Quad{{S: IRI{Value: "http://example.com/s"}, P: IRI{Value: "http://example.com/p"}, O: Literal{Lexical: "{", Datatype: IRI{Value: rdfJSONIRI}}}}
+	_, err = NewJSONLDProcessor().FromRDF(context.Background(), bad, JSONLDOptions{})
+	var jerr *JSONLDError
+	if !errors.As(err, &jerr) || jerr.Code != "invalid JSON literal" {
+		t.Fatalf("expected invalid JSON literal error, got %v", err)
+	}
+}
+
+func TestJSONLDWriterCompact(t *testing.T) {
+	input := `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:alice a foaf:Person ; foaf:name "Alice" ; foaf:knows ex:bob .
+ex:g { ex:bob foaf:name "Bob"@en }`
+	reader, err := NewReader(strings.NewReader(input), FormatTriG)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := collectStatements(reader)
+	reader.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	jsonldContext := map[string]interface{}{
+		"ex":    "http://example.org/",
+		"foaf":  "http://xmlns.com/foaf/0.1/",
+		"name":  "foaf:name",
+		"knows": map[string]interface{}{"@id": "foaf:knows", "@type": "@id"},
+	}
+	for _, ctx := range []interface{}{jsonldContext, map[string]interface{}{"@context": jsonldContext}} {
+		output := writeStatements(t, FormatJSONLD, want, OptJSONLDCompact(ctx))
+		for _, fragment := range []string{`"@id": "ex:alice"`, `"@type": "foaf:Person"`, `"name": "Alice"`, `"knows": "ex:bob"`} {
+			if !strings.Contains(output, fragment) {
+				t.Errorf("expected %s in compacted output:\n%s", fragment, output)
+			}
+		}
+		// The processor keeps the named graph that NewReader drops.
+		var doc interface{}
+		if err := json.Unmarshal([]byte(output), &doc); err != nil {
+			t.Fatalf("invalid JSON %s: %v", output, err)
+		}
+		got, err := NewJSONLDProcessor().ToRDF(context.Background(), doc, JSONLDOptions{})
+		if err != nil {
+			t.Fatalf("cannot read back %s: %v", output, err)
+		}
+		if !isomorphicQuads(statementsToQuads(want), got) {
+			t.Fatalf("roundtrip changed the statements:\n%s", output)
+		}
+	}
+}
+
+func TestJSONLDWriterCompactInvalidContext(t *testing.T) {
+	writer, err := NewWriter(&bytes.Buffer{}, FormatJSONLD, OptJSONLDCompact(map[string]interface{}{"@version": 2}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Write(NewTriple(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "o"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Close(); err == nil {
+		t.Fatal("expected compaction error for invalid context")
+	}
+}
+
+func TestJSONLDWriterFlatten(t *testing.T) {
+	ex := func(name string) IRI { return IRI{Value: "http://example.org/" + name} }
+	stmts := []Statement{
+		NewTriple(ex("b"), ex("p"), Literal{Lexical: "1"}),
+		NewTriple(ex("a"), ex("p"), BlankNode{ID: "x"}),
+		NewTriple(BlankNode{ID: "x"}, ex("q"), Literal{Lexical: "2"}),
+		NewTriple(ex("b"), ex("q"), ex("a")),
+		NewQuad(ex("a"), ex("p"), Literal{Lexical: "3"}, ex("g")),
+	}
+	output := writeStatements(t, FormatJSONLD, stmts, OptJSONLDFlatten())
+	if again := writeStatements(t, FormatJSONLD, stmts, OptJSONLDFlatten()); again != output {
+		t.Fatalf("flattened output is not deterministic:\n%s\n%s", output, again)
+	}
+	var nodes []map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &nodes); err != nil {
+		t.Fatalf("expected a flattened node array: %v\n%s", err, output)
+	}
+	var ids []string
+	for _, node := range nodes {
+		id, _ := node["@id"].(string)
+		ids = append(ids, id)
+	}
+	want := []string{"_:b0", "http://example.org/a", "http://example.org/b", "http://example.org/g"}
+	if strings.Join(ids, " ") != strings.Join(want, " ") {
+		t.Fatalf("expected nodes %v, got %v:\n%s", want, ids, output)
+	}
+
+	output = writeStatements(t, FormatJSONLD, stmts, OptJSONLDFlatten(), OptJSONLDCompact(map[string]interface{}{"ex": "http://example.org/"}))
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("expected a compacted document: %v\n%s", err, output)
+	}
+	if graph, ok := doc["@graph"].([]interface{}); !ok || len(graph) != len(want) {
+		t.Fatalf("expected %d nodes in @graph:\n%s", len(want), output)
+	}
+	if !strings.Contains(output, `"@id": "ex:a"`) {
+		t.Fatalf("expected compact IRIs:\n%s", output)
+	}
+}
+
+func TestJSONLDWriterContext(t *testing.T) {
+	input := `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:alice a foaf:Person ; foaf:name "Alice", "Alicia"@es ; foaf:knows ex:bob ; ex:age "30"^^xsd:integer .
+ex:g { ex:bob foaf:name "Bob" . ex:carol foaf:knows _:x . }
+ex:bob foaf:mbox <mailto:bob@example.org> .`
+	reader, err := NewReader(strings.NewReader(input), FormatTriG)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := collectStatements(reader)
+	reader.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	jsonldContext := map[string]interface{}{
+		"@vocab": "http://example.org/",
+		"foaf":   "http://xmlns.com/foaf/0.1/",
+		"xsd":    "http://www.w3.org/2001/XMLSchema#",
+		"name":   "foaf:name",
+		"knows":  map[string]interface{}{"@id": "foaf:knows", "@type": "@id"},
+	}
+	output := writeStatements(t, FormatJSONLD, want, OptJSONLDContext(map[string]interface{}{"@context": jsonldContext}))
+	expected := `{"@context":{"@vocab":"http://example.org/","foaf":"http://xmlns.com/foaf/0.1/","knows":{"@id":"foaf:knows","@type":"@id"},"name":"foaf:name","xsd":"http://www.w3.org/2001/XMLSchema#"},"@graph":[
+{"@id":"http://example.org/alice","@type":"foaf:Person","name":["Alice",{"@language":"es","@value":"Alicia"}],"foaf:knows":{"@id":"http://example.org/bob"},"age":{"@type":"xsd:integer","@value":"30"}},
+{"@id":"http://example.org/g","@graph":[
+{"@id":"http://example.org/bob","name":"Bob"},
+{"@id":"http://example.org/carol","foaf:knows":{"@id":"_:x"}}
+]},
+{"@id":"http://example.org/bob","foaf:mbox":{"@id":"mailto:bob@example.org"}}
+]}
+`
+	if output != expected {
+		t.Fatalf("got:\n%s\nwant:\n%s", output, expected)
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("invalid JSON %s: %v", output, err)
+	}
+	got, err := NewJSONLDProcessor().ToRDF(context.Background(), doc, JSONLDOptions{})
+	if err != nil {
+		t.Fatalf("cannot read back %s: %v", output, err)
+	}
+	if !isomorphicQuads(statementsToQuads(want), got) {
+		t.Fatalf("roundtrip changed the statements:\n%s", output)
+	}
+}
+
+func TestJSONLDWriterStreaming(t *testing.T) {
+	ex := func(name string) IRI { return IRI{Value: "http://example.org/" + name} }
+	stmts := []Statement{
+		NewTriple(ex("a"), ex("p"), Literal{Lexical: "1", Datatype: IRI{Value: xsdNamespace + "integer"}}),
+		NewTriple(ex("a"), IRI{Value: rdfTypeIRI}, ex("T")),
+		NewTriple(ex("a"), ex("p"), Literal{Lexical: "x", Lang: "en"}),
+		NewQuad(ex("b"), ex("q"), BlankNode{ID: "n"}, ex("g")),
+		NewQuad(BlankNode{ID: "n"}, ex("q"), ex("a"), ex("g")),
+		NewTriple(ex("b"), ex("q"), Literal{Lexical: "y"}),
+	}
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, FormatJSONLD, OptJSONLDStreaming())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, stmt := range stmts {
+		if err := writer.Write(stmt); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if i == 3 {
+			// The node object of ex:a is complete once ex:b starts.
+			if err := writer.Flush(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(buf.String(), `"@id":"http://example.org/a"`) {
+				t.Fatalf("expected the first node object to be written, got %q", buf.String())
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := buf.String()
+	expected := `[
+{"@id":"http://example.org/a","@type":["http://example.org/T"],"http://example.org/p":[{"@type":"http://www.w3.org/2001/XMLSchema#integer","@value":"1"},{"@language":"en","@value":"x"}]},
+{"@id":"http://example.org/g","@graph":[
+{"@id":"http://example.org/b","http://example.org/q":[{"@id":"_:n"}]},
+{"@id":"_:n","http://example.org/q":[{"@id":"http://example.org/a"}]}
+]},
+{"@id":"http://example.org/b","http://example.org/q":[{"@value":"y"}]}
+]
+`
+	if output != expected {
+		t.Fatalf("got:\n%s\nwant:\n%s", output, expected)
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("invalid JSON %s: %v", output, err)
+	}
+	got, err := NewJSONLDProcessor().ToRDF(context.Background(), doc, JSONLDOptions{})
+	if err != nil {
+		t.Fatalf("cannot read back %s: %v", output, err)
+	}
+	if !isomorphicQuads(statementsToQuads(stmts), got) {
+		t.Fatalf("roundtrip changed the statements:\n%s", output)
+	}
+}
@@ -0,0 +1,466 @@
+package rdf
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	rdfJSONIRI      = "http://www.w3.org/1999/02/22-rdf-syntax-ns#JSON"
+	rdfValueIRI     = "http://www.w3.org/1999/02/22-rdf-syntax-ns#value"
+	rdfLanguageIRI  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#language"
+	rdfDirectionIRI = "http://www.w3.org/1999/02/22-rdf-syntax-ns#direction"
+	i18nNamespace   = "https://www.w3.org/ns/i18n#"
+)
+
+// jsonldToRDF converts a JSON-LD document to quads: it expands the document,
+// generates its node map and deserializes the node map as the toRdf()
+// method of the JSON-LD 1.1 API does.
+func (p *jsonldProcessor) jsonldToRDF(input interface{}) ([]Quad, error) {
+	expanded, err := p.expandDocument(input)
+	if err != nil {
+		return nil, err
+	}
+	nodeMap := map[string]map[string]map[string]interface{}{"@default": {}}
+	if err := p.generateNodeMap(expanded, nodeMap, "@default", nil, "", nil); err != nil {
+		return nil, err
+	}
+	// Distinct values in the node map can still map to the same literal,
+	// for example true and "true"^^xsd:boolean, so quads are deduplicated.
+	var quads []Quad
+	seen := map[Quad]struct{}{}
+	emit := func(s Term, pred string, o Term, g Term) error {
+		q := Quad{S: s, P: IRI{Value: pred}, O: o, G: g}
+		if _, ok := seen[q]; ok {
+			return nil
+		}
+		if p.opts.MaxQuads > 0 && len(quads) >= p.opts.MaxQuads {
+			return ErrTripleLimitExceeded
+		}
+		seen[q] = struct{}{}
+		quads = append(quads, q)
+		return nil
+	}
+	for _, graphName := range sortedNodeMapKeys(nodeMap) {
+		var graph Term
+		if graphName != "@default" {
+			if graph = jsonldNodeTerm(graphName); graph == nil {
+				continue
+			}
+		}
+		nodes := nodeMap[graphName]
+		for _, subject := range sortedNodeKeys(nodes) {
+			s := jsonldNodeTerm(subject)
+			if s == nil {
+				continue
+			}
+			node := nodes[subject]
+			for _, property := range sortedKeys(node) {
+				if err := p.ctx.Err(); err != nil {
+					return nil, err
+				}
+				if property == "@type" {
+					for _, typ := range jsonldArray(node[property]) {
+						if o := jsonldNodeTerm(typ.(string)); o != nil {
+							if err := emit(s, rdfTypeIRI, o, graph); err != nil {
+								return nil, err
+							}
+						}
+					}
+					continue
+				}
+				if jsonldIsKeyword(property) {
+					continue
+				}
+				if strings.HasPrefix(property, "_:") {
+					if !p.opts.ProduceGeneralizedRdf {
+						continue
+					}
+				} else if !jsonldWellFormedIRI(property) {
+					continue
+				}
+				for _, item := range jsonldArray(node[property]) {
+					var triples []Quad
+					o := p.objectToRDF(item, &triples)
+					if o != nil {
+						if err := emit(s, property, o, graph); err != nil {
+							return nil, err
+						}
+					}
+					for _, t := range triples {
+						if err := emit(t.S, t.P.Value, t.O, graph); err != nil {
+							return nil, err
+						}
+					}
+				}
+			}
+		}
+	}
+	return quads, nil
+}
+
+// generateNodeMap implements the Node Map Generation algorithm. An empty
+// activeProperty is the null active property; activeSubject is a string,
+// a map (for reverse properties) or nil.
+func (p *jsonldProcessor) generateNodeMap(element interface{}, nodeMap map[string]map[string]map[string]interface{}, activeGraph string, activeSubject interface{}, activeProperty string, list map[string]interface{}) error {
+	if arr, ok := element.([]interface{}); ok {
+		for _, item := range arr {
+			if err := p.generateNodeMap(item, nodeMap, activeGraph, activeSubject, activeProperty, list); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	obj, ok := element.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	graph := nodeMap[activeGraph]
+	if graph == nil {
+		graph = map[string]map[string]interface{}{}
+		nodeMap[activeGraph] = graph
+	}
+	var subjectNode map[string]interface{}
+	if id, ok := activeSubject.(string); ok {
+		subjectNode = graph[id]
+	}
+	if _, isValue := obj["@value"]; !isValue && obj["@type"] != nil {
+		types := obj["@type"]
+		relabeled := []interface{}{}
+		for _, typ := range jsonldArray(types) {
+			if s, ok := typ.(string); ok && strings.HasPrefix(s, "_:") {
+				typ = p.blankNode(s)
+			}
+			relabeled = append(relabeled, typ)
+		}
+		obj["@type"] = relabeled
+	}
+
+	if _, ok := obj["@value"]; ok {
+		if list == nil {
+			jsonldAddUniqueValue(subjectNode, activeProperty, obj)
+		} else {
+			list["@list"] = append(list["@list"].([]interface{}), obj)
+		}
+		return nil
+	}
+	if items, ok := obj["@list"]; ok {
+		result := map[string]interface{}{"@list": []interface{}{}}
+		if err := p.generateNodeMap(items, nodeMap, activeGraph, activeSubject, activeProperty, result); err != nil {
+			return err
+		}
+		if list == nil {
+			if subjectNode != nil {
+				subjectNode[activeProperty] = append(jsonldArrayOrEmpty(subjectNode[activeProperty]), result)
+			}
+		} else {
+			list["@list"] = append(list["@list"].([]interface{}), result)
+		}
+		return nil
+	}
+
+	// An @id that expanded to null keeps the node out of the output rather
+	// than turning it into a blank node.
+	var id string
+	if raw, ok := obj["@id"]; ok {
+		id, _ = raw.(string)
+		if strings.HasPrefix(id, "_:") {
+			id = p.blankNode(id)
+		}
+	} else {
+		id = p.blankNode("")
+	}
+	delete(obj, "@id")
+	node := graph[id]
+	if node == nil {
+		node = map[string]interface{}{"@id": id}
+		graph[id] = node
+	}
+	switch subject := activeSubject.(type) {
+	case map[string]interface{}:
+		jsonldAddUniqueValue(node, activeProperty, subject)
+	default:
+		if activeProperty != "" {
+			reference := map[string]interface{}{"@id": id}
+			if list == nil {
+				jsonldAddUniqueValue(subjectNode, activeProperty, reference)
+			} else {
+				list["@list"] = append(list["@list"].([]interface{}), reference)
+			}
+		}
+	}
+	if types, ok := obj["@type"]; ok {
+		for _, typ := range jsonldArray(types) {
+			jsonldAddUniqueValue(node, "@type", typ)
+		}
+		delete(obj, "@type")
+	}
+	if index, ok := obj["@index"]; ok {
+		if existing, ok := node["@index"]; ok && existing != index {
+			return jsonldError("conflicting indexes", "%v and %v for %s", existing, index, id)
+		}
+		node["@index"] = index
+		delete(obj, "@index")
+	}
+	if reverse, ok := obj["@reverse"].(map[string]interface{}); ok {
+		referenced := map[string]interface{}{"@id": id}
+		for _, property := range sortedKeys(reverse) {
+			for _, value := range jsonldArray(reverse[property]) {
+				if err := p.generateNodeMap(value, nodeMap, activeGraph, referenced, property, nil); err != nil {
+					return err
+				}
+			}
+		}
+		delete(obj, "@reverse")
+	}
+	if graphValue, ok := obj["@graph"]; ok {
+		if err := p.generateNodeMap(graphValue, nodeMap, id, nil, "", nil); err != nil {
+			return err
+		}
+		delete(obj, "@graph")
+	}
+	if included, ok := obj["@included"]; ok {
+		if err := p.generateNodeMap(included, nodeMap, activeGraph, nil, "", nil); err != nil {
+			return err
+		}
+		delete(obj, "@included")
+	}
+	for _, property := range sortedKeys(obj) {
+		value := obj[property]
+		if strings.HasPrefix(property, "_:") {
+			property = p.blankNode(property)
+		}
+		if _, ok := node[property]; !ok {
+			node[property] = []interface{}{}
+		}
+		if err := p.generateNodeMap(value, nodeMap, activeGraph, id, property, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectToRDF implements the Object to RDF Conversion algorithm. Triples
+// describing lists and compound literals are appended to triples.
+func (p *jsonldProcessor) objectToRDF(item interface{}, triples *[]Quad) Term {
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if list, ok := obj["@list"]; ok {
+		return p.listToRDF(jsonldArray(list), triples)
+	}
+	value, ok := obj["@value"]
+	if !ok {
+		id, _ := obj["@id"].(string)
+		return jsonldNodeTerm(id)
+	}
+	datatype, _ := obj["@type"].(string)
+	if datatype != "" && datatype != "@json" && !jsonldWellFormedIRI(datatype) {
+		return nil
+	}
+	language, hasLanguage := obj["@language"].(string)
+	if hasLanguage && !jsonldWellFormedLanguage(language) {
+		return nil
+	}
+	var lexical string
+	switch v := value.(type) {
+	case bool:
+		lexical = strconv.FormatBool(v)
+		if datatype == "" {
+			datatype = xsdNamespace + "boolean"
+		}
+	case float64, json.Number:
+		text := jsonldNumberText(v)
+		if integer, ok := jsonldIntegerLexical(text); ok && datatype != xsdNamespace+"double" && datatype != "@json" {
+			lexical = integer
+			if datatype == "" {
+				datatype = xsdNamespace + "integer"
+			}
+		} else if datatype != "@json" {
+			f, _ := strconv.ParseFloat(text, 64)
+			lexical = canonicalXSDDouble(f)
+			if datatype == "" {
+				datatype = xsdNamespace + "double"
+			}
+		}
+	case string:
+		lexical = v
+	}
+	if datatype == "@json" {
+		canonical, err := canonicalizeJSONLiteralValue(value)
+		if err != nil {
+			return nil
+		}
+		lexical, datatype = canonical, rdfJSONIRI
+	}
+	direction, _ := obj["@direction"].(string)
+	if direction != "" {
+		switch p.opts.RdfDirection {
+		case "i18n-datatype":
+			return Literal{Lexical: lexical, Datatype: IRI{Value: i18nNamespace + strings.ToLower(language) + "_" + direction}}
+		case "compound-literal":
+			node := BlankNode{ID: strings.TrimPrefix(p.blankNode(""), "_:")}
+			*triples = append(*triples, Quad{S: node, P: IRI{Value: rdfValueIRI}, O: Literal{Lexical: lexical}})
+			if hasLanguage {
+				*triples = append(*triples, Quad{S: node, P: IRI{Value: rdfLanguageIRI}, O: Literal{Lexical: strings.ToLower(language)}})
+			}
+			*triples = append(*triples, Quad{S: node, P: IRI{Value: rdfDirectionIRI}, O: Literal{Lexical: direction}})
+			return node
+		}
+	}
+	if hasLanguage {
+		return Literal{Lexical: lexical, Lang: language}
+	}
+	if datatype == xsdNamespace+"string" {
+		datatype = ""
+	}
+	return Literal{Lexical: lexical, Datatype: IRI{Value: datatype}}
+}
+
+// listToRDF implements the List to RDF Conversion algorithm.
+func (p *jsonldProcessor) listToRDF(list []interface{}, triples *[]Quad) Term {
+	if len(list) == 0 {
+		return IRI{Value: rdfNilIRI}
+	}
+	nodes := make([]BlankNode, len(list))
+	for i := range list {
+		nodes[i] = BlankNode{ID: strings.TrimPrefix(p.blankNode(""), "_:")}
+	}
+	for i, item := range list {
+		var embedded []Quad
+		if o := p.objectToRDF(item, &embedded); o != nil {
+			*triples = append(*triples, Quad{S: nodes[i], P: IRI{Value: rdfFirstIRI}, O: o})
+		}
+		var rest Term = IRI{Value: rdfNilIRI}
+		if i+1 < len(nodes) {
+			rest = nodes[i+1]
+		}
+		*triples = append(*triples, Quad{S: nodes[i], P: IRI{Value: rdfRestIRI}, O: rest})
+		*triples = append(*triples, embedded...)
+	}
+	return nodes[0]
+}
+
+// jsonldNodeTerm returns the term for a node identifier, or nil if it is
+// neither a blank node identifier nor a well-formed IRI.
+func jsonldNodeTerm(id string) Term {
+	if strings.HasPrefix(id, "_:") {
+		return BlankNode{ID: id[2:]}
+	}
+	if !jsonldWellFormedIRI(id) {
+		return nil
+	}
+	return IRI{Value: id}
+}
+
+// jsonldWellFormedIRI reports whether s is an absolute IRI that can be
+// written in N-Quads.
+func jsonldWellFormedIRI(s string) bool {
+	if !hasIRIScheme(s) || strings.Count(s, "#") > 1 {
+		return false
+	}
+	for _, r := range s {
+		if r <= 0x20 || strings.ContainsRune("<>\"{}|\\^`", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonldWellFormedLanguage reports whether s is a well-formed BCP 47
+// language tag.
+func jsonldWellFormedLanguage(s string) bool {
+	for i, part := range strings.Split(s, "-") {
+		if len(part) == 0 || len(part) > 8 {
+			return false
+		}
+		for j := 0; j < len(part); j++ {
+			c := part[j]
+			if !isASCIILetter(c) && (i == 0 || !isDigit(c)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func jsonldNumberText(v interface{}) string {
+	if n, ok := v.(json.Number); ok {
+		return string(n)
+	}
+	return strconv.FormatFloat(v.(float64), 'g', -1, 64)
+}
+
+// jsonldAddUniqueValue adds value to the array of key in obj unless an
+// equal value is present.
+func jsonldAddUniqueValue(obj map[string]interface{}, key string, value interface{}) {
+	if obj == nil {
+		return
+	}
+	existing := jsonldArrayOrEmpty(obj[key])
+	for _, item := range existing {
+		if jsonldEqual(item, value) {
+			obj[key] = existing
+			return
+		}
+	}
+	obj[key] = append(existing, value)
+}
+
+// jsonldEqual compares two JSON values.
+func jsonldEqual(a, b interface{}) bool {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !jsonldEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonldEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case float64, json.Number:
+		switch b.(type) {
+		case float64, json.Number:
+			return jsonldNumberText(a) == jsonldNumberText(b)
+		}
+		return false
+	default:
+		return a == b
+	}
+}
+
+func sortedNodeMapKeys(m map[string]map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedNodeKeys(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,167 @@
+package rdf
+
+// Limits bounds the resources a reader spends on its input, for parsing
+// untrusted documents. Every reader enforces every limit in the same way,
+// failing with an error whose Code reports the limit exceeded:
+//
+//   - MaxStatementSize (ErrCodeStatementTooLong) bounds the bytes of a
+//     Turtle or TriG statement, a top-level RDF/XML node element or one of
+//     its property elements, and a top-level JSON-LD value or @graph item;
+//     N-Triples and N-Quads lines are bounded by OptMaxLineBytes,
+//   - MaxLiteralLength (ErrCodeLiteralTooLong) bounds the bytes of the
+//     lexical form of a literal,
+//   - MaxIRILength (ErrCodeIRITooLong) bounds the bytes of an IRI,
+//     including datatype IRIs,
+//   - MaxBlankNodes (ErrCodeBlankNodeLimitExceeded) bounds the number of
+//     distinct blank nodes in the document,
+//   - MaxTriples (ErrCodeTripleLimitExceeded) bounds the number of
+//     statements read,
+//   - MaxNestingDepth (ErrCodeDepthExceeded) bounds the nesting of Turtle
+//     and TriG collections, blank node property lists and triple terms, of
+//     RDF/XML elements and of JSON-LD objects and arrays,
+//   - MaxPrefixCount (ErrCodePrefixLimitExceeded) bounds the number of
+//     Turtle and TriG prefix directives, RDF/XML namespace declarations
+//     and JSON-LD context term definitions.
+//
+// A zero field keeps the limit set by other options or its default; a
+// negative field disables the limit. MaxStatementSize, MaxTriples and
+// MaxNestingDepth default to DefaultMaxStatementBytes, DefaultMaxTriples
+// and DefaultMaxDepth, while the other limits are disabled by default.
+// OptSafeLimits sets all of them for untrusted input.
+type Limits struct {
+	MaxStatementSize int
+	MaxLiteralLength int
+	MaxIRILength     int
+	MaxBlankNodes    int
+	MaxTriples       int64
+	MaxNestingDepth  int
+	MaxPrefixCount   int
+}
+
+// OptLimits sets the non-zero limits of l for readers of every format.
+func OptLimits(l Limits) Option {
+	return func(opts *Options) {
+		l.apply(opts)
+	}
+}
+
+// OptFormatLimits sets the non-zero limits of l for readers of format only,
+// over those set by OptLimits and the other limit options. format is one
+// of the Format constants, such as FormatJSONLD.
+func OptFormatLimits(format Format, l Limits) Option {
+	return func(opts *Options) {
+		if opts.FormatLimits == nil {
+			opts.FormatLimits = make(map[Format]Limits)
+		}
+		opts.FormatLimits[format] = l
+	}
+}
+
+func (l Limits) apply(opts *Options) {
+	if l.MaxStatementSize != 0 {
+		opts.MaxStatementBytes = l.MaxStatementSize
+	}
+	if l.MaxLiteralLength != 0 {
+		opts.MaxLiteralLength = l.MaxLiteralLength
+	}
+	if l.MaxIRILength != 0 {
+		opts.MaxIRILength = l.MaxIRILength
+	}
+	if l.MaxBlankNodes != 0 {
+		opts.MaxBlankNodes = l.MaxBlankNodes
+	}
+	if l.MaxTriples != 0 {
+		opts.MaxTriples = l.MaxTriples
+	}
+	if l.MaxNestingDepth != 0 {
+		opts.MaxDepth = l.MaxNestingDepth
+	}
+	if l.MaxPrefixCount != 0 {
+		opts.MaxPrefixCount = l.MaxPrefixCount
+	}
+}
+
+// statementLimits enforces the limits that apply to the statements a
+// reader returns, whatever their format.
+type statementLimits struct {
+	maxLiteral    int
+	maxIRI        int
+	maxBlankNodes int
+	maxTriples    int64
+	blankNodes    map[string]struct{} // Labels seen, when maxBlankNodes is set
+}
+
+func newStatementLimits(opts decodeOptions) *statementLimits {
+	l := &statementLimits{
+		maxLiteral:    opts.MaxLiteralLength,
+		maxIRI:        opts.MaxIRILength,
+		maxBlankNodes: opts.MaxBlankNodes,
+		maxTriples:    opts.MaxTriples,
+	}
+	if l.maxLiteral <= 0 && l.maxIRI <= 0 && l.maxBlankNodes <= 0 && l.maxTriples <= 0 {
+		return nil
+	}
+	if l.maxBlankNodes > 0 {
+		l.blankNodes = make(map[string]struct{})
+	}
+	return l
+}
+
+// check returns an error if s, the statement after count others, exceeds a
+// limit.
+func (l *statementLimits) check(s *Statement, count int64) error {
+	if l.maxTriples > 0 && count >= l.maxTriples {
+		return ErrTripleLimitExceeded
+	}
+	if err := l.checkIRI(s.P); err != nil {
+		return err
+	}
+	if err := l.checkTerm(s.S); err != nil {
+		return err
+	}
+	if err := l.checkTerm(s.O); err != nil {
+		return err
+	}
+	if s.G == nil {
+		return nil
+	}
+	return l.checkTerm(s.G)
+}
+
+func (l *statementLimits) checkTerm(term Term) error {
+	switch t := term.(type) {
+	case IRI:
+		return l.checkIRI(t)
+	case Literal:
+		if l.maxLiteral > 0 && len(t.Lexical) > l.maxLiteral {
+			return ErrLiteralTooLong
+		}
+		return l.checkIRI(t.Datatype)
+	case BlankNode:
+		if l.blankNodes == nil {
+			return nil
+		}
+		if _, ok := l.blankNodes[t.ID]; !ok {
+			if len(l.blankNodes) >= l.maxBlankNodes {
+				return ErrBlankNodeLimitExceeded
+			}
+			l.blankNodes[t.ID] = struct{}{}
+		}
+	case TripleTerm:
+		if err := l.checkIRI(t.P); err != nil {
+			return err
+		}
+		if err := l.checkTerm(t.S); err != nil {
+			return err
+		}
+		return l.checkTerm(t.O)
+	}
+	return nil
+}
+
+func (l *statementLimits) checkIRI(iri IRI) error {
+	if l.maxIRI > 0 && len(iri.Value) > l.maxIRI {
+		return ErrIRITooLong
+	}
+	return nil
+}
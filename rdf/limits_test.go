@@ -0,0 +1,115 @@
+package rdf
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readAllStatements reads r to its end, returning the first error.
+func readAllStatements(r Reader) error {
+	defer r.Close()
+	for {
+		if _, err := r.Next(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func TestOptLimits(t *testing.T) {
+	const rdfxmlHead = `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">`
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		limits Limits
+		code   ErrorCode
+	}{
+		{"turtle literal", FormatTurtle, `<http://example.org/s> <http://example.org/p> "long literal" .`,
+			Limits{MaxLiteralLength: 5}, ErrCodeLiteralTooLong},
+		{"ntriples literal", FormatNTriples, `<http://example.org/s> <http://example.org/p> "long literal" .` + "\n",
+			Limits{MaxLiteralLength: 5}, ErrCodeLiteralTooLong},
+		{"jsonld literal", FormatJSONLD, `{"@id": "http://example.org/s", "http://example.org/p": "long literal"}`,
+			Limits{MaxLiteralLength: 5}, ErrCodeLiteralTooLong},
+		{"trig datatype IRI", FormatTriG, `<http://example.org/g> { <http://example.org/s> <http://example.org/p> "1"^^<http://example.org/a-long-datatype> . }`,
+			Limits{MaxIRILength: 30}, ErrCodeIRITooLong},
+		{"rdfxml IRI", FormatRDFXML, rdfxmlHead + `<rdf:Description rdf:about="http://example.org/a-long-subject"><ex:p>1</ex:p></rdf:Description></rdf:RDF>`,
+			Limits{MaxIRILength: 30}, ErrCodeIRITooLong},
+		{"turtle blank nodes", FormatTurtle, `_:a <http://example.org/p> _:b . _:a <http://example.org/p> _:c .`,
+			Limits{MaxBlankNodes: 2}, ErrCodeBlankNodeLimitExceeded},
+		{"rdfxml triples", FormatRDFXML, rdfxmlHead + `<rdf:Description rdf:about="http://example.org/s"><ex:p>1</ex:p><ex:p>2</ex:p></rdf:Description></rdf:RDF>`,
+			Limits{MaxTriples: 1}, ErrCodeTripleLimitExceeded},
+		{"jsonld triples", FormatJSONLD, `{"@id": "http://example.org/s", "http://example.org/p": ["1", "2"]}`,
+			Limits{MaxTriples: 1}, ErrCodeTripleLimitExceeded},
+		{"turtle depth", FormatTurtle, `<http://example.org/s> <http://example.org/p> [ <http://example.org/p> [ <http://example.org/p> 1 ] ] .`,
+			Limits{MaxNestingDepth: 1}, ErrCodeDepthExceeded},
+		{"rdfxml depth", FormatRDFXML, rdfxmlHead + `<rdf:Description><ex:p><rdf:Description><ex:p>1</ex:p></rdf:Description></ex:p></rdf:Description></rdf:RDF>`,
+			Limits{MaxNestingDepth: 3}, ErrCodeDepthExceeded},
+		{"jsonld depth", FormatJSONLD, `[{"@id": "http://example.org/s", "http://example.org/p": {"@id": "http://example.org/o", "http://example.org/p": "1"}}]`,
+			Limits{MaxNestingDepth: 2}, ErrCodeDepthExceeded},
+		{"turtle prefixes", FormatTurtle, "@prefix a: <http://example.org/a#> .\n@prefix b: <http://example.org/b#> .\na:s a:p b:o .",
+			Limits{MaxPrefixCount: 1}, ErrCodePrefixLimitExceeded},
+		{"rdfxml prefixes", FormatRDFXML, rdfxmlHead + `<rdf:Description rdf:about="http://example.org/s"><ex:p>1</ex:p></rdf:Description></rdf:RDF>`,
+			Limits{MaxPrefixCount: 1}, ErrCodePrefixLimitExceeded},
+		{"jsonld term definitions", FormatJSONLD, `{"@context": {"a": "http://example.org/a#", "b": "http://example.org/b#"}, "@id": "a:s", "a:p": "1"}`,
+			Limits{MaxPrefixCount: 1}, ErrCodePrefixLimitExceeded},
+		{"turtle statement size", FormatTurtle, `<http://example.org/s> <http://example.org/p> "1", "2", "3", "4" .`,
+			Limits{MaxStatementSize: 40}, ErrCodeStatementTooLong},
+		{"rdfxml statement size", FormatRDFXML, rdfxmlHead + `<rdf:Description rdf:about="http://example.org/s"><ex:p>1</ex:p><ex:p>2</ex:p><ex:p>3</ex:p></rdf:Description></rdf:RDF>`,
+			Limits{MaxStatementSize: 60}, ErrCodeStatementTooLong},
+		{"jsonld statement size", FormatJSONLD, `[{"@id": "http://example.org/s", "http://example.org/p": ["1", "2", "3", "4"]}]`,
+			Limits{MaxStatementSize: 40}, ErrCodeStatementTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(tt.input), tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := readAllStatements(reader); err != nil {
+				t.Fatalf("without limits: %v", err)
+			}
+			reader, err = NewReader(strings.NewReader(tt.input), tt.format, OptLimits(tt.limits))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = readAllStatements(reader)
+			if Code(err) != tt.code {
+				t.Errorf("Code(%v) = %q, want %q", err, Code(err), tt.code)
+			}
+		})
+	}
+}
+
+func TestOptFormatLimits(t *testing.T) {
+	const input = `<http://example.org/s> <http://example.org/p> "long literal" .` + "\n"
+	opts := []Option{OptLimits(Limits{MaxLiteralLength: 100}), OptFormatLimits(FormatNTriples, Limits{MaxLiteralLength: 5})}
+
+	reader, _ := NewReader(strings.NewReader(input), FormatTurtle, opts...)
+	if err := readAllStatements(reader); err != nil {
+		t.Errorf("Turtle reader error = %v", err)
+	}
+	reader, _ = NewReader(strings.NewReader(input), FormatNTriples, opts...)
+	if err := readAllStatements(reader); !errors.Is(err, ErrLiteralTooLong) {
+		t.Errorf("N-Triples reader error = %v, want ErrLiteralTooLong", err)
+	}
+	// Detected formats use their limits too.
+	turtle := "@prefix ex: <http://example.org/> .\nex:s ex:p \"long literal\" .\n"
+	reader, _ = NewReader(strings.NewReader(turtle), FormatAuto, OptFormatLimits(FormatTurtle, Limits{MaxLiteralLength: 5}))
+	if err := readAllStatements(reader); !errors.Is(err, ErrLiteralTooLong) {
+		t.Errorf("detected reader error = %v, want ErrLiteralTooLong", err)
+	}
+}
+
+func TestOptSafeLimitsLiteral(t *testing.T) {
+	// The literal spans lines to stay within the line length limit.
+	input := `<http://example.org/s> <http://example.org/p> """` + strings.Repeat(strings.Repeat("x", 1023)+"\n", 100) + `""" .`
+	reader, _ := NewReader(strings.NewReader(input), FormatTurtle, OptSafeLimits())
+	if err := readAllStatements(reader); Code(err) != ErrCodeLiteralTooLong {
+		t.Errorf("Code(%v) = %q, want %q", err, Code(err), ErrCodeLiteralTooLong)
+	}
+}
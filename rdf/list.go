@@ -0,0 +1,70 @@
+package rdf
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalidList indicates that ReadList found a malformed rdf:List: a node
+// without exactly one rdf:first and one rdf:rest, a cycle, or a list longer
+// than the limit.
+var ErrInvalidList = errors.New("rdf: invalid rdf:List")
+
+// ReadList returns the members of the rdf:List starting at head, following
+// the rdf:first and rdf:rest statements of stmts in any graph. rdf:nil is
+// the empty list. ReadList fails with ErrInvalidList if a node lacks or has
+// several rdf:first or rdf:rest values, if the list runs into a cycle, or
+// if it has more than maxLength members (0 = no limit).
+func ReadList(stmts []Statement, head Term, maxLength int) ([]Term, error) {
+	first := make(map[Term][]Term)
+	rest := make(map[Term][]Term)
+	for _, s := range stmts {
+		switch s.P.Value {
+		case rdfFirstIRI:
+			first[s.S] = append(first[s.S], s.O)
+		case rdfRestIRI:
+			rest[s.S] = append(rest[s.S], s.O)
+		}
+	}
+	var items []Term
+	visited := make(map[Term]bool)
+	for node := head; node != (IRI{Value: rdfNilIRI}); {
+		if visited[node] {
+			return nil, fmt.Errorf("%w: cycle at %s", ErrInvalidList, node)
+		}
+		visited[node] = true
+		if len(first[node]) != 1 || len(rest[node]) != 1 {
+			return nil, fmt.Errorf("%w: %s has %d rdf:first and %d rdf:rest values, want one each", ErrInvalidList, node, len(first[node]), len(rest[node]))
+		}
+		if maxLength > 0 && len(items) == maxLength {
+			return nil, fmt.Errorf("%w: more than %d members", ErrInvalidList, maxLength)
+		}
+		items = append(items, first[node][0])
+		node = rest[node][0]
+	}
+	return items, nil
+}
+
+// WriteList passes the rdf:first and rdf:rest statements of an rdf:List of
+// items to sink, in the default graph and in list order, and returns the
+// head of the list: rdf:nil for no items, otherwise a blank node. The blank
+// node labels carry a random prefix so that lists written to one output
+// never clash. WriteList stops at the first error returned by sink.
+func WriteList(items []Term, sink Handler) (Term, error) {
+	prefix := "list" + hex.EncodeToString(randomBytes(8)) + "n"
+	counter := 0
+	newBlankNode := func() BlankNode {
+		counter++
+		return BlankNode{ID: prefix + strconv.Itoa(counter)}
+	}
+	var expansion []Triple
+	head := generateCollectionTriples(items, &expansion, newBlankNode)
+	for _, t := range expansion {
+		if err := sink(t.ToStatement()); err != nil {
+			return head, err
+		}
+	}
+	return head, nil
+}
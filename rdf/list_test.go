@@ -0,0 +1,89 @@
+package rdf
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadList(t *testing.T) {
+	var stmts []Statement
+	input := `<http://example.org/s> <http://example.org/p> (1 "two" <http://example.org/three>) .`
+	if err := Parse(context.Background(), strings.NewReader(input), FormatTurtle, func(s Statement) error {
+		stmts = append(stmts, s)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	items, err := ReadList(stmts, stmts[0].O, 0)
+	if err != nil {
+		t.Fatalf("ReadList: %v", err)
+	}
+	want := []Term{
+		Literal{Lexical: "1", Datatype: IRI{Value: xsdNamespace + "integer"}},
+		Literal{Lexical: "two"},
+		IRI{Value: "http://example.org/three"},
+	}
+	if !slices.Equal(items, want) {
+		t.Errorf("items = %v, want %v", items, want)
+	}
+	if _, err := ReadList(stmts, stmts[0].O, 2); !errors.Is(err, ErrInvalidList) {
+		t.Errorf("maxLength 2: err = %v, want ErrInvalidList", err)
+	}
+	if items, err := ReadList(nil, IRI{Value: rdfNilIRI}, 0); err != nil || len(items) != 0 {
+		t.Errorf("rdf:nil: items = %v, err = %v", items, err)
+	}
+}
+
+func TestReadListInvalid(t *testing.T) {
+	first, rest := IRI{Value: rdfFirstIRI}, IRI{Value: rdfRestIRI}
+	a, b := BlankNode{ID: "a"}, BlankNode{ID: "b"}
+	one := Literal{Lexical: "1"}
+	cases := map[string][]Statement{
+		"cycle":        {NewTriple(a, first, one), NewTriple(a, rest, b), NewTriple(b, first, one), NewTriple(b, rest, a)},
+		"missing rest": {NewTriple(a, first, one)},
+		"two firsts":   {NewTriple(a, first, one), NewTriple(a, first, Literal{Lexical: "2"}), NewTriple(a, rest, IRI{Value: rdfNilIRI})},
+		"not a list":   {},
+	}
+	for name, stmts := range cases {
+		if _, err := ReadList(stmts, a, 0); !errors.Is(err, ErrInvalidList) {
+			t.Errorf("%s: err = %v, want ErrInvalidList", name, err)
+		}
+	}
+}
+
+func TestWriteList(t *testing.T) {
+	items := []Term{IRI{Value: "http://example.org/a"}, Literal{Lexical: "b"}}
+	var stmts []Statement
+	sink := func(s Statement) error {
+		stmts = append(stmts, s)
+		return nil
+	}
+	head, err := WriteList(items, sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 4 || stmts[0].S != head {
+		t.Fatalf("statements = %v, head %v", stmts, head)
+	}
+	got, err := ReadList(stmts, head, 0)
+	if err != nil || !slices.Equal(got, items) {
+		t.Errorf("round trip = %v, %v", got, err)
+	}
+	// A second list gets distinct blank nodes.
+	other, _ := WriteList(items, sink)
+	if other == head {
+		t.Errorf("second list reuses head %v", head)
+	}
+	if head, _ := WriteList(nil, sink); head != (IRI{Value: rdfNilIRI}) {
+		t.Errorf("empty list head = %v", head)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	if _, err := WriteList(items, func(Statement) error { calls++; return stop }); err != stop || calls != 1 {
+		t.Errorf("sink error: err = %v after %d calls", err, calls)
+	}
+}
@@ -0,0 +1,231 @@
+package rdf
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xsdIntegerTypes are xsd:integer and the XSD datatypes derived from it.
+var xsdIntegerTypes = map[string]bool{
+	"integer": true, "long": true, "int": true, "short": true, "byte": true,
+	"unsignedLong": true, "unsignedInt": true, "unsignedShort": true, "unsignedByte": true,
+	"nonNegativeInteger": true, "positiveInteger": true,
+	"nonPositiveInteger": true, "negativeInteger": true,
+}
+
+// Int returns the value of an xsd:integer literal, or of a datatype derived
+// from it such as xsd:long or xsd:unsignedByte. Values outside the int64
+// range are reported as errors; use Decimal for those.
+func (l Literal) Int() (int64, error) {
+	lexical, datatype, err := l.xsdValue("integer")
+	if err != nil {
+		return 0, err
+	}
+	if !xsdIntegerTypes[datatype] {
+		return 0, l.valueError("integer")
+	}
+	n, err := strconv.ParseInt(lexical, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", ErrLiteralValue, l, err)
+	}
+	return n, nil
+}
+
+// Float returns the value of an xsd:double, xsd:float, xsd:decimal or
+// integer literal. "INF", "-INF" and "NaN" give the IEEE 754 special values,
+// and xsd:float values are rounded to float32 precision.
+func (l Literal) Float() (float64, error) {
+	lexical, datatype, err := l.xsdValue("double")
+	if err != nil {
+		return 0, err
+	}
+	bitSize := 64
+	switch {
+	case datatype == "float":
+		bitSize = 32
+	case datatype == "double", datatype == "decimal", xsdIntegerTypes[datatype]:
+	default:
+		return 0, l.valueError("double")
+	}
+	f, err := strconv.ParseFloat(lexical, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", ErrLiteralValue, l, err)
+	}
+	return f, nil
+}
+
+// Bool returns the value of an xsd:boolean literal, whose lexical forms are
+// "true", "false", "1" and "0".
+func (l Literal) Bool() (bool, error) {
+	lexical, datatype, err := l.xsdValue("boolean")
+	if err != nil {
+		return false, err
+	}
+	if datatype != "boolean" {
+		return false, l.valueError("boolean")
+	}
+	return lexical == "true" || lexical == "1", nil
+}
+
+// Time returns the value of an xsd:dateTime, xsd:dateTimeStamp or xsd:date
+// literal; dates give midnight at the start of the day. Values without a
+// timezone are returned in UTC.
+func (l Literal) Time() (time.Time, error) {
+	lexical, datatype, err := l.xsdValue("dateTime")
+	if err != nil {
+		return time.Time{}, err
+	}
+	var layout string
+	switch datatype {
+	case "dateTime", "dateTimeStamp":
+		layout = "2006-01-02T15:04:05.999999999"
+	case "date":
+		layout = "2006-01-02"
+	default:
+		return time.Time{}, l.valueError("dateTime")
+	}
+	if hasXSDTimezone(lexical) {
+		layout += "Z07:00"
+	}
+	t, err := time.Parse(layout, lexical)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s: %v", ErrLiteralValue, l, err)
+	}
+	return t, nil
+}
+
+// hasXSDTimezone reports whether a date or time lexical form ends with a
+// timezone, "Z" or "+hh:mm"/"-hh:mm".
+func hasXSDTimezone(lexical string) bool {
+	if strings.HasSuffix(lexical, "Z") {
+		return true
+	}
+	n := len(lexical)
+	return n >= 6 && (lexical[n-6] == '+' || lexical[n-6] == '-') && lexical[n-3] == ':'
+}
+
+// Decimal returns the exact value of an xsd:decimal, integer, xsd:double or
+// xsd:float literal. The special values INF, -INF and NaN are errors.
+func (l Literal) Decimal() (*big.Rat, error) {
+	lexical, datatype, err := l.xsdValue("decimal")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case datatype == "decimal", datatype == "double", datatype == "float", xsdIntegerTypes[datatype]:
+	default:
+		return nil, l.valueError("decimal")
+	}
+	r, ok := new(big.Rat).SetString(lexical)
+	if !ok {
+		return nil, l.valueError("decimal")
+	}
+	return r, nil
+}
+
+// xsdValue returns the lexical form of l with XSD whitespace collapsed and
+// the local name of its XSD datatype, checking the lexical form against
+// that datatype. want names the requested type in errors.
+func (l Literal) xsdValue(want string) (string, string, error) {
+	datatype, ok := strings.CutPrefix(l.Datatype.Value, xsdNamespace)
+	if !ok || l.Lang != "" {
+		return "", "", l.valueError(want)
+	}
+	lexical := strings.TrimSpace(l.Lexical)
+	if valid, known := xsdLexicalValidators[datatype]; known && !valid(lexical) {
+		return "", "", fmt.Errorf("%w: %s is not a valid xsd:%s", ErrLiteralValue, l, datatype)
+	}
+	return lexical, datatype, nil
+}
+
+func (l Literal) valueError(want string) error {
+	return fmt.Errorf("%w: %s is not an xsd:%s value", ErrLiteralValue, l, want)
+}
+
+// NewLiteralFromValue returns the literal for a Go value, with the XSD
+// datatype and canonical lexical form that the Literal accessors read back:
+//
+//   - string: a plain (xsd:string) literal
+//   - bool: xsd:boolean
+//   - signed and unsigned integers and *big.Int: xsd:integer
+//   - float64: xsd:double; float32: xsd:float
+//   - *big.Rat: xsd:decimal, if its value has a finite decimal expansion
+//   - time.Time: xsd:dateTime
+//   - []byte: xsd:base64Binary
+//   - Literal: returned unchanged
+//
+// Named types are converted by their underlying kind. Other values are
+// reported as errors wrapping ErrLiteralValue.
+func NewLiteralFromValue(v any) (Literal, error) {
+	switch value := v.(type) {
+	case Literal:
+		return value, nil
+	case time.Time:
+		return xsdLiteral(value.Format(time.RFC3339Nano), "dateTime"), nil
+	case *big.Int:
+		if value != nil {
+			return xsdLiteral(value.String(), "integer"), nil
+		}
+	case *big.Rat:
+		if value != nil {
+			lexical, ok := canonicalXSDDecimal(value)
+			if !ok {
+				return Literal{}, fmt.Errorf("%w: %s has no finite decimal form", ErrLiteralValue, value)
+			}
+			return xsdLiteral(lexical, "decimal"), nil
+		}
+	case []byte:
+		return xsdLiteral(base64.StdEncoding.EncodeToString(value), "base64Binary"), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return Literal{Lexical: rv.String()}, nil
+	case reflect.Bool:
+		return xsdLiteral(strconv.FormatBool(rv.Bool()), "boolean"), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return xsdLiteral(strconv.FormatInt(rv.Int(), 10), "integer"), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return xsdLiteral(strconv.FormatUint(rv.Uint(), 10), "integer"), nil
+	case reflect.Float32:
+		return xsdLiteral(canonicalXSDFloat(rv.Float(), 32), "float"), nil
+	case reflect.Float64:
+		return xsdLiteral(canonicalXSDDouble(rv.Float()), "double"), nil
+	}
+	return Literal{}, fmt.Errorf("%w: no literal form for %T", ErrLiteralValue, v)
+}
+
+func xsdLiteral(lexical, datatype string) Literal {
+	return Literal{Lexical: lexical, Datatype: IRI{Value: xsdNamespace + datatype}}
+}
+
+// canonicalXSDDecimal formats r as a canonical xsd:decimal such as "-1.25"
+// or "3.0", failing when its decimal expansion does not terminate.
+func canonicalXSDDecimal(r *big.Rat) (string, bool) {
+	denom := new(big.Int).Set(r.Denom())
+	digits := 0
+	for _, factor := range []int64{2, 5} {
+		f, n, m := big.NewInt(factor), 0, new(big.Int)
+		for {
+			q, rem := new(big.Int).QuoRem(denom, f, m)
+			if rem.Sign() != 0 {
+				break
+			}
+			denom, n = q, n+1
+		}
+		digits = max(digits, n)
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return "", false
+	}
+	lexical := strings.TrimRight(r.FloatString(max(digits, 1)), "0")
+	if strings.HasSuffix(lexical, ".") {
+		lexical += "0"
+	}
+	return lexical, true
+}
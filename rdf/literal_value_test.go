@@ -0,0 +1,120 @@
+package rdf
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestLiteralAccessors(t *testing.T) {
+	typed := func(lexical, datatype string) Literal { return xsdLiteral(lexical, datatype) }
+
+	if n, err := typed(" +42 ", "integer").Int(); err != nil || n != 42 {
+		t.Errorf("Int() = %d, %v, want 42", n, err)
+	}
+	if n, err := typed("-7", "short").Int(); err != nil || n != -7 {
+		t.Errorf("Int() of xsd:short = %d, %v, want -7", n, err)
+	}
+	if f, err := typed("1.5E2", "double").Float(); err != nil || f != 150 {
+		t.Errorf("Float() = %v, %v, want 150", f, err)
+	}
+	if f, err := typed("-INF", "double").Float(); err != nil || !math.IsInf(f, -1) {
+		t.Errorf("Float() of -INF = %v, %v", f, err)
+	}
+	if f, err := typed("0.1", "float").Float(); err != nil || f != float64(float32(0.1)) {
+		t.Errorf("Float() of xsd:float = %v, %v, want float32 precision", f, err)
+	}
+	if f, err := typed("12", "integer").Float(); err != nil || f != 12 {
+		t.Errorf("Float() of xsd:integer = %v, %v, want 12", f, err)
+	}
+	for lexical, want := range map[string]bool{"true": true, "1": true, "false": false, "0": false} {
+		if b, err := typed(lexical, "boolean").Bool(); err != nil || b != want {
+			t.Errorf("Bool() of %q = %v, %v, want %v", lexical, b, err, want)
+		}
+	}
+	if d, err := typed("123456789012345678901234.5", "decimal").Decimal(); err != nil || d.FloatString(1) != "123456789012345678901234.5" {
+		t.Errorf("Decimal() = %v, %v", d, err)
+	}
+
+	times := map[Literal]time.Time{
+		typed("2024-03-01T12:30:00.25Z", "dateTime"):   time.Date(2024, 3, 1, 12, 30, 0, 250000000, time.UTC),
+		typed("2024-03-01T12:30:00-05:00", "dateTime"): time.Date(2024, 3, 1, 17, 30, 0, 0, time.UTC),
+		typed("2024-03-01T12:30:00", "dateTime"):       time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		typed("2024-03-01", "date"):                    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		typed("2024-03-01+01:00", "date"):              time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC),
+		typed("2024-03-01T00:00:00Z", "dateTimeStamp"): time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for lit, want := range times {
+		if got, err := lit.Time(); err != nil || !got.Equal(want) {
+			t.Errorf("Time() of %s = %v, %v, want %v", lit, got, err, want)
+		}
+	}
+
+	invalid := []func() error{
+		func() error { _, err := Literal{Lexical: "42"}.Int(); return err },
+		func() error { _, err := typed("4.2", "integer").Int(); return err },
+		func() error { _, err := typed("4.2", "decimal").Int(); return err },
+		func() error { _, err := typed("99999999999999999999", "integer").Int(); return err },
+		func() error { _, err := typed("yes", "boolean").Bool(); return err },
+		func() error { _, err := typed("1", "integer").Bool(); return err },
+		func() error { _, err := typed("NaN", "double").Decimal(); return err },
+		func() error { _, err := typed("abc", "string").Float(); return err },
+		func() error { _, err := typed("2024-13-01", "date").Time(); return err },
+		func() error { _, err := Literal{Lexical: "1", Lang: "en"}.Float(); return err },
+	}
+	for i, fn := range invalid {
+		if err := fn(); !errors.Is(err, ErrLiteralValue) || Code(err) != ErrCodeInvalidLiteral {
+			t.Errorf("case %d: expected ErrLiteralValue, got %v", i, err)
+		}
+	}
+}
+
+func TestNewLiteralFromValue(t *testing.T) {
+	type age uint8
+	cases := []struct {
+		value any
+		want  Literal
+	}{
+		{"hello", Literal{Lexical: "hello"}},
+		{true, xsdLiteral("true", "boolean")},
+		{-42, xsdLiteral("-42", "integer")},
+		{age(7), xsdLiteral("7", "integer")},
+		{uint64(math.MaxUint64), xsdLiteral("18446744073709551615", "integer")},
+		{1.5, xsdLiteral("1.5E0", "double")},
+		{float32(0.1), xsdLiteral("1.0E-1", "float")},
+		{big.NewRat(-5, 4), xsdLiteral("-1.25", "decimal")},
+		{big.NewRat(3, 1), xsdLiteral("3.0", "decimal")},
+		{new(big.Int).Lsh(big.NewInt(1), 70), xsdLiteral("1180591620717411303424", "integer")},
+		{time.Date(2024, 3, 1, 12, 30, 0, 5, time.UTC), xsdLiteral("2024-03-01T12:30:00.000000005Z", "dateTime")},
+		{[]byte("hi"), xsdLiteral("aGk=", "base64Binary")},
+		{xsdLiteral("x", "token"), xsdLiteral("x", "token")},
+	}
+	for _, c := range cases {
+		got, err := NewLiteralFromValue(c.value)
+		if err != nil || got != c.want {
+			t.Errorf("NewLiteralFromValue(%v) = %v, %v, want %v", c.value, got, err, c.want)
+		}
+	}
+
+	// Values read back through the accessors.
+	lit, _ := NewLiteralFromValue(int64(math.MinInt64))
+	if n, err := lit.Int(); err != nil || n != math.MinInt64 {
+		t.Errorf("Int() round trip = %d, %v", n, err)
+	}
+	lit, _ = NewLiteralFromValue(math.Inf(1))
+	if f, err := lit.Float(); err != nil || !math.IsInf(f, 1) {
+		t.Errorf("Float() round trip = %v, %v", f, err)
+	}
+	lit, _ = NewLiteralFromValue(big.NewRat(1, 8))
+	if d, err := lit.Decimal(); err != nil || d.Cmp(big.NewRat(1, 8)) != 0 {
+		t.Errorf("Decimal() round trip = %v, %v", d, err)
+	}
+
+	for _, value := range []any{big.NewRat(1, 3), nil, IRI{Value: "http://example.org/"}, []int{1}, (*big.Int)(nil)} {
+		if _, err := NewLiteralFromValue(value); !errors.Is(err, ErrLiteralValue) {
+			t.Errorf("NewLiteralFromValue(%#v): expected ErrLiteralValue, got %v", value, err)
+		}
+	}
+}
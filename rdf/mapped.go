@@ -0,0 +1,121 @@
+package rdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"unsafe"
+)
+
+// OpenMapped opens the N-Triples or N-Quads file at path by mapping it into
+// memory, and returns a Reader that parses the mapping in place: the
+// IRIs, blank node labels, language tags and unescaped literals of the
+// statements it returns are strings pointing into the mapping rather than
+// copies. This avoids reading and copying multi-gigabyte dumps at the cost
+// of a strict contract: those strings are only valid until Close, which
+// unmaps the file, and the file must not be truncated while it is mapped.
+// Use Statement.Clone to keep a statement after Close; OptInternTerms
+// copies IRIs, datatypes and language tags as a side effect.
+//
+// Other formats fail with ErrUnsupportedFormat. opts apply as for
+// NewReader, but with OptDecompress or OptParallelism the input is read
+// through copies. On platforms without mmap the file is read into memory
+// instead.
+func OpenMapped(path string, format Format, opts ...Option) (Reader, error) {
+	if format != FormatNTriples && format != FormatNQuads {
+		return nil, fmt.Errorf("rdf: cannot map %s input: %w", format, ErrUnsupportedFormat)
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := mapFile(file)
+	// The mapping stays valid once the file is closed.
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		m.Close()
+		return nil, err
+	}
+	input := &mappedInput{data: m.data}
+	if state := options.ResumeFrom; state != nil {
+		if state.Offset < 0 || state.Offset > int64(len(m.data)) {
+			m.Close()
+			return nil, fmt.Errorf("rdf: resume offset %d outside %q", state.Offset, path)
+		}
+		input.pos = int(state.Offset)
+	}
+	reader, err := NewReader(input, format, opts...)
+	if err != nil {
+		m.Close()
+		return nil, err
+	}
+	return &closingReader{Reader: reader, closer: m}, nil
+}
+
+// mapping is a file mapped into memory by mapFile.
+type mapping struct {
+	data  []byte
+	unmap func([]byte) error // nil if data is not mapped
+}
+
+// Close unmaps the file. It may be called more than once.
+func (m *mapping) Close() error {
+	data, unmap := m.data, m.unmap
+	m.data, m.unmap = nil, nil
+	if unmap == nil || len(data) == 0 {
+		return nil
+	}
+	return unmap(data)
+}
+
+// mappedInput is the input of a Reader returned by OpenMapped. The
+// N-Triples and N-Quads decoders take lines from it in place; other
+// consumers read it as an io.Reader.
+type mappedInput struct {
+	data    []byte
+	pos     int
+	metrics *streamMetrics // Counts the bytes read, set by NewReader
+}
+
+func (m *mappedInput) Read(p []byte) (int, error) {
+	if m.pos >= len(m.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, m.data[m.pos:])
+	m.advance(n)
+	return n, nil
+}
+
+// advance moves past the next n bytes.
+func (m *mappedInput) advance(n int) {
+	m.pos += n
+	if m.metrics != nil {
+		m.metrics.bytes.Add(int64(n))
+	}
+}
+
+// readLine returns the next line, including its line feed, as a string
+// pointing into the mapping. Like readLineWithLimit, it skips a line longer
+// than maxBytes (0 = unlimited) and returns ErrLineTooLong.
+func (m *mappedInput) readLine(maxBytes int) (string, error) {
+	rest := m.data[m.pos:]
+	if len(rest) == 0 {
+		return "", io.EOF
+	}
+	n := bytes.IndexByte(rest, '\n') + 1
+	if n == 0 {
+		n = len(rest)
+	}
+	m.advance(n)
+	if maxBytes > 0 && n > maxBytes {
+		return "", ErrLineTooLong
+	}
+	return unsafe.String(&rest[0], n), nil
+}
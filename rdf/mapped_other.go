@@ -0,0 +1,14 @@
+//go:build !unix
+
+package rdf
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads file into memory on platforms without mmap support.
+func mapFile(file *os.File) (*mapping, error) {
+	data, err := io.ReadAll(file)
+	return &mapping{data: data}, err
+}
@@ -0,0 +1,133 @@
+package rdf
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+const mappedInputNQuads = `<http://example.org/s> <http://example.org/p> "plain" .
+# comment
+<http://example.org/s> <http://example.org/p> "esc\"apedé"@en <http://example.org/g> .
+_:b <http://example.org/p> <<( <http://example.org/s> <http://example.org/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> )>> .
+<http://example.org/s> <http://example.org/p> _:b` // No final line feed
+
+func writeMappedFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.nq")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOpenMapped(t *testing.T) {
+	path := writeMappedFile(t, mappedInputNQuads+" .")
+	want := parseContainerInput(t, mappedInputNQuads+" .", FormatNQuads)
+	reader, err := OpenMapped(path, FormatNQuads)
+	if err != nil {
+		t.Fatalf("OpenMapped: %v", err)
+	}
+	got, err := collectStatements(reader)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	var cloned []Statement
+	for _, s := range got {
+		cloned = append(cloned, s.Clone())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := reader.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := reader.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	// Clones do not point into the unmapped file.
+	if !slices.Equal(cloned, want) {
+		t.Errorf("clones = %v, want %v", cloned, want)
+	}
+}
+
+func TestOpenMappedInPlace(t *testing.T) {
+	input := mappedInputNQuads + " .\n"
+	reader, err := OpenMapped(writeMappedFile(t, input), FormatNQuads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	data := reader.(*closingReader).closer.(*mapping).data
+	stmt, err := reader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := uintptr(unsafe.Pointer(unsafe.SliceData(data)))
+	if p := uintptr(unsafe.Pointer(unsafe.StringData(stmt.S.(IRI).Value))); p < start || p >= start+uintptr(len(data)) {
+		t.Error("subject IRI does not point into the mapping")
+	}
+	if _, err := collectStatements(reader); err != nil {
+		t.Fatal(err)
+	}
+	if m := reader.(MetricsReporter).Metrics(); m.BytesRead != int64(len(input)) {
+		t.Errorf("BytesRead = %d, want %d", m.BytesRead, len(input))
+	}
+}
+
+func TestOpenMappedResume(t *testing.T) {
+	input := mappedInputNQuads + " .\n"
+	path := writeMappedFile(t, input)
+	reader, err := OpenMapped(path, FormatNQuads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	if _, err := reader.Next(); err != nil {
+		t.Fatal(err)
+	}
+	state, err := reader.(StateExporter).State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := OpenMapped(path, FormatNQuads, OptResumeFrom(state))
+	if err != nil {
+		t.Fatalf("OpenMapped resume: %v", err)
+	}
+	defer resumed.Close()
+	rest, err := collectStatements(resumed)
+	if err != nil || len(rest) != 3 {
+		t.Errorf("resumed = %d statements, %v, want 3", len(rest), err)
+	}
+}
+
+func TestOpenMappedErrors(t *testing.T) {
+	if _, err := OpenMapped(writeMappedFile(t, ""), FormatTurtle); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Turtle: err = %v, want ErrUnsupportedFormat", err)
+	}
+	if _, err := OpenMapped(filepath.Join(t.TempDir(), "missing.nt"), FormatNTriples); err == nil {
+		t.Error("missing file: expected error")
+	}
+	empty, err := OpenMapped(writeMappedFile(t, ""), FormatNTriples)
+	if err != nil {
+		t.Fatalf("empty file: %v", err)
+	}
+	if _, err := empty.Next(); err != io.EOF {
+		t.Errorf("empty file: Next = %v, want io.EOF", err)
+	}
+	empty.Close()
+	long := bytes.Repeat([]byte("x"), 100)
+	reader, err := OpenMapped(writeMappedFile(t, "<http://example.org/"+string(long)+"> <http://example.org/p> \"o\" ."), FormatNTriples, OptMaxLineBytes(64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	if _, err := reader.Next(); !errors.Is(err, ErrLineTooLong) {
+		t.Errorf("long line: err = %v, want ErrLineTooLong", err)
+	}
+}
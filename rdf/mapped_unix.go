@@ -0,0 +1,30 @@
+//go:build unix
+
+package rdf
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"syscall"
+)
+
+// mapFile maps file read-only into memory.
+func mapFile(file *os.File) (*mapping, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return &mapping{}, err
+	}
+	size := info.Size()
+	if size == 0 {
+		return &mapping{}, nil
+	}
+	if size > math.MaxInt {
+		return &mapping{}, fmt.Errorf("rdf: %s is too large to map", file.Name())
+	}
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return &mapping{}, fmt.Errorf("rdf: mmap %s: %w", file.Name(), err)
+	}
+	return &mapping{data: data, unmap: syscall.Munmap}, nil
+}
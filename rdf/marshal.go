@@ -0,0 +1,678 @@
+package rdf
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarshalOption configures Marshal and Unmarshal.
+type MarshalOption func(*marshalOptions)
+
+type marshalOptions struct {
+	prefixes map[string]string
+}
+
+// OptMarshalPrefixes adds prefixes, a map from prefix names such as "ex" to
+// namespace IRIs, to those the rdf struct tags and string @id fields of
+// Marshal and Unmarshal may use. The prefixes rdf, rdfs, owl, xsd, skos,
+// foaf, dc, dcterms, schema and prov are predefined; prefixes given here
+// replace them.
+func OptMarshalPrefixes(prefixes map[string]string) MarshalOption {
+	return func(opts *marshalOptions) {
+		maps.Copy(opts.prefixes, prefixes)
+	}
+}
+
+func newMarshalOptions(opts []MarshalOption) marshalOptions {
+	options := marshalOptions{prefixes: make(map[string]string, len(rdfxmlWellKnownPrefixes))}
+	for ns, prefix := range rdfxmlWellKnownPrefixes {
+		options.prefixes[prefix] = ns
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// expand returns the IRI of a struct tag or string @id: an IRI in angle
+// brackets, a prefixed name, "@type" for rdf:type, or an absolute IRI.
+func (o *marshalOptions) expand(name string) (IRI, error) {
+	if inner, ok := strings.CutPrefix(name, "<"); ok && strings.HasSuffix(inner, ">") {
+		return IRI{Value: inner[:len(inner)-1]}, nil
+	}
+	if name == "@type" {
+		return IRI{Value: rdfTypeIRI}, nil
+	}
+	if prefix, local, ok := strings.Cut(name, ":"); ok {
+		if ns, ok := o.prefixes[prefix]; ok {
+			return IRI{Value: ns + local}, nil
+		}
+		if iri := (IRI{Value: name}); iri.Validate() == nil {
+			return iri, nil
+		}
+	}
+	return IRI{}, fmt.Errorf("%q is neither a prefixed name nor an absolute IRI", name)
+}
+
+// Marshal returns the statements describing v, a struct or a pointer to a
+// struct, in the default graph. The exported fields with an rdf struct tag
+// give the statements, and other fields are ignored:
+//
+//	type Person struct {
+//		ID    rdf.IRI   `rdf:"@id"`
+//		Name  string    `rdf:"foaf:name"`
+//		Label string    `rdf:"rdfs:label,lang=en,omitempty"`
+//		Knows []*Person `rdf:"foaf:knows"`
+//		Tags  []string  `rdf:"<http://example.org/tag>,list"`
+//	}
+//
+// The tag names the predicate: a prefixed name (see OptMarshalPrefixes), an
+// absolute IRI, optionally in angle brackets, or "@type" for rdf:type. As in
+// JSON-LD, a name whose prefix is not defined is taken as an absolute IRI,
+// so "urn:isbn" needs no brackets but a misspelled prefix goes unnoticed.
+// The name may be followed by the options omitempty, which leaves out zero values;
+// list, which writes a slice as an rdf:List instead of one statement per
+// element; iri, which writes strings as IRIs; and lang=tag, which writes
+// strings as literals with that language tag. The tag "-" ignores a field,
+// and the fields of embedded structs without a tag are those of the struct
+// that embeds them.
+//
+// The field tagged "@id" names the subject: an IRI, a BlankNode, a Term, or
+// a string holding a prefixed name, an absolute IRI or a "_:" blank node.
+// Without one or when it is zero the subject is a new blank node. Marshal
+// labels the blank nodes it creates b1, b2 and so on.
+//
+// Fields of Term types give their term. Strings, booleans, integers,
+// floats and time.Time values become literals as with SubjectBuilder.Add.
+// Structs and pointers to structs other than terms become the objects of
+// their own statements, and a pointer marshaled twice, as in cycles, gives
+// the same subject. Slices and arrays give one statement per element. Nil
+// pointers and interfaces and zero IRI, BlankNode and TripleTerm values are
+// left out. Other types, such as maps, fail.
+func Marshal(v any, opts ...MarshalOption) ([]Statement, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || isTermType(rv.Type()) || rv.Type() == timeType {
+		return nil, fmt.Errorf("rdf: Marshal needs a struct, got %T", v)
+	}
+	m := &structMarshaler{opts: newMarshalOptions(opts), blanks: newBlankNodeGenerator(), subjects: map[structPointer]Term{}}
+	var err error
+	if rv.CanAddr() {
+		// Register the pointer for cycles back to v.
+		_, err = m.term(rv.Addr(), structField{})
+	} else {
+		_, err = m.marshalStruct(rv)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.stmts, nil
+}
+
+// Unmarshal sets the fields of the struct v points to from the statements
+// describing a subject, the reverse of Marshal with the same struct tags
+// and options. The subject is the value of the @id field if it is set, or
+// else the only subject of stmts that is not the object of a statement.
+// Statements of every graph are read.
+//
+// A field is set from the objects of the statements with its predicate, in
+// the order of stmts; fields without objects are left as they are. Slices
+// get every object and other fields the first, and with the lang option
+// only literals with that language tag are read. Strings get the lexical
+// form of literals and the IRI of IRIs; booleans and numbers parse the
+// lexical form, and time.Time the xsd:dateTime or xsd:date form. Structs,
+// and the structs pointers are set to, are read from the statements about
+// the object, and each subject read into a pointer is read once, so cycles
+// give pointers to the same struct, v for its own subject.
+func Unmarshal(stmts []Statement, v any, opts ...MarshalOption) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct || isTermType(rv.Elem().Type()) {
+		return fmt.Errorf("rdf: Unmarshal needs a non-nil pointer to a struct, got %T", v)
+	}
+	u := &structUnmarshaler{
+		opts:     newMarshalOptions(opts),
+		stmts:    stmts,
+		objects:  map[Term]map[string][]Term{},
+		pointers: map[structKey]reflect.Value{},
+		active:   map[structKey]bool{},
+	}
+	for _, s := range stmts {
+		if u.objects[s.S] == nil {
+			u.objects[s.S] = map[string][]Term{}
+		}
+		u.objects[s.S][s.P.Value] = append(u.objects[s.S][s.P.Value], s.O)
+	}
+	fields, err := cachedStructFields(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	subject, err := u.subject(rv.Elem(), fields)
+	if err != nil {
+		return err
+	}
+	u.pointers[structKey{subject: subject, typ: rv.Elem().Type()}] = rv
+	return u.unmarshalStruct(subject, rv.Elem())
+}
+
+// structField is a field of a struct with an rdf tag.
+type structField struct {
+	index     []int
+	name      string // Go field name, for errors
+	predicate string // Tag name, "@id" for the subject field
+	omitEmpty bool
+	list      bool
+	iri       bool
+	lang      string
+}
+
+type structFieldsEntry struct {
+	fields []structField
+	err    error
+}
+
+var structFieldsCache sync.Map // reflect.Type -> structFieldsEntry
+
+// cachedStructFields returns the tagged fields of the struct type t,
+// including those of embedded structs without a tag.
+func cachedStructFields(t reflect.Type) ([]structField, error) {
+	if entry, ok := structFieldsCache.Load(t); ok {
+		return entry.(structFieldsEntry).fields, entry.(structFieldsEntry).err
+	}
+	var entry structFieldsEntry
+	entry.fields, entry.err = structFields(t, nil)
+	structFieldsCache.Store(t, entry)
+	return entry.fields, entry.err
+}
+
+func structFields(t reflect.Type, index []int) ([]structField, error) {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("rdf")
+		if !tagged && sf.Anonymous && sf.Type.Kind() == reflect.Struct && !isTermType(sf.Type) {
+			embedded, err := structFields(sf.Type, append(append([]int(nil), index...), i))
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if !tagged || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		f := structField{index: append(append([]int(nil), index...), i), name: t.Name() + "." + sf.Name, predicate: name}
+		if name == "" {
+			return nil, fmt.Errorf("rdf: field %s: rdf tag without predicate", f.name)
+		}
+		if name == "@id" && !isIDType(sf.Type) {
+			return nil, fmt.Errorf("rdf: field %s: @id field of type %s, want string, IRI, BlankNode or Term", f.name, sf.Type)
+		}
+		for option := range strings.SplitSeq(options, ",") {
+			switch lang, isLang := strings.CutPrefix(option, "lang="); {
+			case option == "":
+			case option == "omitempty":
+				f.omitEmpty = true
+			case option == "list":
+				f.list = true
+			case option == "iri":
+				f.iri = true
+			case isLang && isValidLangTag(lang):
+				f.lang = lang
+			default:
+				return nil, fmt.Errorf("rdf: field %s: invalid rdf tag option %q", f.name, option)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+var (
+	termType = reflect.TypeFor[Term]()
+	timeType = reflect.TypeFor[time.Time]()
+)
+
+// isTermType reports whether t is a Term interface or implementation other
+// than a pointer.
+func isTermType(t reflect.Type) bool {
+	return t.Kind() != reflect.Pointer && t.Implements(termType)
+}
+
+// isIDType reports whether a field of type t can be tagged "@id".
+func isIDType(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t == termType || t == reflect.TypeFor[IRI]() || t == reflect.TypeFor[BlankNode]()
+}
+
+// idTerm returns the subject named by the @id field of the struct v, or nil
+// if it has none or it is zero.
+func idTerm(v reflect.Value, fields []structField, opts *marshalOptions) (Term, error) {
+	for _, f := range fields {
+		if f.predicate != "@id" {
+			continue
+		}
+		switch id := v.FieldByIndex(f.index).Interface().(type) {
+		case string:
+			if label, ok := strings.CutPrefix(id, "_:"); ok {
+				return BlankNode{ID: label}, nil
+			}
+			if id != "" {
+				iri, err := opts.expand(id)
+				if err != nil {
+					return nil, fmt.Errorf("rdf: field %s: %w", f.name, err)
+				}
+				return iri, nil
+			}
+		case Term:
+			if !isZeroTerm(id) {
+				return id, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// structPointer identifies a struct marshaled through a pointer.
+type structPointer struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type structMarshaler struct {
+	opts     marshalOptions
+	stmts    []Statement
+	blanks   *blankNodeGenerator
+	subjects map[structPointer]Term // Subjects of the structs marshaled through pointers
+}
+
+// marshalStruct adds the statements of the struct v and returns its subject.
+func (m *structMarshaler) marshalStruct(v reflect.Value) (Term, error) {
+	subject, err := m.subject(v)
+	if err != nil {
+		return nil, err
+	}
+	return subject, m.marshalFields(subject, v)
+}
+
+// subject returns the subject named by the @id field of v, or a new blank
+// node.
+func (m *structMarshaler) subject(v reflect.Value) (Term, error) {
+	fields, err := cachedStructFields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	subject, err := idTerm(v, fields, &m.opts)
+	if subject == nil && err == nil {
+		subject = m.blanks.next()
+	}
+	return subject, err
+}
+
+func (m *structMarshaler) marshalFields(subject Term, v reflect.Value) error {
+	fields, err := cachedStructFields(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if f.predicate == "@id" {
+			continue
+		}
+		fv := v.FieldByIndex(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		predicate, err := m.opts.expand(f.predicate)
+		if err != nil {
+			return fmt.Errorf("rdf: field %s: %w", f.name, err)
+		}
+		objects, err := m.objects(fv, f)
+		if err != nil {
+			return fmt.Errorf("rdf: field %s: %w", f.name, err)
+		}
+		if f.list {
+			var expansion []Triple
+			head := generateCollectionTriples(objects, &expansion, m.blanks.next)
+			m.stmts = append(m.stmts, Statement{S: subject, P: predicate, O: head})
+			for _, t := range expansion {
+				m.stmts = append(m.stmts, t.ToStatement())
+			}
+			continue
+		}
+		for _, o := range objects {
+			m.stmts = append(m.stmts, Statement{S: subject, P: predicate, O: o})
+		}
+	}
+	return nil
+}
+
+// objects returns the objects of the field value v: one per element of a
+// slice or an array, or the term of v.
+func (m *structMarshaler) objects(v reflect.Value, f structField) ([]Term, error) {
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8 {
+		var objects []Term
+		for i := 0; i < v.Len(); i++ {
+			term, err := m.term(v.Index(i), f)
+			if err != nil {
+				return nil, err
+			}
+			if term != nil {
+				objects = append(objects, term)
+			}
+		}
+		return objects, nil
+	}
+	if f.list {
+		return nil, fmt.Errorf("list option on %s field", v.Type())
+	}
+	term, err := m.term(v, f)
+	if term == nil || err != nil {
+		return nil, err
+	}
+	return []Term{term}, nil
+}
+
+// term returns the term of a field value or element, or nil if it is left
+// out.
+func (m *structMarshaler) term(v reflect.Value, f structField) (Term, error) {
+	if (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) && v.IsNil() {
+		return nil, nil
+	}
+	if isTermType(v.Type()) {
+		if term := v.Interface().(Term); !isZeroTerm(term) {
+			return term, nil
+		}
+		return nil, nil
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		return m.term(v.Elem(), f)
+	case reflect.Pointer:
+		if v.Elem().Kind() != reflect.Struct || v.Elem().Type() == timeType || isTermType(v.Elem().Type()) {
+			return m.term(v.Elem(), f)
+		}
+		key := structPointer{ptr: v.Pointer(), typ: v.Elem().Type()}
+		if subject, ok := m.subjects[key]; ok {
+			return subject, nil
+		}
+		subject, err := m.subject(v.Elem())
+		if err != nil {
+			return nil, err
+		}
+		m.subjects[key] = subject
+		return subject, m.marshalFields(subject, v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return builderTerm(v.Interface()), nil
+		}
+		return m.marshalStruct(v)
+	case reflect.String:
+		switch {
+		case f.iri:
+			return IRI{Value: v.String()}, nil
+		case f.lang != "":
+			lang, direction := splitLangDirection(f.lang)
+			return Literal{Lexical: v.String(), Lang: lang, Direction: direction}, nil
+		}
+		return Literal{Lexical: v.String()}, nil
+	case reflect.Bool:
+		return builderTerm(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return builderTerm(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return builderTerm(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return builderTerm(v.Float()), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// isZeroTerm reports whether t is a zero IRI, BlankNode or TripleTerm,
+// which Marshal leaves out.
+func isZeroTerm(t Term) bool {
+	switch t := t.(type) {
+	case IRI:
+		return t.Value == ""
+	case BlankNode:
+		return t.ID == ""
+	case TripleTerm:
+		return t.S == nil && t.P.Value == "" && t.O == nil
+	}
+	return false
+}
+
+// structKey identifies a subject read into a struct type.
+type structKey struct {
+	subject Term
+	typ     reflect.Type
+}
+
+type structUnmarshaler struct {
+	opts     marshalOptions
+	stmts    []Statement
+	objects  map[Term]map[string][]Term  // Objects by subject and predicate IRI
+	pointers map[structKey]reflect.Value // Structs allocated for pointers
+	active   map[structKey]bool          // Structs being read, to stop at cycles
+}
+
+// subject returns the subject of the top-level struct v: its @id or the
+// only subject that is not an object.
+func (u *structUnmarshaler) subject(v reflect.Value, fields []structField) (Term, error) {
+	if subject, err := idTerm(v, fields, &u.opts); subject != nil || err != nil {
+		return subject, err
+	}
+	isObject := map[Term]bool{}
+	for _, s := range u.stmts {
+		isObject[s.O] = true
+	}
+	var roots []Term
+	seen := map[Term]bool{}
+	for _, s := range u.stmts {
+		if !isObject[s.S] && !seen[s.S] {
+			seen[s.S] = true
+			roots = append(roots, s.S)
+		}
+	}
+	if len(roots) != 1 {
+		return nil, fmt.Errorf("rdf: Unmarshal found %d subjects that are not objects, want one; set the @id field", len(roots))
+	}
+	return roots[0], nil
+}
+
+// unmarshalStruct sets the fields of the struct v from the statements about
+// subject.
+func (u *structUnmarshaler) unmarshalStruct(subject Term, v reflect.Value) error {
+	fields, err := cachedStructFields(v.Type())
+	if err != nil {
+		return err
+	}
+	key := structKey{subject: subject, typ: v.Type()}
+	if u.active[key] {
+		// A cycle through values rather than pointers: only name the subject.
+		return u.setID(subject, v, fields)
+	}
+	u.active[key] = true
+	defer delete(u.active, key)
+	if err := u.setID(subject, v, fields); err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if f.predicate == "@id" {
+			continue
+		}
+		predicate, err := u.opts.expand(f.predicate)
+		if err != nil {
+			return fmt.Errorf("rdf: field %s: %w", f.name, err)
+		}
+		objects := u.objects[subject][predicate.Value]
+		if f.list && len(objects) > 0 {
+			if objects, err = ReadList(u.stmts, objects[0], 0); err != nil {
+				return fmt.Errorf("rdf: field %s: %w", f.name, err)
+			}
+		}
+		if f.lang != "" {
+			objects = literalsWithLang(objects, f.lang)
+		}
+		if len(objects) == 0 {
+			continue
+		}
+		if err := u.setField(v.FieldByIndex(f.index), objects, f); err != nil {
+			return fmt.Errorf("rdf: field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// setID sets the @id field of v, if it has one, to subject.
+func (u *structUnmarshaler) setID(subject Term, v reflect.Value, fields []structField) error {
+	for _, f := range fields {
+		if f.predicate != "@id" {
+			continue
+		}
+		fv := v.FieldByIndex(f.index)
+		if fv.Kind() == reflect.String {
+			fv.SetString(subject.String())
+			continue
+		}
+		if !reflect.TypeOf(subject).AssignableTo(fv.Type()) {
+			return fmt.Errorf("rdf: field %s: cannot set %s subject %s", f.name, fv.Type(), renderTerm(subject))
+		}
+		fv.Set(reflect.ValueOf(subject))
+	}
+	return nil
+}
+
+// setField sets a field from its objects: all of them for slices and
+// arrays, the first otherwise.
+func (u *structUnmarshaler) setField(v reflect.Value, objects []Term, f structField) error {
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		slice := reflect.MakeSlice(v.Type(), len(objects), len(objects))
+		for i, o := range objects {
+			if err := u.set(slice.Index(i), o); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	case v.Kind() == reflect.Array && v.Type().Elem().Kind() != reflect.Uint8:
+		for i := 0; i < v.Len() && i < len(objects); i++ {
+			if err := u.set(v.Index(i), objects[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return u.set(v, objects[0])
+}
+
+// set sets v from the term t.
+func (u *structUnmarshaler) set(v reflect.Value, t Term) error {
+	if isTermType(v.Type()) || v.Kind() == reflect.Interface {
+		if !reflect.TypeOf(t).AssignableTo(v.Type()) {
+			return fmt.Errorf("cannot set %s from %s", v.Type(), renderTerm(t))
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		elem := v.Type().Elem()
+		if elem.Kind() == reflect.Struct && elem != timeType && !isTermType(elem) {
+			key := structKey{subject: t, typ: elem}
+			if ptr, ok := u.pointers[key]; ok {
+				v.Set(ptr)
+				return nil
+			}
+			ptr := reflect.New(elem)
+			u.pointers[key] = ptr
+			v.Set(ptr)
+			return u.unmarshalStruct(t, ptr.Elem())
+		}
+		ptr := reflect.New(elem)
+		if err := u.set(ptr.Elem(), t); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+	if v.Kind() == reflect.Struct && v.Type() != timeType {
+		if _, ok := t.(Literal); ok {
+			return fmt.Errorf("cannot set %s from %s", v.Type(), renderTerm(t))
+		}
+		return u.unmarshalStruct(t, v)
+	}
+	var lexical string
+	switch t := t.(type) {
+	case Literal:
+		lexical = t.Lexical
+	case IRI:
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("cannot set %s from %s", v.Type(), renderTerm(t))
+		}
+		v.SetString(t.Value)
+		return nil
+	default:
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("cannot set %s from %s", v.Type(), renderTerm(t))
+		}
+		v.SetString(t.String())
+		return nil
+	}
+	var err error
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(lexical)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(lexical); err == nil {
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(strings.TrimPrefix(lexical, "+"), 10, v.Type().Bits()); err == nil {
+			v.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if n, err = strconv.ParseUint(strings.TrimPrefix(lexical, "+"), 10, v.Type().Bits()); err == nil {
+			v.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(lexical, v.Type().Bits()); err == nil {
+			v.SetFloat(f)
+		}
+	case reflect.Struct: // time.Time
+		var tm time.Time
+		if tm, err = time.Parse(time.RFC3339Nano, lexical); err != nil {
+			tm, err = time.Parse(time.DateOnly, lexical)
+		}
+		if err == nil {
+			v.Set(reflect.ValueOf(tm))
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("cannot set %s from %s: %w", v.Type(), renderTerm(t), err)
+	}
+	return nil
+}
+
+// literalsWithLang returns the literals of objects whose language tag,
+// with its base direction, is lang ignoring ASCII case.
+func literalsWithLang(objects []Term, lang string) []Term {
+	var matching []Term
+	for _, o := range objects {
+		if l, ok := o.(Literal); ok && l.Lang != "" && strings.EqualFold(l.langTag(), lang) {
+			matching = append(matching, o)
+		}
+	}
+	return matching
+}
@@ -0,0 +1,164 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type marshalPerson struct {
+	ID       IRI              `rdf:"@id"`
+	Type     IRI              `rdf:"@type"`
+	Name     string           `rdf:"foaf:name"`
+	Label    string           `rdf:"rdfs:label,lang=en,omitempty"`
+	Age      int              `rdf:"foaf:age"`
+	Born     time.Time        `rdf:"ex:born,omitempty"`
+	Homepage string           `rdf:"foaf:homepage,iri,omitempty"`
+	Knows    []*marshalPerson `rdf:"foaf:knows"`
+	Scores   []float64        `rdf:"<http://example.org/scores>,list"`
+	Address  *marshalAddress  `rdf:"ex:address"`
+	Note     string
+	Skipped  string `rdf:"-"`
+}
+
+type marshalAddress struct {
+	City string `rdf:"ex:city"`
+}
+
+func TestMarshal(t *testing.T) {
+	alice := &marshalPerson{
+		ID:       IRI{Value: "http://example.org/alice"},
+		Type:     IRI{Value: "http://xmlns.com/foaf/0.1/Person"},
+		Name:     "Alice",
+		Age:      42,
+		Homepage: "http://alice.example/",
+		Scores:   []float64{1.5, 2},
+		Address:  &marshalAddress{City: "Paris"},
+		Note:     "not in RDF",
+	}
+	bob := &marshalPerson{ID: IRI{Value: "http://example.org/bob"}, Name: "Bob", Label: "Bob", Knows: []*marshalPerson{alice}}
+	alice.Knows = []*marshalPerson{bob}
+
+	ex := OptMarshalPrefixes(map[string]string{"ex": "http://example.org/"})
+	stmts, err := Marshal(alice, ex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, s := range stmts {
+		text, err := s.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, string(text))
+	}
+	got := strings.Join(lines, "\n")
+	want := strings.Join([]string{
+		`<http://example.org/alice> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://xmlns.com/foaf/0.1/Person> .`,
+		`<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice" .`,
+		`<http://example.org/alice> <http://xmlns.com/foaf/0.1/age> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+		`<http://example.org/alice> <http://xmlns.com/foaf/0.1/homepage> <http://alice.example/> .`,
+		`<http://example.org/bob> <http://xmlns.com/foaf/0.1/name> "Bob" .`,
+		`<http://example.org/bob> <http://www.w3.org/2000/01/rdf-schema#label> "Bob"@en .`,
+		`<http://example.org/bob> <http://xmlns.com/foaf/0.1/age> "0"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+		`<http://example.org/bob> <http://xmlns.com/foaf/0.1/knows> <http://example.org/alice> .`,
+		`<http://example.org/bob> <http://example.org/scores> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .`,
+		`<http://example.org/alice> <http://xmlns.com/foaf/0.1/knows> <http://example.org/bob> .`,
+		`<http://example.org/alice> <http://example.org/scores> _:b1 .`,
+		`_:b1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "1.5E0"^^<http://www.w3.org/2001/XMLSchema#double> .`,
+		`_:b1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> _:b2 .`,
+		`_:b2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "2.0E0"^^<http://www.w3.org/2001/XMLSchema#double> .`,
+		`_:b2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .`,
+		`_:b3 <http://example.org/city> "Paris" .`,
+		`<http://example.org/alice> <http://example.org/address> _:b3 .`,
+	}, "\n")
+	if got != want {
+		t.Fatalf("Marshal:\n%s\nwant:\n%s", got, want)
+	}
+
+	var decoded marshalPerson
+	decoded.ID = alice.ID
+	if err := Unmarshal(stmts, &decoded, ex); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Name != "Alice" || decoded.Age != 42 || decoded.Type != alice.Type || decoded.Homepage != alice.Homepage ||
+		len(decoded.Scores) != 2 || decoded.Scores[0] != 1.5 || decoded.Address == nil || decoded.Address.City != "Paris" {
+		t.Fatalf("Unmarshal: %+v", decoded)
+	}
+	if len(decoded.Knows) != 1 || decoded.Knows[0].Name != "Bob" || decoded.Knows[0].Label != "Bob" {
+		t.Fatalf("Unmarshal knows: %+v", decoded.Knows)
+	}
+	if back := decoded.Knows[0].Knows; len(back) != 1 || back[0] != &decoded {
+		t.Fatalf("Unmarshal cycle: %+v", back)
+	}
+}
+
+func TestUnmarshalSubject(t *testing.T) {
+	type doc struct {
+		ID      string    `rdf:"@id"`
+		Title   string    `rdf:"dcterms:title,lang=fr"`
+		Created time.Time `rdf:"dcterms:created"`
+		Pages   uint16    `rdf:"<http://example.org/pages>"`
+		Tags    []Term    `rdf:"dcterms:subject"`
+	}
+	stmts := readScoped(t, `
+@prefix dcterms: <http://purl.org/dc/terms/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.org/doc> dcterms:title "Title"@en, "Titre"@fr ;
+  dcterms:created "2024-05-01"^^xsd:date ;
+  <http://example.org/pages> 12 ;
+  dcterms:subject <http://example.org/rdf>, "go" .
+`, FormatTurtle)
+	var d doc
+	if err := Unmarshal(stmts, &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.ID != "http://example.org/doc" || d.Title != "Titre" || d.Pages != 12 || len(d.Tags) != 2 ||
+		!d.Created.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unmarshal: %+v", d)
+	}
+	again, err := Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again[0].S != (IRI{Value: "http://example.org/doc"}) || again[0].O != (Literal{Lexical: "Titre", Lang: "fr"}) {
+		t.Fatalf("Marshal: %v", again)
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	if _, err := Marshal(struct {
+		Name string `rdf:"name"`
+	}{}); err == nil || !strings.Contains(err.Error(), `"name"`) {
+		t.Errorf("predicate without prefix: %v", err)
+	}
+	if _, err := Marshal(struct {
+		Data map[string]string `rdf:"ex:data"`
+	}{}, OptMarshalPrefixes(map[string]string{"ex": "http://example.org/"})); err == nil {
+		t.Error("Marshal accepted a map field")
+	}
+	if _, err := Marshal(struct {
+		ID int `rdf:"@id"`
+	}{}); err == nil {
+		t.Error("Marshal accepted an int @id field")
+	}
+	if _, err := Marshal("text"); err == nil {
+		t.Error("Marshal accepted a string")
+	}
+
+	stmts := []Statement{
+		NewTriple(IRI{Value: "http://example.org/a"}, IRI{Value: "http://xmlns.com/foaf/0.1/age"}, Literal{Lexical: "old"}),
+		NewTriple(IRI{Value: "http://example.org/b"}, IRI{Value: "http://xmlns.com/foaf/0.1/age"}, Literal{Lexical: "1"}),
+	}
+	var p marshalPerson
+	if err := Unmarshal(stmts, &p); err == nil || !strings.Contains(err.Error(), "2 subjects") {
+		t.Errorf("ambiguous subject: %v", err)
+	}
+	p.ID = IRI{Value: "http://example.org/a"}
+	if err := Unmarshal(stmts, &p); err == nil || !strings.Contains(err.Error(), "marshalPerson.Age") {
+		t.Errorf("invalid integer: %v", err)
+	}
+	if err := Unmarshal(stmts, p); err == nil {
+		t.Error("Unmarshal accepted a struct value")
+	}
+}
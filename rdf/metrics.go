@@ -0,0 +1,196 @@
+package rdf
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultProgressInterval is the default interval between OptProgress
+// calls.
+const DefaultProgressInterval = time.Second
+
+// progressCheckEvery is the number of statements between checks of the
+// progress interval, which keeps reading the clock off the hot path.
+const progressCheckEvery = 256
+
+// ProgressInfo reports the progress of a reader to an OptProgress callback.
+type ProgressInfo struct {
+	// BytesRead is the number of bytes read from the input so far, before
+	// decompression.
+	BytesRead int64
+	// Statements is the number of statements returned so far.
+	Statements int64
+	// Elapsed is the time since the reader was created.
+	Elapsed time.Duration
+	// Done is set in the last call, made when the input ends.
+	Done bool
+}
+
+// StatementsPerSecond returns the average rate of statements since the
+// reader was created.
+func (p ProgressInfo) StatementsPerSecond() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Statements) / p.Elapsed.Seconds()
+}
+
+// Metrics is a snapshot of the counters of a reader or writer, for
+// dashboards and monitoring. Its fields are cumulative counters that map
+// directly to Prometheus counters, and it marshals to JSON, so it can be
+// published with expvar:
+//
+//	expvar.Publish("load", expvar.Func(func() any {
+//		return reader.(rdf.MetricsReporter).Metrics()
+//	}))
+type Metrics struct {
+	// BytesRead is the number of bytes a reader has read from its input,
+	// before decompression.
+	BytesRead int64 `json:"bytesRead"`
+	// BytesWritten is the number of bytes a writer has passed to its
+	// output, after compression. Buffered output is counted once flushed.
+	BytesWritten int64 `json:"bytesWritten"`
+	// Statements is the number of statements a reader has returned or a
+	// writer has been given.
+	Statements int64 `json:"statements"`
+	// Elapsed is the time since the reader or writer was created, up to
+	// the end of the input or the Close of the writer.
+	Elapsed time.Duration `json:"elapsedNanoseconds"`
+	// Done reports whether the input has ended or the writer is closed.
+	Done bool `json:"done"`
+}
+
+// MetricsReporter is implemented by readers returned from NewReader and
+// writers returned from NewWriter. Metrics may be called from any
+// goroutine, while another reads or writes statements.
+type MetricsReporter interface {
+	Metrics() Metrics
+}
+
+// OptProgress sets a function called with the progress of a reader at
+// most every OptProgressInterval (DefaultProgressInterval by default), and
+// once more with Done set when the input ends. The interval is checked
+// every few hundred statements, so calls may be further apart when
+// statements are slow to read. fn is called from the goroutine calling
+// Next.
+func OptProgress(fn func(ProgressInfo)) Option {
+	return func(opts *Options) {
+		opts.Progress = fn
+	}
+}
+
+// OptProgressInterval sets the interval between OptProgress calls.
+func OptProgressInterval(d time.Duration) Option {
+	return func(opts *Options) {
+		opts.ProgressInterval = d
+	}
+}
+
+// streamMetrics holds the counters of a reader or writer, updated
+// atomically so that Metrics can be called concurrently.
+type streamMetrics struct {
+	start      time.Time
+	bytes      atomic.Int64
+	statements atomic.Int64
+	end        atomic.Int64 // Nanoseconds since start at the end, 0 before
+
+	byteInput *countingByteReader // Input counting bytes read one at a time, or nil
+}
+
+func newStreamMetrics() *streamMetrics {
+	return &streamMetrics{start: time.Now()}
+}
+
+// finish records the end of the stream once.
+func (m *streamMetrics) finish() {
+	m.end.CompareAndSwap(0, max(int64(time.Since(m.start)), 1))
+}
+
+// publishBytes adds the bytes read one at a time since the last call to the
+// counter. It is called from the goroutine reading the input.
+func (m *streamMetrics) publishBytes() {
+	if c := m.byteInput; c != nil && c.pending != 0 {
+		m.bytes.Add(c.pending)
+		c.pending = 0
+	}
+}
+
+func (m *streamMetrics) elapsed() (time.Duration, bool) {
+	if end := m.end.Load(); end != 0 {
+		return time.Duration(end), true
+	}
+	return time.Since(m.start), false
+}
+
+// progress tracks when a reader last called its OptProgress function.
+type progress struct {
+	fn       func(ProgressInfo)
+	interval time.Duration
+	last     time.Duration // Elapsed time of the last call
+	done     bool
+}
+
+// report calls fn if the interval has passed, or with Done set if done.
+func (p *progress) report(m *streamMetrics, done bool) {
+	if p.done {
+		return
+	}
+	if done {
+		m.finish()
+	}
+	elapsed, _ := m.elapsed()
+	if !done && elapsed-p.last < p.interval {
+		return
+	}
+	p.last, p.done = elapsed, done
+	p.fn(ProgressInfo{BytesRead: m.bytes.Load(), Statements: m.statements.Load(), Elapsed: elapsed, Done: done})
+}
+
+// newCountingReader returns the input of a reader counting the bytes read
+// from r into m. The input of OpenMapped counts its own bytes, so that the
+// N-Triples and N-Quads decoders still find it and take lines in place, and
+// an io.ByteReader stays one, so that RDF/XML input is not buffered again.
+func newCountingReader(r io.Reader, m *streamMetrics) io.Reader {
+	switch r := r.(type) {
+	case *mappedInput:
+		r.metrics = m
+		return r
+	case interface {
+		io.Reader
+		io.ByteReader
+	}:
+		m.byteInput = &countingByteReader{countingReader: countingReader{r: r, m: m}, br: r}
+		return m.byteInput
+	}
+	return &countingReader{r: r, m: m}
+}
+
+// countingReader counts the bytes read from the input of a reader.
+type countingReader struct {
+	r io.Reader
+	m *streamMetrics
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.m.bytes.Add(int64(n))
+	return n, err
+}
+
+// countingByteReader is a countingReader over an io.ByteReader. Bytes read
+// one at a time are kept pending until the reader publishes them with each
+// statement, which keeps atomic operations off the per-byte path.
+type countingByteReader struct {
+	countingReader
+	br      io.ByteReader
+	pending int64
+}
+
+func (c *countingByteReader) ReadByte() (byte, error) {
+	b, err := c.br.ReadByte()
+	if err == nil {
+		c.pending++
+	}
+	return b, err
+}
@@ -0,0 +1,116 @@
+package rdf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptProgress(t *testing.T) {
+	var input strings.Builder
+	const n = 3 * progressCheckEvery
+	for i := range n {
+		fmt.Fprintf(&input, "<http://example.org/s%d> <http://example.org/p> \"%d\" .\n", i, i)
+	}
+	var calls []ProgressInfo
+	r, err := NewReader(strings.NewReader(input.String()), FormatNTriples,
+		OptProgress(func(p ProgressInfo) { calls = append(calls, p) }),
+		OptProgressInterval(time.Nanosecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	for {
+		if _, err := r.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.Next()
+	if len(calls) != 4 {
+		t.Fatalf("progress calls = %d, want 4: %+v", len(calls), calls)
+	}
+	for i, p := range calls[:3] {
+		if p.Statements != int64(i+1)*progressCheckEvery || p.Done {
+			t.Errorf("call %d = %+v", i, p)
+		}
+	}
+	last := calls[3]
+	if !last.Done || last.Statements != n || last.BytesRead != int64(input.Len()) || last.Elapsed <= 0 {
+		t.Errorf("last call = %+v, want Done with %d statements and %d bytes", last, n, input.Len())
+	}
+
+	m := r.(MetricsReporter).Metrics()
+	if !m.Done || m.Statements != n || m.BytesRead != int64(input.Len()) || m.Elapsed != last.Elapsed {
+		t.Errorf("Metrics() = %+v, last progress %+v", m, last)
+	}
+}
+
+func TestProgressInterval(t *testing.T) {
+	input := strings.Repeat("<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n", 2*progressCheckEvery)
+	calls := 0
+	r, _ := NewReader(strings.NewReader(input), FormatNTriples, OptProgress(func(ProgressInfo) { calls++ }), OptProgressInterval(time.Hour))
+	defer r.Close()
+	for {
+		if _, err := r.Next(); err != nil {
+			break
+		}
+	}
+	if calls != 1 {
+		t.Errorf("progress calls = %d, want only the final one", calls)
+	}
+}
+
+func TestReaderMetricsByteReader(t *testing.T) {
+	// RDF/XML reads a strings.Reader byte by byte.
+	r, err := NewReader(strings.NewReader(benchRDFXMLInput), FormatRDFXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := r.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if m := r.(MetricsReporter).Metrics(); m.BytesRead <= 0 || m.BytesRead >= int64(len(benchRDFXMLInput)) {
+		t.Errorf("BytesRead after one statement = %d", m.BytesRead)
+	}
+	for {
+		if _, err := r.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if m := r.(MetricsReporter).Metrics(); m.BytesRead != int64(len(benchRDFXMLInput)) {
+		t.Errorf("BytesRead = %d, want %d", m.BytesRead, len(benchRDFXMLInput))
+	}
+}
+
+func TestWriterMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatNQuads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt := Statement{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}}
+	for range 3 {
+		w.Write(stmt)
+	}
+	if m := w.(MetricsReporter).Metrics(); m.Statements != 3 || m.Done {
+		t.Errorf("Metrics() before Close = %+v", m)
+	}
+	w.Close()
+	m := w.(MetricsReporter).Metrics()
+	if m.Statements != 3 || !m.Done || m.BytesWritten != int64(buf.Len()) || m.BytesRead != 0 {
+		t.Errorf("Metrics() after Close = %+v, %d bytes written", m, buf.Len())
+	}
+	data, err := json.Marshal(m)
+	if err != nil || !strings.Contains(string(data), `"statements":3`) {
+		t.Errorf("json.Marshal(Metrics) = %s, %v", data, err)
+	}
+}
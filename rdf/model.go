@@ -1,6 +1,9 @@
 package rdf
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // TermKind identifies RDF term types.
 type TermKind uint8
@@ -14,6 +17,8 @@ const (
 	TermLiteral
 	// TermTriple represents an RDF-star triple term.
 	TermTriple
+	// TermVariable represents a Variable of a TriplePattern.
+	TermVariable
 )
 
 // Term is a value that can appear in RDF statements.
@@ -54,6 +59,9 @@ type Literal struct {
 	Datatype IRI
 	// Lang is the language tag, if any.
 	Lang string
+	// Direction is the RDF 1.2 base direction of a language-tagged string,
+	// "ltr" or "rtl", if any. Such literals have datatype rdf:dirLangString.
+	Direction string
 }
 
 // Kind returns TermLiteral.
@@ -62,7 +70,7 @@ func (l Literal) Kind() TermKind { return TermLiteral }
 // String returns a string representation of the literal.
 func (l Literal) String() string {
 	if l.Lang != "" {
-		return fmt.Sprintf("%q@%s", l.Lexical, l.Lang)
+		return fmt.Sprintf("%q@%s", l.Lexical, l.langTag())
 	}
 	if l.Datatype.Value != "" {
 		return fmt.Sprintf("%q^^<%s>", l.Lexical, l.Datatype.Value)
@@ -70,6 +78,15 @@ func (l Literal) String() string {
 	return fmt.Sprintf("%q", l.Lexical)
 }
 
+// langTag returns the language tag with the base direction appended in
+// Turtle and N-Triples syntax ("en--ltr").
+func (l Literal) langTag() string {
+	if l.Direction != "" {
+		return l.Lang + "--" + l.Direction
+	}
+	return l.Lang
+}
+
 // TripleTerm is an RDF-star quoted triple term.
 type TripleTerm struct {
 	// S is the subject of the quoted triple.
@@ -131,6 +148,34 @@ func (s Statement) AsQuad() Quad {
 	return Quad{S: s.S, P: s.P, O: s.O, G: s.G}
 }
 
+// Clone returns a copy of the statement whose strings share no memory with
+// it. Statements read by OpenMapped must be cloned to be kept after their
+// reader is closed.
+func (s Statement) Clone() Statement {
+	return Statement{S: cloneTerm(s.S), P: IRI{Value: strings.Clone(s.P.Value)}, O: cloneTerm(s.O), G: cloneTerm(s.G)}
+}
+
+// cloneTerm returns a copy of t with cloned strings. Term implementations
+// of other packages are returned as they are.
+func cloneTerm(t Term) Term {
+	switch t := t.(type) {
+	case IRI:
+		return IRI{Value: strings.Clone(t.Value)}
+	case BlankNode:
+		return BlankNode{ID: strings.Clone(t.ID)}
+	case Literal:
+		return Literal{
+			Lexical:   strings.Clone(t.Lexical),
+			Datatype:  IRI{Value: strings.Clone(t.Datatype.Value)},
+			Lang:      strings.Clone(t.Lang),
+			Direction: strings.Clone(t.Direction),
+		}
+	case TripleTerm:
+		return TripleTerm{S: cloneTerm(t.S), P: IRI{Value: strings.Clone(t.P.Value)}, O: cloneTerm(t.O)}
+	}
+	return t
+}
+
 // ToStatement converts a triple to a statement.
 func (t Triple) ToStatement() Statement {
 	return Statement{S: t.S, P: t.P, O: t.O, G: nil}
@@ -2,18 +2,29 @@ package rdf
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
 )
 
 // Triple decoder for N-Triples
 type nttripleDecoder struct {
-	reader      *bufio.Reader
+	reader      *bufio.Reader    // Input of OptArena, or nil
+	lines       *blockLineReader // Input otherwise
 	err         error
 	opts        decodeOptions
-	lineNum     int   // Current line number (1-based)
-	tripleCount int64 // Number of triples processed
+	lineNum     int          // Current line number (1-based)
+	offset      int          // Byte offset of the next line
+	tripleCount int64        // Number of triples processed
+	mapped      *mappedInput // Input read in place by OpenMapped, or nil
+	line        []byte       // Line buffer reused with OptArena
+	terms       *termArena   // Terms reused with OptArena and for lines, or nil
+	owned       *ntTermCache // Copies the terms of lines out of their block, or nil
 }
 
 func newNTriplestripleDecoder(r io.Reader) tripleDecoder {
@@ -21,12 +32,21 @@ func newNTriplestripleDecoder(r io.Reader) tripleDecoder {
 }
 
 func newNTriplestripleDecoderWithOptions(r io.Reader, opts decodeOptions) tripleDecoder {
-	return &nttripleDecoder{
-		reader:      bufio.NewReader(r),
+	d := &nttripleDecoder{
 		opts:        normalizeDecodeOptions(opts),
 		lineNum:     0,
 		tripleCount: 0,
 	}
+	d.lineNum, d.offset = ntResumePosition(opts.resume)
+	d.mapped, _ = r.(*mappedInput)
+	switch {
+	case d.mapped != nil:
+	case d.opts.arena:
+		d.reader, d.terms = bufio.NewReader(r), &termArena{}
+	default:
+		d.lines, d.terms, d.owned = newBlockLineReader(r), &termArena{}, newNTTermCache()
+	}
+	return d
 }
 
 func (d *nttripleDecoder) Next() (Triple, error) {
@@ -40,10 +60,13 @@ func (d *nttripleDecoder) Next() (Triple, error) {
 			if err == io.EOF {
 				return Triple{}, io.EOF
 			}
-			d.err = err
-			return Triple{}, err
+			d.err = wrapParseErrorWithPosition("ntriples", "", d.lineNum+1, 0, d.offset, err)
+			return Triple{}, d.err
 		}
 		d.lineNum++
+		raw := line
+		lineOffset := d.offset
+		d.offset += len(raw)
 		line = strings.TrimSpace(line)
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -51,34 +74,57 @@ func (d *nttripleDecoder) Next() (Triple, error) {
 
 		// Check triple count limit
 		if d.opts.MaxTriples > 0 && d.tripleCount >= d.opts.MaxTriples {
-			err := wrapParseErrorWithPosition("ntriples", line, d.lineNum, 0, -1, ErrTripleLimitExceeded)
+			err := wrapNTLineError("ntriples", raw, d.lineNum, lineOffset, ErrTripleLimitExceeded)
 			d.err = err
 			return Triple{}, err
 		}
 
-		triple, err := parseNTTripleLine(line)
+		triple, err := parseNTTripleLine(line, d.opts.generalized, d.terms)
 		if err != nil {
-			err = wrapParseErrorWithPosition("ntriples", line, d.lineNum, 0, -1, err)
+			err = wrapNTLineError("ntriples", raw, d.lineNum, lineOffset, err)
+			if d.opts.errors.recover(err) {
+				continue
+			}
 			d.err = err
 			return Triple{}, err
 		}
+		if d.owned != nil {
+			d.owned.ownTriple(&triple, raw)
+		}
 		d.tripleCount++
 		return triple, nil
 	}
 }
 
+func (d *nttripleDecoder) statementLine() int { return d.lineNum }
+
+func (d *nttripleDecoder) exportState() (DecoderState, error) {
+	return ntLineState(d.err, d.lineNum, d.offset)
+}
+
 func (d *nttripleDecoder) Err() error { return d.err }
 func (d *nttripleDecoder) Close() error {
+	if d.lines != nil {
+		d.lines.release()
+		d.owned.release()
+		d.lines, d.owned = nil, nil
+	}
 	return nil
 }
 
 // Quad decoder for N-Quads
 type ntquadDecoder struct {
-	reader    *bufio.Reader
+	reader    *bufio.Reader    // Input of OptArena, or nil
+	lines     *blockLineReader // Input otherwise
 	err       error
 	opts      decodeOptions
-	lineNum   int   // Current line number (1-based)
-	quadCount int64 // Number of quads processed
+	lineNum   int          // Current line number (1-based)
+	offset    int          // Byte offset of the next line
+	quadCount int64        // Number of quads processed
+	mapped    *mappedInput // Input read in place by OpenMapped, or nil
+	line      []byte       // Line buffer reused with OptArena
+	terms     *termArena   // Terms reused with OptArena and for lines, or nil
+	owned     *ntTermCache // Copies the terms of lines out of their block, or nil
 }
 
 func newNQuadsquadDecoder(r io.Reader) quadDecoder {
@@ -86,12 +132,39 @@ func newNQuadsquadDecoder(r io.Reader) quadDecoder {
 }
 
 func newNQuadsquadDecoderWithOptions(r io.Reader, opts decodeOptions) quadDecoder {
-	return &ntquadDecoder{
-		reader:    bufio.NewReader(r),
+	d := &ntquadDecoder{
 		opts:      normalizeDecodeOptions(opts),
 		lineNum:   0,
 		quadCount: 0,
 	}
+	d.lineNum, d.offset = ntResumePosition(opts.resume)
+	d.mapped, _ = r.(*mappedInput)
+	switch {
+	case d.mapped != nil:
+	case d.opts.arena:
+		d.reader, d.terms = bufio.NewReader(r), &termArena{}
+	default:
+		d.lines, d.terms, d.owned = newBlockLineReader(r), &termArena{}, newNTTermCache()
+	}
+	return d
+}
+
+// ntResumePosition returns the number of lines before and the byte offset
+// of the line a resumed N-Triples/N-Quads decoder starts at.
+func ntResumePosition(state *DecoderState) (lineNum, offset int) {
+	if state == nil {
+		return 0, 0
+	}
+	return max(state.Line-1, 0), int(state.Offset)
+}
+
+// ntLineState is the state of a line-based decoder about to read the line
+// following lineNum lines, at offset.
+func ntLineState(err error, lineNum, offset int) (DecoderState, error) {
+	if err != nil {
+		return DecoderState{}, fmt.Errorf("rdf: no resumable state after error: %w", err)
+	}
+	return DecoderState{Offset: int64(offset), Line: lineNum + 1, Column: 1}, nil
 }
 
 func (d *ntquadDecoder) Next() (Quad, error) {
@@ -105,10 +178,13 @@ func (d *ntquadDecoder) Next() (Quad, error) {
 			if err == io.EOF {
 				return Quad{}, io.EOF
 			}
-			d.err = err
-			return Quad{}, err
+			d.err = wrapParseErrorWithPosition("nquads", "", d.lineNum+1, 0, d.offset, err)
+			return Quad{}, d.err
 		}
 		d.lineNum++
+		raw := line
+		lineOffset := d.offset
+		d.offset += len(raw)
 		line = strings.TrimSpace(line)
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -116,41 +192,195 @@ func (d *ntquadDecoder) Next() (Quad, error) {
 
 		// Check quad count limit
 		if d.opts.MaxTriples > 0 && d.quadCount >= d.opts.MaxTriples {
-			err := wrapParseErrorWithPosition("nquads", line, d.lineNum, 0, -1, ErrTripleLimitExceeded)
+			err := wrapNTLineError("nquads", raw, d.lineNum, lineOffset, ErrTripleLimitExceeded)
 			d.err = err
 			return Quad{}, err
 		}
 
-		quad, err := parseNTQuadLine(line)
+		quad, err := parseNTQuadLine(line, d.opts.generalized, d.terms)
 		if err != nil {
-			err = wrapParseErrorWithPosition("nquads", line, d.lineNum, 0, -1, err)
+			err = wrapNTLineError("nquads", raw, d.lineNum, lineOffset, err)
+			if d.opts.errors.recover(err) {
+				continue
+			}
 			d.err = err
 			return Quad{}, err
 		}
+		if d.owned != nil {
+			d.owned.ownQuad(&quad, raw)
+		}
 		d.quadCount++
 		return quad, nil
 	}
 }
 
+func (d *ntquadDecoder) statementLine() int { return d.lineNum }
+
+func (d *ntquadDecoder) exportState() (DecoderState, error) {
+	return ntLineState(d.err, d.lineNum, d.offset)
+}
+
 func (d *ntquadDecoder) Err() error { return d.err }
 func (d *ntquadDecoder) Close() error {
+	if d.lines != nil {
+		d.lines.release()
+		d.owned.release()
+		d.lines, d.owned = nil, nil
+	}
 	return nil
 }
 
 // Shared readLine method
 func (d *nttripleDecoder) readLine() (string, error) {
-	return readLineWithLimit(d.reader, d.opts.MaxLineBytes)
+	if d.mapped != nil {
+		return d.mapped.readLine(d.opts.MaxLineBytes)
+	}
+	if d.opts.arena {
+		return readLineReuse(d.reader, &d.line, d.opts.MaxLineBytes)
+	}
+	if d.lines == nil { // Closed
+		return "", io.EOF
+	}
+	return d.lines.readLine(d.opts.MaxLineBytes)
 }
 
 func (d *ntquadDecoder) readLine() (string, error) {
-	return readLineWithLimit(d.reader, d.opts.MaxLineBytes)
+	if d.mapped != nil {
+		return d.mapped.readLine(d.opts.MaxLineBytes)
+	}
+	if d.opts.arena {
+		return readLineReuse(d.reader, &d.line, d.opts.MaxLineBytes)
+	}
+	if d.lines == nil { // Closed
+		return "", io.EOF
+	}
+	return d.lines.readLine(d.opts.MaxLineBytes)
 }
-func parseNTTripleLine(line string) (Triple, error) {
-	cursor, subject, predicate, object, err := parseNTCore(line, "N-Triples")
+
+// ntTermCacheSize is the number of recent IRIs and blank nodes an
+// ntTermCache keeps, a power of two.
+const ntTermCacheSize = 256
+
+// ntTermCache makes the statements parsed from a line of a blockLineReader
+// independent of the block, which the next line may overwrite. IRIs and
+// blank nodes seen recently, such as repeated subjects, predicates and
+// datatypes, are looked up in a small table and shared rather than copied.
+// Other terms are copied along with a copy of the line, made at most once
+// per line, which keeps only the line alive rather than the block.
+type ntTermCache struct {
+	recent [ntTermCacheSize]Term // IRIs and blank nodes by ntTermSlot of their value
+	line   string                // Line of the statement being copied
+	copied string                // Copy of line, or "" until needed
+}
+
+// ntTermCaches holds the ntTermCaches of closed decoders.
+var ntTermCaches = sync.Pool{New: func() any { return new(ntTermCache) }}
+
+// newNTTermCache returns an empty ntTermCache from ntTermCaches.
+func newNTTermCache() *ntTermCache {
+	return ntTermCaches.Get().(*ntTermCache)
+}
+
+// release clears c, so that it keeps no terms of its input alive, and puts
+// it in ntTermCaches.
+func (c *ntTermCache) release() {
+	*c = ntTermCache{}
+	ntTermCaches.Put(c)
+}
+
+// ntTermSlot returns the index in ntTermCache.recent of value, hashed from
+// its length and its last eight bytes, where IRIs with a common namespace
+// differ. The table has 2^8 slots.
+func ntTermSlot(value string) int {
+	h := uint64(len(value))
+	if s := value; len(s) >= 8 {
+		s = s[len(s)-8:]
+		h ^= uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+			uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[6])<<48 | uint64(s[7])<<56
+	} else {
+		for i := 0; i < len(s); i++ {
+			h = h<<8 | uint64(s[i])
+		}
+	}
+	return int((h * 0x9e3779b97f4a7c15) >> (64 - 8))
+}
+
+// ownTriple makes t, parsed from line, independent of the block.
+func (c *ntTermCache) ownTriple(t *Triple, line string) {
+	c.line, c.copied = line, ""
+	t.S, t.P.Value, t.O = c.own(t.S), c.iriValue(t.P.Value), c.own(t.O)
+}
+
+// ownQuad makes q, parsed from line, independent of the block.
+func (c *ntTermCache) ownQuad(q *Quad, line string) {
+	c.line, c.copied = line, ""
+	q.S, q.P.Value, q.O = c.own(q.S), c.iriValue(q.P.Value), c.own(q.O)
+	if q.G != nil {
+		q.G = c.own(q.G)
+	}
+}
+
+// own returns t, or a copy of t, that does not refer to the block.
+func (c *ntTermCache) own(t Term) Term {
+	switch t := t.(type) {
+	case IRI:
+		slot := &c.recent[ntTermSlot(t.Value)]
+		if recent, ok := (*slot).(IRI); ok && recent.Value == t.Value {
+			return *slot
+		}
+		*slot = IRI{Value: c.rebase(t.Value)}
+		return *slot
+	case BlankNode:
+		slot := &c.recent[ntTermSlot(t.ID)]
+		if recent, ok := (*slot).(BlankNode); ok && recent.ID == t.ID {
+			return *slot
+		}
+		*slot = BlankNode{ID: c.rebase(t.ID)}
+		return *slot
+	case Literal:
+		t.Lexical, t.Lang, t.Direction = c.rebase(t.Lexical), c.rebase(t.Lang), c.rebase(t.Direction)
+		if t.Datatype.Value != "" {
+			t.Datatype.Value = c.iriValue(t.Datatype.Value)
+		}
+		return t
+	case TripleTerm:
+		return TripleTerm{S: c.own(t.S), P: IRI{Value: c.iriValue(t.P.Value)}, O: c.own(t.O)}
+	}
+	return t
+}
+
+// iriValue returns the value of a recent IRI equal to value, or a copy of
+// value.
+func (c *ntTermCache) iriValue(value string) string {
+	slot := &c.recent[ntTermSlot(value)]
+	if recent, ok := (*slot).(IRI); ok && recent.Value == value {
+		return recent.Value
+	}
+	iri := IRI{Value: c.rebase(value)}
+	*slot = iri
+	return iri.Value
+}
+
+// rebase returns s, or the same bytes in the copy of the line if s points
+// into the line. Unescaped strings are already copies.
+func (c *ntTermCache) rebase(s string) string {
+	off := uintptr(unsafe.Pointer(unsafe.StringData(s))) - uintptr(unsafe.Pointer(unsafe.StringData(c.line)))
+	if s == "" || off >= uintptr(len(c.line)) {
+		return s
+	}
+	if c.copied == "" {
+		c.copied = strings.Clone(c.line)
+	}
+	return c.copied[off : off+uintptr(len(s))]
+}
+
+func parseNTTripleLine(line string, generalized bool, terms *termArena) (Triple, error) {
+	terms.reset()
+	cursor := &ntCursor{input: line, generalized: generalized, terms: terms}
+	subject, predicate, object, err := parseNTCore(cursor, "N-Triples")
 	if err != nil {
 		return Triple{}, err
 	}
-	cursor.skipWS()
 	if !cursor.consume('.') {
 		return Triple{}, cursor.errorf("expected '.' at end of statement")
 	}
@@ -171,12 +401,19 @@ func parseNTTripleLine(line string) (Triple, error) {
 	return Triple{S: subject, P: predicate, O: object}, nil
 }
 
-func parseNTQuadLine(line string) (Quad, error) {
-	cursor, subject, predicate, object, err := parseNTCore(line, "N-Quads")
+func parseNTQuadLine(line string, generalized bool, terms *termArena) (Quad, error) {
+	terms.reset()
+	cursor := &ntCursor{input: line, generalized: generalized, terms: terms}
+	subject, predicate, object, err := parseNTCore(cursor, "N-Quads")
 	if err != nil {
 		return Quad{}, err
 	}
-	graph := cursor.parseOptionalTerm()
+	var graph Term
+	if cursor.skipWS(); cursor.pos < len(cursor.input) && cursor.input[cursor.pos] != '.' {
+		if graph, err = cursor.parseTerm(cursor.generalized); err != nil {
+			return Quad{}, err
+		}
+	}
 	if graph != nil {
 		if _, ok := graph.(TripleTerm); ok {
 			return Quad{}, cursor.errorf("triple term cannot be used as graph name")
@@ -222,39 +459,47 @@ func parseNTQuadLine(line string) (Quad, error) {
 	return Quad{S: subject, P: predicate, O: object, G: graph}, nil
 }
 
-func parseNTCore(line string, context string) (*ntCursor, Term, IRI, Term, error) {
-	cursor := &ntCursor{input: line}
-	cursor.skipWS()
+func parseNTCore(cursor *ntCursor, context string) (Term, IRI, Term, error) {
 	subject, err := cursor.parseSubject()
 	if err != nil {
-		return cursor, nil, IRI{}, nil, err
+		return nil, IRI{}, nil, err
 	}
 	if _, ok := subject.(TripleTerm); ok {
-		return cursor, nil, IRI{}, nil, cursor.errorf("triple term cannot be used as subject in %s", context)
+		return nil, IRI{}, nil, cursor.errorf("triple term cannot be used as subject in %s", context)
 	}
 	// Predicate must be an IRI, not a triple term
 	cursor.skipWS()
 	if strings.HasPrefix(cursor.input[cursor.pos:], "<<") {
-		return cursor, nil, IRI{}, nil, cursor.errorf("triple term cannot be used as predicate")
+		return nil, IRI{}, nil, cursor.errorf("triple term cannot be used as predicate")
 	}
-	predicate, err := cursor.parseIRI()
-	if err != nil {
-		return cursor, nil, IRI{}, nil, err
+	var predicate IRI
+	if cursor.generalized && strings.HasPrefix(cursor.input[cursor.pos:], "_:") {
+		// Blank node predicates are IRIs starting with "_:", as with
+		// JSONLDOptions.ProduceGeneralizedRdf.
+		blank, err := cursor.parseBlankNode()
+		if err != nil {
+			return nil, IRI{}, nil, err
+		}
+		predicate = IRI{Value: blank.String()}
+	} else if predicate, err = cursor.parseIRI(); err != nil {
+		return nil, IRI{}, nil, err
 	}
 	object, err := cursor.parseObject()
 	if err != nil {
-		return cursor, nil, IRI{}, nil, err
+		return nil, IRI{}, nil, err
 	}
-	return cursor, subject, predicate, object, nil
+	return subject, predicate, object, nil
 }
 
 type ntCursor struct {
-	input string
-	pos   int
+	input       string
+	pos         int
+	generalized bool       // Accept literal subjects and graph names and blank node predicates
+	terms       *termArena // Holds the terms parsed with OptArena, or nil
 }
 
 func (c *ntCursor) skipWS() {
-	for c.pos < len(c.input) {
+	for c.pos < len(c.input) && c.input[c.pos] <= ' ' {
 		switch c.input[c.pos] {
 		case ' ', '\t', '\r', '\n':
 			c.pos++
@@ -274,16 +519,10 @@ func (c *ntCursor) consume(ch byte) bool {
 }
 
 func (c *ntCursor) parseSubject() (Term, error) {
-	c.skipWS()
-	term, err := c.parseTerm(false)
-	if err != nil {
-		return nil, err
-	}
-	return term, nil
+	return c.parseTerm(c.generalized)
 }
 
 func (c *ntCursor) parseObject() (Term, error) {
-	c.skipWS()
 	return c.parseTerm(true)
 }
 
@@ -309,14 +548,25 @@ func (c *ntCursor) parseTerm(allowLiteral bool) (Term, error) {
 		return c.parseTripleTerm()
 	case c.input[c.pos] == '<':
 		iri, err := c.parseIRI()
-		return iri, err
+		if err != nil {
+			return nil, err
+		}
+		return c.terms.iri(iri), nil
 	case strings.HasPrefix(c.input[c.pos:], "_:"):
-		return c.parseBlankNode()
+		blank, err := c.parseBlankNode()
+		if err != nil {
+			return nil, err
+		}
+		return c.terms.blankNode(blank), nil
 	case c.input[c.pos] == '"':
 		if !allowLiteral {
 			return nil, c.errorf("literal not allowed here")
 		}
-		return c.parseLiteral()
+		literal, err := c.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return c.terms.literal(literal), nil
 	default:
 		return nil, c.errorf("unexpected token")
 	}
@@ -330,86 +580,66 @@ func (c *ntCursor) parseIRI() (IRI, error) {
 	}
 	c.pos++ // Consume '<'
 	start := c.pos
-	for c.pos < len(c.input) && c.input[c.pos] != '>' {
-		ch := c.input[c.pos]
-		// Validate IRI characters - spaces, newlines, and control chars are invalid
-		if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' {
+	// Find the closing '>' and the bytes that need attention in one pass
+	// that tests eight bytes at a time, then check those over a lookup
+	// table.
+	rest := c.input[start:]
+	end := -1
+	for i := ntIRIScan(rest); i < len(rest); i += ntIRIScan(rest[i:]) {
+		if rest[i] == '>' {
+			end = i
+			break
+		}
+		if !ntIRISpecial[rest[i]] {
+			i++
+			continue
+		}
+		c.pos = start + i
+		// Spaces, newlines, and tabs are invalid
+		if rest[i] != '\\' {
 			return IRI{}, c.errorf("invalid character in IRI")
 		}
-		// Check for escape sequences - only Unicode escapes are allowed in IRIs, not other escapes
-		if ch == '\\' {
-			if c.pos+1 < len(c.input) {
-				next := c.input[c.pos+1]
-				if next == 'u' {
-					// Unicode escape \uXXXX - validate it has 4 valid hex digits
-					if c.pos+5 >= len(c.input) {
-						return IRI{}, c.errorf("invalid character in IRI")
-					}
-					for i := 2; i < 6; i++ {
-						hex := c.input[c.pos+i]
-						if !((hex >= '0' && hex <= '9') || (hex >= 'a' && hex <= 'f') || (hex >= 'A' && hex <= 'F')) {
-							return IRI{}, c.errorf("invalid character in IRI")
-						}
-					}
-					c.pos += 6
-					continue
-				} else if next == 'U' {
-					// Unicode escape \UXXXXXXXX - validate it has 8 valid hex digits
-					if c.pos+9 >= len(c.input) {
-						return IRI{}, c.errorf("invalid character in IRI")
-					}
-					for i := 2; i < 10; i++ {
-						hex := c.input[c.pos+i]
-						if !((hex >= '0' && hex <= '9') || (hex >= 'a' && hex <= 'f') || (hex >= 'A' && hex <= 'F')) {
-							return IRI{}, c.errorf("invalid character in IRI")
-						}
-					}
-					c.pos += 10
-					continue
-				} else {
-					// Other escape sequences (like \n, \t, etc.) are not allowed in IRIs
-					return IRI{}, c.errorf("invalid character in IRI")
-				}
-			}
+		// Only Unicode escapes are allowed in IRIs, not other escapes
+		if i+1 == len(rest) {
+			break
 		}
-		c.pos++
+		digits := 0
+		switch rest[i+1] {
+		case 'u':
+			digits = 4
+		case 'U':
+			digits = 8
+		default:
+			return IRI{}, c.errorf("invalid character in IRI")
+		}
+		if i+2+digits > len(rest) || !isHexDigits(rest[i+2:i+2+digits]) {
+			return IRI{}, c.errorf("invalid character in IRI")
+		}
+		i += 2 + digits
 	}
-	if c.pos >= len(c.input) {
+	if end < 0 {
+		c.pos = len(c.input)
 		return IRI{}, c.errorf("unterminated IRI")
 	}
-	value := c.input[start:c.pos]
-	c.pos++ // Advance past '>'
+	value := rest[:end]
+	c.pos = start + end + 1 // Advance past '>'
 
 	// Validate IRI value - reject relative IRIs
 	// IRIs must be absolute (have a scheme like http:, https:, etc.)
 	if strings.HasPrefix(value, "//") {
 		return IRI{}, c.errorf("invalid IRI: relative IRI without scheme")
 	}
-	// Check if it's a relative IRI (no scheme, just a path like <s> or <p>)
-	// Valid IRIs must have a scheme followed by :
+	// Check if it's a relative IRI (no scheme, just a path like <s> or <p>):
+	// valid IRIs start with scheme characters (letters, digits, +, -, .)
+	// followed by ':'
 	hasScheme := false
-	for i, ch := range value {
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
 		if ch == ':' {
-			// Found colon - check if there's a scheme before it
-			if i > 0 {
-				// Check if the part before : looks like a scheme (letters, digits, +, -, .)
-				scheme := value[:i]
-				validScheme := true
-				for _, sch := range scheme {
-					if !((sch >= 'a' && sch <= 'z') || (sch >= 'A' && sch <= 'Z') ||
-						(sch >= '0' && sch <= '9') || sch == '+' || sch == '-' || sch == '.') {
-						validScheme = false
-						break
-					}
-				}
-				if validScheme && len(scheme) > 0 {
-					hasScheme = true
-					break
-				}
-			}
+			hasScheme = i > 0
+			break
 		}
-		// Stop checking if we hit a non-scheme character
-		if ch == '/' || ch == '?' || ch == '#' {
+		if !isSchemeByte(ch) {
 			break
 		}
 	}
@@ -424,6 +654,53 @@ func (c *ntCursor) parseIRI() (IRI, error) {
 	return IRI{Value: value}, nil
 }
 
+// ntIRISpecial marks the bytes parseIRI must look at: whitespace, which is
+// invalid, and the backslash starting an escape.
+var ntIRISpecial = [256]bool{' ': true, '\t': true, '\n': true, '\r': true, '\\': true}
+
+// ntIRIScan returns the index of the first byte of s that may end an IRI
+// or be in ntIRISpecial (a byte below 0x21, a backslash or '>'), or len(s).
+// It tests eight bytes at a time with SWAR arithmetic on 64-bit words.
+func ntIRIScan(s string) int {
+	const (
+		ones  = 0x0101010101010101
+		highs = 0x8080808080808080
+	)
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		w := uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 | uint64(s[i+3])<<24 |
+			uint64(s[i+4])<<32 | uint64(s[i+5])<<40 | uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+		// Bytes below 0x21, and bytes equal to '\\' or '>', set their high bit.
+		below := (w - ones*0x21) &^ w
+		backslash := w ^ ones*'\\'
+		backslash = (backslash - ones) &^ backslash
+		closing := w ^ ones*'>'
+		closing = (closing - ones) &^ closing
+		if (below|backslash|closing)&highs != 0 {
+			break
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i] < 0x21 || s[i] == '\\' || s[i] == '>' {
+			return i
+		}
+	}
+	return i
+}
+
+func isSchemeByte(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '+' || ch == '-' || ch == '.'
+}
+
+func isHexDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isHexDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *ntCursor) parseBlankNode() (BlankNode, error) {
 	c.skipWS()
 	if !strings.HasPrefix(c.input[c.pos:], "_:") {
@@ -435,7 +712,7 @@ func (c *ntCursor) parseBlankNode() (BlankNode, error) {
 		return BlankNode{}, c.errorf("invalid blank node syntax")
 	}
 	start := c.pos
-	for c.pos < len(c.input) && !isTermDelimiter(c.input[c.pos]) {
+	for c.pos < len(c.input) && !ntTermDelimiter[c.input[c.pos]] {
 		// Blank node IDs cannot contain colons (except the initial _:)
 		if c.input[c.pos] == ':' {
 			return BlankNode{}, c.errorf("invalid blank node syntax")
@@ -453,82 +730,70 @@ func (c *ntCursor) parseLiteral() (Literal, error) {
 	if !c.consume('"') {
 		return Literal{}, c.errorf("expected literal")
 	}
-	// Collect the escaped string (with escape sequences intact)
-	// Track whether we're in an escape sequence to handle escaped quotes
-	var escapedBuilder strings.Builder
-	escapeNext := false
-	for c.pos < len(c.input) {
-		ch := c.input[c.pos]
-		if escapeNext {
-			// We're processing an escape sequence
-			escapedBuilder.WriteByte('\\')
-			escapedBuilder.WriteByte(ch)
-			c.pos++
-			escapeNext = false
-			// For unicode escapes, collect the hex digits
-			if ch == 'u' {
-				if c.pos+4 > len(c.input) {
-					return Literal{}, c.errorf("invalid escape sequence")
-				}
-				for i := 0; i < 4 && c.pos < len(c.input); i++ {
-					escapedBuilder.WriteByte(c.input[c.pos])
-					c.pos++
-				}
-			} else if ch == 'U' {
-				if c.pos+8 > len(c.input) {
-					return Literal{}, c.errorf("invalid escape sequence")
-				}
-				for i := 0; i < 8 && c.pos < len(c.input); i++ {
-					escapedBuilder.WriteByte(c.input[c.pos])
-					c.pos++
-				}
-			}
-			continue
+	// Find the closing quote with vectorized searches for the quote and for
+	// a backslash before it, skipping escape sequences; the escaped string is
+	// then unescaped, or used in place if it has no escapes.
+	start := c.pos
+	end := -1
+	for i := start; ; {
+		rest := c.input[i:]
+		quote := strings.IndexByte(rest, '"')
+		span := rest
+		if quote >= 0 {
+			span = rest[:quote]
 		}
-		if ch == '\\' {
-			// Start of escape sequence
-			if c.pos+1 >= len(c.input) {
-				return Literal{}, c.errorf("unterminated escape")
+		escape := strings.IndexByte(span, '\\')
+		if escape < 0 {
+			if quote < 0 {
+				c.pos = len(c.input)
+				return Literal{}, c.errorf("unterminated string literal")
 			}
-			escapeNext = true
-			c.pos++
-			continue
-		}
-		if ch == '"' {
-			// End of string
-			c.pos++
+			end = i + quote
 			break
 		}
-		escapedBuilder.WriteByte(ch)
-		c.pos++
-	}
-	if escapeNext {
-		return Literal{}, c.errorf("unterminated escape")
-	}
-	if c.pos > len(c.input) || (c.pos == len(c.input) && c.input[c.pos-1] != '"') {
-		// Check if we found the closing quote
-		if c.pos == 0 || c.input[c.pos-1] != '"' {
-			return Literal{}, c.errorf("unterminated string literal")
+		i += escape
+		if i+1 >= len(c.input) {
+			c.pos = i
+			return Literal{}, c.errorf("unterminated escape")
 		}
+		// Skip the escaped character, and the hex digits of a unicode escape
+		digits := 0
+		switch c.input[i+1] {
+		case 'u':
+			digits = 4
+		case 'U':
+			digits = 8
+		}
+		i += 2
+		if i+digits > len(c.input) {
+			c.pos = i
+			return Literal{}, c.errorf("invalid escape sequence")
+		}
+		i += digits
 	}
+	c.pos = end + 1
 
 	// Unescape using shared function
-	lexical, err := UnescapeString(escapedBuilder.String())
-	if err != nil {
-		return Literal{}, c.errorf("%v", err)
+	lexical := c.input[start:end]
+	if strings.IndexByte(lexical, '\\') >= 0 {
+		var err error
+		if lexical, err = UnescapeString(lexical); err != nil {
+			return Literal{}, c.errorf("%v", err)
+		}
 	}
 	c.skipWS()
 	if strings.HasPrefix(c.input[c.pos:], "@") {
 		c.pos++
 		start := c.pos
-		for c.pos < len(c.input) && !isTermDelimiter(c.input[c.pos]) {
+		for c.pos < len(c.input) && !ntTermDelimiter[c.input[c.pos]] {
 			c.pos++
 		}
 		lang := c.input[start:c.pos]
 		if !isValidLangTag(lang) {
 			return Literal{}, c.errorf("invalid language tag")
 		}
-		return Literal{Lexical: lexical, Lang: lang}, nil
+		lang, direction := splitLangDirection(lang)
+		return Literal{Lexical: lexical, Lang: lang, Direction: direction}, nil
 	}
 	if strings.HasPrefix(c.input[c.pos:], "^^") {
 		c.pos += 2
@@ -588,26 +853,75 @@ func (c *ntCursor) parseTripleTerm() (Term, error) {
 }
 
 func (c *ntCursor) errorf(format string, args ...interface{}) error {
-	return fmt.Errorf("ntriples: "+format, args...)
+	return &ntPositionError{pos: c.pos, err: fmt.Errorf("ntriples: "+format, args...)}
 }
 
-func isTermDelimiter(ch byte) bool {
-	switch ch {
-	case ' ', '\t', '\r', '\n', '.', ')', '<', '>':
-		return true
-	default:
-		return false
+// ntPositionError records the cursor position within the trimmed line at
+// which an N-Triples/N-Quads syntax error was detected.
+type ntPositionError struct {
+	pos int
+	err error
+}
+
+func (e *ntPositionError) Error() string { return e.err.Error() }
+func (e *ntPositionError) Unwrap() error { return e.err }
+
+// wrapNTLineError wraps an error for the raw input line starting at lineOffset,
+// pointing at the failing character when the cursor recorded one and at the
+// start of the statement otherwise.
+func wrapNTLineError(format, raw string, lineNum, lineOffset int, err error) error {
+	pos := len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace))
+	var posErr *ntPositionError
+	if errors.As(err, &posErr) {
+		pos += posErr.pos
+		// An error at the end of a final line without a line break means
+		// the input stopped in the middle of the statement.
+		if !strings.HasSuffix(raw, "\n") && posErr.pos >= len(strings.TrimSpace(raw)) {
+			err = &truncatedError{err}
+		}
+	}
+	if pos > len(raw) {
+		pos = len(raw)
 	}
+	column := utf8.RuneCountInString(raw[:pos]) + 1
+	// The statement is copied since OptArena reuses the line buffer.
+	return wrapParseErrorWithPosition(format, strings.Clone(strings.TrimSpace(raw)), lineNum, column, lineOffset+pos, err)
+}
+
+// ntTermDelimiter marks the bytes ending a blank node label or language tag.
+var ntTermDelimiter = [256]bool{' ': true, '\t': true, '\r': true, '\n': true, '.': true, ')': true, '<': true, '>': true}
+
+func isTermDelimiter(ch byte) bool {
+	return ntTermDelimiter[ch]
+}
+
+// NTriplesEncodeOptions configures N-Triples and N-Quads encoding.
+type NTriplesEncodeOptions struct {
+	// Canonical writes the canonical form of RDF 1.2 N-Triples and N-Quads:
+	// one space between terms, literals escaped with \b, \t, \n, \f, \r, \"
+	// and \\ and other control characters only as \u escapes with upper-case
+	// digits, other characters as UTF-8, language tags in lower case and
+	// xsd:string datatypes left out.
+	Canonical bool
+	// Generalized writes predicates whose IRI starts with "_:" as blank
+	// nodes, for generalized RDF.
+	Generalized bool
 }
 
 // Triple encoder for N-Triples
 type nttripleEncoder struct {
 	writer *bufio.Writer
 	err    error
+	opts   NTriplesEncodeOptions
+	line   []byte // Buffer of canonical lines
 }
 
 func newNTriplestripleEncoder(w io.Writer) tripleEncoder {
-	return &nttripleEncoder{writer: bufio.NewWriter(w)}
+	return newNTriplestripleEncoderWithOptions(w, NTriplesEncodeOptions{})
+}
+
+func newNTriplestripleEncoderWithOptions(w io.Writer, opts NTriplesEncodeOptions) tripleEncoder {
+	return &nttripleEncoder{writer: newEncoderBuffer(w), opts: opts}
 }
 
 func (e *nttripleEncoder) Write(t Triple) error {
@@ -617,7 +931,20 @@ func (e *nttripleEncoder) Write(t Triple) error {
 	if t.S == nil || t.P.Value == "" || t.O == nil {
 		return fmt.Errorf("ntriples: missing statement fields")
 	}
-	line := renderTerm(t.S) + " " + renderIRI(t.P) + " " + renderTerm(t.O) + " .\n"
+	if _, ok := t.S.(TripleTerm); ok {
+		return fmt.Errorf("ntriples: triple term subjects cannot be written (use OptReifyTripleTerms)")
+	}
+	if e.opts.Canonical {
+		var err error
+		if e.line, err = appendCanonicalStatement(e.line[:0], Statement{S: t.S, P: t.P, O: t.O}, e.opts.Generalized); err != nil {
+			return fmt.Errorf("ntriples: %w", err)
+		}
+		if _, err = e.writer.Write(append(e.line, '\n')); err != nil {
+			e.err = err
+		}
+		return err
+	}
+	line := renderTerm(t.S) + " " + renderPredicate(t.P, e.opts.Generalized) + " " + renderTerm(t.O) + " .\n"
 	_, err := e.writer.WriteString(line)
 	if err != nil {
 		e.err = err
@@ -648,10 +975,16 @@ func (e *nttripleEncoder) Close() error {
 type ntquadEncoder struct {
 	writer *bufio.Writer
 	err    error
+	opts   NTriplesEncodeOptions
+	line   []byte // Buffer of canonical lines
 }
 
 func newNQuadsquadEncoder(w io.Writer) quadEncoder {
-	return &ntquadEncoder{writer: bufio.NewWriter(w)}
+	return newNQuadsquadEncoderWithOptions(w, NTriplesEncodeOptions{})
+}
+
+func newNQuadsquadEncoderWithOptions(w io.Writer, opts NTriplesEncodeOptions) quadEncoder {
+	return &ntquadEncoder{writer: newEncoderBuffer(w), opts: opts}
 }
 
 func (e *ntquadEncoder) Write(q Quad) error {
@@ -664,7 +997,20 @@ func (e *ntquadEncoder) Write(q Quad) error {
 	if q.S == nil || q.P.Value == "" || q.O == nil {
 		return fmt.Errorf("nquads: missing statement fields")
 	}
-	line := renderTerm(q.S) + " " + renderIRI(q.P) + " " + renderTerm(q.O)
+	if _, ok := q.S.(TripleTerm); ok {
+		return fmt.Errorf("nquads: triple term subjects cannot be written (use OptReifyTripleTerms)")
+	}
+	if e.opts.Canonical {
+		var err error
+		if e.line, err = appendCanonicalStatement(e.line[:0], q.ToStatement(), e.opts.Generalized); err != nil {
+			return fmt.Errorf("nquads: %w", err)
+		}
+		if _, err = e.writer.Write(append(e.line, '\n')); err != nil {
+			e.err = err
+		}
+		return err
+	}
+	line := renderTerm(q.S) + " " + renderPredicate(q.P, e.opts.Generalized) + " " + renderTerm(q.O)
 	if q.G != nil {
 		line += " " + renderTerm(q.G)
 	}
@@ -699,6 +1045,15 @@ func renderIRI(iri IRI) string {
 	return "<" + iri.Value + ">"
 }
 
+// renderPredicate renders a predicate, as a blank node if generalized is set
+// and its IRI starts with "_:".
+func renderPredicate(iri IRI, generalized bool) string {
+	if generalized && strings.HasPrefix(iri.Value, "_:") {
+		return iri.Value
+	}
+	return renderIRI(iri)
+}
+
 func renderTerm(term Term) string {
 	switch value := term.(type) {
 	case IRI:
@@ -707,14 +1062,14 @@ func renderTerm(term Term) string {
 		return value.String()
 	case Literal:
 		if value.Lang != "" {
-			return fmt.Sprintf("%q@%s", value.Lexical, value.Lang)
+			return fmt.Sprintf("%q@%s", value.Lexical, value.langTag())
 		}
 		if value.Datatype.Value != "" {
 			return fmt.Sprintf("%q^^%s", value.Lexical, renderIRI(value.Datatype))
 		}
 		return fmt.Sprintf("%q", value.Lexical)
 	case TripleTerm:
-		return value.String()
+		return "<<( " + renderTerm(value.S) + " " + renderIRI(value.P) + " " + renderTerm(value.O) + " )>>"
 	default:
 		return ""
 	}
@@ -0,0 +1,242 @@
+package rdf
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+)
+
+// ntParallelBatchLines is the number of input lines handed to a worker at once.
+const ntParallelBatchLines = 512
+
+// ntparallelDecoder parses N-Triples/N-Quads on a pool of worker goroutines.
+// Lines are read sequentially, parsed in batches concurrently, and returned
+// in input order.
+type ntparallelDecoder struct {
+	format  string // "ntriples" or "nquads"
+	opts    decodeOptions
+	results chan chan ntBatchResult
+	done    chan struct{}
+	once    sync.Once
+
+	batch     ntBatchResult
+	pos       int
+	skip      int
+	quadCount int64
+	err       error
+
+	// startLine and startOffset locate the first line read, for exportState.
+	startLine   int
+	startOffset int
+}
+
+// ntBatch is a unit of work for a parser worker.
+type ntBatch struct {
+	firstLine   int
+	firstOffset int
+	lines       []string
+	out         chan ntBatchResult
+}
+
+// ntBatchResult holds the parsed statements of a batch, in input order.
+type ntBatchResult struct {
+	quads   []Quad
+	lines   []string
+	nums    []int
+	offsets []int
+	skipped []ntSkippedLine
+	err     error
+}
+
+// ntSkippedLine is a syntax error left for the consumer to record when error
+// recovery is enabled. before is the number of quads of the batch preceding
+// the failing line.
+type ntSkippedLine struct {
+	before int
+	err    error
+}
+
+func newNTParallelDecoder(r io.Reader, format string, opts decodeOptions) quadDecoder {
+	opts = normalizeDecodeOptions(opts)
+	workers := opts.Parallelism
+	if workers < 1 {
+		workers = 1
+	}
+	d := &ntparallelDecoder{
+		format:  format,
+		opts:    opts,
+		results: make(chan chan ntBatchResult, workers*2),
+		done:    make(chan struct{}),
+	}
+	d.startLine, d.startOffset = ntResumePosition(opts.resume)
+	jobs := make(chan ntBatch, workers)
+	for i := 0; i < workers; i++ {
+		go d.work(jobs)
+	}
+	go d.produce(bufio.NewReader(r), jobs)
+	return d
+}
+
+// produce reads lines into batches and dispatches them to workers.
+func (d *ntparallelDecoder) produce(reader *bufio.Reader, jobs chan<- ntBatch) {
+	defer close(d.results)
+	defer close(jobs)
+	lineNum, offset := d.startLine, d.startOffset
+	for {
+		if err := checkDecodeContext(d.opts.Context); err != nil {
+			d.emitError(err)
+			return
+		}
+		batch := ntBatch{firstLine: lineNum + 1, firstOffset: offset, out: make(chan ntBatchResult, 1)}
+		var readErr error
+		for len(batch.lines) < ntParallelBatchLines {
+			line, err := readLineWithLimit(reader, d.opts.MaxLineBytes)
+			if err != nil {
+				if err != io.EOF {
+					readErr = wrapParseErrorWithPosition(d.format, "", lineNum+1, 0, offset, err)
+				}
+				break
+			}
+			lineNum++
+			offset += len(line)
+			batch.lines = append(batch.lines, line)
+		}
+		if len(batch.lines) > 0 {
+			select {
+			case d.results <- batch.out:
+			case <-d.done:
+				return
+			}
+			select {
+			case jobs <- batch:
+			case <-d.done:
+				return
+			}
+		}
+		if readErr != nil {
+			d.emitError(readErr)
+			return
+		}
+		if len(batch.lines) < ntParallelBatchLines {
+			return
+		}
+	}
+}
+
+// emitError queues a terminal error after all previously dispatched batches.
+func (d *ntparallelDecoder) emitError(err error) {
+	out := make(chan ntBatchResult, 1)
+	out <- ntBatchResult{err: err}
+	select {
+	case d.results <- out:
+	case <-d.done:
+	}
+}
+
+// work parses batches until the job channel is closed.
+func (d *ntparallelDecoder) work(jobs <-chan ntBatch) {
+	for batch := range jobs {
+		result := ntBatchResult{quads: make([]Quad, 0, len(batch.lines))}
+		offset := batch.firstOffset
+		for i, raw := range batch.lines {
+			lineOffset := offset
+			offset += len(raw)
+			line := strings.TrimSpace(raw)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			quad, err := d.parseLine(line)
+			if err != nil {
+				err = wrapNTLineError(d.format, raw, batch.firstLine+i, lineOffset, err)
+				if d.opts.errors != nil {
+					result.skipped = append(result.skipped, ntSkippedLine{before: len(result.quads), err: err})
+					continue
+				}
+				result.err = err
+				break
+			}
+			result.quads = append(result.quads, quad)
+			result.lines = append(result.lines, raw)
+			result.nums = append(result.nums, batch.firstLine+i)
+			result.offsets = append(result.offsets, lineOffset)
+		}
+		batch.out <- result
+	}
+}
+
+func (d *ntparallelDecoder) parseLine(line string) (Quad, error) {
+	if d.format == "nquads" {
+		return parseNTQuadLine(line, d.opts.generalized, nil)
+	}
+	triple, err := parseNTTripleLine(line, d.opts.generalized, nil)
+	if err != nil {
+		return Quad{}, err
+	}
+	return triple.ToQuad(), nil
+}
+
+func (d *ntparallelDecoder) Next() (Quad, error) {
+	if d.err != nil {
+		return Quad{}, d.err
+	}
+	for {
+		// Skipped lines are recorded in input order relative to the quads.
+		for d.skip < len(d.batch.skipped) && d.batch.skipped[d.skip].before <= d.pos {
+			if err := d.batch.skipped[d.skip].err; !d.opts.errors.recover(err) {
+				d.err = err
+				return Quad{}, err
+			}
+			d.skip++
+		}
+		if d.pos < len(d.batch.quads) {
+			break
+		}
+		if d.batch.err != nil {
+			d.err = d.batch.err
+			return Quad{}, d.err
+		}
+		if err := checkDecodeContext(d.opts.Context); err != nil {
+			d.err = err
+			return Quad{}, err
+		}
+		out, ok := <-d.results
+		if !ok {
+			return Quad{}, io.EOF
+		}
+		d.batch = <-out
+		d.pos = 0
+		d.skip = 0
+	}
+	if d.opts.MaxTriples > 0 && d.quadCount >= d.opts.MaxTriples {
+		d.err = wrapNTLineError(d.format, d.batch.lines[d.pos], d.batch.nums[d.pos], d.batch.offsets[d.pos], ErrTripleLimitExceeded)
+		return Quad{}, d.err
+	}
+	quad := d.batch.quads[d.pos]
+	d.pos++
+	d.quadCount++
+	return quad, nil
+}
+
+func (d *ntparallelDecoder) statementLine() int {
+	if d.pos == 0 {
+		return 0
+	}
+	return d.batch.nums[d.pos-1]
+}
+
+func (d *ntparallelDecoder) exportState() (DecoderState, error) {
+	if d.pos == 0 {
+		return ntLineState(d.err, d.startLine, d.startOffset)
+	}
+	last := d.pos - 1
+	return ntLineState(d.err, d.batch.nums[last], d.batch.offsets[last]+len(d.batch.lines[last]))
+}
+
+func (d *ntparallelDecoder) Err() error { return d.err }
+
+// Close stops the reader and worker goroutines.
+func (d *ntparallelDecoder) Close() error {
+	d.once.Do(func() { close(d.done) })
+	return nil
+}
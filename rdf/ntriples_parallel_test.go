@@ -0,0 +1,122 @@
+package rdf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func buildNTriplesInput(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "<http://example.org/s%d> <http://example.org/p> \"v%d\" .\n", i, i)
+		if i%100 == 0 {
+			b.WriteString("# comment\n\n")
+		}
+	}
+	return b.String()
+}
+
+func TestParallelNTriples_PreservesOrder(t *testing.T) {
+	const n = 2000
+	input := buildNTriplesInput(n)
+	reader, err := NewReader(strings.NewReader(input), FormatNTriples, OptParallelism(4))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Close()
+	for i := 0; i < n; i++ {
+		stmt, err := reader.Next()
+		if err != nil {
+			t.Fatalf("statement %d: %v", i, err)
+		}
+		want := fmt.Sprintf("http://example.org/s%d", i)
+		if stmt.S.(IRI).Value != want {
+			t.Fatalf("statement %d: subject = %s, want %s", i, stmt.S, want)
+		}
+		if stmt.G != nil {
+			t.Fatalf("expected triple, got graph %v", stmt.G)
+		}
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestParallelNQuads_Graphs(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> <http://example.org/o> <http://example.org/g> .\n" +
+		"<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n"
+	reader, err := NewReader(strings.NewReader(input), FormatNQuads, OptParallelism(2))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Close()
+	first, err := reader.Next()
+	if err != nil || first.G == nil {
+		t.Fatalf("expected quad with graph, got %v (%v)", first, err)
+	}
+	second, err := reader.Next()
+	if err != nil || second.G != nil {
+		t.Fatalf("expected default graph statement, got %v (%v)", second, err)
+	}
+}
+
+func TestParallelNTriples_ErrorLine(t *testing.T) {
+	input := buildNTriplesInput(700) + "<http://example.org/s> <http://example.org/p> .\n"
+	reader, err := NewReader(strings.NewReader(input), FormatNTriples, OptParallelism(3))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Close()
+	count := 0
+	for {
+		_, err := reader.Next()
+		if err == nil {
+			count++
+			continue
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("expected ParseError, got %T: %v", err, err)
+		}
+		if want := strings.Count(input, "\n"); parseErr.Line != want {
+			t.Fatalf("error line = %d, want %d", parseErr.Line, want)
+		}
+		break
+	}
+	if count != 700 {
+		t.Fatalf("expected 700 statements before the error, got %d", count)
+	}
+}
+
+func TestParallelNTriples_MaxTriples(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(buildNTriplesInput(50)), FormatNTriples,
+		OptParallelism(2), OptMaxTriples(10))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Close()
+	for i := 0; i < 10; i++ {
+		if _, err := reader.Next(); err != nil {
+			t.Fatalf("statement %d: %v", i, err)
+		}
+	}
+	if _, err := reader.Next(); !errors.Is(err, ErrTripleLimitExceeded) {
+		t.Fatalf("expected ErrTripleLimitExceeded, got %v", err)
+	}
+}
+
+func TestParallelNTriples_CloseEarly(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(buildNTriplesInput(5000)), FormatNTriples, OptParallelism(4))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	if _, err := reader.Next(); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if err := reader.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -2,8 +2,14 @@ package rdf
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"unsafe"
 )
 
 func TestNTriplesDecodeErrors(t *testing.T) {
@@ -130,3 +136,221 @@ func TestNTriplesEncodeErrors(t *testing.T) {
 		t.Fatal("expected cached error")
 	}
 }
+
+func TestNTriplesScanTermsAtEveryOffset(t *testing.T) {
+	// The scanner checks IRIs eight bytes at a time and finds quotes and
+	// escapes with vectorized searches, so move each construct across a
+	// word boundary.
+	cases := []struct {
+		object  string
+		want    Term
+		wantErr string
+	}{
+		{object: `<http://example.org/PAD>`, want: IRI{Value: "http://example.org/PAD"}},
+		{object: `<http://example.org/PADé>`, want: IRI{Value: `http://example.org/PADé`}},
+		{object: `<http://example.org/PAD\U0001F600>`, want: IRI{Value: `http://example.org/PAD\U0001F600`}},
+		{object: `<http://example.org/PAD a>`, wantErr: "invalid character in IRI"},
+		{object: "<http://example.org/PAD\ta>", wantErr: "invalid character in IRI"},
+		{object: `<http://example.org/PAD\n>`, wantErr: "invalid character in IRI"},
+		{object: `<http://example.org/PAD\u00G9>`, wantErr: "invalid character in IRI"},
+		{object: `<http://example.org/PAD\u00E>`, wantErr: "invalid character in IRI"},
+		{object: `<PAD>`, wantErr: "relative IRI"},
+		{object: `"PAD"`, want: Literal{Lexical: "PAD"}},
+		{object: `"PAD\"q\\"`, want: Literal{Lexical: `PAD"q\`}},
+		{object: `"PADé"@en`, want: Literal{Lexical: "PADé", Lang: "en"}},
+		{object: `"PAD\U0001F600"`, want: Literal{Lexical: "PAD😀"}},
+		{object: `"PAD`, wantErr: "unterminated string literal"},
+		{object: `"PAD\"`, wantErr: "unterminated string literal"},
+		{object: `"PAD\u0`, wantErr: "invalid escape sequence"},
+	}
+	for pad := 0; pad <= 16; pad++ {
+		for _, tc := range cases {
+			object := strings.ReplaceAll(tc.object, "PAD", strings.Repeat("x", pad))
+			line := "<http://example.org/s> <http://example.org/p> " + object + " .\n"
+			dec, err := NewReader(strings.NewReader(line), FormatNTriples)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stmt, err := dec.Next()
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("%s: error = %v, want %q", object, err, tc.wantErr)
+				}
+				continue
+			}
+			want := tc.want
+			switch term := want.(type) {
+			case IRI:
+				term.Value = strings.ReplaceAll(term.Value, "PAD", strings.Repeat("x", pad))
+				want = term
+			case Literal:
+				term.Lexical = strings.ReplaceAll(term.Lexical, "PAD", strings.Repeat("x", pad))
+				want = term
+			}
+			if err != nil || stmt.O != want {
+				t.Errorf("%s: object = %#v, %v, want %#v", object, stmt.O, err, want)
+			}
+		}
+	}
+}
+
+func TestNTriplesScanLinesAcrossBlocks(t *testing.T) {
+	// Lines longer than a block, and reads returning one byte at a time,
+	// must split into the same lines as one large read.
+	long := strings.Repeat("y", 3*maxLineBlockSize)
+	input := "<http://example.org/s> <http://example.org/p> \"a\" .\n" +
+		"\n# comment\n" +
+		"<http://example.org/s> <http://example.org/p> \"" + long + "\" .\n" +
+		"<http://example.org/s> <http://example.org/p> \"b\" ."
+	readers := map[string]func() io.Reader{
+		"whole":    func() io.Reader { return strings.NewReader(input) },
+		"one byte": func() io.Reader { return iotest.OneByteReader(strings.NewReader(input)) },
+		"half":     func() io.Reader { return iotest.HalfReader(strings.NewReader(input)) },
+	}
+	for name, open := range readers {
+		dec, err := NewReader(open(), FormatNTriples)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stmts, err := collectStatements(dec)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var got []string
+		for _, stmt := range stmts {
+			got = append(got, stmt.O.(Literal).Lexical)
+		}
+		if want := []string{"a", long, "b"}; !slices.Equal(got, want) {
+			t.Errorf("%s: read %d literals, want 3 ending in %q", name, len(got), "b")
+		}
+	}
+}
+
+func TestNTriplesScanLineLimit(t *testing.T) {
+	long := strings.Repeat("z", 2*maxLineBlockSize) + "\n"
+	lines := newBlockLineReader(iotest.HalfReader(strings.NewReader(long + "short\n" + long)))
+	if _, err := lines.readLine(1024); err != ErrLineTooLong {
+		t.Fatalf("long line: error = %v, want ErrLineTooLong", err)
+	}
+	if line, err := lines.readLine(1024); line != "short\n" || err != nil {
+		t.Fatalf("next line = %q, %v, want %q", line, err, "short\n")
+	}
+	if _, err := lines.readLine(1024); err != ErrLineTooLong {
+		t.Fatalf("last line: error = %v, want ErrLineTooLong", err)
+	}
+	if _, err := lines.readLine(1024); err != io.EOF {
+		t.Fatalf("after last line: error = %v, want io.EOF", err)
+	}
+}
+
+func TestNTriplesScanTermsCopied(t *testing.T) {
+	// Lines point into their block, so the terms a statement keeps are
+	// copied out of it, and neither pin the block nor change when its
+	// buffer is reused.
+	var input strings.Builder
+	var want []Triple
+	for i := 0; input.Len() < 4*maxLineBlockSize; i++ {
+		fmt.Fprintf(&input, "<http://example.org/s%d> <http://example.org/p> \"%d\"@en .\n", i, i)
+		want = append(want, Triple{S: IRI{Value: fmt.Sprintf("http://example.org/s%d", i)}, P: IRI{Value: "http://example.org/p"},
+			O: Literal{Lexical: fmt.Sprint(i), Lang: "en"}})
+	}
+	d := newNTriplestripleDecoderWithOptions(strings.NewReader(input.String()), defaultDecodeOptions()).(*nttripleDecoder)
+	inBlock := func(s string) bool {
+		buf := d.lines.buf[:cap(d.lines.buf)]
+		return uintptr(unsafe.Pointer(unsafe.StringData(s)))-uintptr(unsafe.Pointer(unsafe.SliceData(buf))) < uintptr(len(buf))
+	}
+	var got []Triple
+	for {
+		triple, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		o := triple.O.(Literal)
+		for _, s := range []string{triple.S.(IRI).Value, triple.P.Value, o.Lexical, o.Lang} {
+			if inBlock(s) {
+				t.Fatalf("statement %d keeps %q in the block", len(got)+1, s)
+			}
+		}
+		got = append(got, triple)
+	}
+	if !slices.EqualFunc(got, want, func(a, b Triple) bool { return a.S == b.S && a.P == b.P && a.O == b.O }) {
+		t.Errorf("kept %d statements that differ from the %d input statements", len(got), len(want))
+	}
+}
+
+func TestNTriplesScanReadError(t *testing.T) {
+	errRead := errors.New("read failed")
+	input := io.MultiReader(strings.NewReader("<http://example.org/s> <http://example.org/p> \"a\" .\n<http://exa"),
+		iotest.ErrReader(errRead))
+	dec, err := NewReader(input, FormatNTriples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dec.Next(); err != nil {
+		t.Fatalf("first statement: %v", err)
+	}
+	if _, err := dec.Next(); !errors.Is(err, errRead) {
+		t.Fatalf("error = %v, want %v", err, errRead)
+	}
+}
+
+func TestOptGeneralizedRDF(t *testing.T) {
+	input := "\"s\" _:p <http://example.org/o> \"g\"@en .\n" +
+		"_:b _:p \"o\" .\n"
+	r, err := NewReader(strings.NewReader(input), FormatNQuads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := collectStatements(r); err == nil {
+		t.Fatal("generalized statements read without OptGeneralizedRDF")
+	}
+	stmts := readScoped(t, input, FormatNQuads, OptGeneralizedRDF())
+	if len(stmts) != 2 || stmts[0].S != (Literal{Lexical: "s"}) || stmts[0].P.Value != "_:p" || stmts[0].G != (Literal{Lexical: "g", Lang: "en"}) {
+		t.Fatalf("unexpected statements %v", stmts)
+	}
+
+	for _, opts := range [][]Option{{OptGeneralizedRDF(), OptValidateOnWrite()}, {OptGeneralizedRDF(), OptCanonicalNTriples()}} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, FormatNQuads, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, stmt := range stmts {
+			if err := w.Write(stmt); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != input {
+			t.Fatalf("got\n%s\nwant\n%s", buf.String(), input)
+		}
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatNQuads, OptValidateOnWrite())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stmtErr *StatementError
+	if err := w.Write(stmts[0]); !errors.As(err, &stmtErr) || stmtErr.Position != "subject" {
+		t.Fatalf("expected a subject *StatementError without OptGeneralizedRDF, got %v", err)
+	}
+}
+
+func TestOptGeneralizedRDFJSONLD(t *testing.T) {
+	input := `{"@id": "http://example.org/s", "_:p": "o"}`
+	for _, tt := range []struct {
+		opts []Option
+		want int
+	}{{nil, 0}, {[]Option{OptGeneralizedRDF()}, 1}} {
+		stmts := readScoped(t, input, FormatJSONLD, tt.opts...)
+		if len(stmts) != tt.want || tt.want == 1 && stmts[0].P.Value != "_:p" {
+			t.Fatalf("got %v, want %d statements", stmts, tt.want)
+		}
+	}
+}
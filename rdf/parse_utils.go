@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
+	"unsafe"
 )
 
 // Unicode surrogate pair constants
@@ -17,21 +19,8 @@ const (
 	unicodeSurrogateBase      = 0x10000
 )
 
-// Directive keywords for Turtle/TriG
+// Escape sequence length constants
 const (
-	directiveAtPrefix  = "@prefix"
-	directivePrefix    = "PREFIX"
-	directiveAtBase    = "@base"
-	directiveBase      = "BASE"
-	directiveAtVersion = "@version"
-	directiveVersion   = "VERSION"
-	directiveGraph     = "GRAPH"
-)
-
-// String literal length constants
-const (
-	minLongStringLength     = 6  // Minimum length for """...""" or '''...'''
-	minStringLength         = 2  // Minimum length for "..." or '...'
 	unicodeEscapeLength     = 6  // Length of \uXXXX escape sequence
 	unicodeLongEscapeLength = 10 // Length of \UXXXXXXXX escape sequence
 )
@@ -49,6 +38,15 @@ func isValidPNLocalEscape(ch byte) bool {
 	}
 }
 
+// splitLangDirection splits a language tag validated by isValidLangTag into
+// the language and the RDF 1.2 base direction ("en--ltr" gives "en", "ltr").
+func splitLangDirection(tag string) (lang, direction string) {
+	if i := strings.Index(tag, "--"); i >= 0 {
+		return tag[:i], tag[i+2:]
+	}
+	return tag, ""
+}
+
 func isValidLangTag(tag string) bool {
 	if tag == "" {
 		return false
@@ -68,15 +66,10 @@ func isValidLangTag(tag string) bool {
 		}
 	}
 
-	parts := strings.Split(tag, "-")
-	if len(parts) == 0 {
-		return false
-	}
-	if len(parts[0]) < 1 || len(parts[0]) > 8 {
-		return false
-	}
-	for i, part := range parts {
-		if part == "" {
+	// The subtags are cut one at a time, without allocating a slice.
+	for i := 0; ; i++ {
+		part, rest, more := strings.Cut(tag, "-")
+		if part == "" || i == 0 && len(part) > 8 {
 			return false
 		}
 		for j := 0; j < len(part); j++ {
@@ -91,8 +84,11 @@ func isValidLangTag(tag string) bool {
 				}
 			}
 		}
+		if !more {
+			return true
+		}
+		tag = rest
 	}
-	return true
 }
 
 func isValidUnicodeCodePoint(codePoint rune) bool {
@@ -177,6 +173,10 @@ func readLineWithLimit(reader *bufio.Reader, maxBytes int) (string, error) {
 	var buffer []byte
 	for {
 		part, err := reader.ReadSlice('\n')
+		if err == nil && buffer == nil && len(part) <= maxBytes {
+			// The whole line is in the reader's buffer: copy it once.
+			return string(part), nil
+		}
 		buffer = append(buffer, part...)
 		if len(buffer) > maxBytes {
 			discardLine(reader)
@@ -195,6 +195,153 @@ func readLineWithLimit(reader *bufio.Reader, maxBytes int) (string, error) {
 	}
 }
 
+// readLineReuse is readLineWithLimit for OptArena: it reads the line into
+// *buf, which the next call overwrites, and returns a string over it.
+func readLineReuse(reader *bufio.Reader, buf *[]byte, maxBytes int) (string, error) {
+	*buf = (*buf)[:0]
+	for {
+		part, err := reader.ReadSlice('\n')
+		*buf = append(*buf, part...)
+		if maxBytes > 0 && len(*buf) > maxBytes {
+			if err == bufio.ErrBufferFull {
+				discardLine(reader)
+			}
+			return "", ErrLineTooLong
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == nil || err == io.EOF && len(*buf) > 0 {
+			return unsafe.String(unsafe.SliceData(*buf), len(*buf)), nil
+		}
+		return "", err
+	}
+}
+
+// A blockLineReader starts with blocks of minLineBlockSize bytes, so that
+// small inputs stay cheap, and doubles them up to maxLineBlockSize.
+const (
+	minLineBlockSize = 4 << 10
+	maxLineBlockSize = 64 << 10
+)
+
+// blockLineReader splits its input into lines the way readLineWithLimit
+// does, but reads it into large blocks viewed as strings, in which a line
+// is found with a vectorized newline search. A line points into its block
+// and is only valid until the next call, since the block's buffer is
+// reused; ntTermCache copies what a statement keeps out of it.
+type blockLineReader struct {
+	r      io.Reader
+	buf    []byte // Memory of block, read into past its length
+	block  string
+	pos    int
+	err    error   // first error from r
+	pooled *[]byte // Holds buf in lineBlocks, or nil
+}
+
+// lineBlocks holds the buffers of closed blockLineReaders up to
+// maxLineBlockSize bytes, so that the next reader starts with a grown
+// block.
+var lineBlocks sync.Pool
+
+func newBlockLineReader(r io.Reader) *blockLineReader {
+	return &blockLineReader{r: r}
+}
+
+// readLine returns the next line, including its line feed. Like
+// readLineWithLimit, it skips a line longer than maxBytes (0 = unlimited)
+// and returns ErrLineTooLong.
+func (b *blockLineReader) readLine(maxBytes int) (string, error) {
+	for {
+		rest := b.block[b.pos:]
+		if n := strings.IndexByte(rest, '\n') + 1; n > 0 {
+			b.pos += n
+			if maxBytes > 0 && n > maxBytes {
+				return "", ErrLineTooLong
+			}
+			return rest[:n], nil
+		}
+		if maxBytes > 0 && len(rest) > maxBytes {
+			b.skipLine()
+			return "", ErrLineTooLong
+		}
+		if b.err != nil {
+			b.pos = len(b.block)
+			if b.err == io.EOF && len(rest) > 0 {
+				return rest, nil
+			}
+			return "", b.err
+		}
+		b.fill()
+	}
+}
+
+// skipLine discards the input up to and including the next line feed.
+func (b *blockLineReader) skipLine() {
+	for {
+		if n := strings.IndexByte(b.block[b.pos:], '\n'); n >= 0 {
+			b.pos += n + 1
+			return
+		}
+		b.pos = len(b.block)
+		if b.err != nil {
+			return
+		}
+		b.fill()
+	}
+}
+
+// fill appends the next read from r to the block. When the block's buffer
+// is full, it first moves the unread rest to its start, or to a new
+// buffer while blocks are still growing and for lines longer than half a
+// block. The lines returned before are no longer used then.
+func (b *blockLineReader) fill() {
+	if b.buf == nil {
+		if p, _ := lineBlocks.Get().(*[]byte); p != nil {
+			b.buf, b.pooled = (*p)[:0], p
+		}
+	}
+	if len(b.buf) == cap(b.buf) {
+		rest := b.buf[b.pos:]
+		size := min(max(2*cap(b.buf), minLineBlockSize), maxLineBlockSize)
+		if size > cap(b.buf) || 2*len(rest) > cap(b.buf) {
+			buf := make([]byte, len(rest), max(size, 2*len(rest)))
+			copy(buf, rest)
+			b.buf = buf
+		} else {
+			b.buf = b.buf[:copy(b.buf, rest)]
+		}
+		b.pos = 0
+	}
+	// Read once rather than fill the buffer, so that a slow stream yields
+	// its lines as they arrive.
+	for tries := 1; b.err == nil; tries++ {
+		n, err := b.r.Read(b.buf[len(b.buf):cap(b.buf)])
+		b.buf = b.buf[:len(b.buf)+n]
+		b.err = err
+		if n > 0 {
+			break
+		}
+		if tries == 100 {
+			b.err = io.ErrNoProgress
+		}
+	}
+	b.block = unsafe.String(unsafe.SliceData(b.buf), len(b.buf))
+}
+
+// release puts the block's buffer in lineBlocks once the reader is done
+// with it. The last line returned is no longer valid then.
+func (b *blockLineReader) release() {
+	if cap(b.buf) >= minLineBlockSize && cap(b.buf) <= maxLineBlockSize {
+		if b.pooled == nil {
+			b.pooled = new([]byte)
+		}
+		*b.pooled = b.buf[:0]
+		lineBlocks.Put(b.pooled)
+	}
+	b.buf, b.block, b.pos, b.pooled = nil, "", 0, nil
+}
+
 func discardLine(reader *bufio.Reader) {
 	for {
 		_, err := reader.ReadSlice('\n')
@@ -215,7 +362,7 @@ type contextReader struct {
 func (c *contextReader) Read(p []byte) (int, error) {
 	select {
 	case <-c.ctx.Done():
-		return 0, c.ctx.Err()
+		return 0, context.Cause(c.ctx)
 	default:
 		return c.r.Read(p)
 	}
@@ -227,129 +374,35 @@ func checkDecodeContext(ctx context.Context) error {
 	}
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return context.Cause(ctx)
 	default:
 		return nil
 	}
 }
 
-func parseAtPrefixDirective(line string, requireTerminator bool) (string, string, bool) {
-	if !strings.HasPrefix(line, directiveAtPrefix) {
-		return "", "", false
-	}
-	parts := strings.Fields(line)
-	if len(parts) < 3 {
-		return "", "", false
-	}
-	if !strings.HasSuffix(parts[1], ":") {
-		return "", "", false
-	}
-	prefix := strings.TrimSuffix(parts[1], ":")
-	if !isValidPrefixName(prefix) {
-		return "", "", false
-	}
-	iriPart := parts[2]
-	if !strings.HasPrefix(iriPart, "<") {
-		return "", "", false
-	}
-	closeIdx := strings.Index(iriPart, ">")
-	if closeIdx <= 0 {
-		return "", "", false
-	}
-	if requireTerminator {
-		if closeIdx+1 < len(iriPart) && iriPart[closeIdx+1] == '.' {
-			// Period is part of the statement terminator, already handled.
-		} else if len(parts) > 3 && parts[3] == "." {
-			// Period is separate token.
-		} else if !strings.HasSuffix(line, ".") {
-			return "", "", false
-		}
-	}
-	return prefix, iriPart[1:closeIdx], true
-}
-
-func parseBarePrefixDirective(line string) (string, string, bool) {
-	if strings.HasPrefix(line, "@") || !strings.HasPrefix(strings.ToUpper(line), directivePrefix) {
-		return "", "", false
-	}
-	parts := strings.Fields(line)
-	if len(parts) < 3 {
-		return "", "", false
-	}
-	if !strings.HasSuffix(parts[1], ":") {
-		return "", "", false
-	}
-	prefix := strings.TrimSuffix(parts[1], ":")
-	if !isValidPrefixName(prefix) {
-		return "", "", false
-	}
-	iriPart := parts[2]
-	if !strings.HasPrefix(iriPart, "<") {
-		return "", "", false
-	}
-	closeIdx := strings.Index(iriPart, ">")
-	if closeIdx <= 0 {
-		return "", "", false
-	}
-	return prefix, iriPart[1:closeIdx], true
-}
+// contextCheckEvery is the number of tokens a decoder reads between checks
+// of its context, which bounds the work done after a cancellation while
+// keeping the check off the hot path.
+const contextCheckEvery = 64
 
-func parseVersionDirective(line string) bool {
-	if !strings.HasPrefix(strings.ToLower(line), strings.ToLower(directiveVersion)) && !strings.HasPrefix(line, directiveAtVersion) {
-		return false
-	}
-	rest := strings.TrimSpace(line)
-	if strings.HasPrefix(rest, directiveAtVersion) {
-		rest = strings.TrimSpace(rest[len(directiveAtVersion):])
-	} else {
-		rest = strings.TrimSpace(rest[len(directiveVersion):])
-	}
-	rest = strings.TrimSpace(strings.TrimSuffix(rest, "."))
-	if rest == "" {
-		return false
-	}
-	quote := rest[0]
-	if quote != '"' && quote != '\'' {
-		return false
-	}
-	if len(rest) >= 3 && rest[1] == quote && rest[2] == quote {
-		return false
-	}
-	end := strings.IndexByte(rest[1:], quote)
-	return end >= 0
-}
-
-func parseAtBaseDirective(line string) (string, bool) {
-	if !strings.HasPrefix(line, directiveAtBase) {
-		return "", false
-	}
-	rest := strings.TrimSpace(line[len(directiveAtBase):])
-	if !strings.HasPrefix(rest, "<") {
-		return "", false
-	}
-	closeIdx := strings.Index(rest, ">")
-	if closeIdx <= 0 {
-		return "", false
-	}
-	return rest[1:closeIdx], true
+// contextChecker checks a decoder context every contextCheckEvery tokens.
+type contextChecker struct {
+	ctx    context.Context
+	tokens int
 }
 
-func parseBaseDirective(line string) (string, bool) {
-	if strings.HasPrefix(line, "@") || !strings.HasPrefix(strings.ToUpper(line), directiveBase) {
-		return "", false
-	}
-	if strings.HasSuffix(strings.TrimSpace(line), ".") {
-		return "", false
-	}
-	rest := strings.TrimSpace(line[len(directiveBase):])
-	if !strings.HasPrefix(rest, "<") {
-		return "", false
+// check counts a token and returns the context error on every
+// contextCheckEvery-th call once the context is done.
+func (c *contextChecker) check() error {
+	if c.ctx == nil {
+		return nil
 	}
-	closeIdx := strings.Index(rest, ">")
-	if closeIdx <= 0 {
-		return "", false
+	c.tokens++
+	if c.tokens < contextCheckEvery {
+		return nil
 	}
-	return rest[1:closeIdx], true
+	c.tokens = 0
+	return checkDecodeContext(c.ctx)
 }
 
 // UnescapeString decodes escape sequences in RDF string literals.
@@ -414,7 +467,7 @@ func unescapeSimpleEscape(builder *strings.Builder, escapeChar byte) (int, error
 
 // unescapeUnicodeEscape handles \uXXXX escape sequences, including surrogate pairs.
 func unescapeUnicodeEscape(builder *strings.Builder, s string, pos int) (int, error) {
-	if pos+unicodeEscapeLength >= len(s) {
+	if pos+unicodeEscapeLength > len(s) {
 		return 0, fmt.Errorf("invalid escape sequence")
 	}
 	codePoint := decodeUChar(s[pos+2 : pos+6])
@@ -441,7 +494,7 @@ func unescapeUnicodeEscape(builder *strings.Builder, s string, pos int) (int, er
 
 // unescapeSurrogatePair handles surrogate pair escape sequences \uXXXX\uYYYY.
 func unescapeSurrogatePair(builder *strings.Builder, s string, pos int, high rune) (int, error) {
-	if pos+11 >= len(s) || s[pos+6] != '\\' || s[pos+7] != 'u' {
+	if pos+12 > len(s) || s[pos+6] != '\\' || s[pos+7] != 'u' {
 		return 0, fmt.Errorf("invalid escape sequence")
 	}
 	low := decodeUChar(s[pos+8 : pos+12])
@@ -458,7 +511,7 @@ func unescapeSurrogatePair(builder *strings.Builder, s string, pos int, high run
 
 // unescapeUnicodeLongEscape handles \UXXXXXXXX escape sequences.
 func unescapeUnicodeLongEscape(builder *strings.Builder, s string, pos int) (int, error) {
-	if pos+unicodeLongEscapeLength >= len(s) {
+	if pos+unicodeLongEscapeLength > len(s) {
 		return 0, fmt.Errorf("invalid escape sequence")
 	}
 	var codePoint rune
@@ -0,0 +1,138 @@
+package rdf
+
+// Transform wraps a Reader with a statement rewrite or filter.
+// Transforms are composed with Pipe.
+type Transform func(Reader) Reader
+
+// Pipe applies transforms to r in order and returns the resulting reader:
+//
+//	reader = rdf.Pipe(reader,
+//		rdf.FilterGraph(rdf.IRI{Value: "http://example.org/g"}),
+//		rdf.MapIRIs(rewrite),
+//		rdf.DeduplicateWindow(1024),
+//	)
+//
+// Closing the returned reader closes r.
+func Pipe(r Reader, transforms ...Transform) Reader {
+	for _, t := range transforms {
+		if t != nil {
+			r = t(r)
+		}
+	}
+	return r
+}
+
+// Filter keeps only statements for which keep returns true.
+func Filter(keep func(Statement) bool) Transform {
+	return func(r Reader) Reader {
+		return &filterReader{src: r, keep: keep}
+	}
+}
+
+// Map replaces every statement with the result of fn.
+func Map(fn func(Statement) Statement) Transform {
+	return func(r Reader) Reader {
+		return &mapReader{src: r, fn: fn}
+	}
+}
+
+// FilterGraph keeps only statements in graph g. A nil g selects the default graph.
+func FilterGraph(g Term) Transform {
+	return Filter(func(s Statement) bool {
+		return s.G == g
+	})
+}
+
+// RenameGraph moves statements from graph from to graph to. Either may be nil
+// to denote the default graph.
+func RenameGraph(from, to Term) Transform {
+	return Map(func(s Statement) Statement {
+		if s.G == from {
+			s.G = to
+		}
+		return s
+	})
+}
+
+// MapIRIs rewrites every IRI in subject, predicate, object and graph position,
+// including IRIs nested in triple terms. Datatype IRIs of literals are not
+// rewritten.
+func MapIRIs(fn func(IRI) IRI) Transform {
+	return Map(func(s Statement) Statement {
+		return mapStatementTerms(s, func(t Term) Term {
+			if iri, ok := t.(IRI); ok {
+				return fn(iri)
+			}
+			return t
+		})
+	})
+}
+
+// DeduplicateWindow drops statements equal to one of the last n distinct
+// statements returned. Memory use is bounded by n, so duplicates further
+// apart than the window are kept. If n <= 0, every duplicate in the stream is
+// dropped and memory grows with the number of distinct statements.
+func DeduplicateWindow(n int) Transform {
+	return func(r Reader) Reader {
+		return &dedupReader{src: r, size: n, seen: make(map[Statement]struct{})}
+	}
+}
+
+// mapReader rewrites each statement returned by src.
+type mapReader struct {
+	src Reader
+	fn  func(Statement) Statement
+}
+
+func (m *mapReader) Next() (Statement, error) {
+	stmt, err := m.src.Next()
+	if err != nil {
+		return Statement{}, err
+	}
+	return m.fn(stmt), nil
+}
+
+func (m *mapReader) Close() error {
+	return m.src.Close()
+}
+
+// dedupReader drops statements present in a FIFO window of recent statements.
+type dedupReader struct {
+	src    Reader
+	size   int
+	seen   map[Statement]struct{}
+	window []Statement
+	next   int
+}
+
+func (d *dedupReader) Next() (Statement, error) {
+	for {
+		stmt, err := d.src.Next()
+		if err != nil {
+			return Statement{}, err
+		}
+		if _, dup := d.seen[stmt]; dup {
+			continue
+		}
+		d.remember(stmt)
+		return stmt, nil
+	}
+}
+
+func (d *dedupReader) remember(stmt Statement) {
+	d.seen[stmt] = struct{}{}
+	if d.size <= 0 {
+		return
+	}
+	if len(d.window) < d.size {
+		d.window = append(d.window, stmt)
+		return
+	}
+	delete(d.seen, d.window[d.next])
+	d.window[d.next] = stmt
+	d.next = (d.next + 1) % d.size
+}
+
+func (d *dedupReader) Close() error {
+	return d.src.Close()
+}
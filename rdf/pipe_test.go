@@ -0,0 +1,81 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+)
+
+func pipeStatements(t *testing.T, stmts []Statement, transforms ...Transform) []Statement {
+	t.Helper()
+	reader := Pipe(&stubStatementReader{stmts: stmts}, transforms...)
+	defer reader.Close()
+	out, err := collectStatements(reader)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	return out
+}
+
+func TestPipe_FilterGraphAndRename(t *testing.T) {
+	g1 := IRI{Value: "http://example.org/g1"}
+	g2 := IRI{Value: "http://example.org/g2"}
+	p := IRI{Value: "http://example.org/p"}
+	stmts := []Statement{
+		NewQuad(IRI{Value: "http://example.org/a"}, p, Literal{Lexical: "1"}, g1),
+		NewQuad(IRI{Value: "http://example.org/b"}, p, Literal{Lexical: "2"}, g2),
+		NewTriple(IRI{Value: "http://example.org/c"}, p, Literal{Lexical: "3"}),
+	}
+	out := pipeStatements(t, stmts, FilterGraph(g1), RenameGraph(g1, nil))
+	if len(out) != 1 || out[0].G != nil || out[0].S.String() != "http://example.org/a" {
+		t.Fatalf("unexpected output %v", out)
+	}
+	out = pipeStatements(t, stmts, FilterGraph(nil))
+	if len(out) != 1 || out[0].S.String() != "http://example.org/c" {
+		t.Fatalf("default graph filter: unexpected output %v", out)
+	}
+}
+
+func TestPipe_MapIRIs(t *testing.T) {
+	stmts := []Statement{
+		NewQuad(TripleTerm{S: IRI{Value: "http://old.org/s"}, P: IRI{Value: "http://old.org/p"}, O: IRI{Value: "http://old.org/o"}},
+			IRI{Value: "http://old.org/p"},
+			Literal{Lexical: "x", Datatype: IRI{Value: "http://old.org/dt"}},
+			IRI{Value: "http://old.org/g"}),
+	}
+	rewrite := MapIRIs(func(iri IRI) IRI {
+		return IRI{Value: strings.Replace(iri.Value, "http://old.org/", "http://new.org/", 1)}
+	})
+	out := pipeStatements(t, stmts, rewrite)
+	tt := out[0].S.(TripleTerm)
+	if tt.S.String() != "http://new.org/s" || tt.P.Value != "http://new.org/p" || tt.O.String() != "http://new.org/o" {
+		t.Fatalf("triple term not rewritten: %v", tt)
+	}
+	if out[0].P.Value != "http://new.org/p" || out[0].G.String() != "http://new.org/g" {
+		t.Fatalf("predicate/graph not rewritten: %v", out[0])
+	}
+	if out[0].O.(Literal).Datatype.Value != "http://old.org/dt" {
+		t.Fatalf("datatype IRI must not be rewritten")
+	}
+}
+
+func TestPipe_DeduplicateWindow(t *testing.T) {
+	p := IRI{Value: "http://example.org/p"}
+	stmt := func(v string) Statement {
+		return NewTriple(IRI{Value: "http://example.org/s"}, p, Literal{Lexical: v})
+	}
+	stmts := []Statement{stmt("a"), stmt("a"), stmt("b"), stmt("c"), stmt("a"), stmt("c")}
+
+	if out := pipeStatements(t, stmts, DeduplicateWindow(2)); len(out) != 4 {
+		t.Fatalf("window 2: expected 4 statements, got %d", len(out))
+	}
+	if out := pipeStatements(t, stmts, DeduplicateWindow(0)); len(out) != 3 {
+		t.Fatalf("unbounded: expected 3 statements, got %d", len(out))
+	}
+}
+
+func TestPipe_NoTransforms(t *testing.T) {
+	src := &stubStatementReader{}
+	if Pipe(src) != Reader(src) {
+		t.Fatalf("Pipe without transforms should return the reader unchanged")
+	}
+}
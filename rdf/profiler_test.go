@@ -0,0 +1,54 @@
+package rdf
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestProfiler(t *testing.T) {
+	input := `<http://example.org/a> <http://example.org/name> "A" .
+<http://example.org/a> <http://example.org/label> "A"@en .
+<http://example.org/b> <http://example.org/label> "B"@en--ltr .
+<http://example.org/b> <http://example.org/age> "3"^^<http://www.w3.org/2001/XMLSchema#integer> .
+_:c <http://example.org/knows> <http://example.org/a> .
+`
+	profiler := NewProfiler()
+	if err := Parse(context.Background(), strings.NewReader(input), FormatNTriples, profiler.Handle); err != nil {
+		t.Fatal(err)
+	}
+	profile := profiler.Profile()
+	if profile.Statements != 5 || profile.DistinctSubjects != 3 {
+		t.Errorf("Statements = %d, DistinctSubjects = %d", profile.Statements, profile.DistinctSubjects)
+	}
+	if profile.Predicates["http://example.org/label"] != 2 || len(profile.Predicates) != 4 {
+		t.Errorf("Predicates = %v", profile.Predicates)
+	}
+	wantDatatypes := map[string]int64{
+		xsdNamespace + "string":  1,
+		xsdNamespace + "integer": 1,
+		rdfLangStringIRI:         1,
+		rdfDirLangStringIRI:      1,
+	}
+	if fmt.Sprint(profile.Datatypes) != fmt.Sprint(wantDatatypes) {
+		t.Errorf("Datatypes = %v, want %v", profile.Datatypes, wantDatatypes)
+	}
+	if profile.Languages["en"] != 2 || len(profile.Languages) != 1 {
+		t.Errorf("Languages = %v", profile.Languages)
+	}
+}
+
+func TestProfilerDistinctSubjectsEstimate(t *testing.T) {
+	profiler := NewProfiler()
+	p := IRI{Value: "http://example.org/p"}
+	const distinct = 200_000
+	for i := 0; i < 2*distinct; i++ {
+		profiler.Handle(NewTriple(IRI{Value: fmt.Sprintf("http://example.org/s%d", i%distinct)}, p, Literal{Lexical: "x"}))
+	}
+	got := profiler.Profile().DistinctSubjects
+	if relErr := math.Abs(float64(got-distinct)) / distinct; relErr > 0.04 {
+		t.Errorf("DistinctSubjects = %d, want about %d", got, distinct)
+	}
+}
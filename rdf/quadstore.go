@@ -0,0 +1,537 @@
+package rdf
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrInvalidQuad indicates that a quad without a subject, predicate or
+// object, or with a Variable, was added to or deleted from a QuadStore.
+var ErrInvalidQuad = errors.New("rdf: invalid quad")
+
+// ErrTxDone indicates that a QuadTx was used after Commit or Rollback.
+var ErrTxDone = errors.New("rdf: transaction has already been committed or rolled back")
+
+// ErrSnapshotClosed indicates that a QuadSnapshot was read after Close.
+var ErrSnapshotClosed = errors.New("rdf: snapshot is closed")
+
+// QuadPattern selects quads by their terms. A nil S, O or G, or an empty P,
+// matches any term; other terms match the terms equal to them by TermEqual.
+// Since a nil G matches every graph, DefaultGraph selects the quads of the
+// default graph only.
+type QuadPattern struct {
+	// S is the subject to match, or nil for any subject.
+	S Term
+	// P is the predicate to match, or empty for any predicate.
+	P IRI
+	// O is the object to match, or nil for any object.
+	O Term
+	// G is the graph name to match, or nil for any graph.
+	G Term
+	// DefaultGraph restricts the pattern to the default graph. G must be nil.
+	DefaultGraph bool
+}
+
+// Matches reports whether q matches the pattern.
+func (p QuadPattern) Matches(q Quad) bool {
+	switch {
+	case p.S != nil && !TermEqual(p.S, q.S),
+		p.P.Value != "" && p.P.Value != q.P.Value,
+		p.O != nil && !TermEqual(p.O, q.O),
+		p.G != nil && !TermEqual(p.G, q.G),
+		p.DefaultGraph && q.G != nil:
+		return false
+	}
+	return true
+}
+
+// QuadMatcher is implemented by QuadStore and QuadTx, the sources that
+// Dataset, Graph and MatchBGP read quads from.
+type QuadMatcher interface {
+	// MatchQuads returns the quads matching p. Errors of the backend are
+	// yielded with a zero Quad, after which iteration stops.
+	MatchQuads(p QuadPattern) iter.Seq2[Quad, error]
+}
+
+// QuadStore is a storage backend holding a set of quads. Dataset and Graph
+// read and write their statements through it and MatchBGP evaluates
+// patterns over it, so a persistent or remote store plugs into the same
+// query and serialization code as MemoryQuadStore, the in-memory reference
+// implementation.
+//
+// Stores hold quads as a set: terms equal by TermEqual are the same term,
+// adding a quad the store holds and deleting one it does not hold do
+// nothing.
+type QuadStore interface {
+	QuadMatcher
+	// AddQuad adds q to the store. It fails with ErrInvalidQuad if q has
+	// no subject, predicate or object, or has a Variable.
+	AddQuad(q Quad) error
+	// DeleteQuad removes q from the store. It fails with ErrInvalidQuad
+	// like AddQuad.
+	DeleteQuad(q Quad) error
+	// Begin starts a transaction. Its changes are invisible to other users
+	// of the store until Commit applies them all at once.
+	Begin() (QuadTx, error)
+}
+
+// QuadTx is a transaction of a QuadStore. Its MatchQuads sees the store
+// with the changes of the transaction applied. Methods fail with ErrTxDone
+// after Commit or Rollback, except Rollback which then does nothing, so it
+// can be deferred.
+type QuadTx interface {
+	QuadMatcher
+	// AddQuad adds q within the transaction.
+	AddQuad(q Quad) error
+	// DeleteQuad removes q within the transaction.
+	DeleteQuad(q Quad) error
+	// Commit applies the changes of the transaction to the store.
+	Commit() error
+	// Rollback discards the changes of the transaction.
+	Rollback() error
+}
+
+// QuadSnapshot is a consistent read-only view of a QuadStore: its
+// MatchQuads sees the store as it was when the snapshot was taken, whatever
+// is changed or committed since. Close releases it; MatchQuads then fails
+// with ErrSnapshotClosed.
+type QuadSnapshot interface {
+	QuadMatcher
+	Close() error
+}
+
+// QuadSnapshotter is implemented by QuadStores that take snapshots, such as
+// MemoryQuadStore.
+type QuadSnapshotter interface {
+	// Snapshot returns a snapshot of the committed quads of the store.
+	Snapshot() (QuadSnapshot, error)
+}
+
+func checkQuad(q Quad) error {
+	switch {
+	case q.S == nil:
+		return fmt.Errorf("%w: no subject", ErrInvalidQuad)
+	case q.P.Value == "":
+		return fmt.Errorf("%w: no predicate", ErrInvalidQuad)
+	case q.O == nil:
+		return fmt.Errorf("%w: no object", ErrInvalidQuad)
+	}
+	for _, t := range quadTerms(q) {
+		if _, ok := t.(Variable); ok {
+			return fmt.Errorf("%w: variable %v", ErrInvalidQuad, t)
+		}
+	}
+	return nil
+}
+
+// Positions of the indexes of MemoryQuadStore.
+const (
+	quadSubject = iota
+	quadPredicate
+	quadObject
+	quadGraph
+)
+
+// MemoryQuadStore is a QuadStore held in memory, indexing quads by each of
+// their terms. MatchQuads yields quads in the order of their
+// EncodeStatementKey keys, from a copy taken when iteration starts, so the
+// store may be changed while iterating. It is safe for concurrent use;
+// transactions are serialized with AddQuad and DeleteQuad, which wait for
+// an open transaction to end.
+//
+// Snapshots are copy-on-write by graph: taking one is cheap, and the first
+// change to a graph after it copies that graph, leaving the snapshot its own
+// version. The cost of that change grows with the size of the graph, so a
+// store holding most of its quads in one graph copies nearly all of them
+// on the first write after each snapshot.
+type MemoryQuadStore struct {
+	writer sync.Mutex   // Held by a transaction or a single change
+	mu     sync.RWMutex // Guards graphs
+	graphs *memoryGraphs
+}
+
+// memoryGraphs is a version of the quads of a MemoryQuadStore, held in a
+// memoryQuadState per graph. Once shared with a snapshot, it is never
+// changed again.
+type memoryGraphs struct {
+	byName map[string]*memoryQuadState // By EncodeTermKey of the graph name
+	shared bool
+}
+
+// memoryQuadState is a version of the quads of a graph of a
+// MemoryQuadStore, or of a ConcurrentGraph shard. Once shared with a
+// snapshot, it is never changed again.
+type memoryQuadState struct {
+	quads  map[string]Quad
+	index  [4]map[string]map[string]struct{} // Term key to statement keys, by position
+	shared bool
+}
+
+// NewMemoryQuadStore returns an empty MemoryQuadStore.
+func NewMemoryQuadStore() *MemoryQuadStore {
+	return &MemoryQuadStore{graphs: &memoryGraphs{byName: make(map[string]*memoryQuadState)}}
+}
+
+func newMemoryQuadState() *memoryQuadState {
+	s := &memoryQuadState{quads: make(map[string]Quad)}
+	for i := range s.index {
+		s.index[i] = make(map[string]map[string]struct{})
+	}
+	return s
+}
+
+// Len returns the number of quads in the store.
+func (m *MemoryQuadStore) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	n := 0
+	for _, s := range m.graphs.byName {
+		n += len(s.quads)
+	}
+	return n
+}
+
+// AddQuad implements QuadStore.
+func (m *MemoryQuadStore) AddQuad(q Quad) error {
+	if err := checkQuad(q); err != nil {
+		return err
+	}
+	m.writer.Lock()
+	defer m.writer.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.writable(q.G).add(memoryQuadKey(q), q)
+	return nil
+}
+
+// DeleteQuad implements QuadStore.
+func (m *MemoryQuadStore) DeleteQuad(q Quad) error {
+	if err := checkQuad(q); err != nil {
+		return err
+	}
+	m.writer.Lock()
+	defer m.writer.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleteQuad(memoryQuadKey(q), q.G)
+	return nil
+}
+
+// MatchQuads implements QuadStore.
+func (m *MemoryQuadStore) MatchQuads(p QuadPattern) iter.Seq2[Quad, error] {
+	return func(yield func(Quad, error) bool) {
+		m.mu.RLock()
+		quads := m.graphs.match(p)
+		m.mu.RUnlock()
+		for _, q := range quads {
+			if !yield(q, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Begin implements QuadStore. The transaction holds the store's write lock
+// until it ends; readers outside it see the store as it was before.
+func (m *MemoryQuadStore) Begin() (QuadTx, error) {
+	m.writer.Lock()
+	return &memoryQuadTx{store: m, added: make(map[string]Quad), deleted: make(map[string]Term)}, nil
+}
+
+// Snapshot implements QuadSnapshotter. It does not wait for an open
+// transaction, and sees the store as it was before it.
+func (m *MemoryQuadStore) Snapshot() (QuadSnapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.graphs.shared = true
+	snap := &memoryQuadSnapshot{}
+	snap.graphs.Store(m.graphs)
+	return snap, nil
+}
+
+// writable returns the state of the graph name to change, copying it if it
+// is shared with a snapshot; the caller holds mu.
+func (m *MemoryQuadStore) writable(name Term) *memoryQuadState {
+	if m.graphs.shared {
+		// The graphs are shared along with the version holding them.
+		c := &memoryGraphs{byName: maps.Clone(m.graphs.byName)}
+		for _, s := range c.byName {
+			s.shared = true
+		}
+		m.graphs = c
+	}
+	key := string(EncodeTermKey(name))
+	s := m.graphs.byName[key]
+	switch {
+	case s == nil:
+		s = newMemoryQuadState()
+		m.graphs.byName[key] = s
+	case s.shared:
+		s = s.clone()
+		m.graphs.byName[key] = s
+	}
+	return s
+}
+
+// deleteQuad deletes the quad with key from the graph name, dropping the
+// graph once empty; the caller holds mu.
+func (m *MemoryQuadStore) deleteQuad(key string, name Term) {
+	if _, ok := m.graphs.byName[string(EncodeTermKey(name))]; !ok {
+		return
+	}
+	s := m.writable(name)
+	s.delete(key)
+	if len(s.quads) == 0 {
+		delete(m.graphs.byName, string(EncodeTermKey(name)))
+	}
+}
+
+// states returns the states of the graphs p may match.
+func (g *memoryGraphs) states(p QuadPattern) []*memoryQuadState {
+	if p.G != nil || p.DefaultGraph {
+		if s := g.byName[string(EncodeTermKey(p.G))]; s != nil {
+			return []*memoryQuadState{s}
+		}
+		return nil
+	}
+	return slices.Collect(maps.Values(g.byName))
+}
+
+// match returns the quads matching p sorted by key.
+func (g *memoryGraphs) match(p QuadPattern) []Quad {
+	type keyed struct {
+		key  string
+		quad Quad
+	}
+	var found []keyed
+	for _, s := range g.states(p) {
+		for _, key := range s.matchKeys(p) {
+			found = append(found, keyed{key, s.quads[key]})
+		}
+	}
+	slices.SortFunc(found, func(a, b keyed) int { return cmp.Compare(a.key, b.key) })
+	quads := make([]Quad, len(found))
+	for i, k := range found {
+		quads[i] = k.quad
+	}
+	return quads
+}
+
+func (s *memoryQuadState) clone() *memoryQuadState {
+	c := &memoryQuadState{quads: maps.Clone(s.quads)}
+	for i, index := range s.index {
+		c.index[i] = make(map[string]map[string]struct{}, len(index))
+		for tk, set := range index {
+			c.index[i][tk] = maps.Clone(set)
+		}
+	}
+	return c
+}
+
+// add and delete change the state.
+func (s *memoryQuadState) add(key string, q Quad) {
+	if _, ok := s.quads[key]; ok {
+		return
+	}
+	// Terms are copied since they may point into a reader's buffer.
+	q = Quad{S: cloneTerm(q.S), P: cloneTerm(q.P).(IRI), O: cloneTerm(q.O), G: cloneTerm(q.G)}
+	s.quads[key] = q
+	for i, t := range quadTerms(q) {
+		tk := string(EncodeTermKey(t))
+		set := s.index[i][tk]
+		if set == nil {
+			set = make(map[string]struct{})
+			s.index[i][tk] = set
+		}
+		set[key] = struct{}{}
+	}
+}
+
+func (s *memoryQuadState) delete(key string) {
+	q, ok := s.quads[key]
+	if !ok {
+		return
+	}
+	delete(s.quads, key)
+	for i, t := range quadTerms(q) {
+		tk := string(EncodeTermKey(t))
+		set := s.index[i][tk]
+		delete(set, key)
+		if len(set) == 0 {
+			delete(s.index[i], tk)
+		}
+	}
+}
+
+// matchKeys returns the keys of the quads matching p, scanning the smallest
+// index set of its bound terms.
+func (s *memoryQuadState) matchKeys(p QuadPattern) []string {
+	bound := [4]Term{p.S, nil, p.O, p.G}
+	if p.P.Value != "" {
+		bound[quadPredicate] = p.P
+	}
+	var best map[string]struct{}
+	found := false
+	for i, t := range bound {
+		if t == nil && !(i == quadGraph && p.DefaultGraph) {
+			continue
+		}
+		set := s.index[i][string(EncodeTermKey(t))]
+		if !found || len(set) < len(best) {
+			best, found = set, true
+		}
+	}
+	var keys []string
+	if !found {
+		keys = make([]string, 0, len(s.quads))
+		for key, q := range s.quads {
+			if p.Matches(q) {
+				keys = append(keys, key)
+			}
+		}
+		return keys
+	}
+	for key := range best {
+		if p.Matches(s.quads[key]) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// memoryQuadSnapshot is a snapshot of a MemoryQuadStore, reading shared
+// graphs without locking.
+type memoryQuadSnapshot struct {
+	graphs atomic.Pointer[memoryGraphs] // Nil once closed
+}
+
+func (s *memoryQuadSnapshot) MatchQuads(p QuadPattern) iter.Seq2[Quad, error] {
+	return func(yield func(Quad, error) bool) {
+		graphs := s.graphs.Load()
+		if graphs == nil {
+			yield(Quad{}, ErrSnapshotClosed)
+			return
+		}
+		for _, q := range graphs.match(p) {
+			if !yield(q, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *memoryQuadSnapshot) Close() error {
+	s.graphs.Store(nil)
+	return nil
+}
+
+// memoryQuadTx is a transaction of a MemoryQuadStore, recording its changes
+// until Commit.
+type memoryQuadTx struct {
+	store   *MemoryQuadStore
+	added   map[string]Quad
+	deleted map[string]Term // Graph names by key
+	done    bool
+}
+
+func (tx *memoryQuadTx) AddQuad(q Quad) error {
+	if tx.done {
+		return ErrTxDone
+	}
+	if err := checkQuad(q); err != nil {
+		return err
+	}
+	key := memoryQuadKey(q)
+	delete(tx.deleted, key)
+	tx.added[key] = q
+	return nil
+}
+
+func (tx *memoryQuadTx) DeleteQuad(q Quad) error {
+	if tx.done {
+		return ErrTxDone
+	}
+	if err := checkQuad(q); err != nil {
+		return err
+	}
+	key := memoryQuadKey(q)
+	delete(tx.added, key)
+	tx.deleted[key] = q.G
+	return nil
+}
+
+func (tx *memoryQuadTx) MatchQuads(p QuadPattern) iter.Seq2[Quad, error] {
+	return func(yield func(Quad, error) bool) {
+		if tx.done {
+			yield(Quad{}, ErrTxDone)
+			return
+		}
+		m := tx.store
+		matched := make(map[string]Quad)
+		m.mu.RLock()
+		for _, s := range m.graphs.states(p) {
+			for _, key := range s.matchKeys(p) {
+				if _, ok := tx.deleted[key]; !ok {
+					matched[key] = s.quads[key]
+				}
+			}
+		}
+		m.mu.RUnlock()
+		for key, q := range tx.added {
+			if p.Matches(q) {
+				matched[key] = q
+			}
+		}
+		keys := slices.Sorted(maps.Keys(matched))
+		for _, key := range keys {
+			if !yield(matched[key], nil) {
+				return
+			}
+		}
+	}
+}
+
+// Commit applies the changes at once: readers see the store either before
+// or after all of them.
+func (tx *memoryQuadTx) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	m := tx.store
+	m.mu.Lock()
+	for key, name := range tx.deleted {
+		m.deleteQuad(key, name)
+	}
+	for key, q := range tx.added {
+		m.writable(q.G).add(key, q)
+	}
+	m.mu.Unlock()
+	m.writer.Unlock()
+	return nil
+}
+
+func (tx *memoryQuadTx) Rollback() error {
+	if tx.done {
+		return nil
+	}
+	tx.done = true
+	tx.store.writer.Unlock()
+	return nil
+}
+
+// memoryQuadKey returns the statement key identifying q in a MemoryQuadStore.
+func memoryQuadKey(q Quad) string {
+	var scratch [256]byte
+	return string(AppendStatementKey(scratch[:0], q.ToStatement()))
+}
+
+func quadTerms(q Quad) [4]Term {
+	return [4]Term{q.S, q.P, q.O, q.G}
+}
@@ -0,0 +1,199 @@
+package rdf
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func exQuad(s, p, o string, g Term) Quad {
+	return Quad{S: IRI{Value: "http://example.org/" + s}, P: IRI{Value: "http://example.org/" + p}, O: IRI{Value: "http://example.org/" + o}, G: g}
+}
+
+func matchAll(t *testing.T, m QuadMatcher, p QuadPattern) []Quad {
+	t.Helper()
+	var quads []Quad
+	for q, err := range m.MatchQuads(p) {
+		if err != nil {
+			t.Fatalf("MatchQuads(%+v) error: %v", p, err)
+		}
+		quads = append(quads, q)
+	}
+	return quads
+}
+
+func TestMemoryQuadStoreMatch(t *testing.T) {
+	g := IRI{Value: "http://example.org/g"}
+	store := NewMemoryQuadStore()
+	quads := []Quad{
+		exQuad("a", "p", "b", nil),
+		exQuad("a", "q", "c", nil),
+		exQuad("b", "p", "c", g),
+		exQuad("a", "p", "b", g),
+	}
+	for _, q := range quads {
+		if err := store.AddQuad(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Equal terms are the same: xsd:string literals and language tag case.
+	lit := Quad{S: IRI{Value: "http://example.org/a"}, P: IRI{Value: "http://example.org/label"}, O: Literal{Lexical: "x", Lang: "en"}}
+	store.AddQuad(lit)
+	store.AddQuad(Quad{S: lit.S, P: lit.P, O: Literal{Lexical: "x", Lang: "EN"}})
+	store.AddQuad(quads[0])
+	if store.Len() != 5 {
+		t.Fatalf("Len() = %d, want 5", store.Len())
+	}
+
+	tests := []struct {
+		name    string
+		pattern QuadPattern
+		want    int
+	}{
+		{"all", QuadPattern{}, 5},
+		{"subject", QuadPattern{S: IRI{Value: "http://example.org/a"}}, 4},
+		{"predicate", QuadPattern{P: IRI{Value: "http://example.org/p"}}, 3},
+		{"object", QuadPattern{O: IRI{Value: "http://example.org/c"}}, 2},
+		{"graph", QuadPattern{G: g}, 2},
+		{"default graph", QuadPattern{DefaultGraph: true}, 3},
+		{"subject in default graph", QuadPattern{S: IRI{Value: "http://example.org/b"}, DefaultGraph: true}, 0},
+		{"literal", QuadPattern{O: Literal{Lexical: "x", Lang: "En"}}, 1},
+		{"absent", QuadPattern{S: IRI{Value: "http://example.org/z"}}, 0},
+	}
+	for _, tt := range tests {
+		got := matchAll(t, store, tt.pattern)
+		if len(got) != tt.want {
+			t.Errorf("%s: %d quads, want %d: %v", tt.name, len(got), tt.want, got)
+		}
+		for _, q := range got {
+			if !tt.pattern.Matches(q) {
+				t.Errorf("%s: %v does not match", tt.name, q)
+			}
+		}
+		if !slices.IsSortedFunc(got, func(a, b Quad) int {
+			return slices.Compare(EncodeStatementKey(a.ToStatement()), EncodeStatementKey(b.ToStatement()))
+		}) {
+			t.Errorf("%s: quads not in key order: %v", tt.name, got)
+		}
+	}
+
+	// The store can be changed while iterating.
+	for q := range store.MatchQuads(QuadPattern{}) {
+		if err := store.DeleteQuad(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if store.Len() != 0 {
+		t.Errorf("Len() after deleting all = %d", store.Len())
+	}
+	if len(store.graphs.byName) != 0 {
+		t.Errorf("graphs not dropped once empty: %v", store.graphs.byName)
+	}
+}
+
+func TestMemoryQuadStoreInvalidQuad(t *testing.T) {
+	store := NewMemoryQuadStore()
+	for _, q := range []Quad{
+		{P: IRI{Value: "http://example.org/p"}, O: IRI{Value: "http://example.org/o"}},
+		{S: IRI{Value: "http://example.org/s"}, O: IRI{Value: "http://example.org/o"}},
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}},
+		{S: Variable("s"), P: IRI{Value: "http://example.org/p"}, O: IRI{Value: "http://example.org/o"}},
+	} {
+		if err := store.AddQuad(q); !errors.Is(err, ErrInvalidQuad) {
+			t.Errorf("AddQuad(%v) error = %v, want ErrInvalidQuad", q, err)
+		}
+	}
+}
+
+func TestMemoryQuadStoreTx(t *testing.T) {
+	store := NewMemoryQuadStore()
+	store.AddQuad(exQuad("a", "p", "b", nil))
+
+	tx, err := store.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.DeleteQuad(exQuad("a", "p", "b", nil))
+	tx.AddQuad(exQuad("c", "p", "d", nil))
+	if got := matchAll(t, tx, QuadPattern{}); len(got) != 1 || got[0] != exQuad("c", "p", "d", nil) {
+		t.Errorf("transaction sees %v", got)
+	}
+	if got := matchAll(t, store, QuadPattern{}); len(got) != 1 || got[0] != exQuad("a", "p", "b", nil) {
+		t.Errorf("store sees uncommitted changes: %v", got)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := matchAll(t, store, QuadPattern{}); len(got) != 1 || got[0] != exQuad("c", "p", "d", nil) {
+		t.Errorf("store after commit = %v", got)
+	}
+	if err := tx.AddQuad(exQuad("e", "p", "f", nil)); !errors.Is(err, ErrTxDone) {
+		t.Errorf("AddQuad after Commit error = %v, want ErrTxDone", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("Rollback after Commit error = %v", err)
+	}
+
+	tx, _ = store.Begin()
+	tx.AddQuad(exQuad("e", "p", "f", nil))
+	tx.Rollback()
+	if store.Len() != 1 {
+		t.Errorf("Len() after rollback = %d, want 1", store.Len())
+	}
+	// The write lock was released.
+	if err := store.AddQuad(exQuad("g", "p", "h", nil)); err != nil || store.Len() != 2 {
+		t.Errorf("AddQuad after rollback: %v, Len() = %d", err, store.Len())
+	}
+}
+
+func TestMemoryQuadStoreSnapshot(t *testing.T) {
+	store := NewMemoryQuadStore()
+	store.AddQuad(exQuad("a", "p", "b", nil))
+	snap, err := store.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.AddQuad(exQuad("c", "p", "d", nil))
+	store.DeleteQuad(exQuad("a", "p", "b", nil))
+	if got := matchAll(t, snap, QuadPattern{}); len(got) != 1 || got[0] != exQuad("a", "p", "b", nil) {
+		t.Errorf("snapshot sees %v", got)
+	}
+	if got := matchAll(t, store, QuadPattern{}); len(got) != 1 || got[0] != exQuad("c", "p", "d", nil) {
+		t.Errorf("store sees %v", got)
+	}
+
+	// A snapshot taken during a transaction sees the store before it.
+	tx, _ := store.Begin()
+	tx.AddQuad(exQuad("e", "p", "f", nil))
+	during, _ := store.Snapshot()
+	tx.Commit()
+	if got := matchAll(t, during, QuadPattern{}); len(got) != 1 {
+		t.Errorf("snapshot during transaction sees %v", got)
+	}
+	if store.Len() != 2 {
+		t.Errorf("Len() after commit = %d, want 2", store.Len())
+	}
+
+	snap.Close()
+	for _, err := range snap.MatchQuads(QuadPattern{}) {
+		if !errors.Is(err, ErrSnapshotClosed) {
+			t.Errorf("MatchQuads after Close error = %v, want ErrSnapshotClosed", err)
+		}
+	}
+
+	// A change copies only the graph it changes.
+	g := IRI{Value: "http://example.org/g"}
+	store.AddQuad(exQuad("a", "p", "b", g))
+	graphs, _ := store.Snapshot()
+	store.AddQuad(exQuad("g", "p", "h", nil))
+	if got := matchAll(t, graphs, QuadPattern{DefaultGraph: true}); len(got) != 2 {
+		t.Errorf("snapshot sees %v in the default graph", got)
+	}
+	shared := graphs.(*memoryQuadSnapshot).graphs.Load()
+	if key := string(EncodeTermKey(g)); store.graphs.byName[key] != shared.byName[key] {
+		t.Error("unchanged graph copied")
+	}
+	if key := string(EncodeTermKey(nil)); store.graphs.byName[key] == shared.byName[key] {
+		t.Error("changed graph shared with the snapshot")
+	}
+}
@@ -0,0 +1,83 @@
+// Package rdfotel records OpenTelemetry spans of the Parse calls, readers
+// and writers of the rdf package:
+//
+//	r, err := rdf.NewReader(in, rdf.FormatTurtle,
+//		rdf.OptContext(ctx), rdfotel.OptTracerProvider(otel.GetTracerProvider()))
+//
+// Spans carry the attributes rdf.format and rdf.statements, rdf.bytes_read
+// for Parse calls and readers and rdf.bytes_written for writers. A failed
+// span records the error, an Error status and the rdf.error_code attribute
+// with the rdf.ErrorCode of the error.
+package rdfotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// tracerName is the OpenTelemetry instrumentation scope of the rdf package.
+const tracerName = "github.com/geoknoesis/rdf-go/rdf"
+
+// Span attributes.
+const (
+	attrFormat       = attribute.Key("rdf.format")
+	attrStatements   = attribute.Key("rdf.statements")
+	attrBytesRead    = attribute.Key("rdf.bytes_read")
+	attrBytesWritten = attribute.Key("rdf.bytes_written")
+	attrErrorCode    = attribute.Key("rdf.error_code")
+)
+
+// OptTracerProvider makes Parse, NewReader and NewWriter record
+// OpenTelemetry spans with tp; see rdf.OptTracer. A nil tp disables
+// tracing.
+func OptTracerProvider(tp trace.TracerProvider) rdf.Option {
+	if tp == nil {
+		return rdf.OptTracer(nil)
+	}
+	return rdf.OptTracer(NewTracer(tp))
+}
+
+// NewTracer returns an rdf.Tracer recording spans with tp.
+func NewTracer(tp trace.TracerProvider) rdf.Tracer {
+	return tracer{tp.Tracer(tracerName)}
+}
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+func (t tracer) Start(ctx context.Context, name string, format rdf.Format) (context.Context, rdf.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(attrFormat.String(string(format))))
+	return ctx, streamSpan{span: span, writing: name == "rdf.Writer"}
+}
+
+// streamSpan is the span of a Parse call, reader or writer.
+type streamSpan struct {
+	span    trace.Span
+	writing bool
+}
+
+func (s streamSpan) SetFormat(format rdf.Format) {
+	s.span.SetAttributes(attrFormat.String(string(format)))
+}
+
+func (s streamSpan) End(m rdf.Metrics, err error) {
+	attrs := []attribute.KeyValue{attrStatements.Int64(m.Statements)}
+	if s.writing {
+		attrs = append(attrs, attrBytesWritten.Int64(m.BytesWritten))
+	} else {
+		attrs = append(attrs, attrBytesRead.Int64(m.BytesRead))
+	}
+	s.span.SetAttributes(attrs...)
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+		s.span.SetAttributes(attrErrorCode.String(string(rdf.Code(err))))
+	}
+	s.span.End()
+}
@@ -0,0 +1,149 @@
+package rdfotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// recordingProvider is a TracerProvider keeping the spans it starts, in the
+// order they end.
+type recordingProvider struct {
+	noop.TracerProvider
+	ended []*recordedSpan
+}
+
+func (p *recordingProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return recordingTracer{provider: p}
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	provider *recordingProvider
+}
+
+func (t recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	parent, _ := trace.SpanFromContext(ctx).(*recordedSpan)
+	span := &recordedSpan{provider: t.provider, name: name, parent: parent, attrs: make(map[attribute.Key]attribute.Value)}
+	cfg := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(cfg.Attributes()...)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordedSpan struct {
+	noop.Span
+	provider *recordingProvider
+	name     string
+	parent   *recordedSpan
+	attrs    map[attribute.Key]attribute.Value
+	status   codes.Code
+	err      error
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordedSpan) RecordError(err error, _ ...trace.EventOption) { s.err = err }
+
+func (s *recordedSpan) End(...trace.SpanEndOption) {
+	s.provider.ended = append(s.provider.ended, s)
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestOptTracerProviderParse(t *testing.T) {
+	tp := &recordingProvider{}
+	const input = "<http://example.org/s> <http://example.org/p> \"a\" .\n<http://example.org/s> <http://example.org/p> \"b\" .\n"
+	err := rdf.Parse(context.Background(), strings.NewReader(input), rdf.FormatNTriples, func(rdf.Statement) error { return nil }, OptTracerProvider(tp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := tp.ended
+	if len(spans) != 2 {
+		t.Fatalf("%d spans ended, want 2", len(spans))
+	}
+	reader, parse := spans[0], spans[1]
+	if reader.name != "rdf.Reader" || parse.name != "rdf.Parse" {
+		t.Fatalf("spans = %s, %s", reader.name, parse.name)
+	}
+	if reader.parent != parse {
+		t.Error("reader span is not a child of the Parse span")
+	}
+	for _, span := range spans {
+		if span.attrs["rdf.format"].AsString() != "ntriples" || span.attrs["rdf.statements"].AsInt64() != 2 || span.attrs["rdf.bytes_read"].AsInt64() != int64(len(input)) {
+			t.Errorf("%s attributes = %v", span.name, span.attrs)
+		}
+		if span.status == codes.Error {
+			t.Errorf("%s status = %v", span.name, span.status)
+		}
+	}
+}
+
+func TestOptTracerProviderErrors(t *testing.T) {
+	tp := &recordingProvider{}
+
+	r, err := rdf.NewReader(strings.NewReader("<http://example.org/s> <http://example.org/p> .\n"), rdf.FormatAuto, OptTracerProvider(tp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Next(); err == nil {
+		t.Fatal("Next() succeeded on invalid input")
+	}
+	r.Close()
+	if len(tp.ended) != 1 {
+		t.Fatalf("%d spans ended, want 1", len(tp.ended))
+	}
+	span := tp.ended[0]
+	if span.status != codes.Error || span.err == nil || span.attrs["rdf.error_code"].AsString() != string(rdf.ErrCodeParseError) {
+		t.Errorf("reader span status %v, attributes %v", span.status, span.attrs)
+	}
+	if span.attrs["rdf.format"].AsString() == "" {
+		t.Errorf("detected format attribute = %v", span.attrs["rdf.format"])
+	}
+
+	w, err := rdf.NewWriter(failingWriter{}, rdf.FormatNTriples, OptTracerProvider(tp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(rdf.Statement{S: rdf.IRI{Value: "http://example.org/s"}, P: rdf.IRI{Value: "http://example.org/p"}, O: rdf.Literal{Lexical: "o"}})
+	if err := w.Close(); !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if len(tp.ended) != 2 || tp.ended[1].name != "rdf.Writer" || tp.ended[1].status != codes.Error {
+		t.Fatalf("writer span not recorded as failed: %v", tp.ended)
+	}
+	if got := tp.ended[1].attrs["rdf.statements"].AsInt64(); got != 1 {
+		t.Errorf("writer rdf.statements = %d, want 1", got)
+	}
+	if _, ok := tp.ended[1].attrs["rdf.bytes_read"]; ok {
+		t.Error("writer span has rdf.bytes_read")
+	}
+}
+
+func TestOptTracerProviderNil(t *testing.T) {
+	var buf strings.Builder
+	w, err := rdf.NewWriter(&buf, rdf.FormatNTriples, OptTracerProvider(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,6 +2,7 @@ package rdf
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -21,6 +22,9 @@ const (
 // Triple decoder for RDF/XML
 type rdfxmltripleDecoder struct {
 	dec              *xml.Decoder
+	input            *xmlEntityReader    // Input that may declare a DTD, or nil
+	counted          *countingByteReader // Input counted by countInput, or nil
+	countedOffset    int64               // Input offset counted so far
 	queue            []Triple
 	err              error
 	baseURI          string
@@ -30,14 +34,40 @@ type rdfxmltripleDecoder struct {
 	idsSeen          map[string]struct{}
 	rootElementSeen  bool
 	baseStack        []string
+	lang             string   // xml:lang in scope
+	langStack        []string // xml:lang of enclosing elements
+	dir              string   // its:dir in scope
+	dirStack         []string // its:dir of enclosing elements
 	containerIndex   map[string]int
 	expandContainers bool // Enable container membership expansion
+	reifiers         bool // Reify rdf:ID property elements with rdf:reifies
+	// DTD handling (see rdfxml_dtd.go)
+	disallowDTD        bool
+	maxEntityDepth     int
+	maxEntityExpansion int
+
+	// Limits on element nesting, statement bytes and namespace declarations.
+	// A statement spans a child of the root element or, when the root is a
+	// node element, one of its property elements; stmtStart is its offset.
+	maxDepth          int
+	maxStatementBytes int
+	maxPrefixCount    int
+	prefixCount       int
+	stmtStart         int64
+
+	// queueBytes is the size of the first queueCounted triples of queue,
+	// kept within maxBufferedBytes.
+	maxBufferedBytes int64
+	queueBytes       int64
+	queueCounted     int
+
+	cancel contextChecker
 }
 
 func newRDFXMLtripleDecoder(r io.Reader) tripleDecoder {
-	return newRDFXMLtripleDecoderWithOptions(r, decodeOptions{
+	return newRDFXMLtripleDecoderWithOptions(r, normalizeDecodeOptions(decodeOptions{
 		ExpandRDFXMLContainers: true, // Default: enable container expansion
-	})
+	}))
 }
 
 func newRDFXMLtripleDecoderWithOptions(r io.Reader, opts decodeOptions) tripleDecoder {
@@ -46,32 +76,46 @@ func newRDFXMLtripleDecoderWithOptions(r io.Reader, opts decodeOptions) tripleDe
 	// was explicitly called, so we respect that choice.
 	expandContainers := opts.ExpandRDFXMLContainers
 
+	dec, input, counted := newXMLDecoder(r, opts.MaxEntityExpansion)
 	return &rdfxmltripleDecoder{
-		dec:              xml.NewDecoder(r),
-		namespaces:       make(map[string]string),
-		idsSeen:          make(map[string]struct{}),
-		containerIndex:   make(map[string]int),
-		expandContainers: expandContainers,
+		dec:                dec,
+		input:              input,
+		counted:            counted,
+		namespaces:         make(map[string]string),
+		idsSeen:            make(map[string]struct{}),
+		containerIndex:     make(map[string]int),
+		expandContainers:   expandContainers,
+		baseURI:            opts.BaseIRI,
+		reifiers:           opts.RDFXMLReifiers,
+		disallowDTD:        opts.DisallowDTD,
+		maxEntityDepth:     opts.MaxEntityDepth,
+		maxEntityExpansion: opts.MaxEntityExpansion,
+		maxDepth:           opts.MaxDepth,
+		maxStatementBytes:  opts.MaxStatementBytes,
+		maxPrefixCount:     opts.MaxPrefixCount,
+		maxBufferedBytes:   opts.MaxBufferedBytes,
+		cancel:             contextChecker{ctx: opts.Context},
 	}
 }
 
 func (d *rdfxmltripleDecoder) Next() (Triple, error) {
 	for {
 		if len(d.queue) > 0 {
-			next := d.queue[0]
-			d.queue = d.queue[1:]
-			return next, nil
+			return d.dequeue(), nil
+		}
+		if d.err != nil {
+			return Triple{}, d.err
 		}
 		tok, err := d.nextToken()
 		if err != nil {
 			if err == io.EOF {
 				if !d.seenRoot {
-					d.err = wrapParseError("rdfxml", "", int(d.dec.InputOffset()), d.wrapRDFXMLError(fmt.Errorf("missing root element")))
+					d.err = d.wrapPositionError(d.wrapRDFXMLError(fmt.Errorf("missing root element")))
 					return Triple{}, d.err
 				}
 				return Triple{}, io.EOF
 			}
-			d.err = wrapParseError("rdfxml", "", int(d.dec.InputOffset()), err)
+			d.err = d.wrapPositionError(err)
 			return Triple{}, d.err
 		}
 		switch t := tok.(type) {
@@ -80,16 +124,15 @@ func (d *rdfxmltripleDecoder) Next() (Triple, error) {
 			if err := d.handleStartElement(t); err != nil {
 				// If we have queued triples, return them before the error
 				if len(d.queue) > 0 {
-					next := d.queue[0]
-					d.queue = d.queue[1:]
+					next := d.dequeue()
 					// Store the error for later
 					if d.err == nil {
-						d.err = wrapParseError("rdfxml", "", int(d.dec.InputOffset()), err)
+						d.err = d.wrapPositionError(err)
 					}
 					return next, nil
 				}
 				if d.err == nil {
-					d.err = wrapParseError("rdfxml", "", int(d.dec.InputOffset()), err)
+					d.err = d.wrapPositionError(err)
 				}
 				return Triple{}, d.err
 			}
@@ -103,6 +146,49 @@ func (d *rdfxmltripleDecoder) Next() (Triple, error) {
 	}
 }
 
+// dequeue removes and returns the first queued triple.
+func (d *rdfxmltripleDecoder) dequeue() Triple {
+	next := d.queue[0]
+	d.queue = d.queue[1:]
+	if d.queueCounted > 0 {
+		d.queueCounted--
+		d.queueBytes -= tripleBytes(next)
+	}
+	return next
+}
+
+// checkQueueBudget adds the triples queued since the last call to the size
+// of the queue and fails if it exceeds maxBufferedBytes. Triples are only
+// queued while elements are read, so checking on every token bounds the
+// queue to the budget and the triples of one element.
+func (d *rdfxmltripleDecoder) checkQueueBudget() error {
+	if d.maxBufferedBytes <= 0 {
+		return nil
+	}
+	for _, t := range d.queue[d.queueCounted:] {
+		d.queueBytes += tripleBytes(t)
+	}
+	d.queueCounted = len(d.queue)
+	if d.queueBytes > d.maxBufferedBytes {
+		return errBufferBudget(d.maxBufferedBytes)
+	}
+	return nil
+}
+
+// wrapPositionError wraps err with the decoder's current line, column and
+// byte offset, which point just past the most recently read XML token.
+func (d *rdfxmltripleDecoder) wrapPositionError(err error) error {
+	line, column := d.dec.InputPos()
+	return wrapParseErrorWithPosition("rdfxml", "", line, column, int(d.dec.InputOffset()), err)
+}
+
+// statementLine approximates the line of the last triple with the decoder's
+// input position, since RDF/XML triples can be completed by later elements.
+func (d *rdfxmltripleDecoder) statementLine() int {
+	line, _ := d.dec.InputPos()
+	return line
+}
+
 func (d *rdfxmltripleDecoder) Err() error { return d.err }
 func (d *rdfxmltripleDecoder) Close() error {
 	return nil
@@ -138,20 +224,8 @@ func (d *rdfxmltripleDecoder) handleStartElement(el xml.StartElement) error {
 
 	// Handle node elements
 	if d.isNodeElement(el) {
-		if err := d.validateNodeIDs(el.Attr); err != nil {
-			return err
-		}
-		subject := d.subjectFromNode(el)
-		// If it's a typed node element, queue the type triple
-		if el.Name.Space != rdfXMLNS || el.Name.Local != "Description" {
-			typIRI := d.resolveQName(el.Name.Space, el.Name.Local)
-			d.queue = append(d.queue, Triple{
-				S: subject,
-				P: IRI{Value: rdfXMLNS + "type"},
-				O: IRI{Value: typIRI},
-			})
-		}
-		return d.readPredicateElements(subject, el)
+		_, err := d.readNodeElement(el)
+		return err
 	}
 
 	// Disallow RDF namespace elements as node elements unless explicitly allowed.
@@ -162,6 +236,26 @@ func (d *rdfxmltripleDecoder) handleStartElement(el xml.StartElement) error {
 	return nil
 }
 
+// readNodeElement queues the triples of the node element el, up to and
+// including its end element, and returns the node it describes.
+func (d *rdfxmltripleDecoder) readNodeElement(el xml.StartElement) (Term, error) {
+	if err := d.validateNodeIDs(el.Attr); err != nil {
+		return nil, err
+	}
+	subject := d.subjectFromNode(el)
+	// If it's a typed node element, queue the type triple
+	if el.Name.Space != rdfXMLNS || el.Name.Local != "Description" {
+		typIRI := d.resolveQName(el.Name.Space, el.Name.Local)
+		d.queue = append(d.queue, Triple{
+			S: subject,
+			P: IRI{Value: rdfXMLNS + "type"},
+			O: IRI{Value: typIRI},
+		})
+	}
+	d.queuePropertyAttributes(subject, el)
+	return subject, d.readPredicateElements(subject, el)
+}
+
 func (d *rdfxmltripleDecoder) readPredicateElements(subject Term, parentEl xml.StartElement) error {
 	depth := 1
 	containerKey := d.containerKey(subject)
@@ -173,7 +267,7 @@ func (d *rdfxmltripleDecoder) readPredicateElements(subject Term, parentEl xml.S
 			// still allow queued triples to be returned.
 			if err == io.EOF && depth > 1 {
 				// Missing EndElement for parent - this is an error
-				return d.wrapRDFXMLError(fmt.Errorf("unexpected EOF, missing EndElement (depth=%d)", depth))
+				return d.wrapRDFXMLError(&truncatedError{fmt.Errorf("unexpected EOF, missing EndElement (depth=%d)", depth)})
 			}
 			return err
 		}
@@ -193,7 +287,7 @@ func (d *rdfxmltripleDecoder) readPredicateElements(subject Term, parentEl xml.S
 
 			// Handle empty property elements with attributes
 			if parseType == "" && d.isEmptyElement(t) {
-				handled, err := d.handleEmptyPropertyElementWithAttributes(t, subject)
+				handled, err := d.handleEmptyPropertyElementWithAttributes(t, subject, containerKey)
 				if err != nil {
 					return err
 				}
@@ -209,6 +303,10 @@ func (d *rdfxmltripleDecoder) readPredicateElements(subject Term, parentEl xml.S
 			}
 			// objectFromPredicate consumes the EndElement, so decrement depth
 			depth--
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) != "" {
+				return d.wrapRDFXMLError(fmt.Errorf("node element %s contains text", parentEl.Name.Local))
+			}
 		case xml.EndElement:
 			depth--
 			if depth == 0 {
@@ -228,7 +326,7 @@ func (d *rdfxmltripleDecoder) objectFromPredicate(start xml.StartElement) (Term,
 	}
 
 	// Handle rdf:resource attribute (empty property element with resource)
-	if resource := d.attrValue(start.Attr, rdfXMLNS, "resource"); resource != "" && parseType == "" {
+	if resource, ok := d.attrLookup(start.Attr, rdfXMLNS, "resource"); ok && parseType == "" {
 		obj := IRI{Value: d.resolveIRI(d.baseURI, resource)}
 		if err := d.consumeElement(); err != nil {
 			return nil, annotation, annotationNodeID, err
@@ -285,88 +383,88 @@ func (d *rdfxmltripleDecoder) objectFromPredicate(start xml.StartElement) (Term,
 		return tripleTerm, annotation, annotationNodeID, nil
 	}
 
-	// Handle nested node element or literal content
-	firstTok, err := d.nextToken()
-	if err != nil {
-		return nil, annotation, annotationNodeID, err
-	}
-	if _, ok := firstTok.(xml.StartElement); ok {
-		// Nested elements without parseType are not supported in this parser.
-		return nil, annotation, annotationNodeID, d.wrapRDFXMLError(fmt.Errorf("nested property elements are not supported without parseType"))
+	// Property attributes on an empty property element describe its object,
+	// a blank node.
+	if parseType == "" && d.hasPropertyAttributes(start) {
+		bnode := d.newBlankNode()
+		d.queuePropertyAttributes(bnode, start)
+		if err := d.consumeElement(); err != nil {
+			return nil, annotation, annotationNodeID, err
+		}
+		return bnode, annotation, annotationNodeID, nil
 	}
 
-	// Handle literal content (CharData or EndElement)
-	obj, _, _, err := d.readLiteralContent(start, firstTok)
-	return obj, annotation, annotationNodeID, err
-}
-
-func (d *rdfxmltripleDecoder) readLiteralContent(start xml.StartElement, firstTok xml.Token) (Term, string, string, error) {
+	// Handle a nested node element or literal content. The datatype,
+	// language and direction are those of the property element, whose
+	// scope is popped when its end element is read.
+	base, lang, dir := d.baseURI, d.lang, d.dir
 	var content strings.Builder
-	lang := d.attrValue(start.Attr, xmlNS, "lang")
-	dir := d.attrValue(start.Attr, itsNS, "dir")
-	datatype := d.attrValue(start.Attr, rdfXMLNS, "datatype")
-
-	// Process first token if it's CharData
-	if charData, ok := firstTok.(xml.CharData); ok {
-		content.WriteString(string(charData))
-	} else if endEl, ok := firstTok.(xml.EndElement); ok {
-		// Empty element - return empty literal
-		// Verify this is the EndElement for the property element
-		if endEl.Name.Space == start.Name.Space && endEl.Name.Local == start.Name.Local {
-			lit := Literal{Lexical: ""}
-			if lang != "" {
-				lit.Lang = lang
-				if dir != "" {
-					lit.Lang = lang + "-" + dir
-				}
-			} else if datatype != "" {
-				lit.Datatype = IRI{Value: d.resolveIRI(d.baseURI, datatype)}
+	for {
+		tok, err := d.nextToken()
+		if err != nil {
+			return nil, annotation, annotationNodeID, err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			content.Write(t)
+		case xml.StartElement:
+			if strings.TrimSpace(content.String()) != "" {
+				return nil, annotation, annotationNodeID, d.wrapRDFXMLError(fmt.Errorf("property element %s mixes text and element content", start.Name.Local))
 			}
-			annotation := d.attrValue(start.Attr, rdfXMLNS, "annotation")
-			annotationNodeID := d.attrValue(start.Attr, rdfXMLNS, "annotationNodeID")
-			return lit, annotation, annotationNodeID, nil
+			obj, err := d.readNestedNodeElement(start, t)
+			return obj, annotation, annotationNodeID, err
+		case xml.EndElement:
+			obj, err := d.literalContent(start, content.String(), base, lang, dir)
+			return obj, annotation, annotationNodeID, err
 		}
-		// EndElement doesn't match - this shouldn't happen
-		return nil, "", "", d.wrapRDFXMLError(fmt.Errorf("unexpected EndElement in empty property element"))
 	}
+}
 
-	// Read remaining content until we hit the EndElement for the property element
+// readNestedNodeElement reads the node element el, the object of the
+// property element start, and the rest of start up to its end element,
+// which may contain only whitespace.
+func (d *rdfxmltripleDecoder) readNestedNodeElement(start, el xml.StartElement) (Term, error) {
+	d.handleNamespaceDeclarations(el.Attr)
+	if !d.isNodeElement(el) {
+		return nil, d.wrapRDFXMLError(fmt.Errorf("illegal node element %s in property element %s", el.Name.Local, start.Name.Local))
+	}
+	obj, err := d.readNodeElement(el)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		tok, err := d.nextToken()
 		if err != nil {
-			return nil, "", "", err
+			return nil, err
 		}
 		switch t := tok.(type) {
 		case xml.CharData:
-			content.WriteString(string(t))
-		case xml.StartElement:
-			// Nested element - consume it entirely
-			if err := d.consumeElement(); err != nil {
-				return nil, "", "", err
+			if strings.TrimSpace(string(t)) != "" {
+				return nil, d.wrapRDFXMLError(fmt.Errorf("property element %s mixes text and element content", start.Name.Local))
 			}
+		case xml.StartElement:
+			return nil, d.wrapRDFXMLError(fmt.Errorf("property element %s has more than one node element", start.Name.Local))
 		case xml.EndElement:
-			// Verify this is the EndElement for the property element.
-			if t.Name.Space != start.Name.Space || t.Name.Local != start.Name.Local {
-				return nil, "", "", d.wrapRDFXMLError(fmt.Errorf("unexpected EndElement %s:%s, expected %s:%s", t.Name.Space, t.Name.Local, start.Name.Space, start.Name.Local))
-			}
-			lexical := strings.TrimSpace(content.String())
-			lit := Literal{Lexical: lexical}
-			if lang != "" {
-				lit.Lang = lang
-				if dir != "" {
-					// RDF 1.2: directional language-tagged strings
-					lit.Lang = lang + "-" + dir
-				}
-			} else if datatype != "" {
-				lit.Datatype = IRI{Value: d.resolveIRI(d.baseURI, datatype)}
-			}
-			annotation := d.attrValue(start.Attr, rdfXMLNS, "annotation")
-			annotationNodeID := d.attrValue(start.Attr, rdfXMLNS, "annotationNodeID")
-			return lit, annotation, annotationNodeID, nil
+			return obj, nil
 		}
 	}
 }
 
+// literalContent returns the literal given by the text content of the
+// property element start, in the language lang and direction dir or with
+// its rdf:datatype resolved against base.
+func (d *rdfxmltripleDecoder) literalContent(start xml.StartElement, content, base, lang, dir string) (Term, error) {
+	datatype := d.attrValue(start.Attr, rdfXMLNS, "datatype")
+	lit := Literal{Lexical: strings.TrimSpace(content)}
+	if lang != "" {
+		// RDF 1.2: its:dir gives the base direction
+		lit.Lang, lit.Direction = lang, dir
+	} else if datatype != "" {
+		lit.Datatype = IRI{Value: d.resolveIRI(base, datatype)}
+	}
+	return lit, nil
+}
+
 func (d *rdfxmltripleDecoder) readNestedResource(start xml.StartElement, bnode BlankNode) error {
 	// Read nested properties
 	depth := 1
@@ -379,12 +477,15 @@ func (d *rdfxmltripleDecoder) readNestedResource(start xml.StartElement, bnode B
 		case xml.StartElement:
 			if d.isPropertyElement(t) {
 				pred := d.resolveQName(t.Name.Space, t.Name.Local)
+				reifier := d.reifier(t)
 				obj, _, _, err := d.objectFromPredicate(t)
 				if err != nil {
 					return err
 				}
 				if obj != nil {
-					d.queue = append(d.queue, Triple{S: bnode, P: IRI{Value: pred}, O: obj})
+					triple := Triple{S: bnode, P: IRI{Value: pred}, O: obj}
+					d.queue = append(d.queue, triple)
+					d.queueReification(reifier, triple)
 				}
 				// objectFromPredicate consumes the EndElement, so depth stays the same
 			} else {
@@ -487,6 +588,14 @@ func (d *rdfxmltripleDecoder) readCollection(start xml.StartElement) (Term, erro
 				}
 				item := d.subjectFromNode(t)
 				items = append(items, item)
+				if t.Name.Space != rdfXMLNS || t.Name.Local != "Description" {
+					d.queue = append(d.queue, Triple{
+						S: item,
+						P: IRI{Value: rdfXMLNS + "type"},
+						O: IRI{Value: d.resolveQName(t.Name.Space, t.Name.Local)},
+					})
+				}
+				d.queuePropertyAttributes(item, t)
 				if err := d.readPredicateElements(item, t); err != nil {
 					return nil, err
 				}
@@ -678,9 +787,9 @@ func (d *rdfxmltripleDecoder) isEmptyElement(el xml.StartElement) bool {
 	if parseType != "" {
 		return false
 	}
-	resource := d.attrValue(el.Attr, rdfXMLNS, "resource")
+	_, resource := d.attrLookup(el.Attr, rdfXMLNS, "resource")
 	nodeID := d.attrValue(el.Attr, rdfXMLNS, "nodeID")
-	return resource != "" || nodeID != ""
+	return resource || nodeID != ""
 }
 
 func (d *rdfxmltripleDecoder) validateNodeIDs(attrs []xml.Attr) error {
@@ -810,7 +919,7 @@ func (d *rdfxmltripleDecoder) isNodeElement(el xml.StartElement) bool {
 		return true
 	}
 	// Check for node element attributes (rdf:about or rdf:ID, but NOT rdf:nodeID which can be on property elements)
-	if d.attrValue(el.Attr, rdfXMLNS, "about") != "" ||
+	if _, ok := d.attrLookup(el.Attr, rdfXMLNS, "about"); ok ||
 		d.attrValue(el.Attr, rdfXMLNS, "ID") != "" {
 		return true
 	}
@@ -820,9 +929,9 @@ func (d *rdfxmltripleDecoder) isNodeElement(el xml.StartElement) bool {
 		parseType := d.attrValue(el.Attr, rdfXMLNS, "parseType")
 		if parseType == "" {
 			// Check if it has property attributes - if it does, it's a property element, not a node element
-			resource := d.attrValue(el.Attr, rdfXMLNS, "resource")
+			_, resource := d.attrLookup(el.Attr, rdfXMLNS, "resource")
 			nodeID := d.attrValue(el.Attr, rdfXMLNS, "nodeID")
-			if resource == "" && nodeID == "" {
+			if !resource && nodeID == "" {
 				// No property attributes, so it could be a typed node element
 				// But we also need to check if it has node element attributes
 				// If it has neither, it's an implicit blank node (typed node element)
@@ -848,7 +957,7 @@ func (d *rdfxmltripleDecoder) isContainerElement(el xml.StartElement) bool {
 }
 
 func (d *rdfxmltripleDecoder) subjectFromNode(el xml.StartElement) Term {
-	if about := d.attrValue(el.Attr, rdfXMLNS, "about"); about != "" {
+	if about, ok := d.attrLookup(el.Attr, rdfXMLNS, "about"); ok {
 		return IRI{Value: d.resolveIRI(d.baseURI, about)}
 	}
 	if id := d.attrValue(el.Attr, rdfXMLNS, "ID"); id != "" {
@@ -861,11 +970,9 @@ func (d *rdfxmltripleDecoder) subjectFromNode(el xml.StartElement) Term {
 }
 
 func (d *rdfxmltripleDecoder) resolveID(id string) string {
-	// rdf:ID generates an IRI by appending #id to the base URI.
-	if d.baseURI == "" {
-		return "#" + id
-	}
-	return d.baseURI + "#" + id
+	// rdf:ID="id" abbreviates rdf:about="#id": the fragment replaces any
+	// fragment of the base IRI.
+	return d.resolveIRI(d.baseURI, "#"+id)
 }
 
 func (d *rdfxmltripleDecoder) newBlankNode() BlankNode {
@@ -907,24 +1014,95 @@ func parseContainerIndex(local string) (int, bool) {
 	return value, true
 }
 
+// countInput adds the bytes the XML decoder read since the last call to
+// the pending bytes of the countingByteReader it reads directly.
+func (d *rdfxmltripleDecoder) countInput() {
+	if d.counted != nil {
+		offset := d.dec.InputOffset()
+		d.counted.pending += offset - d.countedOffset
+		d.countedOffset = offset
+	}
+}
+
 func (d *rdfxmltripleDecoder) nextToken() (xml.Token, error) {
+	// Node elements are read whole before their triples are returned, so
+	// cancellation is checked per token rather than per triple.
+	if err := d.cancel.check(); err != nil {
+		return nil, err
+	}
+	if err := d.checkQueueBudget(); err != nil {
+		return nil, err
+	}
+	start := d.dec.InputOffset()
 	tok, err := d.dec.Token()
+	d.countInput()
 	if err != nil {
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) && syntaxErr.Msg == "unexpected EOF" {
+			err = &truncatedError{err}
+		}
 		return nil, err
 	}
 	switch t := tok.(type) {
 	case xml.StartElement:
 		d.pushBase(t)
+		if d.dir != "" && d.dir != "ltr" && d.dir != "rtl" {
+			return nil, d.wrapRDFXMLError(fmt.Errorf("its:dir must be \"ltr\" or \"rtl\", got %q", d.dir))
+		}
+		if err := d.checkElementLimits(t, start); err != nil {
+			return nil, err
+		}
 	case xml.EndElement:
 		d.popBase()
+	case xml.Directive:
+		if err := d.handleDirective(t); err != nil {
+			return nil, err
+		}
+	}
+	if d.maxStatementBytes > 0 && len(d.baseStack) >= 2 && d.dec.InputOffset()-d.stmtStart > int64(d.maxStatementBytes) {
+		return nil, ErrStatementTooLong
 	}
 	return tok, nil
 }
 
+// checkElementLimits enforces the nesting and namespace declaration limits
+// on el, which starts at offset start, and marks the start of a statement.
+func (d *rdfxmltripleDecoder) checkElementLimits(el xml.StartElement, start int64) error {
+	depth := len(d.baseStack)
+	if d.maxDepth > 0 && depth > d.maxDepth {
+		return ErrDepthExceeded
+	}
+	if depth <= 2 {
+		d.stmtStart = start
+	}
+	for _, attr := range el.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			d.prefixCount++
+		}
+	}
+	if d.maxPrefixCount > 0 && d.prefixCount > d.maxPrefixCount {
+		return ErrPrefixLimitExceeded
+	}
+	return nil
+}
+
+// pushBase saves the base IRI, language and direction in scope and applies
+// the xml:base attribute of el, resolved against the base, and its xml:lang
+// and its:dir attributes to el and its descendants until popBase is called
+// for the matching end element. xml:lang="" removes the language.
 func (d *rdfxmltripleDecoder) pushBase(el xml.StartElement) {
 	d.baseStack = append(d.baseStack, d.baseURI)
-	if base := d.attrValue(el.Attr, xmlNS, "base"); base != "" {
-		d.baseURI = d.resolveIRI(d.baseURI, base)
+	d.langStack = append(d.langStack, d.lang)
+	d.dirStack = append(d.dirStack, d.dir)
+	for _, attr := range el.Attr {
+		switch {
+		case attr.Name.Space == xmlNS && attr.Name.Local == "base":
+			d.baseURI = d.resolveIRI(d.baseURI, attr.Value)
+		case attr.Name.Space == xmlNS && attr.Name.Local == "lang":
+			d.lang = attr.Value
+		case attr.Name.Space == itsNS && attr.Name.Local == "dir":
+			d.dir = attr.Value
+		}
 	}
 }
 
@@ -934,15 +1112,27 @@ func (d *rdfxmltripleDecoder) popBase() {
 	}
 	d.baseURI = d.baseStack[len(d.baseStack)-1]
 	d.baseStack = d.baseStack[:len(d.baseStack)-1]
+	d.lang = d.langStack[len(d.langStack)-1]
+	d.langStack = d.langStack[:len(d.langStack)-1]
+	d.dir = d.dirStack[len(d.dirStack)-1]
+	d.dirStack = d.dirStack[:len(d.dirStack)-1]
 }
 
 func (d *rdfxmltripleDecoder) attrValue(attrs []xml.Attr, space, local string) string {
+	value, _ := d.attrLookup(attrs, space, local)
+	return value
+}
+
+// attrLookup is like attrValue but also reports whether the attribute is
+// present, for attributes such as rdf:about="" where an empty value is
+// meaningful.
+func (d *rdfxmltripleDecoder) attrLookup(attrs []xml.Attr, space, local string) (string, bool) {
 	for _, attr := range attrs {
 		if attr.Name.Space == space && attr.Name.Local == local {
-			return attr.Value
+			return attr.Value, true
 		}
 	}
-	return ""
+	return "", false
 }
 
 func (d *rdfxmltripleDecoder) rdfAttrValue(attrs []xml.Attr, local string) string {
@@ -971,13 +1161,13 @@ func (d *rdfxmltripleDecoder) resolveQName(space, local string) string {
 }
 
 // resolveIRI resolves a relative IRI against a base IRI.
-// This is a convenience method that delegates to the centralized resolveIRI function.
+// This is a convenience method that delegates to ResolveIRI.
 func (d *rdfxmltripleDecoder) resolveIRI(base, relative string) string {
 	if base == "" {
 		return relative
 	}
 	// Delegate to centralized IRI resolution function
-	return resolveIRI(base, relative)
+	return ResolveIRI(base, relative)
 }
 
 func (d *rdfxmltripleDecoder) findPrefix(namespace string) string {
@@ -0,0 +1,313 @@
+package rdf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxXMLEntityNameBytes bounds the name of an entity reference tracked by
+// xmlEntityReader; longer names cannot refer to a declared entity.
+const maxXMLEntityNameBytes = 256
+
+// xmlEntityReader feeds the RDF/XML input to encoding/xml. It implements
+// io.ByteReader so that the XML decoder reads it byte by byte without
+// buffering ahead, which lets it count the bytes produced by references to
+// entities declared in the DTD before the decoder expands them.
+type xmlEntityReader struct {
+	r        io.ByteReader
+	counted  *countingByteReader // Input whose bytes r.r reads and this reader counts, or nil
+	entities map[string]string   // expanded replacement text by entity name
+	limit    int                 // maximum bytes produced by expansion (negative = unlimited)
+	expanded int
+	inRef    bool
+	ref      []byte
+}
+
+// xmlPrologBytes is how much of the input newXMLDecoder looks at for a
+// DOCTYPE declaration before the first element.
+const xmlPrologBytes = 1024
+
+// newXMLDecoder returns the XML decoder of the RDF/XML input r, and the
+// xmlEntityReader it reads through when the input may declare a DTD, or
+// nil. Other input is read directly, which saves a call per byte; the
+// bytes the decoder reads of a countingByteReader are then counted from
+// its input offset (see rdfxmltripleDecoder.countInput), and counted is
+// returned for that.
+func newXMLDecoder(r io.Reader, limit int) (dec *xml.Decoder, entities *xmlEntityReader, counted *countingByteReader) {
+	src := r
+	switch r := r.(type) {
+	case *countingByteReader:
+		// Reading past the counting reader saves a call per byte.
+		src, counted = r.countingReader.r, r
+	case io.ByteReader:
+	default:
+		src = bufio.NewReader(r)
+	}
+	if prolog, ok := peekXMLProlog(src); ok && !xmlPrologMayDeclareDTD(prolog) {
+		return xml.NewDecoder(src), nil, counted
+	}
+	entities = &xmlEntityReader{r: src.(io.ByteReader), counted: counted, limit: limit}
+	return xml.NewDecoder(entities), entities, nil
+}
+
+// peekXMLProlog returns up to xmlPrologBytes of the input r without
+// consuming them, or false if r allows no look-ahead.
+func peekXMLProlog(r io.Reader) ([]byte, bool) {
+	switch r := r.(type) {
+	case interface{ Peek(int) ([]byte, error) }:
+		prolog, _ := r.Peek(xmlPrologBytes)
+		return prolog, true
+	case io.ReadSeeker:
+		pos, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, false
+		}
+		prolog := make([]byte, xmlPrologBytes)
+		n, _ := io.ReadFull(r, prolog)
+		if _, err := r.Seek(pos, io.SeekStart); err != nil {
+			return nil, false
+		}
+		return prolog[:n], true
+	}
+	return nil, false
+}
+
+// xmlPrologMayDeclareDTD reports whether the document starting with
+// prolog may have a DOCTYPE declaration: prolog has one before the first
+// element, or ends before it.
+func xmlPrologMayDeclareDTD(prolog []byte) bool {
+	for {
+		i := bytes.IndexByte(prolog, '<')
+		if i < 0 || i+1 == len(prolog) {
+			return true
+		}
+		rest := prolog[i+1:]
+		var end int
+		switch {
+		case rest[0] == '?':
+			end = bytes.Index(rest, []byte("?>"))
+		case bytes.HasPrefix(rest, []byte("!--")):
+			end = bytes.Index(rest, []byte("-->"))
+		case rest[0] == '!':
+			return true
+		default:
+			return false
+		}
+		if end < 0 {
+			return true
+		}
+		prolog = rest[end+2:]
+	}
+}
+
+func (r *xmlEntityReader) Read(p []byte) (int, error) {
+	for i := range p {
+		b, err := r.ReadByte()
+		if err != nil {
+			return i, err
+		}
+		p[i] = b
+	}
+	return len(p), nil
+}
+
+func (r *xmlEntityReader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err == nil && r.counted != nil {
+		r.counted.pending++
+	}
+	if err != nil || len(r.entities) == 0 {
+		return b, err
+	}
+	switch {
+	case b == '&':
+		r.inRef = true
+		r.ref = r.ref[:0]
+	case !r.inRef:
+	case b == ';':
+		r.inRef = false
+		if value, ok := r.entities[string(r.ref)]; ok && r.limit >= 0 {
+			r.expanded += len(value)
+			if r.expanded > r.limit {
+				return 0, fmt.Errorf("rdfxml: entity &%s; expands past %d bytes: %w", r.ref, r.limit, ErrEntityLimitExceeded)
+			}
+		}
+	case len(r.ref) >= maxXMLEntityNameBytes || b == '<' || b == '&' || isXMLSpace(b):
+		r.inRef = false
+	default:
+		r.ref = append(r.ref, b)
+	}
+	return b, nil
+}
+
+// handleDirective processes a <!DOCTYPE ...> declaration: it is rejected
+// when DTDs are disallowed, otherwise the internal general entities it
+// declares are expanded and made available to the XML decoder. Parameter
+// entities are ignored and external entities are never fetched, so
+// references to them fail as undefined entities.
+func (d *rdfxmltripleDecoder) handleDirective(dir []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(dir), []byte("DOCTYPE")) {
+		return nil
+	}
+	if d.disallowDTD {
+		return d.errorf("%w", ErrDTDNotAllowed)
+	}
+	if d.input == nil {
+		return d.errorf("DOCTYPE after the root element")
+	}
+	declared := parseXMLEntityDecls(string(dir))
+	if len(declared) == 0 {
+		return nil
+	}
+	expanded := make(map[string]xmlEntity, len(declared))
+	entities := make(map[string]string, len(declared))
+	for name := range declared {
+		entity, err := d.expandXMLEntity(name, declared, expanded, nil)
+		if err != nil {
+			return err
+		}
+		entities[name] = entity.text
+	}
+	d.dec.Entity = entities
+	d.input.entities = entities
+	return nil
+}
+
+// xmlEntity is the expanded replacement text of a DTD entity and the
+// number of nested entity levels it was expanded from (1 when it refers
+// to no other entity).
+type xmlEntity struct {
+	text  string
+	depth int
+}
+
+// expandXMLEntity expands the character references and nested entity
+// references in the declared replacement text of the entity name,
+// memoizing results in expanded. open holds the entities being expanded
+// to detect entities that refer to themselves.
+func (d *rdfxmltripleDecoder) expandXMLEntity(name string, declared map[string]string, expanded map[string]xmlEntity, open []string) (xmlEntity, error) {
+	if entity, ok := expanded[name]; ok {
+		return entity, nil
+	}
+	for _, o := range open {
+		if o == name {
+			return xmlEntity{}, d.errorf("entity &%s; refers to itself", name)
+		}
+	}
+	open = append(open, name)
+	raw := declared[name]
+	var out strings.Builder
+	depth := 1
+	for {
+		amp := strings.IndexByte(raw, '&')
+		if amp < 0 {
+			out.WriteString(raw)
+			break
+		}
+		out.WriteString(raw[:amp])
+		semi := strings.IndexByte(raw[amp:], ';')
+		if semi < 0 {
+			return xmlEntity{}, d.errorf("unterminated reference in entity &%s;", name)
+		}
+		ref := raw[amp+1 : amp+semi]
+		raw = raw[amp+semi+1:]
+		if text, ok := xmlPredefinedEntity(ref); ok {
+			out.WriteString(text)
+			continue
+		}
+		if _, ok := declared[ref]; !ok {
+			return xmlEntity{}, d.errorf("entity &%s; refers to undefined entity &%s;", name, ref)
+		}
+		nested, err := d.expandXMLEntity(ref, declared, expanded, open)
+		if err != nil {
+			return xmlEntity{}, err
+		}
+		depth = max(depth, nested.depth+1)
+		if d.maxEntityDepth >= 0 && depth > d.maxEntityDepth {
+			return xmlEntity{}, d.errorf("entity &%s; nests entities more than %d deep: %w", name, d.maxEntityDepth, ErrEntityLimitExceeded)
+		}
+		out.WriteString(nested.text)
+		if d.maxEntityExpansion >= 0 && out.Len() > d.maxEntityExpansion {
+			return xmlEntity{}, d.errorf("entity &%s; expands past %d bytes: %w", name, d.maxEntityExpansion, ErrEntityLimitExceeded)
+		}
+	}
+	entity := xmlEntity{text: out.String(), depth: depth}
+	expanded[name] = entity
+	return entity, nil
+}
+
+// xmlPredefinedEntity returns the text of a character reference (#n or
+// #xn) or of one of the five predefined XML entities.
+func xmlPredefinedEntity(ref string) (string, bool) {
+	switch ref {
+	case "amp":
+		return "&", true
+	case "lt":
+		return "<", true
+	case "gt":
+		return ">", true
+	case "apos":
+		return "'", true
+	case "quot":
+		return `"`, true
+	}
+	if !strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+	var n uint64
+	var err error
+	if strings.HasPrefix(ref, "#x") {
+		n, err = strconv.ParseUint(ref[2:], 16, 32)
+	} else {
+		n, err = strconv.ParseUint(ref[1:], 10, 32)
+	}
+	if err != nil {
+		return "", false
+	}
+	return string(rune(n)), true
+}
+
+// parseXMLEntityDecls returns the replacement text of the internal general
+// entities declared in a DOCTYPE directive. As in XML, the first
+// declaration of a name is binding.
+func parseXMLEntityDecls(doctype string) map[string]string {
+	decls := map[string]string{}
+	for {
+		i := strings.Index(doctype, "<!ENTITY")
+		if i < 0 {
+			return decls
+		}
+		doctype = strings.TrimLeft(doctype[i+len("<!ENTITY"):], " \t\r\n")
+		parameter := strings.HasPrefix(doctype, "%")
+		if parameter {
+			doctype = strings.TrimLeft(doctype[1:], " \t\r\n")
+		}
+		end := strings.IndexAny(doctype, " \t\r\n")
+		if end < 0 {
+			return decls
+		}
+		name := doctype[:end]
+		doctype = strings.TrimLeft(doctype[end:], " \t\r\n")
+		if doctype == "" || (doctype[0] != '"' && doctype[0] != '\'') {
+			continue // external entity
+		}
+		closing := strings.IndexByte(doctype[1:], doctype[0])
+		if closing < 0 {
+			return decls
+		}
+		value := doctype[1 : closing+1]
+		doctype = doctype[closing+2:]
+		if _, ok := decls[name]; !ok && !parameter {
+			decls[name] = value
+		}
+	}
+}
+
+func isXMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
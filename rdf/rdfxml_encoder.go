@@ -4,11 +4,15 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 )
 
 // RDFXMLEncodeOptions configures RDF/XML encoding.
 type RDFXMLEncodeOptions struct {
+	// Pretty holds all triples until Close and writes one indented node
+	// element per subject, typed by its first rdf:type where possible,
+	// with every namespace declared on the root element.
 	Pretty   bool
 	Indent   string
 	Prefixes map[string]string
@@ -27,6 +31,18 @@ type rdfxmltripleEncoder struct {
 	rootPrefixes map[string]string
 	nsToPref     map[string]string
 	autoSeq      int
+
+	// Pretty mode: subjects in first-seen order and their descriptions
+	nodes     []*rdfxmlNode
+	nodeIndex map[Term]*rdfxmlNode
+}
+
+// rdfxmlNode is the description of one subject in pretty mode.
+type rdfxmlNode struct {
+	subject    Term
+	typeIRI    string // rdf:type written as the node element name
+	typeName   string // QName of the node element, "" for rdf:Description
+	properties []Triple
 }
 
 func newRDFXMLtripleEncoder(w io.Writer) tripleEncoder {
@@ -45,12 +61,13 @@ func newRDFXMLtripleEncoderWithOptions(w io.Writer, opts RDFXMLEncodeOptions) tr
 		nsToPref[ns] = prefix
 	}
 	return &rdfxmltripleEncoder{
-		writer:       bufio.NewWriter(w),
+		writer:       newEncoderBuffer(w),
 		opts:         opts,
 		indent:       indent,
 		prefixes:     prefixes,
 		rootPrefixes: rootPrefixes,
 		nsToPref:     nsToPref,
+		nodeIndex:    map[Term]*rdfxmlNode{},
 	}
 }
 
@@ -61,6 +78,9 @@ func (e *rdfxmltripleEncoder) Write(t Triple) error {
 	if e.closed {
 		return fmt.Errorf("rdfxml: writer closed")
 	}
+	if e.opts.Pretty {
+		return e.add(t)
+	}
 	if !e.started {
 		e.started = true
 		if _, err := e.writer.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n"); err != nil {
@@ -88,6 +108,9 @@ func (e *rdfxmltripleEncoder) Write(t Triple) error {
 			return err
 		}
 	}
+	if e.opts.BaseIRI != "" {
+		t.S, t.O = e.relativize(t.S), e.relativize(t.O)
+	}
 	subjectAttrs, err := rdfxmlSubjectAttrs(t.S)
 	if err != nil {
 		return err
@@ -116,14 +139,9 @@ func (e *rdfxmltripleEncoder) Write(t Triple) error {
 	if !ok {
 		return fmt.Errorf("rdfxml: unsupported object type")
 	}
-	if lit.Lang != "" && lit.Datatype.Value != "" {
-		return fmt.Errorf("rdfxml: literal cannot have both language and datatype")
-	}
-	literalAttrs := ""
-	if lit.Lang != "" {
-		literalAttrs = ` xml:lang="` + escapeXMLAttr(lit.Lang) + `"`
-	} else if lit.Datatype.Value != "" {
-		literalAttrs = ` rdf:datatype="` + escapeXMLAttr(lit.Datatype.Value) + `"`
+	literalAttrs, err := rdfxmlLiteralAttrs(lit)
+	if err != nil {
+		return err
 	}
 	line := fmt.Sprintf(`%s<rdf:Description %s><%s%s%s>%s</%s></rdf:Description>`+"\n", e.indent, subjectAttrs, predicate, predicateNS, literalAttrs, escapeXML(lit.Lexical), predicate)
 	_, err = e.writer.WriteString(line)
@@ -140,6 +158,9 @@ func (e *rdfxmltripleEncoder) Flush() error {
 	if e.closed {
 		return fmt.Errorf("rdfxml: writer closed")
 	}
+	if e.opts.Pretty {
+		return nil
+	}
 	return e.writer.Flush()
 }
 
@@ -148,6 +169,13 @@ func (e *rdfxmltripleEncoder) Close() error {
 		return e.err
 	}
 	e.closed = true
+	if e.opts.Pretty {
+		if err := e.writeDocument(); err != nil {
+			e.err = err
+			return err
+		}
+		return e.writer.Flush()
+	}
 	if e.started {
 		_, err := e.writer.WriteString(`</rdf:RDF>` + "\n")
 		if err != nil {
@@ -159,6 +187,315 @@ func (e *rdfxmltripleEncoder) Close() error {
 	return nil
 }
 
+// add records t in the description of its subject. An rdf:type whose
+// object can be written as a QName becomes the node element name if the
+// subject has none yet; rdf:Bag, rdf:Seq and rdf:Alt take precedence over
+// other types.
+func (e *rdfxmltripleEncoder) add(t Triple) error {
+	if _, err := rdfxmlSubjectAttrs(t.S); err != nil {
+		return err
+	}
+	switch o := t.O.(type) {
+	case IRI, BlankNode:
+	case Literal:
+		if _, err := rdfxmlLiteralAttrs(o); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("rdfxml: unsupported object type")
+	}
+	if _, err := e.qname(t.P.Value); err != nil {
+		return err
+	}
+	if e.opts.BaseIRI != "" {
+		t.S = e.relativize(t.S)
+	}
+	node := e.nodeIndex[t.S]
+	if node == nil {
+		node = &rdfxmlNode{subject: t.S}
+		e.nodeIndex[t.S] = node
+		e.nodes = append(e.nodes, node)
+	}
+	if typ, ok := t.O.(IRI); ok && t.P.Value == rdfTypeIRI && (node.typeName == "" || isContainerType(typ.Value) && !isContainerType(node.typeIRI)) {
+		if name, err := e.qname(typ.Value); err == nil {
+			if node.typeIRI != "" {
+				node.properties = append(node.properties, Triple{S: t.S, P: t.P, O: IRI{Value: node.typeIRI}})
+			}
+			node.typeIRI, node.typeName = typ.Value, name
+			return nil
+		}
+	}
+	if e.opts.BaseIRI != "" {
+		t.O = e.relativize(t.O)
+	}
+	node.properties = append(node.properties, t)
+	return nil
+}
+
+// qname returns the QName of iri, declaring a prefix for its namespace on
+// the root element if there is none yet.
+func (e *rdfxmltripleEncoder) qname(iri string) (string, error) {
+	ns, local, ok := splitIRIForQName(iri)
+	if !ok {
+		return "", fmt.Errorf("rdfxml: unable to abbreviate IRI %q", iri)
+	}
+	prefix, ok := e.nsToPref[ns]
+	if !ok {
+		prefix = e.newPrefix(ns)
+		e.prefixes[prefix] = ns
+		e.rootPrefixes[prefix] = ns
+		e.nsToPref[ns] = prefix
+	}
+	if prefix == "" {
+		return local, nil
+	}
+	return prefix + ":" + local, nil
+}
+
+// newPrefix picks an unused prefix for ns: its well-known prefix if it has
+// one, otherwise the next free ns<N>.
+func (e *rdfxmltripleEncoder) newPrefix(ns string) string {
+	if prefix, ok := rdfxmlWellKnownPrefixes[ns]; ok {
+		if _, taken := e.prefixes[prefix]; !taken {
+			return prefix
+		}
+	}
+	for {
+		prefix := fmt.Sprintf("ns%d", e.autoSeq)
+		e.autoSeq++
+		if _, taken := e.prefixes[prefix]; !taken {
+			return prefix
+		}
+	}
+}
+
+// rdfxmlWellKnownPrefixes maps common vocabulary namespaces to the prefixes
+// pretty output declares for them.
+var rdfxmlWellKnownPrefixes = map[string]string{
+	rdfXMLNS:                                "rdf",
+	"http://www.w3.org/2000/01/rdf-schema#": "rdfs",
+	"http://www.w3.org/2002/07/owl#":        "owl",
+	xsdNamespace:                            "xsd",
+	"http://www.w3.org/2004/02/skos/core#":  "skos",
+	"http://xmlns.com/foaf/0.1/":            "foaf",
+	"http://purl.org/dc/elements/1.1/":      "dc",
+	"http://purl.org/dc/terms/":             "dcterms",
+	"http://schema.org/":                    "schema",
+	"http://www.w3.org/ns/prov#":            "prov",
+}
+
+// writeDocument writes the buffered descriptions as an indented document.
+func (e *rdfxmltripleEncoder) writeDocument() error {
+	if len(e.nodes) == 0 {
+		return nil
+	}
+	w := e.writer
+	w.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	w.WriteString("<rdf:RDF\n" + e.indent + e.indent + `xmlns:rdf="` + rdfXMLNS + `"`)
+	if e.opts.BaseIRI != "" {
+		w.WriteString("\n" + e.indent + e.indent + `xml:base="` + escapeXMLAttr(e.opts.BaseIRI) + `"`)
+	}
+	for _, prefix := range sortedPrefixKeys(e.rootPrefixes) {
+		ns := e.rootPrefixes[prefix]
+		switch {
+		case prefix == "rdf":
+			continue
+		case prefix == "":
+			w.WriteString("\n" + e.indent + e.indent + `xmlns="` + escapeXMLAttr(ns) + `"`)
+		default:
+			w.WriteString("\n" + e.indent + e.indent + `xmlns:` + prefix + `="` + escapeXMLAttr(ns) + `"`)
+		}
+	}
+	w.WriteString(">\n")
+	lists := e.collections()
+	for _, node := range e.nodes {
+		if _, ok := lists.nodes[node.subject]; ok {
+			continue
+		}
+		subjectAttrs, _ := rdfxmlSubjectAttrs(node.subject)
+		name := node.typeName
+		if name == "" {
+			name = "rdf:Description"
+		}
+		if len(node.properties) == 0 {
+			w.WriteString(e.indent + "<" + name + " " + subjectAttrs + "/>\n")
+			continue
+		}
+		properties := node.properties
+		if isContainerType(node.typeIRI) {
+			properties = rdfxmlSortMembers(properties)
+		}
+		w.WriteString(e.indent + "<" + name + " " + subjectAttrs + ">\n")
+		for _, t := range properties {
+			e.writeProperty(t, lists)
+		}
+		w.WriteString(e.indent + "</" + name + ">\n")
+	}
+	_, err := w.WriteString("</rdf:RDF>\n")
+	return err
+}
+
+// writeProperty writes the property element of t, as an
+// rdf:parseType="Collection" element if its object heads a collection.
+func (e *rdfxmltripleEncoder) writeProperty(t Triple, lists rdfxmlCollections) {
+	w := e.writer
+	predicate, _ := e.qname(t.P.Value)
+	w.WriteString(e.indent + e.indent + "<" + predicate)
+	switch o := t.O.(type) {
+	case IRI:
+		w.WriteString(` rdf:resource="` + escapeXMLAttr(o.Value) + `"/>` + "\n")
+	case BlankNode:
+		items, ok := lists.heads[t]
+		if !ok {
+			w.WriteString(` rdf:nodeID="` + escapeXMLAttr(o.ID) + `"/>` + "\n")
+			return
+		}
+		w.WriteString(` rdf:parseType="Collection">` + "\n")
+		for _, item := range items {
+			attrs, _ := rdfxmlSubjectAttrs(item)
+			w.WriteString(e.indent + e.indent + e.indent + "<rdf:Description " + attrs + "/>\n")
+		}
+		w.WriteString(e.indent + e.indent + "</" + predicate + ">\n")
+	case Literal:
+		literalAttrs, _ := rdfxmlLiteralAttrs(o)
+		w.WriteString(literalAttrs + ">" + escapeXML(o.Lexical) + "</" + predicate + ">\n")
+	}
+}
+
+// rdfxmlCollections records the collections written with
+// rdf:parseType="Collection": the items of the list headed by the object of
+// each property, and the list nodes they replace.
+type rdfxmlCollections struct {
+	heads map[Triple][]Term
+	nodes map[Term]struct{}
+}
+
+// collections finds the properties whose object is the head of a
+// well-formed collection: a chain of blank nodes, each the object of exactly
+// one statement and described only by one rdf:first and one rdf:rest,
+// ending in rdf:nil, with IRI or blank node items. Collections are only
+// found at their head, not at the rdf:rest of an enclosing list node.
+func (e *rdfxmltripleEncoder) collections() rdfxmlCollections {
+	refs := map[Term]int{}
+	for _, node := range e.nodes {
+		for _, t := range node.properties {
+			if b, ok := t.O.(BlankNode); ok {
+				refs[b]++
+			}
+		}
+	}
+	lists := rdfxmlCollections{heads: map[Triple][]Term{}, nodes: map[Term]struct{}{}}
+	for _, node := range e.nodes {
+		if _, _, ok := e.listNode(node.subject, refs); ok {
+			continue
+		}
+		for _, t := range node.properties {
+			items, chain, ok := e.listItems(t.O, refs)
+			if !ok || len(items) == 0 {
+				continue
+			}
+			lists.heads[t] = items
+			for _, b := range chain {
+				lists.nodes[b] = struct{}{}
+			}
+		}
+	}
+	return lists
+}
+
+// listItems returns the items and list nodes of the collection headed by
+// head, and whether head is the head of a well-formed collection.
+func (e *rdfxmltripleEncoder) listItems(head Term, refs map[Term]int) ([]Term, []Term, bool) {
+	var items, chain []Term
+	visited := map[Term]bool{}
+	for head != (IRI{Value: rdfNilIRI}) {
+		if visited[head] {
+			return nil, nil, false
+		}
+		visited[head] = true
+		first, rest, ok := e.listNode(head, refs)
+		if !ok {
+			return nil, nil, false
+		}
+		items = append(items, first)
+		chain = append(chain, head)
+		head = rest
+	}
+	return items, chain, true
+}
+
+// listNode returns the rdf:first and rdf:rest of term if it is a blank
+// node that can be written as part of a collection.
+func (e *rdfxmltripleEncoder) listNode(term Term, refs map[Term]int) (Term, Term, bool) {
+	b, ok := term.(BlankNode)
+	if !ok || refs[b] != 1 {
+		return nil, nil, false
+	}
+	node := e.nodeIndex[b]
+	if node == nil || node.typeName != "" || len(node.properties) != 2 {
+		return nil, nil, false
+	}
+	var first, rest Term
+	for _, t := range node.properties {
+		switch t.P.Value {
+		case rdfFirstIRI:
+			first = t.O
+		case rdfRestIRI:
+			rest = t.O
+		}
+	}
+	switch first.(type) {
+	case IRI, BlankNode:
+	default:
+		return nil, nil, false
+	}
+	switch r := rest.(type) {
+	case IRI:
+		if r.Value != rdfNilIRI {
+			return nil, nil, false
+		}
+	case BlankNode:
+	default:
+		return nil, nil, false
+	}
+	return first, rest, true
+}
+
+// rdfxmlSortMembers returns the properties of a container with the
+// rdf:_n membership properties moved to the end in index order. They are
+// written as rdf:_n rather than rdf:li so that they read back the same with
+// and without container expansion.
+func rdfxmlSortMembers(properties []Triple) []Triple {
+	index := func(t Triple) int {
+		n, _ := containerMemberIndex(t.P.Value)
+		return n
+	}
+	sorted := append([]Triple(nil), properties...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return index(sorted[i]) < index(sorted[j])
+	})
+	return sorted
+}
+
+// rdfxmlLiteralAttrs returns the xml:lang, its:dir or rdf:datatype
+// attributes of a property element with lit as its content.
+func rdfxmlLiteralAttrs(lit Literal) (string, error) {
+	if lit.Lang != "" && lit.Datatype.Value != "" {
+		return "", fmt.Errorf("rdfxml: literal cannot have both language and datatype")
+	}
+	if lit.Lang != "" {
+		attrs := ` xml:lang="` + escapeXMLAttr(lit.Lang) + `"`
+		if lit.Direction != "" {
+			attrs += ` xmlns:its="` + itsNS + `" its:version="2.0" its:dir="` + escapeXMLAttr(lit.Direction) + `"`
+		}
+		return attrs, nil
+	}
+	if lit.Datatype.Value != "" {
+		return ` rdf:datatype="` + escapeXMLAttr(lit.Datatype.Value) + `"`, nil
+	}
+	return "", nil
+}
+
 func escapeXML(value string) string {
 	replacer := strings.NewReplacer(
 		`&`, "&amp;",
@@ -196,6 +533,15 @@ func rdfxmlSubjectAttrs(term Term) (string, error) {
 	}
 }
 
+// relativize writes IRIs under the base as relative references. The base
+// itself stays absolute: an empty rdf:resource reads as a missing one.
+func (e *rdfxmltripleEncoder) relativize(term Term) Term {
+	if iri, ok := relativizeTerm(term, e.opts.BaseIRI, nil).(IRI); ok && iri.Value != "" {
+		return iri
+	}
+	return term
+}
+
 func (e *rdfxmltripleEncoder) predicateQName(iri string) (string, string, error) {
 	ns, local, ok := splitIRIForQName(iri)
 	if !ok {
@@ -57,12 +57,15 @@ func (d *rdfxmltripleDecoder) validateParseTypeAttributes(attrs []xml.Attr, pars
 func (d *rdfxmltripleDecoder) handleEmptyPropertyElementWithAttributes(
 	el xml.StartElement,
 	subject Term,
+	containerKey string,
 ) (bool, error) {
-	resource := d.attrValue(el.Attr, rdfXMLNS, "resource")
-	if resource != "" {
-		pred := d.resolveQName(el.Name.Space, el.Name.Local)
+	if resource, ok := d.attrLookup(el.Attr, rdfXMLNS, "resource"); ok {
+		pred, _ := d.resolveContainerPredicate(el, containerKey)
 		obj := IRI{Value: d.resolveIRI(d.baseURI, resource)}
-		d.queue = append(d.queue, Triple{S: subject, P: IRI{Value: pred}, O: obj})
+		triple := Triple{S: subject, P: IRI{Value: pred}, O: obj}
+		d.queue = append(d.queue, triple)
+		d.queueReification(d.reifier(el), triple)
+		d.queuePropertyAttributes(obj, el)
 		if err := d.consumeElement(); err != nil {
 			return false, err
 		}
@@ -74,9 +77,12 @@ func (d *rdfxmltripleDecoder) handleEmptyPropertyElementWithAttributes(
 		if !isValidXMLName(nodeID) {
 			return false, d.wrapRDFXMLError(fmt.Errorf("invalid rdf:nodeID %q", nodeID))
 		}
-		pred := d.resolveQName(el.Name.Space, el.Name.Local)
+		pred, _ := d.resolveContainerPredicate(el, containerKey)
 		obj := BlankNode{ID: nodeID}
-		d.queue = append(d.queue, Triple{S: subject, P: IRI{Value: pred}, O: obj})
+		triple := Triple{S: subject, P: IRI{Value: pred}, O: obj}
+		d.queue = append(d.queue, triple)
+		d.queueReification(d.reifier(el), triple)
+		d.queuePropertyAttributes(obj, el)
 		if err := d.consumeElement(); err != nil {
 			return false, err
 		}
@@ -118,6 +124,7 @@ func (d *rdfxmltripleDecoder) processPropertyElement(
 	containerKey string,
 ) error {
 	pred, _ := d.resolveContainerPredicate(el, containerKey)
+	reifier := d.reifier(el)
 	obj, annotation, annotationNodeID, err := d.objectFromPredicate(el)
 	if err != nil {
 		return err
@@ -129,6 +136,7 @@ func (d *rdfxmltripleDecoder) processPropertyElement(
 
 	triple := Triple{S: subject, P: IRI{Value: pred}, O: obj}
 	d.queue = append(d.queue, triple)
+	d.queueReification(reifier, triple)
 
 	if annotation != "" || annotationNodeID != "" {
 		anns := d.handleAnnotation(subject, IRI{Value: pred}, obj, annotation, annotationNodeID)
@@ -139,3 +147,99 @@ func (d *rdfxmltripleDecoder) processPropertyElement(
 
 	return nil
 }
+
+// queuePropertyAttributes queues the triples abbreviated as property
+// attributes of el, which describe subject: one literal-valued triple per
+// attribute, in the language and direction in scope, and an IRI-valued
+// triple for rdf:type.
+func (d *rdfxmltripleDecoder) queuePropertyAttributes(subject Term, el xml.StartElement) {
+	for _, attr := range el.Attr {
+		if attr.Name.Space == rdfXMLNS && attr.Name.Local == "type" {
+			d.queue = append(d.queue, Triple{
+				S: subject,
+				P: IRI{Value: rdfXMLNS + "type"},
+				O: IRI{Value: d.resolveIRI(d.baseURI, attr.Value)},
+			})
+			continue
+		}
+		if !isPropertyAttribute(attr) {
+			continue
+		}
+		d.queue = append(d.queue, Triple{
+			S: subject,
+			P: IRI{Value: d.resolveQName(attr.Name.Space, attr.Name.Local)},
+			O: d.propertyAttributeLiteral(attr.Value),
+		})
+	}
+}
+
+// propertyAttributeLiteral returns the literal value of a property
+// attribute in the language in scope, with the direction in scope if it
+// has a language.
+func (d *rdfxmltripleDecoder) propertyAttributeLiteral(value string) Literal {
+	lit := Literal{Lexical: value, Lang: d.lang}
+	if d.lang != "" {
+		lit.Direction = d.dir
+	}
+	return lit
+}
+
+// hasPropertyAttributes reports whether el has property attributes,
+// including rdf:type.
+func (d *rdfxmltripleDecoder) hasPropertyAttributes(el xml.StartElement) bool {
+	for _, attr := range el.Attr {
+		if isPropertyAttribute(attr) || attr.Name.Space == rdfXMLNS && attr.Name.Local == "type" {
+			return true
+		}
+	}
+	return false
+}
+
+// isPropertyAttribute reports whether attr is a property attribute: a
+// namespaced attribute that is not an RDF syntax attribute, an xml:, xmlns
+// or its: attribute, or reserved by XML (names starting with "xml").
+// rdf:type is handled separately since its value is an IRI.
+func isPropertyAttribute(attr xml.Attr) bool {
+	switch attr.Name.Space {
+	case "", "xmlns", xmlNS, itsNS:
+		return false
+	case rdfXMLNS:
+		switch attr.Name.Local {
+		case "RDF", "ID", "about", "bagID", "parseType", "resource", "nodeID", "datatype", "li",
+			"aboutEach", "aboutEachPrefix", "Description", "annotation", "annotationNodeID", "type", "version":
+			return false
+		}
+	}
+	return !strings.HasPrefix(strings.ToLower(attr.Name.Local), "xml")
+}
+
+// reifier returns the IRI named by the rdf:ID attribute of the property
+// element el, or nil if it has none. It must be called while el is in scope
+// so that the IRI is resolved against its base.
+func (d *rdfxmltripleDecoder) reifier(el xml.StartElement) Term {
+	id := d.rdfAttrValue(el.Attr, "ID")
+	if id == "" {
+		return nil
+	}
+	return IRI{Value: d.resolveID(id)}
+}
+
+// queueReification queues the reification of t named by the rdf:ID of its
+// property element: the rdf:Statement, rdf:subject, rdf:predicate and
+// rdf:object triples of RDF/XML 1.1 or, with OptRDFXMLReifiers, a single
+// RDF 1.2 rdf:reifies triple. A nil reifier queues nothing.
+func (d *rdfxmltripleDecoder) queueReification(reifier Term, t Triple) {
+	if reifier == nil {
+		return
+	}
+	if d.reifiers {
+		d.queue = append(d.queue, Triple{S: reifier, P: IRI{Value: rdfXMLNS + "reifies"}, O: TripleTerm{S: t.S, P: t.P, O: t.O}})
+		return
+	}
+	d.queue = append(d.queue,
+		Triple{S: reifier, P: IRI{Value: rdfXMLNS + "type"}, O: IRI{Value: rdfXMLNS + "Statement"}},
+		Triple{S: reifier, P: IRI{Value: rdfXMLNS + "subject"}, O: t.S},
+		Triple{S: reifier, P: IRI{Value: rdfXMLNS + "predicate"}, O: t.P},
+		Triple{S: reifier, P: IRI{Value: rdfXMLNS + "object"}, O: t.O},
+	)
+}
@@ -2,6 +2,7 @@ package rdf
 
 import (
 	"bytes"
+	"io"
 	"strings"
 	"testing"
 )
@@ -42,8 +43,15 @@ func TestRDFXMLUnsupportedNestedElement(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if _, err := dec.Next(); err == nil {
-		t.Fatal("expected nested element error")
+	// A nested node element may contain only property elements.
+	for {
+		_, err := dec.Next()
+		if err == io.EOF {
+			t.Fatal("expected nested element error")
+		}
+		if err != nil {
+			break
+		}
 	}
 }
 
@@ -62,3 +70,294 @@ func TestRDFXMLEncoderUnsupportedObject(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestRDFXMLEncoderPretty(t *testing.T) {
+	alice := IRI{Value: "http://example.org/alice"}
+	stmts := []Statement{
+		NewTriple(alice, IRI{Value: rdfTypeIRI}, IRI{Value: "http://xmlns.com/foaf/0.1/Person"}),
+		NewTriple(alice, IRI{Value: "http://xmlns.com/foaf/0.1/name"}, Literal{Lexical: "Alice & co", Lang: "en"}),
+		NewTriple(BlankNode{ID: "b1"}, IRI{Value: "http://example.org/age"}, Literal{Lexical: "3", Datatype: IRI{Value: xsdNamespace + "integer"}}),
+		NewTriple(alice, IRI{Value: "http://xmlns.com/foaf/0.1/knows"}, BlankNode{ID: "b1"}),
+		NewTriple(alice, IRI{Value: rdfTypeIRI}, IRI{Value: "http://example.org/Agent"}),
+		NewTriple(IRI{Value: "http://example.org/bob"}, IRI{Value: rdfTypeIRI}, IRI{Value: "http://xmlns.com/foaf/0.1/Person"}),
+	}
+	var buf bytes.Buffer
+	enc, err := NewWriter(&buf, FormatRDFXML, OptRDFXMLPretty())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, stmt := range stmts {
+		if err := enc.Write(stmt); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := enc.Flush(); err != nil || buf.Len() != 0 {
+		t.Fatalf("expected Flush to write nothing, got %q (%v)", buf.String(), err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF
+    xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+    xmlns:foaf="http://xmlns.com/foaf/0.1/"
+    xmlns:ns0="http://example.org/">
+  <foaf:Person rdf:about="http://example.org/alice">
+    <foaf:name xml:lang="en">Alice &amp; co</foaf:name>
+    <foaf:knows rdf:nodeID="b1"/>
+    <rdf:type rdf:resource="http://example.org/Agent"/>
+  </foaf:Person>
+  <rdf:Description rdf:nodeID="b1">
+    <ns0:age rdf:datatype="http://www.w3.org/2001/XMLSchema#integer">3</ns0:age>
+  </rdf:Description>
+  <foaf:Person rdf:about="http://example.org/bob"/>
+</rdf:RDF>
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+
+	dec, err := NewReader(strings.NewReader(buf.String()), FormatRDFXML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := collectStatements(dec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isomorphicQuads(statementsToQuads(stmts), statementsToQuads(parsed)) {
+		t.Fatalf("round trip changed the graph: %v", parsed)
+	}
+}
+
+func TestRDFXMLEncoderPrettyContainersAndCollections(t *testing.T) {
+	input := `@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+ex:s ex:list ( ex:a _:z ex:c ) ; ex:empty () ; ex:literals _:l1 .
+_:l1 rdf:first "lit" ; rdf:rest _:l2 .
+_:l2 rdf:first ex:d ; rdf:rest rdf:nil .
+ex:c a ex:Thing, rdf:Seq ; rdf:_2 "two" ; rdf:_1 ex:one ; rdf:_10 "ten" .
+_:z ex:p "z" .
+`
+	dec, err := NewReader(strings.NewReader(input), FormatTurtle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmts, err := collectStatements(dec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	enc, err := NewWriter(&buf, FormatRDFXML, OptRDFXMLPretty())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, stmt := range stmts {
+		if err := enc.Write(stmt); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		`    <ns0:list rdf:parseType="Collection">
+      <rdf:Description rdf:about="http://example.org/a"/>
+      <rdf:Description rdf:nodeID="z"/>
+      <rdf:Description rdf:about="http://example.org/c"/>
+    </ns0:list>`,
+		`<ns0:empty rdf:resource="http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"/>`,
+		`    <rdf:first>lit</rdf:first>
+    <rdf:rest rdf:parseType="Collection">
+      <rdf:Description rdf:about="http://example.org/d"/>
+    </rdf:rest>`,
+		`  <rdf:Seq rdf:about="http://example.org/c">
+    <rdf:type rdf:resource="http://example.org/Thing"/>
+    <rdf:_1 rdf:resource="http://example.org/one"/>
+    <rdf:_2>two</rdf:_2>
+    <rdf:_10>ten</rdf:_10>
+  </rdf:Seq>`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain\n%s\ngot:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "rdf:first rdf:resource") {
+		t.Errorf("expected list nodes to be written as collections:\n%s", output)
+	}
+
+	for _, opts := range [][]Option{nil, {OptDisableRDFXMLContainerExpansion()}} {
+		dec, err := NewReader(strings.NewReader(output), FormatRDFXML, opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		parsed, err := collectStatements(dec)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !isomorphicQuads(statementsToQuads(stmts), statementsToQuads(parsed)) {
+			t.Fatalf("round trip changed the graph: %v", parsed)
+		}
+	}
+}
+
+func TestRDFXMLScopedBase(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/ns#" xml:base="dir/file#frag">
+  <rdf:Description rdf:about="">
+    <ex:p rdf:resource="a"/>
+    <ex:p xml:base="http://other.example/x/" rdf:resource="b"/>
+    <ex:q xml:base="http://other.example/types/" rdf:datatype="T">v</ex:q>
+    <ex:q xml:base="http://other.example/types/" rdf:datatype="T"></ex:q>
+  </rdf:Description>
+  <rdf:Description rdf:ID="id" xml:base="http://nested.example/doc">
+    <ex:p rdf:resource="c"/>
+  </rdf:Description>
+  <rdf:Description rdf:ID="id">
+    <ex:p rdf:resource="d"/>
+  </rdf:Description>
+</rdf:RDF>`
+	dec, err := NewReader(strings.NewReader(input), FormatRDFXML, OptBaseIRI("http://example.org/root/doc"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmts, err := collectStatements(dec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, stmt := range stmts {
+		got = append(got, stmt.S.String()+" "+stmt.O.String())
+	}
+	expected := []string{
+		`http://example.org/root/dir/file http://example.org/root/dir/a`,
+		`http://example.org/root/dir/file http://other.example/x/b`,
+		`http://example.org/root/dir/file "v"^^<http://other.example/types/T>`,
+		`http://example.org/root/dir/file ""^^<http://other.example/types/T>`,
+		`http://nested.example/doc#id http://nested.example/c`,
+		`http://example.org/root/dir/file#id http://example.org/root/dir/d`,
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected statements:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRDFXMLPropertyAttributes(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:foaf="http://xmlns.com/foaf/0.1/" xmlns:ex="http://example.org/" xml:lang="en" xml:base="http://example.org/">
+  <foaf:Person rdf:about="alice" foaf:name="Alice" rdf:type="Agent" xmlns:other="urn:x:" xml:space="preserve">
+    <ex:address ex:city="Paris" xml:lang="fr"/>
+    <foaf:knows rdf:resource="bob" foaf:name="Bob" xml:lang=""/>
+  </foaf:Person>
+</rdf:RDF>`
+	dec, err := NewReader(strings.NewReader(input), FormatRDFXML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmts, err := collectStatements(dec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, stmt := range stmts {
+		got = append(got, stmt.S.String()+" "+stmt.P.String()+" "+stmt.O.String())
+	}
+	expected := []string{
+		`http://example.org/alice http://www.w3.org/1999/02/22-rdf-syntax-ns#type http://xmlns.com/foaf/0.1/Person`,
+		`http://example.org/alice http://xmlns.com/foaf/0.1/name "Alice"@en`,
+		`http://example.org/alice http://www.w3.org/1999/02/22-rdf-syntax-ns#type http://example.org/Agent`,
+		`_:b1 http://example.org/city "Paris"@fr`,
+		`http://example.org/alice http://example.org/address _:b1`,
+		`http://example.org/alice http://xmlns.com/foaf/0.1/knows http://example.org/bob`,
+		`http://example.org/bob http://xmlns.com/foaf/0.1/name "Bob"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected statements:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRDFXMLInheritedDirection(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/" xmlns:its="http://www.w3.org/2005/11/its" xml:lang="ar">
+  <rdf:Description rdf:about="http://example.org/s" its:dir="rtl" ex:a="x">
+    <ex:b>y</ex:b>
+    <ex:c its:dir="ltr" xml:lang="en">z</ex:c>
+    <ex:d xml:lang="">w</ex:d>
+  </rdf:Description>
+  <rdf:Description rdf:about="http://example.org/t" ex:e="v"/>
+</rdf:RDF>`
+	dec, err := NewReader(strings.NewReader(input), FormatRDFXML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmts, err := collectStatements(dec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, stmt := range stmts {
+		got = append(got, stmt.S.String()+" "+stmt.P.String()+" "+stmt.O.String())
+	}
+	expected := []string{
+		`http://example.org/s http://example.org/a "x"@ar--rtl`,
+		`http://example.org/s http://example.org/b "y"@ar--rtl`,
+		`http://example.org/s http://example.org/c "z"@en--ltr`,
+		`http://example.org/s http://example.org/d "w"`,
+		`http://example.org/t http://example.org/e "v"@ar`,
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected statements:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRDFXMLReification(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/" xml:base="http://example.org/doc">
+  <rdf:Description rdf:about="#s">
+    <ex:name rdf:ID="r1">Alice</ex:name>
+    <ex:knows rdf:ID="r2" rdf:resource="#o" xml:base="http://example.com/"/>
+  </rdf:Description>
+</rdf:RDF>`
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected []string
+	}{
+		{"statement", nil, []string{
+			`http://example.org/doc#s http://example.org/name "Alice"`,
+			`http://example.org/doc#r1 http://www.w3.org/1999/02/22-rdf-syntax-ns#type http://www.w3.org/1999/02/22-rdf-syntax-ns#Statement`,
+			`http://example.org/doc#r1 http://www.w3.org/1999/02/22-rdf-syntax-ns#subject http://example.org/doc#s`,
+			`http://example.org/doc#r1 http://www.w3.org/1999/02/22-rdf-syntax-ns#predicate http://example.org/name`,
+			`http://example.org/doc#r1 http://www.w3.org/1999/02/22-rdf-syntax-ns#object "Alice"`,
+			`http://example.org/doc#s http://example.org/knows http://example.com/#o`,
+			`http://example.com/#r2 http://www.w3.org/1999/02/22-rdf-syntax-ns#type http://www.w3.org/1999/02/22-rdf-syntax-ns#Statement`,
+			`http://example.com/#r2 http://www.w3.org/1999/02/22-rdf-syntax-ns#subject http://example.org/doc#s`,
+			`http://example.com/#r2 http://www.w3.org/1999/02/22-rdf-syntax-ns#predicate http://example.org/knows`,
+			`http://example.com/#r2 http://www.w3.org/1999/02/22-rdf-syntax-ns#object http://example.com/#o`,
+		}},
+		{"reifier", []Option{OptRDFXMLReifiers()}, []string{
+			`http://example.org/doc#s http://example.org/name "Alice"`,
+			`http://example.org/doc#r1 http://www.w3.org/1999/02/22-rdf-syntax-ns#reifies <<http://example.org/doc#s http://example.org/name "Alice">>`,
+			`http://example.org/doc#s http://example.org/knows http://example.com/#o`,
+			`http://example.com/#r2 http://www.w3.org/1999/02/22-rdf-syntax-ns#reifies <<http://example.org/doc#s http://example.org/knows http://example.com/#o>>`,
+		}},
+	}
+	for _, tt := range tests {
+		dec, err := NewReader(strings.NewReader(input), FormatRDFXML, tt.opts...)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		stmts, err := collectStatements(dec)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		var got []string
+		for _, stmt := range stmts {
+			got = append(got, stmt.S.String()+" "+stmt.P.String()+" "+stmt.O.String())
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+			t.Errorf("%s: unexpected statements:\n%s", tt.name, strings.Join(got, "\n"))
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package rdf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+)
+
+const (
+	rdfStatementIRI = "http://www.w3.org/1999/02/22-rdf-syntax-ns#Statement"
+	rdfSubjectIRI   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#subject"
+	rdfPredicateIRI = "http://www.w3.org/1999/02/22-rdf-syntax-ns#predicate"
+	rdfObjectIRI    = "http://www.w3.org/1999/02/22-rdf-syntax-ns#object"
+)
+
+// ExpandTripleTerms rewrites triple terms into the reification vocabulary of
+// RDF 1.0 (rdf:Statement, rdf:subject, rdf:predicate, rdf:object), for
+// tools that do not support RDF 1.2:
+//
+//   - "r rdf:reifies <<( s p o )>>" becomes a description of r itself, so
+//     statements about the reifier r keep applying to it.
+//   - Any other triple term in subject or object position is replaced with
+//     a blank node described the same way. The node label is derived from a
+//     hash of the triple term, so repeated occurrences share one node, whose
+//     description is returned once per graph before its first use.
+//
+// Nested triple terms are expanded too. Memory grows with the number of
+// distinct triple terms. ContractReification reverses the first rule.
+func ExpandTripleTerms() Transform {
+	return func(r Reader) Reader {
+		return &expandReader{src: r, reifier: newTripleTermReifier()}
+	}
+}
+
+// ContractReification rewrites RDF 1.0 reifications into RDF 1.2: the
+// statements "r rdf:subject s", "r rdf:predicate p", "r rdf:object o" and an
+// optional "r rdf:type rdf:Statement" in one graph are replaced with
+// "r rdf:reifies <<( s p o )>>", as RDF 1.2 maps classic reification. Other
+// statements about r are kept. A triple term that ExpandTripleTerms replaced
+// with a blank node therefore comes back as that blank node reifying it.
+//
+// The reification statements of r are held back until all three are read;
+// incomplete reifications are returned unchanged at the end of the input.
+// Memory grows with the number of reifications.
+func ContractReification() Transform {
+	return func(r Reader) Reader {
+		return &contractReader{
+			src:     r,
+			partial: make(map[reificationKey]*reification),
+			done:    make(map[reificationKey]bool),
+		}
+	}
+}
+
+// tripleTermReifier applies the rules of ExpandTripleTerms. It is shared by
+// the transform and by writers with OptReifyTripleTerms.
+type tripleTermReifier struct {
+	described map[string]bool // Graph and label of each description written
+}
+
+func newTripleTermReifier() *tripleTermReifier {
+	return &tripleTermReifier{described: make(map[string]bool)}
+}
+
+// reify returns the statements replacing stmt: descriptions of triple terms
+// not yet described in its graph, followed by stmt with its triple terms
+// replaced, or by the description of its reifier.
+func (r *tripleTermReifier) reify(stmt Statement) []Statement {
+	_, isSubjectTerm := stmt.S.(TripleTerm)
+	objectTerm, isObjectTerm := stmt.O.(TripleTerm)
+	if !isSubjectTerm && !isObjectTerm {
+		return []Statement{stmt}
+	}
+	var out []Statement
+	if isObjectTerm && !isSubjectTerm && stmt.P.Value == rdfReifiesIRI {
+		r.describe(stmt.S, objectTerm, stmt.G, &out)
+		return out
+	}
+	stmt.S = r.replace(stmt.S, stmt.G, &out)
+	stmt.O = r.replace(stmt.O, stmt.G, &out)
+	return append(out, stmt)
+}
+
+// replace returns the blank node standing for term if it is a triple term,
+// appending its description (and those of nested triple terms) to out.
+func (r *tripleTermReifier) replace(term Term, graph Term, out *[]Statement) Term {
+	tt, ok := term.(TripleTerm)
+	if !ok {
+		return term
+	}
+	sum := sha256.Sum256([]byte(renderTerm(tt)))
+	node := BlankNode{ID: "reif" + hex.EncodeToString(sum[:8])}
+	key := node.ID
+	if graph != nil {
+		key = renderTerm(graph) + " " + key
+	}
+	if r.described[key] {
+		return node
+	}
+	r.described[key] = true
+	r.describe(node, tt, graph, out)
+	return node
+}
+
+// describe appends the reification statements of node for tt to out.
+func (r *tripleTermReifier) describe(node Term, tt TripleTerm, graph Term, out *[]Statement) {
+	subject := r.replace(tt.S, graph, out)
+	object := r.replace(tt.O, graph, out)
+	*out = append(*out,
+		Statement{S: node, P: IRI{Value: rdfTypeIRI}, O: IRI{Value: rdfStatementIRI}, G: graph},
+		Statement{S: node, P: IRI{Value: rdfSubjectIRI}, O: subject, G: graph},
+		Statement{S: node, P: IRI{Value: rdfPredicateIRI}, O: tt.P, G: graph},
+		Statement{S: node, P: IRI{Value: rdfObjectIRI}, O: object, G: graph},
+	)
+}
+
+// expandReader returns the statements produced by a tripleTermReifier.
+type expandReader struct {
+	src     Reader
+	reifier *tripleTermReifier
+	queue   []Statement
+}
+
+func (e *expandReader) Next() (Statement, error) {
+	for len(e.queue) == 0 {
+		stmt, err := e.src.Next()
+		if err != nil {
+			return Statement{}, err
+		}
+		e.queue = e.reifier.reify(stmt)
+	}
+	stmt := e.queue[0]
+	e.queue = e.queue[1:]
+	return stmt, nil
+}
+
+func (e *expandReader) Close() error {
+	return e.src.Close()
+}
+
+// reificationKey identifies a reification node within a graph.
+type reificationKey struct {
+	node  Term
+	graph Term
+}
+
+// reification collects the statements of one reification node.
+type reification struct {
+	subject, object Term
+	predicate       *IRI
+	held            []Statement // Statements to return if it stays incomplete
+}
+
+// contractReader applies the rules of ContractReification.
+type contractReader struct {
+	src     Reader
+	partial map[reificationKey]*reification
+	order   []reificationKey // Keys of partial in the order they were seen
+	done    map[reificationKey]bool
+	queue   []Statement
+	err     error
+}
+
+func (c *contractReader) Next() (Statement, error) {
+	for len(c.queue) == 0 {
+		if c.err != nil {
+			return Statement{}, c.err
+		}
+		stmt, err := c.src.Next()
+		if err == io.EOF {
+			// Return incomplete reifications unchanged.
+			for _, key := range c.order {
+				if part, ok := c.partial[key]; ok {
+					c.queue = append(c.queue, part.held...)
+				}
+			}
+			c.partial, c.order = nil, nil
+		}
+		if err != nil {
+			c.err = err
+			continue
+		}
+		c.add(stmt)
+	}
+	stmt := c.queue[0]
+	c.queue = c.queue[1:]
+	return stmt, nil
+}
+
+// add holds stmt if it belongs to a reification and queues it otherwise.
+func (c *contractReader) add(stmt Statement) {
+	switch stmt.S.(type) {
+	case IRI, BlankNode:
+	default:
+		c.queue = append(c.queue, stmt)
+		return
+	}
+	key := reificationKey{node: stmt.S, graph: stmt.G}
+	part := c.partial[key]
+	if part == nil {
+		part = &reification{}
+	}
+	held := false
+	switch stmt.P.Value {
+	case rdfTypeIRI:
+		if stmt.O == (IRI{Value: rdfStatementIRI}) {
+			// A type read after the reification completed is dropped too.
+			if c.done[key] {
+				return
+			}
+			held = true
+		}
+	case rdfSubjectIRI:
+		if _, isLiteral := stmt.O.(Literal); !isLiteral && part.subject == nil {
+			part.subject, held = stmt.O, true
+		}
+	case rdfPredicateIRI:
+		if iri, ok := stmt.O.(IRI); ok && part.predicate == nil {
+			part.predicate, held = &iri, true
+		}
+	case rdfObjectIRI:
+		if part.object == nil {
+			part.object, held = stmt.O, true
+		}
+	}
+	if !held || c.done[key] {
+		c.queue = append(c.queue, stmt)
+		return
+	}
+	part.held = append(part.held, stmt)
+	if c.partial[key] == nil {
+		c.partial[key] = part
+		c.order = append(c.order, key)
+	}
+	if part.subject == nil || part.predicate == nil || part.object == nil {
+		return
+	}
+	delete(c.partial, key)
+	c.done[key] = true
+	c.queue = append(c.queue, Statement{
+		S: stmt.S,
+		P: IRI{Value: rdfReifiesIRI},
+		O: TripleTerm{S: part.subject, P: *part.predicate, O: part.object},
+		G: stmt.G,
+	})
+}
+
+func (c *contractReader) Close() error {
+	return c.src.Close()
+}
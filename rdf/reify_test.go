@@ -0,0 +1,225 @@
+package rdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func tripleTermStatements() []Statement {
+	s := IRI{Value: "http://example.org/s"}
+	p := IRI{Value: "http://example.org/p"}
+	inner := TripleTerm{S: BlankNode{ID: "b1"}, P: p, O: Literal{Lexical: "v", Lang: "en"}}
+	outer := TripleTerm{S: s, P: p, O: inner}
+	g := IRI{Value: "http://example.org/g"}
+	return []Statement{
+		{S: s, P: IRI{Value: "http://example.org/says"}, O: inner},
+		{S: outer, P: IRI{Value: "http://example.org/source"}, O: IRI{Value: "http://example.org/doc"}},
+		{S: inner, P: IRI{Value: "http://example.org/certainty"}, O: Literal{Lexical: "0.9", Datatype: IRI{Value: "http://www.w3.org/2001/XMLSchema#decimal"}}, G: g},
+	}
+}
+
+func writeStatements(t *testing.T, format Format, stmts []Statement, opts ...Option) string {
+	t.Helper()
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, format, opts...)
+	if err != nil {
+		t.Fatalf("format %s: %v", format, err)
+	}
+	for _, stmt := range stmts {
+		if err := writer.Write(stmt); err != nil {
+			t.Fatalf("format %s: write error: %v", format, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("format %s: close error: %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestWriteTripleTerms(t *testing.T) {
+	for _, format := range []Format{FormatTurtle, FormatTriG, FormatNTriples, FormatNQuads} {
+		want := tripleTermStatements()
+		if format == FormatNTriples || format == FormatNQuads {
+			// RDF 1.2 N-Triples has no triple term subjects.
+			want = append(want[:1], want[1].AsTriple().ToStatement())
+			want[1].S = IRI{Value: "http://example.org/t"}
+		}
+		if !format.IsQuadFormat() {
+			for i := range want {
+				want[i].G = nil
+			}
+		}
+		output := writeStatements(t, format, want)
+		if !strings.Contains(output, "<<( ") {
+			t.Errorf("format %s: expected <<( )>> triple terms in\n%s", format, output)
+		}
+		reader, err := NewReader(strings.NewReader(output), format)
+		if err != nil {
+			t.Fatalf("format %s: %v", format, err)
+		}
+		got, err := collectStatements(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("format %s: cannot read back\n%s\n%v", format, output, err)
+		}
+		if !isomorphicQuads(statementsToQuads(want), statementsToQuads(got)) {
+			t.Errorf("format %s: roundtrip changed the statements\n%s", format, output)
+		}
+	}
+}
+
+func TestWriteNTriplesTripleTermSubject(t *testing.T) {
+	for _, format := range []Format{FormatNTriples, FormatNQuads} {
+		writer, err := NewWriter(&bytes.Buffer{}, format)
+		if err != nil {
+			t.Fatalf("format %s: %v", format, err)
+		}
+		if err := writer.Write(tripleTermStatements()[1]); err == nil {
+			t.Errorf("format %s: expected error for triple term subject", format)
+		}
+	}
+}
+
+func TestWriteTurtleTripleTermSubject(t *testing.T) {
+	output := writeStatements(t, FormatTurtle, tripleTermStatements()[1:2])
+	want := `<< <http://example.org/s> <http://example.org/p> <<( _:b1 <http://example.org/p> "v"@en )>> >> <http://example.org/source> <http://example.org/doc> .`
+	if strings.TrimSpace(output) != want {
+		t.Fatalf("got %s, want %s", output, want)
+	}
+}
+
+func TestOptReifyTripleTerms(t *testing.T) {
+	for _, format := range []Format{FormatTurtle, FormatTriG, FormatNTriples, FormatNQuads, FormatRDFXML, FormatJSONLD} {
+		stmts := tripleTermStatements()
+		if !format.IsQuadFormat() {
+			for i := range stmts {
+				stmts[i].G = nil
+			}
+		}
+		output := writeStatements(t, format, stmts, OptReifyTripleTerms())
+		if strings.Contains(output, "<<") {
+			t.Errorf("format %s: triple term written despite reification:\n%s", format, output)
+		}
+		reader, err := NewReader(strings.NewReader(output), format)
+		if err != nil {
+			t.Fatalf("format %s: %v", format, err)
+		}
+		got, err := collectStatements(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("format %s: cannot read back\n%s\n%v", format, output, err)
+		}
+		// The inner term is described once per graph and the outer once:
+		// 3 statements plus 4 reification triples for each description.
+		want := 3 + 4*2
+		if format.IsQuadFormat() {
+			want += 4
+		}
+		if len(got) != want {
+			t.Fatalf("format %s: expected %d statements, got %d:\n%s", format, want, len(got), output)
+		}
+		statements := 0
+		for _, stmt := range got {
+			if _, ok := stmt.S.(TripleTerm); ok {
+				t.Fatalf("format %s: triple term subject read back: %v", format, stmt)
+			}
+			if _, ok := stmt.O.(TripleTerm); ok {
+				t.Fatalf("format %s: triple term object read back: %v", format, stmt)
+			}
+			if stmt.P.Value == rdfTypeIRI && stmt.O == (IRI{Value: rdfStatementIRI}) {
+				statements++
+			}
+		}
+		if statements != (want-3)/4 {
+			t.Fatalf("format %s: expected %d rdf:Statement nodes, got %d", format, (want-3)/4, statements)
+		}
+	}
+}
+
+func TestExpandTripleTerms(t *testing.T) {
+	stmts := tripleTermStatements()
+	reifier := BlankNode{ID: "r"}
+	stmts = append(stmts,
+		Statement{S: reifier, P: IRI{Value: rdfReifiesIRI}, O: stmts[1].S},
+		Statement{S: reifier, P: IRI{Value: "http://example.org/source"}, O: IRI{Value: "http://example.org/doc"}},
+	)
+	out := pipeStatements(t, stmts, ExpandTripleTerms())
+	// Four statements remain besides the descriptions: the inner term once
+	// per graph, the outer term as a blank node and the reifier r.
+	if want := 4 + 4*4; len(out) != want {
+		t.Fatalf("expected %d statements, got %d: %v", want, len(out), out)
+	}
+	for _, stmt := range out {
+		if _, ok := stmt.S.(TripleTerm); ok {
+			t.Fatalf("triple term subject left: %v", stmt)
+		}
+		if _, ok := stmt.O.(TripleTerm); ok {
+			t.Fatalf("triple term object left: %v", stmt)
+		}
+		if stmt.P.Value == rdfReifiesIRI {
+			t.Fatalf("rdf:reifies statement left: %v", stmt)
+		}
+	}
+	var types int
+	for _, stmt := range out {
+		if stmt.S == reifier && stmt.P.Value == rdfTypeIRI && stmt.O == (IRI{Value: rdfStatementIRI}) {
+			types++
+		}
+	}
+	if types != 1 {
+		t.Fatalf("expected the reifier to be described once, got %d", types)
+	}
+}
+
+func TestContractReification(t *testing.T) {
+	s := IRI{Value: "http://example.org/s"}
+	p := IRI{Value: "http://example.org/p"}
+	r := IRI{Value: "http://example.org/r"}
+	g := IRI{Value: "http://example.org/g"}
+	source := Statement{S: r, P: IRI{Value: "http://example.org/source"}, O: IRI{Value: "http://example.org/doc"}}
+	stmts := []Statement{
+		{S: r, P: IRI{Value: rdfSubjectIRI}, O: s},
+		source,
+		{S: r, P: IRI{Value: rdfPredicateIRI}, O: p},
+		{S: r, P: IRI{Value: rdfObjectIRI}, O: Literal{Lexical: "v"}},
+		{S: r, P: IRI{Value: rdfTypeIRI}, O: IRI{Value: rdfStatementIRI}},
+		// Incomplete in another graph, returned unchanged at the end.
+		{S: r, P: IRI{Value: rdfSubjectIRI}, O: s, G: g},
+	}
+	out := pipeStatements(t, stmts, ContractReification())
+	want := []Statement{
+		source,
+		{S: r, P: IRI{Value: rdfReifiesIRI}, O: TripleTerm{S: s, P: p, O: Literal{Lexical: "v"}}},
+		stmts[5],
+	}
+	if len(out) != len(want) {
+		t.Fatalf("expected %v, got %v", want, out)
+	}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Fatalf("statement %d: expected %v, got %v", i, want[i], out[i])
+		}
+	}
+}
+
+func TestExpandAndContractRoundtrip(t *testing.T) {
+	stmts := []Statement{
+		{S: BlankNode{ID: "r"}, P: IRI{Value: rdfReifiesIRI}, O: tripleTermStatements()[1].S},
+		{S: BlankNode{ID: "r"}, P: IRI{Value: "http://example.org/source"}, O: IRI{Value: "http://example.org/doc"}},
+	}
+	out := pipeStatements(t, stmts, ExpandTripleTerms(), ContractReification())
+	// The nested triple term comes back as a blank node reifying it.
+	if len(out) != 3 {
+		t.Fatalf("expected 3 statements, got %d: %v", len(out), out)
+	}
+	for _, stmt := range out {
+		if stmt.P.Value == rdfSubjectIRI || stmt.P.Value == rdfTypeIRI {
+			t.Fatalf("reification left: %v", stmt)
+		}
+	}
+	outer, ok := out[1].O.(TripleTerm)
+	if out[1].S != (BlankNode{ID: "r"}) || !ok || outer.O != out[0].S {
+		t.Fatalf("unexpected contraction %v", out)
+	}
+}
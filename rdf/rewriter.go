@@ -0,0 +1,279 @@
+package rdf
+
+import (
+	"bytes"
+	"io"
+	"maps"
+	"strings"
+)
+
+// FormatPreservingRewriter edits the statements of a Turtle or TriG document
+// and writes it back with as few changes as possible. Comments, blank lines,
+// directives and the layout of the statements that are not edited are kept
+// byte for byte, so that scripted edits of hand-maintained files such as
+// ontologies give small diffs.
+type FormatPreservingRewriter struct {
+	src      []byte
+	format   Format
+	chunks   []*rewriteChunk
+	prefixes map[string]string // Prefixes in effect at the end of the document
+	added    []Statement
+	newline  string // Line break of the source document
+}
+
+// rewriteChunk is a Turtle statement of the source document: its byte range,
+// the prefixes in effect there and the RDF statements it holds.
+type rewriteChunk struct {
+	start, end int
+	prefixes   map[string]string
+	stmts      []Statement
+	edited     bool
+}
+
+// NewFormatPreservingRewriter reads a Turtle or TriG document to be edited.
+// The options are those of NewEventReader; with OptDecompress the document
+// is written back uncompressed. Other formats fail with ErrUnsupportedFormat.
+func NewFormatPreservingRewriter(r io.Reader, format Format, opts ...Option) (*FormatPreservingRewriter, error) {
+	if format != FormatTurtle && format != FormatTriG {
+		return nil, ErrUnsupportedFormat
+	}
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.Decompress {
+		// The byte ranges of statements refer to the decompressed text.
+		decompressed, err := decompressReader(r)
+		if err != nil {
+			return nil, err
+		}
+		r = decompressed
+		opts = append(opts, func(opts *Options) { opts.Decompress = false })
+	}
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	events, err := NewEventReader(bytes.NewReader(src), format, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer events.Close()
+	rw := &FormatPreservingRewriter{src: src, format: format, prefixes: map[string]string{}, newline: "\n"}
+	if bytes.Contains(src, []byte("\r\n")) {
+		rw.newline = "\r\n"
+	}
+	shared := false // Whether the last chunk holds rw.prefixes
+	for {
+		e, err := events.Next()
+		if err == io.EOF {
+			return rw, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch e := e.(type) {
+		case PrefixEvent:
+			if shared {
+				rw.prefixes, shared = maps.Clone(rw.prefixes), false
+			}
+			rw.prefixes[e.Prefix] = e.IRI
+		case StatementEvent:
+			last := len(rw.chunks) - 1
+			if last >= 0 && rw.chunks[last].start == e.Offset {
+				rw.chunks[last].stmts = append(rw.chunks[last].stmts, e.Statement)
+				continue
+			}
+			rw.chunks = append(rw.chunks, &rewriteChunk{start: e.Offset, end: e.End, prefixes: rw.prefixes, stmts: []Statement{e.Statement}})
+			shared = true
+		}
+	}
+}
+
+// Statements returns the statements of the document in document order,
+// followed by those added with Add.
+func (rw *FormatPreservingRewriter) Statements() []Statement {
+	var stmts []Statement
+	for _, chunk := range rw.chunks {
+		stmts = append(stmts, chunk.stmts...)
+	}
+	return append(stmts, rw.added...)
+}
+
+// Add adds a statement. Added statements are written at the end of the
+// document, grouped by subject and, in TriG, in graph blocks.
+func (rw *FormatPreservingRewriter) Add(stmt Statement) {
+	rw.added = append(rw.added, stmt)
+}
+
+// Remove removes every statement equal to stmt by TermEqual and reports
+// whether there was one. A Turtle statement left without RDF statements is
+// deleted, with its lines if it had them to itself.
+func (rw *FormatPreservingRewriter) Remove(stmt Statement) bool {
+	return rw.edit(stmt, nil)
+}
+
+// Replace replaces every statement equal to old by TermEqual with new and
+// reports whether there was one. The replacement stays in place: the Turtle
+// statement holding old is written again with new in its position.
+func (rw *FormatPreservingRewriter) Replace(old, new Statement) bool {
+	return rw.edit(old, &new)
+}
+
+// edit removes the statements equal to old, or replaces them with new if it
+// is not nil.
+func (rw *FormatPreservingRewriter) edit(old Statement, new *Statement) bool {
+	found := false
+	apply := func(stmts []Statement) ([]Statement, bool) {
+		kept := stmts[:0]
+		changed := false
+		for _, stmt := range stmts {
+			if !statementEqual(stmt, old) {
+				kept = append(kept, stmt)
+				continue
+			}
+			changed = true
+			if new != nil {
+				kept = append(kept, *new)
+			}
+		}
+		return kept, changed
+	}
+	for _, chunk := range rw.chunks {
+		var changed bool
+		chunk.stmts, changed = apply(chunk.stmts)
+		if changed {
+			chunk.edited = true
+			found = true
+		}
+	}
+	var changed bool
+	rw.added, changed = apply(rw.added)
+	return found || changed
+}
+
+// WriteTo writes the edited document to w: the source document with the
+// edited Turtle statements written again and the added statements at the
+// end. Written statements use the prefixes in effect at their position, the
+// indentation of the statement they replace and the line breaks of the
+// document; blank nodes in them are written as labels, including those
+// written as "[ ... ]" or "( ... )" in the source.
+func (rw *FormatPreservingRewriter) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	pos := 0
+	for _, chunk := range rw.chunks {
+		if !chunk.edited {
+			continue
+		}
+		start, end := chunk.start, chunk.end
+		if len(chunk.stmts) == 0 {
+			start, end = rw.ownLines(start, end)
+		}
+		out.Write(rw.src[pos:start])
+		rw.writeLines(&out, renderPreservedStatements(chunk.stmts, chunk.prefixes, rw.indentAt(chunk.start)))
+		pos = end
+	}
+	out.Write(rw.src[pos:])
+	if len(rw.added) > 0 {
+		if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+			out.WriteString(rw.newline)
+		}
+		rw.writeAdded(&out)
+	}
+	return out.WriteTo(w)
+}
+
+// writeAdded writes the added statements, those of named graphs in TriG
+// graph blocks.
+func (rw *FormatPreservingRewriter) writeAdded(out *bytes.Buffer) {
+	var graphs []Term
+	byGraph := map[string][]Statement{}
+	for _, stmt := range rw.added {
+		key := ""
+		if rw.format == FormatTriG && stmt.G != nil {
+			key = stmt.G.String()
+		}
+		if _, ok := byGraph[key]; !ok {
+			if key != "" {
+				graphs = append(graphs, stmt.G)
+			} else {
+				graphs = append([]Term{nil}, graphs...)
+			}
+		}
+		byGraph[key] = append(byGraph[key], stmt)
+	}
+	for _, graph := range graphs {
+		if graph == nil {
+			rw.writeLines(out, renderPreservedStatements(byGraph[""], rw.prefixes, "")+"\n")
+			continue
+		}
+		rw.writeLines(out, renderTermWithPrefixes(graph, rw.prefixes)+" {\n  "+
+			renderPreservedStatements(byGraph[graph.String()], rw.prefixes, "  ")+"\n}\n")
+	}
+}
+
+// writeLines writes text with the line breaks of the source document.
+func (rw *FormatPreservingRewriter) writeLines(out *bytes.Buffer, text string) {
+	if rw.newline != "\n" {
+		text = strings.ReplaceAll(text, "\n", rw.newline)
+	}
+	out.WriteString(text)
+}
+
+// ownLines widens the byte range of a statement to its whole lines,
+// including the final line break, if nothing but spaces shares them.
+func (rw *FormatPreservingRewriter) ownLines(start, end int) (int, int) {
+	lineStart := bytes.LastIndexByte(rw.src[:start], '\n') + 1
+	if len(bytes.Trim(rw.src[lineStart:start], " \t")) > 0 {
+		return start, end
+	}
+	lineEnd := len(rw.src)
+	if i := bytes.IndexByte(rw.src[end:], '\n'); i >= 0 {
+		lineEnd = end + i + 1
+	}
+	if len(bytes.TrimRight(rw.src[end:lineEnd], " \t\r\n")) > 0 {
+		return start, end
+	}
+	return lineStart, lineEnd
+}
+
+// indentAt returns the spaces and tabs before offset if they start its line.
+func (rw *FormatPreservingRewriter) indentAt(offset int) string {
+	lineStart := bytes.LastIndexByte(rw.src[:offset], '\n') + 1
+	indent := rw.src[lineStart:offset]
+	if len(bytes.Trim(indent, " \t")) > 0 {
+		return ""
+	}
+	return string(indent)
+}
+
+// renderPreservedStatements renders stmts as Turtle statements without a
+// final line break, one per run of statements with the same subject, with
+// their graph names left out. Lines after the first start with indent.
+func renderPreservedStatements(stmts []Statement, prefixes map[string]string, indent string) string {
+	var b strings.Builder
+	for i, stmt := range stmts {
+		switch {
+		case i > 0 && TermEqual(stmt.S, stmts[i-1].S) && stmt.P.Value == stmts[i-1].P.Value:
+			b.WriteString(", ")
+		case i > 0 && TermEqual(stmt.S, stmts[i-1].S):
+			b.WriteString(" ;\n" + indent + "    " + renderIRIWithPrefixes(stmt.P, prefixes) + " ")
+		default:
+			if i > 0 {
+				b.WriteString(" .\n" + indent)
+			}
+			b.WriteString(renderSubjectWithPrefixes(stmt.S, prefixes) + " " + renderIRIWithPrefixes(stmt.P, prefixes) + " ")
+		}
+		b.WriteString(renderTermWithPrefixes(stmt.O, prefixes))
+	}
+	if len(stmts) > 0 {
+		b.WriteString(" .")
+	}
+	return b.String()
+}
+
+// statementEqual reports whether two statements have equal terms by
+// TermEqual.
+func statementEqual(a, b Statement) bool {
+	return a.P.Value == b.P.Value && TermEqual(a.S, b.S) && TermEqual(a.O, b.O) && TermEqual(a.G, b.G)
+}
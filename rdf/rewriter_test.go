@@ -0,0 +1,110 @@
+package rdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const rewriterInput = `# Ontology header
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+# People
+ex:alice rdfs:label "Alice" ;
+    ex:knows ex:bob , ex:carol .   # friends
+
+ex:bob rdfs:label "Bob" .
+  ex:carol rdfs:label "Carol" .
+`
+
+func rewrite(t *testing.T, rw *FormatPreservingRewriter) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := rw.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	return buf.String()
+}
+
+func TestFormatPreservingRewriterUnchanged(t *testing.T) {
+	rw, err := NewFormatPreservingRewriter(strings.NewReader(rewriterInput), FormatTurtle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rewrite(t, rw); got != rewriterInput {
+		t.Fatalf("unedited document changed:\n%s", got)
+	}
+	if n := len(rw.Statements()); n != 5 {
+		t.Fatalf("expected 5 statements, got %d", n)
+	}
+}
+
+func TestFormatPreservingRewriterEdits(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	label := IRI{Value: "http://www.w3.org/2000/01/rdf-schema#label"}
+	rw, err := NewFormatPreservingRewriter(strings.NewReader(rewriterInput), FormatTurtle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rw.Remove(Statement{S: ex("alice"), P: ex("knows"), O: ex("carol")}) {
+		t.Fatal("Remove did not find ex:alice ex:knows ex:carol")
+	}
+	if !rw.Remove(Statement{S: ex("bob"), P: label, O: Literal{Lexical: "Bob"}}) {
+		t.Fatal("Remove did not find the label of ex:bob")
+	}
+	if rw.Remove(Statement{S: ex("bob"), P: label, O: Literal{Lexical: "Bob"}}) {
+		t.Fatal("Remove found a statement already removed")
+	}
+	if !rw.Replace(Statement{S: ex("carol"), P: label, O: Literal{Lexical: "Carol"}}, Statement{S: ex("carol"), P: label, O: Literal{Lexical: "Carol", Lang: "en"}}) {
+		t.Fatal("Replace did not find the label of ex:carol")
+	}
+	rw.Add(Statement{S: ex("dave"), P: label, O: Literal{Lexical: "Dave"}})
+	rw.Add(Statement{S: ex("dave"), P: ex("knows"), O: ex("alice")})
+	want := `# Ontology header
+@prefix ex: <http://example.org/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+# People
+ex:alice rdfs:label "Alice" ;
+    ex:knows ex:bob .   # friends
+
+  ex:carol rdfs:label "Carol"@en .
+ex:dave rdfs:label "Dave" ;
+    ex:knows ex:alice .
+`
+	if got := rewrite(t, rw); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatPreservingRewriterTriG(t *testing.T) {
+	ex := func(local string) IRI { return IRI{Value: "http://example.org/" + local} }
+	input := "PREFIX ex: <http://example.org/>\r\n" +
+		"ex:g { ex:s ex:p ex:o , ex:x }\r\n" +
+		"ex:s ex:p ex:o ."
+	rw, err := NewFormatPreservingRewriter(strings.NewReader(input), FormatTriG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw.Remove(Statement{S: ex("s"), P: ex("p"), O: ex("x"), G: ex("g")})
+	rw.Add(Statement{S: ex("n"), P: ex("p"), O: ex("o"), G: ex("g")})
+	rw.Add(Statement{S: ex("n"), P: ex("p"), O: ex("o")})
+	want := "PREFIX ex: <http://example.org/>\r\n" +
+		"ex:g { ex:s ex:p ex:o . }\r\n" +
+		"ex:s ex:p ex:o .\r\n" +
+		"ex:n ex:p ex:o .\r\n" +
+		"ex:g {\r\n  ex:n ex:p ex:o .\r\n}\r\n"
+	if got := rewrite(t, rw); got != want {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+	if n := len(rw.Statements()); n != 4 {
+		t.Fatalf("expected 4 statements, got %d", n)
+	}
+}
+
+func TestNewFormatPreservingRewriterUnsupportedFormat(t *testing.T) {
+	if _, err := NewFormatPreservingRewriter(strings.NewReader(""), FormatNQuads); err != ErrUnsupportedFormat {
+		t.Fatalf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
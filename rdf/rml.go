@@ -0,0 +1,566 @@
+package rdf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Vocabulary namespaces recognised by ParseRMLMapping.
+const (
+	r2rmlNS = "http://www.w3.org/ns/r2rml#"
+	rmlNS   = "http://semweb.mmlab.be/ns/rml#"
+	qlNS    = "http://semweb.mmlab.be/ns/ql#"
+)
+
+// RMLSourceFormat identifies how the records of a logical source are read.
+type RMLSourceFormat string
+
+const (
+	// RMLSourceCSV reads a CSV file whose first row names the columns.
+	// References name columns, and empty cells are treated as missing values.
+	RMLSourceCSV RMLSourceFormat = "CSV"
+	// RMLSourceJSON reads a JSON document. The iterator selects the records
+	// and references are dotted paths into each record.
+	RMLSourceJSON RMLSourceFormat = "JSONPath"
+)
+
+// RMLTermType selects the kind of term a term map generates.
+type RMLTermType uint8
+
+const (
+	// RMLTermTypeDefault applies the R2RML defaults: literals for object
+	// maps with a reference, language or datatype, IRIs otherwise.
+	RMLTermTypeDefault RMLTermType = iota
+	// RMLTermTypeIRI generates IRIs.
+	RMLTermTypeIRI
+	// RMLTermTypeBlankNode generates blank nodes. A term map without a
+	// template or reference generates a fresh blank node per record.
+	RMLTermTypeBlankNode
+	// RMLTermTypeLiteral generates literals.
+	RMLTermTypeLiteral
+)
+
+// RMLMapping is a set of triples maps in the subset of RML/R2RML supported
+// by NewRMLReader: CSV and JSON logical sources, subject, predicate, object
+// and graph maps built from constants, templates and references, and
+// rr:class. Referencing object maps (joins) are not supported.
+type RMLMapping struct {
+	// BaseIRI resolves relative IRIs produced by templates and references.
+	BaseIRI string
+	// TriplesMaps are executed in order, each streaming its logical source.
+	TriplesMaps []RMLTriplesMap
+}
+
+// RMLTriplesMap generates statements for each record of a logical source.
+type RMLTriplesMap struct {
+	// Name identifies the triples map in errors, typically its IRI.
+	Name string
+	// Source is the logical source iterated by the map.
+	Source RMLLogicalSource
+	// Subject generates the subject of every statement for a record.
+	Subject RMLTermMap
+	// Classes adds an rdf:type statement per class for each subject.
+	Classes []IRI
+	// Graphs places every statement of the map in the generated graphs.
+	Graphs []RMLTermMap
+	// PredicateObjects generate the predicates and objects for each subject.
+	PredicateObjects []RMLPredicateObjectMap
+}
+
+// RMLLogicalSource describes where the records of a triples map come from.
+type RMLLogicalSource struct {
+	// Path locates the source in the fs.FS passed to NewRMLReader.
+	Path string
+	// Format selects the record reader. When empty it is inferred from the
+	// path extension, defaulting to CSV.
+	Format RMLSourceFormat
+	// Iterator is a JSONPath selecting the records of a JSON source: "$",
+	// optionally followed by ".key" steps and a final "[*]" to iterate an
+	// array. The default "$" maps the whole document as one record.
+	Iterator string
+}
+
+// RMLPredicateObjectMap generates a statement for every combination of its
+// predicates and objects.
+type RMLPredicateObjectMap struct {
+	Predicates []RMLTermMap
+	Objects    []RMLTermMap
+	// Graphs are added to the graphs of the triples map for these statements.
+	Graphs []RMLTermMap
+}
+
+// RMLTermMap generates terms from a record. Exactly one of Constant,
+// Template and Reference is set, except for blank node maps, which may set
+// none of them.
+type RMLTermMap struct {
+	// Constant is used as-is for every record.
+	Constant Term
+	// Template interpolates references written as {name}. Braces and
+	// backslashes are escaped with a backslash. Values are percent-encoded
+	// when the template generates IRIs.
+	Template string
+	// Reference names a CSV column or a dotted JSON path in the record.
+	Reference string
+	// TermType overrides the default term type.
+	TermType RMLTermType
+	// Datatype is the datatype of generated literals.
+	Datatype IRI
+	// Language is the language tag of generated literals.
+	Language string
+}
+
+// NewRMLReader executes mapping and returns the generated statements as a
+// Reader. Logical sources are opened from sources one triples map at a time
+// and read record by record, so memory use does not grow with the input. A
+// record whose references are missing simply produces no term for that map.
+// The result can be passed to Copy or Pipe like any other Reader.
+func NewRMLReader(mapping *RMLMapping, sources fs.FS) (Reader, error) {
+	if mapping == nil {
+		return nil, errors.New("rml: nil mapping")
+	}
+	r := &rmlReader{sources: sources, baseIRI: mapping.BaseIRI, bnodes: newBlankNodeGenerator()}
+	for _, tm := range mapping.TriplesMaps {
+		compiled, err := compileRMLTriplesMap(tm)
+		if err != nil {
+			return nil, err
+		}
+		r.maps = append(r.maps, compiled)
+	}
+	return r, nil
+}
+
+type rmlReader struct {
+	sources fs.FS
+	baseIRI string
+	maps    []*rmlCompiledTriplesMap
+	bnodes  *blankNodeGenerator
+
+	current int
+	file    fs.File
+	records rmlRecordSource
+	queue   []Statement
+	pos     int
+	err     error
+}
+
+func (r *rmlReader) Next() (Statement, error) {
+	for {
+		if r.pos < len(r.queue) {
+			stmt := r.queue[r.pos]
+			r.pos++
+			return stmt, nil
+		}
+		if r.err != nil {
+			return Statement{}, r.err
+		}
+		if r.records == nil {
+			if r.current >= len(r.maps) {
+				return Statement{}, io.EOF
+			}
+			if err := r.open(r.maps[r.current]); err != nil {
+				r.err = err
+				return Statement{}, err
+			}
+		}
+		tm := r.maps[r.current]
+		record, err := r.records.Next()
+		if err == io.EOF {
+			r.closeSource()
+			r.current++
+			continue
+		}
+		if err != nil {
+			r.err = fmt.Errorf("rml: triples map %s: %w", tm.name, err)
+			return Statement{}, r.err
+		}
+		r.queue = r.generate(tm, record, r.queue[:0])
+		r.pos = 0
+	}
+}
+
+func (r *rmlReader) Close() error {
+	r.closeSource()
+	r.current = len(r.maps)
+	return nil
+}
+
+func (r *rmlReader) open(tm *rmlCompiledTriplesMap) error {
+	if r.sources == nil {
+		return fmt.Errorf("rml: triples map %s: no sources to open %q", tm.name, tm.source.Path)
+	}
+	file, err := r.sources.Open(tm.source.Path)
+	if err != nil {
+		return fmt.Errorf("rml: triples map %s: %w", tm.name, err)
+	}
+	records, err := newRMLRecordSource(file, tm.source)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("rml: triples map %s: %w", tm.name, err)
+	}
+	r.file = file
+	r.records = records
+	return nil
+}
+
+func (r *rmlReader) closeSource() {
+	if r.file != nil {
+		_ = r.file.Close()
+	}
+	r.file = nil
+	r.records = nil
+}
+
+// generate appends the statements of tm for one record to out.
+func (r *rmlReader) generate(tm *rmlCompiledTriplesMap, record rmlRecord, out []Statement) []Statement {
+	subjects := r.terms(tm.subject, record, rmlPositionSubject)
+	if len(subjects) == 0 {
+		return out
+	}
+	graphs := r.graphTerms(tm.graphs, record, nil)
+	for _, s := range subjects {
+		for _, class := range tm.classes {
+			for _, g := range graphs {
+				out = append(out, Statement{S: s, P: IRI{Value: rdfTypeIRI}, O: class, G: g})
+			}
+		}
+		for _, pom := range tm.predicateObjects {
+			var predicates []IRI
+			for _, pm := range pom.predicates {
+				for _, p := range r.terms(pm, record, rmlPositionPredicate) {
+					if iri, ok := p.(IRI); ok {
+						predicates = append(predicates, iri)
+					}
+				}
+			}
+			var objects []Term
+			for _, om := range pom.objects {
+				objects = append(objects, r.terms(om, record, rmlPositionObject)...)
+			}
+			pomGraphs := graphs
+			if len(pom.graphs) > 0 {
+				pomGraphs = r.graphTerms(pom.graphs, record, tm.graphs)
+			}
+			for _, p := range predicates {
+				for _, o := range objects {
+					for _, g := range pomGraphs {
+						out = append(out, Statement{S: s, P: p, O: o, G: g})
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// graphTerms evaluates inherited and own graph maps, returning a single nil
+// graph (the default graph) when no graph map is given.
+func (r *rmlReader) graphTerms(own []*rmlCompiledTermMap, record rmlRecord, inherited []*rmlCompiledTermMap) []Term {
+	var graphs []Term
+	for _, gm := range append(append([]*rmlCompiledTermMap(nil), inherited...), own...) {
+		for _, g := range r.terms(gm, record, rmlPositionGraph) {
+			if iri, ok := g.(IRI); ok && iri.Value == r2rmlNS+"defaultGraph" {
+				g = nil
+			}
+			graphs = append(graphs, g)
+		}
+	}
+	if len(graphs) == 0 {
+		return []Term{nil}
+	}
+	return graphs
+}
+
+type rmlPosition uint8
+
+const (
+	rmlPositionSubject rmlPosition = iota
+	rmlPositionPredicate
+	rmlPositionObject
+	rmlPositionGraph
+)
+
+// terms evaluates a term map against a record.
+func (r *rmlReader) terms(tm *rmlCompiledTermMap, record rmlRecord, position rmlPosition) []Term {
+	if tm.constant != nil {
+		return []Term{tm.constant}
+	}
+	termType := tm.termType
+	if termType == RMLTermTypeDefault {
+		termType = RMLTermTypeIRI
+		if position == rmlPositionObject && (tm.reference != "" || tm.language != "" || tm.datatype.Value != "") {
+			termType = RMLTermTypeLiteral
+		}
+	}
+	var values []rmlValue
+	switch {
+	case tm.template != nil:
+		for _, text := range tm.template.expand(record, termType == RMLTermTypeIRI) {
+			values = append(values, rmlValue{text: text})
+		}
+	case tm.reference != "":
+		values = record.values(tm.reference)
+	case termType == RMLTermTypeBlankNode:
+		return []Term{r.bnodes.next()}
+	}
+	terms := make([]Term, 0, len(values))
+	for _, v := range values {
+		switch termType {
+		case RMLTermTypeIRI:
+			iri := v.text
+			if r.baseIRI != "" && !hasIRIScheme(iri) {
+				iri = ResolveIRI(r.baseIRI, iri)
+			}
+			terms = append(terms, IRI{Value: iri})
+		case RMLTermTypeBlankNode:
+			terms = append(terms, BlankNode{ID: v.text})
+		case RMLTermTypeLiteral:
+			lit := Literal{Lexical: v.text, Lang: tm.language, Datatype: tm.datatype}
+			if lit.Lang == "" && lit.Datatype.Value == "" && tm.reference != "" && v.datatype != "" {
+				lit.Datatype = IRI{Value: v.datatype}
+			}
+			terms = append(terms, lit)
+		}
+	}
+	return terms
+}
+
+// hasIRIScheme reports whether s starts with an RFC 3986 scheme.
+func hasIRIScheme(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ':':
+			return i > 0
+		case isASCIILetter(c):
+		case i > 0 && (isDigit(c) || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+type rmlCompiledTriplesMap struct {
+	name             string
+	source           RMLLogicalSource
+	subject          *rmlCompiledTermMap
+	classes          []Term
+	graphs           []*rmlCompiledTermMap
+	predicateObjects []rmlCompiledPredicateObjectMap
+}
+
+type rmlCompiledPredicateObjectMap struct {
+	predicates []*rmlCompiledTermMap
+	objects    []*rmlCompiledTermMap
+	graphs     []*rmlCompiledTermMap
+}
+
+type rmlCompiledTermMap struct {
+	constant  Term
+	template  *rmlTemplate
+	reference string
+	termType  RMLTermType
+	datatype  IRI
+	language  string
+}
+
+func compileRMLTriplesMap(tm RMLTriplesMap) (*rmlCompiledTriplesMap, error) {
+	name := tm.Name
+	if name == "" {
+		name = tm.Source.Path
+	}
+	source := tm.Source
+	if source.Path == "" {
+		return nil, fmt.Errorf("rml: triples map %s: logical source has no path", name)
+	}
+	if source.Format == "" {
+		source.Format = RMLSourceCSV
+		if strings.EqualFold(path.Ext(source.Path), ".json") {
+			source.Format = RMLSourceJSON
+		}
+	}
+	if source.Format != RMLSourceCSV && source.Format != RMLSourceJSON {
+		return nil, fmt.Errorf("rml: triples map %s: unsupported source format %q", name, source.Format)
+	}
+	compiled := &rmlCompiledTriplesMap{name: name, source: source}
+	var err error
+	if compiled.subject, err = compileRMLTermMap(tm.Subject); err != nil {
+		return nil, fmt.Errorf("rml: triples map %s: subject map: %w", name, err)
+	}
+	if compiled.subject.termType == RMLTermTypeLiteral {
+		return nil, fmt.Errorf("rml: triples map %s: subject map cannot generate literals", name)
+	}
+	for _, class := range tm.Classes {
+		compiled.classes = append(compiled.classes, class)
+	}
+	if compiled.graphs, err = compileRMLTermMaps(tm.Graphs); err != nil {
+		return nil, fmt.Errorf("rml: triples map %s: graph map: %w", name, err)
+	}
+	for _, pom := range tm.PredicateObjects {
+		var c rmlCompiledPredicateObjectMap
+		if c.predicates, err = compileRMLTermMaps(pom.Predicates); err != nil {
+			return nil, fmt.Errorf("rml: triples map %s: predicate map: %w", name, err)
+		}
+		if c.objects, err = compileRMLTermMaps(pom.Objects); err != nil {
+			return nil, fmt.Errorf("rml: triples map %s: object map: %w", name, err)
+		}
+		if c.graphs, err = compileRMLTermMaps(pom.Graphs); err != nil {
+			return nil, fmt.Errorf("rml: triples map %s: graph map: %w", name, err)
+		}
+		if len(c.predicates) == 0 || len(c.objects) == 0 {
+			return nil, fmt.Errorf("rml: triples map %s: predicate-object map needs a predicate and an object", name)
+		}
+		compiled.predicateObjects = append(compiled.predicateObjects, c)
+	}
+	return compiled, nil
+}
+
+func compileRMLTermMaps(maps []RMLTermMap) ([]*rmlCompiledTermMap, error) {
+	compiled := make([]*rmlCompiledTermMap, 0, len(maps))
+	for _, tm := range maps {
+		c, err := compileRMLTermMap(tm)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+func compileRMLTermMap(tm RMLTermMap) (*rmlCompiledTermMap, error) {
+	set := 0
+	for _, ok := range []bool{tm.Constant != nil, tm.Template != "", tm.Reference != ""} {
+		if ok {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, errors.New("term map sets more than one of constant, template and reference")
+	}
+	if set == 0 && tm.TermType != RMLTermTypeBlankNode {
+		return nil, errors.New("term map needs a constant, template or reference")
+	}
+	c := &rmlCompiledTermMap{
+		constant:  tm.Constant,
+		reference: tm.Reference,
+		termType:  tm.TermType,
+		datatype:  tm.Datatype,
+		language:  tm.Language,
+	}
+	if tm.Template != "" {
+		template, err := parseRMLTemplate(tm.Template)
+		if err != nil {
+			return nil, err
+		}
+		c.template = template
+	}
+	return c, nil
+}
+
+// rmlTemplate is a parsed string template: literal text interleaved with
+// references. parts[i] is literal text when refs[i] is false.
+type rmlTemplate struct {
+	parts []string
+	refs  []bool
+}
+
+func parseRMLTemplate(s string) (*rmlTemplate, error) {
+	t := &rmlTemplate{}
+	var text strings.Builder
+	inRef := false
+	flush := func(ref bool) {
+		t.parts = append(t.parts, text.String())
+		t.refs = append(t.refs, ref)
+		text.Reset()
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(s) {
+				return nil, fmt.Errorf("template %q ends with a backslash", s)
+			}
+			i++
+			text.WriteByte(s[i])
+		case c == '{' && !inRef:
+			flush(false)
+			inRef = true
+		case c == '}' && inRef:
+			if text.Len() == 0 {
+				return nil, fmt.Errorf("template %q has an empty reference", s)
+			}
+			flush(true)
+			inRef = false
+		case c == '{' || c == '}':
+			return nil, fmt.Errorf("template %q has unbalanced braces", s)
+		default:
+			text.WriteByte(c)
+		}
+	}
+	if inRef {
+		return nil, fmt.Errorf("template %q has unbalanced braces", s)
+	}
+	flush(false)
+	return t, nil
+}
+
+// expand returns the template instantiated with every combination of the
+// referenced values, or nothing when a reference has no value.
+func (t *rmlTemplate) expand(record rmlRecord, iriSafe bool) []string {
+	results := []string{""}
+	for i, part := range t.parts {
+		if !t.refs[i] {
+			for j := range results {
+				results[j] += part
+			}
+			continue
+		}
+		values := record.values(part)
+		if len(values) == 0 {
+			return nil
+		}
+		next := make([]string, 0, len(results)*len(values))
+		for _, prefix := range results {
+			for _, v := range values {
+				text := v.text
+				if iriSafe {
+					text = rmlIRISafe(text)
+				}
+				next = append(next, prefix+text)
+			}
+		}
+		results = next
+	}
+	return results
+}
+
+// rmlIRISafe percent-encodes every character of s that is not an IRI
+// unreserved character, as R2RML requires for values inserted into IRI
+// templates.
+func rmlIRISafe(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r < utf8.RuneSelf {
+			c := byte(r)
+			if isASCIILetter(c) || isDigit(c) || c == '-' || c == '.' || c == '_' || c == '~' {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		} else if r != utf8.RuneError && r >= 0xA0 && !(r >= 0xFFF0 && r <= 0xFFFF) {
+			b.WriteString(s[i : i+size])
+		} else {
+			for _, c := range []byte(s[i : i+size]) {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		}
+		i += size
+	}
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package rdf
+
+import (
+	"fmt"
+	"io"
+)
+
+// ParseRMLMapping reads an RML or R2RML mapping document, for example one
+// opened with NewReader(f, FormatTurtle), and returns its triples maps in
+// document order. Every subject with an rml:logicalSource is a triples map.
+// Logical sources name their file with rml:source and select the record
+// reader with rml:referenceFormulation ql:CSV or ql:JSONPath. Term maps use
+// rr:constant (or the rr:subject, rr:predicate, rr:object and rr:graph
+// shortcuts), rr:template, rml:reference or rr:column, rr:termType,
+// rr:datatype and rr:language. Referencing object maps are rejected.
+func ParseRMLMapping(r Reader) (*RMLMapping, error) {
+	doc := rmlDocument{props: make(map[Term]map[string][]Term)}
+	var order []Term
+	for {
+		stmt, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		props := doc.props[stmt.S]
+		if props == nil {
+			props = make(map[string][]Term)
+			doc.props[stmt.S] = props
+		}
+		props[stmt.P.Value] = append(props[stmt.P.Value], stmt.O)
+		if stmt.P.Value == rmlNS+"logicalSource" && len(props[stmt.P.Value]) == 1 {
+			order = append(order, stmt.S)
+		}
+	}
+
+	mapping := &RMLMapping{}
+	for _, node := range order {
+		tm, err := doc.triplesMap(node)
+		if err != nil {
+			return nil, err
+		}
+		mapping.TriplesMaps = append(mapping.TriplesMaps, tm)
+	}
+	return mapping, nil
+}
+
+// rmlDocument indexes a mapping document by subject and predicate IRI.
+type rmlDocument struct {
+	props map[Term]map[string][]Term
+}
+
+func (d rmlDocument) objects(node Term, predicate string) []Term {
+	return d.props[node][predicate]
+}
+
+func (d rmlDocument) object(node Term, predicate string) Term {
+	if objects := d.objects(node, predicate); len(objects) > 0 {
+		return objects[0]
+	}
+	return nil
+}
+
+func (d rmlDocument) text(node Term, predicate string) string {
+	switch v := d.object(node, predicate).(type) {
+	case Literal:
+		return v.Lexical
+	case IRI:
+		return v.Value
+	default:
+		return ""
+	}
+}
+
+func (d rmlDocument) triplesMap(node Term) (RMLTriplesMap, error) {
+	tm := RMLTriplesMap{Name: rmlNodeName(node)}
+	source := d.object(node, rmlNS+"logicalSource")
+	tm.Source = RMLLogicalSource{
+		Path:     d.text(source, rmlNS+"source"),
+		Iterator: d.text(source, rmlNS+"iterator"),
+	}
+	switch formulation := d.text(source, rmlNS+"referenceFormulation"); formulation {
+	case "":
+	case qlNS + "CSV":
+		tm.Source.Format = RMLSourceCSV
+	case qlNS + "JSONPath":
+		tm.Source.Format = RMLSourceJSON
+	default:
+		return tm, fmt.Errorf("rml: triples map %s: unsupported reference formulation <%s>", tm.Name, formulation)
+	}
+
+	if subject := d.object(node, r2rmlNS+"subject"); subject != nil {
+		tm.Subject = RMLTermMap{Constant: subject}
+	} else if subjectMap := d.object(node, r2rmlNS+"subjectMap"); subjectMap != nil {
+		var err error
+		if tm.Subject, err = d.termMap(subjectMap); err != nil {
+			return tm, fmt.Errorf("rml: triples map %s: %w", tm.Name, err)
+		}
+		for _, class := range d.objects(subjectMap, r2rmlNS+"class") {
+			if iri, ok := class.(IRI); ok {
+				tm.Classes = append(tm.Classes, iri)
+			}
+		}
+		if tm.Graphs, err = d.graphMaps(subjectMap); err != nil {
+			return tm, fmt.Errorf("rml: triples map %s: %w", tm.Name, err)
+		}
+	} else {
+		return tm, fmt.Errorf("rml: triples map %s: missing subject map", tm.Name)
+	}
+
+	for _, pomNode := range d.objects(node, r2rmlNS+"predicateObjectMap") {
+		var pom RMLPredicateObjectMap
+		for _, p := range d.objects(pomNode, r2rmlNS+"predicate") {
+			pom.Predicates = append(pom.Predicates, RMLTermMap{Constant: p})
+		}
+		for _, pm := range d.objects(pomNode, r2rmlNS+"predicateMap") {
+			termMap, err := d.termMap(pm)
+			if err != nil {
+				return tm, fmt.Errorf("rml: triples map %s: %w", tm.Name, err)
+			}
+			pom.Predicates = append(pom.Predicates, termMap)
+		}
+		for _, o := range d.objects(pomNode, r2rmlNS+"object") {
+			pom.Objects = append(pom.Objects, RMLTermMap{Constant: o})
+		}
+		for _, om := range d.objects(pomNode, r2rmlNS+"objectMap") {
+			if d.object(om, r2rmlNS+"parentTriplesMap") != nil {
+				return tm, fmt.Errorf("rml: triples map %s: referencing object maps are not supported", tm.Name)
+			}
+			termMap, err := d.termMap(om)
+			if err != nil {
+				return tm, fmt.Errorf("rml: triples map %s: %w", tm.Name, err)
+			}
+			pom.Objects = append(pom.Objects, termMap)
+		}
+		var err error
+		if pom.Graphs, err = d.graphMaps(pomNode); err != nil {
+			return tm, fmt.Errorf("rml: triples map %s: %w", tm.Name, err)
+		}
+		tm.PredicateObjects = append(tm.PredicateObjects, pom)
+	}
+	return tm, nil
+}
+
+func (d rmlDocument) graphMaps(node Term) ([]RMLTermMap, error) {
+	var maps []RMLTermMap
+	for _, g := range d.objects(node, r2rmlNS+"graph") {
+		maps = append(maps, RMLTermMap{Constant: g})
+	}
+	for _, gm := range d.objects(node, r2rmlNS+"graphMap") {
+		termMap, err := d.termMap(gm)
+		if err != nil {
+			return nil, err
+		}
+		maps = append(maps, termMap)
+	}
+	return maps, nil
+}
+
+func (d rmlDocument) termMap(node Term) (RMLTermMap, error) {
+	tm := RMLTermMap{
+		Constant: d.object(node, r2rmlNS+"constant"),
+		Template: d.text(node, r2rmlNS+"template"),
+		Language: d.text(node, r2rmlNS+"language"),
+	}
+	tm.Reference = d.text(node, rmlNS+"reference")
+	if tm.Reference == "" {
+		tm.Reference = d.text(node, r2rmlNS+"column")
+	}
+	if datatype, ok := d.object(node, r2rmlNS+"datatype").(IRI); ok {
+		tm.Datatype = datatype
+	}
+	switch termType := d.text(node, r2rmlNS+"termType"); termType {
+	case "":
+	case r2rmlNS + "IRI":
+		tm.TermType = RMLTermTypeIRI
+	case r2rmlNS + "BlankNode":
+		tm.TermType = RMLTermTypeBlankNode
+	case r2rmlNS + "Literal":
+		tm.TermType = RMLTermTypeLiteral
+	default:
+		return tm, fmt.Errorf("unsupported term type <%s>", termType)
+	}
+	return tm, nil
+}
+
+func rmlNodeName(node Term) string {
+	if iri, ok := node.(IRI); ok {
+		return "<" + iri.Value + ">"
+	}
+	return node.String()
+}
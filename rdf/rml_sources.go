@@ -0,0 +1,258 @@
+package rdf
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// rmlValue is a referenced value with the datatype of its natural RDF
+// literal, empty for plain strings.
+type rmlValue struct {
+	text     string
+	datatype string
+}
+
+// rmlRecord is one iteration of a logical source.
+type rmlRecord interface {
+	values(reference string) []rmlValue
+}
+
+// rmlRecordSource streams the records of a logical source.
+type rmlRecordSource interface {
+	Next() (rmlRecord, error)
+}
+
+func newRMLRecordSource(r io.Reader, source RMLLogicalSource) (rmlRecordSource, error) {
+	if source.Format == RMLSourceJSON {
+		return newRMLJSONSource(r, source.Iterator)
+	}
+	return newRMLCSVSource(r)
+}
+
+type rmlCSVSource struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newRMLCSVSource(r io.Reader) (*rmlCSVSource, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return &rmlCSVSource{reader: reader}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[name] = i
+	}
+	return &rmlCSVSource{reader: reader, columns: columns}, nil
+}
+
+func (s *rmlCSVSource) Next() (rmlRecord, error) {
+	if s.columns == nil {
+		return nil, io.EOF
+	}
+	row, err := s.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	return rmlCSVRecord{columns: s.columns, row: row}, nil
+}
+
+type rmlCSVRecord struct {
+	columns map[string]int
+	row     []string
+}
+
+func (r rmlCSVRecord) values(reference string) []rmlValue {
+	i, ok := r.columns[reference]
+	if !ok || i >= len(r.row) || r.row[i] == "" {
+		return nil
+	}
+	return []rmlValue{{text: r.row[i]}}
+}
+
+// rmlJSONSource streams the records selected by an iterator of the form
+// "$", "$.a.b" or "$.a.b[*]". Array elements are decoded one at a time and
+// members outside the selected path are skipped without building Go values.
+type rmlJSONSource struct {
+	dec   *json.Decoder
+	keys  []string
+	array bool
+	state int // 0: not started, 1: inside the iterated array, 2: done
+}
+
+func newRMLJSONSource(r io.Reader, iterator string) (*rmlJSONSource, error) {
+	if iterator == "" {
+		iterator = "$"
+	}
+	if !strings.HasPrefix(iterator, "$") {
+		return nil, fmt.Errorf("unsupported JSONPath iterator %q", iterator)
+	}
+	rest := iterator[1:]
+	s := &rmlJSONSource{dec: json.NewDecoder(r)}
+	s.dec.UseNumber()
+	if strings.HasSuffix(rest, "[*]") {
+		s.array = true
+		rest = strings.TrimSuffix(rest, "[*]")
+	}
+	if rest != "" {
+		if !strings.HasPrefix(rest, ".") {
+			return nil, fmt.Errorf("unsupported JSONPath iterator %q", iterator)
+		}
+		for _, key := range strings.Split(rest[1:], ".") {
+			if key == "" || strings.ContainsAny(key, "[]*") {
+				return nil, fmt.Errorf("unsupported JSONPath iterator %q", iterator)
+			}
+			s.keys = append(s.keys, key)
+		}
+	}
+	return s, nil
+}
+
+func (s *rmlJSONSource) Next() (rmlRecord, error) {
+	switch s.state {
+	case 0:
+		found, err := s.descend()
+		if err != nil || !found {
+			s.state = 2
+			if err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		if !s.array {
+			s.state = 2
+			return s.decodeRecord()
+		}
+		tok, err := s.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if tok != json.Delim('[') {
+			s.state = 2
+			return nil, io.EOF
+		}
+		s.state = 1
+		return s.Next()
+	case 1:
+		if !s.dec.More() {
+			s.state = 2
+			return nil, io.EOF
+		}
+		return s.decodeRecord()
+	default:
+		return nil, io.EOF
+	}
+}
+
+func (s *rmlJSONSource) decodeRecord() (rmlRecord, error) {
+	var value interface{}
+	if err := s.dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return rmlJSONRecord{value: value}, nil
+}
+
+// descend positions the decoder before the value at s.keys, reporting false
+// when the path does not exist.
+func (s *rmlJSONSource) descend() (bool, error) {
+	for _, key := range s.keys {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return false, err
+		}
+		if tok != json.Delim('{') {
+			return false, nil
+		}
+		found := false
+		for s.dec.More() {
+			name, err := s.dec.Token()
+			if err != nil {
+				return false, err
+			}
+			if name == key {
+				found = true
+				break
+			}
+			var skip json.RawMessage
+			if err := s.dec.Decode(&skip); err != nil {
+				return false, err
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type rmlJSONRecord struct {
+	value interface{}
+}
+
+func (r rmlJSONRecord) values(reference string) []rmlValue {
+	reference = strings.TrimPrefix(strings.TrimPrefix(reference, "$"), ".")
+	nodes := []interface{}{r.value}
+	if reference != "" {
+		for _, key := range strings.Split(reference, ".") {
+			var next []interface{}
+			for _, node := range nodes {
+				next = appendJSONField(next, node, key)
+			}
+			nodes = next
+		}
+	}
+	var values []rmlValue
+	for _, node := range flattenJSONArrays(nodes) {
+		switch v := node.(type) {
+		case string:
+			values = append(values, rmlValue{text: v})
+		case bool:
+			values = append(values, rmlValue{text: fmt.Sprint(v), datatype: "http://www.w3.org/2001/XMLSchema#boolean"})
+		case json.Number:
+			datatype := "http://www.w3.org/2001/XMLSchema#integer"
+			if strings.ContainsAny(string(v), ".eE") {
+				datatype = "http://www.w3.org/2001/XMLSchema#double"
+			}
+			values = append(values, rmlValue{text: string(v), datatype: datatype})
+		}
+	}
+	return values
+}
+
+// appendJSONField appends the key member of node, looking into every element
+// when node is an array.
+func appendJSONField(out []interface{}, node interface{}, key string) []interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if field, ok := v[key]; ok {
+			out = append(out, field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			out = appendJSONField(out, item, key)
+		}
+	}
+	return out
+}
+
+func flattenJSONArrays(nodes []interface{}) []interface{} {
+	var out []interface{}
+	for _, node := range nodes {
+		if arr, ok := node.([]interface{}); ok {
+			out = append(out, flattenJSONArrays(arr)...)
+			continue
+		}
+		out = append(out, node)
+	}
+	return out
+}
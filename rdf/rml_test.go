@@ -0,0 +1,170 @@
+package rdf
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const rmlTestPrefixes = `@prefix rr: <http://www.w3.org/ns/r2rml#> .
+@prefix rml: <http://semweb.mmlab.be/ns/rml#> .
+@prefix ql: <http://semweb.mmlab.be/ns/ql#> .
+@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+`
+
+func parseRMLTestMapping(t *testing.T, doc string) *RMLMapping {
+	t.Helper()
+	reader, err := NewReader(strings.NewReader(rmlTestPrefixes+doc), FormatTurtle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	mapping, err := ParseRMLMapping(reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return mapping
+}
+
+func runRML(t *testing.T, mapping *RMLMapping, sources fstest.MapFS) []string {
+	t.Helper()
+	reader, err := NewRMLReader(mapping, sources)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	var out []string
+	for {
+		stmt, err := reader.Next()
+		if err == io.EOF {
+			return out
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		line := stmt.S.String() + " " + stmt.P.String() + " " + stmt.O.String()
+		if stmt.G != nil {
+			line += " " + stmt.G.String()
+		}
+		out = append(out, line)
+	}
+}
+
+func TestRMLCSVMapping(t *testing.T) {
+	mapping := parseRMLTestMapping(t, `
+ex:PersonMap
+  rml:logicalSource [ rml:source "people.csv" ; rml:referenceFormulation ql:CSV ] ;
+  rr:subjectMap [ rr:template "http://example.org/person/{id}" ; rr:class ex:Person ] ;
+  rr:predicateObjectMap [
+    rr:predicate ex:name ;
+    rr:objectMap [ rml:reference "name" ; rr:language "en" ]
+  ] , [
+    rr:predicate ex:age ;
+    rr:objectMap [ rr:column "age" ; rr:datatype xsd:integer ]
+  ] , [
+    rr:predicate ex:city ;
+    rr:objectMap [ rr:template "http://example.org/city/{city}" ]
+  ] .
+`)
+	sources := fstest.MapFS{"people.csv": {Data: []byte("id,name,age,city\n1,Alice,30,New York\n2,Bob,,\n")}}
+	got := runRML(t, mapping, sources)
+	want := []string{
+		"http://example.org/person/1 http://www.w3.org/1999/02/22-rdf-syntax-ns#type http://example.org/Person",
+		`http://example.org/person/1 http://example.org/name "Alice"@en`,
+		`http://example.org/person/1 http://example.org/age "30"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+		"http://example.org/person/1 http://example.org/city http://example.org/city/New%20York",
+		"http://example.org/person/2 http://www.w3.org/1999/02/22-rdf-syntax-ns#type http://example.org/Person",
+		`http://example.org/person/2 http://example.org/name "Bob"@en`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected statements:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRMLJSONMapping(t *testing.T) {
+	mapping := &RMLMapping{
+		BaseIRI: "http://example.org/",
+		TriplesMaps: []RMLTriplesMap{{
+			Source:  RMLLogicalSource{Path: "data.json", Iterator: "$.data.items[*]"},
+			Subject: RMLTermMap{Template: "item/{id}"},
+			Graphs:  []RMLTermMap{{Constant: IRI{Value: "http://example.org/g"}}},
+			PredicateObjects: []RMLPredicateObjectMap{
+				{
+					Predicates: []RMLTermMap{{Constant: IRI{Value: "http://example.org/tag"}}},
+					Objects:    []RMLTermMap{{Reference: "tags"}},
+				},
+				{
+					Predicates: []RMLTermMap{{Constant: IRI{Value: "http://example.org/price"}}},
+					Objects:    []RMLTermMap{{Reference: "$.info.price"}},
+				},
+			},
+		}},
+	}
+	sources := fstest.MapFS{"data.json": {Data: []byte(`{"meta": {"skip": [1, 2]}, "data": {"items": [
+		{"id": 1, "tags": ["a", "b"], "info": {"price": 9.5}},
+		{"id": 2, "tags": [], "info": {}}
+	]}}`)}}
+	got := runRML(t, mapping, sources)
+	want := []string{
+		`http://example.org/item/1 http://example.org/tag "a" http://example.org/g`,
+		`http://example.org/item/1 http://example.org/tag "b" http://example.org/g`,
+		`http://example.org/item/1 http://example.org/price "9.5"^^<http://www.w3.org/2001/XMLSchema#double> http://example.org/g`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected statements:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRMLBlankNodesAndDefaultGraph(t *testing.T) {
+	mapping := parseRMLTestMapping(t, `
+ex:Map
+  rml:logicalSource [ rml:source "rows.csv" ] ;
+  rr:subjectMap [ rr:termType rr:BlankNode ; rr:graph rr:defaultGraph ] ;
+  rr:predicateObjectMap [ rr:predicate ex:v ; rr:objectMap [ rml:reference "v" ] ] .
+`)
+	got := runRML(t, mapping, fstest.MapFS{"rows.csv": {Data: []byte("v\nx\ny\n")}})
+	if len(got) != 2 || got[0] == got[1] || !strings.HasPrefix(got[0], "_:") || strings.Count(got[0], " ") != 2 {
+		t.Fatalf("expected two distinct blank node subjects in the default graph, got %q", got)
+	}
+}
+
+func TestRMLMappingErrors(t *testing.T) {
+	if _, err := NewRMLReader(&RMLMapping{TriplesMaps: []RMLTriplesMap{{
+		Source:  RMLLogicalSource{Path: "a.csv"},
+		Subject: RMLTermMap{Template: "http://example.org/{id"},
+	}}}, nil); err == nil {
+		t.Fatal("expected unbalanced template error")
+	}
+
+	reader, err := NewReader(strings.NewReader(rmlTestPrefixes+`
+ex:Map rml:logicalSource [ rml:source "a.csv" ] ;
+  rr:subject ex:s ;
+  rr:predicateObjectMap [ rr:predicate ex:p ; rr:objectMap [ rr:parentTriplesMap ex:Other ] ] .
+`), FormatTurtle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ParseRMLMapping(reader); err == nil || !strings.Contains(err.Error(), "referencing object maps") {
+		t.Fatalf("expected referencing object map error, got %v", err)
+	}
+
+	rml, err := NewRMLReader(&RMLMapping{TriplesMaps: []RMLTriplesMap{{
+		Name:    "missing",
+		Source:  RMLLogicalSource{Path: "missing.csv"},
+		Subject: RMLTermMap{Constant: IRI{Value: "http://example.org/s"}},
+	}}}, fstest.MapFS{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := rml.Next(); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected missing source error, got %v", err)
+	}
+}
+
+func TestRMLIRISafe(t *testing.T) {
+	if got := rmlIRISafe("a b/c?é"); got != "a%20b%2Fc%3Fé" {
+		t.Fatalf("unexpected IRI-safe value %q", got)
+	}
+}
@@ -89,6 +89,7 @@ func TestRoundTripTriplesIsomorphic(t *testing.T) {
 		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "v", Lang: "en"}},
 		{S: BlankNode{ID: "b1"}, P: IRI{Value: "http://example.org/p2"}, O: IRI{Value: "http://example.org/o"}},
 		{S: IRI{Value: "http://example.org/s2"}, P: IRI{Value: "http://example.org/p3"}, O: Literal{Lexical: "1", Datatype: IRI{Value: "http://www.w3.org/2001/XMLSchema#integer"}}},
+		{S: IRI{Value: "http://example.org/s2"}, P: IRI{Value: "http://example.org/p4"}, O: Literal{Lexical: "שלום", Lang: "he", Direction: "rtl"}},
 	}
 
 	formats := []Format{FormatNTriples, FormatTurtle, FormatRDFXML, FormatJSONLD}
@@ -132,6 +133,7 @@ func TestRoundTripQuadsIsomorphic(t *testing.T) {
 		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "v"}},
 		{S: BlankNode{ID: "b1"}, P: IRI{Value: "http://example.org/p2"}, O: IRI{Value: "http://example.org/o"}, G: IRI{Value: "http://example.org/g"}},
 		{S: IRI{Value: "http://example.org/s2"}, P: IRI{Value: "http://example.org/p3"}, O: BlankNode{ID: "b2"}},
+		{S: IRI{Value: "http://example.org/s2"}, P: IRI{Value: "http://example.org/p4"}, O: Literal{Lexical: "v", Lang: "en-US", Direction: "ltr"}, G: IRI{Value: "http://example.org/g"}},
 	}
 
 	formats := []Format{FormatNQuads, FormatTriG}
@@ -315,7 +317,7 @@ func isoTermKey(term Term, mapping map[string]string, requireMapped bool) (strin
 		}
 		return "B:" + mapped, true
 	case Literal:
-		return "L:" + value.Lexical + "|lang:" + value.Lang + "|dt:" + value.Datatype.Value, true
+		return "L:" + value.Lexical + "|lang:" + value.Lang + "|dir:" + value.Direction + "|dt:" + value.Datatype.Value, true
 	case TripleTerm:
 		subject, ok := isoTermKey(value.S, mapping, requireMapped)
 		if !ok {
@@ -356,3 +358,49 @@ func isoCollectBlankNodesFromTerm(term Term, seen map[string]bool) {
 		isoCollectBlankNodesFromTerm(value.O, seen)
 	}
 }
+
+func TestDirectionalLiteralParsing(t *testing.T) {
+	const rdfxml = `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:its="http://www.w3.org/2005/11/its" its:version="2.0">` +
+		`<rdf:Description rdf:about="http://example.org/s"><p xmlns="http://example.org/" xml:lang="en" its:dir="rtl">v</p></rdf:Description></rdf:RDF>`
+	inputs := map[Format]string{
+		FormatNTriples: `<http://example.org/s> <http://example.org/p> "v"@en--rtl .`,
+		FormatNQuads:   `<http://example.org/s> <http://example.org/p> "v"@en--rtl <http://example.org/g> .`,
+		FormatTurtle:   `<http://example.org/s> <http://example.org/p> "v"@en--rtl .`,
+		FormatTriG:     `<http://example.org/g> { <http://example.org/s> <http://example.org/p> "v"@en--rtl }`,
+		FormatJSONLD:   `{"@id": "http://example.org/s", "http://example.org/p": {"@value": "v", "@language": "en", "@direction": "rtl"}}`,
+		FormatRDFXML:   rdfxml,
+	}
+	for format, input := range inputs {
+		reader, err := NewReader(strings.NewReader(input), format)
+		if err != nil {
+			t.Fatalf("format %s: %v", format, err)
+		}
+		stmt, err := reader.Next()
+		reader.Close()
+		if err != nil {
+			t.Fatalf("format %s: unexpected error: %v", format, err)
+		}
+		want := Literal{Lexical: "v", Lang: "en", Direction: "rtl"}
+		if stmt.O != want {
+			t.Errorf("format %s: got %#v, want %#v", format, stmt.O, want)
+		}
+		if got := stmt.O.String(); got != `"v"@en--rtl` {
+			t.Errorf("format %s: String() = %s", format, got)
+		}
+	}
+
+	invalid := map[Format]string{
+		FormatJSONLD: `{"@id": "http://example.org/s", "http://example.org/p": {"@value": "v", "@language": "en", "@direction": "up"}}`,
+		FormatRDFXML: strings.Replace(rdfxml, `its:dir="rtl"`, `its:dir="up"`, 1),
+	}
+	for format, input := range invalid {
+		reader, err := NewReader(strings.NewReader(input), format)
+		if err != nil {
+			t.Fatalf("format %s: %v", format, err)
+		}
+		if _, err := reader.Next(); err == nil {
+			t.Errorf("format %s: expected error for invalid direction", format)
+		}
+		reader.Close()
+	}
+}
@@ -3,8 +3,11 @@ package rdf
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 )
 
@@ -301,3 +304,85 @@ func TestWithSafeLimits(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestRDFXMLEntityLimits(t *testing.T) {
+	const body = `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">
+  <rdf:Description rdf:about="&ex;s"><ex:p>%s</ex:p></rdf:Description>
+</rdf:RDF>`
+	entities := `<!DOCTYPE rdf:RDF [
+  <!ENTITY ex "http://example.org/">
+  <!ENTITY greeting "Hello &#x26;amp; &who;">
+  <!ENTITY who "world">
+  <!ENTITY remote SYSTEM "file:///etc/passwd">
+]>`
+	laughs := `<!DOCTYPE rdf:RDF [
+  <!ENTITY ex "http://example.org/">
+  <!ENTITY l0 "lol">
+  <!ENTITY l1 "&l0;&l0;&l0;&l0;&l0;&l0;&l0;&l0;&l0;&l0;">
+  <!ENTITY l2 "&l1;&l1;&l1;&l1;&l1;&l1;&l1;&l1;&l1;&l1;">
+  <!ENTITY l3 "&l2;&l2;&l2;&l2;&l2;&l2;&l2;&l2;&l2;&l2;">
+  <!ENTITY l4 "&l3;&l3;&l3;&l3;&l3;&l3;&l3;&l3;&l3;&l3;">
+]>`
+	read := func(input string, opts ...Option) ([]Statement, error) {
+		dec, err := NewReader(strings.NewReader(input), FormatRDFXML, opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return collectStatements(dec)
+	}
+
+	stmts, err := read(entities + fmt.Sprintf(body, "&greeting;"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stmts) != 1 || stmts[0].S.String() != "http://example.org/s" || stmts[0].O.String() != `"Hello &amp; world"` {
+		t.Fatalf("unexpected statements: %v", stmts)
+	}
+	if _, err := read(entities + fmt.Sprintf(body, "&remote;")); err == nil {
+		t.Error("expected error for external entity reference")
+	}
+	if _, err := read(entities+fmt.Sprintf(body, "&greeting;"), OptSafeLimits()); !errors.Is(err, ErrDTDNotAllowed) {
+		t.Errorf("expected ErrDTDNotAllowed with OptSafeLimits, got %v", err)
+	}
+	if _, err := read(entities+fmt.Sprintf(body, "&greeting;"), OptDisallowDTD()); !errors.Is(err, ErrDTDNotAllowed) {
+		t.Errorf("expected ErrDTDNotAllowed with OptDisallowDTD, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		refs string
+		opts []Option
+	}{
+		{"depth", "&l4;", []Option{OptMaxEntityDepth(3)}},
+		{"entity size", "&l4;", []Option{OptMaxEntityExpansion(10_000)}},
+		{"document size", strings.Repeat("&l3;", 20), []Option{OptMaxEntityExpansion(10_000)}},
+	}
+	for _, tt := range tests {
+		_, err := read(laughs+fmt.Sprintf(body, tt.refs), tt.opts...)
+		if !errors.Is(err, ErrEntityLimitExceeded) {
+			t.Errorf("%s: expected ErrEntityLimitExceeded, got %v", tt.name, err)
+		}
+		if Code(err) != ErrCodeEntityLimitExceeded {
+			t.Errorf("%s: expected code %s, got %s", tt.name, ErrCodeEntityLimitExceeded, Code(err))
+		}
+	}
+	stmts, err = read(laughs + fmt.Sprintf(body, "&l3;"))
+	if err != nil || len(stmts) != 1 || len(stmts[0].O.(Literal).Lexical) != 3000 {
+		t.Errorf("expected entity expansion within default limits, got %v", err)
+	}
+
+	// The DOCTYPE is found after a declaration and comments, and past the
+	// prolog looked at for it, whether or not the input can be peeked.
+	for _, prolog := range []string{`<?xml version="1.0"?>` + "\n<!-- c -->", "<!--" + strings.Repeat("c", 2*xmlPrologBytes) + "-->"} {
+		input := prolog + laughs + fmt.Sprintf(body, "&l4;")
+		for _, r := range []io.Reader{strings.NewReader(input), iotest.OneByteReader(strings.NewReader(input))} {
+			dec, err := NewReader(r, FormatRDFXML, OptMaxEntityExpansion(10_000))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := collectStatements(dec); !errors.Is(err, ErrEntityLimitExceeded) {
+				t.Errorf("%.20q: expected ErrEntityLimitExceeded, got %v", prolog, err)
+			}
+		}
+	}
+}
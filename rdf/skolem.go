@@ -0,0 +1,90 @@
+package rdf
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// skolemPathSegment is the well-known path for Skolem IRIs (RDF 1.1 Concepts §3.5).
+const skolemPathSegment = "/.well-known/genid/"
+
+// SkolemIDGenerator returns the genid identifier used for a blank node.
+// The same blank node is passed only once per Skolemize call; the result is
+// reused for every later occurrence. Identifiers must be valid IRI path segments.
+type SkolemIDGenerator func(BlankNode) string
+
+// LabelSkolemIDs keeps the blank node label as the genid identifier.
+// It is deterministic and makes Deskolemize restore the original labels.
+func LabelSkolemIDs() SkolemIDGenerator {
+	return func(b BlankNode) string { return b.ID }
+}
+
+// RandomSkolemIDs generates 128-bit random identifiers, which keeps Skolem
+// IRIs from different documents from colliding.
+func RandomSkolemIDs() SkolemIDGenerator {
+	return func(BlankNode) string {
+		return hex.EncodeToString(randomBytes(16))
+	}
+}
+
+// SkolemPrefix returns the Skolem IRI prefix for baseIRI,
+// e.g. "http://example.org/.well-known/genid/".
+func SkolemPrefix(baseIRI string) string {
+	return strings.TrimRight(baseIRI, "/") + skolemPathSegment
+}
+
+// Skolemize replaces blank nodes with well-known genid IRIs under baseIRI,
+// using blank node labels as identifiers. Blank nodes inside triple terms and
+// blank node graph names are rewritten as well. The input slice is not modified.
+func Skolemize(stmts []Statement, baseIRI string) []Statement {
+	return SkolemizeWith(stmts, baseIRI, LabelSkolemIDs())
+}
+
+// SkolemizeWith is like Skolemize but uses gen to choose genid identifiers.
+func SkolemizeWith(stmts []Statement, baseIRI string, gen SkolemIDGenerator) []Statement {
+	if gen == nil {
+		gen = LabelSkolemIDs()
+	}
+	prefix := SkolemPrefix(baseIRI)
+	assigned := make(map[string]IRI)
+	rewrite := func(t Term) Term {
+		b, ok := t.(BlankNode)
+		if !ok {
+			return t
+		}
+		iri, ok := assigned[b.ID]
+		if !ok {
+			iri = IRI{Value: prefix + gen(b)}
+			assigned[b.ID] = iri
+		}
+		return iri
+	}
+	out := make([]Statement, len(stmts))
+	for i, s := range stmts {
+		out[i] = mapStatementTerms(s, rewrite)
+	}
+	return out
+}
+
+// Deskolemize replaces genid IRIs under baseIRI with blank nodes whose label
+// is the genid identifier. Other IRIs are left unchanged.
+func Deskolemize(stmts []Statement, baseIRI string) []Statement {
+	prefix := SkolemPrefix(baseIRI)
+	rewrite := func(t Term) Term {
+		iri, ok := t.(IRI)
+		if !ok || !strings.HasPrefix(iri.Value, prefix) || len(iri.Value) == len(prefix) {
+			return t
+		}
+		return BlankNode{ID: iri.Value[len(prefix):]}
+	}
+	out := make([]Statement, len(stmts))
+	for i, s := range stmts {
+		out[i] = mapStatementTerms(s, rewrite)
+	}
+	return out
+}
+
+// IsSkolemIRI reports whether iri is a well-known genid IRI.
+func IsSkolemIRI(iri IRI) bool {
+	return strings.Contains(iri.Value, skolemPathSegment)
+}
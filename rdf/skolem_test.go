@@ -0,0 +1,70 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSkolemize_RoundTrip(t *testing.T) {
+	stmts := []Statement{
+		NewTriple(BlankNode{ID: "b1"}, IRI{Value: "http://example.org/p"}, BlankNode{ID: "b2"}),
+		NewQuad(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, BlankNode{ID: "b1"}, BlankNode{ID: "g"}),
+		NewTriple(TripleTerm{S: BlankNode{ID: "b2"}, P: IRI{Value: "http://example.org/q"}, O: Literal{Lexical: "x"}},
+			IRI{Value: "http://example.org/r"}, Literal{Lexical: "y"}),
+	}
+	skolem := Skolemize(stmts, "http://example.org/")
+	if got := skolem[0].S.(IRI).Value; got != "http://example.org/.well-known/genid/b1" {
+		t.Fatalf("unexpected skolem IRI %s", got)
+	}
+	if skolem[1].O != skolem[0].S {
+		t.Fatalf("same blank node must map to the same IRI")
+	}
+	if _, ok := skolem[1].G.(IRI); !ok {
+		t.Fatalf("blank node graph name should be skolemized, got %T", skolem[1].G)
+	}
+	if tt := skolem[2].S.(TripleTerm); tt.S != skolem[0].O {
+		t.Fatalf("blank nodes inside triple terms should be skolemized, got %v", tt.S)
+	}
+	if _, ok := stmts[0].S.(BlankNode); !ok {
+		t.Fatalf("input must not be modified")
+	}
+
+	back := Deskolemize(skolem, "http://example.org")
+	for i := range stmts {
+		if back[i] != stmts[i] {
+			t.Fatalf("statement %d: got %v, want %v", i, back[i], stmts[i])
+		}
+	}
+}
+
+func TestSkolemizeWith_Generator(t *testing.T) {
+	stmts := []Statement{
+		NewTriple(BlankNode{ID: "a"}, IRI{Value: "http://example.org/p"}, BlankNode{ID: "a"}),
+		NewTriple(BlankNode{ID: "b"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "v"}),
+	}
+	skolem := SkolemizeWith(stmts, "http://example.org", RandomSkolemIDs())
+	s0 := skolem[0].S.(IRI)
+	if s0 != skolem[0].O.(IRI) {
+		t.Fatalf("generator must be applied once per blank node")
+	}
+	if s0 == skolem[1].S.(IRI) {
+		t.Fatalf("distinct blank nodes must get distinct IRIs")
+	}
+	if !IsSkolemIRI(s0) || !strings.HasPrefix(s0.Value, SkolemPrefix("http://example.org")) {
+		t.Fatalf("unexpected skolem IRI %s", s0.Value)
+	}
+}
+
+func TestDeskolemize_IgnoresOtherIRIs(t *testing.T) {
+	stmts := []Statement{
+		NewTriple(IRI{Value: "http://other.org/.well-known/genid/x"}, IRI{Value: "http://example.org/p"},
+			IRI{Value: "http://example.org/.well-known/genid/"}),
+	}
+	out := Deskolemize(stmts, "http://example.org")
+	if _, ok := out[0].S.(IRI); !ok {
+		t.Fatalf("IRIs under another base must be kept")
+	}
+	if _, ok := out[0].O.(IRI); !ok {
+		t.Fatalf("bare genid prefix must be kept")
+	}
+}
@@ -0,0 +1,146 @@
+package rdf
+
+import "strings"
+
+const owlSameAsIRI = "http://www.w3.org/2002/07/owl#sameAs"
+
+// SmushPolicy chooses the canonical representative of a set of terms
+// linked by owl:sameAs. members holds the IRIs and blank nodes of the set
+// in the order they first occur in owl:sameAs statements.
+type SmushPolicy func(members []Term) Term
+
+// SmushLowestIRI chooses the IRI with the lexicographically lowest value,
+// or the first blank node if the set has no IRI. The choice does not
+// depend on statement order.
+func SmushLowestIRI() SmushPolicy {
+	return func(members []Term) Term {
+		var lowest Term
+		for _, m := range members {
+			iri, ok := m.(IRI)
+			if !ok {
+				continue
+			}
+			if current, ok := lowest.(IRI); !ok || iri.Value < current.Value {
+				lowest = iri
+			}
+		}
+		if lowest == nil {
+			return members[0]
+		}
+		return lowest
+	}
+}
+
+// SmushFirstSeen chooses the term that occurs first in an owl:sameAs
+// statement.
+func SmushFirstSeen() SmushPolicy {
+	return func(members []Term) Term { return members[0] }
+}
+
+// SmushPreferNamespaces chooses the lowest IRI in the first of namespaces
+// that any member starts with, for example the namespace of an
+// authoritative registry, and falls back to SmushLowestIRI.
+func SmushPreferNamespaces(namespaces ...string) SmushPolicy {
+	lowestIRI := SmushLowestIRI()
+	return func(members []Term) Term {
+		for _, ns := range namespaces {
+			var preferred []Term
+			for _, m := range members {
+				if iri, ok := m.(IRI); ok && strings.HasPrefix(iri.Value, ns) {
+					preferred = append(preferred, iri)
+				}
+			}
+			if len(preferred) > 0 {
+				return lowestIRI(preferred)
+			}
+		}
+		return lowestIRI(members)
+	}
+}
+
+// Smush merges resources declared equal with owl:sameAs: the IRIs and
+// blank nodes linked by owl:sameAs statements, in any graph and
+// transitively, form equivalence classes, and every occurrence of a member
+// in subject, predicate, object or graph position, including inside triple
+// terms, is replaced with the representative policy chooses for its class
+// (SmushLowestIRI if policy is nil). The owl:sameAs statements themselves
+// are kept unchanged so that aliases remain discoverable. Statements that
+// become identical are returned once, in the order of their first
+// occurrence. The input slice is not modified.
+func Smush(stmts []Statement, policy SmushPolicy) []Statement {
+	if policy == nil {
+		policy = SmushLowestIRI()
+	}
+	parent := make(map[Term]Term)
+	var order []Term
+	find := func(t Term) Term {
+		for parent[t] != t {
+			parent[t] = parent[parent[t]]
+			t = parent[t]
+		}
+		return t
+	}
+	add := func(t Term) {
+		if _, ok := parent[t]; !ok {
+			parent[t] = t
+			order = append(order, t)
+		}
+	}
+	for _, s := range stmts {
+		if s.P.Value != owlSameAsIRI || !isSmushable(s.S) || !isSmushable(s.O) {
+			continue
+		}
+		add(s.S)
+		add(s.O)
+		if a, b := find(s.S), find(s.O); a != b {
+			parent[b] = a
+		}
+	}
+
+	classes := make(map[Term][]Term)
+	for _, t := range order {
+		root := find(t)
+		classes[root] = append(classes[root], t)
+	}
+	canonical := make(map[Term]Term, len(order))
+	for _, members := range classes {
+		if len(members) < 2 {
+			continue
+		}
+		rep := policy(members)
+		for _, m := range members {
+			if m != rep {
+				canonical[m] = rep
+			}
+		}
+	}
+
+	rewrite := func(t Term) Term {
+		if rep, ok := canonical[t]; ok {
+			return rep
+		}
+		return t
+	}
+	out := make([]Statement, 0, len(stmts))
+	seen := make(map[Statement]struct{}, len(stmts))
+	for _, s := range stmts {
+		if s.P.Value != owlSameAsIRI {
+			s = mapStatementTerms(s, rewrite)
+		}
+		if _, dup := seen[s]; dup {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
+
+// isSmushable reports whether t can be merged by Smush.
+func isSmushable(t Term) bool {
+	switch t.(type) {
+	case IRI, BlankNode:
+		return true
+	}
+	return false
+}
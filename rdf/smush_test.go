@@ -0,0 +1,63 @@
+package rdf
+
+import "testing"
+
+func smushStatements() []Statement {
+	sameAs := IRI{Value: owlSameAsIRI}
+	name := IRI{Value: "http://example.org/name"}
+	return []Statement{
+		NewTriple(IRI{Value: "http://geonames.org/2988507"}, sameAs, IRI{Value: "http://dbpedia.org/resource/Paris"}),
+		NewTriple(BlankNode{ID: "p"}, sameAs, IRI{Value: "http://geonames.org/2988507"}),
+		NewTriple(IRI{Value: "http://dbpedia.org/resource/Paris"}, name, Literal{Lexical: "Paris"}),
+		NewTriple(BlankNode{ID: "p"}, name, Literal{Lexical: "Paris"}),
+		NewQuad(IRI{Value: "http://example.org/france"}, IRI{Value: "http://example.org/capital"}, BlankNode{ID: "p"}, IRI{Value: "http://geonames.org/2988507"}),
+	}
+}
+
+func TestSmush(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy SmushPolicy
+		want   string
+	}{
+		{"default", nil, "http://dbpedia.org/resource/Paris"},
+		{"first seen", SmushFirstSeen(), "http://geonames.org/2988507"},
+		{"namespace", SmushPreferNamespaces("http://example.org/", "http://geonames.org/"), "http://geonames.org/2988507"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := smushStatements()
+			out := Smush(in, tc.policy)
+			rep := IRI{Value: tc.want}
+			// The two name statements collapse into one.
+			if len(out) != 4 {
+				t.Fatalf("got %d statements, want 4: %v", len(out), out)
+			}
+			if out[0] != in[0] || out[1] != in[1] {
+				t.Errorf("owl:sameAs statements changed: %v", out[:2])
+			}
+			if out[2].S != rep {
+				t.Errorf("subject = %v, want %v", out[2].S, rep)
+			}
+			if out[3].O != rep || out[3].G != rep {
+				t.Errorf("object and graph = %v, %v, want %v", out[3].O, out[3].G, rep)
+			}
+			if in[3].S != (BlankNode{ID: "p"}) {
+				t.Error("input was modified")
+			}
+		})
+	}
+}
+
+func TestSmushBlankNodesOnly(t *testing.T) {
+	sameAs := IRI{Value: owlSameAsIRI}
+	stmts := []Statement{
+		NewTriple(BlankNode{ID: "a"}, sameAs, BlankNode{ID: "b"}),
+		NewTriple(BlankNode{ID: "b"}, IRI{Value: "http://example.org/p"}, TripleTerm{S: BlankNode{ID: "b"}, P: sameAs, O: Literal{Lexical: "x"}}),
+	}
+	out := Smush(stmts, nil)
+	want := Statement{S: BlankNode{ID: "a"}, P: IRI{Value: "http://example.org/p"}, O: TripleTerm{S: BlankNode{ID: "a"}, P: sameAs, O: Literal{Lexical: "x"}}}
+	if len(out) != 2 || out[1] != want {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+}
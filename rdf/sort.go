@@ -0,0 +1,653 @@
+package rdf
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// DefaultSortMemoryLimit is the default number of bytes SortStatements
+// holds in memory before spilling sorted runs to disk.
+const DefaultSortMemoryLimit = 256 << 20
+
+const (
+	// sortRecordOverhead estimates the memory used by one record besides
+	// its bytes: the slice header and its share of the record slice.
+	sortRecordOverhead = 32
+	// sortMergeFanIn is the number of runs merged at once.
+	sortMergeFanIn = 64
+)
+
+// SortOption configures SortStatements.
+type SortOption func(*sortOptions)
+
+type sortOptions struct {
+	memoryLimit int64
+	tempDir     string
+	unique      bool
+}
+
+// OptSortMemoryLimit sets the number of bytes of statements SortStatements
+// holds in memory before writing them to a sorted temporary file (default
+// DefaultSortMemoryLimit, 0 = never spill).
+func OptSortMemoryLimit(bytes int64) SortOption {
+	return func(opts *sortOptions) {
+		opts.memoryLimit = bytes
+	}
+}
+
+// OptSortTempDir sets the directory of the files SortStatements spills to
+// (default os.TempDir()).
+func OptSortTempDir(dir string) SortOption {
+	return func(opts *sortOptions) {
+		opts.tempDir = dir
+	}
+}
+
+// OptSortUnique makes SortStatements write statements with the same
+// canonical N-Quads line once, like sort -u.
+func OptSortUnique() SortOption {
+	return func(opts *sortOptions) {
+		opts.unique = true
+	}
+}
+
+// SortStatements writes every statement from r to w in the byte order of
+// their canonical N-Quads lines, as "LC_ALL=C sort" would order the lines,
+// then flushes w. Equal inputs thus produce identical, diff-able output
+// whatever order the statements were read in. It returns the number of
+// statements written; neither r nor w is closed.
+//
+// Canonical lines follow RDF 1.2 N-Quads: single spaces between terms, no
+// xsd:string datatype, language tags in lower case and only the required
+// string escapes. Statements are held in memory up to OptSortMemoryLimit;
+// beyond it they are sorted and written to temporary files in
+// OptSortTempDir, which are merged at the end (an external merge sort), so
+// inputs much larger than memory can be sorted. The files are removed
+// before SortStatements returns. Statements must consist of the terms
+// defined in this package.
+func SortStatements(r Reader, w Writer, opts ...SortOption) (int64, error) {
+	options := sortOptions{memoryLimit: DefaultSortMemoryLimit}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	s := &statementSorter{opts: options}
+	defer s.removeRuns()
+	for {
+		stmt, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if err := s.add(stmt); err != nil {
+			return 0, err
+		}
+	}
+	s.sortRecords()
+	if len(s.runs) == 0 {
+		return s.write(w, &sliceRecords{records: s.records})
+	}
+	if len(s.records) > 0 {
+		if err := s.spill(); err != nil {
+			return 0, err
+		}
+	}
+	for len(s.runs) > sortMergeFanIn {
+		if err := s.mergeRuns(sortMergeFanIn); err != nil {
+			return 0, err
+		}
+	}
+	merger, err := s.openMerger(s.runs)
+	if err != nil {
+		return 0, err
+	}
+	return s.write(w, merger)
+}
+
+// statementSorter holds the state of SortStatements. Each record is a
+// statement encoded as its length-prefixed canonical N-Quads line, the sort
+// key, followed by the appendTermRecord encoding of its terms.
+type statementSorter struct {
+	opts    sortOptions
+	records [][]byte
+	size    int64
+	runs    []*os.File
+}
+
+func (s *statementSorter) add(stmt Statement) error {
+	line, err := appendCanonicalNQuad(nil, stmt)
+	if err != nil {
+		return err
+	}
+	record := binary.AppendUvarint(make([]byte, 0, len(line)*2+16), uint64(len(line)))
+	record = append(record, line...)
+	for _, t := range []Term{stmt.S, stmt.P, stmt.O, stmt.G} {
+		record = appendTermRecord(record, t)
+	}
+	s.records = append(s.records, record)
+	s.size += int64(len(record)) + sortRecordOverhead
+	if s.opts.memoryLimit > 0 && s.size >= s.opts.memoryLimit {
+		s.sortRecords()
+		return s.spill()
+	}
+	return nil
+}
+
+func (s *statementSorter) sortRecords() {
+	slices.SortFunc(s.records, func(a, b []byte) int {
+		return bytes.Compare(recordLine(a), recordLine(b))
+	})
+}
+
+// spill writes the sorted records in memory to a new run.
+func (s *statementSorter) spill() error {
+	err := s.writeRun(&sliceRecords{records: s.records})
+	clear(s.records)
+	s.records, s.size = s.records[:0], 0
+	return err
+}
+
+// mergeRuns merges the first n runs into a new run at the end.
+func (s *statementSorter) mergeRuns(n int) error {
+	merger, err := s.openMerger(s.runs[:n])
+	if err != nil {
+		return err
+	}
+	if err := s.writeRun(merger); err != nil {
+		return err
+	}
+	for _, run := range s.runs[:n] {
+		if err := removeRun(run); err != nil {
+			return err
+		}
+	}
+	s.runs = slices.Delete(s.runs, 0, n)
+	return nil
+}
+
+// writeRun writes the records of src to a new temporary file, each
+// prefixed with its length, and adds it to the runs.
+func (s *statementSorter) writeRun(src recordSource) error {
+	file, err := os.CreateTemp(s.opts.tempDir, "rdf-sort-*")
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, file)
+	w := bufio.NewWriterSize(file, 64<<10)
+	var prefix [binary.MaxVarintLen64]byte
+	for {
+		record, err := src.next()
+		if err == io.EOF {
+			return w.Flush()
+		}
+		if err != nil {
+			return err
+		}
+		n := binary.PutUvarint(prefix[:], uint64(len(record)))
+		if _, err := w.Write(prefix[:n]); err != nil {
+			return err
+		}
+		if _, err := w.Write(record); err != nil {
+			return err
+		}
+	}
+}
+
+// openMerger returns a source merging the records of runs in order.
+func (s *statementSorter) openMerger(runs []*os.File) (*runMerger, error) {
+	m := &runMerger{}
+	for _, run := range runs {
+		if _, err := run.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		c := &runCursor{r: bufio.NewReaderSize(run, 64<<10)}
+		ok, err := c.advance()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			m.cursors = append(m.cursors, c)
+		}
+	}
+	heap.Init(m)
+	return m, nil
+}
+
+// write decodes the records of src and writes them to w.
+func (s *statementSorter) write(w Writer, src recordSource) (int64, error) {
+	var n int64
+	var previous []byte
+	for {
+		record, err := src.next()
+		if err == io.EOF {
+			return n, w.Flush()
+		}
+		if err != nil {
+			return n, err
+		}
+		line := recordLine(record)
+		if s.opts.unique && previous != nil && bytes.Equal(line, previous) {
+			continue
+		}
+		previous = append(previous[:0], line...)
+		stmt, err := decodeRecord(record)
+		if err != nil {
+			return n, err
+		}
+		if err := w.Write(stmt); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+func (s *statementSorter) removeRuns() {
+	for _, run := range s.runs {
+		removeRun(run)
+	}
+	s.runs = nil
+}
+
+func removeRun(run *os.File) error {
+	err := run.Close()
+	if rerr := os.Remove(run.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// recordLine returns the canonical N-Quads line of a record.
+func recordLine(record []byte) []byte {
+	n, size := binary.Uvarint(record)
+	return record[size : size+int(n)]
+}
+
+// decodeRecord returns the statement of a record.
+func decodeRecord(record []byte) (Statement, error) {
+	n, size := binary.Uvarint(record)
+	rest := record[size+int(n):]
+	var terms [4]Term
+	for i := range terms {
+		var err error
+		if terms[i], rest, err = decodeTermRecord(rest); err != nil {
+			return Statement{}, err
+		}
+	}
+	p, _ := terms[1].(IRI)
+	return Statement{S: terms[0], P: p, O: terms[2], G: terms[3]}, nil
+}
+
+// recordSource returns sorted records until io.EOF. A record is valid
+// until the next call.
+type recordSource interface {
+	next() ([]byte, error)
+}
+
+type sliceRecords struct {
+	records [][]byte
+}
+
+func (s *sliceRecords) next() ([]byte, error) {
+	if len(s.records) == 0 {
+		return nil, io.EOF
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil
+}
+
+// runCursor reads the records of a run.
+type runCursor struct {
+	r       *bufio.Reader
+	current []byte
+}
+
+// advance reads the next record into current and reports whether there
+// was one.
+func (c *runCursor) advance() (bool, error) {
+	n, err := binary.ReadUvarint(c.r)
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	c.current = slices.Grow(c.current[:0], int(n))[:n]
+	if _, err := io.ReadFull(c.r, c.current); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// runMerger is a heap of run cursors ordered by their current records.
+type runMerger struct {
+	cursors []*runCursor
+	last    *runCursor // Cursor of the record returned last, to advance
+	record  []byte
+}
+
+func (m *runMerger) Len() int { return len(m.cursors) }
+func (m *runMerger) Less(i, j int) bool {
+	return bytes.Compare(recordLine(m.cursors[i].current), recordLine(m.cursors[j].current)) < 0
+}
+func (m *runMerger) Swap(i, j int) { m.cursors[i], m.cursors[j] = m.cursors[j], m.cursors[i] }
+func (m *runMerger) Push(x any)    { m.cursors = append(m.cursors, x.(*runCursor)) }
+func (m *runMerger) Pop() any {
+	c := m.cursors[len(m.cursors)-1]
+	m.cursors = m.cursors[:len(m.cursors)-1]
+	return c
+}
+
+func (m *runMerger) next() ([]byte, error) {
+	if m.last != nil {
+		// The record returned last is copied before its cursor moves on.
+		ok, err := m.last.advance()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			heap.Fix(m, 0)
+		} else {
+			heap.Pop(m)
+		}
+		m.last = nil
+	}
+	if len(m.cursors) == 0 {
+		return nil, io.EOF
+	}
+	m.last = m.cursors[0]
+	m.record = append(m.record[:0], m.last.current...)
+	return m.record, nil
+}
+
+// ErrNotSorted indicates that an input of Union, Intersect or Subtract is
+// not in the order of SortStatements.
+var ErrNotSorted = errors.New("rdf: statements not sorted")
+
+// Union returns the statements of a or b, which must both be sorted by
+// canonical N-Quads line as SortStatements writes them. Statements with
+// the same canonical line are returned once, from a if it has one, and
+// the result is sorted in turn. The inputs are read in a single pass that
+// holds one statement of each, and Close closes both.
+func Union(a, b Reader) Reader {
+	return newSetOpReader(setUnion, a, b)
+}
+
+// Intersect returns the statements of a that are also in b, comparing
+// canonical N-Quads lines. Like Union, it requires sorted inputs, returns
+// each line once in sorted order and uses constant memory.
+func Intersect(a, b Reader) Reader {
+	return newSetOpReader(setIntersect, a, b)
+}
+
+// Subtract returns the statements of a that are not in b, comparing
+// canonical N-Quads lines. Like Union, it requires sorted inputs, returns
+// each line once in sorted order and uses constant memory. Subtracting two
+// sorted dumps both ways lists the statements added and removed between
+// them.
+func Subtract(a, b Reader) Reader {
+	return newSetOpReader(setSubtract, a, b)
+}
+
+type setOp int
+
+const (
+	setUnion setOp = iota
+	setIntersect
+	setSubtract
+)
+
+// setOpReader merge-joins two sorted streams. Next fails with
+// ErrNotSorted when an input goes back in order.
+type setOpReader struct {
+	op      setOp
+	a, b    *sortedCursor
+	started bool
+	last    []byte // Canonical line returned last
+	hasLast bool
+	err     error
+}
+
+func newSetOpReader(op setOp, a, b Reader) *setOpReader {
+	return &setOpReader{op: op, a: &sortedCursor{src: a, name: "first"}, b: &sortedCursor{src: b, name: "second"}}
+}
+
+func (r *setOpReader) Next() (Statement, error) {
+	if r.err != nil {
+		return Statement{}, r.err
+	}
+	stmt, err := r.next()
+	if err != nil {
+		r.err = err
+	}
+	return stmt, err
+}
+
+func (r *setOpReader) next() (Statement, error) {
+	if !r.started {
+		r.started = true
+		if err := r.a.advance(); err != nil {
+			return Statement{}, err
+		}
+		if err := r.b.advance(); err != nil {
+			return Statement{}, err
+		}
+	}
+	for {
+		if r.a.done && (r.b.done || r.op != setUnion) {
+			return Statement{}, io.EOF
+		}
+		cmp := r.a.compare(r.b)
+		var from *sortedCursor
+		switch {
+		case r.op == setUnion && cmp <= 0:
+			from = r.a
+			if cmp == 0 {
+				if err := r.b.advance(); err != nil {
+					return Statement{}, err
+				}
+			}
+		case r.op == setUnion:
+			from = r.b
+		case r.op == setIntersect && r.b.done:
+			return Statement{}, io.EOF
+		case cmp == 0 && r.op == setIntersect:
+			from = r.a
+		case cmp < 0 && r.op == setSubtract:
+			from = r.a
+		case cmp < 0 || cmp == 0 && r.op == setSubtract:
+			// A statement of a only for Intersect, or one in b for Subtract.
+			if err := r.a.advance(); err != nil {
+				return Statement{}, err
+			}
+			continue
+		default:
+			if err := r.b.advance(); err != nil {
+				return Statement{}, err
+			}
+			continue
+		}
+		stmt := from.stmt
+		duplicate := r.hasLast && bytes.Equal(from.line, r.last)
+		r.last, r.hasLast = append(r.last[:0], from.line...), true
+		if err := from.advance(); err != nil {
+			return Statement{}, err
+		}
+		if !duplicate {
+			return stmt, nil
+		}
+	}
+}
+
+func (r *setOpReader) Close() error {
+	err := r.a.src.Close()
+	if cerr := r.b.src.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// sortedCursor holds the current statement of a sorted input and its
+// canonical line.
+type sortedCursor struct {
+	src  Reader
+	name string
+	stmt Statement
+	line []byte
+	prev []byte
+	done bool
+}
+
+func (c *sortedCursor) advance() error {
+	stmt, err := c.src.Next()
+	if err == io.EOF {
+		c.done = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	line, err := appendCanonicalNQuad(c.prev[:0], stmt)
+	if err != nil {
+		return err
+	}
+	if c.line != nil && bytes.Compare(line, c.line) < 0 {
+		return fmt.Errorf("%w: %s input has %s after %s", ErrNotSorted, c.name, line, c.line)
+	}
+	c.stmt, c.prev, c.line = stmt, c.line, line
+	return nil
+}
+
+// compare compares the current lines of c and d, an exhausted input
+// coming last.
+func (c *sortedCursor) compare(d *sortedCursor) int {
+	switch {
+	case c.done && d.done:
+		return 0
+	case c.done:
+		return 1
+	case d.done:
+		return -1
+	}
+	return bytes.Compare(c.line, d.line)
+}
+
+// appendCanonicalNQuad appends s to buf as a canonical N-Quads line
+// (RDF 1.2 N-Quads), without the line feed.
+func appendCanonicalNQuad(buf []byte, s Statement) ([]byte, error) {
+	return appendCanonicalStatement(buf, s, false)
+}
+
+// appendCanonicalStatement is appendCanonicalNQuad, writing predicates whose
+// IRI starts with "_:" as blank nodes if generalized is set.
+func appendCanonicalStatement(buf []byte, s Statement, generalized bool) ([]byte, error) {
+	var err error
+	if buf, err = appendCanonicalTerm(buf, s.S); err != nil {
+		return nil, err
+	}
+	buf = append(buf, ' ')
+	if generalized && strings.HasPrefix(s.P.Value, "_:") {
+		buf = append(buf, s.P.Value...)
+	} else {
+		buf = appendCanonicalIRI(buf, s.P.Value)
+	}
+	buf = append(buf, ' ')
+	if buf, err = appendCanonicalTerm(buf, s.O); err != nil {
+		return nil, err
+	}
+	if s.G != nil {
+		buf = append(buf, ' ')
+		if buf, err = appendCanonicalTerm(buf, s.G); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, " ."...), nil
+}
+
+func appendCanonicalTerm(buf []byte, t Term) ([]byte, error) {
+	switch t := t.(type) {
+	case IRI:
+		return appendCanonicalIRI(buf, t.Value), nil
+	case BlankNode:
+		return append(append(buf, "_:"...), t.ID...), nil
+	case Literal:
+		buf = appendCanonicalString(buf, t.Lexical)
+		switch {
+		case t.Lang != "":
+			buf = append(append(buf, '@'), strings.ToLower(t.Lang)...)
+			if t.Direction != "" {
+				buf = append(append(buf, "--"...), t.Direction...)
+			}
+		case t.Datatype.Value != "" && t.Datatype.Value != xsdNamespace+"string":
+			buf = appendCanonicalIRI(append(buf, "^^"...), t.Datatype.Value)
+		}
+		return buf, nil
+	case TripleTerm:
+		var err error
+		buf = append(buf, "<<( "...)
+		if buf, err = appendCanonicalTerm(buf, t.S); err != nil {
+			return nil, err
+		}
+		buf = appendCanonicalIRI(append(buf, ' '), t.P.Value)
+		if buf, err = appendCanonicalTerm(append(buf, ' '), t.O); err != nil {
+			return nil, err
+		}
+		return append(buf, " )>>"...), nil
+	}
+	return nil, fmt.Errorf("rdf: cannot write %T as N-Quads", t)
+}
+
+// appendCanonicalString appends s as a quoted N-Quads string, escaping the
+// quote, the backslash and control characters.
+func appendCanonicalString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\b':
+			buf = append(buf, `\b`...)
+		case '\t':
+			buf = append(buf, `\t`...)
+		case '\n':
+			buf = append(buf, `\n`...)
+		case '\f':
+			buf = append(buf, `\f`...)
+		case '\r':
+			buf = append(buf, `\r`...)
+		case '"':
+			buf = append(buf, `\"`...)
+		case '\\':
+			buf = append(buf, `\\`...)
+		default:
+			if c < 0x20 || c == 0x7f {
+				buf = fmt.Appendf(buf, `\u%04X`, c)
+			} else {
+				buf = append(buf, c)
+			}
+		}
+	}
+	return append(buf, '"')
+}
+
+// appendCanonicalIRI appends <iri>, escaping the characters IRIs cannot
+// contain so that the line stays parseable.
+func appendCanonicalIRI(buf []byte, iri string) []byte {
+	buf = append(buf, '<')
+	for i := 0; i < len(iri); i++ {
+		c := iri[i]
+		if c <= 0x20 || strings.IndexByte("<>\"{}|^`\\", c) >= 0 {
+			buf = fmt.Appendf(buf, `\u%04X`, c)
+			continue
+		}
+		buf = append(buf, c)
+	}
+	return append(buf, '>')
+}
@@ -0,0 +1,149 @@
+package rdf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func sortedLines(t *testing.T, stmts []Statement) []string {
+	t.Helper()
+	var lines []string
+	for _, s := range stmts {
+		line, err := appendCanonicalNQuad(nil, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, string(line))
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func TestSortStatements(t *testing.T) {
+	s := IRI{Value: "http://example.org/s"}
+	p := IRI{Value: "http://example.org/p"}
+	stmts := []Statement{
+		NewQuad(s, p, Literal{Lexical: "b"}, IRI{Value: "http://example.org/g"}),
+		NewTriple(s, p, Literal{Lexical: "a\n\"q\"\x01", Datatype: IRI{Value: xsdNamespace + "string"}}),
+		NewTriple(BlankNode{ID: "x"}, p, Literal{Lexical: "hi", Lang: "EN-gb", Direction: "rtl"}),
+		NewTriple(s, p, TripleTerm{S: s, P: p, O: Literal{Lexical: "1", Datatype: IRI{Value: xsdNamespace + "integer"}}}),
+		NewTriple(s, p, IRI{Value: "http://example.org/a b"}),
+		NewTriple(s, p, Literal{Lexical: "b"}),
+	}
+	var out []Statement
+	n, err := SortStatements(&stubStatementReader{stmts: append(stmts, stmts[5])}, &statementCollector{stmts: &out}, OptSortUnique())
+	if err != nil {
+		t.Fatalf("SortStatements: %v", err)
+	}
+	if n != int64(len(stmts)) {
+		t.Errorf("n = %d, want %d", n, len(stmts))
+	}
+	want := `<http://example.org/s> <http://example.org/p> "a\n\"q\"\u0001" .
+<http://example.org/s> <http://example.org/p> "b" .
+<http://example.org/s> <http://example.org/p> "b" <http://example.org/g> .
+<http://example.org/s> <http://example.org/p> <<( <http://example.org/s> <http://example.org/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> )>> .
+<http://example.org/s> <http://example.org/p> <http://example.org/a\u0020b> .
+_:x <http://example.org/p> "hi"@en-gb--rtl .
+`
+	if got := strings.Join(sortedLines(t, stmts), "\n") + "\n"; got != want {
+		t.Errorf("canonical lines:\n%s\nwant:\n%s", got, want)
+	}
+	// Statements are written back unchanged, in the order of their lines.
+	wantOrder := []Statement{stmts[1], stmts[5], stmts[0], stmts[3], stmts[4], stmts[2]}
+	if !slices.Equal(out, wantOrder) {
+		t.Errorf("written statements = %v, want %v", out, wantOrder)
+	}
+}
+
+func TestSortStatementsSpill(t *testing.T) {
+	dir := t.TempDir()
+	input, distinct := dedupInput(3000)
+	var out []Statement
+	w := &statementCollector{stmts: &out}
+	// About 20 records fit in memory, so more runs than the merge fan-in
+	// are written and merged over two passes.
+	n, err := SortStatements(&stubStatementReader{stmts: input}, w, OptSortMemoryLimit(20*100), OptSortTempDir(dir), OptSortUnique())
+	if err != nil {
+		t.Fatalf("SortStatements: %v", err)
+	}
+	if n != int64(len(distinct)) || len(out) != len(distinct) {
+		t.Fatalf("wrote %d (%d) statements, want %d", n, len(out), len(distinct))
+	}
+	lines := sortedLines(t, out)
+	for i, s := range out {
+		line, _ := appendCanonicalNQuad(nil, s)
+		if string(line) != lines[i] {
+			t.Fatalf("statement %d out of order: %s", i, line)
+		}
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("spill files left: %d", len(files))
+	}
+	if _, err := SortStatements(&stubStatementReader{stmts: input}, w, OptSortMemoryLimit(1), OptSortTempDir(filepath.Join(dir, "missing"))); err == nil {
+		t.Error("expected an error creating the spill file")
+	}
+}
+
+// statementCollector is a Writer appending to stmts.
+type statementCollector struct {
+	stmts *[]Statement
+}
+
+func (c *statementCollector) Write(s Statement) error {
+	*c.stmts = append(*c.stmts, s)
+	return nil
+}
+
+func (c *statementCollector) Flush() error { return nil }
+func (c *statementCollector) Close() error { return nil }
+
+func TestSetOperations(t *testing.T) {
+	p := IRI{Value: "http://example.org/p"}
+	stmt := func(o string) Statement {
+		return NewTriple(IRI{Value: "http://example.org/s"}, p, Literal{Lexical: o})
+	}
+	a := []Statement{stmt("a"), stmt("b"), stmt("b"), stmt("d"), stmt("e")}
+	b := []Statement{stmt("b"), stmt("c"), stmt("e"), stmt("e"), stmt("f")}
+	cases := []struct {
+		name string
+		op   func(a, b Reader) Reader
+		want []Statement
+	}{
+		{"Union", Union, []Statement{stmt("a"), stmt("b"), stmt("c"), stmt("d"), stmt("e"), stmt("f")}},
+		{"Intersect", Intersect, []Statement{stmt("b"), stmt("e")}},
+		{"Subtract", Subtract, []Statement{stmt("a"), stmt("d")}},
+	}
+	for _, tc := range cases {
+		got, err := collectStatements(tc.op(&stubStatementReader{stmts: a}, &stubStatementReader{stmts: b}))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+	// With one input empty, the other is returned without duplicates.
+	empty := func() Reader { return &stubStatementReader{} }
+	for name, r := range map[string]Reader{
+		"Union(empty, b)":     Union(empty(), &stubStatementReader{stmts: b}),
+		"Subtract(b, empty)":  Subtract(&stubStatementReader{stmts: b}, empty()),
+		"Intersect(b, empty)": Intersect(&stubStatementReader{stmts: b}, empty()),
+	} {
+		want := []Statement{stmt("b"), stmt("c"), stmt("e"), stmt("f")}
+		if strings.HasPrefix(name, "Intersect") {
+			want = nil
+		}
+		if got, err := collectStatements(r); err != nil || !slices.Equal(got, want) {
+			t.Errorf("%s = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	unsorted := []Statement{stmt("b"), stmt("a")}
+	if _, err := collectStatements(Union(&stubStatementReader{stmts: unsorted}, &stubStatementReader{})); !errors.Is(err, ErrNotSorted) {
+		t.Errorf("unsorted input: err = %v, want ErrNotSorted", err)
+	}
+}
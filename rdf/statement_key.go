@@ -0,0 +1,337 @@
+package rdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidStatementKey indicates that DecodeStatementKey,
+// DecodeStatementKeyWith or DecodeTermKey was given bytes that are not a
+// key they decode.
+var ErrInvalidStatementKey = errors.New("rdf: invalid statement key")
+
+// Term tags of statement keys, in the order of TermCompare's kinds so that
+// keys sort like their terms.
+const (
+	keyTagNil     byte = 0x01
+	keyTagBlank   byte = 0x02
+	keyTagIRI     byte = 0x03
+	keyTagLiteral byte = 0x04
+	keyTagTriple  byte = 0x05
+)
+
+// Literal tags following the lexical form of a literal in a statement key,
+// in the order of TermCompare.
+const (
+	keyLiteralSimple byte = 0x01
+	keyLiteralLang   byte = 0x02
+	keyLiteralTyped  byte = 0x03
+)
+
+// EncodeStatementKey returns a compact binary key for s, for stores that
+// keep statements as keys of an ordered key-value store such as LMDB,
+// Badger or Pebble. Keys of statements equal by TermEqual are equal, and
+// bytes.Compare orders keys by subject, predicate, object and graph, with
+// the default graph first. Terms are ordered by kind as by TermCompare,
+// then by their strings (literals by lexical form, not by numeric value),
+// so a store can answer a pattern with a bound subject, or subject and
+// predicate, with a prefix scan.
+//
+// Each term is a tag byte followed by its strings, each ending in 0x00 0x01
+// with 0x00 bytes escaped as 0x00 0xFF. DecodeStatementKey returns language
+// tags in lower case and xsd:string literals without a datatype. Term
+// implementations of other packages are encoded from their Kind and String,
+// literals and triple terms as simple literals.
+func EncodeStatementKey(s Statement) []byte {
+	return AppendStatementKey(nil, s)
+}
+
+// AppendStatementKey appends the key of s returned by EncodeStatementKey
+// to buf.
+func AppendStatementKey(buf []byte, s Statement) []byte {
+	buf = appendKeyTerm(buf, s.S)
+	buf = appendKeyString(buf, s.P.Value)
+	buf = appendKeyTerm(buf, s.O)
+	return appendKeyTerm(buf, s.G)
+}
+
+// DecodeStatementKey decodes a key returned by EncodeStatementKey. It fails
+// with ErrInvalidStatementKey if key is not one.
+func DecodeStatementKey(key []byte) (Statement, error) {
+	d := keyDecoder{buf: key}
+	s := Statement{S: d.term(), P: IRI{Value: d.string()}, O: d.term(), G: d.term()}
+	if d.err == nil && len(d.buf) > 0 {
+		d.fail("%d trailing bytes", len(d.buf))
+	}
+	if d.err != nil {
+		return Statement{}, d.err
+	}
+	return s, nil
+}
+
+// EncodeTermKey returns the encoding of t within the keys of
+// EncodeStatementKey, which orders terms the same way, for instance to key
+// the terms of a TermDictionary.
+func EncodeTermKey(t Term) []byte {
+	return appendKeyTerm(nil, t)
+}
+
+// DecodeTermKey decodes a key returned by EncodeTermKey. It fails with
+// ErrInvalidStatementKey if key is not one.
+func DecodeTermKey(key []byte) (Term, error) {
+	d := keyDecoder{buf: key}
+	t := d.term()
+	if d.err == nil && len(d.buf) > 0 {
+		d.fail("%d trailing bytes", len(d.buf))
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return t, nil
+}
+
+func appendKeyTerm(buf []byte, t Term) []byte {
+	switch t := t.(type) {
+	case nil:
+		return append(buf, keyTagNil)
+	case IRI:
+		return appendKeyString(append(buf, keyTagIRI), t.Value)
+	case BlankNode:
+		return appendKeyString(append(buf, keyTagBlank), t.ID)
+	case Literal:
+		buf = appendKeyString(append(buf, keyTagLiteral), t.Lexical)
+		switch {
+		case t.Lang != "":
+			buf = append(buf, keyLiteralLang)
+			buf = appendKeyString(buf, asciiLower(t.Lang))
+			return appendKeyString(buf, t.Direction)
+		case literalDatatype(t) == xsdNamespace+"string":
+			return append(buf, keyLiteralSimple)
+		}
+		return appendKeyString(append(buf, keyLiteralTyped), t.Datatype.Value)
+	case TripleTerm:
+		buf = appendKeyTerm(append(buf, keyTagTriple), t.S)
+		buf = appendKeyString(buf, t.P.Value)
+		return appendKeyTerm(buf, t.O)
+	}
+	switch t.Kind() {
+	case TermIRI:
+		return appendKeyTerm(buf, IRI{Value: t.String()})
+	case TermBlankNode:
+		return appendKeyTerm(buf, BlankNode{ID: strings.TrimPrefix(t.String(), "_:")})
+	}
+	return appendKeyTerm(buf, Literal{Lexical: t.String()})
+}
+
+// appendKeyString appends s terminated by 0x00 0x01, escaping 0x00 as
+// 0x00 0xFF, which keeps the byte order of strings and of what follows them.
+func appendKeyString(buf []byte, s string) []byte {
+	for {
+		i := strings.IndexByte(s, 0)
+		if i < 0 {
+			break
+		}
+		buf = append(append(buf, s[:i]...), 0x00, 0xFF)
+		s = s[i+1:]
+	}
+	return append(append(buf, s...), 0x00, 0x01)
+}
+
+// keyDecoder reads the terms of a statement key, recording the first error.
+type keyDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *keyDecoder) fail(format string, args ...any) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: %s", ErrInvalidStatementKey, fmt.Sprintf(format, args...))
+	}
+}
+
+func (d *keyDecoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) == 0 {
+		d.fail("unexpected end")
+		return 0
+	}
+	b := d.buf[0]
+	d.buf = d.buf[1:]
+	return b
+}
+
+func (d *keyDecoder) string() string {
+	var s []byte
+	for d.err == nil {
+		i := bytes.IndexByte(d.buf, 0)
+		if i < 0 || i+1 == len(d.buf) {
+			d.fail("unterminated string")
+			break
+		}
+		s = append(s, d.buf[:i]...)
+		escape := d.buf[i+1]
+		d.buf = d.buf[i+2:]
+		if escape == 0x01 {
+			return string(s)
+		}
+		if escape != 0xFF {
+			d.fail("invalid escape 0x00 0x%02X", escape)
+			break
+		}
+		s = append(s, 0)
+	}
+	return ""
+}
+
+func (d *keyDecoder) term() Term {
+	switch tag := d.byte(); tag {
+	case keyTagNil:
+		return nil
+	case keyTagIRI:
+		return IRI{Value: d.string()}
+	case keyTagBlank:
+		return BlankNode{ID: d.string()}
+	case keyTagLiteral:
+		l := Literal{Lexical: d.string()}
+		switch kind := d.byte(); kind {
+		case keyLiteralSimple:
+		case keyLiteralLang:
+			l.Lang = d.string()
+			l.Direction = d.string()
+		case keyLiteralTyped:
+			l.Datatype = IRI{Value: d.string()}
+		default:
+			d.fail("invalid literal tag 0x%02X", kind)
+		}
+		return l
+	case keyTagTriple:
+		t := TripleTerm{S: d.term(), P: IRI{Value: d.string()}}
+		t.O = d.term()
+		return t
+	default:
+		d.fail("invalid term tag 0x%02X", tag)
+		return nil
+	}
+}
+
+// TermDictionary maps terms to integer identifiers for
+// EncodeStatementKeyWith, so that a store keeps each term once and
+// statement keys stay small. A persistent store typically implements it
+// with two key-value tables, keyed by EncodeTermKey and by identifier.
+type TermDictionary interface {
+	// ID returns the identifier of t, assigning one if t has none yet.
+	// Identifiers are not zero, and terms equal by TermEqual have the
+	// same identifier.
+	ID(t Term) (uint64, error)
+	// Term returns the term with identifier id.
+	Term(id uint64) (Term, error)
+}
+
+// EncodeStatementKeyWith returns a key of 32 bytes for s: the identifiers
+// dict assigns to the subject, predicate, object and graph, as big-endian
+// integers, the default graph being 0. bytes.Compare orders keys by
+// identifier, so a dictionary assigning identifiers in TermCompare order
+// gives keys in statement order.
+func EncodeStatementKeyWith(s Statement, dict TermDictionary) ([]byte, error) {
+	key := make([]byte, 0, 32)
+	for _, t := range [...]Term{s.S, s.P, s.O, s.G} {
+		var id uint64
+		if t != nil {
+			var err error
+			if id, err = dict.ID(t); err != nil {
+				return nil, err
+			}
+		}
+		key = binary.BigEndian.AppendUint64(key, id)
+	}
+	return key, nil
+}
+
+// DecodeStatementKeyWith decodes a key returned by EncodeStatementKeyWith
+// with the same dictionary. It fails with ErrInvalidStatementKey if key is
+// not one, and with the errors of dict.
+func DecodeStatementKeyWith(key []byte, dict TermDictionary) (Statement, error) {
+	if len(key) != 32 {
+		return Statement{}, fmt.Errorf("%w: %d bytes, want 32", ErrInvalidStatementKey, len(key))
+	}
+	var terms [4]Term
+	for i := range terms {
+		id := binary.BigEndian.Uint64(key[8*i:])
+		if id == 0 {
+			if i < 3 {
+				return Statement{}, fmt.Errorf("%w: no identifier at position %d", ErrInvalidStatementKey, i)
+			}
+			continue
+		}
+		t, err := dict.Term(id)
+		if err != nil {
+			return Statement{}, err
+		}
+		terms[i] = t
+	}
+	p, ok := terms[1].(IRI)
+	if !ok {
+		return Statement{}, fmt.Errorf("%w: predicate %v is not an IRI", ErrInvalidStatementKey, terms[1])
+	}
+	return Statement{S: terms[0], P: p, O: terms[2], G: terms[3]}, nil
+}
+
+// MemoryTermDictionary is a TermDictionary held in memory, assigning
+// identifiers from 1 in the order terms are first seen. It is safe for
+// concurrent use.
+type MemoryTermDictionary struct {
+	mu    sync.RWMutex
+	ids   map[string]uint64
+	terms []Term
+}
+
+// NewMemoryTermDictionary returns an empty MemoryTermDictionary.
+func NewMemoryTermDictionary() *MemoryTermDictionary {
+	return &MemoryTermDictionary{ids: make(map[string]uint64)}
+}
+
+// ID implements TermDictionary.
+func (d *MemoryTermDictionary) ID(t Term) (uint64, error) {
+	var scratch [128]byte
+	key := appendKeyTerm(scratch[:0], t)
+	d.mu.RLock()
+	id, ok := d.ids[string(key)]
+	d.mu.RUnlock()
+	if ok {
+		return id, nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if id, ok := d.ids[string(key)]; ok {
+		return id, nil
+	}
+	// The term is copied since it may point into a reader's buffer.
+	d.terms = append(d.terms, cloneTerm(t))
+	id = uint64(len(d.terms))
+	d.ids[string(key)] = id
+	return id, nil
+}
+
+// Term implements TermDictionary. It fails with ErrInvalidStatementKey for
+// an identifier it did not assign.
+func (d *MemoryTermDictionary) Term(id uint64) (Term, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if id == 0 || id > uint64(len(d.terms)) {
+		return nil, fmt.Errorf("%w: unknown term identifier %d", ErrInvalidStatementKey, id)
+	}
+	return d.terms[id-1], nil
+}
+
+// Len returns the number of terms in the dictionary.
+func (d *MemoryTermDictionary) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.terms)
+}
@@ -0,0 +1,125 @@
+package rdf
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+)
+
+var statementKeyTerms = []Term{
+	BlankNode{ID: "b1"},
+	BlankNode{ID: "b1\x00x"},
+	IRI{Value: "http://example.org/a"},
+	IRI{Value: "http://example.org/a\x00"},
+	IRI{Value: "http://example.org/ab"},
+	Literal{Lexical: "x"},
+	Literal{Lexical: "x", Lang: "en"},
+	Literal{Lexical: "x", Lang: "en", Direction: "rtl"},
+	Literal{Lexical: "x", Datatype: IRI{Value: xsdNamespace + "token"}},
+	Literal{Lexical: "x\x00"},
+	Literal{Lexical: "y"},
+	TripleTerm{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o", Lang: "fr"}},
+}
+
+func TestStatementKeyRoundTrip(t *testing.T) {
+	for _, s := range statementKeyTerms {
+		for _, o := range statementKeyTerms {
+			for _, g := range []Term{nil, IRI{Value: "http://example.org/g"}, BlankNode{ID: "g"}} {
+				stmt := Statement{S: s, P: IRI{Value: "http://example.org/p"}, O: o, G: g}
+				got, err := DecodeStatementKey(EncodeStatementKey(stmt))
+				if err != nil {
+					t.Fatalf("decode %v: %v", stmt, err)
+				}
+				if got != stmt {
+					t.Fatalf("decoded %v, want %v", got, stmt)
+				}
+			}
+		}
+	}
+}
+
+func TestStatementKeyNormalizesEqualTerms(t *testing.T) {
+	pairs := [][2]Term{
+		{Literal{Lexical: "x"}, Literal{Lexical: "x", Datatype: IRI{Value: xsdNamespace + "string"}}},
+		{Literal{Lexical: "x", Lang: "en-GB"}, Literal{Lexical: "x", Lang: "en-gb"}},
+	}
+	for _, pair := range pairs {
+		if !bytes.Equal(EncodeTermKey(pair[0]), EncodeTermKey(pair[1])) {
+			t.Errorf("keys of %v and %v differ", pair[0], pair[1])
+		}
+		got, err := DecodeTermKey(EncodeTermKey(pair[0]))
+		if err != nil || !TermEqual(got, pair[0]) {
+			t.Errorf("DecodeTermKey = %v, %v, want %v", got, err, pair[0])
+		}
+	}
+}
+
+func TestStatementKeyOrder(t *testing.T) {
+	// statementKeyTerms is in TermCompare order, so keys of statements built
+	// from it sort like the statements do.
+	var stmts []Statement
+	for _, s := range statementKeyTerms {
+		for _, o := range statementKeyTerms[:4] {
+			for _, g := range []Term{nil, IRI{Value: "http://example.org/g"}} {
+				stmts = append(stmts, Statement{S: s, P: IRI{Value: "http://example.org/p"}, O: o, G: g})
+			}
+		}
+	}
+	for i := 1; i < len(statementKeyTerms); i++ {
+		if TermCompare(statementKeyTerms[i-1], statementKeyTerms[i]) >= 0 {
+			t.Fatalf("test terms out of order at %v", statementKeyTerms[i])
+		}
+	}
+	keys := make([][]byte, len(stmts))
+	for i, stmt := range stmts {
+		keys[i] = EncodeStatementKey(stmt)
+	}
+	if !slices.IsSortedFunc(keys, bytes.Compare) {
+		t.Fatal("keys are not in statement order")
+	}
+}
+
+func TestStatementKeyInvalid(t *testing.T) {
+	valid := EncodeStatementKey(Statement{S: BlankNode{ID: "b"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "x", Lang: "en"}})
+	for _, key := range [][]byte{
+		nil,
+		valid[:len(valid)-1],
+		append(slices.Clone(valid), 0),
+		{0x09},
+		{keyTagIRI, 'a', 0x00, 0x02},
+	} {
+		if _, err := DecodeStatementKey(key); !errors.Is(err, ErrInvalidStatementKey) {
+			t.Errorf("DecodeStatementKey(%q) error = %v, want ErrInvalidStatementKey", key, err)
+		}
+	}
+}
+
+func TestStatementKeyWith(t *testing.T) {
+	dict := NewMemoryTermDictionary()
+	stmts := []Statement{
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "x", Lang: "en"}},
+		{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: IRI{Value: "http://example.org/s"}, G: BlankNode{ID: "g"}},
+	}
+	for _, stmt := range stmts {
+		key, err := EncodeStatementKeyWith(stmt, dict)
+		if err != nil || len(key) != 32 {
+			t.Fatalf("EncodeStatementKeyWith = %x, %v", key, err)
+		}
+		got, err := DecodeStatementKeyWith(key, dict)
+		if err != nil || got != stmt {
+			t.Fatalf("DecodeStatementKeyWith = %v, %v, want %v", got, err, stmt)
+		}
+	}
+	if dict.Len() != 4 {
+		t.Errorf("dictionary has %d terms, want 4", dict.Len())
+	}
+	if _, err := DecodeStatementKeyWith(make([]byte, 32), dict); !errors.Is(err, ErrInvalidStatementKey) {
+		t.Errorf("zero key error = %v, want ErrInvalidStatementKey", err)
+	}
+	key := make([]byte, 32)
+	key[7], key[15], key[23] = 1, 9, 1
+	if _, err := DecodeStatementKeyWith(key, dict); !errors.Is(err, ErrInvalidStatementKey) {
+		t.Errorf("unknown identifier error = %v, want ErrInvalidStatementKey", err)
+	}
+}
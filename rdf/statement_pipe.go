@@ -0,0 +1,159 @@
+package rdf
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// NewPipe creates a connected in-memory Writer/Reader pair. Statements written
+// to the PipeWriter are returned by the PipeReader in order. Up to buffer
+// statements are queued; further writes block until the reader catches up,
+// so a fast producer cannot outrun a slow consumer. A buffer below 1 makes
+// every Write wait for a matching Next.
+//
+// Cancelling ctx unblocks both sides, which then return ctx.Err(). A nil ctx
+// is treated as context.Background().
+//
+// The writer and reader are meant to be used from different goroutines:
+//
+//	pr, pw := rdf.NewPipe(ctx, 256)
+//	go func() {
+//		defer pw.Close()
+//		for _, s := range generate() {
+//			if err := pw.Write(s); err != nil {
+//				return
+//			}
+//		}
+//	}()
+//	for {
+//		s, err := pr.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+func NewPipe(ctx context.Context, buffer int) (*PipeReader, *PipeWriter) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if buffer < 0 {
+		buffer = 0
+	}
+	p := &statementPipe{
+		ctx:   ctx,
+		ch:    make(chan Statement, buffer),
+		done:  make(chan struct{}),
+		wdone: make(chan struct{}),
+	}
+	return &PipeReader{p: p}, &PipeWriter{p: p}
+}
+
+// statementPipe is the state shared by a PipeReader and PipeWriter.
+type statementPipe struct {
+	ctx   context.Context
+	ch    chan Statement
+	done  chan struct{} // closed by the reader
+	wdone chan struct{} // closed by the writer
+
+	rOnce sync.Once
+	wOnce sync.Once
+	mu    sync.Mutex
+	werr  error // error reported to the reader once the queue is drained
+}
+
+// PipeReader is the read half of a pipe created by NewPipe.
+type PipeReader struct {
+	p *statementPipe
+}
+
+// Next returns the next queued statement. It blocks until a statement is
+// available, the writer is closed (io.EOF or the error passed to
+// CloseWithError), or the context is cancelled.
+func (r *PipeReader) Next() (Statement, error) {
+	p := r.p
+	select {
+	case <-p.done:
+		return Statement{}, io.ErrClosedPipe
+	default:
+	}
+	select {
+	case stmt := <-p.ch:
+		return stmt, nil
+	case <-p.wdone:
+		// Drain anything written before Close.
+		select {
+		case stmt := <-p.ch:
+			return stmt, nil
+		default:
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.werr != nil {
+			return Statement{}, p.werr
+		}
+		return Statement{}, io.EOF
+	case <-p.done:
+		return Statement{}, io.ErrClosedPipe
+	case <-p.ctx.Done():
+		return Statement{}, p.ctx.Err()
+	}
+}
+
+// Close closes the reader. Subsequent and blocked writes return io.ErrClosedPipe.
+func (r *PipeReader) Close() error {
+	r.p.rOnce.Do(func() { close(r.p.done) })
+	return nil
+}
+
+// PipeWriter is the write half of a pipe created by NewPipe.
+type PipeWriter struct {
+	p *statementPipe
+}
+
+// Write queues s for the reader, blocking while the buffer is full.
+// It returns io.ErrClosedPipe once either side is closed, or ctx.Err() if the
+// context is cancelled.
+func (w *PipeWriter) Write(s Statement) error {
+	p := w.p
+	select {
+	case <-p.wdone:
+		return io.ErrClosedPipe
+	case <-p.done:
+		return io.ErrClosedPipe
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	default:
+	}
+	select {
+	case p.ch <- s:
+		return nil
+	case <-p.done:
+		return io.ErrClosedPipe
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	}
+}
+
+// Flush is a no-op; queued statements are visible to the reader immediately.
+func (w *PipeWriter) Flush() error {
+	return nil
+}
+
+// Close closes the writer. The reader returns io.EOF after draining the queue.
+func (w *PipeWriter) Close() error {
+	return w.CloseWithError(nil)
+}
+
+// CloseWithError closes the writer; the reader returns err instead of io.EOF
+// after draining the queue. A nil err is equivalent to Close.
+func (w *PipeWriter) CloseWithError(err error) error {
+	p := w.p
+	p.wOnce.Do(func() {
+		p.mu.Lock()
+		p.werr = err
+		p.mu.Unlock()
+		close(p.wdone)
+	})
+	return nil
+}
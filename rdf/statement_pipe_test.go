@@ -0,0 +1,99 @@
+package rdf
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewPipe_OrderAndEOF(t *testing.T) {
+	pr, pw := NewPipe(context.Background(), 4)
+	go func() {
+		defer pw.Close()
+		for i := 0; i < 100; i++ {
+			stmt := NewTriple(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: strconv.Itoa(i)})
+			if err := pw.Write(stmt); err != nil {
+				t.Errorf("write: %v", err)
+				return
+			}
+		}
+	}()
+	stmts, err := collectStatements(pr)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(stmts) != 100 {
+		t.Fatalf("expected 100 statements, got %d", len(stmts))
+	}
+	for i, s := range stmts {
+		if s.O.(Literal).Lexical != strconv.Itoa(i) {
+			t.Fatalf("statement %d out of order: %v", i, s.O)
+		}
+	}
+}
+
+func TestNewPipe_Backpressure(t *testing.T) {
+	pr, pw := NewPipe(context.Background(), 1)
+	defer pr.Close()
+	stmt := NewTriple(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "v"})
+	if err := pw.Write(stmt); err != nil {
+		t.Fatalf("first write should fit in buffer: %v", err)
+	}
+	blocked := make(chan error, 1)
+	go func() { blocked <- pw.Write(stmt) }()
+	select {
+	case err := <-blocked:
+		t.Fatalf("write should block while buffer is full, returned %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if _, err := pr.Next(); err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if err := <-blocked; err != nil {
+		t.Fatalf("blocked write should complete: %v", err)
+	}
+}
+
+func TestNewPipe_CloseWithError(t *testing.T) {
+	pr, pw := NewPipe(context.Background(), 2)
+	want := errors.New("producer failed")
+	stmt := NewTriple(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "v"})
+	if err := pw.Write(stmt); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	pw.CloseWithError(want)
+	if _, err := pr.Next(); err != nil {
+		t.Fatalf("queued statement should be delivered first: %v", err)
+	}
+	if _, err := pr.Next(); !errors.Is(err, want) {
+		t.Fatalf("expected producer error, got %v", err)
+	}
+	if err := pw.Write(stmt); !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("write after close: expected io.ErrClosedPipe, got %v", err)
+	}
+}
+
+func TestNewPipe_ReaderClose(t *testing.T) {
+	pr, pw := NewPipe(context.Background(), 0)
+	done := make(chan error, 1)
+	go func() {
+		done <- pw.Write(NewTriple(IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "v"}))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	pr.Close()
+	if err := <-done; !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("expected io.ErrClosedPipe, got %v", err)
+	}
+}
+
+func TestNewPipe_ContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pr, _ := NewPipe(ctx, 0)
+	cancel()
+	if _, err := pr.Next(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package rdf
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// StatementErrorReason identifies the rule a statement checked by
+// OptValidateOnWrite violates.
+type StatementErrorReason string
+
+const (
+	// StatementReasonMissingTerm indicates a statement without a subject,
+	// predicate or object.
+	StatementReasonMissingTerm StatementErrorReason = "MISSING_TERM"
+	// StatementReasonInvalidTermKind indicates a term of a kind its position
+	// cannot hold: a literal subject or a graph name other than an IRI or a
+	// blank node, or with OptGeneralizedRDF a triple term graph name.
+	StatementReasonInvalidTermKind StatementErrorReason = "INVALID_TERM_KIND"
+	// StatementReasonInvalidIRI indicates an IRI that is not an absolute
+	// RFC 3987 IRI.
+	StatementReasonInvalidIRI StatementErrorReason = "INVALID_IRI"
+	// StatementReasonInvalidBlankNode indicates a blank node without a label.
+	StatementReasonInvalidBlankNode StatementErrorReason = "INVALID_BLANK_NODE"
+	// StatementReasonInvalidLiteral indicates a literal whose lexical form is
+	// not valid UTF-8.
+	StatementReasonInvalidLiteral StatementErrorReason = "INVALID_LITERAL"
+	// StatementReasonInvalidLanguage indicates a malformed language tag or a
+	// base direction other than "ltr" and "rtl" or without a language tag.
+	StatementReasonInvalidLanguage StatementErrorReason = "INVALID_LANGUAGE"
+	// StatementReasonInvalidDatatype indicates a datatype that is not an
+	// absolute IRI, or that does not match the language tag of the literal:
+	// rdf:langString and rdf:dirLangString without one, another datatype
+	// with one.
+	StatementReasonInvalidDatatype StatementErrorReason = "INVALID_DATATYPE"
+)
+
+// StatementError describes why a writer created with OptValidateOnWrite
+// refused a statement. Code reports ErrCodeInvalidStatement for errors
+// wrapping it.
+type StatementError struct {
+	Statement Statement
+	Position  string // "subject", "predicate", "object" or "graph"
+	Term      Term   // Offending term, nested in the term at Position for triple terms
+	Reason    StatementErrorReason
+	Err       error // *IRIError of an invalid IRI or datatype IRI, nil otherwise
+}
+
+func (e *StatementError) Error() string {
+	var problem string
+	switch e.Reason {
+	case StatementReasonMissingTerm:
+		return fmt.Sprintf("rdf: invalid statement: missing %s", e.Position)
+	case StatementReasonInvalidTermKind:
+		problem = "term kind not allowed"
+	case StatementReasonInvalidIRI:
+		problem = e.Err.Error()
+	case StatementReasonInvalidBlankNode:
+		problem = "empty blank node label"
+	case StatementReasonInvalidLiteral:
+		problem = "lexical form is not valid UTF-8"
+	case StatementReasonInvalidLanguage:
+		problem = "invalid language tag"
+	case StatementReasonInvalidDatatype:
+		problem = "invalid datatype"
+		if e.Err != nil {
+			problem += ": " + e.Err.Error()
+		}
+	default:
+		problem = string(e.Reason)
+	}
+	return fmt.Sprintf("rdf: invalid statement: %s %s: %s", e.Position, renderTerm(e.Term), problem)
+}
+
+func (e *StatementError) Unwrap() error { return e.Err }
+
+// validateStatement checks the rules of OptValidateOnWrite, relaxed for
+// generalized RDF if generalized is set. The returned error is a
+// *StatementError.
+func validateStatement(s Statement, generalized bool) error {
+	fail := func(position string, term Term, reason StatementErrorReason, err error) error {
+		return &StatementError{Statement: s, Position: position, Term: term, Reason: reason, Err: err}
+	}
+	for _, part := range []struct {
+		position string
+		term     Term
+	}{{"subject", s.S}, {"predicate", s.P}, {"object", s.O}} {
+		if part.term == nil || part.position == "predicate" && s.P.Value == "" {
+			return fail(part.position, nil, StatementReasonMissingTerm, nil)
+		}
+	}
+	if _, ok := s.S.(Literal); ok && !generalized {
+		return fail("subject", s.S, StatementReasonInvalidTermKind, nil)
+	}
+	switch s.G.(type) {
+	case nil, IRI, BlankNode:
+	case Literal:
+		if !generalized {
+			return fail("graph", s.G, StatementReasonInvalidTermKind, nil)
+		}
+	default:
+		return fail("graph", s.G, StatementReasonInvalidTermKind, nil)
+	}
+	for _, part := range []struct {
+		position string
+		term     Term
+	}{{"subject", s.S}, {"predicate", s.P}, {"object", s.O}, {"graph", s.G}} {
+		if part.term == nil {
+			continue
+		}
+		if label, ok := strings.CutPrefix(s.P.Value, "_:"); ok && generalized && part.position == "predicate" {
+			if label == "" {
+				return fail("predicate", s.P, StatementReasonInvalidBlankNode, nil)
+			}
+			continue
+		}
+		if term, reason, err := validateTerm(part.term); reason != "" {
+			return fail(part.position, term, reason, err)
+		}
+	}
+	return nil
+}
+
+// validateTerm returns the first invalid term in t, the reason it is invalid
+// and the *IRIError of an invalid IRI, or an empty reason if t is valid.
+func validateTerm(t Term) (Term, StatementErrorReason, error) {
+	switch t := t.(type) {
+	case IRI:
+		if err := t.Validate(); err != nil {
+			return t, StatementReasonInvalidIRI, err
+		}
+	case BlankNode:
+		if t.ID == "" {
+			return t, StatementReasonInvalidBlankNode, nil
+		}
+	case Literal:
+		if !utf8.ValidString(t.Lexical) {
+			return t, StatementReasonInvalidLiteral, nil
+		}
+		if (t.Lang != "" || t.Direction != "") && (t.Lang == "" || !isValidLangTag(t.langTag())) {
+			return t, StatementReasonInvalidLanguage, nil
+		}
+		switch dt := t.Datatype.Value; {
+		case dt == "":
+		case t.Lang != "" && dt != rdfLangStringIRI && dt != rdfDirLangStringIRI,
+			t.Lang == "" && (dt == rdfLangStringIRI || dt == rdfDirLangStringIRI):
+			return t, StatementReasonInvalidDatatype, nil
+		default:
+			if err := t.Datatype.Validate(); err != nil {
+				return t, StatementReasonInvalidDatatype, err
+			}
+		}
+	case TripleTerm:
+		if _, ok := t.S.(Literal); ok || t.S == nil || t.P.Value == "" || t.O == nil {
+			return t, StatementReasonInvalidTermKind, nil
+		}
+		for _, term := range []Term{t.S, t.P, t.O} {
+			if term, reason, err := validateTerm(term); reason != "" {
+				return term, reason, err
+			}
+		}
+	}
+	return nil, "", nil
+}
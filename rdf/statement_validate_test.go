@@ -0,0 +1,79 @@
+package rdf
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestOptValidateOnWrite(t *testing.T) {
+	s := IRI{Value: "http://example.org/s"}
+	p := IRI{Value: "http://example.org/p"}
+	o := IRI{Value: "http://example.org/o"}
+	tests := []struct {
+		name     string
+		stmt     Statement
+		position string
+		reason   StatementErrorReason
+	}{
+		{"valid", Statement{S: s, P: p, O: Literal{Lexical: "x", Lang: "en", Direction: "ltr"}, G: BlankNode{ID: "g"}}, "", ""},
+		{"missing object", Statement{S: s, P: p}, "object", StatementReasonMissingTerm},
+		{"missing predicate", Statement{S: s, O: o}, "predicate", StatementReasonMissingTerm},
+		{"literal subject", Statement{S: Literal{Lexical: "x"}, P: p, O: o}, "subject", StatementReasonInvalidTermKind},
+		{"literal graph", Statement{S: s, P: p, O: o, G: Literal{Lexical: "g"}}, "graph", StatementReasonInvalidTermKind},
+		{"relative IRI", Statement{S: s, P: IRI{Value: "p"}, O: o}, "predicate", StatementReasonInvalidIRI},
+		{"IRI with space", Statement{S: s, P: p, O: IRI{Value: "http://example.org/a b"}}, "object", StatementReasonInvalidIRI},
+		{"empty blank node", Statement{S: BlankNode{}, P: p, O: o}, "subject", StatementReasonInvalidBlankNode},
+		{"invalid UTF-8", Statement{S: s, P: p, O: Literal{Lexical: "\xff"}}, "object", StatementReasonInvalidLiteral},
+		{"bad language tag", Statement{S: s, P: p, O: Literal{Lexical: "x", Lang: "en_GB"}}, "object", StatementReasonInvalidLanguage},
+		{"direction without language", Statement{S: s, P: p, O: Literal{Lexical: "x", Direction: "ltr"}}, "object", StatementReasonInvalidLanguage},
+		{"relative datatype", Statement{S: s, P: p, O: Literal{Lexical: "1", Datatype: IRI{Value: "integer"}}}, "object", StatementReasonInvalidDatatype},
+		{"language with datatype", Statement{S: s, P: p, O: Literal{Lexical: "1", Lang: "en", Datatype: IRI{Value: xsdNamespace + "integer"}}}, "object", StatementReasonInvalidDatatype},
+		{"langString without language", Statement{S: s, P: p, O: Literal{Lexical: "x", Datatype: IRI{Value: rdfLangStringIRI}}}, "object", StatementReasonInvalidDatatype},
+		{"literal in triple term subject", Statement{S: s, P: p, O: TripleTerm{S: Literal{Lexical: "x"}, P: p, O: o}}, "object", StatementReasonInvalidTermKind},
+		{"invalid IRI in triple term", Statement{S: s, P: p, O: TripleTerm{S: s, P: p, O: IRI{Value: "o"}}}, "object", StatementReasonInvalidIRI},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, FormatNQuads, OptValidateOnWrite())
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = w.Write(tt.stmt)
+			if closeErr := w.Close(); closeErr != nil {
+				t.Fatal(closeErr)
+			}
+			if tt.reason == "" {
+				if err != nil || buf.Len() == 0 {
+					t.Fatalf("valid statement: err %v, output %q", err, buf.String())
+				}
+				return
+			}
+			var stmtErr *StatementError
+			if !errors.As(err, &stmtErr) {
+				t.Fatalf("expected *StatementError, got %v", err)
+			}
+			if stmtErr.Position != tt.position || stmtErr.Reason != tt.reason {
+				t.Fatalf("got %s %s, want %s %s (%v)", stmtErr.Position, stmtErr.Reason, tt.position, tt.reason, err)
+			}
+			if Code(err) != ErrCodeInvalidStatement {
+				t.Fatalf("Code = %s, want %s", Code(err), ErrCodeInvalidStatement)
+			}
+			if buf.Len() != 0 {
+				t.Fatalf("invalid statement written: %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestStatementErrorUnwrapsIRIError(t *testing.T) {
+	err := validateStatement(Statement{S: IRI{Value: "s"}, P: IRI{Value: "http://example.org/p"}, O: IRI{Value: "http://example.org/o"}}, false)
+	var iriErr *IRIError
+	if !errors.As(err, &iriErr) || iriErr.Reason != IRIReasonMissingScheme {
+		t.Fatalf("expected a wrapped *IRIError, got %v", err)
+	}
+	if want := "rdf: invalid statement: subject <s>: invalid IRI <s>: missing scheme at offset 0"; err.Error() != want {
+		t.Fatalf("got %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,285 @@
+package rdf
+
+import (
+	"cmp"
+	"encoding/binary"
+	"hash/maphash"
+	"strconv"
+	"strings"
+)
+
+// TermEqual reports whether a and b are the same RDF term. Literals are
+// equal if their lexical forms are equal and either their language tags are
+// equal ignoring ASCII case and their base directions are equal, or neither
+// has a language tag and their datatypes are equal; a literal without a
+// datatype or language tag equals the same literal typed xsd:string. Nil
+// terms are equal to each other only.
+//
+// Term implementations of other packages are equal when their Kind and
+// String are, and never equal to terms of this package. Unlike comparing
+// String forms, TermEqual does not allocate for terms of this package.
+func TermEqual(a, b Term) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case IRI:
+		b, ok := b.(IRI)
+		return ok && a.Value == b.Value
+	case BlankNode:
+		b, ok := b.(BlankNode)
+		return ok && a.ID == b.ID
+	case Literal:
+		b, ok := b.(Literal)
+		return ok && literalEqual(a, b)
+	case TripleTerm:
+		b, ok := b.(TripleTerm)
+		return ok && a.P.Value == b.P.Value && TermEqual(a.S, b.S) && TermEqual(a.O, b.O)
+	}
+	return b != nil && !isModelTerm(b) && a.Kind() == b.Kind() && a.String() == b.String()
+}
+
+// TermCompare orders terms as SPARQL ORDER BY does, returning -1, 0 or +1:
+// nil (unbound) first, then blank nodes, IRIs, literals and triple terms.
+// IRIs are ordered by code point, blank nodes by identifier, and triple
+// terms by subject, predicate and object.
+//
+// Literals of the XSD numeric datatypes with valid lexical forms come first,
+// ordered by value. Other literals are ordered by lexical form, then simple
+// literals before language-tagged ones before other datatypes, then by
+// language tag, base direction and datatype IRI. Numeric ties are broken the
+// same way, so TermCompare is a total order that returns 0 exactly when
+// TermEqual reports true, and can sort and merge terms.
+//
+// Term implementations of other packages follow the terms of this package of
+// the same kind and are ordered by String.
+func TermCompare(a, b Term) int {
+	if c := cmp.Compare(termRank(a), termRank(b)); c != 0 {
+		return c
+	}
+	switch a := a.(type) {
+	case nil:
+		return 0
+	case IRI:
+		if b, ok := b.(IRI); ok {
+			return strings.Compare(a.Value, b.Value)
+		}
+	case BlankNode:
+		if b, ok := b.(BlankNode); ok {
+			return strings.Compare(a.ID, b.ID)
+		}
+	case Literal:
+		if b, ok := b.(Literal); ok {
+			return literalCompare(a, b)
+		}
+	case TripleTerm:
+		if b, ok := b.(TripleTerm); ok {
+			if c := TermCompare(a.S, b.S); c != 0 {
+				return c
+			}
+			if c := strings.Compare(a.P.Value, b.P.Value); c != 0 {
+				return c
+			}
+			return TermCompare(a.O, b.O)
+		}
+	}
+	switch {
+	case isModelTerm(a):
+		return -1
+	case isModelTerm(b):
+		return 1
+	}
+	if c := cmp.Compare(a.Kind(), b.Kind()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.String(), b.String())
+}
+
+// TermHash returns a hash of t with the given seed that is consistent with
+// TermEqual: equal terms have equal hashes. It hashes the fields of terms of
+// this package in place, without allocating, and the Kind and String of
+// other terms.
+func TermHash(t Term, seed maphash.Seed) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	hashTerm(&h, t)
+	return h.Sum64()
+}
+
+// hashTerm writes t to h as a kind byte followed by length-prefixed fields,
+// so that different terms write different byte sequences.
+func hashTerm(h *maphash.Hash, t Term) {
+	switch t := t.(type) {
+	case nil:
+		h.WriteByte(0)
+	case IRI:
+		h.WriteByte('I')
+		hashField(h, t.Value)
+	case BlankNode:
+		h.WriteByte('B')
+		hashField(h, t.ID)
+	case Literal:
+		h.WriteByte('L')
+		hashField(h, t.Lexical)
+		if t.Lang != "" {
+			// Language tags are hashed in lower case.
+			writeLength(h, len(t.Lang))
+			for i := 0; i < len(t.Lang); i++ {
+				h.WriteByte(lowerASCII(t.Lang[i]))
+			}
+			hashField(h, t.Direction)
+			return
+		}
+		hashField(h, literalDatatype(t))
+	case TripleTerm:
+		h.WriteByte('T')
+		hashTerm(h, t.S)
+		hashField(h, t.P.Value)
+		hashTerm(h, t.O)
+	default:
+		h.WriteByte('X')
+		h.WriteByte(byte(t.Kind()))
+		hashField(h, t.String())
+	}
+}
+
+func hashField(h *maphash.Hash, s string) {
+	writeLength(h, len(s))
+	h.WriteString(s)
+}
+
+func writeLength(h *maphash.Hash, n int) {
+	var buf [binary.MaxVarintLen64]byte
+	h.Write(binary.AppendUvarint(buf[:0], uint64(n)))
+}
+
+// termRank returns the position of the kind of t in the SPARQL order.
+func termRank(t Term) int {
+	if t == nil {
+		return 0
+	}
+	switch t.Kind() {
+	case TermBlankNode:
+		return 1
+	case TermIRI:
+		return 2
+	case TermLiteral:
+		return 3
+	case TermTriple:
+		return 4
+	}
+	return 5
+}
+
+// isModelTerm reports whether t is one of the Term types of this package.
+func isModelTerm(t Term) bool {
+	switch t.(type) {
+	case IRI, BlankNode, Literal, TripleTerm:
+		return true
+	}
+	return false
+}
+
+// literalCompare implements TermCompare for literals.
+func literalCompare(a, b Literal) int {
+	x, aNumeric := literalNumber(a)
+	y, bNumeric := literalNumber(b)
+	switch {
+	case aNumeric && bNumeric:
+		if c := cmp.Compare(x, y); c != 0 {
+			return c
+		}
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+	if c := strings.Compare(a.Lexical, b.Lexical); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(literalRank(a), literalRank(b)); c != 0 {
+		return c
+	}
+	if c := compareFoldASCII(a.Lang, b.Lang); c != 0 {
+		return c
+	}
+	if a.Lang != "" {
+		return strings.Compare(a.Direction, b.Direction)
+	}
+	return strings.Compare(literalDatatype(a), literalDatatype(b))
+}
+
+// literalEqual implements TermEqual for literals.
+func literalEqual(a, b Literal) bool {
+	if a.Lexical != b.Lexical || (a.Lang == "") != (b.Lang == "") {
+		return false
+	}
+	if a.Lang != "" {
+		return compareFoldASCII(a.Lang, b.Lang) == 0 && a.Direction == b.Direction
+	}
+	return literalDatatype(a) == literalDatatype(b)
+}
+
+// literalRank orders simple literals before language-tagged literals
+// before literals with other datatypes.
+func literalRank(l Literal) int {
+	switch {
+	case l.Lang != "":
+		return 1
+	case literalDatatype(l) == xsdNamespace+"string":
+		return 0
+	}
+	return 2
+}
+
+// literalDatatype returns the datatype IRI of a literal without a language
+// tag, xsd:string if it has none.
+func literalDatatype(l Literal) string {
+	if l.Datatype.Value == "" {
+		return xsdNamespace + "string"
+	}
+	return l.Datatype.Value
+}
+
+// literalNumber returns the value of a literal of an XSD numeric datatype,
+// and false for other literals and invalid lexical forms.
+func literalNumber(l Literal) (float64, bool) {
+	local, ok := strings.CutPrefix(l.Datatype.Value, xsdNamespace)
+	if !ok || l.Lang != "" {
+		return 0, false
+	}
+	switch local {
+	case "integer", "long", "int", "short", "byte",
+		"nonNegativeInteger", "positiveInteger", "nonPositiveInteger", "negativeInteger",
+		"unsignedLong", "unsignedInt", "unsignedShort", "unsignedByte":
+		_, _, ok = splitXSDInteger(l.Lexical)
+	case "decimal":
+		ok = isXSDDecimal(l.Lexical)
+	case "float", "double":
+		ok = isXSDDouble(l.Lexical)
+	default:
+		return 0, false
+	}
+	if !ok {
+		return 0, false
+	}
+	// Values beyond the float64 range become infinities.
+	v, _ := strconv.ParseFloat(l.Lexical, 64)
+	return v, true
+}
+
+// compareFoldASCII compares a and b ignoring ASCII case.
+func compareFoldASCII(a, b string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := cmp.Compare(lowerASCII(a[i]), lowerASCII(b[i])); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
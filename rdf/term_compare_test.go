@@ -0,0 +1,124 @@
+package rdf
+
+import (
+	"hash/maphash"
+	"slices"
+	"testing"
+)
+
+// fakeTerm is a Term implementation of another package.
+type fakeTerm string
+
+func (f fakeTerm) Kind() TermKind { return TermIRI }
+func (f fakeTerm) String() string { return string(f) }
+
+func TestTermEqual(t *testing.T) {
+	xsdInt := IRI{Value: xsdNamespace + "integer"}
+	tt := TripleTerm{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}}
+	equal := [][2]Term{
+		{nil, nil},
+		{IRI{Value: "http://example.org/a"}, IRI{Value: "http://example.org/a"}},
+		{BlankNode{ID: "b"}, BlankNode{ID: "b"}},
+		{Literal{Lexical: "x"}, Literal{Lexical: "x", Datatype: IRI{Value: xsdNamespace + "string"}}},
+		{Literal{Lexical: "x", Lang: "en-GB"}, Literal{Lexical: "x", Lang: "en-gb"}},
+		{Literal{Lexical: "x", Lang: "ar", Direction: "rtl"}, Literal{Lexical: "x", Lang: "AR", Direction: "rtl"}},
+		{tt, TripleTerm{S: tt.S, P: tt.P, O: Literal{Lexical: "o", Datatype: IRI{Value: xsdNamespace + "string"}}}},
+		{fakeTerm("http://example.org/a"), fakeTerm("http://example.org/a")},
+	}
+	different := [][2]Term{
+		{nil, IRI{}},
+		{IRI{Value: "http://example.org/a"}, IRI{Value: "http://example.org/b"}},
+		{IRI{Value: "b"}, BlankNode{ID: "b"}},
+		{Literal{Lexical: "1", Datatype: xsdInt}, Literal{Lexical: "01", Datatype: xsdInt}},
+		{Literal{Lexical: "1", Datatype: xsdInt}, Literal{Lexical: "1"}},
+		{Literal{Lexical: "x", Lang: "en"}, Literal{Lexical: "x"}},
+		{Literal{Lexical: "x", Lang: "ar", Direction: "rtl"}, Literal{Lexical: "x", Lang: "ar"}},
+		{tt, TripleTerm{S: tt.S, P: tt.P, O: Literal{Lexical: "p"}}},
+		{IRI{Value: "http://example.org/a"}, fakeTerm("http://example.org/a")},
+	}
+	seed := maphash.MakeSeed()
+	for _, pair := range equal {
+		a, b := pair[0], pair[1]
+		if !TermEqual(a, b) || !TermEqual(b, a) {
+			t.Errorf("TermEqual(%v, %v) = false, want true", a, b)
+		}
+		if TermCompare(a, b) != 0 || TermCompare(b, a) != 0 {
+			t.Errorf("TermCompare(%v, %v) = %d, want 0", a, b, TermCompare(a, b))
+		}
+		if TermHash(a, seed) != TermHash(b, seed) {
+			t.Errorf("TermHash(%v) != TermHash(%v)", a, b)
+		}
+	}
+	for _, pair := range different {
+		a, b := pair[0], pair[1]
+		if TermEqual(a, b) || TermEqual(b, a) {
+			t.Errorf("TermEqual(%v, %v) = true, want false", a, b)
+		}
+		if c := TermCompare(a, b); c == 0 || TermCompare(b, a) != -c {
+			t.Errorf("TermCompare(%v, %v) = %d, reversed %d", a, b, c, TermCompare(b, a))
+		}
+		if TermHash(a, seed) == TermHash(b, seed) {
+			t.Errorf("TermHash(%v) == TermHash(%v)", a, b)
+		}
+	}
+}
+
+func TestTermCompareOrder(t *testing.T) {
+	xsd := func(local string) IRI { return IRI{Value: xsdNamespace + local} }
+	want := []Term{
+		nil,
+		BlankNode{ID: "a"},
+		BlankNode{ID: "b"},
+		IRI{Value: "http://example.org/a"},
+		IRI{Value: "http://example.org/é"},
+		fakeTerm("http://example.org/0"),
+		Literal{Lexical: "-INF", Datatype: xsd("double")},
+		Literal{Lexical: "-5", Datatype: xsd("integer")},
+		Literal{Lexical: "1.5", Datatype: xsd("decimal")},
+		Literal{Lexical: "2", Datatype: xsd("int")},
+		Literal{Lexical: "2", Datatype: xsd("integer")},
+		Literal{Lexical: "10", Datatype: xsd("integer")},
+		Literal{Lexical: "1000.0", Datatype: xsd("double")},
+		Literal{Lexical: "1e3", Datatype: xsd("double")},
+		Literal{Lexical: "abc", Datatype: xsd("integer")},
+		Literal{Lexical: "apple"},
+		Literal{Lexical: "apple", Lang: "de"},
+		Literal{Lexical: "apple", Lang: "en"},
+		Literal{Lexical: "apple", Lang: "en", Direction: "ltr"},
+		Literal{Lexical: "apple", Datatype: xsd("token")},
+		Literal{Lexical: "banana"},
+		TripleTerm{S: BlankNode{ID: "s"}, P: IRI{Value: "http://example.org/p"}, O: IRI{Value: "http://example.org/o"}},
+		TripleTerm{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: IRI{Value: "http://example.org/o"}},
+	}
+	got := slices.Clone(want)
+	slices.Reverse(got)
+	slices.SortFunc(got, TermCompare)
+	for i := range want {
+		if !TermEqual(got[i], want[i]) {
+			t.Fatalf("sorted terms = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTermCompareAllocs(t *testing.T) {
+	seed := maphash.MakeSeed()
+	terms := []Term{
+		IRI{Value: "http://example.org/a"},
+		BlankNode{ID: "b"},
+		Literal{Lexical: "x", Lang: "en-GB", Direction: "ltr"},
+		Literal{Lexical: "42", Datatype: IRI{Value: xsdNamespace + "integer"}},
+		TripleTerm{S: BlankNode{ID: "s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}},
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, a := range terms {
+			TermHash(a, seed)
+			for _, b := range terms {
+				TermEqual(a, b)
+				TermCompare(a, b)
+			}
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("allocations per run = %v, want 0", allocs)
+	}
+}
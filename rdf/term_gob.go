@@ -0,0 +1,14 @@
+package rdf
+
+import "encoding/gob"
+
+// The terms and statements are encoded by encoding/gob with their
+// MarshalText methods. The term types are registered so that values of the
+// Term interface, such as the terms of a []Term or of a struct field of
+// type Term, can be sent as well.
+func init() {
+	gob.Register(IRI{})
+	gob.Register(BlankNode{})
+	gob.Register(Literal{})
+	gob.Register(TripleTerm{})
+}
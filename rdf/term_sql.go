@@ -0,0 +1,137 @@
+package rdf
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// The terms and statements implement sql.Scanner, and all but IRI, whose
+// Value field rules out a Value method, implement driver.Valuer, so that
+// they can be stored in text columns. Columns hold the canonical N-Triples
+// of OptCanonicalNTriples, so that equal terms by TermEqual are stored as
+// equal strings; reading a column gives a term equal by TermEqual to the
+// one stored. Zero values other than the empty literal are stored as NULL,
+// which reads back as the zero value. Use SQLTerm for IRIs and for columns
+// holding terms of any kind.
+
+// SQLTerm stores a term of any kind in a text column, as a driver.Valuer
+// and sql.Scanner. A nil Term is stored as NULL.
+type SQLTerm struct {
+	Term Term
+}
+
+// Value returns the canonical N-Triples of the term, or nil for a nil Term.
+func (t SQLTerm) Value() (driver.Value, error) {
+	if t.Term == nil {
+		return nil, nil
+	}
+	return canonicalTermValue(t.Term)
+}
+
+// Scan reads a term in N-Triples syntax, from a string or []byte, or nil.
+func (t *SQLTerm) Scan(src any) error { return scanTerm(src, &t.Term, "a term") }
+
+// Scan reads an IRI in N-Triples syntax, from a string or []byte, or nil.
+func (i *IRI) Scan(src any) error { return scanTerm(src, i, "an IRI") }
+
+// Value returns the blank node in N-Triples syntax, or nil for the zero
+// BlankNode.
+func (b BlankNode) Value() (driver.Value, error) {
+	if b.ID == "" {
+		return nil, nil
+	}
+	return canonicalTermValue(b)
+}
+
+// Scan reads a blank node in N-Triples syntax, from a string or []byte, or
+// nil.
+func (b *BlankNode) Scan(src any) error { return scanTerm(src, b, "a blank node") }
+
+// Value returns the literal in canonical N-Triples syntax.
+func (l Literal) Value() (driver.Value, error) { return canonicalTermValue(l) }
+
+// Scan reads a literal in N-Triples syntax, from a string or []byte. NULL
+// reads as the zero Literal, the empty string literal.
+func (l *Literal) Scan(src any) error { return scanTerm(src, l, "a literal") }
+
+// Value returns the triple term in canonical N-Triples syntax, or nil for
+// the zero TripleTerm.
+func (t TripleTerm) Value() (driver.Value, error) {
+	if t.S == nil && t.P.Value == "" && t.O == nil {
+		return nil, nil
+	}
+	return canonicalTermValue(t)
+}
+
+// Scan reads a triple term in N-Triples syntax, from a string or []byte, or
+// nil.
+func (t *TripleTerm) Scan(src any) error { return scanTerm(src, t, "a triple term") }
+
+// Value returns the statement as a canonical N-Quads line without its line
+// break, or nil for the zero Statement. Predicates whose IRI starts with
+// "_:" are written as blank nodes, as with OptGeneralizedRDF.
+func (s Statement) Value() (driver.Value, error) {
+	if s.S == nil && s.P.Value == "" && s.O == nil && s.G == nil {
+		return nil, nil
+	}
+	if s.S == nil || s.P.Value == "" || s.O == nil {
+		return nil, fmt.Errorf("rdf: cannot store statement without subject, predicate or object")
+	}
+	buf, err := appendCanonicalStatement(nil, s, true)
+	if err != nil {
+		return nil, err
+	}
+	return string(buf), nil
+}
+
+// Scan reads an N-Quads line as UnmarshalText does, from a string or
+// []byte, or nil.
+func (s *Statement) Scan(src any) error {
+	text, ok, err := scanText(src)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*s = Statement{}
+		return nil
+	}
+	return s.UnmarshalText([]byte(text))
+}
+
+// canonicalTermValue returns t in canonical N-Triples syntax as a string.
+func canonicalTermValue(t Term) (driver.Value, error) {
+	buf, err := appendCanonicalTerm(nil, t)
+	if err != nil {
+		return nil, err
+	}
+	return string(buf), nil
+}
+
+// scanTerm reads the term of type T in the column value src into dst, the
+// zero value for NULL; kind names the term kind for errors.
+func scanTerm[T Term](src any, dst *T, kind string) error {
+	text, ok, err := scanText(src)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		var zero T
+		*dst = zero
+		return nil
+	}
+	return unmarshalTermText([]byte(text), dst, kind)
+}
+
+// scanText returns the text of the column value src and whether it is not
+// NULL. Column values other than strings and byte slices fail.
+func scanText(src any) (string, bool, error) {
+	switch src := src.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return src, true, nil
+	case []byte:
+		return string(src), true, nil
+	}
+	return "", false, fmt.Errorf("rdf: cannot scan %T into an RDF term", src)
+}
@@ -0,0 +1,91 @@
+package rdf
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestTermSQLValues(t *testing.T) {
+	alice := IRI{Value: "http://example.org/alice"}
+	label := Literal{Lexical: "hi", Lang: "en-GB"}
+	triple := TripleTerm{S: BlankNode{ID: "b0"}, P: alice, O: label}
+	for _, tt := range []struct {
+		valuer driver.Valuer
+		want   driver.Value
+	}{
+		{SQLTerm{Term: alice}, "<http://example.org/alice>"},
+		{SQLTerm{}, nil},
+		{BlankNode{ID: "b0"}, "_:b0"},
+		{BlankNode{}, nil},
+		{label, `"hi"@en-gb`},
+		{Literal{Lexical: "x", Datatype: IRI{Value: xsdNamespace + "string"}}, `"x"`},
+		{triple, `<<( _:b0 <http://example.org/alice> "hi"@en-gb )>>`},
+		{TripleTerm{}, nil},
+		{NewQuad(alice, IRI{Value: "_:p"}, label, BlankNode{ID: "g"}), `<http://example.org/alice> _:p "hi"@en-gb _:g .`},
+		{Statement{}, nil},
+	} {
+		if got, err := tt.valuer.Value(); err != nil || got != tt.want {
+			t.Errorf("Value(%#v) = %v, %v, want %v", tt.valuer, got, err, tt.want)
+		}
+	}
+
+	var term SQLTerm
+	if err := term.Scan([]byte(`"hi"@en-gb`)); err != nil || !TermEqual(term.Term, label) {
+		t.Fatalf("SQLTerm.Scan: %v, %v", term.Term, err)
+	}
+	if err := term.Scan(nil); err != nil || term.Term != nil {
+		t.Fatalf("SQLTerm.Scan(nil): %v, %v", term.Term, err)
+	}
+	var iri IRI
+	if err := iri.Scan("<http://example.org/alice>"); err != nil || iri != alice {
+		t.Fatalf("IRI.Scan: %v, %v", iri, err)
+	}
+	if err := iri.Scan(42); err == nil || iri != alice {
+		t.Fatalf("IRI.Scan(42): %v, %v", iri, err)
+	}
+	if err := iri.Scan(`"x"`); !errors.Is(err, ErrInvalidTerm) {
+		t.Fatalf("IRI.Scan of a literal: %v", err)
+	}
+	var gotTriple TripleTerm
+	if err := gotTriple.Scan(`<<( _:b0 <http://example.org/alice> "hi"@en-gb )>>`); err != nil || !TermEqual(gotTriple, triple) {
+		t.Fatalf("TripleTerm.Scan: %v, %v", gotTriple, err)
+	}
+	var stmt Statement
+	if err := stmt.Scan(`<http://example.org/alice> _:p "hi"@en-gb _:g .`); err != nil || stmt.P.Value != "_:p" || !TermEqual(stmt.G, BlankNode{ID: "g"}) {
+		t.Fatalf("Statement.Scan: %#v, %v", stmt, err)
+	}
+	if err := stmt.Scan(nil); err != nil || stmt != (Statement{}) {
+		t.Fatalf("Statement.Scan(nil): %#v, %v", stmt, err)
+	}
+}
+
+func TestTermGob(t *testing.T) {
+	type message struct {
+		Terms      []Term
+		Statements []Statement
+	}
+	sent := message{
+		Terms: []Term{IRI{Value: "http://example.org/a"}, BlankNode{ID: "b0"}, Literal{Lexical: "chat", Lang: "fr"},
+			TripleTerm{S: BlankNode{ID: "b0"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "1"}}},
+		Statements: []Statement{NewQuad(BlankNode{ID: "b0"}, IRI{Value: "http://example.org/p"}, Literal{Lexical: "o"}, IRI{Value: "http://example.org/g"})},
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(sent); err != nil {
+		t.Fatal(err)
+	}
+	var received message
+	if err := gob.NewDecoder(&buf).Decode(&received); err != nil {
+		t.Fatal(err)
+	}
+	if len(received.Terms) != len(sent.Terms) || len(received.Statements) != 1 || !statementEqual(received.Statements[0], sent.Statements[0]) {
+		t.Fatalf("received %#v", received)
+	}
+	for i := range sent.Terms {
+		if !TermEqual(received.Terms[i], sent.Terms[i]) {
+			t.Errorf("term %d: got %#v, want %#v", i, received.Terms[i], sent.Terms[i])
+		}
+	}
+}
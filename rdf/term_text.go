@@ -0,0 +1,190 @@
+package rdf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The terms and statements implement encoding.TextMarshaler and
+// encoding.TextUnmarshaler with the N-Triples syntax of RDF 1.2, so that
+// they can be used in JSON and YAML documents, logs and flag values. Unlike
+// the canonical N-Quads of OptCanonicalNTriples, language tags keep their
+// case and xsd:string datatypes are written, so that text read back gives
+// the same term. The zero IRI, BlankNode, TripleTerm and Statement are
+// written as "", which reads back as the zero value. Errors reading text
+// match ErrInvalidTerm.
+
+// MarshalText returns the IRI in N-Triples syntax, as in "<http://a.example/>".
+func (i IRI) MarshalText() ([]byte, error) {
+	if i.Value == "" {
+		return []byte{}, nil
+	}
+	return appendCanonicalIRI(nil, i.Value), nil
+}
+
+// UnmarshalText reads an absolute IRI in N-Triples syntax.
+func (i *IRI) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*i = IRI{}
+		return nil
+	}
+	return unmarshalTermText(text, i, "an IRI")
+}
+
+// MarshalText returns the blank node in N-Triples syntax, as in "_:b0".
+func (b BlankNode) MarshalText() ([]byte, error) {
+	if b.ID == "" {
+		return []byte{}, nil
+	}
+	return []byte("_:" + b.ID), nil
+}
+
+// UnmarshalText reads a blank node in N-Triples syntax.
+func (b *BlankNode) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*b = BlankNode{}
+		return nil
+	}
+	return unmarshalTermText(text, b, "a blank node")
+}
+
+// MarshalText returns the literal in N-Triples syntax, as in "\"chat\"@fr".
+func (l Literal) MarshalText() ([]byte, error) {
+	return appendTermText(nil, l)
+}
+
+// UnmarshalText reads a literal in N-Triples syntax.
+func (l *Literal) UnmarshalText(text []byte) error {
+	return unmarshalTermText(text, l, "a literal")
+}
+
+// MarshalText returns the triple term in N-Triples syntax, as in
+// "<<( _:b0 <http://a.example/p> \"o\" )>>". Terms of other packages in it
+// fail.
+func (t TripleTerm) MarshalText() ([]byte, error) {
+	if t.S == nil && t.P.Value == "" && t.O == nil {
+		return []byte{}, nil
+	}
+	return appendTermText(nil, t)
+}
+
+// UnmarshalText reads a triple term in N-Triples syntax.
+func (t *TripleTerm) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = TripleTerm{}
+		return nil
+	}
+	return unmarshalTermText(text, t, "a triple term")
+}
+
+// MarshalText returns the statement as an N-Quads line without its line
+// break, as in "_:b0 <http://a.example/p> \"o\" <http://a.example/g> .".
+// Predicates whose IRI starts with "_:" are written as blank nodes, as with
+// OptGeneralizedRDF.
+func (s Statement) MarshalText() ([]byte, error) {
+	if s.S == nil && s.P.Value == "" && s.O == nil && s.G == nil {
+		return []byte{}, nil
+	}
+	if s.S == nil || s.P.Value == "" || s.O == nil {
+		return nil, fmt.Errorf("rdf: cannot marshal statement without subject, predicate or object")
+	}
+	buf, err := appendTermText(nil, s.S)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(s.P.Value, "_:") {
+		buf = append(append(buf, ' '), s.P.Value...)
+	} else {
+		buf = appendCanonicalIRI(append(buf, ' '), s.P.Value)
+	}
+	if buf, err = appendTermText(append(buf, ' '), s.O); err != nil {
+		return nil, err
+	}
+	if s.G != nil {
+		if buf, err = appendTermText(append(buf, ' '), s.G); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, " ."...), nil
+}
+
+// UnmarshalText reads an N-Quads line. Generalized statements, such as
+// those with a literal subject or a blank node predicate, are accepted as
+// with OptGeneralizedRDF.
+func (s *Statement) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = Statement{}
+		return nil
+	}
+	cursor := &ntCursor{input: string(text), generalized: true}
+	subject, predicate, object, err := parseNTCore(cursor, "N-Quads")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTerm, err)
+	}
+	var graph Term
+	if cursor.skipWS(); cursor.pos < len(cursor.input) && cursor.input[cursor.pos] != '.' {
+		if graph, err = cursor.parseTerm(true); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTerm, err)
+		}
+		if _, ok := graph.(TripleTerm); ok {
+			return fmt.Errorf("%w: triple term graph name in %q", ErrInvalidTerm, text)
+		}
+	}
+	if !cursor.consume('.') {
+		return fmt.Errorf("%w: missing '.' at end of statement %q", ErrInvalidTerm, text)
+	}
+	if cursor.skipWS(); cursor.pos < len(cursor.input) {
+		return fmt.Errorf("%w: text after statement in %q", ErrInvalidTerm, text)
+	}
+	*s = Statement{S: subject, P: predicate, O: object, G: graph}
+	return nil
+}
+
+// appendTermText appends t in N-Triples syntax, as appendCanonicalTerm does
+// but keeping the case of language tags and xsd:string datatypes.
+func appendTermText(buf []byte, t Term) ([]byte, error) {
+	switch t := t.(type) {
+	case Literal:
+		buf = appendCanonicalString(buf, t.Lexical)
+		switch {
+		case t.Lang != "":
+			buf = append(append(buf, '@'), t.langTag()...)
+		case t.Datatype.Value != "":
+			buf = appendCanonicalIRI(append(buf, "^^"...), t.Datatype.Value)
+		}
+		return buf, nil
+	case TripleTerm:
+		if t.S == nil || t.P.Value == "" || t.O == nil {
+			return nil, fmt.Errorf("rdf: cannot marshal triple term without subject, predicate or object")
+		}
+		var err error
+		if buf, err = appendTermText(append(buf, "<<( "...), t.S); err != nil {
+			return nil, err
+		}
+		buf = appendCanonicalIRI(append(buf, ' '), t.P.Value)
+		if buf, err = appendTermText(append(buf, ' '), t.O); err != nil {
+			return nil, err
+		}
+		return append(buf, " )>>"...), nil
+	}
+	return appendCanonicalTerm(buf, t)
+}
+
+// unmarshalTermText reads the term of type T in text into dst; kind names
+// the term kind for errors.
+func unmarshalTermText[T Term](text []byte, dst *T, kind string) error {
+	cursor := &ntCursor{input: string(text)}
+	term, err := cursor.parseTerm(true)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTerm, err)
+	}
+	if cursor.skipWS(); cursor.pos < len(cursor.input) {
+		return fmt.Errorf("%w: text after term in %q", ErrInvalidTerm, text)
+	}
+	value, ok := term.(T)
+	if !ok {
+		return fmt.Errorf("%w: %q is not %s", ErrInvalidTerm, text, kind)
+	}
+	*dst = value
+	return nil
+}
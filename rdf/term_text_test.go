@@ -0,0 +1,105 @@
+package rdf
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestTermTextRoundTrip(t *testing.T) {
+	alice := IRI{Value: "http://example.org/alice"}
+	knows := IRI{Value: "http://example.org/knows"}
+	label := Literal{Lexical: "say \"hi\"\n\\", Lang: "en-GB", Direction: "ltr"}
+	typed := Literal{Lexical: "x", Datatype: IRI{Value: xsdNamespace + "string"}}
+	triple := TripleTerm{S: BlankNode{ID: "b0"}, P: knows, O: TripleTerm{S: alice, P: knows, O: label}}
+
+	tests := []struct {
+		value encodingTextValue
+		text  string
+	}{
+		{&alice, "<http://example.org/alice>"},
+		{&BlankNode{ID: "b0"}, "_:b0"},
+		{&label, `"say \"hi\"\n\\"@en-GB--ltr`},
+		{&typed, `"x"^^<http://www.w3.org/2001/XMLSchema#string>`},
+		{&Literal{}, `""`},
+		{&triple, `<<( _:b0 <http://example.org/knows> <<( <http://example.org/alice> <http://example.org/knows> "say \"hi\"\n\\"@en-GB--ltr )>> )>>`},
+		{&IRI{}, ""},
+	}
+	for _, tt := range tests {
+		text, err := tt.value.MarshalText()
+		if err != nil || string(text) != tt.text {
+			t.Fatalf("MarshalText(%#v) = %s, %v, want %s", tt.value, text, err, tt.text)
+		}
+	}
+
+	var gotIRI IRI
+	var gotLabel, gotTyped Literal
+	var gotTriple TripleTerm
+	for _, tt := range []struct {
+		text string
+		dst  encodingTextValue
+	}{{tests[0].text, &gotIRI}, {tests[2].text, &gotLabel}, {tests[3].text, &gotTyped}, {tests[5].text, &gotTriple}} {
+		if err := tt.dst.UnmarshalText([]byte(tt.text)); err != nil {
+			t.Fatalf("UnmarshalText(%s): %v", tt.text, err)
+		}
+	}
+	if gotIRI != alice || gotLabel != label || gotTyped != typed || !TermEqual(gotTriple, triple) {
+		t.Fatalf("round trip: %#v %#v %#v %#v", gotIRI, gotLabel, gotTyped, gotTriple)
+	}
+
+	stmts := []Statement{
+		NewTriple(alice, knows, label),
+		NewQuad(BlankNode{ID: "b0"}, knows, triple, IRI{Value: "http://example.org/g"}),
+		NewTriple(Literal{Lexical: "s"}, IRI{Value: "_:p"}, alice),
+	}
+	data, err := json.Marshal(stmts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []Statement
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", data, err)
+	}
+	if len(decoded) != len(stmts) {
+		t.Fatalf("decoded %d statements", len(decoded))
+	}
+	for i := range stmts {
+		if !statementEqual(decoded[i], stmts[i]) {
+			t.Errorf("statement %d: got %#v, want %#v", i, decoded[i], stmts[i])
+		}
+	}
+}
+
+type encodingTextValue interface {
+	MarshalText() ([]byte, error)
+	UnmarshalText([]byte) error
+}
+
+func TestTermTextErrors(t *testing.T) {
+	var iri IRI
+	var blank BlankNode
+	var lit Literal
+	var stmt Statement
+	for _, tt := range []struct {
+		dst  encodingTextValue
+		text string
+	}{
+		{&iri, "<relative>"},
+		{&iri, "_:b0"},
+		{&iri, "<http://example.org/a> x"},
+		{&blank, "<http://example.org/a>"},
+		{&lit, `"x"@`},
+		{&stmt, "<http://example.org/a> <http://example.org/b> <http://example.org/c>"},
+		{&stmt, "<http://example.org/a> <http://example.org/b> <http://example.org/c> . ."},
+	} {
+		if err := tt.dst.UnmarshalText([]byte(tt.text)); !errors.Is(err, ErrInvalidTerm) {
+			t.Errorf("UnmarshalText(%s) into %T: %v", tt.text, tt.dst, err)
+		}
+	}
+	if iri != (IRI{}) {
+		t.Errorf("failed UnmarshalText changed the IRI to %v", iri)
+	}
+	if _, err := (Statement{S: IRI{Value: "http://example.org/a"}}).MarshalText(); err == nil {
+		t.Error("MarshalText accepted a statement without predicate and object")
+	}
+}
@@ -0,0 +1,109 @@
+package rdf
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ErrInvalidTerm indicates that a term constructor such as NewIRI or
+// NewLangLiteral was given data that does not form a valid RDF term.
+var ErrInvalidTerm = errors.New("rdf: invalid term")
+
+// NewIRI returns the IRI s, checking that it is an absolute RFC 3987 IRI as
+// IRI.Validate does. The error matches ErrInvalidTerm and wraps the
+// *IRIError.
+func NewIRI(s string) (IRI, error) {
+	iri := IRI{Value: s}
+	if err := iri.Validate(); err != nil {
+		return IRI{}, fmt.Errorf("%w: %w", ErrInvalidTerm, err)
+	}
+	return iri, nil
+}
+
+// MustIRI is like NewIRI but panics if s is not a valid IRI. It simplifies
+// the initialization of variables holding well-known IRIs.
+func MustIRI(s string) IRI {
+	return must(NewIRI(s))
+}
+
+// NewBlankNode returns the blank node labeled id, given without "_:",
+// checking that id is a valid N-Triples and Turtle blank node label.
+func NewBlankNode(id string) (BlankNode, error) {
+	if !isBlankNodeLabel(id) {
+		return BlankNode{}, fmt.Errorf("%w: invalid blank node label %q", ErrInvalidTerm, id)
+	}
+	return BlankNode{ID: id}, nil
+}
+
+// MustBlankNode is like NewBlankNode but panics if id is not a valid label.
+func MustBlankNode(id string) BlankNode {
+	return must(NewBlankNode(id))
+}
+
+// NewLangLiteral returns a language-tagged string. lang is a BCP 47
+// language tag, optionally followed by an RDF 1.2 base direction as in
+// "ar--rtl", which gives the literal its Direction.
+func NewLangLiteral(lexical, lang string) (Literal, error) {
+	if !utf8.ValidString(lexical) {
+		return Literal{}, fmt.Errorf("%w: lexical form %q is not valid UTF-8", ErrInvalidTerm, lexical)
+	}
+	if !isValidLangTag(lang) {
+		return Literal{}, fmt.Errorf("%w: invalid language tag %q", ErrInvalidTerm, lang)
+	}
+	lang, direction := splitLangDirection(lang)
+	return Literal{Lexical: lexical, Lang: lang, Direction: direction}, nil
+}
+
+// MustLangLiteral is like NewLangLiteral but panics if the literal is
+// invalid.
+func MustLangLiteral(lexical, lang string) Literal {
+	return must(NewLangLiteral(lexical, lang))
+}
+
+// NewTypedLiteral returns a literal of the given datatype, checking that the
+// datatype is an absolute IRI other than rdf:langString and
+// rdf:dirLangString, which only language-tagged strings have. The lexical
+// form is not checked against the datatype.
+func NewTypedLiteral(lexical string, datatype IRI) (Literal, error) {
+	if !utf8.ValidString(lexical) {
+		return Literal{}, fmt.Errorf("%w: lexical form %q is not valid UTF-8", ErrInvalidTerm, lexical)
+	}
+	if datatype.Value == rdfLangStringIRI || datatype.Value == rdfDirLangStringIRI {
+		return Literal{}, fmt.Errorf("%w: datatype <%s> requires a language tag", ErrInvalidTerm, datatype.Value)
+	}
+	if err := datatype.Validate(); err != nil {
+		return Literal{}, fmt.Errorf("%w: invalid datatype: %w", ErrInvalidTerm, err)
+	}
+	return Literal{Lexical: lexical, Datatype: datatype}, nil
+}
+
+// MustTypedLiteral is like NewTypedLiteral but panics if the literal is
+// invalid.
+func MustTypedLiteral(lexical string, datatype IRI) Literal {
+	return must(NewTypedLiteral(lexical, datatype))
+}
+
+func must[T Term](term T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return term
+}
+
+// isBlankNodeLabel reports whether id follows the BLANK_NODE_LABEL
+// production without its "_:".
+func isBlankNodeLabel(id string) bool {
+	if !isValidBlankNodeLabel(id) || !utf8.ValidString(id) {
+		return false
+	}
+	if c := id[0]; !isPNCharsBase(c) && !isDigit(c) && c != '_' {
+		return false
+	}
+	for i := 1; i < len(id); i++ {
+		if c := id[i]; !isPNChars(c) && c != '.' {
+			return false
+		}
+	}
+	return true
+}
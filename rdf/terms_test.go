@@ -0,0 +1,71 @@
+package rdf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTermConstructors(t *testing.T) {
+	if iri, err := NewIRI("http://example.org/a"); err != nil || iri.Value != "http://example.org/a" {
+		t.Fatalf("NewIRI: %v, %v", iri, err)
+	}
+	_, err := NewIRI("relative")
+	var iriErr *IRIError
+	if !errors.Is(err, ErrInvalidTerm) || !errors.As(err, &iriErr) || Code(err) != ErrCodeInvalidIRI {
+		t.Fatalf("NewIRI(relative): %v", err)
+	}
+
+	for _, id := range []string{"b1", "_x", "0", "a.b", "né"} {
+		if _, err := NewBlankNode(id); err != nil {
+			t.Errorf("NewBlankNode(%q): %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "-a", ".a", "a.", "a b", "a:b"} {
+		if _, err := NewBlankNode(id); !errors.Is(err, ErrInvalidTerm) {
+			t.Errorf("NewBlankNode(%q) accepted", id)
+		}
+	}
+
+	if lit, err := NewLangLiteral("مرحبا", "ar--rtl"); err != nil || lit != (Literal{Lexical: "مرحبا", Lang: "ar", Direction: "rtl"}) {
+		t.Fatalf("NewLangLiteral: %v, %v", lit, err)
+	}
+	for _, lang := range []string{"", "en_GB", "en--up"} {
+		if _, err := NewLangLiteral("x", lang); !errors.Is(err, ErrInvalidTerm) {
+			t.Errorf("NewLangLiteral(%q) accepted", lang)
+		}
+	}
+	if _, err := NewLangLiteral("\xff", "en"); !errors.Is(err, ErrInvalidTerm) {
+		t.Error("NewLangLiteral accepted invalid UTF-8")
+	}
+
+	integer := IRI{Value: xsdNamespace + "integer"}
+	if lit, err := NewTypedLiteral("1", integer); err != nil || lit != (Literal{Lexical: "1", Datatype: integer}) {
+		t.Fatalf("NewTypedLiteral: %v, %v", lit, err)
+	}
+	for _, datatype := range []IRI{{Value: "integer"}, {Value: rdfLangStringIRI}, {}} {
+		if _, err := NewTypedLiteral("1", datatype); !errors.Is(err, ErrInvalidTerm) {
+			t.Errorf("NewTypedLiteral with datatype %q accepted", datatype.Value)
+		}
+	}
+}
+
+func TestMustTermConstructorsPanic(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"MustIRI":          func() { MustIRI("relative") },
+		"MustBlankNode":    func() { MustBlankNode("") },
+		"MustLangLiteral":  func() { MustLangLiteral("x", "") },
+		"MustTypedLiteral": func() { MustTypedLiteral("x", IRI{Value: "integer"}) },
+	} {
+		func() {
+			defer func() {
+				if r, _ := recover().(error); !errors.Is(r, ErrInvalidTerm) {
+					t.Errorf("%s: expected a panic with ErrInvalidTerm, got %v", name, r)
+				}
+			}()
+			fn()
+		}()
+	}
+	if MustIRI("http://example.org/a").Value != "http://example.org/a" {
+		t.Fatal("MustIRI changed a valid IRI")
+	}
+}
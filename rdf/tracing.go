@@ -0,0 +1,75 @@
+package rdf
+
+import "context"
+
+// Tracer starts a span for each Parse call, reader and writer, so that RDF
+// input and output shows up in the distributed traces of a service. The
+// rdfotel package implements it with OpenTelemetry; see OptTracer.
+type Tracer interface {
+	// Start starts the span named name for a stream of format, as a child
+	// of the span of ctx, and returns a context holding the new span. name
+	// is "rdf.Parse" for a call to Parse, "rdf.Reader" for a reader and
+	// "rdf.Writer" for a writer. format is FormatAuto for a reader whose
+	// format is not yet detected.
+	Start(ctx context.Context, name string, format Format) (context.Context, Span)
+}
+
+// Span is the span of a Parse call, reader or writer started by a Tracer.
+type Span interface {
+	// SetFormat records the format of a reader once it is detected.
+	SetFormat(format Format)
+	// End records the counters of the stream and err, if not nil, and ends
+	// the span. It is called once, when a reader reaches the end of its
+	// input, its first error or its Close, and when a writer is closed or
+	// fails.
+	End(m Metrics, err error)
+}
+
+// OptTracer makes Parse, NewReader and NewWriter record spans with t:
+// "rdf.Parse" spans a call to Parse, "rdf.Reader" spans a reader from its
+// creation to the end of its input, its first error or its Close, and
+// "rdf.Writer" spans a writer from its creation to its Close or its first
+// error. Spans are children of the span of the OptContext context. Without
+// this option, or with a nil t, nothing is traced.
+func OptTracer(t Tracer) Option {
+	return func(opts *Options) {
+		opts.Tracer = t
+	}
+}
+
+// startSpan starts a span named name for a stream of format, returning a
+// nil streamSpan if tracing is disabled.
+func startSpan(opts Options, name string, format Format) (context.Context, *streamSpan) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.Tracer == nil {
+		return ctx, nil
+	}
+	ctx, span := opts.Tracer.Start(ctx, name, format)
+	return ctx, &streamSpan{span: span}
+}
+
+// streamSpan is the span of a reader, writer or Parse call, ended once.
+type streamSpan struct {
+	span  Span
+	ended bool
+}
+
+// setFormat records the format of a stream once it is detected.
+func (s *streamSpan) setFormat(format Format) {
+	if s != nil {
+		s.span.SetFormat(format)
+	}
+}
+
+// end records the counters of m and err, if not nil, and ends the span.
+// It does nothing on a nil or ended span.
+func (s *streamSpan) end(m Metrics, err error) {
+	if s == nil || s.ended {
+		return
+	}
+	s.ended = true
+	s.span.End(m, err)
+}
@@ -0,0 +1,106 @@
+package rdf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// recordingTracer is a Tracer keeping its spans in the order they end.
+type recordingTracer struct {
+	ended []*recordedSpan
+}
+
+type spanKey struct{}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, format Format) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(*recordedSpan)
+	span := &recordedSpan{tracer: t, name: name, parent: parent, format: format}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+type recordedSpan struct {
+	tracer  *recordingTracer
+	name    string
+	parent  *recordedSpan
+	format  Format
+	metrics Metrics
+	err     error
+}
+
+func (s *recordedSpan) SetFormat(format Format) { s.format = format }
+
+func (s *recordedSpan) End(m Metrics, err error) {
+	s.metrics, s.err = m, err
+	s.tracer.ended = append(s.tracer.ended, s)
+}
+
+func TestOptTracerParse(t *testing.T) {
+	tracer := &recordingTracer{}
+	const input = "<http://example.org/s> <http://example.org/p> \"a\" .\n<http://example.org/s> <http://example.org/p> \"b\" .\n"
+	err := Parse(context.Background(), strings.NewReader(input), FormatNTriples, func(Statement) error { return nil }, OptTracer(tracer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := tracer.ended
+	if len(spans) != 2 {
+		t.Fatalf("%d spans ended, want 2", len(spans))
+	}
+	reader, parse := spans[0], spans[1]
+	if reader.name != "rdf.Reader" || parse.name != "rdf.Parse" {
+		t.Fatalf("spans = %s, %s", reader.name, parse.name)
+	}
+	if reader.parent != parse {
+		t.Error("reader span is not a child of the Parse span")
+	}
+	for _, span := range spans {
+		if span.format != FormatNTriples || span.metrics.Statements != 2 || span.metrics.BytesRead != int64(len(input)) || span.err != nil {
+			t.Errorf("%s span: format %s, metrics %+v, error %v", span.name, span.format, span.metrics, span.err)
+		}
+	}
+}
+
+func TestOptTracerErrors(t *testing.T) {
+	tracer := &recordingTracer{}
+
+	r, err := NewReader(strings.NewReader("<http://example.org/s> <http://example.org/p> .\n"), FormatAuto, OptTracer(tracer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Next(); err == nil {
+		t.Fatal("Next() succeeded on invalid input")
+	}
+	r.Close()
+	if len(tracer.ended) != 1 {
+		t.Fatalf("%d spans ended, want 1", len(tracer.ended))
+	}
+	if span := tracer.ended[0]; Code(span.err) != ErrCodeParseError || span.format == FormatAuto {
+		t.Errorf("reader span: format %s, error %v", span.format, span.err)
+	}
+
+	w, err := NewWriter(failingWriter{}, FormatNTriples, OptTracer(tracer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(Statement{S: IRI{Value: "http://example.org/s"}, P: IRI{Value: "http://example.org/p"}, O: Literal{Lexical: "o"}})
+	if err := w.Close(); !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if len(tracer.ended) != 2 || tracer.ended[1].name != "rdf.Writer" || !errors.Is(tracer.ended[1].err, io.ErrClosedPipe) {
+		t.Fatalf("writer span not recorded as failed: %v", tracer.ended)
+	}
+	if got := tracer.ended[1].metrics.Statements; got != 1 {
+		t.Errorf("writer statements = %d, want 1", got)
+	}
+
+	// Without a tracer nothing is traced.
+	var buf bytes.Buffer
+	w, _ = NewWriter(&buf, FormatNTriples)
+	w.Close()
+	if len(tracer.ended) != 2 {
+		t.Error("span recorded without OptTracer")
+	}
+}
@@ -0,0 +1,122 @@
+package rdf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func readUntilError(t *testing.T, r io.Reader, format Format, opts ...Option) (int64, error) {
+	t.Helper()
+	reader, err := NewReader(r, format, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	var count int64
+	for {
+		if _, err := reader.Next(); err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+func TestTruncatedInput(t *testing.T) {
+	const rdfxmlHead = `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">`
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		count  int64
+	}{
+		{"turtle literal", FormatTurtle, "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n<http://example.org/s> <http://example.org/p> \"ab", 1},
+		{"turtle long literal", FormatTurtle, `<http://example.org/s> <http://example.org/p> """ab`, 0},
+		{"turtle blank node property list", FormatTurtle, `<http://example.org/s> <http://example.org/p> [ <http://example.org/q> 1`, 0},
+		{"turtle missing dot", FormatTurtle, `<http://example.org/s> <http://example.org/p> <http://example.org/o>`, 0},
+		{"trig graph", FormatTriG, `<http://example.org/g> { <http://example.org/s> <http://example.org/p> <http://example.org/o> .`, 1},
+		{"ntriples IRI", FormatNTriples, "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n<http://example.org/s> <http://example.org/p> <http://exa", 1},
+		{"nquads graph", FormatNQuads, `<http://example.org/s> <http://example.org/p> <http://example.org/o> <http://exa`, 0},
+		{"rdfxml element", FormatRDFXML, rdfxmlHead + `<rdf:Description rdf:about="http://example.org/s"><ex:p>v</ex:p><ex:q>w`, 1},
+		{"rdfxml missing root end", FormatRDFXML, rdfxmlHead + `<rdf:Description rdf:about="http://example.org/s"><ex:p>v</ex:p></rdf:Description>`, 1},
+		{"jsonld string", FormatJSONLD, `{"@id": "http://example.org/s", "http://example.org/p": "ab`, 0},
+		{"jsonld object", FormatJSONLD, `[{"@id": "http://example.org/s", "http://example.org/p": "a"}, {"@id": "http://example.org/t", "http://example.org/p": {"@id"`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, opts := range [][]Option{nil, {OptContinueOnError(0)}} {
+				count, err := readUntilError(t, strings.NewReader(tt.input), tt.format, opts...)
+				var truncErr *TruncatedInputError
+				if !errors.As(err, &truncErr) || !errors.Is(err, ErrTruncatedInput) || Code(err) != ErrCodeTruncatedInput {
+					t.Fatalf("expected truncated input error, got %v", err)
+				}
+				if truncErr.Statements != tt.count || count != tt.count {
+					t.Fatalf("expected %d statements before truncation, got %d (read %d)", tt.count, truncErr.Statements, count)
+				}
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) || parseErr.Line == 0 {
+					t.Fatalf("expected positioned parse error, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncatedInputSyntaxErrorsStayDistinct(t *testing.T) {
+	inputs := map[Format]string{
+		FormatNTriples: "<http://example.org/s> <http://example.org/p> \"ab\n<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n",
+		FormatTurtle:   "<http://example.org/s> <http://example.org/p> . <http://example.org/s>",
+		FormatRDFXML:   `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><a></b></rdf:RDF>`,
+		FormatJSONLD:   `{"@id": "http://example.org/s", "http://example.org/p": ]}`,
+	}
+	for format, input := range inputs {
+		_, err := readUntilError(t, strings.NewReader(input), format)
+		if err == io.EOF || errors.Is(err, ErrTruncatedInput) {
+			t.Errorf("%s: expected a syntax error, got %v", format, err)
+		}
+	}
+}
+
+func TestTruncatedInputCompressedStream(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	for i := 0; i < 100; i++ {
+		_, _ = zw.Write([]byte("<http://example.org/s> <http://example.org/p> \"some repeated object value\" .\n"))
+	}
+	_ = zw.Close()
+	zr, err := gzip.NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-10]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = readUntilError(t, zr, FormatNTriples)
+	if !errors.Is(err, ErrTruncatedInput) {
+		t.Fatalf("expected truncated input error, got %v", err)
+	}
+}
+
+// TestTruncatedInputEveryPrefix cuts valid documents at every byte and checks
+// that a truncation error always reports the statements actually returned.
+func TestTruncatedInputEveryPrefix(t *testing.T) {
+	docs := map[Format]string{
+		FormatTurtle: "@prefix ex: <http://example.org/> .\nex:s ex:p \"a\"@en , [ ex:q ( 1 2 ) ] .\nex:t ex:p \"\"\"long\"\"\" .\n",
+		FormatTriG:   "@prefix ex: <http://example.org/> .\nex:g { ex:s ex:p ex:o . }\nex:s ex:p 1.5 .\n",
+		FormatNQuads: "<http://example.org/s> <http://example.org/p> \"a\\n\" <http://example.org/g> .\n<http://example.org/s> <http://example.org/p> _:b .\n",
+		FormatRDFXML: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/"><rdf:Description rdf:about="http://example.org/s"><ex:p>v</ex:p><ex:q rdf:resource="http://example.org/o"/></rdf:Description></rdf:RDF>`,
+		FormatJSONLD: `[{"@id": "http://example.org/s", "http://example.org/p": ["a", {"@id": "http://example.org/o"}]}, {"@id": "http://example.org/t", "http://example.org/p": 1}]`,
+	}
+	for format, doc := range docs {
+		if _, err := readUntilError(t, strings.NewReader(doc), format); err != io.EOF {
+			t.Fatalf("%s: expected complete document to parse, got %v", format, err)
+		}
+		for n := 1; n < len(doc); n++ {
+			count, err := readUntilError(t, strings.NewReader(doc[:n]), format)
+			var truncErr *TruncatedInputError
+			if errors.As(err, &truncErr) && truncErr.Statements != count {
+				t.Fatalf("%s cut at %d: error reports %d statements, read %d", format, n, truncErr.Statements, count)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package rdf
+
+import "strings"
+
+// annotatedLine is a Turtle statement produced by renderAnnotatedStatements,
+// without the terminating " .".
+type annotatedLine struct {
+	text  string
+	graph Term
+}
+
+// annotationRenderer collapses rdf:reifies statements into the annotation
+// syntax of the triples they reify. A statement "r rdf:reifies <<( s p o )>>"
+// in the same graph as "s p o" becomes "s p o ~ r", and the statements with
+// subject r move into an annotation block "s p o ~ r {| ... |}". A blank node
+// reifier that occurs nowhere else is left out, giving "s p o {| ... |}" or
+// "s p o ~". Annotation blocks nest when their statements are reified too.
+type annotationRenderer struct {
+	quads    []Quad
+	prefixes map[string]string
+
+	reifiers map[int][]Term   // Reifiers of each reified statement
+	members  map[string][]int // Statements with a reifier as subject, by reifier key
+	hidden   []bool           // rdf:reifies statements written as annotations
+	member   []bool           // Statements written in annotation blocks
+	rendered []bool
+	blanks   map[string]int // Occurrences of each blank node label
+}
+
+// renderAnnotatedStatements renders quads as Turtle statements with
+// annotation syntax. Every statement is rendered exactly once.
+func renderAnnotatedStatements(quads []Quad, prefixes map[string]string) []annotatedLine {
+	r := &annotationRenderer{
+		quads:    quads,
+		prefixes: prefixes,
+		reifiers: make(map[int][]Term),
+		members:  make(map[string][]int),
+		hidden:   make([]bool, len(quads)),
+		member:   make([]bool, len(quads)),
+		rendered: make([]bool, len(quads)),
+		blanks:   make(map[string]int),
+	}
+	r.index()
+	lines := make([]annotatedLine, 0, len(quads))
+	for pass := 0; pass < 2; pass++ {
+		for i, q := range quads {
+			// Statements inside annotation blocks are rendered with their
+			// reified statement; the second pass picks up those left over
+			// by cycles between annotations.
+			if r.rendered[i] || r.hidden[i] || (pass == 0 && r.member[i]) {
+				continue
+			}
+			r.rendered[i] = true
+			text := renderSubjectWithPrefixes(q.S, prefixes) + " " + renderIRIWithPrefixes(q.P, prefixes) + " " + renderTermWithPrefixes(q.O, prefixes) + r.renderAnnotations(i)
+			lines = append(lines, annotatedLine{text: text, graph: q.G})
+		}
+	}
+	return lines
+}
+
+func (r *annotationRenderer) index() {
+	asserted := make(map[string]int, len(r.quads))
+	for i, q := range r.quads {
+		key := reifiedQuadKey(q.S, q.P, q.O, q.G)
+		if _, ok := asserted[key]; !ok {
+			asserted[key] = i
+		}
+	}
+	assigned := make(map[string]bool)
+	for i, q := range r.quads {
+		tt, ok := q.O.(TripleTerm)
+		if q.P.Value != rdfReifiesIRI || !ok {
+			continue
+		}
+		switch q.S.(type) {
+		case IRI, BlankNode:
+		default:
+			continue
+		}
+		reifierKey := renderTerm(q.S) + " " + renderTerm(q.G)
+		j, ok := asserted[reifiedQuadKey(tt.S, tt.P, tt.O, q.G)]
+		if !ok || j == i || assigned[reifierKey] {
+			continue
+		}
+		assigned[reifierKey] = true
+		r.hidden[i] = true
+		r.reifiers[j] = append(r.reifiers[j], q.S)
+	}
+	// The triple terms of hidden rdf:reifies statements are implied by the
+	// position of their annotations.
+	for i, q := range r.quads {
+		r.countBlankNodes(q.S)
+		r.countBlankNodes(q.G)
+		if !r.hidden[i] {
+			r.countBlankNodes(q.O)
+		}
+	}
+	for i, q := range r.quads {
+		key := renderTerm(q.S) + " " + renderTerm(q.G)
+		if r.hidden[i] || !assigned[key] {
+			continue
+		}
+		r.members[key] = append(r.members[key], i)
+		r.member[i] = true
+	}
+	// A statement annotated by its own subject stays at the top level.
+	for j, reifiers := range r.reifiers {
+		for _, reifier := range reifiers {
+			if reifier == r.quads[j].S {
+				r.member[j] = false
+			}
+		}
+	}
+}
+
+func (r *annotationRenderer) countBlankNodes(term Term) {
+	switch value := term.(type) {
+	case BlankNode:
+		r.blanks[value.ID]++
+	case TripleTerm:
+		r.countBlankNodes(value.S)
+		r.countBlankNodes(value.O)
+	}
+}
+
+// renderAnnotations renders the reifiers and annotation blocks of statement
+// i. Reifiers without a block come last so that a following block is not
+// attached to them.
+func (r *annotationRenderer) renderAnnotations(i int) string {
+	var blocks, bare strings.Builder
+	for _, reifier := range r.reifiers[i] {
+		var objects []string
+		for _, m := range r.members[renderTerm(reifier)+" "+renderTerm(r.quads[i].G)] {
+			if r.rendered[m] {
+				continue
+			}
+			r.rendered[m] = true
+			q := r.quads[m]
+			objects = append(objects, renderIRIWithPrefixes(q.P, r.prefixes)+" "+renderTermWithPrefixes(q.O, r.prefixes)+r.renderAnnotations(m))
+		}
+		anonymous := false
+		if bnode, ok := reifier.(BlankNode); ok {
+			anonymous = r.blanks[bnode.ID] == 1+len(objects)
+		}
+		switch {
+		case len(objects) == 0 && anonymous:
+			bare.WriteString(" ~")
+		case len(objects) == 0:
+			bare.WriteString(" ~ " + renderTermWithPrefixes(reifier, r.prefixes))
+		default:
+			if !anonymous {
+				blocks.WriteString(" ~ " + renderTermWithPrefixes(reifier, r.prefixes))
+			}
+			blocks.WriteString(" {| " + strings.Join(objects, " ; ") + " |}")
+		}
+	}
+	return blocks.String() + bare.String()
+}
+
+func reifiedQuadKey(s Term, p IRI, o Term, g Term) string {
+	return renderTerm(s) + " " + renderIRI(p) + " " + renderTerm(o) + " " + renderTerm(g)
+}
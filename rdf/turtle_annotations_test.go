@@ -0,0 +1,111 @@
+package rdf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOptAnnotationSyntax(t *testing.T) {
+	const prefix = "@prefix ex: <http://example.org/> .\n"
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   string
+	}{
+		{
+			"anonymous block",
+			FormatTurtle,
+			`ex:s ex:p ex:o {| ex:q ex:r |} .`,
+			`<http://example.org/s> <http://example.org/p> <http://example.org/o> {| <http://example.org/q> <http://example.org/r> |} .`,
+		},
+		{
+			"named reifier",
+			FormatTurtle,
+			`ex:s ex:p ex:o ~ ex:id {| ex:q ex:r |} .`,
+			`<http://example.org/s> <http://example.org/p> <http://example.org/o> ~ <http://example.org/id> {| <http://example.org/q> <http://example.org/r> |} .`,
+		},
+		{
+			"reifier only",
+			FormatTurtle,
+			`ex:s ex:p ex:o ~ ex:id ~ .`,
+			`<http://example.org/s> <http://example.org/p> <http://example.org/o> ~ <http://example.org/id> ~ .`,
+		},
+		{
+			"nested blocks",
+			FormatTurtle,
+			`ex:s ex:p ex:o {| ex:q ex:r {| ex:source ex:doc |} ; ex:q2 2 |} .`,
+			`<http://example.org/s> <http://example.org/p> <http://example.org/o> {| <http://example.org/q> <http://example.org/r> {| <http://example.org/source> <http://example.org/doc> |} ; <http://example.org/q2> "2"^^<http://www.w3.org/2001/XMLSchema#integer> |} .`,
+		},
+		{
+			"graph",
+			FormatTriG,
+			`ex:g { ex:s ex:p ex:o {| ex:q ex:r |} }`,
+			`<http://example.org/g> { <http://example.org/s> <http://example.org/p> <http://example.org/o> {| <http://example.org/q> <http://example.org/r> |} . }`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(prefix+tt.input), tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want, err := collectStatements(reader)
+			reader.Close()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			output := writeStatements(t, tt.format, want, OptAnnotationSyntax())
+			if strings.TrimSpace(output) != tt.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", output, tt.want)
+			}
+			reader, err = NewReader(strings.NewReader(output), tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := collectStatements(reader)
+			reader.Close()
+			if err != nil {
+				t.Fatalf("cannot read back %s: %v", output, err)
+			}
+			if !isomorphicQuads(statementsToQuads(want), statementsToQuads(got)) {
+				t.Fatalf("roundtrip changed the statements:\n%s", output)
+			}
+		})
+	}
+}
+
+// TestOptAnnotationSyntaxKeepsStatements checks reifications that cannot be
+// written as plain annotation blocks: blank node reifiers used elsewhere,
+// reified triples that are not asserted and annotations reifying each other.
+func TestOptAnnotationSyntaxKeepsStatements(t *testing.T) {
+	s, p, q := IRI{Value: "http://example.org/s"}, IRI{Value: "http://example.org/p"}, IRI{Value: "http://example.org/q"}
+	reifies := IRI{Value: rdfReifiesIRI}
+	r1, r2 := BlankNode{ID: "r1"}, BlankNode{ID: "r2"}
+	a := Statement{S: r2, P: p, O: Literal{Lexical: "a"}}
+	b := Statement{S: r1, P: q, O: Literal{Lexical: "b"}}
+	stmts := []Statement{
+		a,
+		b,
+		{S: r1, P: reifies, O: TripleTerm{S: a.S, P: a.P, O: a.O}},
+		{S: r2, P: reifies, O: TripleTerm{S: b.S, P: b.P, O: b.O}},
+		{S: s, P: p, O: r1},
+		{S: BlankNode{ID: "r3"}, P: reifies, O: TripleTerm{S: s, P: q, O: s}},
+	}
+	output := writeStatements(t, FormatTurtle, stmts, OptAnnotationSyntax())
+	if !strings.Contains(output, "~ _:r1") {
+		t.Errorf("expected the shared reifier to keep its label:\n%s", output)
+	}
+	reader, err := NewReader(strings.NewReader(output), FormatTurtle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := collectStatements(reader)
+	reader.Close()
+	if err != nil {
+		t.Fatalf("cannot read back %s: %v", output, err)
+	}
+	if !isomorphicQuads(statementsToQuads(stmts), statementsToQuads(got)) {
+		t.Fatalf("roundtrip changed the statements:\n%s", output)
+	}
+}
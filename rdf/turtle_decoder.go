@@ -21,6 +21,10 @@ func (d *turtletripleDecoder) Next() (Triple, error) {
 	return d.parser.NextTriple()
 }
 
+func (d *turtletripleDecoder) statementLine() int { return d.parser.lexer.stmtLine }
+
+func (d *turtletripleDecoder) exportState() (DecoderState, error) { return d.parser.exportState() }
+
 func (d *turtletripleDecoder) Err() error { return d.parser.Err() }
 func (d *turtletripleDecoder) Close() error {
 	return nil
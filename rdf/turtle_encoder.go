@@ -14,6 +14,15 @@ type TurtleEncodeOptions struct {
 	Indent   string
 	Prefixes map[string]string
 	BaseIRI  string
+	// AnnotationSyntax holds statements until Flush or Close and writes
+	// rdf:reifies statements as annotations of the triples they reify.
+	AnnotationSyntax bool
+	// PreserveContainers holds statements until Flush or Close and writes
+	// each rdf:Bag, rdf:Seq and rdf:Alt as one statement with its members
+	// in index order.
+	PreserveContainers bool
+	// SPARQLDirectives writes BASE and PREFIX instead of @base and @prefix.
+	SPARQLDirectives bool
 }
 
 // TriGEncodeOptions configures TriG encoding.
@@ -22,6 +31,18 @@ type TriGEncodeOptions struct {
 	Indent   string
 	Prefixes map[string]string
 	BaseIRI  string
+	// AnnotationSyntax holds statements until Flush or Close and writes
+	// rdf:reifies statements as annotations of the triples they reify.
+	AnnotationSyntax bool
+	// SPARQLDirectives writes BASE and PREFIX instead of @base and @prefix.
+	SPARQLDirectives bool
+	// GraphBlocks holds statements until Flush or Close and writes those of
+	// the default graph first, then those of each named graph in a
+	// "GRAPH g { ... }" block, grouped by subject and predicate.
+	GraphBlocks bool
+	// SortGraphs writes the named graph blocks of GraphBlocks in TermCompare
+	// order of their names instead of the order of their first statements.
+	SortGraphs bool
 }
 
 // Triple encoder for Turtle
@@ -30,6 +51,7 @@ type turtletripleEncoder struct {
 	err     error
 	started bool
 	opts    TurtleEncodeOptions
+	pending []Quad // Statements held for AnnotationSyntax and PreserveContainers
 }
 
 func newTurtletripleEncoder(w io.Writer) tripleEncoder {
@@ -37,7 +59,7 @@ func newTurtletripleEncoder(w io.Writer) tripleEncoder {
 }
 
 func newTurtletripleEncoderWithOptions(w io.Writer, opts TurtleEncodeOptions) tripleEncoder {
-	return &turtletripleEncoder{writer: bufio.NewWriter(w), opts: opts}
+	return &turtletripleEncoder{writer: newEncoderBuffer(w), opts: opts}
 }
 
 func (e *turtletripleEncoder) Write(t Triple) error {
@@ -52,21 +74,83 @@ func (e *turtletripleEncoder) Write(t Triple) error {
 	if t.S == nil || t.P.Value == "" || t.O == nil {
 		return fmt.Errorf("turtle: missing statement fields")
 	}
-	line := renderTermWithPrefixes(t.S, e.opts.Prefixes) + " " + renderIRIWithPrefixes(t.P, e.opts.Prefixes) + " " + renderTermWithPrefixes(t.O, e.opts.Prefixes) + " .\n"
+	if base := e.opts.BaseIRI; base != "" {
+		t.S, t.O = relativizeTerm(t.S, base, e.opts.Prefixes), relativizeTerm(t.O, base, e.opts.Prefixes)
+		t.P = relativizeTerm(t.P, base, e.opts.Prefixes).(IRI)
+	}
+	if e.opts.AnnotationSyntax || e.opts.PreserveContainers {
+		e.pending = append(e.pending, Quad{S: t.S, P: t.P, O: t.O})
+		return nil
+	}
+	return e.writeLine(renderSubjectWithPrefixes(t.S, e.opts.Prefixes) + " " + renderIRIWithPrefixes(t.P, e.opts.Prefixes) + " " + renderTermWithPrefixes(t.O, e.opts.Prefixes))
+}
+
+// writeLine writes a statement. Its parts are written separately, so that
+// the statement is not copied again; errors of the buffered writer stick.
+func (e *turtletripleEncoder) writeLine(statement string) error {
 	if e.opts.Indent != "" {
-		line = e.opts.Indent + line
+		e.writer.WriteString(e.opts.Indent)
 	}
-	_, err := e.writer.WriteString(line)
+	e.writer.WriteString(statement)
+	_, err := e.writer.WriteString(" .\n")
 	if err != nil {
 		e.err = err
 	}
 	return err
 }
 
+// writePending writes the statements held for AnnotationSyntax and
+// PreserveContainers. With both, containers are written as consecutive
+// statements so that annotations can be attached to them.
+func (e *turtletripleEncoder) writePending() error {
+	pending := e.pending
+	e.pending = e.pending[:0]
+	if !e.opts.AnnotationSyntax {
+		for _, group := range groupContainers(pending) {
+			if err := e.writeLine(e.renderGroup(group)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if e.opts.PreserveContainers {
+		var grouped []Quad
+		for _, group := range groupContainers(pending) {
+			grouped = append(grouped, group...)
+		}
+		pending = grouped
+	}
+	for _, line := range renderAnnotatedStatements(pending, e.opts.Prefixes) {
+		if err := e.writeLine(line.text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderGroup renders statements about one subject as a single statement
+// with a predicate-object list.
+func (e *turtletripleEncoder) renderGroup(group []Quad) string {
+	var b strings.Builder
+	b.WriteString(renderSubjectWithPrefixes(group[0].S, e.opts.Prefixes))
+	for i, q := range group {
+		if i > 0 {
+			b.WriteString(" ;\n" + e.opts.Indent + "    ")
+		} else {
+			b.WriteString(" ")
+		}
+		b.WriteString(renderIRIWithPrefixes(q.P, e.opts.Prefixes) + " " + renderTermWithPrefixes(q.O, e.opts.Prefixes))
+	}
+	return b.String()
+}
+
 func (e *turtletripleEncoder) Flush() error {
 	if e.err != nil {
 		return e.err
 	}
+	if err := e.writePending(); err != nil {
+		return err
+	}
 	return e.writer.Flush()
 }
 
@@ -74,6 +158,9 @@ func (e *turtletripleEncoder) Close() error {
 	if e.err != nil {
 		return e.err
 	}
+	if err := e.writePending(); err != nil {
+		return err
+	}
 	if err := e.writer.Flush(); err != nil {
 		e.err = err
 		return err
@@ -84,26 +171,9 @@ func (e *turtletripleEncoder) Close() error {
 
 func (e *turtletripleEncoder) writeHeader() error {
 	e.started = true
-	if e.opts.BaseIRI != "" {
-		if _, err := e.writer.WriteString("@base <" + e.opts.BaseIRI + "> .\n"); err != nil {
-			e.err = err
-			return err
-		}
-	}
-	if len(e.opts.Prefixes) == 0 {
-		return nil
-	}
-	for _, prefix := range sortedPrefixKeys(e.opts.Prefixes) {
-		ns := e.opts.Prefixes[prefix]
-		label := prefix + ":"
-		if prefix == "" {
-			label = ":"
-		}
-		line := "@prefix " + label + " <" + ns + "> .\n"
-		if _, err := e.writer.WriteString(line); err != nil {
-			e.err = err
-			return err
-		}
+	if _, err := e.writer.WriteString(renderDirectives(e.opts.BaseIRI, e.opts.Prefixes, e.opts.SPARQLDirectives)); err != nil {
+		e.err = err
+		return err
 	}
 	return nil
 }
@@ -114,6 +184,7 @@ type trigquadEncoder struct {
 	err     error
 	started bool
 	opts    TriGEncodeOptions
+	pending []Quad // Statements held for AnnotationSyntax and GraphBlocks
 }
 
 func newTriGquadEncoder(w io.Writer) quadEncoder {
@@ -121,7 +192,7 @@ func newTriGquadEncoder(w io.Writer) quadEncoder {
 }
 
 func newTriGquadEncoderWithOptions(w io.Writer, opts TriGEncodeOptions) quadEncoder {
-	return &trigquadEncoder{writer: bufio.NewWriter(w), opts: opts}
+	return &trigquadEncoder{writer: newEncoderBuffer(w), opts: opts}
 }
 
 func (e *trigquadEncoder) Write(q Quad) error {
@@ -136,21 +207,35 @@ func (e *trigquadEncoder) Write(q Quad) error {
 	if q.S == nil || q.P.Value == "" || q.O == nil {
 		return fmt.Errorf("trig: missing statement fields")
 	}
-	subject := renderTermWithPrefixes(q.S, e.opts.Prefixes)
+	if base := e.opts.BaseIRI; base != "" {
+		q.S, q.O = relativizeTerm(q.S, base, e.opts.Prefixes), relativizeTerm(q.O, base, e.opts.Prefixes)
+		q.P = relativizeTerm(q.P, base, e.opts.Prefixes).(IRI)
+		if q.G != nil {
+			q.G = relativizeTerm(q.G, base, e.opts.Prefixes)
+		}
+	}
+	if e.opts.AnnotationSyntax || e.opts.GraphBlocks {
+		e.pending = append(e.pending, q)
+		return nil
+	}
+	subject := renderSubjectWithPrefixes(q.S, e.opts.Prefixes)
 	predicate := renderIRIWithPrefixes(q.P, e.opts.Prefixes)
 	object := renderTermWithPrefixes(q.O, e.opts.Prefixes)
-	line := subject + " " + predicate + " " + object + " ."
+	return e.writeLine(subject+" "+predicate+" "+object, q.G)
+}
+
+// writeLine writes a statement, in a graph block when graph is not nil.
+func (e *trigquadEncoder) writeLine(statement string, graph Term) error {
 	indent := e.opts.Indent
 	if e.opts.Pretty && indent == "" {
 		indent = "  "
 	}
-	if q.G != nil && e.opts.Pretty {
-		graph := renderTermWithPrefixes(q.G, e.opts.Prefixes)
-		if _, err := e.writer.WriteString(graph + " {\n"); err != nil {
+	if graph != nil && e.opts.Pretty {
+		if _, err := e.writer.WriteString(renderTermWithPrefixes(graph, e.opts.Prefixes) + " {\n"); err != nil {
 			e.err = err
 			return err
 		}
-		if _, err := e.writer.WriteString(indent + line + "\n"); err != nil {
+		if _, err := e.writer.WriteString(indent + statement + " .\n"); err != nil {
 			e.err = err
 			return err
 		}
@@ -160,24 +245,134 @@ func (e *trigquadEncoder) Write(q Quad) error {
 		}
 		return err
 	}
-	if q.G != nil {
-		graph := renderTermWithPrefixes(q.G, e.opts.Prefixes)
-		line = graph + " { " + line + " }"
-	}
 	if e.opts.Indent != "" {
-		line = e.opts.Indent + line
+		e.writer.WriteString(e.opts.Indent)
 	}
-	_, err := e.writer.WriteString(line + "\n")
+	// As in turtletripleEncoder.writeLine, the parts are written separately.
+	end := " .\n"
+	if graph != nil {
+		e.writer.WriteString(renderTermWithPrefixes(graph, e.opts.Prefixes) + " { ")
+		end = " . }\n"
+	}
+	e.writer.WriteString(statement)
+	_, err := e.writer.WriteString(end)
 	if err != nil {
 		e.err = err
 	}
 	return err
 }
 
+// writePending writes the statements held for AnnotationSyntax and
+// GraphBlocks.
+func (e *trigquadEncoder) writePending() error {
+	if e.opts.GraphBlocks {
+		return e.writeBlocks()
+	}
+	lines := renderAnnotatedStatements(e.pending, e.opts.Prefixes)
+	e.pending = e.pending[:0]
+	for _, line := range lines {
+		if err := e.writeLine(line.text, line.graph); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBlocks writes the statements held for GraphBlocks: the default graph
+// section, then one block per named graph, separated by blank lines.
+func (e *trigquadEncoder) writeBlocks() error {
+	var graphs []Term
+	byGraph := make(map[Term][]Quad)
+	for _, q := range e.pending {
+		if _, ok := byGraph[q.G]; !ok && q.G != nil {
+			graphs = append(graphs, q.G)
+		}
+		byGraph[q.G] = append(byGraph[q.G], q)
+	}
+	e.pending = e.pending[:0]
+	if e.opts.SortGraphs {
+		sort.SliceStable(graphs, func(i, j int) bool { return TermCompare(graphs[i], graphs[j]) < 0 })
+	}
+	if byGraph[nil] != nil {
+		graphs = append([]Term{nil}, graphs...)
+	}
+	indent := e.opts.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	var b strings.Builder
+	for i, graph := range graphs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if graph == nil {
+			e.renderSection(&b, byGraph[nil], e.opts.Indent)
+			continue
+		}
+		b.WriteString("GRAPH " + renderTermWithPrefixes(graph, e.opts.Prefixes) + " {\n")
+		e.renderSection(&b, byGraph[graph], e.opts.Indent+indent)
+		b.WriteString(e.opts.Indent + "}\n")
+	}
+	if _, err := e.writer.WriteString(b.String()); err != nil {
+		e.err = err
+		return err
+	}
+	return nil
+}
+
+// renderSection renders the statements of one graph, each line starting with
+// indent: with AnnotationSyntax one statement per annotated triple, otherwise
+// one statement per subject with predicate-object and object lists.
+func (e *trigquadEncoder) renderSection(b *strings.Builder, quads []Quad, indent string) {
+	if e.opts.AnnotationSyntax {
+		for _, line := range renderAnnotatedStatements(quads, e.opts.Prefixes) {
+			b.WriteString(indent + line.text + " .\n")
+		}
+		return
+	}
+	var subjects []Term
+	bySubject := make(map[Term][]Quad)
+	for _, q := range quads {
+		if _, ok := bySubject[q.S]; !ok {
+			subjects = append(subjects, q.S)
+		}
+		bySubject[q.S] = append(bySubject[q.S], q)
+	}
+	for _, subject := range subjects {
+		var predicates []IRI
+		objects := make(map[IRI][]Term)
+		for _, q := range bySubject[subject] {
+			if _, ok := objects[q.P]; !ok {
+				predicates = append(predicates, q.P)
+			}
+			objects[q.P] = append(objects[q.P], q.O)
+		}
+		b.WriteString(indent + renderSubjectWithPrefixes(subject, e.opts.Prefixes))
+		for i, predicate := range predicates {
+			if i > 0 {
+				b.WriteString(" ;\n" + indent + "    ")
+			} else {
+				b.WriteString(" ")
+			}
+			b.WriteString(renderIRIWithPrefixes(predicate, e.opts.Prefixes) + " ")
+			for j, object := range objects[predicate] {
+				if j > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(renderTermWithPrefixes(object, e.opts.Prefixes))
+			}
+		}
+		b.WriteString(" .\n")
+	}
+}
+
 func (e *trigquadEncoder) Flush() error {
 	if e.err != nil {
 		return e.err
 	}
+	if err := e.writePending(); err != nil {
+		return err
+	}
 	return e.writer.Flush()
 }
 
@@ -185,6 +380,9 @@ func (e *trigquadEncoder) Close() error {
 	if e.err != nil {
 		return e.err
 	}
+	if err := e.writePending(); err != nil {
+		return err
+	}
 	if err := e.writer.Flush(); err != nil {
 		e.err = err
 		return err
@@ -195,28 +393,33 @@ func (e *trigquadEncoder) Close() error {
 
 func (e *trigquadEncoder) writeHeader() error {
 	e.started = true
-	if e.opts.BaseIRI != "" {
-		if _, err := e.writer.WriteString("@base <" + e.opts.BaseIRI + "> .\n"); err != nil {
-			e.err = err
-			return err
-		}
-	}
-	if len(e.opts.Prefixes) == 0 {
-		return nil
+	if _, err := e.writer.WriteString(renderDirectives(e.opts.BaseIRI, e.opts.Prefixes, e.opts.SPARQLDirectives)); err != nil {
+		e.err = err
+		return err
 	}
-	for _, prefix := range sortedPrefixKeys(e.opts.Prefixes) {
-		ns := e.opts.Prefixes[prefix]
-		label := prefix + ":"
-		if prefix == "" {
-			label = ":"
+	return nil
+}
+
+// renderDirectives renders the base and prefix declarations of a Turtle or
+// TriG document, as @base and @prefix or, when sparql is set, as the BASE
+// and PREFIX forms shared with SPARQL.
+func renderDirectives(base string, prefixes map[string]string, sparql bool) string {
+	var b strings.Builder
+	if base != "" {
+		if sparql {
+			b.WriteString("BASE <" + base + ">\n")
+		} else {
+			b.WriteString("@base <" + base + "> .\n")
 		}
-		line := "@prefix " + label + " <" + ns + "> .\n"
-		if _, err := e.writer.WriteString(line); err != nil {
-			e.err = err
-			return err
+	}
+	for _, prefix := range sortedPrefixKeys(prefixes) {
+		if sparql {
+			b.WriteString("PREFIX " + prefix + ": <" + prefixes[prefix] + ">\n")
+		} else {
+			b.WriteString("@prefix " + prefix + ": <" + prefixes[prefix] + "> .\n")
 		}
 	}
-	return nil
+	return b.String()
 }
 
 func sortedPrefixKeys(prefixes map[string]string) []string {
@@ -243,19 +446,50 @@ func renderTermWithPrefixes(term Term, prefixes map[string]string) string {
 		return value.String()
 	case Literal:
 		if value.Lang != "" {
-			return fmt.Sprintf("%q@%s", value.Lexical, value.Lang)
+			return fmt.Sprintf("%q@%s", value.Lexical, value.langTag())
 		}
 		if value.Datatype.Value != "" {
 			return fmt.Sprintf("%q^^%s", value.Lexical, renderIRIWithPrefixes(value.Datatype, prefixes))
 		}
 		return fmt.Sprintf("%q", value.Lexical)
 	case TripleTerm:
-		return value.String()
+		return "<<( " + renderTermWithPrefixes(value.S, prefixes) + " " + renderIRIWithPrefixes(value.P, prefixes) + " " + renderTermWithPrefixes(value.O, prefixes) + " )>>"
 	default:
 		return ""
 	}
 }
 
+// relativizeTerm writes IRIs under base that no prefix abbreviates as
+// relative references, in triple terms too, so that they are read back
+// against the @base declaration of the output.
+func relativizeTerm(term Term, base string, prefixes map[string]string) Term {
+	switch value := term.(type) {
+	case IRI:
+		if _, ok := abbreviateQName(value.Value, prefixes, true); ok {
+			return value
+		}
+		if ref, ok := relativizeIRI(base, value.Value); ok {
+			return IRI{Value: ref}
+		}
+	case TripleTerm:
+		value.S = relativizeTerm(value.S, base, prefixes)
+		value.P = relativizeTerm(value.P, base, prefixes).(IRI)
+		value.O = relativizeTerm(value.O, base, prefixes)
+		return value
+	}
+	return term
+}
+
+// renderSubjectWithPrefixes renders a statement subject. Turtle and TriG do
+// not allow "<<( )>>" as a subject, so triple terms use the "<< s p o >>"
+// quoted triple form, which the parser reads back as the same triple term.
+func renderSubjectWithPrefixes(term Term, prefixes map[string]string) string {
+	if value, ok := term.(TripleTerm); ok {
+		return "<< " + renderTermWithPrefixes(value.S, prefixes) + " " + renderIRIWithPrefixes(value.P, prefixes) + " " + renderTermWithPrefixes(value.O, prefixes) + " >>"
+	}
+	return renderTermWithPrefixes(term, prefixes)
+}
+
 func abbreviateQName(iri string, prefixes map[string]string, allowEmptyPrefix bool) (string, bool) {
 	if len(prefixes) == 0 {
 		return "", false
@@ -2,17 +2,18 @@ package rdf
 
 import (
 	"bufio"
+	"bytes"
+	"fmt"
 	"io"
 	"strings"
+	"unicode/utf8"
 )
 
 type turtleTokenKind int
 
 const (
-	TokLine turtleTokenKind = iota
-	TokEOF
+	TokEOF turtleTokenKind = iota
 	TokError
-	// Future token kinds for full lexical scanning.
 	TokIRIRef
 	TokPNAMENS
 	TokPNAMELN
@@ -42,6 +43,8 @@ const (
 	TokA
 	TokLangTag
 	TokDatatypePrefix
+	TokTilde
+	TokGraph
 )
 
 const (
@@ -49,32 +52,19 @@ const (
 	lexRDoubleAngle = ">>"
 	lexAnnotationL  = "{|"
 	lexAnnotationR  = "|}"
-	lexDot          = "."
-	lexComma        = ","
-	lexSemicolon    = ";"
-	lexLBracket     = "["
-	lexRBracket     = "]"
-	lexLParen       = "("
-	lexRParen       = ")"
-	lexLBrace       = "{"
-	lexRBrace       = "}"
-	lexIRIStart     = "<"
-	lexIRIEnd       = ">"
+	lexDatatype     = "^^"
 	lexBlankNode    = "_:"
-	lexQuote        = "\""
-	lexApos         = "'"
 	lexPrefix       = "@prefix"
 	lexBase         = "@base"
 	lexVersion      = "@version"
 	lexPrefixBare   = "prefix"
 	lexBaseBare     = "base"
 	lexVersionBare  = "version"
+	lexGraphBare    = "graph"
 )
 
 func (k turtleTokenKind) String() string {
 	switch k {
-	case TokLine:
-		return "TokLine"
 	case TokEOF:
 		return "TokEOF"
 	case TokError:
@@ -137,315 +127,634 @@ func (k turtleTokenKind) String() string {
 		return "TokLangTag"
 	case TokDatatypePrefix:
 		return "TokDatatypePrefix"
+	case TokTilde:
+		return "TokTilde"
+	case TokGraph:
+		return "TokGraph"
 	default:
 		return "TokUnknown"
 	}
 }
 
+// turtleToken is a single lexical token. IRI lexemes keep their angle
+// brackets and string lexemes their quotes, with escapes left undecoded.
+// Line and Column are 1-based and Offset is the byte offset of the first
+// byte of the token.
 type turtleToken struct {
 	Kind   turtleTokenKind
 	Lexeme string
 	Err    error
+	Line   int
+	Column int
+	Offset int
+	End    int // Offset just past the token
 }
 
+// turtleLexer is a streaming tokenizer for Turtle and TriG. It reads the input
+// one byte at a time through a bufio.Reader, so statements may span any number
+// of lines and only the current token is held in memory.
 type turtleLexer struct {
 	reader *bufio.Reader
 	opts   decodeOptions
+
+	line      int
+	column    int
+	offset    int
+	lineBytes int
+
+	// stmtStart is the offset of the first token of the current statement;
+	// it is used to enforce MaxStatementBytes. startPending defers setting it
+	// until that token is reached, so comments between statements are free.
+	stmtStart    int
+	stmtLine     int
+	stmtColumn   int
+	startPending bool
+	// capture, when non-nil, records the raw text of the current statement
+	// so it can be attached to parse errors in debug mode.
+	capture *bytes.Buffer
+
+	buf    []byte
+	ioErr  error
+	cancel contextChecker
 }
 
 func newTurtleLexer(r io.Reader, opts decodeOptions) *turtleLexer {
 	return &turtleLexer{
 		reader: bufio.NewReader(r),
 		opts:   normalizeDecodeOptions(opts),
+		line:   1,
+		column: 1,
+		cancel: contextChecker{ctx: opts.Context},
+		// The first statement starts at the first token.
+		startPending: true,
+	}
+}
+
+// beginStatement marks the next token as the start of a new statement for
+// MaxStatementBytes accounting and debug capture.
+func (l *turtleLexer) beginStatement() {
+	l.startPending = true
+}
+
+// statementText returns the captured text of the current statement, or ""
+// when capture is disabled.
+func (l *turtleLexer) statementText() string {
+	if l.capture == nil {
+		return ""
+	}
+	return strings.TrimSpace(l.capture.String())
+}
+
+func (l *turtleLexer) peekByte(n int) (byte, bool) {
+	b, err := l.reader.Peek(n + 1)
+	if len(b) <= n {
+		if err != nil && err != io.EOF && l.ioErr == nil {
+			l.ioErr = err
+		}
+		return 0, false
+	}
+	return b[n], true
+}
+
+func (l *turtleLexer) readByte() (byte, error) {
+	ch, err := l.reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	l.offset++
+	if l.capture != nil {
+		l.capture.WriteByte(ch)
+	}
+	if ch == '\n' {
+		l.line++
+		l.column = 1
+		l.lineBytes = 0
+		return ch, nil
+	}
+	l.lineBytes++
+	if l.opts.MaxLineBytes > 0 && l.lineBytes > l.opts.MaxLineBytes {
+		return 0, ErrLineTooLong
 	}
+	// Count runes rather than bytes so columns match what editors display.
+	if !utf8.RuneStart(ch) {
+		return ch, nil
+	}
+	l.column++
+	return ch, nil
 }
 
+// Next returns the next token. Errors are reported as TokError tokens.
 func (l *turtleLexer) Next() turtleToken {
+	// Statements can hold any number of tokens, so cancellation is checked
+	// here as well as between statements.
+	if err := l.cancel.check(); err != nil {
+		return l.errorToken(err)
+	}
+	if err := l.skipWhitespaceAndComments(); err != nil {
+		return l.errorToken(err)
+	}
+	if l.startPending {
+		l.startPending = false
+		l.stmtStart = l.offset
+		l.stmtLine = l.line
+		l.stmtColumn = l.column
+		if l.capture != nil {
+			l.capture.Reset()
+		}
+	}
+	tok := turtleToken{Line: l.line, Column: l.column, Offset: l.offset}
+	kind, lexeme, err := l.scan()
+	if err == nil {
+		err = l.checkStatementSize()
+	}
+	if l.ioErr != nil {
+		err = l.ioErr
+	}
+	if err != nil {
+		if l.offset == tok.Offset && l.ioErr == nil {
+			// Make progress so a parser recovering from the error does not
+			// read the same token again.
+			l.discardRune()
+		}
+		tok.Kind = TokError
+		tok.Err = err
+		return tok
+	}
+	tok.Kind = kind
+	tok.Lexeme = lexeme
+	tok.End = l.offset
+	return tok
+}
+
+// discardRune consumes the bytes of the next rune.
+func (l *turtleLexer) discardRune() {
+	if _, err := l.readByte(); err != nil {
+		return
+	}
 	for {
-		line, err := readLineWithLimit(l.reader, l.opts.MaxLineBytes)
-		if err != nil {
-			if err == io.EOF && line == "" {
-				return turtleToken{Kind: TokEOF}
-			}
-			if err == io.EOF && line != "" {
-				trimmed := strings.TrimSpace(stripComment(line))
-				if trimmed == "" {
-					return turtleToken{Kind: TokEOF}
-				}
-				return turtleToken{Kind: TokLine, Lexeme: trimmed}
-			}
-			return turtleToken{Kind: TokError, Err: err}
+		ch, ok := l.peekByte(0)
+		if !ok || utf8.RuneStart(ch) {
+			return
 		}
-		trimmed := strings.TrimSpace(stripComment(line))
-		if trimmed == "" {
-			continue
+		if _, err := l.readByte(); err != nil {
+			return
 		}
-		return turtleToken{Kind: TokLine, Lexeme: trimmed}
 	}
 }
 
-// tokenizeTurtleLine splits a single Turtle statement line into tokens.
-// It is intentionally conservative and defers semantic validation to the parser.
-func tokenizeTurtleLine(line string) ([]turtleToken, error) {
-	scanner := &turtleScanner{input: line}
-	var tokens []turtleToken
+func (l *turtleLexer) errorToken(err error) turtleToken {
+	return turtleToken{Kind: TokError, Err: err, Line: l.line, Column: l.column, Offset: l.offset}
+}
+
+func (l *turtleLexer) skipWhitespaceAndComments() error {
 	for {
-		tok, err := scanner.nextToken()
-		if err != nil {
-			return nil, err
-		}
-		if tok.Kind == TokEOF {
-			return tokens, nil
-		}
-		tokens = append(tokens, tok)
-	}
-}
-
-type turtleScanner struct {
-	input string
-	pos   int
-}
-
-func (s *turtleScanner) nextToken() (turtleToken, error) {
-	s.skipWS()
-	if s.pos >= len(s.input) {
-		return turtleToken{Kind: TokEOF}, nil
-	}
-	if s.match(lexLDoubleAngle) {
-		s.pos += 2
-		return turtleToken{Kind: TokLDoubleAngle, Lexeme: lexLDoubleAngle}, nil
-	}
-	if s.match(lexRDoubleAngle) {
-		s.pos += 2
-		return turtleToken{Kind: TokRDoubleAngle, Lexeme: lexRDoubleAngle}, nil
-	}
-	if s.match(lexAnnotationL) {
-		s.pos += 2
-		return turtleToken{Kind: TokAnnotationL, Lexeme: lexAnnotationL}, nil
-	}
-	if s.match(lexAnnotationR) {
-		s.pos += 2
-		return turtleToken{Kind: TokAnnotationR, Lexeme: lexAnnotationR}, nil
-	}
-	if s.pos+1 < len(s.input) && s.input[s.pos] == '^' && s.input[s.pos+1] == '^' {
-		s.pos += 2
-		return turtleToken{Kind: TokDatatypePrefix, Lexeme: "^^"}, nil
-	}
-	// Check for @ but only scan as language tag if it's not a directive
-	if s.input[s.pos] == '@' {
-		// Check if it's a directive (@prefix, @base, @version) - these are handled by scanWord
-		remaining := s.input[s.pos:]
-		if strings.HasPrefix(remaining, "@prefix") || strings.HasPrefix(remaining, "@base") || strings.HasPrefix(remaining, "@version") {
-			// Let scanWord handle it
-		} else {
-			// It's likely a language tag
-			return s.scanLangTag()
-		}
-	}
-	switch s.input[s.pos] {
-	case lexDot[0]:
-		s.pos++
-		return turtleToken{Kind: TokDot, Lexeme: lexDot}, nil
-	case lexComma[0]:
-		s.pos++
-		return turtleToken{Kind: TokComma, Lexeme: lexComma}, nil
-	case lexSemicolon[0]:
-		s.pos++
-		return turtleToken{Kind: TokSemicolon, Lexeme: lexSemicolon}, nil
-	case lexLBracket[0]:
-		s.pos++
-		return turtleToken{Kind: TokLBracket, Lexeme: lexLBracket}, nil
-	case lexRBracket[0]:
-		s.pos++
-		return turtleToken{Kind: TokRBracket, Lexeme: lexRBracket}, nil
-	case lexLParen[0]:
-		s.pos++
-		return turtleToken{Kind: TokLParen, Lexeme: lexLParen}, nil
-	case lexRParen[0]:
-		s.pos++
-		return turtleToken{Kind: TokRParen, Lexeme: lexRParen}, nil
-	case lexLBrace[0]:
-		s.pos++
-		return turtleToken{Kind: TokLBrace, Lexeme: lexLBrace}, nil
-	case lexRBrace[0]:
-		s.pos++
-		return turtleToken{Kind: TokRBrace, Lexeme: lexRBrace}, nil
-	case lexIRIStart[0]:
-		return s.scanIRIRef()
-	case lexQuote[0], lexApos[0]:
-		return s.scanString()
-	}
-	if s.match(lexBlankNode) {
-		return s.scanBlankNode()
-	}
-	return s.scanWord()
-}
-
-func (s *turtleScanner) scanIRIRef() (turtleToken, error) {
-	start := s.pos
-	s.pos++ // consume '<'
-	for s.pos < len(s.input) && s.input[s.pos] != '>' {
-		if s.input[s.pos] == '\\' {
-			// Skip escape sequence, parser will validate if needed.
-			if s.pos+1 < len(s.input) && (s.input[s.pos+1] == 'u' || s.input[s.pos+1] == 'U') {
-				s.pos += 2
-				continue
+		ch, ok := l.peekByte(0)
+		if !ok {
+			return nil
+		}
+		switch ch {
+		case ' ', '\t', '\r', '\n':
+			if _, err := l.readByte(); err != nil {
+				return err
 			}
+		case '#':
+			if err := l.skipComment(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// skipComment skips a comment up to and including the end of its line and
+// reports it to the event sink of an EventReader.
+func (l *turtleLexer) skipComment() error {
+	line, column := l.line, l.column
+	var text []byte
+	for {
+		ch, err := l.readByte()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if err == io.EOF || ch == '\n' {
+			break
+		}
+		if l.opts.events != nil {
+			text = append(text, ch)
 		}
-		s.pos++
 	}
-	if s.pos >= len(s.input) {
-		return turtleToken{}, ErrStatementTooLong
+	if l.opts.events != nil {
+		text = bytes.TrimSuffix(text[1:], []byte("\r"))
+		l.opts.events.event(CommentEvent{Text: string(text), Line: line, Column: column})
 	}
-	s.pos++ // consume '>'
-	return turtleToken{Kind: TokIRIRef, Lexeme: s.input[start:s.pos]}, nil
+	return nil
 }
 
-func (s *turtleScanner) scanString() (turtleToken, error) {
-	quote := s.input[s.pos]
-	if s.pos+2 < len(s.input) && s.input[s.pos+1] == quote && s.input[s.pos+2] == quote {
-		return s.scanLongString(quote)
+func (l *turtleLexer) scan() (turtleTokenKind, string, error) {
+	ch, ok := l.peekByte(0)
+	if !ok {
+		if _, err := l.reader.Peek(1); err != nil && err != io.EOF {
+			return TokError, "", err
+		}
+		return TokEOF, "", nil
 	}
-	start := s.pos
-	s.pos++
-	for s.pos < len(s.input) {
-		ch := s.input[s.pos]
-		if ch == '\\' {
-			s.pos += 2
-			continue
+	next, _ := l.peekByte(1)
+	switch {
+	case ch == '<' && next == '<':
+		return l.punct(TokLDoubleAngle, lexLDoubleAngle)
+	case ch == '>' && next == '>':
+		return l.punct(TokRDoubleAngle, lexRDoubleAngle)
+	case ch == '{' && next == '|':
+		return l.punct(TokAnnotationL, lexAnnotationL)
+	case ch == '|' && next == '}':
+		return l.punct(TokAnnotationR, lexAnnotationR)
+	case ch == '^' && next == '^':
+		return l.punct(TokDatatypePrefix, lexDatatype)
+	case ch == '_' && next == ':':
+		return l.scanBlankNode()
+	case ch == '.' && isDigit(next):
+		return l.scanNumber()
+	}
+	switch ch {
+	case '<':
+		return l.scanIRIRef()
+	case '"', '\'':
+		return l.scanString(ch)
+	case '@':
+		return l.scanAt()
+	case '.':
+		return l.punct(TokDot, ".")
+	case ',':
+		return l.punct(TokComma, ",")
+	case ';':
+		return l.punct(TokSemicolon, ";")
+	case '[':
+		return l.punct(TokLBracket, "[")
+	case ']':
+		return l.punct(TokRBracket, "]")
+	case '(':
+		return l.punct(TokLParen, "(")
+	case ')':
+		return l.punct(TokRParen, ")")
+	case '{':
+		return l.punct(TokLBrace, "{")
+	case '}':
+		return l.punct(TokRBrace, "}")
+	case '~':
+		return l.punct(TokTilde, "~")
+	case '+', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return l.scanNumber()
+	}
+	if isPNCharsBase(ch) || ch == ':' {
+		return l.scanName()
+	}
+	return TokError, "", fmt.Errorf("unexpected character %q", l.peekRune())
+}
+
+func (l *turtleLexer) punct(kind turtleTokenKind, lexeme string) (turtleTokenKind, string, error) {
+	for range lexeme {
+		if _, err := l.readByte(); err != nil {
+			return TokError, "", err
 		}
-		if ch == quote {
-			s.pos++
-			return turtleToken{Kind: TokString, Lexeme: s.input[start:s.pos]}, nil
+	}
+	return kind, lexeme, nil
+}
+
+func (l *turtleLexer) peekRune() rune {
+	b, _ := l.reader.Peek(utf8.UTFMax)
+	r, _ := utf8.DecodeRune(b)
+	return r
+}
+
+// take consumes the next byte and appends it to the token buffer.
+func (l *turtleLexer) take() error {
+	ch, err := l.readByte()
+	if err != nil {
+		return err
+	}
+	l.buf = append(l.buf, ch)
+	return l.checkStatementSize()
+}
+
+func (l *turtleLexer) checkStatementSize() error {
+	if l.opts.MaxStatementBytes > 0 && l.offset-l.stmtStart > l.opts.MaxStatementBytes {
+		return ErrStatementTooLong
+	}
+	return nil
+}
+
+func (l *turtleLexer) scanIRIRef() (turtleTokenKind, string, error) {
+	l.buf = l.buf[:0]
+	if err := l.take(); err != nil {
+		return TokError, "", err
+	}
+	for {
+		ch, ok := l.peekByte(0)
+		if !ok {
+			return TokError, "", &truncatedError{fmt.Errorf("unterminated IRI")}
+		}
+		// Whitespace can never appear in an IRIREF; stopping here keeps a
+		// stray '<' from swallowing the rest of the document.
+		if ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n' {
+			return TokError, "", fmt.Errorf("invalid character in IRI")
+		}
+		if err := l.take(); err != nil {
+			return TokError, "", err
+		}
+		if ch == '>' {
+			return TokIRIRef, string(l.buf), nil
 		}
-		s.pos++
 	}
-	return turtleToken{}, ErrStatementTooLong
 }
 
-func (s *turtleScanner) scanLangTag() (turtleToken, error) {
-	start := s.pos
-	s.pos++ // consume '@'
-	for s.pos < len(s.input) {
-		ch := s.input[s.pos]
-		next := byte(0)
-		if s.pos+1 < len(s.input) {
-			next = s.input[s.pos+1]
+func (l *turtleLexer) scanString(quote byte) (turtleTokenKind, string, error) {
+	l.buf = l.buf[:0]
+	if q1, _ := l.peekByte(1); q1 == quote {
+		if q2, _ := l.peekByte(2); q2 == quote {
+			return l.scanLongString(quote)
 		}
-		if isTurtleTerminator(ch, next) {
-			break
+	}
+	if err := l.take(); err != nil {
+		return TokError, "", err
+	}
+	for {
+		ch, ok := l.peekByte(0)
+		if !ok {
+			return TokError, "", &truncatedError{fmt.Errorf("unterminated string literal")}
+		}
+		if ch == '\n' || ch == '\r' {
+			return TokError, "", fmt.Errorf("line break in string literal")
+		}
+		if err := l.take(); err != nil {
+			return TokError, "", err
+		}
+		switch ch {
+		case '\\':
+			if _, ok := l.peekByte(0); !ok {
+				return TokError, "", &truncatedError{fmt.Errorf("unterminated escape")}
+			}
+			if err := l.take(); err != nil {
+				return TokError, "", err
+			}
+		case quote:
+			return TokString, string(l.buf), nil
 		}
-		s.pos++
 	}
-	lang := s.input[start+1 : s.pos] // skip '@'
-	return turtleToken{Kind: TokLangTag, Lexeme: lang}, nil
 }
 
-func (s *turtleScanner) scanLongString(quote byte) (turtleToken, error) {
-	start := s.pos
-	s.pos += 3
-	for s.pos+2 < len(s.input) {
-		ch := s.input[s.pos]
-		if ch == '\\' {
-			s.pos += 2
-			continue
+func (l *turtleLexer) scanLongString(quote byte) (turtleTokenKind, string, error) {
+	for i := 0; i < 3; i++ {
+		if err := l.take(); err != nil {
+			return TokError, "", err
+		}
+	}
+	for {
+		ch, ok := l.peekByte(0)
+		if !ok {
+			return TokError, "", &truncatedError{fmt.Errorf("unterminated long string literal")}
+		}
+		if ch == quote {
+			q1, _ := l.peekByte(1)
+			q2, _ := l.peekByte(2)
+			if q1 == quote && q2 == quote {
+				for i := 0; i < 3; i++ {
+					if err := l.take(); err != nil {
+						return TokError, "", err
+					}
+				}
+				return TokStringLong, string(l.buf), nil
+			}
+		}
+		if err := l.take(); err != nil {
+			return TokError, "", err
 		}
-		if s.input[s.pos] == quote && s.input[s.pos+1] == quote && s.input[s.pos+2] == quote {
-			s.pos += 3
-			return turtleToken{Kind: TokStringLong, Lexeme: s.input[start:s.pos]}, nil
+		if ch == '\\' {
+			if _, ok := l.peekByte(0); !ok {
+				return TokError, "", &truncatedError{fmt.Errorf("unterminated escape")}
+			}
+			if err := l.take(); err != nil {
+				return TokError, "", err
+			}
 		}
-		s.pos++
 	}
-	return turtleToken{}, ErrStatementTooLong
 }
 
-func (s *turtleScanner) scanBlankNode() (turtleToken, error) {
-	start := s.pos
-	s.pos += 2
-	for s.pos < len(s.input) {
-		ch := s.input[s.pos]
-		if isTurtleTerminator(ch, 0) {
+// scanAt scans a directive keyword (@prefix, @base, @version) or a language
+// tag. The language tag lexeme omits the '@'. Which of the two a keyword is
+// depends on the grammar, not the preceding token, so the parser reads a
+// keyword that follows a string literal as a language tag (see
+// turtleParser.parseLiteral).
+func (l *turtleLexer) scanAt() (turtleTokenKind, string, error) {
+	if _, err := l.readByte(); err != nil {
+		return TokError, "", err
+	}
+	l.buf = l.buf[:0]
+	for {
+		ch, ok := l.peekByte(0)
+		if !ok || !(isASCIILetter(ch) || isDigit(ch) || ch == '-') {
 			break
 		}
-		s.pos++
+		if err := l.take(); err != nil {
+			return TokError, "", err
+		}
+	}
+	word := string(l.buf)
+	switch "@" + word {
+	case lexPrefix:
+		return TokPrefix, lexPrefix, nil
+	case lexBase:
+		return TokBase, lexBase, nil
+	case lexVersion:
+		return TokVersion, lexVersion, nil
 	}
-	return turtleToken{Kind: TokBlankNode, Lexeme: s.input[start:s.pos]}, nil
+	if word == "" {
+		return TokError, "", fmt.Errorf("expected language tag after '@'")
+	}
+	return TokLangTag, word, nil
 }
 
-func (s *turtleScanner) scanWord() (turtleToken, error) {
-	start := s.pos
-	for s.pos < len(s.input) {
-		ch := s.input[s.pos]
-		next := byte(0)
-		if s.pos+1 < len(s.input) {
-			next = s.input[s.pos+1]
-		}
-		if isTurtleTerminator(ch, next) {
-			break
+func (l *turtleLexer) scanBlankNode() (turtleTokenKind, string, error) {
+	l.buf = l.buf[:0]
+	for i := 0; i < 2; i++ {
+		if err := l.take(); err != nil {
+			return TokError, "", err
 		}
-		s.pos++
 	}
-	lexeme := s.input[start:s.pos]
-	switch {
-	case lexeme == lexPrefix || strings.EqualFold(lexeme, lexPrefixBare):
-		return turtleToken{Kind: TokPrefix, Lexeme: lexeme}, nil
-	case lexeme == lexBase || strings.EqualFold(lexeme, lexBaseBare):
-		return turtleToken{Kind: TokBase, Lexeme: lexeme}, nil
-	case lexeme == lexVersion || strings.EqualFold(lexeme, lexVersionBare):
-		return turtleToken{Kind: TokVersion, Lexeme: lexeme}, nil
+	if err := l.scanNameChars(false); err != nil {
+		return TokError, "", err
 	}
-	if lexeme == "a" {
-		return turtleToken{Kind: TokA, Lexeme: lexeme}, nil
+	return TokBlankNode, string(l.buf), nil
+}
+
+// scanNumber scans INTEGER, DECIMAL and DOUBLE literals. A '.' is only part
+// of the number when it is followed by a digit or an exponent, so "1." is the
+// integer 1 followed by the statement terminator.
+func (l *turtleLexer) scanNumber() (turtleTokenKind, string, error) {
+	l.buf = l.buf[:0]
+	kind := TokInteger
+	if ch, _ := l.peekByte(0); ch == '+' || ch == '-' {
+		if err := l.take(); err != nil {
+			return TokError, "", err
+		}
 	}
-	if lexeme == "true" || lexeme == "false" {
-		return turtleToken{Kind: TokBoolean, Lexeme: lexeme}, nil
+	digits, err := l.scanDigits()
+	if err != nil {
+		return TokError, "", err
 	}
-	if strings.Contains(lexeme, ":") {
-		if strings.HasSuffix(lexeme, ":") {
-			return turtleToken{Kind: TokPNAMENS, Lexeme: lexeme}, nil
+	if ch, _ := l.peekByte(0); ch == '.' {
+		next, _ := l.peekByte(1)
+		if isDigit(next) || (digits > 0 && l.exponentAt(1)) {
+			if err := l.take(); err != nil {
+				return TokError, "", err
+			}
+			fraction, err := l.scanDigits()
+			if err != nil {
+				return TokError, "", err
+			}
+			digits += fraction
+			kind = TokDecimal
 		}
-		return turtleToken{Kind: TokPNAMELN, Lexeme: lexeme}, nil
 	}
-	if isNumericLiteral(lexeme) {
-		// Distinguish between integer and decimal per Turtle spec
-		// Bare integers (no decimal point, no exponent) → TokInteger
-		// Numbers with decimal point or exponent → TokDecimal or TokDouble
-		if strings.Contains(lexeme, "e") || strings.Contains(lexeme, "E") {
-			return turtleToken{Kind: TokDouble, Lexeme: lexeme}, nil
-		} else if strings.Contains(lexeme, ".") {
-			return turtleToken{Kind: TokDecimal, Lexeme: lexeme}, nil
-		} else {
-			return turtleToken{Kind: TokInteger, Lexeme: lexeme}, nil
+	if digits == 0 {
+		return TokError, "", fmt.Errorf("invalid numeric literal %q", string(l.buf))
+	}
+	if l.exponentAt(0) {
+		if err := l.take(); err != nil {
+			return TokError, "", err
+		}
+		if ch, _ := l.peekByte(0); ch == '+' || ch == '-' {
+			if err := l.take(); err != nil {
+				return TokError, "", err
+			}
 		}
+		if _, err := l.scanDigits(); err != nil {
+			return TokError, "", err
+		}
+		kind = TokDouble
+	}
+	if ch, ok := l.peekByte(0); ok && (isPNChars(ch) || ch == ':') {
+		return TokError, "", fmt.Errorf("invalid numeric literal %q", string(l.buf)+string(ch))
 	}
-	return turtleToken{Kind: TokError, Lexeme: lexeme, Err: ErrUnsupportedFormat}, nil
+	return kind, string(l.buf), nil
 }
 
-func (s *turtleScanner) skipWS() {
-	for s.pos < len(s.input) {
-		switch s.input[s.pos] {
-		case ' ', '\t', '\r', '\n':
-			s.pos++
-		default:
-			return
+func (l *turtleLexer) scanDigits() (int, error) {
+	n := 0
+	for {
+		ch, ok := l.peekByte(0)
+		if !ok || !isDigit(ch) {
+			return n, nil
 		}
+		if err := l.take(); err != nil {
+			return n, err
+		}
+		n++
 	}
 }
 
-func (s *turtleScanner) match(prefix string) bool {
-	return strings.HasPrefix(s.input[s.pos:], prefix)
+// exponentAt reports whether an exponent ([eE][+-]?[0-9]) starts n bytes ahead.
+func (l *turtleLexer) exponentAt(n int) bool {
+	ch, _ := l.peekByte(n)
+	if ch != 'e' && ch != 'E' {
+		return false
+	}
+	next, _ := l.peekByte(n + 1)
+	if next == '+' || next == '-' {
+		next, _ = l.peekByte(n + 2)
+	}
+	return isDigit(next)
 }
 
-func isNumericLiteral(value string) bool {
-	if value == "" {
-		return false
+// scanName scans prefixed names and the bare keywords a, true, false,
+// PREFIX, BASE, VERSION and GRAPH.
+func (l *turtleLexer) scanName() (turtleTokenKind, string, error) {
+	l.buf = l.buf[:0]
+	if err := l.scanNameChars(true); err != nil {
+		return TokError, "", err
 	}
-	for i := 0; i < len(value); i++ {
-		ch := value[i]
-		if (ch >= '0' && ch <= '9') || ch == '.' || ch == '+' || ch == '-' || ch == 'e' || ch == 'E' {
-			continue
+	word := string(l.buf)
+	colon := strings.IndexByte(word, ':')
+	if colon < 0 {
+		switch {
+		case word == "a":
+			return TokA, word, nil
+		case word == "true" || word == "false":
+			return TokBoolean, word, nil
+		case strings.EqualFold(word, lexPrefixBare):
+			return TokPrefix, word, nil
+		case strings.EqualFold(word, lexBaseBare):
+			return TokBase, word, nil
+		case strings.EqualFold(word, lexVersionBare):
+			return TokVersion, word, nil
+		case strings.EqualFold(word, lexGraphBare):
+			return TokGraph, word, nil
+		}
+		return TokError, "", fmt.Errorf("unexpected token %q", word)
+	}
+	if colon == len(word)-1 {
+		return TokPNAMENS, word, nil
+	}
+	return TokPNAMELN, word, nil
+}
+
+// scanNameChars appends name characters to the token buffer. Dots are only
+// consumed when another name character follows them, since a trailing dot
+// terminates the statement. When allowColon is set, ':' and the PN_LOCAL
+// escapes ('\' followed by a character) are accepted too.
+func (l *turtleLexer) scanNameChars(allowColon bool) error {
+	for {
+		ch, ok := l.peekByte(0)
+		if !ok {
+			return nil
+		}
+		switch {
+		case ch == '.':
+			// Look past a run of dots for the character that decides
+			// whether they belong to the name.
+			n := 1
+			for next, _ := l.peekByte(n); next == '.'; next, _ = l.peekByte(n) {
+				n++
+			}
+			next, _ := l.peekByte(n)
+			if !(isPNChars(next) || (allowColon && (next == ':' || next == '%' || next == '\\'))) {
+				return nil
+			}
+		case ch == '\\' && allowColon:
+			if err := l.take(); err != nil {
+				return err
+			}
+			if _, ok := l.peekByte(0); !ok {
+				return &truncatedError{fmt.Errorf("unterminated escape")}
+			}
+		case ch == ':' || ch == '%':
+			if !allowColon {
+				return nil
+			}
+		case !isPNChars(ch):
+			return nil
+		}
+		if err := l.take(); err != nil {
+			return err
 		}
-		return false
 	}
-	return true
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isASCIILetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+// isPNCharsBase reports whether ch may start a name. Bytes of multi-byte
+// UTF-8 sequences are accepted as a whole; the grammar's exclusions are rare
+// enough to leave to IRI validation.
+func isPNCharsBase(ch byte) bool {
+	return isASCIILetter(ch) || ch >= 0x80
+}
+
+func isPNChars(ch byte) bool {
+	return isPNCharsBase(ch) || isDigit(ch) || ch == '_' || ch == '-'
 }
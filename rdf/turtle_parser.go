@@ -1,36 +1,77 @@
 package rdf
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 )
 
+// turtleParser is a recursive-descent parser for Turtle and TriG that pulls
+// tokens from a streaming turtleLexer with a single token of lookahead.
 type turtleParser struct {
 	lexer                      *turtleLexer
 	opts                       decodeOptions
+	format                     string
+	trig                       bool
 	prefixes                   map[string]string
+	prefixCount                int // Prefix directives read, for MaxPrefixCount
 	baseIRI                    string
 	allowQuotedTripleStatement bool
+	tok                        turtleToken
+	hasTok                     bool
+	lastEnd                    int // End of the last token consumed, for EventReader
 	pending                    []Triple
-	expansionTriples           []Triple // Triples from collections and blank node lists
+	expansionTriples           []Triple // Triples from collections, blank node lists and reifiers
 	blankNodeCounter           int
-	tripleCount                int64 // Number of triples processed
-	err                        error
+	// graph is the graph of the TriG block being parsed and stmtGraph the
+	// graph of the triples in pending.
+	graph     Term
+	inGraph   bool
+	stmtGraph Term
+	err       error
+	// stmtTriples and stmtBlankNodes record the triple count and the blank
+	// node counter at the start of the statement in pending, for
+	// exportState; skip drops triples already returned before a resume.
+	stmtTriples    int
+	stmtBlankNodes int
+	skip           int
+	resumed        bool
 }
 
 func newTurtleParser(r io.Reader, opts decodeOptions) *turtleParser {
 	if opts.AllowEnvOverrides && os.Getenv("TURTLE_ALLOW_QT_STMT") != "" {
 		opts.AllowQuotedTripleStatement = true
 	}
-	return &turtleParser{
+	p := &turtleParser{
 		lexer:                      newTurtleLexer(r, opts),
 		opts:                       normalizeDecodeOptions(opts),
+		format:                     "turtle",
 		prefixes:                   map[string]string{},
 		allowQuotedTripleStatement: opts.AllowQuotedTripleStatement,
+		baseIRI:                    opts.BaseIRI,
 		// blankNodeCounter uses zero value (0)
 	}
+	if p.shouldDebugStatements() {
+		p.lexer.capture = &bytes.Buffer{}
+	}
+	if opts.resume != nil {
+		p.restoreState(opts.resume)
+	}
+	return p
+}
+
+// newTriGParser returns a parser that additionally accepts TriG graph blocks.
+func newTriGParser(r io.Reader, opts decodeOptions) *turtleParser {
+	if opts.AllowEnvOverrides && os.Getenv("TRIG_ALLOW_QT_STMT") != "" {
+		opts.AllowQuotedTripleStatement = true
+	}
+	p := newTurtleParser(r, opts)
+	p.format = "trig"
+	p.trig = true
+	return p
 }
 
 func (p *turtleParser) newBlankNode() BlankNode {
@@ -57,31 +98,34 @@ func (p *turtleParser) NextTriple() (Triple, error) {
 	return triple, err
 }
 
-// readNextTriple reads and parses the next triple from the input stream.
+func (p *turtleParser) Err() error { return p.err }
+
+// readNextTriple parses statements until one of them produces triples.
 func (p *turtleParser) readNextTriple() (Triple, error) {
 	for {
 		if err := checkDecodeContext(p.opts.Context); err != nil {
 			return Triple{}, err
 		}
-
-		statement, err := p.readStatementLines()
+		p.stmtBlankNodes = p.blankNodeCounter
+		triples, err := p.parseStatement()
 		if err != nil {
+			if err != io.EOF && p.lexer.ioErr == nil && p.opts.errors.recover(err) {
+				p.skipStatement()
+				continue
+			}
 			return Triple{}, err
 		}
-
-		if statement == "" {
-			return Triple{}, io.EOF
-		}
-
-		triples, err := p.parseStatement(statement)
-		if err != nil {
+		if err := p.checkBufferBudget(triples); err != nil {
 			return Triple{}, err
 		}
-
+		p.stmtTriples = len(triples)
+		if p.skip > 0 {
+			n := min(p.skip, len(triples))
+			triples, p.skip = triples[n:], p.skip-n
+		}
 		if len(triples) == 0 {
 			continue
 		}
-
 		if len(triples) > 1 {
 			p.pending = triples[1:]
 		}
@@ -89,155 +133,306 @@ func (p *turtleParser) readNextTriple() (Triple, error) {
 	}
 }
 
-// readStatementLines reads lines from the lexer and builds a complete statement.
-func (p *turtleParser) readStatementLines() (string, error) {
-	var statement strings.Builder
-	for {
-		if err := checkDecodeContext(p.opts.Context); err != nil {
-			return "", err
-		}
-
-		token := p.lexer.Next()
-		switch token.Kind {
-		case TokEOF:
-			if statement.Len() == 0 {
-				return "", io.EOF
-			}
-			return strings.TrimSpace(statement.String()), nil
-
-		case TokError:
-			return "", token.Err
-
-		case TokLine:
-			// Quick check: if line looks like a directive, tokenize and parse it
-			if statement.Len() == 0 && p.isLikelyDirective(token.Lexeme) {
-				tokens, err := tokenizeTurtleLine(token.Lexeme)
-				if err == nil {
-					if handled, _ := p.parseDirectiveTokens(tokens); handled {
-						continue
-					}
-				}
-			}
-
-			if err := p.appendStatementPart(&statement, token.Lexeme); err != nil {
-				return "", err
-			}
-
-			stmt := strings.TrimSpace(statement.String())
-			if stmt != "" && isStatementComplete(stmt) {
-				return stmt, nil
-			}
-		}
+// checkBufferBudget fails if the triples of a statement, held until they
+// are returned, exceed MaxBufferedBytes.
+func (p *turtleParser) checkBufferBudget(triples []Triple) error {
+	max := p.opts.MaxBufferedBytes
+	if max <= 0 {
+		return nil
 	}
-}
-
-func (p *turtleParser) parseStatement(line string) ([]Triple, error) {
-	tokens, err := tokenizeTurtleLine(line)
-	if err != nil {
-		return nil, err
+	var size int64
+	for _, t := range triples {
+		size += tripleBytes(t)
 	}
-	if handled, err := p.parseDirectiveTokens(tokens); err != nil {
-		return nil, err
-	} else if handled {
-		return nil, nil
+	if size <= max {
+		return nil
 	}
-	return p.parseTriplesTokens(tokens, line)
+	l := p.lexer
+	return wrapParseErrorWithPosition(p.format, l.statementText(), l.stmtLine, l.stmtColumn, l.stmtStart, errBufferBudget(max))
 }
 
-func (p *turtleParser) Err() error { return p.err }
-
-func (p *turtleParser) appendStatementPart(builder *strings.Builder, part string) error {
-	if builder.Len() > 0 {
-		builder.WriteString(" ")
+// parseStatement parses one directive, graph block delimiter or triples
+// statement. It returns io.EOF at the end of the input.
+func (p *turtleParser) parseStatement() ([]Triple, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case TokEOF:
+		// A resumed parser reads a byte range that may end at a split point
+		// inside a graph block.
+		if p.inGraph && !p.resumed {
+			return nil, p.errorf(tok, "expected '}'")
+		}
+		return nil, io.EOF
+	case TokError:
+		return nil, p.fail(tok, tok.Err)
+	case TokPrefix, TokBase, TokVersion:
+		if p.inGraph {
+			return nil, p.errorf(tok, "directives not allowed inside graph")
+		}
+		err := p.parseDirective()
+		p.lexer.beginStatement()
+		return nil, err
 	}
-	builder.WriteString(part)
-	if p.opts.MaxStatementBytes > 0 && builder.Len() > p.opts.MaxStatementBytes {
-		return ErrStatementTooLong
+	if p.trig {
+		handled, err := p.parseGraphDelimiter()
+		if handled || err != nil {
+			p.lexer.beginStatement()
+			return nil, err
+		}
 	}
-	return nil
-}
-
-func (p *turtleParser) wrapParseError(statement string, err error) error {
-	if p.shouldDebugStatements() {
-		return wrapParseError("turtle", statement, -1, err)
+	line, column, offset := p.lexer.stmtLine, p.lexer.stmtColumn, p.lexer.stmtStart
+	triples, err := p.parseTriples()
+	p.lexer.beginStatement()
+	if err != nil {
+		return nil, err
 	}
-	return wrapParseError("turtle", "", -1, err)
+	if p.opts.events != nil && len(triples) > 0 {
+		p.opts.events.statement(line, column, offset, p.lastEnd)
+	}
+	p.stmtGraph = p.graph
+	return triples, nil
 }
 
-// isLikelyDirective performs a quick string-based check to see if a line might be a directive.
-// This is used for early detection before tokenization. The actual parsing is done by parseDirectiveTokens.
-func (p *turtleParser) isLikelyDirective(line string) bool {
-	line = strings.TrimSpace(line)
-	if len(line) == 0 {
-		return false
-	}
-	// Check for @-prefixed directives first (most common)
-	if line[0] == '@' {
-		return strings.HasPrefix(line, directiveAtPrefix) ||
-			strings.HasPrefix(line, directiveAtBase) ||
-			strings.HasPrefix(line, directiveAtVersion)
+// skipStatement discards tokens up to and including the '.' ending the
+// statement that failed to parse, or the '}' closing the enclosing graph
+// block, so parsing can resume after a recovered syntax error. Graph blocks
+// opened while skipping are skipped whole. It stops before a directive, so
+// that a BASE or PREFIX following a statement missing its '.' still applies
+// to the statements after it.
+func (p *turtleParser) skipStatement() {
+	p.pending = nil
+	p.expansionTriples = nil
+	defer p.lexer.beginStatement()
+	depth := 0
+	prev := TokError
+	for {
+		tok := p.peek()
+		p.hasTok = false
+		switch tok.Kind {
+		case TokPrefix, TokBase, TokVersion:
+			// After a string, "@prefix" and the like are language tags, and
+			// directives inside a graph block are themselves the error.
+			if depth == 0 && !p.inGraph && prev != TokString && prev != TokStringLong {
+				p.hasTok = true
+				return
+			}
+		case TokEOF:
+			p.hasTok = true
+			p.inGraph, p.graph = false, nil
+			return
+		case TokError:
+			if p.lexer.ioErr != nil || Code(tok.Err) != ErrCodeParseError {
+				p.hasTok = true
+				return
+			}
+		case TokDot:
+			if depth == 0 {
+				return
+			}
+		case TokLBrace:
+			if p.trig {
+				depth++
+			}
+		case TokRBrace:
+			if depth > 0 {
+				depth--
+				if depth == 0 {
+					return
+				}
+			} else if p.inGraph {
+				p.closeGraph(tok)
+				return
+			}
+		}
+		prev = tok.Kind
 	}
-	// Check for bare directives (case-insensitive)
-	upper := strings.ToUpper(line)
-	return strings.HasPrefix(upper, directivePrefix) ||
-		strings.HasPrefix(upper, directiveBase) ||
-		strings.HasPrefix(upper, directiveVersion)
 }
 
-func (p *turtleParser) parseDirectiveTokens(tokens []turtleToken) (bool, error) {
-	if len(tokens) == 0 {
-		return false, nil
-	}
-	switch tokens[0].Kind {
+func (p *turtleParser) parseDirective() error {
+	tok := p.next()
+	// @prefix, @base and @version end with '.'; the SPARQL-style forms don't.
+	atForm := strings.HasPrefix(tok.Lexeme, "@")
+	switch tok.Kind {
 	case TokPrefix:
-		if tokens[0].Lexeme != lexPrefix && !strings.EqualFold(tokens[0].Lexeme, lexPrefixBare) {
-			return false, nil
+		prefixTok := p.next()
+		if prefixTok.Kind != TokPNAMENS {
+			return p.errorf(prefixTok, "expected prefix name")
 		}
-		if len(tokens) < 3 {
-			return false, nil
+		prefix := strings.TrimSuffix(prefixTok.Lexeme, ":")
+		if !isValidPrefixName(prefix) {
+			return p.errorf(prefixTok, "invalid prefix name %q", prefix)
 		}
-		prefixTok := tokens[1]
-		iriTok := tokens[2]
-		if prefixTok.Kind != TokPNAMENS || iriTok.Kind != TokIRIRef {
-			return false, nil
+		iriTok := p.next()
+		if iriTok.Kind != TokIRIRef {
+			return p.errorf(iriTok, "expected IRI")
+		}
+		iri, err := p.resolveIRIRef(iriTok)
+		if err != nil {
+			return err
+		}
+		p.prefixCount++
+		if p.opts.MaxPrefixCount > 0 && p.prefixCount > p.opts.MaxPrefixCount {
+			return p.fail(prefixTok, ErrPrefixLimitExceeded)
+		}
+		if previous, ok := p.prefixes[prefix]; ok {
+			p.warnf(prefixTok, WarnDuplicatePrefix, "prefix %q redeclared (was <%s>, now <%s>)", prefix, previous, iri)
 		}
-		prefix := strings.TrimSuffix(prefixTok.Lexeme, ":")
-		iri := strings.Trim(iriTok.Lexeme, "<>")
 		p.prefixes[prefix] = iri
-		return true, nil
+		if p.opts.onPrefix != nil {
+			p.opts.onPrefix(prefix, iri)
+		}
+		p.emit(PrefixEvent{Prefix: prefix, IRI: iri, Line: tok.Line, Column: tok.Column})
 	case TokBase:
-		if tokens[0].Lexeme != lexBase && !strings.EqualFold(tokens[0].Lexeme, lexBaseBare) {
-			return false, nil
+		iriTok := p.next()
+		if iriTok.Kind != TokIRIRef {
+			return p.errorf(iriTok, "expected IRI")
 		}
-		if len(tokens) < 2 {
-			return false, nil
+		iri, err := p.resolveIRIRef(iriTok)
+		if err != nil {
+			return err
 		}
-		iriTok := tokens[1]
-		if iriTok.Kind != TokIRIRef {
-			return false, nil
+		p.baseIRI = iri
+		if p.opts.onBase != nil {
+			p.opts.onBase(iri)
 		}
-		p.baseIRI = strings.Trim(iriTok.Lexeme, "<>")
-		return true, nil
+		p.emit(BaseEvent{IRI: iri, Line: tok.Line, Column: tok.Column})
 	case TokVersion:
-		if tokens[0].Lexeme != lexVersion && !strings.EqualFold(tokens[0].Lexeme, lexVersionBare) {
-			return false, nil
+		versionTok := p.next()
+		if versionTok.Kind != TokString {
+			return p.errorf(versionTok, "expected version string")
 		}
 		p.allowQuotedTripleStatement = true
+		if p.opts.events != nil {
+			version, err := UnescapeString(versionTok.Lexeme[1 : len(versionTok.Lexeme)-1])
+			if err != nil {
+				return p.errorf(versionTok, "%v", err)
+			}
+			p.emit(VersionEvent{Version: version, Line: tok.Line, Column: tok.Column})
+		}
+	}
+	if atForm {
+		return p.expect(TokDot, "'.'")
+	}
+	return nil
+}
+
+// parseGraphDelimiter handles the TriG-only tokens that open and close graph
+// blocks: "{", "}", "GRAPH label {" and "label {". Triples statements are
+// left to the caller.
+func (p *turtleParser) parseGraphDelimiter() (bool, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case TokRBrace:
+		if !p.inGraph {
+			return true, p.errorf(tok, "unexpected '}'")
+		}
+		p.next()
+		p.closeGraph(tok)
 		return true, nil
+	case TokLBrace:
+		if p.inGraph {
+			return true, p.errorf(tok, "nested graph blocks are not allowed")
+		}
+		p.next()
+		p.openGraph(tok, nil)
+		return true, nil
+	case TokGraph:
+		if p.inGraph {
+			return true, p.errorf(tok, "nested graph blocks are not allowed")
+		}
+		p.next()
+		if p.peek().Kind == TokLBrace {
+			return true, p.errorf(p.peek(), "expected graph name")
+		}
+		label, err := p.parseGraphLabel()
+		if err != nil {
+			return true, err
+		}
+		if err := p.expect(TokLBrace, "'{'"); err != nil {
+			return true, err
+		}
+		p.openGraph(tok, label)
+		return true, nil
+	}
+	return false, nil
+}
+
+// openGraph starts the TriG graph block of graph, opened at tok.
+func (p *turtleParser) openGraph(tok turtleToken, graph Term) {
+	p.graph = graph
+	p.inGraph = true
+	p.emit(GraphStartEvent{Graph: graph, Line: tok.Line, Column: tok.Column})
+}
+
+// closeGraph ends the TriG graph block closed by tok.
+func (p *turtleParser) closeGraph(tok turtleToken) {
+	p.emit(GraphEndEvent{Graph: p.graph, Line: tok.Line, Column: tok.Column})
+	p.graph = nil
+	p.inGraph = false
+}
+
+// emit reports e to the event sink of an EventReader.
+func (p *turtleParser) emit(e Event) {
+	if p.opts.events != nil {
+		p.opts.events.event(e)
+	}
+}
+
+// parseGraphLabel parses a graph name: an IRI, a blank node label or "[]".
+func (p *turtleParser) parseGraphLabel() (Term, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case TokIRIRef, TokPNAMELN, TokPNAMENS, TokBlankNode:
+		return p.parseTerm(false, 0)
+	case TokLBracket:
+		p.next()
+		if p.peek().Kind != TokRBracket {
+			return nil, p.errorf(tok, "invalid graph name")
+		}
+		p.next()
+		return p.newBlankNode(), nil
 	default:
-		return false, nil
+		return nil, p.errorf(tok, "invalid graph name")
 	}
 }
 
-func (p *turtleParser) parseTriplesTokens(tokens []turtleToken, line string) ([]Triple, error) {
-	stream := &turtleTokenStream{tokens: tokens}
-	subject, err := p.parseSubjectTokens(stream)
+// parseTriples parses "subject predicateObjectList ." and the statement forms
+// that omit the predicate object list. In TriG it also recognizes
+// "label {" as the start of a named graph block.
+func (p *turtleParser) parseTriples() ([]Triple, error) {
+	start := p.peek()
+	expansionStart := len(p.expansionTriples)
+	subject, err := p.parseSubject()
 	if err != nil {
-		return nil, p.wrapParseError(line, err)
+		return nil, err
 	}
-	triples, err := p.parsePredicateObjectListTokens(stream, subject)
-	if err != nil {
-		return nil, p.wrapParseError(line, err)
+
+	if p.trig && p.peek().Kind == TokLBrace {
+		if p.inGraph {
+			return nil, p.errorf(p.peek(), "nested graph blocks are not allowed")
+		}
+		if !isGraphLabelStart(start.Kind) || len(p.expansionTriples) > expansionStart {
+			return nil, p.errorf(start, "invalid graph name")
+		}
+		switch subject.(type) {
+		case IRI, BlankNode:
+		default:
+			return nil, p.errorf(start, "invalid graph name")
+		}
+		p.next()
+		p.openGraph(start, subject)
+		return nil, nil
+	}
+
+	var triples []Triple
+	if !p.atStatementEnd() || !p.allowsBareSubject(start, len(p.expansionTriples) > expansionStart) {
+		triples, err = p.parsePredicateObjectList(subject, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.endStatement(); err != nil {
+		return nil, err
 	}
 	// Add expansion triples (from collections and blank node lists)
 	triples = append(triples, p.expansionTriples...)
@@ -248,521 +443,548 @@ func (p *turtleParser) parseTriplesTokens(tokens []turtleToken, line string) ([]
 	} else {
 		p.expansionTriples = p.expansionTriples[:0]
 	}
-	if stream.peek().Kind == TokDot {
-		stream.next()
+	return triples, nil
+}
+
+func (p *turtleParser) parseSubject() (Term, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case TokLDoubleAngle:
+		term, reified, err := p.parseTripleTerm(0)
+		if err != nil {
+			return nil, err
+		}
+		if reified {
+			return nil, p.errorf(tok, "reified triple term cannot be used as subject")
+		}
+		return term, nil
+	case TokString, TokStringLong, TokInteger, TokDecimal, TokDouble, TokBoolean:
+		return nil, p.errorf(tok, "literal not allowed as subject")
 	}
-	if stream.peek().Kind != TokEOF {
-		return nil, p.wrapParseError(line, fmt.Errorf("unexpected token after statement: %v", stream.peek().Kind))
+	return p.parseTerm(false, 0)
+}
+
+// allowsBareSubject reports whether a subject may be followed directly by
+// the statement terminator: a non-empty blank node property list, a triple
+// term with a reifier, or any triple term when quoted triple statements are
+// enabled.
+func (p *turtleParser) allowsBareSubject(start turtleToken, expanded bool) bool {
+	switch start.Kind {
+	case TokLBracket:
+		return expanded
+	case TokLDoubleAngle:
+		return expanded || p.allowQuotedTripleStatement
 	}
-	return triples, nil
+	return false
 }
 
-func (p *turtleParser) parseSubjectTokens(stream *turtleTokenStream) (Term, error) {
-	return p.parseTermTokens(stream, false)
+func (p *turtleParser) atStatementEnd() bool {
+	switch p.peek().Kind {
+	case TokDot, TokEOF:
+		return true
+	case TokRBrace:
+		return p.inGraph
+	}
+	return false
 }
 
-func (p *turtleParser) parsePredicateObjectListTokens(stream *turtleTokenStream, subject Term) ([]Triple, error) {
+// endStatement consumes the '.' that terminates a statement. Inside a TriG
+// graph block the last statement may omit it.
+func (p *turtleParser) endStatement() error {
+	tok := p.peek()
+	if tok.Kind == TokDot {
+		p.next()
+		return nil
+	}
+	if p.inGraph && (tok.Kind == TokRBrace || tok.Kind == TokEOF && p.resumed) {
+		return nil
+	}
+	if tok.Kind == TokError {
+		return p.fail(tok, tok.Err)
+	}
+	return p.errorf(tok, "expected ',' or ';' or '.'")
+}
+
+func (p *turtleParser) parsePredicateObjectList(subject Term, depth int) ([]Triple, error) {
 	var triples []Triple
 	for {
-		predicate, err := p.parseVerbTokens(stream)
+		predicate, err := p.parseVerb()
 		if err != nil {
 			return nil, err
 		}
-		objectTriples, err := p.parseObjectListTokens(stream, subject, predicate)
+		triples, err = p.parseObjectList(triples, subject, predicate, depth)
 		if err != nil {
 			return nil, err
 		}
-		triples = append(triples, objectTriples...)
-
-		next := stream.peek().Kind
-		if next == TokSemicolon {
-			p.skipSemicolons(stream)
-			if stream.peek().Kind == TokDot || stream.peek().Kind == TokEOF {
-				return triples, nil
-			}
-			continue
+		if p.peek().Kind != TokSemicolon {
+			return triples, nil
+		}
+		// Repeated and trailing semicolons are allowed.
+		for p.peek().Kind == TokSemicolon {
+			p.next()
+		}
+		if !p.startsVerb() {
+			return triples, nil
 		}
-		return triples, nil
 	}
 }
 
-// skipSemicolons consumes one or more semicolon tokens.
-func (p *turtleParser) skipSemicolons(stream *turtleTokenStream) {
-	for stream.peek().Kind == TokSemicolon {
-		stream.next()
+func (p *turtleParser) startsVerb() bool {
+	switch p.peek().Kind {
+	case TokA, TokIRIRef, TokPNAMELN, TokPNAMENS:
+		return true
 	}
+	return false
 }
 
-func (p *turtleParser) parseVerbTokens(stream *turtleTokenStream) (IRI, error) {
-	tok := stream.peek()
-	if tok.Kind == TokA {
-		stream.next()
+func (p *turtleParser) parseVerb() (IRI, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case TokA:
+		p.next()
 		return IRI{Value: rdfTypeIRI}, nil
+	case TokIRIRef, TokPNAMELN, TokPNAMENS:
+		term, err := p.parseTerm(false, 0)
+		if err != nil {
+			return IRI{}, err
+		}
+		return term.(IRI), nil
+	case TokError:
+		return IRI{}, p.fail(tok, tok.Err)
 	}
-	term, err := p.parseTermTokens(stream, false)
-	if err != nil {
-		return IRI{}, err
-	}
-	iri, ok := term.(IRI)
-	if !ok {
-		return IRI{}, p.wrapParseError("", fmt.Errorf("predicate must be IRI, got %T", term))
-	}
-	return iri, nil
+	return IRI{}, p.errorf(tok, "predicate must be IRI, got %v", tok.Kind)
 }
 
-func (p *turtleParser) parseObjectListTokens(stream *turtleTokenStream, subject Term, predicate IRI) ([]Triple, error) {
-	var triples []Triple
+// parseObjectList parses "object annotation? (',' object annotation?)*",
+// appending the triples to out.
+func (p *turtleParser) parseObjectList(out []Triple, subject Term, predicate IRI, depth int) ([]Triple, error) {
 	for {
-		obj, err := p.parseTermTokens(stream, true)
+		object, err := p.parseObject(depth)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Triple{S: subject, P: predicate, O: object})
+		out, err = p.parseAnnotations(out, subject, predicate, object, depth)
 		if err != nil {
 			return nil, err
 		}
-		triples = append(triples, Triple{S: subject, P: predicate, O: obj})
+		if p.peek().Kind != TokComma {
+			return out, nil
+		}
+		p.next()
+	}
+}
 
-		// Handle annotations after object: {| ... |}
-		if stream.peek().Kind == TokAnnotationL {
-			annotationTriples, err := p.parseAnnotationTokens(stream, obj)
+// parseAnnotations parses the reifiers ("~ id?") and annotation blocks
+// ("{| ... |}") that may follow an object. Each names the triple through a
+// reifier, with an rdf:reifies triple recorded as an expansion triple.
+func (p *turtleParser) parseAnnotations(out []Triple, subject Term, predicate IRI, object Term, depth int) ([]Triple, error) {
+	var reifier Term
+	for {
+		switch p.peek().Kind {
+		case TokTilde:
+			var err error
+			reifier, err = p.parseReifier(subject, predicate, object)
 			if err != nil {
 				return nil, err
 			}
-			triples = append(triples, annotationTriples...)
+		case TokAnnotationL:
+			if p.opts.MaxDepth > 0 && depth >= p.opts.MaxDepth {
+				return nil, p.fail(p.peek(), ErrDepthExceeded)
+			}
+			if reifier == nil {
+				reifier = p.newBlankNode()
+				p.addReification(reifier, subject, predicate, object)
+			}
+			p.next()
+			var err error
+			out, err = p.parsePredicateObjectListInto(out, reifier, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(TokAnnotationR, "'|}'"); err != nil {
+				return nil, err
+			}
+			// A new annotation block needs a new reifier unless one is given.
+			reifier = nil
+		default:
+			return out, nil
 		}
+	}
+}
 
-		if stream.peek().Kind == TokComma {
-			stream.next()
-			continue
+func (p *turtleParser) parsePredicateObjectListInto(out []Triple, subject Term, depth int) ([]Triple, error) {
+	triples, err := p.parsePredicateObjectList(subject, depth)
+	if err != nil {
+		return nil, err
+	}
+	return append(out, triples...), nil
+}
+
+// parseReifier parses "~" followed by an optional IRI or blank node naming
+// the reifier. A fresh blank node is used when the name is omitted.
+func (p *turtleParser) parseReifier(subject Term, predicate IRI, object Term) (Term, error) {
+	p.next() // '~'
+	var reifier Term
+	switch p.peek().Kind {
+	case TokIRIRef, TokPNAMELN, TokPNAMENS, TokBlankNode:
+		term, err := p.parseTerm(false, 0)
+		if err != nil {
+			return nil, err
 		}
-		return triples, nil
+		reifier = term
+	case TokLBracket:
+		tok := p.next()
+		if p.peek().Kind != TokRBracket {
+			return nil, p.errorf(tok, "reifier must be IRI or blank node")
+		}
+		p.next()
+		reifier = p.newBlankNode()
+	default:
+		reifier = p.newBlankNode()
 	}
+	p.addReification(reifier, subject, predicate, object)
+	return reifier, nil
+}
+
+func (p *turtleParser) addReification(reifier Term, subject Term, predicate IRI, object Term) {
+	p.expansionTriples = append(p.expansionTriples, Triple{
+		S: reifier,
+		P: IRI{Value: rdfReifiesIRI},
+		O: TripleTerm{S: subject, P: predicate, O: object},
+	})
 }
 
-func (p *turtleParser) parseTermTokens(stream *turtleTokenStream, allowLiteral bool) (Term, error) {
-	return p.parseTermTokensWithDepth(stream, allowLiteral, 0)
+func (p *turtleParser) parseObject(depth int) (Term, error) {
+	return p.parseTerm(true, depth)
 }
 
-func (p *turtleParser) parseTermTokensWithDepth(stream *turtleTokenStream, allowLiteral bool, depth int) (Term, error) {
-	tok := stream.peek()
+// parseTerm parses a single RDF term. Literals are only accepted when
+// allowLiteral is set.
+func (p *turtleParser) parseTerm(allowLiteral bool, depth int) (Term, error) {
+	tok := p.peek()
 	switch tok.Kind {
 	case TokIRIRef:
-		stream.next()
-		iri := strings.Trim(tok.Lexeme, "<>")
-		if p.baseIRI != "" {
-			iri = resolveIRI(p.baseIRI, iri)
-		}
-		// Validate IRI if strict validation is enabled
-		if p.opts.StrictIRIValidation {
-			if err := ValidateIRI(iri); err != nil {
-				return nil, p.wrapParseError("", fmt.Errorf("invalid IRI: %w", err))
-			}
-		}
-		return IRI{Value: iri}, nil
-	case TokPNAMENS:
-		stream.next()
-		prefix := strings.TrimSuffix(tok.Lexeme, ":")
-		base, ok := p.prefixes[prefix]
-		if !ok {
-			return nil, p.wrapParseError("", fmt.Errorf("undefined prefix: %s", prefix))
-		}
-		// Validate IRI if strict validation is enabled
-		if p.opts.StrictIRIValidation {
-			if err := ValidateIRI(base); err != nil {
-				return nil, p.wrapParseError("", fmt.Errorf("invalid IRI from prefix %s: %w", prefix, err))
-			}
-		}
-		return IRI{Value: base}, nil
-	case TokPNAMELN:
-		stream.next()
-		parts := strings.SplitN(tok.Lexeme, ":", 2)
-		if len(parts) != 2 {
-			return nil, p.wrapParseError("", fmt.Errorf("invalid prefixed name: %s", tok.Lexeme))
-		}
-		base, ok := p.prefixes[parts[0]]
-		if !ok {
-			return nil, p.wrapParseError("", fmt.Errorf("undefined prefix: %s", parts[0]))
-		}
-		iri := base + parts[1]
-		// Validate IRI if strict validation is enabled
-		if p.opts.StrictIRIValidation {
-			if err := ValidateIRI(iri); err != nil {
-				return nil, p.wrapParseError("", fmt.Errorf("invalid IRI from prefixed name %s: %w", tok.Lexeme, err))
-			}
+		p.next()
+		iri, err := p.resolveIRIRef(tok)
+		if err != nil {
+			return nil, err
 		}
 		return IRI{Value: iri}, nil
+	case TokPNAMENS, TokPNAMELN:
+		p.next()
+		return p.expandPrefixedName(tok)
 	case TokBlankNode:
-		stream.next()
-		return BlankNode{ID: tok.Lexeme[2:]}, nil // Skip "_:"
+		p.next()
+		id := tok.Lexeme[len(lexBlankNode):]
+		if !isValidBlankNodeLabel(id) {
+			return nil, p.errorf(tok, "invalid blank node label %q", tok.Lexeme)
+		}
+		return BlankNode{ID: id}, nil
 	case TokString, TokStringLong:
-		return p.parseLiteralTokens(stream, allowLiteral)
-	case TokInteger, TokDecimal, TokDouble:
-		stream.next()
-		return p.parseNumericLiteralToken(tok)
-	case TokBoolean:
-		stream.next()
-		return p.parseBooleanLiteralToken(tok)
+		if !allowLiteral {
+			return nil, p.errorf(tok, "literal not allowed here")
+		}
+		return p.parseLiteral()
+	case TokInteger, TokDecimal, TokDouble, TokBoolean:
+		if !allowLiteral {
+			return nil, p.errorf(tok, "literal not allowed here")
+		}
+		p.next()
+		return Literal{Lexical: tok.Lexeme, Datatype: IRI{Value: turtleLiteralDatatype(tok.Kind)}}, nil
 	case TokLBracket:
-		return p.parseBlankNodePropertyListTokens(stream, depth)
+		return p.parseBlankNodePropertyList(depth)
 	case TokLParen:
-		return p.parseCollectionTokens(stream, depth)
+		return p.parseCollection(depth)
 	case TokLDoubleAngle:
-		return p.parseTripleTermTokensWithDepth(stream, depth)
+		term, _, err := p.parseTripleTerm(depth)
+		return term, err
 	case TokLangTag:
 		// Language tags should only appear after string literals, not as standalone tokens
-		return nil, p.wrapParseError("", fmt.Errorf("unexpected language tag (must follow a string literal)"))
+		return nil, p.errorf(tok, "unexpected language tag (must follow a string literal)")
 	case TokDatatypePrefix:
 		// Datatype prefix should only appear after string literals, not as standalone tokens
-		return nil, p.wrapParseError("", fmt.Errorf("unexpected datatype prefix (must follow a string literal)"))
+		return nil, p.errorf(tok, "unexpected datatype prefix (must follow a string literal)")
+	case TokError:
+		return nil, p.fail(tok, tok.Err)
+	case TokEOF:
+		return nil, p.errorf(tok, "unexpected end of input")
+	default:
+		return nil, p.errorf(tok, "unexpected token: %v", tok.Kind)
+	}
+}
+
+func turtleLiteralDatatype(kind turtleTokenKind) string {
+	switch kind {
+	case TokDouble:
+		return "http://www.w3.org/2001/XMLSchema#double"
+	case TokDecimal:
+		return "http://www.w3.org/2001/XMLSchema#decimal"
+	case TokBoolean:
+		return "http://www.w3.org/2001/XMLSchema#boolean"
 	default:
-		return nil, p.wrapParseError("", fmt.Errorf("unexpected token: %v", tok.Kind))
+		return "http://www.w3.org/2001/XMLSchema#integer"
 	}
 }
 
-func (p *turtleParser) parseLiteralTokens(stream *turtleTokenStream, allowLiteral bool) (Term, error) {
-	if !allowLiteral {
-		return nil, p.wrapParseError("", fmt.Errorf("literal not allowed here"))
+func (p *turtleParser) resolveIRIRef(tok turtleToken) (string, error) {
+	iri, err := decodeIRIRef(tok.Lexeme)
+	if err != nil {
+		return "", p.errorf(tok, "%v", err)
+	}
+	if p.baseIRI != "" {
+		iri = ResolveIRI(p.baseIRI, iri)
 	}
-	tok := stream.next()
-	lexeme := tok.Lexeme
+	// Validate IRI if strict validation is enabled
+	if p.opts.StrictIRIValidation {
+		if err := ValidateIRI(iri); err != nil {
+			return "", p.fail(tok, err)
+		}
+	}
+	return iri, nil
+}
 
-	// Parse the string value (remove quotes)
+func (p *turtleParser) expandPrefixedName(tok turtleToken) (Term, error) {
+	prefix, local, _ := strings.Cut(tok.Lexeme, ":")
+	base, ok := p.prefixes[prefix]
+	if !ok {
+		return nil, p.errorf(tok, "undefined prefix: %s", prefix)
+	}
+	local, err := decodePrefixedLocal(local)
+	if err != nil {
+		return nil, p.errorf(tok, "invalid prefixed name %q: %v", tok.Lexeme, err)
+	}
+	iri := base + local
+	// Validate IRI if strict validation is enabled
+	if p.opts.StrictIRIValidation {
+		if err := ValidateIRI(iri); err != nil {
+			return nil, p.fail(tok, fmt.Errorf("prefixed name %s: %w", tok.Lexeme, err))
+		}
+	}
+	return IRI{Value: iri}, nil
+}
+
+func (p *turtleParser) parseLiteral() (Term, error) {
+	tok := p.next()
 	var lexical string
 	if tok.Kind == TokStringLong {
-		// Long string: """...""" or '''...'''
-		if len(lexeme) < minLongStringLength {
-			return nil, p.wrapParseError("", fmt.Errorf("invalid long string"))
-		}
-		lexical = lexeme[3 : len(lexeme)-3] // Remove triple quotes
-		// Unescape the string
-		var err error
-		lexical, err = UnescapeString(lexical)
-		if err != nil {
-			return nil, p.wrapParseError("", err)
-		}
+		lexical = tok.Lexeme[3 : len(tok.Lexeme)-3]
 	} else {
-		// Regular string: "..." or '...'
-		if len(lexeme) < minStringLength {
-			return nil, p.wrapParseError("", fmt.Errorf("invalid string"))
-		}
-		lexical = lexeme[1 : len(lexeme)-1] // Remove quotes
-		// Unescape the string
-		var err error
-		lexical, err = UnescapeString(lexical)
-		if err != nil {
-			return nil, p.wrapParseError("", err)
-		}
+		lexical = tok.Lexeme[1 : len(tok.Lexeme)-1]
+	}
+	lexical, err := UnescapeString(lexical)
+	if err != nil {
+		return nil, p.errorf(tok, "%v", err)
 	}
 
-	// Check for language tag or datatype
-	next := stream.peek()
-	if next.Kind == TokLangTag {
-		stream.next()
-		lang := next.Lexeme
-		if !isValidLangTag(lang) {
-			return nil, p.wrapParseError("", fmt.Errorf("invalid language tag: %s", lang))
+	// Check for language tag or datatype. Directives cannot follow a
+	// string literal, so "@prefix", "@base" and "@version" are language
+	// tags here.
+	next := p.peek()
+	switch next.Kind {
+	case TokPrefix, TokBase, TokVersion:
+		if !strings.HasPrefix(next.Lexeme, "@") {
+			break
+		}
+		next.Kind, next.Lexeme = TokLangTag, next.Lexeme[1:]
+		fallthrough
+	case TokLangTag:
+		p.next()
+		if !isValidLangTag(next.Lexeme) {
+			return nil, p.errorf(next, "invalid language tag: %s", next.Lexeme)
 		}
-		// Check that there's no datatype after lang tag
-		if stream.peek().Kind == TokDatatypePrefix {
-			return nil, p.wrapParseError("", fmt.Errorf("literal cannot have both language tag and datatype"))
+		if p.peek().Kind == TokDatatypePrefix {
+			return nil, p.errorf(p.peek(), "literal cannot have both language tag and datatype")
 		}
-		return Literal{Lexical: lexical, Lang: lang}, nil
-	}
-	if next.Kind == TokDatatypePrefix {
-		stream.next()
-		// Parse the datatype (should be an IRI or prefixed name)
-		dtTerm, err := p.parseTermTokens(stream, false)
+		lang, direction := splitLangDirection(next.Lexeme)
+		return Literal{Lexical: lexical, Lang: lang, Direction: direction}, nil
+	case TokDatatypePrefix:
+		p.next()
+		dtTok := p.peek()
+		switch dtTok.Kind {
+		case TokIRIRef, TokPNAMELN, TokPNAMENS:
+		default:
+			return nil, p.errorf(dtTok, "datatype must be IRI")
+		}
+		dt, err := p.parseTerm(false, 0)
 		if err != nil {
 			return nil, err
 		}
-		iri, ok := dtTerm.(IRI)
-		if !ok {
-			return nil, p.wrapParseError("", fmt.Errorf("datatype must be IRI"))
-		}
-		return Literal{Lexical: lexical, Datatype: iri}, nil
+		return Literal{Lexical: lexical, Datatype: dt.(IRI)}, nil
 	}
 	return Literal{Lexical: lexical}, nil
 }
 
-func (p *turtleParser) parseCollectionTokens(stream *turtleTokenStream, depth int) (Term, error) {
-	// Check depth limit
+// parseCollection parses "( object* )" and returns the head of the list,
+// recording the rdf:first/rdf:rest triples as expansion triples.
+func (p *turtleParser) parseCollection(depth int) (Term, error) {
+	tok := p.next() // '('
 	if p.opts.MaxDepth > 0 && depth >= p.opts.MaxDepth {
-		return nil, p.wrapParseError("", ErrDepthExceeded)
-	}
-	// Consume LParen
-	if stream.next().Kind != TokLParen {
-		return nil, p.wrapParseError("", fmt.Errorf("expected '('"))
+		return nil, p.fail(tok, ErrDepthExceeded)
 	}
-
-	// Check for empty collection
-	if stream.peek().Kind == TokRParen {
-		stream.next()
-		return IRI{Value: rdfNilIRI}, nil
-	}
-
 	var objects []Term
-	for {
-		if stream.peek().Kind == TokRParen {
-			stream.next()
-			break
+	for p.peek().Kind != TokRParen {
+		if p.peek().Kind == TokEOF {
+			return nil, p.errorf(p.peek(), "unterminated collection")
 		}
-		obj, err := p.parseTermTokensWithDepth(stream, true, depth+1)
+		obj, err := p.parseObject(depth + 1)
 		if err != nil {
 			return nil, err
 		}
 		objects = append(objects, obj)
-		if stream.peek().Kind == TokRParen {
-			stream.next()
-			break
-		}
 	}
-
-	if len(objects) == 0 {
-		return IRI{Value: rdfNilIRI}, nil
-	}
-
-	// Generate rdf:first/rdf:rest triples
-	head := generateCollectionTriples(objects, &p.expansionTriples, p.newBlankNode)
-	return head, nil
+	p.next()
+	return generateCollectionTriples(objects, &p.expansionTriples, p.newBlankNode), nil
 }
 
-func (p *turtleParser) parseBlankNodePropertyListTokens(stream *turtleTokenStream, depth int) (Term, error) {
-	// Check depth limit
+// parseBlankNodePropertyList parses "[ predicateObjectList ]" (or "[]") and
+// returns the blank node, recording its triples as expansion triples.
+func (p *turtleParser) parseBlankNodePropertyList(depth int) (Term, error) {
+	tok := p.next() // '['
 	if p.opts.MaxDepth > 0 && depth >= p.opts.MaxDepth {
-		return nil, p.wrapParseError("", ErrDepthExceeded)
+		return nil, p.fail(tok, ErrDepthExceeded)
 	}
-	// Consume LBracket
-	if stream.next().Kind != TokLBracket {
-		return nil, p.wrapParseError("", fmt.Errorf("expected '['"))
+	bn := p.newBlankNode()
+	if p.peek().Kind == TokRBracket {
+		p.next()
+		return bn, nil
 	}
-
-	// Check for empty blank node property list
-	if stream.peek().Kind == TokRBracket {
-		stream.next()
-		return p.newBlankNode(), nil
+	triples, err := p.parsePredicateObjectList(bn, depth+1)
+	if err != nil {
+		return nil, err
 	}
-
-	bn := p.newBlankNode()
-
-	// Parse predicateObjectList
-	for {
-		// Parse predicate
-		predicate, err := p.parseVerbTokens(stream)
-		if err != nil {
-			return nil, err
-		}
-
-		// Parse objectList
-		for {
-			object, err := p.parseTermTokensWithDepth(stream, true, depth+1)
-			if err != nil {
-				return nil, err
-			}
-			p.expansionTriples = append(p.expansionTriples, Triple{
-				S: bn,
-				P: predicate,
-				O: object,
-			})
-
-			if stream.peek().Kind == TokComma {
-				stream.next()
-				continue
-			}
-			if stream.peek().Kind == TokRBracket {
-				stream.next()
-				return bn, nil
-			}
-			break
-		}
-
-		// Check for semicolon or closing bracket
-		next := stream.peek().Kind
-		if next == TokRBracket {
-			stream.next()
-			return bn, nil
-		}
-		if next == TokSemicolon {
-			p.skipSemicolons(stream)
-			if stream.peek().Kind == TokRBracket {
-				stream.next()
-				return bn, nil
-			}
-			continue
-		}
-		return nil, p.wrapParseError("", fmt.Errorf("expected ',' or ';' or ']'"))
+	if err := p.expect(TokRBracket, "',' or ';' or ']'"); err != nil {
+		return nil, err
 	}
+	p.expansionTriples = append(p.expansionTriples, triples...)
+	return bn, nil
 }
 
-func (p *turtleParser) parseTripleTermTokens(stream *turtleTokenStream) (Term, error) {
-	return p.parseTripleTermTokensWithDepth(stream, 0)
-}
-
-func (p *turtleParser) parseTripleTermTokensWithDepth(stream *turtleTokenStream, depth int) (Term, error) {
-	// Check depth limit
+// parseTripleTerm parses "<< s p o ~reifier? >>" and "<<( s p o )>>". The
+// former yields a triple term, or the reifier when one is given; reified
+// reports whether the parenthesized form was used.
+func (p *turtleParser) parseTripleTerm(depth int) (Term, bool, error) {
+	open := p.next() // '<<'
 	if p.opts.MaxDepth > 0 && depth >= p.opts.MaxDepth {
-		return nil, p.wrapParseError("", ErrDepthExceeded)
-	}
-
-	// Consume LDoubleAngle
-	if stream.next().Kind != TokLDoubleAngle {
-		return nil, p.wrapParseError("", fmt.Errorf("expected '<<'"))
+		return nil, false, p.fail(open, ErrDepthExceeded)
 	}
-
-	// Check for optional parens: <<( ... )>>
+	// Reified triple terms use the form <<( ... )>> with no whitespace between << and (
 	hasParens := false
-	if stream.peek().Kind == TokLParen {
+	if paren := p.peek(); paren.Kind == TokLParen {
+		if paren.Offset != open.Offset+len(lexLDoubleAngle) {
+			return nil, false, p.errorf(paren, "unexpected '(' after '<<'")
+		}
 		hasParens = true
-		stream.next()
+		p.next()
 	}
 
-	// Parse subject (nested triple terms are allowed, increment depth)
-	subject, err := p.parseTermTokensWithDepth(stream, false, depth+1)
+	subject, err := p.parseQuotedTerm(false, depth+1)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-
-	// Parse predicate
-	predicate, err := p.parseVerbTokens(stream)
+	predicate, err := p.parseVerb()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-
-	// Parse object (nested triple terms are allowed, increment depth)
-	object, err := p.parseTermTokensWithDepth(stream, true, depth+1)
+	object, err := p.parseQuotedTerm(true, depth+1)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-
 	if hasParens {
-		if stream.peek().Kind != TokRParen {
-			return nil, p.wrapParseError("", fmt.Errorf("expected ')'"))
+		if err := p.expect(TokRParen, "')'"); err != nil {
+			return nil, false, err
 		}
-		stream.next()
 	}
-
-	// Handle optional reifier: << ... ~ ... >>
-	// Note: "~" is not tokenized by the lexer, so we need to check the raw input
-	// For now, we'll skip reifier support in token-based parsing and fall back to cursor
-	// This is a limitation that can be addressed by extending the lexer
-
-	if stream.peek().Kind != TokRDoubleAngle {
-		return nil, p.wrapParseError("", fmt.Errorf("expected '>>'"))
+	var term Term = TripleTerm{S: subject, P: predicate, O: object}
+	// Handle optional reifier syntax: << :s :p :o ~ :i >>
+	if p.peek().Kind == TokTilde {
+		term, err = p.parseReifier(subject, predicate, object)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if err := p.expect(TokRDoubleAngle, "'>>'"); err != nil {
+		return nil, false, err
 	}
-	stream.next()
-	return TripleTerm{S: subject, P: predicate, O: object}, nil
+	return term, hasParens, nil
 }
 
-func (p *turtleParser) parseNumericLiteralToken(tok turtleToken) (Term, error) {
-	lexical := tok.Lexeme
-	var datatype IRI
-
-	// Determine datatype based on token kind
+// parseQuotedTerm parses the subject or object of a triple term, where
+// collections and non-empty blank node property lists are not allowed.
+func (p *turtleParser) parseQuotedTerm(allowLiteral bool, depth int) (Term, error) {
+	tok := p.peek()
 	switch tok.Kind {
-	case TokDouble:
-		datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#double"}
-	case TokDecimal:
-		datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#decimal"}
-	case TokInteger:
-		datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#integer"}
-	default:
-		// Fallback: determine from lexical form
-		if strings.Contains(lexical, "e") || strings.Contains(lexical, "E") {
-			datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#double"}
-		} else if strings.Contains(lexical, ".") {
-			datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#decimal"}
-		} else {
-			datatype = IRI{Value: "http://www.w3.org/2001/XMLSchema#integer"}
+	case TokLBracket:
+		p.next()
+		if p.peek().Kind != TokRBracket {
+			return nil, p.errorf(tok, "invalid blank node syntax")
 		}
+		p.next()
+		return p.newBlankNode(), nil
+	case TokLParen:
+		return nil, p.errorf(tok, "collections are not allowed in triple terms")
 	}
-
-	return Literal{Lexical: lexical, Datatype: datatype}, nil
+	return p.parseTerm(allowLiteral, depth)
 }
 
-func (p *turtleParser) parseBooleanLiteralToken(tok turtleToken) (Term, error) {
-	lexical := tok.Lexeme
-	return Literal{
-		Lexical:  lexical,
-		Datatype: IRI{Value: "http://www.w3.org/2001/XMLSchema#boolean"},
-	}, nil
+func (p *turtleParser) peek() turtleToken {
+	if !p.hasTok {
+		p.tok = p.lexer.Next()
+		p.hasTok = true
+	}
+	return p.tok
 }
 
-func (p *turtleParser) parseAnnotationTokens(stream *turtleTokenStream, annotationSubject Term) ([]Triple, error) {
-	// Consume AnnotationL {|
-	if stream.next().Kind != TokAnnotationL {
-		return nil, p.wrapParseError("", fmt.Errorf("expected '{|'"))
+func (p *turtleParser) next() turtleToken {
+	tok := p.peek()
+	// Errors and EOF are sticky so every caller sees them.
+	if tok.Kind != TokEOF && tok.Kind != TokError {
+		p.hasTok = false
+		p.lastEnd = tok.End
 	}
+	return tok
+}
 
-	var annotationTriples []Triple
-
-	// Parse predicateObjectList
-	for {
-		// Parse predicate
-		pred, err := p.parseVerbTokens(stream)
-		if err != nil {
-			return nil, err
-		}
-
-		// Parse objectList
-		for {
-			obj, err := p.parseTermTokens(stream, true)
-			if err != nil {
-				return nil, err
-			}
-			annotationTriples = append(annotationTriples, Triple{
-				S: annotationSubject,
-				P: pred,
-				O: obj,
-			})
-
-			// Handle nested annotations
-			if stream.peek().Kind == TokAnnotationL {
-				nestedTriples, err := p.parseAnnotationTokens(stream, obj)
-				if err != nil {
-					return nil, err
-				}
-				annotationTriples = append(annotationTriples, nestedTriples...)
-			}
-
-			if stream.peek().Kind == TokComma {
-				stream.next()
-				continue
-			}
-			break
-		}
-
-		// Check for semicolon or closing annotation
-		next := stream.peek().Kind
-		if next == TokSemicolon {
-			p.skipSemicolons(stream)
-			if stream.peek().Kind == TokAnnotationR {
-				stream.next()
-				break
-			}
-			continue
-		}
-		if next == TokAnnotationR {
-			stream.next()
-			break
-		}
-		return nil, p.wrapParseError("", fmt.Errorf("expected ',' or ';' or '|}'"))
+func (p *turtleParser) expect(kind turtleTokenKind, what string) error {
+	tok := p.peek()
+	if tok.Kind == kind {
+		p.next()
+		return nil
 	}
+	if tok.Kind == TokError {
+		return p.fail(tok, tok.Err)
+	}
+	return p.errorf(tok, "expected %s", what)
+}
 
-	return annotationTriples, nil
+func (p *turtleParser) errorf(tok turtleToken, format string, args ...interface{}) error {
+	return p.fail(tok, fmt.Errorf(format, args...))
 }
 
-type turtleTokenStream struct {
-	tokens []turtleToken
-	pos    int
+// warnf reports a non-fatal diagnostic positioned at tok when OptWarnings is set.
+func (p *turtleParser) warnf(tok turtleToken, code WarningCode, format string, args ...interface{}) {
+	if p.opts.warnings != nil {
+		p.opts.warnings(Warning{Code: code, Format: p.format, Line: tok.Line, Column: tok.Column, Message: fmt.Sprintf(format, args...)})
+	}
 }
 
-func (s *turtleTokenStream) peek() turtleToken {
-	if s.pos >= len(s.tokens) {
-		return turtleToken{Kind: TokEOF}
+// fail wraps err in a ParseError positioned at tok. Errors at the end of
+// the input are marked as truncation.
+func (p *turtleParser) fail(tok turtleToken, err error) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		return err
 	}
-	return s.tokens[s.pos]
+	if tok.Kind == TokEOF {
+		err = &truncatedError{err}
+	}
+	return wrapParseErrorWithPosition(p.format, p.lexer.statementText(), tok.Line, tok.Column, tok.Offset, err)
 }
 
-func (s *turtleTokenStream) next() turtleToken {
-	tok := s.peek()
-	if s.pos < len(s.tokens) {
-		s.pos++
+func isGraphLabelStart(kind turtleTokenKind) bool {
+	switch kind {
+	case TokIRIRef, TokPNAMELN, TokPNAMENS, TokBlankNode, TokLBracket:
+		return true
 	}
-	return tok
+	return false
 }
@@ -1,6 +1,8 @@
 package rdf
 
 import (
+	"errors"
+	"io"
 	"strings"
 	"testing"
 )
@@ -35,6 +37,97 @@ func TestTurtleBaseIRI(t *testing.T) {
 	}
 }
 
+func TestTurtleBaseRebinding(t *testing.T) {
+	input := "<a> <p> <o> .\n" +
+		"@base <http://example.org/x/y/> .\n" +
+		"<a> <p> <o> .\n" +
+		"BASE <../z/>\n" +
+		"PREFIX ex: <ns#>\n" +
+		"<a> ex:p <#f>, \"1\"^^<dt>, <> .\n" +
+		"@base <//example.com/> .\n" +
+		"<a> ex:p <o> .\n"
+	stmts := readScoped(t, input, FormatTurtle, OptBaseIRI("http://init.example/doc"))
+	want := []string{
+		"<http://init.example/a> <http://init.example/p> <http://init.example/o> .",
+		"<http://example.org/x/y/a> <http://example.org/x/y/p> <http://example.org/x/y/o> .",
+		"<http://example.org/x/z/a> <http://example.org/x/z/ns#p> <http://example.org/x/z/#f> .",
+		"<http://example.org/x/z/a> <http://example.org/x/z/ns#p> \"1\"^^<http://example.org/x/z/dt> .",
+		"<http://example.org/x/z/a> <http://example.org/x/z/ns#p> <http://example.org/x/z/> .",
+		"<http://example.com/a> <http://example.org/x/z/ns#p> <http://example.com/o> .",
+	}
+	if len(stmts) != len(want) {
+		t.Fatalf("expected %d triples, got %d: %v", len(want), len(stmts), stmts)
+	}
+	for i, stmt := range stmts {
+		if got := strings.TrimSpace(canonicalLine(stmt)); got != want[i] {
+			t.Errorf("triple %d = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestTriGBaseRebinding(t *testing.T) {
+	input := "BASE <http://example.org/>\n<g> { <s> <p> <o> }\nBASE <http://example.com/>\nGRAPH <g> { <s> <p> <o> }\n"
+	stmts := readScoped(t, input, FormatTriG)
+	if len(stmts) != 2 {
+		t.Fatalf("expected 2 quads, got %v", stmts)
+	}
+	for i, base := range []string{"http://example.org/", "http://example.com/"} {
+		if stmts[i].S.(IRI).Value != base+"s" || stmts[i].G.(IRI).Value != base+"g" {
+			t.Errorf("quad %d not resolved against %s: %v", i, base, stmts[i])
+		}
+	}
+}
+
+func TestTurtleSPARQLDirectives(t *testing.T) {
+	input := "prefix ex: <http://example.org/>\nBase <http://example.com/>\nPrEfIx : <ns#>\nex:s :p <o> .\n"
+	for _, format := range []Format{FormatTurtle, FormatTriG} {
+		stmts := readScoped(t, input, format)
+		if len(stmts) != 1 || stmts[0].S.(IRI).Value != "http://example.org/s" ||
+			stmts[0].P.Value != "http://example.com/ns#p" || stmts[0].O.(IRI).Value != "http://example.com/o" {
+			t.Fatalf("%s: unexpected statements %v", format, stmts)
+		}
+	}
+	for _, input := range []string{
+		"PREFIX ex: <http://example.org/> .\nex:s ex:p ex:o .\n",
+		"BASE <http://example.org/> .\n<s> <p> <o> .\n",
+	} {
+		reader, err := NewReader(strings.NewReader(input), FormatTurtle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := reader.Next(); err == nil {
+			t.Fatalf("expected an error for the '.' after a SPARQL-style directive in %q", input)
+		}
+	}
+}
+
+func TestOptOnPrefixAndBase(t *testing.T) {
+	input := "@prefix ex: <http://example.org/> .\n" +
+		"ex:s ex:p ex:o .\n" +
+		"BASE <http://example.com/dir/>\n" +
+		"PREFIX : <ns#>\n" +
+		"@prefix ex: <other/> .\n" +
+		"<s> :p ex:o .\n"
+	for _, format := range []Format{FormatTurtle, FormatTriG} {
+		var events []string
+		stmts := readScoped(t, input, format,
+			OptOnPrefix(func(prefix, iri string) { events = append(events, "prefix "+prefix+" "+iri) }),
+			OptOnBase(func(iri string) { events = append(events, "base "+iri) }))
+		if len(stmts) != 2 {
+			t.Fatalf("%s: expected 2 statements, got %v", format, stmts)
+		}
+		want := []string{
+			"prefix ex http://example.org/",
+			"base http://example.com/dir/",
+			"prefix  http://example.com/dir/ns#",
+			"prefix ex http://example.com/dir/other/",
+		}
+		if strings.Join(events, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("%s: got events %q, want %q", format, events, want)
+		}
+	}
+}
+
 func TestTurtleTripleTerm(t *testing.T) {
 	input := "<< <http://example.org/s> <http://example.org/p> <http://example.org/o> >> <http://example.org/p2> <http://example.org/o2> .\n"
 	dec, err := NewReader(strings.NewReader(input), FormatTurtle)
@@ -127,3 +220,146 @@ func TestTurtleBadTripleTerm(t *testing.T) {
 		t.Fatal("expected triple term error")
 	}
 }
+
+func TestTurtleMultiLineStatement(t *testing.T) {
+	input := "@prefix ex: <http://example.org/> .\n" +
+		"ex:s\n  ex:p # comment with . and ;\n    ex:o1 ,\n    ex:o2 ;\n  ex:q \"\"\"line one. # not a comment\nline two\"\"\"\n.\n"
+	dec, err := NewReader(strings.NewReader(input), FormatTurtle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var triples []Statement
+	for {
+		triple, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		triples = append(triples, triple)
+	}
+	if len(triples) != 3 {
+		t.Fatalf("expected 3 triples, got %d", len(triples))
+	}
+	lit, ok := triples[2].O.(Literal)
+	if !ok || lit.Lexical != "line one. # not a comment\nline two" {
+		t.Fatalf("unexpected long literal: %#v", triples[2].O)
+	}
+}
+
+func TestTurtleStatementLayout(t *testing.T) {
+	cases := []struct {
+		name   string
+		format Format
+		input  string
+		want   int
+	}{
+		{
+			name:   "directives after version",
+			format: FormatTurtle,
+			input:  "VERSION \"1.2\"\n@version \"1.2\" .\n@prefix ex: <http://example.org/> .\n@base <http://example.org/> .\nex:s ex:p <o> .\n",
+			want:   1,
+		},
+		{
+			name:   "trig directives after version",
+			format: FormatTriG,
+			input:  "VERSION \"1.2\"\n@prefix ex: <http://example.org/> .\nex:g { ex:s ex:p ex:o }\n",
+			want:   1,
+		},
+		{
+			name:   "comments inside lists",
+			format: FormatTurtle,
+			input:  "@prefix ex: <http://example.org/> .\nex:s ex:p [ # open\n  ex:q ex:o ; # more\n  ex:r ( # list\n    ex:a # item\n  ) # close\n] .\n",
+			want:   5,
+		},
+		{
+			name:   "interleaved directives",
+			format: FormatTurtle,
+			input:  "PREFIX ex: <http://example.org/>\nex:s ex:p ex:o .\n@prefix ex: <http://example.com/> .\nex:s ex:p ex:o .\nBASE <http://example.net/>\n<s> <p> <o> .\n",
+			want:   3,
+		},
+		{
+			name:   "several statements per line",
+			format: FormatTurtle,
+			input:  "@prefix ex: <http://example.org/> . ex:a ex:p ex:b . ex:c ex:p ex:d .ex:e ex:p ex:f.\n",
+			want:   3,
+		},
+		{
+			name:   "one token per line",
+			format: FormatTurtle,
+			input:  "@prefix\nex:\n<http://example.org/>\n.\nex:s\nex:p\n\"o\"\n@en\n,\n\"1\"\n^^\nex:t\n.\n",
+			want:   2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stmts := readScoped(t, tc.input, tc.format)
+			if len(stmts) != tc.want {
+				t.Fatalf("expected %d statements, got %d: %v", tc.want, len(stmts), stmts)
+			}
+		})
+	}
+}
+
+func TestTurtleDirectiveKeywordLangTag(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"a\"@prefix, 'b'@base, \"\"\"c\"\"\"@version .\n"
+	stmts := readScoped(t, input, FormatTurtle)
+	want := []string{"prefix", "base", "version"}
+	if len(stmts) != len(want) {
+		t.Fatalf("expected %d triples, got %d", len(want), len(stmts))
+	}
+	for i, stmt := range stmts {
+		lit, ok := stmt.O.(Literal)
+		if !ok || lit.Lang != want[i] {
+			t.Fatalf("triple %d: expected language tag %q, got %#v", i, want[i], stmt.O)
+		}
+	}
+}
+
+func TestTurtleErrorPosition(t *testing.T) {
+	input := "@prefix ex: <http://example.org/> .\nex:s\n  ex:p\n  ex:o ex:extra .\n"
+	dec, err := NewReader(strings.NewReader(input), FormatTurtle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = dec.Next()
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	if parseErr.Line != 4 || parseErr.Column != 8 {
+		t.Fatalf("unexpected position %d:%d", parseErr.Line, parseErr.Column)
+	}
+}
+
+func TestTurtleStatementLimitIgnoresComments(t *testing.T) {
+	input := "# " + strings.Repeat("x", 200) + "\n<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n"
+	dec, err := NewReader(strings.NewReader(input), FormatTurtle, OptMaxStatementBytes(100))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := dec.Next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTurtleStreamsBeforeInputEnds(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		_, _ = io.WriteString(pw, "<http://example.org/s> <http://example.org/p> <http://example.org/o> .\n")
+	}()
+	dec, err := NewReader(pr, FormatTurtle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	triple, err := dec.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if triple.O.(IRI).Value != "http://example.org/o" {
+		t.Fatalf("unexpected object: %#v", triple.O)
+	}
+	_ = pw.Close()
+}
@@ -0,0 +1,166 @@
+package rdf
+
+import (
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const voidNamespace = "http://rdfs.org/ns/void#"
+
+// VoIDStats holds the statistics of a dataset that the VoID vocabulary
+// (https://www.w3.org/TR/void/) can describe.
+type VoIDStats struct {
+	Triples          int64 // Statements, in any graph
+	DistinctSubjects int64
+	DistinctObjects  int64
+	Properties       int64 // Distinct predicates
+	Classes          int64 // Distinct objects of rdf:type statements
+	// Vocabularies lists the namespaces of predicates and classes (the IRI
+	// up to its last '#' or '/'), sorted.
+	Vocabularies       []string
+	ClassPartitions    []ClassPartition    // Sorted by class with TermCompare
+	PropertyPartitions []PropertyPartition // Sorted by property
+}
+
+// ClassPartition describes the instances of a class.
+type ClassPartition struct {
+	Class    Term
+	Entities int64 // Distinct subjects typed with Class
+}
+
+// PropertyPartition describes the statements using a predicate.
+type PropertyPartition struct {
+	Property         IRI
+	Triples          int64
+	DistinctSubjects int64
+	DistinctObjects  int64
+}
+
+// Describe computes VoID statistics for stmts in one pass. Statements in
+// named graphs count like triples of the default graph. Distinct counts
+// are exact, so memory grows with the number of distinct subjects and
+// objects of each property.
+func Describe(stmts iter.Seq[Statement]) VoIDStats {
+	type propertyUsage struct {
+		triples  int64
+		subjects map[Term]struct{}
+		objects  map[Term]struct{}
+	}
+	var stats VoIDStats
+	subjects := make(map[Term]struct{})
+	objects := make(map[Term]struct{})
+	properties := make(map[string]*propertyUsage)
+	classes := make(map[Term]map[Term]struct{})
+	for s := range stmts {
+		stats.Triples++
+		subjects[s.S] = struct{}{}
+		objects[s.O] = struct{}{}
+		usage := properties[s.P.Value]
+		if usage == nil {
+			usage = &propertyUsage{subjects: make(map[Term]struct{}), objects: make(map[Term]struct{})}
+			properties[s.P.Value] = usage
+		}
+		usage.triples++
+		usage.subjects[s.S] = struct{}{}
+		usage.objects[s.O] = struct{}{}
+		if s.P.Value == rdfTypeIRI {
+			if classes[s.O] == nil {
+				classes[s.O] = make(map[Term]struct{})
+			}
+			classes[s.O][s.S] = struct{}{}
+		}
+	}
+
+	stats.DistinctSubjects = int64(len(subjects))
+	stats.DistinctObjects = int64(len(objects))
+	stats.Properties = int64(len(properties))
+	stats.Classes = int64(len(classes))
+	vocabularies := make(map[string]struct{})
+	for property, usage := range properties {
+		stats.PropertyPartitions = append(stats.PropertyPartitions, PropertyPartition{
+			Property:         IRI{Value: property},
+			Triples:          usage.triples,
+			DistinctSubjects: int64(len(usage.subjects)),
+			DistinctObjects:  int64(len(usage.objects)),
+		})
+		if ns, ok := voidVocabulary(property); ok {
+			vocabularies[ns] = struct{}{}
+		}
+	}
+	for class, instances := range classes {
+		stats.ClassPartitions = append(stats.ClassPartitions, ClassPartition{Class: class, Entities: int64(len(instances))})
+		if iri, ok := class.(IRI); ok {
+			if ns, ok := voidVocabulary(iri.Value); ok {
+				vocabularies[ns] = struct{}{}
+			}
+		}
+	}
+	for ns := range vocabularies {
+		stats.Vocabularies = append(stats.Vocabularies, ns)
+	}
+	sort.Strings(stats.Vocabularies)
+	sort.Slice(stats.PropertyPartitions, func(i, j int) bool {
+		return stats.PropertyPartitions[i].Property.Value < stats.PropertyPartitions[j].Property.Value
+	})
+	sort.Slice(stats.ClassPartitions, func(i, j int) bool {
+		return TermCompare(stats.ClassPartitions[i].Class, stats.ClassPartitions[j].Class) < 0
+	})
+	return stats
+}
+
+// voidVocabulary returns the namespace of iri: the IRI up to its last '#'
+// or '/'.
+func voidVocabulary(iri string) (string, bool) {
+	idx := strings.LastIndexAny(iri, "#/")
+	if idx <= 0 || idx+1 >= len(iri) {
+		return "", false
+	}
+	return iri[:idx+1], true
+}
+
+// Statements returns a VoID description of dataset with these statistics:
+// a void:Dataset with void:triples, void:distinctSubjects,
+// void:distinctObjects, void:properties, void:classes and void:vocabulary,
+// and one void:classPartition and void:propertyPartition blank node per
+// partition. The statements are triples, ready for EncodeAll with
+// slices.Values.
+func (s VoIDStats) Statements(dataset IRI) []Statement {
+	rdfType := IRI{Value: rdfTypeIRI}
+	integer := func(n int64) Literal {
+		return Literal{Lexical: strconv.FormatInt(n, 10), Datatype: IRI{Value: xsdNamespace + "integer"}}
+	}
+	void := func(local string) IRI { return IRI{Value: voidNamespace + local} }
+
+	out := []Statement{
+		NewTriple(dataset, rdfType, void("Dataset")),
+		NewTriple(dataset, void("triples"), integer(s.Triples)),
+		NewTriple(dataset, void("distinctSubjects"), integer(s.DistinctSubjects)),
+		NewTriple(dataset, void("distinctObjects"), integer(s.DistinctObjects)),
+		NewTriple(dataset, void("properties"), integer(s.Properties)),
+		NewTriple(dataset, void("classes"), integer(s.Classes)),
+	}
+	for _, ns := range s.Vocabularies {
+		out = append(out, NewTriple(dataset, void("vocabulary"), IRI{Value: ns}))
+	}
+	for i, p := range s.ClassPartitions {
+		node := BlankNode{ID: "classPartition" + strconv.Itoa(i+1)}
+		out = append(out,
+			NewTriple(dataset, void("classPartition"), node),
+			NewTriple(node, void("class"), p.Class),
+			NewTriple(node, void("entities"), integer(p.Entities)),
+		)
+	}
+	for i, p := range s.PropertyPartitions {
+		node := BlankNode{ID: "propertyPartition" + strconv.Itoa(i+1)}
+		out = append(out,
+			NewTriple(dataset, void("propertyPartition"), node),
+			NewTriple(node, void("property"), p.Property),
+			NewTriple(node, void("triples"), integer(p.Triples)),
+			NewTriple(node, void("distinctSubjects"), integer(p.DistinctSubjects)),
+			NewTriple(node, void("distinctObjects"), integer(p.DistinctObjects)),
+		)
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package rdf
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	rdfType := IRI{Value: rdfTypeIRI}
+	name := IRI{Value: "http://xmlns.com/foaf/0.1/name"}
+	person := IRI{Value: "http://xmlns.com/foaf/0.1/Person"}
+	alice, bob := IRI{Value: "http://example.org/alice"}, IRI{Value: "http://example.org/bob"}
+	stmts := []Statement{
+		NewTriple(alice, rdfType, person),
+		NewTriple(bob, rdfType, person),
+		NewTriple(alice, name, Literal{Lexical: "Alice"}),
+		NewQuad(bob, name, Literal{Lexical: "Bob"}, IRI{Value: "http://example.org/g"}),
+		NewTriple(bob, name, Literal{Lexical: "Alice"}),
+	}
+	stats := Describe(slices.Values(stmts))
+
+	if stats.Triples != 5 || stats.DistinctSubjects != 2 || stats.DistinctObjects != 3 || stats.Properties != 2 || stats.Classes != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+	wantVocab := []string{"http://www.w3.org/1999/02/22-rdf-syntax-ns#", "http://xmlns.com/foaf/0.1/"}
+	if !slices.Equal(stats.Vocabularies, wantVocab) {
+		t.Errorf("Vocabularies = %v, want %v", stats.Vocabularies, wantVocab)
+	}
+	if len(stats.ClassPartitions) != 1 || stats.ClassPartitions[0] != (ClassPartition{Class: person, Entities: 2}) {
+		t.Errorf("ClassPartitions = %+v", stats.ClassPartitions)
+	}
+	wantProps := []PropertyPartition{
+		{Property: rdfType, Triples: 2, DistinctSubjects: 2, DistinctObjects: 1},
+		{Property: name, Triples: 3, DistinctSubjects: 2, DistinctObjects: 2},
+	}
+	if !slices.Equal(stats.PropertyPartitions, wantProps) {
+		t.Errorf("PropertyPartitions = %+v, want %+v", stats.PropertyPartitions, wantProps)
+	}
+}
+
+func TestVoIDStatsStatements(t *testing.T) {
+	stats := Describe(slices.Values([]Statement{
+		NewTriple(IRI{Value: "http://example.org/a"}, IRI{Value: rdfTypeIRI}, IRI{Value: "http://example.org/C"}),
+	}))
+	out := writeStatements(t, FormatNTriples, stats.Statements(IRI{Value: "http://example.org/dataset"}))
+	for _, want := range []string{
+		"<http://example.org/dataset> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://rdfs.org/ns/void#Dataset> .",
+		"<http://example.org/dataset> <http://rdfs.org/ns/void#triples> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> .",
+		"<http://example.org/dataset> <http://rdfs.org/ns/void#vocabulary> <http://example.org/> .",
+		"_:classPartition1 <http://rdfs.org/ns/void#class> <http://example.org/C> .",
+		"_:propertyPartition1 <http://rdfs.org/ns/void#property> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> .",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("description lacks %s\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package rdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WarningCode identifies the kind of data quality issue reported by a Warning.
+type WarningCode string
+
+const (
+	// WarnRelativeIRI indicates an IRI left relative because no base IRI was available.
+	WarnRelativeIRI WarningCode = "RELATIVE_IRI"
+	// WarnInvalidLanguageTag indicates a language tag that is not well-formed BCP 47.
+	WarnInvalidLanguageTag WarningCode = "INVALID_LANGUAGE_TAG"
+	// WarnIllFormedLiteral indicates a lexical form outside the lexical space of its XSD datatype.
+	WarnIllFormedLiteral WarningCode = "ILL_FORMED_LITERAL"
+	// WarnDuplicatePrefix indicates a prefix that is declared more than once.
+	WarnDuplicatePrefix WarningCode = "DUPLICATE_PREFIX"
+	// WarnUnknownDatatype indicates a datatype in the XSD namespace that is not an XSD datatype usable in RDF.
+	WarnUnknownDatatype WarningCode = "UNKNOWN_DATATYPE"
+)
+
+// Warning is a non-fatal diagnostic reported through OptWarnings. Parsing
+// continues normally after a warning; the statement that triggered it is
+// still returned.
+type Warning struct {
+	Code    WarningCode
+	Format  string // Format name (e.g., "turtle", "ntriples")
+	Line    int    // 1-based line number (0 if unknown)
+	Column  int    // 1-based column number (0 if unknown)
+	Message string
+}
+
+// String formats the warning like a ParseError: format, position and message.
+func (w Warning) String() string {
+	var msg strings.Builder
+	msg.WriteString(w.Format)
+	if w.Line > 0 {
+		fmt.Fprintf(&msg, ":%d", w.Line)
+		if w.Column > 0 {
+			fmt.Fprintf(&msg, ":%d", w.Column)
+		}
+	}
+	msg.WriteString(": ")
+	msg.WriteString(w.Message)
+	return msg.String()
+}
+
+// statementPositioner is implemented by decoders that know the line of the
+// statement they returned last.
+type statementPositioner interface {
+	statementLine() int
+}
+
+// checkStatementWarnings reports the term-level issues of stmt: relative
+// IRIs, malformed language tags, unknown XSD datatypes and ill-formed XSD
+// literals.
+func checkStatementWarnings(stmt Statement, format string, line int, emit func(Warning)) {
+	warn := func(code WarningCode, message string, args ...interface{}) {
+		emit(Warning{Code: code, Format: format, Line: line, Message: fmt.Sprintf(message, args...)})
+	}
+	var check func(term Term)
+	check = func(term Term) {
+		switch v := term.(type) {
+		case IRI:
+			if !hasIRIScheme(v.Value) {
+				warn(WarnRelativeIRI, "relative IRI <%s> has no base IRI to resolve against", v.Value)
+			}
+		case Literal:
+			if v.Lang != "" && !isWellFormedLanguageTag(v.Lang) {
+				warn(WarnInvalidLanguageTag, "language tag %q is not a well-formed BCP 47 tag", v.Lang)
+			}
+			if v.Datatype.Value == "" {
+				return
+			}
+			check(v.Datatype)
+			if local, ok := strings.CutPrefix(v.Datatype.Value, xsdNamespace); ok {
+				if !xsdDatatypes[local] {
+					warn(WarnUnknownDatatype, "xsd:%s is not an XSD datatype usable in RDF", local)
+				} else if valid := xsdLexicalValidators[local]; valid != nil && !valid(v.Lexical) {
+					warn(WarnIllFormedLiteral, "%q is not a valid xsd:%s lexical form", v.Lexical, local)
+				}
+			}
+		case TripleTerm:
+			check(v.S)
+			check(v.P)
+			check(v.O)
+		}
+	}
+	check(stmt.S)
+	check(stmt.P)
+	check(stmt.O)
+	if stmt.G != nil {
+		check(stmt.G)
+	}
+}
+
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema#"
+
+// xsdDatatypes are the local names of the XSD built-in datatypes that RDF
+// 1.2 Concepts lists as usable in literals.
+var xsdDatatypes = map[string]bool{
+	"string": true, "boolean": true, "decimal": true, "integer": true,
+	"double": true, "float": true,
+	"date": true, "time": true, "dateTime": true, "dateTimeStamp": true,
+	"gYear": true, "gMonth": true, "gDay": true, "gYearMonth": true, "gMonthDay": true,
+	"duration": true, "yearMonthDuration": true, "dayTimeDuration": true,
+	"byte": true, "short": true, "int": true, "long": true,
+	"unsignedByte": true, "unsignedShort": true, "unsignedInt": true, "unsignedLong": true,
+	"positiveInteger": true, "nonNegativeInteger": true, "negativeInteger": true, "nonPositiveInteger": true,
+	"hexBinary": true, "base64Binary": true, "anyURI": true,
+	"language": true, "normalizedString": true, "token": true,
+	"NMTOKEN": true, "Name": true, "NCName": true,
+}
+
+// xsdLexicalValidators checks lexical forms of the common XSD datatypes,
+// keyed by local name.
+var xsdLexicalValidators = map[string]func(string) bool{
+	"boolean": func(s string) bool {
+		return s == "true" || s == "false" || s == "1" || s == "0"
+	},
+	"decimal":       isXSDDecimal,
+	"double":        isXSDDouble,
+	"float":         isXSDDouble,
+	"integer":       func(s string) bool { _, _, ok := splitXSDInteger(s); return ok },
+	"long":          xsdSignedValidator(64),
+	"int":           xsdSignedValidator(32),
+	"short":         xsdSignedValidator(16),
+	"byte":          xsdSignedValidator(8),
+	"unsignedLong":  xsdUnsignedValidator(64),
+	"unsignedInt":   xsdUnsignedValidator(32),
+	"unsignedShort": xsdUnsignedValidator(16),
+	"unsignedByte":  xsdUnsignedValidator(8),
+	"nonNegativeInteger": func(s string) bool {
+		neg, zero, ok := splitXSDInteger(s)
+		return ok && (!neg || zero)
+	},
+	"positiveInteger": func(s string) bool {
+		neg, zero, ok := splitXSDInteger(s)
+		return ok && !neg && !zero
+	},
+	"nonPositiveInteger": func(s string) bool {
+		neg, zero, ok := splitXSDInteger(s)
+		return ok && (neg || zero)
+	},
+	"negativeInteger": func(s string) bool {
+		neg, zero, ok := splitXSDInteger(s)
+		return ok && neg && !zero
+	},
+	"date": func(s string) bool {
+		rest, ok := scanXSDDate(s)
+		return ok && isXSDTimezone(rest)
+	},
+	"time": func(s string) bool {
+		rest, ok := scanXSDTime(s)
+		return ok && isXSDTimezone(rest)
+	},
+	"dateTime": func(s string) bool {
+		rest, ok := scanXSDDate(s)
+		if !ok || !strings.HasPrefix(rest, "T") {
+			return false
+		}
+		rest, ok = scanXSDTime(rest[1:])
+		return ok && isXSDTimezone(rest)
+	},
+}
+
+// splitXSDInteger validates an xsd:integer lexical form and reports whether
+// it has a minus sign and whether its value is zero.
+func splitXSDInteger(s string) (neg, zero, ok bool) {
+	digits := s
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		neg = s[0] == '-'
+		digits = s[1:]
+	}
+	if digits == "" || !allDigits(digits) {
+		return false, false, false
+	}
+	return neg, strings.Trim(digits, "0") == "", true
+}
+
+func xsdSignedValidator(bits int) func(string) bool {
+	return func(s string) bool {
+		if _, _, ok := splitXSDInteger(s); !ok {
+			return false
+		}
+		_, err := strconv.ParseInt(s, 10, bits)
+		return err == nil
+	}
+}
+
+func xsdUnsignedValidator(bits int) func(string) bool {
+	return func(s string) bool {
+		neg, zero, ok := splitXSDInteger(s)
+		if !ok || (neg && !zero) {
+			return false
+		}
+		_, err := strconv.ParseUint(strings.TrimLeft(s, "+-"), 10, bits)
+		return err == nil
+	}
+}
+
+func isXSDDecimal(s string) bool {
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		s = s[1:]
+	}
+	whole, frac, hasDot := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return false
+	}
+	return allDigits(whole) && (!hasDot || allDigits(frac))
+}
+
+func isXSDDouble(s string) bool {
+	switch s {
+	case "INF", "+INF", "-INF", "NaN":
+		return true
+	}
+	i := strings.IndexAny(s, "eE")
+	if i < 0 {
+		return isXSDDecimal(s)
+	}
+	exponent := s[i+1:]
+	if !isXSDDecimal(s[:i]) {
+		return false
+	}
+	if strings.HasPrefix(exponent, "+") || strings.HasPrefix(exponent, "-") {
+		exponent = exponent[1:]
+	}
+	return exponent != "" && allDigits(exponent)
+}
+
+// scanXSDDate reads a -?YYYY-MM-DD date and returns the remaining input.
+func scanXSDDate(s string) (string, bool) {
+	s = strings.TrimPrefix(s, "-")
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	if i < 4 || (i > 4 && s[0] == '0') || len(s) < i+6 || s[i] != '-' || s[i+3] != '-' {
+		return "", false
+	}
+	year, _ := strconv.Atoi(s[:i])
+	month, ok1 := twoDigits(s[i+1:])
+	day, ok2 := twoDigits(s[i+4:])
+	if !ok1 || !ok2 || month < 1 || month > 12 || day < 1 || day > daysInMonth(year, month) {
+		return "", false
+	}
+	return s[i+6:], true
+}
+
+// scanXSDTime reads an hh:mm:ss(.s+)? time and returns the remaining input.
+func scanXSDTime(s string) (string, bool) {
+	if len(s) < 8 || s[2] != ':' || s[5] != ':' {
+		return "", false
+	}
+	hour, ok1 := twoDigits(s)
+	minute, ok2 := twoDigits(s[3:])
+	second, ok3 := twoDigits(s[6:])
+	if !ok1 || !ok2 || !ok3 || hour > 24 || minute > 59 || second > 59 {
+		return "", false
+	}
+	rest := s[8:]
+	fractionZero := true
+	if strings.HasPrefix(rest, ".") {
+		i := 1
+		for i < len(rest) && isDigit(rest[i]) {
+			if rest[i] != '0' {
+				fractionZero = false
+			}
+			i++
+		}
+		if i == 1 {
+			return "", false
+		}
+		rest = rest[i:]
+	}
+	if hour == 24 && (minute != 0 || second != 0 || !fractionZero) {
+		return "", false
+	}
+	return rest, true
+}
+
+// isXSDTimezone reports whether s is empty or a valid Z or ±hh:mm offset.
+func isXSDTimezone(s string) bool {
+	if s == "" || s == "Z" {
+		return true
+	}
+	if len(s) != 6 || (s[0] != '+' && s[0] != '-') || s[3] != ':' {
+		return false
+	}
+	hour, ok1 := twoDigits(s[1:])
+	minute, ok2 := twoDigits(s[4:])
+	return ok1 && ok2 && minute <= 59 && (hour < 14 || (hour == 14 && minute == 0))
+}
+
+func twoDigits(s string) (int, bool) {
+	if len(s) < 2 || !isDigit(s[0]) || !isDigit(s[1]) {
+		return 0, false
+	}
+	return int(s[0]-'0')*10 + int(s[1]-'0'), true
+}
+
+func daysInMonth(year, month int) int {
+	switch month {
+	case 2:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	default:
+		return 31
+	}
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// irregularLanguageTags are the grandfathered BCP 47 tags that do not follow
+// the langtag production.
+var irregularLanguageTags = map[string]bool{
+	"en-gb-oed": true, "i-ami": true, "i-bnn": true, "i-default": true, "i-enochian": true,
+	"i-hak": true, "i-klingon": true, "i-lux": true, "i-mingo": true, "i-navajo": true,
+	"i-pwn": true, "i-tao": true, "i-tay": true, "i-tsu": true,
+	"sgn-be-fr": true, "sgn-be-nl": true, "sgn-ch-de": true,
+}
+
+// isWellFormedLanguageTag checks tag against the BCP 47 (RFC 5646) syntax:
+// language, extlang, script, region, variant, extension and private use
+// subtags in that order. Subtags are not looked up in the IANA registry.
+func isWellFormedLanguageTag(tag string) bool {
+	lower := strings.ToLower(tag)
+	if irregularLanguageTags[lower] {
+		return true
+	}
+	subtags := strings.Split(lower, "-")
+	for _, sub := range subtags {
+		if sub == "" || len(sub) > 8 || !isAlphaNum(sub) {
+			return false
+		}
+	}
+	i := 0
+	if subtags[0] != "x" {
+		lang := subtags[0]
+		if len(lang) < 2 || len(lang) == 4 || !isAlpha(lang) {
+			return false
+		}
+		i = 1
+		if len(lang) <= 3 {
+			for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); n++ {
+				i++
+			}
+		}
+		if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+			i++
+		}
+		if i < len(subtags) && ((len(subtags[i]) == 2 && isAlpha(subtags[i])) || (len(subtags[i]) == 3 && allDigits(subtags[i]))) {
+			i++
+		}
+		for i < len(subtags) && (len(subtags[i]) >= 5 || (len(subtags[i]) == 4 && isDigit(subtags[i][0]))) {
+			i++
+		}
+		for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+			start := i + 1
+			for i = start; i < len(subtags) && len(subtags[i]) >= 2; i++ {
+			}
+			if i == start {
+				return false
+			}
+		}
+	}
+	if i < len(subtags) && subtags[i] == "x" {
+		return i+1 < len(subtags)
+	}
+	return i == len(subtags)
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isASCIILetter(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphaNum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isASCIILetter(s[i]) && !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package rdf
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func readAllWithWarnings(t *testing.T, input string, format Format, opts ...Option) ([]Warning, int) {
+	t.Helper()
+	var warnings []Warning
+	opts = append(opts, OptWarnings(func(w Warning) { warnings = append(warnings, w) }))
+	reader, err := NewReader(strings.NewReader(input), format, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	count := 0
+	for {
+		_, err := reader.Next()
+		if err == io.EOF {
+			return warnings, count
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+}
+
+func TestWarningsTurtle(t *testing.T) {
+	input := `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+@prefix ex: <http://example.com/> .
+ex:s ex:age "12x"^^xsd:integer ;
+  ex:name "Alice"@en-US ;
+  ex:bad "x"@en-toolongsubtag .
+<rel> ex:p "2024-02-30"^^xsd:date .
+ex:s ex:count "3"^^xsd:integr .
+`
+	warnings, count := readAllWithWarnings(t, input, FormatTurtle)
+	if count != 5 {
+		t.Fatalf("expected 5 statements, got %d", count)
+	}
+	want := []struct {
+		code WarningCode
+		line int
+	}{
+		{WarnDuplicatePrefix, 3},
+		{WarnIllFormedLiteral, 4},
+		{WarnInvalidLanguageTag, 4},
+		{WarnRelativeIRI, 7},
+		{WarnIllFormedLiteral, 7},
+		{WarnUnknownDatatype, 8},
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("expected %d warnings, got %v", len(want), warnings)
+	}
+	for i, w := range want {
+		if warnings[i].Code != w.code || warnings[i].Line != w.line || warnings[i].Format != "turtle" {
+			t.Fatalf("warning %d: expected %s on line %d, got %s (%s)", i, w.code, w.line, warnings[i].Code, warnings[i])
+		}
+	}
+	if got := warnings[0].String(); got != `turtle:3:9: prefix "ex" redeclared (was <http://example.org/>, now <http://example.com/>)` {
+		t.Fatalf("unexpected warning text %q", got)
+	}
+}
+
+func TestWarningsNQuadsParallel(t *testing.T) {
+	input := `<http://example.org/s> <http://example.org/p> "1.5e"^^<http://www.w3.org/2001/XMLSchema#double> .
+<http://example.org/s> <http://example.org/p> "300"^^<http://www.w3.org/2001/XMLSchema#byte> <http://example.org/g> .
+<http://example.org/s> <http://example.org/p> "true"^^<http://www.w3.org/2001/XMLSchema#boolean> .
+`
+	for _, opts := range [][]Option{nil, {OptParallelism(2)}} {
+		warnings, _ := readAllWithWarnings(t, input, FormatNQuads, opts...)
+		if len(warnings) != 2 || warnings[0].Line != 1 || warnings[1].Line != 2 {
+			t.Fatalf("expected ill-formed literal warnings on lines 1 and 2, got %v", warnings)
+		}
+	}
+}
+
+func TestWarningsDisabledByDefault(t *testing.T) {
+	reader, err := NewReader(strings.NewReader(`<rel> <http://example.org/p> "x"@en-toolongsubtag .`), FormatTurtle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := reader.Next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestXSDLexicalValidators(t *testing.T) {
+	cases := []struct {
+		datatype string
+		lexical  string
+		valid    bool
+	}{
+		{"integer", "-042", true},
+		{"integer", "4.0", false},
+		{"int", "2147483648", false},
+		{"unsignedByte", "-0", true},
+		{"unsignedByte", "-1", false},
+		{"positiveInteger", "0", false},
+		{"negativeInteger", "-1", true},
+		{"decimal", ".5", true},
+		{"decimal", "1.", true},
+		{"decimal", ".", false},
+		{"double", "-1.5E+10", true},
+		{"double", "INF", true},
+		{"double", "inf", false},
+		{"boolean", "TRUE", false},
+		{"date", "2024-02-29", true},
+		{"date", "2023-02-29", false},
+		{"date", "-0044-03-15Z", true},
+		{"dateTime", "2024-01-01T24:00:00", true},
+		{"dateTime", "2024-01-01T24:00:01", false},
+		{"dateTime", "2024-01-01T10:00:00.5+14:30", false},
+		{"time", "23:59:59.999-05:00", true},
+	}
+	for _, c := range cases {
+		if got := xsdLexicalValidators[c.datatype](c.lexical); got != c.valid {
+			t.Errorf("xsd:%s %q: expected valid=%v", c.datatype, c.lexical, c.valid)
+		}
+	}
+}
+
+func TestIsWellFormedLanguageTag(t *testing.T) {
+	valid := []string{"en", "en-US", "zh-Hant-TW", "sl-rozaj-biske", "de-CH-1901", "en-a-bbb-x-a-ccc", "x-whatever", "i-klingon", "zh-yue-HK"}
+	invalid := []string{"e", "en-", "en-US-x", "en-US-US", "en-a-x-y", "abcd", "en-toolongsubtag", "en_US"}
+	for _, tag := range valid {
+		if !isWellFormedLanguageTag(tag) {
+			t.Errorf("expected %q to be well-formed", tag)
+		}
+	}
+	for _, tag := range invalid {
+		if isWellFormedLanguageTag(tag) {
+			t.Errorf("expected %q to be rejected", tag)
+		}
+	}
+}
@@ -0,0 +1,1012 @@
+package rdf
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// This file implements a Zstandard (RFC 8878) decoder for OptDecompress.
+// Frames may be concatenated and interleaved with skippable frames;
+// dictionaries are not supported.
+
+const (
+	zstdMagic          = 0xFD2FB528
+	zstdSkippableMagic = 0x184D2A50 // The low 4 bits are user-defined
+	zstdMaxBlockSize   = 128 << 10
+	// zstdMaxWindowSize bounds the history kept by the decoder, like the
+	// default limit of the reference implementation.
+	zstdMaxWindowSize = 1 << 27
+
+	zstdMaxLiteralsLengthLog = 9
+	zstdMaxMatchLengthLog    = 9
+	zstdMaxOffsetLog         = 8
+	zstdMaxHuffmanBits       = 11
+)
+
+var (
+	zstdLiteralsLengthBase = [36]uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536,
+	}
+	zstdLiteralsLengthBits = [36]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	zstdMatchLengthBase = [53]uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539,
+	}
+	zstdMatchLengthBits = [53]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16,
+	}
+
+	// Predefined distributions of the sequence codes (RFC 8878, section 3.1.1.3.2.2).
+	zstdPredefinedLiteralsLength = mustZstdFSETable([]int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	}, 6)
+	zstdPredefinedMatchLength = mustZstdFSETable([]int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	}, 6)
+	zstdPredefinedOffset = mustZstdFSETable([]int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	}, 5)
+)
+
+// zstdError reports a malformed zstd stream.
+func zstdError(format string, args ...interface{}) error {
+	return fmt.Errorf("zstd: "+format, args...)
+}
+
+// zstdReader decompresses a zstd stream.
+type zstdReader struct {
+	r   *bufio.Reader
+	err error
+
+	inFrame   bool
+	lastBlock bool
+	checksum  bool
+	hash      xxh64
+	window    int
+
+	// hist holds the output of the current frame that is still within the
+	// window; hist[pos:] has not been returned by Read yet.
+	hist []byte
+	pos  int
+
+	block    []byte
+	literals []byte
+	rep      [3]int
+	huffman  zstdHuffmanTable
+
+	// Tables of the previous block, for the Repeat_Mode of sequences.
+	literalsLength, offset, matchLength *zstdFSETable
+	tables                              [3]zstdFSETable
+}
+
+func newZstdReader(r io.Reader) *zstdReader {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &zstdReader{r: br}
+}
+
+func (z *zstdReader) Read(p []byte) (int, error) {
+	for z.pos == len(z.hist) {
+		if z.err != nil {
+			return 0, z.err
+		}
+		z.err = z.step()
+	}
+	n := copy(p, z.hist[z.pos:])
+	z.pos += n
+	return n, nil
+}
+
+// step reads a frame header, a block or the end of a frame. It is only
+// called once all decoded output has been returned.
+func (z *zstdReader) step() error {
+	switch {
+	case !z.inFrame:
+		return z.readFrameHeader()
+	case z.lastBlock:
+		z.inFrame = false
+		if !z.checksum {
+			return nil
+		}
+		var sum [4]byte
+		if _, err := io.ReadFull(z.r, sum[:]); err != nil {
+			return zstdUnexpectedEOF(err)
+		}
+		if binary.LittleEndian.Uint32(sum[:]) != uint32(z.hash.Sum64()) {
+			return zstdError("checksum mismatch")
+		}
+		return nil
+	default:
+		return z.readBlock()
+	}
+}
+
+func zstdUnexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (z *zstdReader) readFrameHeader() error {
+	var magic [4]byte
+	if _, err := io.ReadFull(z.r, magic[:]); err != nil {
+		return err // io.EOF between frames ends the stream
+	}
+	switch m := binary.LittleEndian.Uint32(magic[:]); {
+	case m&^0xF == zstdSkippableMagic:
+		if _, err := io.ReadFull(z.r, magic[:]); err != nil {
+			return zstdUnexpectedEOF(err)
+		}
+		size := int64(binary.LittleEndian.Uint32(magic[:]))
+		if n, err := io.CopyN(io.Discard, z.r, size); n < size {
+			return zstdUnexpectedEOF(err)
+		}
+		return nil
+	case m != zstdMagic:
+		return zstdError("invalid frame magic %#08x", m)
+	}
+
+	descriptor, err := z.r.ReadByte()
+	if err != nil {
+		return zstdUnexpectedEOF(err)
+	}
+	if descriptor&0x08 != 0 {
+		return zstdError("reserved frame header bit set")
+	}
+	singleSegment := descriptor&0x20 != 0
+	window := 0
+	if !singleSegment {
+		b, err := z.r.ReadByte()
+		if err != nil {
+			return zstdUnexpectedEOF(err)
+		}
+		base := 1 << (10 + b>>3)
+		window = base + base/8*int(b&7)
+	}
+	dictionaryID, err := z.readLittleEndian([4]int{0, 1, 2, 4}[descriptor&3])
+	if err != nil {
+		return err
+	}
+	if dictionaryID != 0 {
+		return zstdError("dictionaries are not supported")
+	}
+	sizeBytes := [4]int{0, 2, 4, 8}[descriptor>>6]
+	if sizeBytes == 0 && singleSegment {
+		sizeBytes = 1
+	}
+	contentSize, err := z.readLittleEndian(sizeBytes)
+	if err != nil {
+		return err
+	}
+	if sizeBytes == 2 {
+		contentSize += 256
+	}
+	if singleSegment {
+		if contentSize > zstdMaxWindowSize {
+			return zstdError("frame content size %d exceeds the window limit", contentSize)
+		}
+		window = int(contentSize)
+	}
+	if window > zstdMaxWindowSize {
+		return zstdError("window size %d exceeds the limit of %d bytes", window, zstdMaxWindowSize)
+	}
+
+	z.inFrame, z.lastBlock = true, false
+	z.checksum = descriptor&0x04 != 0
+	z.hash.Reset()
+	z.window = window
+	z.hist, z.pos = z.hist[:0], 0
+	z.rep = [3]int{1, 4, 8}
+	z.huffman.valid = false
+	z.literalsLength, z.offset, z.matchLength = nil, nil, nil
+	return nil
+}
+
+func (z *zstdReader) readLittleEndian(n int) (uint64, error) {
+	var v uint64
+	for i := 0; i < n; i++ {
+		b, err := z.r.ReadByte()
+		if err != nil {
+			return 0, zstdUnexpectedEOF(err)
+		}
+		v |= uint64(b) << (8 * i)
+	}
+	return v, nil
+}
+
+func (z *zstdReader) readBlock() error {
+	var header [3]byte
+	if _, err := io.ReadFull(z.r, header[:]); err != nil {
+		return zstdUnexpectedEOF(err)
+	}
+	h := uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16
+	z.lastBlock = h&1 != 0
+	size := int(h >> 3)
+	maxSize := min(z.window, zstdMaxBlockSize)
+	if size > maxSize {
+		return zstdError("block size %d exceeds the maximum of %d bytes", size, maxSize)
+	}
+
+	// Everything before pos was returned; keep only the window as history.
+	if len(z.hist) > 2*z.window+zstdMaxBlockSize {
+		n := copy(z.hist, z.hist[len(z.hist)-z.window:])
+		z.hist, z.pos = z.hist[:n], n
+	}
+	start := len(z.hist)
+	switch (h >> 1) & 3 {
+	case 0: // Raw_Block
+		z.hist = append(z.hist, make([]byte, size)...)
+		if _, err := io.ReadFull(z.r, z.hist[start:]); err != nil {
+			return zstdUnexpectedEOF(err)
+		}
+	case 1: // RLE_Block
+		b, err := z.r.ReadByte()
+		if err != nil {
+			return zstdUnexpectedEOF(err)
+		}
+		for i := 0; i < size; i++ {
+			z.hist = append(z.hist, b)
+		}
+	case 2: // Compressed_Block
+		if cap(z.block) < size {
+			z.block = make([]byte, size, zstdMaxBlockSize)
+		}
+		block := z.block[:size]
+		if _, err := io.ReadFull(z.r, block); err != nil {
+			return zstdUnexpectedEOF(err)
+		}
+		if err := z.decodeBlock(block, start+maxSize); err != nil {
+			return err
+		}
+	default:
+		return zstdError("reserved block type")
+	}
+	z.hash.Write(z.hist[start:])
+	return nil
+}
+
+// decodeBlock decompresses a Compressed_Block into hist, failing if the
+// output would grow beyond limit bytes.
+func (z *zstdReader) decodeBlock(block []byte, limit int) error {
+	literals, n, err := z.decodeLiterals(block)
+	if err != nil {
+		return err
+	}
+	block = block[n:]
+	if len(block) == 0 {
+		return zstdError("missing sequences section")
+	}
+
+	count, n := int(block[0]), 1
+	switch {
+	case count == 0:
+		if len(block) != 1 {
+			return zstdError("trailing data after empty sequences section")
+		}
+		if len(z.hist)+len(literals) > limit {
+			return zstdError("block exceeds the maximum size")
+		}
+		z.hist = append(z.hist, literals...)
+		return nil
+	case count == 255:
+		if len(block) < 3 {
+			return zstdError("truncated sequences header")
+		}
+		count, n = int(block[1])+int(block[2])<<8+0x7F00, 3
+	case count >= 128:
+		if len(block) < 2 {
+			return zstdError("truncated sequences header")
+		}
+		count, n = (count-128)<<8+int(block[1]), 2
+	}
+	if len(block) <= n {
+		return zstdError("truncated sequences header")
+	}
+	modes := block[n]
+	n++
+	if modes&3 != 0 {
+		return zstdError("reserved sequence compression mode bits set")
+	}
+	block = block[n:]
+	if z.literalsLength, n, err = z.sequenceTable(block, modes>>6, 0, zstdPredefinedLiteralsLength, z.literalsLength, 35, zstdMaxLiteralsLengthLog); err != nil {
+		return err
+	}
+	block = block[n:]
+	if z.offset, n, err = z.sequenceTable(block, modes>>4&3, 1, zstdPredefinedOffset, z.offset, 31, zstdMaxOffsetLog); err != nil {
+		return err
+	}
+	block = block[n:]
+	if z.matchLength, n, err = z.sequenceTable(block, modes>>2&3, 2, zstdPredefinedMatchLength, z.matchLength, 52, zstdMaxMatchLengthLog); err != nil {
+		return err
+	}
+	block = block[n:]
+	return z.executeSequences(block, count, literals, limit)
+}
+
+// sequenceTable returns the decoding table of a sequence code for the
+// compression mode, reading its description from in if needed.
+func (z *zstdReader) sequenceTable(in []byte, mode byte, slot int, predefined, previous *zstdFSETable, maxSymbol int, maxLog uint) (*zstdFSETable, int, error) {
+	table := &z.tables[slot]
+	switch mode {
+	case 0: // Predefined_Mode
+		return predefined, 0, nil
+	case 1: // RLE_Mode
+		if len(in) == 0 {
+			return nil, 0, zstdError("truncated sequences header")
+		}
+		if int(in[0]) > maxSymbol {
+			return nil, 0, zstdError("invalid sequence code %d", in[0])
+		}
+		table.setRLE(in[0])
+		return table, 1, nil
+	case 2: // FSE_Compressed_Mode
+		n, err := table.readDescription(in, maxSymbol, maxLog)
+		return table, n, err
+	default: // Repeat_Mode
+		if previous == nil {
+			return nil, 0, zstdError("repeated sequence table without a previous table")
+		}
+		return previous, 0, nil
+	}
+}
+
+func (z *zstdReader) executeSequences(in []byte, count int, literals []byte, limit int) error {
+	br, err := newZstdBitReader(in)
+	if err != nil {
+		return err
+	}
+	ll, of, ml := z.literalsLength, z.offset, z.matchLength
+	llState := br.read(ll.log)
+	ofState := br.read(of.log)
+	mlState := br.read(ml.log)
+	for i := 0; i < count; i++ {
+		ofCode := uint(of.entries[ofState].symbol)
+		llCode := ll.entries[llState].symbol
+		mlCode := ml.entries[mlState].symbol
+		offsetValue := int(1<<ofCode + br.read(ofCode))
+		matchLength := int(zstdMatchLengthBase[mlCode] + uint32(br.read(uint(zstdMatchLengthBits[mlCode]))))
+		literalsLength := int(zstdLiteralsLengthBase[llCode] + uint32(br.read(uint(zstdLiteralsLengthBits[llCode]))))
+		if i < count-1 {
+			llState = ll.next(llState, &br)
+			mlState = ml.next(mlState, &br)
+			ofState = of.next(ofState, &br)
+		}
+		if br.overread() {
+			return zstdError("truncated sequences bitstream")
+		}
+
+		var offset int
+		if offsetValue > 3 {
+			offset = offsetValue - 3
+			z.rep = [3]int{offset, z.rep[0], z.rep[1]}
+		} else {
+			if literalsLength == 0 {
+				offsetValue++
+			}
+			switch offsetValue {
+			case 1:
+				offset = z.rep[0]
+			case 2:
+				offset = z.rep[1]
+				z.rep = [3]int{offset, z.rep[0], z.rep[2]}
+			case 3:
+				offset = z.rep[2]
+				z.rep = [3]int{offset, z.rep[0], z.rep[1]}
+			default:
+				offset = z.rep[0] - 1
+				z.rep = [3]int{offset, z.rep[0], z.rep[1]}
+			}
+		}
+
+		if literalsLength > len(literals) {
+			return zstdError("sequence literals exceed the literals section")
+		}
+		if len(z.hist)+literalsLength+matchLength > limit {
+			return zstdError("block exceeds the maximum size")
+		}
+		z.hist = append(z.hist, literals[:literalsLength]...)
+		literals = literals[literalsLength:]
+		if offset < 1 || offset > len(z.hist) {
+			return zstdError("match offset %d out of range", offset)
+		}
+		start := len(z.hist) - offset
+		if offset >= matchLength {
+			z.hist = append(z.hist, z.hist[start:start+matchLength]...)
+		} else {
+			for j := 0; j < matchLength; j++ {
+				z.hist = append(z.hist, z.hist[start+j])
+			}
+		}
+	}
+	if !br.finished() {
+		return zstdError("sequences bitstream not fully consumed")
+	}
+	if len(z.hist)+len(literals) > limit {
+		return zstdError("block exceeds the maximum size")
+	}
+	z.hist = append(z.hist, literals...)
+	return nil
+}
+
+// decodeLiterals decodes the literals section at the start of a block and
+// returns the literals and the size of the section.
+func (z *zstdReader) decodeLiterals(in []byte) ([]byte, int, error) {
+	if len(in) == 0 {
+		return nil, 0, zstdError("missing literals section")
+	}
+	blockType, sizeFormat := in[0]&3, in[0]>>2&3
+	if blockType < 2 { // Raw_Literals_Block, RLE_Literals_Block
+		var size, n int
+		switch sizeFormat {
+		case 0, 2:
+			size, n = int(in[0]>>3), 1
+		case 1:
+			if len(in) < 2 {
+				return nil, 0, zstdError("truncated literals header")
+			}
+			size, n = int(in[0]>>4)|int(in[1])<<4, 2
+		default:
+			if len(in) < 3 {
+				return nil, 0, zstdError("truncated literals header")
+			}
+			size, n = int(in[0]>>4)|int(in[1])<<4|int(in[2])<<12, 3
+		}
+		if size > zstdMaxBlockSize {
+			return nil, 0, zstdError("literals section exceeds the maximum block size")
+		}
+		if blockType == 0 {
+			if len(in) < n+size {
+				return nil, 0, zstdError("truncated literals")
+			}
+			return in[n : n+size], n + size, nil
+		}
+		if len(in) < n+1 {
+			return nil, 0, zstdError("truncated literals")
+		}
+		literals := z.literals[:0]
+		for i := 0; i < size; i++ {
+			literals = append(literals, in[n])
+		}
+		z.literals = literals
+		return literals, n + 1, nil
+	}
+
+	// Compressed_Literals_Block and Treeless_Literals_Block
+	n, sizeBits, streams := 3, uint(10), 4
+	switch sizeFormat {
+	case 0:
+		streams = 1
+	case 2:
+		n, sizeBits = 4, 14
+	case 3:
+		n, sizeBits = 5, 18
+	}
+	if len(in) < n {
+		return nil, 0, zstdError("truncated literals header")
+	}
+	var header uint64
+	for i := n - 1; i >= 0; i-- {
+		header = header<<8 | uint64(in[i])
+	}
+	mask := uint64(1)<<sizeBits - 1
+	regenerated := int(header >> 4 & mask)
+	compressed := int(header >> (4 + sizeBits) & mask)
+	if regenerated > zstdMaxBlockSize {
+		return nil, 0, zstdError("literals section exceeds the maximum block size")
+	}
+	if len(in) < n+compressed {
+		return nil, 0, zstdError("truncated literals")
+	}
+	src := in[n : n+compressed]
+	if blockType == 2 {
+		tableSize, err := z.huffman.readDescription(src)
+		if err != nil {
+			return nil, 0, err
+		}
+		src = src[tableSize:]
+	} else if !z.huffman.valid {
+		return nil, 0, zstdError("treeless literals without a previous Huffman table")
+	}
+
+	if cap(z.literals) < regenerated {
+		z.literals = make([]byte, regenerated, zstdMaxBlockSize)
+	}
+	literals := z.literals[:regenerated]
+	if streams == 1 {
+		if err := z.huffman.decode(literals, src); err != nil {
+			return nil, 0, err
+		}
+		return literals, n + compressed, nil
+	}
+	if len(src) < 6 {
+		return nil, 0, zstdError("truncated literals jump table")
+	}
+	sizes := [4]int{int(binary.LittleEndian.Uint16(src)), int(binary.LittleEndian.Uint16(src[2:])), int(binary.LittleEndian.Uint16(src[4:]))}
+	sizes[3] = len(src) - 6 - sizes[0] - sizes[1] - sizes[2]
+	segment := (regenerated + 3) / 4
+	if sizes[3] < 0 || 3*segment > regenerated {
+		return nil, 0, zstdError("invalid literals jump table")
+	}
+	src = src[6:]
+	for i, size := range sizes {
+		out := literals[i*segment:]
+		if i < 3 {
+			out = out[:segment]
+		}
+		if err := z.huffman.decode(out, src[:size]); err != nil {
+			return nil, 0, err
+		}
+		src = src[size:]
+	}
+	return literals, n + compressed, nil
+}
+
+// zstdHuffmanTable decodes Huffman-coded literals. Each entry is indexed by
+// the next maxBits bits of a stream and holds a symbol and its code length.
+type zstdHuffmanTable struct {
+	entries []zstdHuffmanEntry
+	maxBits uint
+	valid   bool
+}
+
+type zstdHuffmanEntry struct {
+	symbol byte
+	nbBits uint8
+}
+
+// readDescription reads a Huffman tree description and returns its size.
+func (h *zstdHuffmanTable) readDescription(in []byte) (int, error) {
+	h.valid = false
+	if len(in) == 0 {
+		return 0, zstdError("missing Huffman tree description")
+	}
+	var weights [256]byte
+	var count, n int
+	if header := int(in[0]); header >= 128 {
+		count, n = header-127, 1+(header-127+1)/2
+		if len(in) < n {
+			return 0, zstdError("truncated Huffman weights")
+		}
+		for i := 0; i < count; i++ {
+			b := in[1+i/2]
+			if i%2 == 0 {
+				b >>= 4
+			}
+			weights[i] = b & 15
+		}
+	} else {
+		n = 1 + header
+		if len(in) < n {
+			return 0, zstdError("truncated Huffman weights")
+		}
+		var err error
+		if count, err = zstdDecodeHuffmanWeights(in[1:n], weights[:255]); err != nil {
+			return 0, err
+		}
+	}
+
+	total := 0
+	for _, w := range weights[:count] {
+		if w > zstdMaxHuffmanBits {
+			return 0, zstdError("invalid Huffman weight %d", w)
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 {
+		return 0, zstdError("invalid Huffman weights")
+	}
+	maxBits := uint(bits.Len(uint(total)))
+	left := 1<<maxBits - total
+	if maxBits > zstdMaxHuffmanBits || left&(left-1) != 0 {
+		return 0, zstdError("invalid Huffman weights")
+	}
+	weights[count] = byte(bits.Len(uint(left)))
+	count++
+
+	// Codes are assigned in order of increasing weight, then symbol.
+	var start [zstdMaxHuffmanBits + 2]int
+	for _, w := range weights[:count] {
+		if w > 0 {
+			start[w+1] += 1 << (w - 1)
+		}
+	}
+	for w := 2; w < len(start); w++ {
+		start[w] += start[w-1]
+	}
+	size := 1 << maxBits
+	if cap(h.entries) < size {
+		h.entries = make([]zstdHuffmanEntry, size, 1<<zstdMaxHuffmanBits)
+	}
+	h.entries = h.entries[:size]
+	for symbol, w := range weights[:count] {
+		if w == 0 {
+			continue
+		}
+		entry := zstdHuffmanEntry{symbol: byte(symbol), nbBits: uint8(maxBits + 1 - uint(w))}
+		length := 1 << (w - 1)
+		for i := start[w]; i < start[w]+length; i++ {
+			h.entries[i] = entry
+		}
+		start[w] += length
+	}
+	h.maxBits, h.valid = maxBits, true
+	return n, nil
+}
+
+// decode fills out with the symbols of the Huffman-coded stream in.
+func (h *zstdHuffmanTable) decode(out, in []byte) error {
+	br, err := newZstdBitReader(in)
+	if err != nil {
+		return err
+	}
+	for i := range out {
+		entry := h.entries[br.peek(h.maxBits)]
+		br.skip(uint(entry.nbBits))
+		out[i] = entry.symbol
+	}
+	if !br.finished() {
+		return zstdError("invalid Huffman-coded literals stream")
+	}
+	return nil
+}
+
+// zstdDecodeHuffmanWeights decodes FSE-compressed Huffman weights into out
+// and returns their number.
+func zstdDecodeHuffmanWeights(in []byte, out []byte) (int, error) {
+	var table zstdFSETable
+	n, err := table.readDescription(in, zstdMaxHuffmanBits, 6)
+	if err != nil {
+		return 0, err
+	}
+	br, err := newZstdBitReader(in[n:])
+	if err != nil {
+		return 0, err
+	}
+	// Two interleaved states share the bitstream; decoding stops when a
+	// state update reads past its start, after emitting the other state's
+	// symbol.
+	state1, state2 := br.read(table.log), br.read(table.log)
+	count := 0
+	for {
+		if count+2 > len(out) {
+			return 0, zstdError("too many Huffman weights")
+		}
+		out[count] = table.entries[state1].symbol
+		count++
+		state1 = table.next(state1, &br)
+		if br.overread() {
+			out[count] = table.entries[state2].symbol
+			return count + 1, nil
+		}
+		out[count] = table.entries[state2].symbol
+		count++
+		state2 = table.next(state2, &br)
+		if br.overread() {
+			out[count] = table.entries[state1].symbol
+			return count + 1, nil
+		}
+	}
+}
+
+// zstdFSETable is a finite state entropy decoding table.
+type zstdFSETable struct {
+	entries []zstdFSEEntry
+	log     uint
+}
+
+type zstdFSEEntry struct {
+	symbol   uint8
+	nbBits   uint8
+	baseline uint16
+}
+
+// next returns the state following state, reading its low bits from br.
+func (t *zstdFSETable) next(state uint64, br *zstdBitReader) uint64 {
+	e := t.entries[state]
+	return uint64(e.baseline) + br.read(uint(e.nbBits))
+}
+
+func (t *zstdFSETable) setRLE(symbol byte) {
+	t.entries = append(t.entries[:0], zstdFSEEntry{symbol: symbol})
+	t.log = 0
+}
+
+// readDescription reads an FSE table description and builds the table. It
+// returns the size of the description in bytes.
+func (t *zstdFSETable) readDescription(in []byte, maxSymbol int, maxLog uint) (int, error) {
+	if len(in) == 0 {
+		return 0, zstdError("missing FSE table description")
+	}
+	// The description is a little-endian bitstream read from the front.
+	pos := uint(0)
+	peek := func(n uint) int {
+		v := 0
+		for i := uint(0); i < n; i++ {
+			if b := (pos + i) / 8; b < uint(len(in)) && in[b]>>((pos+i)%8)&1 != 0 {
+				v |= 1 << i
+			}
+		}
+		return v
+	}
+	log := uint(peek(4)) + 5
+	pos = 4
+	if log > maxLog {
+		return 0, zstdError("FSE accuracy log %d exceeds %d", log, maxLog)
+	}
+	var probabilities [256]int16
+	remaining := 1 << log
+	symbol := 0
+	for remaining > 0 && symbol <= maxSymbol {
+		n := uint(bits.Len(uint(remaining + 1)))
+		value := peek(n)
+		lowMask := 1<<(n-1) - 1
+		threshold := 1<<n - 1 - (remaining + 1)
+		switch {
+		case value&lowMask < threshold:
+			value &= lowMask
+			pos += n - 1
+		case value > lowMask:
+			value -= threshold
+			pos += n
+		default:
+			pos += n
+		}
+		probability := value - 1
+		if probability < 0 {
+			remaining--
+		} else {
+			remaining -= probability
+		}
+		probabilities[symbol] = int16(probability)
+		symbol++
+		if probability == 0 {
+			for {
+				repeat := peek(2)
+				pos += 2
+				if symbol+repeat > maxSymbol+1 {
+					return 0, zstdError("invalid FSE table description")
+				}
+				symbol += repeat // probabilities are already zero
+				if repeat != 3 {
+					break
+				}
+			}
+		}
+	}
+	if remaining != 0 || pos > uint(len(in))*8 {
+		return 0, zstdError("invalid FSE table description")
+	}
+	if err := t.build(probabilities[:symbol], log); err != nil {
+		return 0, err
+	}
+	return int(pos+7) / 8, nil
+}
+
+// build fills the table from normalized probabilities, where -1 stands for
+// a probability below one.
+func (t *zstdFSETable) build(probabilities []int16, log uint) error {
+	size := 1 << log
+	if cap(t.entries) < size {
+		t.entries = make([]zstdFSEEntry, size, 1<<zstdMaxLiteralsLengthLog)
+	}
+	t.entries, t.log = t.entries[:size], log
+	var next [256]uint16
+	high := size - 1
+	for symbol, p := range probabilities {
+		if p == -1 {
+			t.entries[high].symbol = uint8(symbol)
+			high--
+			next[symbol] = 1
+		} else {
+			next[symbol] = uint16(p)
+		}
+	}
+	pos, step, mask := 0, size>>1+size>>3+3, size-1
+	for symbol, p := range probabilities {
+		for i := 0; i < int(p); i++ {
+			t.entries[pos].symbol = uint8(symbol)
+			for pos = (pos + step) & mask; pos > high; pos = (pos + step) & mask {
+			}
+		}
+	}
+	if pos != 0 {
+		return zstdError("invalid FSE probabilities")
+	}
+	for i := range t.entries {
+		e := &t.entries[i]
+		state := next[e.symbol]
+		next[e.symbol]++
+		e.nbBits = uint8(log + 1 - uint(bits.Len16(state)))
+		e.baseline = state<<e.nbBits - uint16(size)
+	}
+	return nil
+}
+
+func mustZstdFSETable(probabilities []int16, log uint) *zstdFSETable {
+	t := &zstdFSETable{}
+	if err := t.build(probabilities, log); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// zstdBitReader reads a zstd backward bitstream: the stream is a
+// little-endian number read from its highest bit down, starting below the
+// highest set bit of the last byte. Reads past the start yield zero bits
+// and are recorded so that callers can detect truncated streams.
+type zstdBitReader struct {
+	in    []byte
+	off   int    // in[:off] has not been loaded yet
+	value uint64 // the low bits hold the loaded, unread bits
+	bits  uint   // number of loaded, unread bits
+	over  uint   // bits read past the start of the stream
+}
+
+func newZstdBitReader(in []byte) (zstdBitReader, error) {
+	if len(in) == 0 || in[len(in)-1] == 0 {
+		return zstdBitReader{}, zstdError("missing bitstream end marker")
+	}
+	last := in[len(in)-1]
+	return zstdBitReader{in: in, off: len(in) - 1, value: uint64(last), bits: uint(bits.Len8(last)) - 1}, nil
+}
+
+func (b *zstdBitReader) fill() {
+	if b.bits <= 32 && b.off >= 4 {
+		b.off -= 4
+		b.value = b.value<<32 | uint64(binary.LittleEndian.Uint32(b.in[b.off:]))
+		b.bits += 32
+	}
+	for b.bits <= 56 && b.off > 0 {
+		b.off--
+		b.value = b.value<<8 | uint64(b.in[b.off])
+		b.bits += 8
+	}
+}
+
+// read returns the next n bits (n <= 56).
+func (b *zstdBitReader) read(n uint) uint64 {
+	if n == 0 {
+		return 0
+	}
+	if b.bits < n {
+		b.fill()
+		if b.bits < n {
+			b.value <<= n - b.bits
+			b.over += n - b.bits
+			b.bits = n
+		}
+	}
+	b.bits -= n
+	return b.value >> b.bits & (1<<n - 1)
+}
+
+// peek returns the next n bits without consuming them.
+func (b *zstdBitReader) peek(n uint) uint64 {
+	if b.bits < n {
+		b.fill()
+		if b.bits < n {
+			return b.value << (n - b.bits) & (1<<n - 1)
+		}
+	}
+	return b.value >> (b.bits - n) & (1<<n - 1)
+}
+
+func (b *zstdBitReader) skip(n uint) {
+	if n > b.bits {
+		b.over += n - b.bits
+		n = b.bits
+	}
+	b.bits -= n
+}
+
+func (b *zstdBitReader) overread() bool { return b.over > 0 }
+
+func (b *zstdBitReader) finished() bool { return b.off == 0 && b.bits == 0 && b.over == 0 }
+
+// xxh64 computes the XXH64 hash with seed 0, used for zstd content checksums.
+type xxh64 struct {
+	v     [4]uint64
+	total uint64
+	buf   [32]byte
+	n     int
+}
+
+const (
+	xxhPrime1 uint64 = 11400714785074694791
+	xxhPrime2 uint64 = 14029467366897019727
+	xxhPrime3 uint64 = 1609587929392839161
+	xxhPrime4 uint64 = 9650029242287828579
+	xxhPrime5 uint64 = 2870177450012600261
+)
+
+func (x *xxh64) Reset() {
+	prime1 := xxhPrime1 // wraps around at run time
+	*x = xxh64{v: [4]uint64{prime1 + xxhPrime2, xxhPrime2, 0, -prime1}}
+}
+
+func xxhRound(acc, input uint64) uint64 {
+	return bits.RotateLeft64(acc+input*xxhPrime2, 31) * xxhPrime1
+}
+
+func (x *xxh64) Write(p []byte) {
+	x.total += uint64(len(p))
+	if x.n > 0 {
+		c := copy(x.buf[x.n:], p)
+		x.n += c
+		p = p[c:]
+		if x.n < 32 {
+			return
+		}
+		x.blocks(x.buf[:])
+		x.n = 0
+	}
+	full := len(p) &^ 31
+	x.blocks(p[:full])
+	x.n = copy(x.buf[:], p[full:])
+}
+
+func (x *xxh64) blocks(p []byte) {
+	for ; len(p) >= 32; p = p[32:] {
+		for i := range x.v {
+			x.v[i] = xxhRound(x.v[i], binary.LittleEndian.Uint64(p[8*i:]))
+		}
+	}
+}
+
+func (x *xxh64) Sum64() uint64 {
+	var h uint64
+	if x.total >= 32 {
+		h = bits.RotateLeft64(x.v[0], 1) + bits.RotateLeft64(x.v[1], 7) + bits.RotateLeft64(x.v[2], 12) + bits.RotateLeft64(x.v[3], 18)
+		for _, v := range x.v {
+			h = (h^xxhRound(0, v))*xxhPrime1 + xxhPrime4
+		}
+	} else {
+		h = x.v[2] + xxhPrime5
+	}
+	h += x.total
+	p := x.buf[:x.n]
+	for ; len(p) >= 8; p = p[8:] {
+		h ^= xxhRound(0, binary.LittleEndian.Uint64(p))
+		h = bits.RotateLeft64(h, 27)*xxhPrime1 + xxhPrime4
+	}
+	if len(p) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(p)) * xxhPrime1
+		h = bits.RotateLeft64(h, 23)*xxhPrime2 + xxhPrime3
+		p = p[4:]
+	}
+	for _, b := range p {
+		h ^= uint64(b) * xxhPrime5
+		h = bits.RotateLeft64(h, 11) * xxhPrime1
+	}
+	h ^= h >> 33
+	h *= xxhPrime2
+	h ^= h >> 29
+	h *= xxhPrime3
+	h ^= h >> 32
+	return h
+}
@@ -0,0 +1,560 @@
+package rdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+// This file implements a Zstandard (RFC 8878) encoder for OptCompress. It
+// finds matches greedily with a hash table, codes literals with Huffman
+// codes and sequences with the predefined FSE tables, which keeps the
+// encoder small while compressing typical RDF text several times.
+
+const (
+	zstdEncoderWindowLog = 20
+	zstdEncoderWindow    = 1 << zstdEncoderWindowLog
+	zstdEncoderHashLog   = 16
+	// zstdMinMatch is the shortest match the encoder emits. Shorter matches
+	// at large offsets cost more than the literals they replace.
+	zstdMinMatch = 6
+)
+
+// zstdWriter compresses its input into a single zstd frame, written when
+// Flush or Close is called or a block fills up.
+type zstdWriter struct {
+	w   io.Writer
+	err error
+
+	headerWritten bool
+	hash          xxh64
+
+	// hist holds the window of already compressed input followed by the
+	// pending input from start on.
+	hist       []byte
+	start      int
+	table      []int32 // hash of zstdMinMatch bytes -> position in hist + 1
+	lastOffset int     // the decoder's first repeat offset
+
+	out       []byte
+	literals  []byte
+	sequences []zstdSequence
+}
+
+// zstdSequence is a run of literals followed by a match. offsetValue is
+// the offset plus 3, or 1 for a repeat of the last offset.
+type zstdSequence struct {
+	literalsLength, matchLength, offsetValue int
+}
+
+func newZstdWriter(w io.Writer) *zstdWriter {
+	z := &zstdWriter{w: w, table: make([]int32, 1<<zstdEncoderHashLog), lastOffset: 1}
+	z.hash.Reset()
+	return z
+}
+
+func (z *zstdWriter) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), z.start+zstdMaxBlockSize-len(z.hist))
+		z.hist = append(z.hist, p[:n]...)
+		z.hash.Write(p[:n])
+		p, written = p[n:], written+n
+		if len(z.hist)-z.start == zstdMaxBlockSize {
+			if err := z.writeBlock(false); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Flush compresses the pending input into a block and writes it.
+func (z *zstdWriter) Flush() error {
+	if z.err != nil {
+		return z.err
+	}
+	if len(z.hist) == z.start {
+		return nil
+	}
+	return z.writeBlock(false)
+}
+
+// Close writes the last block and the content checksum. It does not close
+// the underlying writer.
+func (z *zstdWriter) Close() error {
+	if z.err != nil {
+		return z.err
+	}
+	if err := z.writeBlock(true); err != nil {
+		return err
+	}
+	var sum [4]byte
+	binary.LittleEndian.PutUint32(sum[:], uint32(z.hash.Sum64()))
+	if _, err := z.w.Write(sum[:]); err != nil {
+		z.err = err
+		return err
+	}
+	z.err = errors.New("zstd: writer closed")
+	return nil
+}
+
+// writeBlock compresses hist[start:] into a block, falling back to a raw
+// block if compression does not pay off.
+func (z *zstdWriter) writeBlock(last bool) error {
+	out := z.out[:0]
+	if !z.headerWritten {
+		// Frame header: content checksum, no content size, 1 MiB window.
+		out = binary.LittleEndian.AppendUint32(out, zstdMagic)
+		out = append(out, 0x04, (zstdEncoderWindowLog-10)<<3)
+		z.headerWritten = true
+	}
+	src := z.hist[z.start:]
+	headerAt := len(out)
+	out = append(out, 0, 0, 0)
+	blockType := 2
+	if len(src) > 0 {
+		out = z.compressBlock(out)
+	}
+	size := len(out) - headerAt - 3
+	if size >= len(src) {
+		out = append(out[:headerAt+3], src...)
+		blockType, size = 0, len(src)
+	}
+	h := uint32(size)<<3 | uint32(blockType)<<1
+	if last {
+		h |= 1
+	}
+	out[headerAt], out[headerAt+1], out[headerAt+2] = byte(h), byte(h>>8), byte(h>>16)
+	z.out = out
+	if _, err := z.w.Write(out); err != nil {
+		z.err = err
+		return err
+	}
+	z.start = len(z.hist)
+	z.slide()
+	return nil
+}
+
+// slide drops input that has left the window, keeping hist from growing
+// without bound.
+func (z *zstdWriter) slide() {
+	shift := z.start - zstdEncoderWindow
+	if shift < zstdEncoderWindow {
+		return
+	}
+	n := copy(z.hist, z.hist[shift:])
+	z.hist, z.start = z.hist[:n], z.start-shift
+	for i, pos := range z.table {
+		if int(pos) > shift {
+			z.table[i] = pos - int32(shift)
+		} else {
+			z.table[i] = 0
+		}
+	}
+}
+
+// zstdHash hashes the first zstdMinMatch of the 8 bytes at the start of b.
+func zstdHash(b []byte) uint32 {
+	return uint32(binary.LittleEndian.Uint64(b) << (64 - 8*zstdMinMatch) * 0xCF1BBCDCB7A56463 >> (64 - zstdEncoderHashLog))
+}
+
+// compressBlock appends the literals and sequences sections for
+// hist[start:] to out.
+func (z *zstdWriter) compressBlock(out []byte) []byte {
+	hist, end := z.hist, len(z.hist)
+	z.literals, z.sequences = z.literals[:0], z.sequences[:0]
+	anchor := z.start
+	for i := z.start; i+8 <= end; {
+		h := zstdHash(hist[i:])
+		candidate := int(z.table[h]) - 1
+		z.table[h] = int32(i + 1)
+		// A match at the last offset is coded as a repeat offset, which
+		// costs a few bits instead of the full offset. Repeats are only
+		// used after literals, where offset value 1 means the last offset.
+		repeat := i > anchor && i >= z.lastOffset &&
+			binary.LittleEndian.Uint32(hist[i-z.lastOffset:]) == binary.LittleEndian.Uint32(hist[i:])
+		if repeat {
+			candidate = i - z.lastOffset
+		} else if candidate < 0 || i-candidate > zstdEncoderWindow ||
+			(binary.LittleEndian.Uint64(hist[candidate:])^binary.LittleEndian.Uint64(hist[i:]))<<(64-8*zstdMinMatch) != 0 {
+			// Skip faster through input without matches.
+			i += 1 + (i-anchor)>>6
+			continue
+		}
+		length := 4
+		for i+length < end && hist[candidate+length] == hist[i+length] {
+			length++
+		}
+		offsetValue := 1
+		if !repeat {
+			z.lastOffset = i - candidate
+			offsetValue = z.lastOffset + 3
+		}
+		z.literals = append(z.literals, hist[anchor:i]...)
+		z.sequences = append(z.sequences, zstdSequence{literalsLength: i - anchor, matchLength: length, offsetValue: offsetValue})
+		for j := i + 1; j < i+length && j+8 <= end; j++ {
+			z.table[zstdHash(hist[j:])] = int32(j + 1)
+		}
+		i += length
+		anchor = i
+	}
+	z.literals = append(z.literals, hist[anchor:end]...)
+	out = zstdAppendLiterals(out, z.literals)
+	return zstdAppendSequences(out, z.sequences)
+}
+
+// zstdAppendLiterals appends a literals section, Huffman-coded if that is
+// smaller than the raw literals.
+func zstdAppendLiterals(out, literals []byte) []byte {
+	if len(literals) >= 64 {
+		if compressed, ok := zstdAppendHuffmanLiterals(out, literals); ok {
+			return compressed
+		}
+	}
+	switch n := len(literals); {
+	case n < 32:
+		out = append(out, byte(n<<3))
+	case n < 4096:
+		out = append(out, byte(1<<2|n<<4), byte(n>>4))
+	default:
+		out = append(out, byte(3<<2|n<<4), byte(n>>4), byte(n>>12))
+	}
+	return append(out, literals...)
+}
+
+// zstdAppendHuffmanLiterals appends a Compressed_Literals_Block. It
+// reports false if the literals do not compress or their Huffman weights
+// cannot be described directly.
+func zstdAppendHuffmanLiterals(out, literals []byte) ([]byte, bool) {
+	var counts [256]int
+	for _, b := range literals {
+		counts[b]++
+	}
+	lengths, maxBits, ok := zstdHuffmanLengths(&counts)
+	if !ok {
+		return out, false
+	}
+	last := 255
+	for lengths[last] == 0 {
+		last--
+	}
+	// Direct representation: one 4-bit weight per symbol below the last,
+	// whose weight is implied.
+	if last > 128 {
+		return out, false
+	}
+	var weights [256]uint8
+	for s, l := range lengths[:last+1] {
+		if l > 0 {
+			weights[s] = uint8(maxBits + 1 - int(l))
+		}
+	}
+	var codes [256]uint16
+	var next [zstdMaxHuffmanBits + 2]int
+	for _, w := range weights[:last+1] {
+		if w > 0 {
+			next[w+1] += 1 << (w - 1)
+		}
+	}
+	for w := 2; w < len(next); w++ {
+		next[w] += next[w-1]
+	}
+	for s, w := range weights[:last+1] {
+		if w > 0 {
+			codes[s] = uint16(next[w] >> (w - 1))
+			next[w] += 1 << (w - 1)
+		}
+	}
+
+	headerAt := len(out)
+	streams := 4
+	if len(literals) <= 1023 {
+		streams = 1
+	}
+	headerSize := 3
+	if streams == 4 && len(literals) > 1023 {
+		headerSize = 5
+	}
+	out = append(out, make([]byte, headerSize)...)
+	bodyAt := len(out)
+	out = append(out, byte(127+last))
+	for s := 0; s < last; s += 2 {
+		low := weights[s+1]
+		if s+1 == last {
+			low = 0
+		}
+		out = append(out, weights[s]<<4|low)
+	}
+	if streams == 1 {
+		out = zstdAppendHuffmanStream(out, literals, &codes, &lengths)
+	} else {
+		jumpAt := len(out)
+		out = append(out, 0, 0, 0, 0, 0, 0)
+		segment := (len(literals) + 3) / 4
+		for i := 0; i < 4; i++ {
+			chunk := literals[i*segment:]
+			if i < 3 {
+				chunk = chunk[:segment]
+			}
+			streamAt := len(out)
+			out = zstdAppendHuffmanStream(out, chunk, &codes, &lengths)
+			if i < 3 {
+				size := len(out) - streamAt
+				if size > 0xFFFF {
+					return out[:headerAt], false
+				}
+				binary.LittleEndian.PutUint16(out[jumpAt+2*i:], uint16(size))
+			}
+		}
+	}
+	compressed := len(out) - bodyAt
+	if compressed+headerSize >= len(literals)+3 {
+		return out[:headerAt], false
+	}
+	regenerated := uint64(len(literals))
+	var header uint64
+	switch {
+	case streams == 1 && compressed <= 1023:
+		header = 2 | regenerated<<4 | uint64(compressed)<<14
+	case streams == 1:
+		return out[:headerAt], false
+	default:
+		// Size_Format 3: four streams, 18-bit sizes.
+		header = 2 | 3<<2 | regenerated<<4 | uint64(compressed)<<22
+	}
+	for i := 0; i < headerSize; i++ {
+		out[headerAt+i] = byte(header >> (8 * i))
+	}
+	return out, true
+}
+
+// zstdAppendHuffmanStream appends literals as a backward Huffman bitstream:
+// the decoder reads the last symbol written first.
+func zstdAppendHuffmanStream(out, literals []byte, codes *[256]uint16, lengths *[256]uint8) []byte {
+	bw := zstdBitWriter{out: out}
+	for i := len(literals) - 1; i >= 0; i-- {
+		s := literals[i]
+		bw.write(uint64(codes[s]), uint(lengths[s]))
+	}
+	return bw.close()
+}
+
+// zstdHuffmanLengths computes Huffman code lengths of at most
+// zstdMaxHuffmanBits bits for the byte counts. It reports false if fewer
+// than two symbols occur.
+func zstdHuffmanLengths(counts *[256]int) ([256]uint8, int, bool) {
+	type node struct {
+		count       int
+		left, right int // children, -1 for leaves
+		symbol      int
+	}
+	freq := *counts
+	for {
+		nodes := make([]node, 0, 512)
+		for s, c := range freq {
+			if c > 0 {
+				nodes = append(nodes, node{count: c, left: -1, right: -1, symbol: s})
+			}
+		}
+		if len(nodes) < 2 {
+			return [256]uint8{}, 0, false
+		}
+		sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].count < nodes[j].count })
+		// Two-queue construction: leaves in nodes[:leaves], internal nodes
+		// appended in order of increasing count.
+		leaves := len(nodes)
+		leaf, internal := 0, leaves
+		pick := func() int {
+			if leaf < leaves && (internal >= len(nodes) || nodes[leaf].count <= nodes[internal].count) {
+				leaf++
+				return leaf - 1
+			}
+			internal++
+			return internal - 1
+		}
+		for i := 0; i < leaves-1; i++ {
+			a, b := pick(), pick()
+			nodes = append(nodes, node{count: nodes[a].count + nodes[b].count, left: a, right: b})
+		}
+		depths := make([]int, len(nodes))
+		var lengths [256]uint8
+		maxBits := 0
+		for i := len(nodes) - 1; i >= 0; i-- {
+			n := nodes[i]
+			if n.left < 0 {
+				lengths[n.symbol] = uint8(depths[i])
+				maxBits = max(maxBits, depths[i])
+				continue
+			}
+			depths[n.left], depths[n.right] = depths[i]+1, depths[i]+1
+		}
+		if maxBits <= zstdMaxHuffmanBits {
+			return lengths, maxBits, true
+		}
+		// Flatten the distribution until the code fits.
+		for s, c := range freq {
+			if c > 0 {
+				freq[s] = (c + 1) / 2
+			}
+		}
+	}
+}
+
+// zstdAppendSequences appends a sequences section coded with the
+// predefined FSE tables.
+func zstdAppendSequences(out []byte, sequences []zstdSequence) []byte {
+	switch n := len(sequences); {
+	case n < 128:
+		out = append(out, byte(n))
+	case n < 0x7F00:
+		out = append(out, byte(n>>8+128), byte(n))
+	default:
+		out = append(out, 255, byte(n-0x7F00), byte((n-0x7F00)>>8))
+	}
+	if len(sequences) == 0 {
+		return out
+	}
+	out = append(out, 0) // Predefined_Mode for all codes
+
+	type coded struct {
+		code  uint8
+		extra uint64
+		bits  uint
+	}
+	codes := func(s zstdSequence) (ll, of, ml coded) {
+		ll.code = zstdLiteralsLengthCode(s.literalsLength)
+		ll.bits = uint(zstdLiteralsLengthBits[ll.code])
+		ll.extra = uint64(s.literalsLength) - uint64(zstdLiteralsLengthBase[ll.code])
+		ml.code = zstdMatchLengthCode(s.matchLength)
+		ml.bits = uint(zstdMatchLengthBits[ml.code])
+		ml.extra = uint64(s.matchLength) - uint64(zstdMatchLengthBase[ml.code])
+		value := uint64(s.offsetValue)
+		of.code = uint8(bits.Len64(value) - 1)
+		of.bits = uint(of.code)
+		of.extra = value - 1<<of.code
+		return ll, of, ml
+	}
+
+	// The decoder reads the bitstream backward, so the sequences are
+	// written last to first and every field in reverse reading order.
+	bw := zstdBitWriter{out: out}
+	last := len(sequences) - 1
+	ll, of, ml := codes(sequences[last])
+	llState := zstdPredefinedLiteralsLengthEncoder.initial(ll.code)
+	ofState := zstdPredefinedOffsetEncoder.initial(of.code)
+	mlState := zstdPredefinedMatchLengthEncoder.initial(ml.code)
+	bw.write(ll.extra, ll.bits)
+	bw.write(ml.extra, ml.bits)
+	bw.write(of.extra, of.bits)
+	for i := last - 1; i >= 0; i-- {
+		ll, of, ml = codes(sequences[i])
+		ofState = zstdPredefinedOffsetEncoder.encode(&bw, of.code, ofState)
+		mlState = zstdPredefinedMatchLengthEncoder.encode(&bw, ml.code, mlState)
+		llState = zstdPredefinedLiteralsLengthEncoder.encode(&bw, ll.code, llState)
+		bw.write(ll.extra, ll.bits)
+		bw.write(ml.extra, ml.bits)
+		bw.write(of.extra, of.bits)
+	}
+	bw.write(uint64(mlState), zstdPredefinedMatchLengthEncoder.table.log)
+	bw.write(uint64(ofState), zstdPredefinedOffsetEncoder.table.log)
+	bw.write(uint64(llState), zstdPredefinedLiteralsLengthEncoder.table.log)
+	return bw.close()
+}
+
+func zstdLiteralsLengthCode(n int) uint8 {
+	if n < 16 {
+		return uint8(n)
+	}
+	code := len(zstdLiteralsLengthBase) - 1
+	for int(zstdLiteralsLengthBase[code]) > n {
+		code--
+	}
+	return uint8(code)
+}
+
+func zstdMatchLengthCode(n int) uint8 {
+	if n < 35 {
+		return uint8(n - 3)
+	}
+	code := len(zstdMatchLengthBase) - 1
+	for int(zstdMatchLengthBase[code]) > n {
+		code--
+	}
+	return uint8(code)
+}
+
+// zstdFSEEncoder encodes symbols with the states of an FSE decoding table.
+// states[symbol][next] is the state of symbol from which the decoder moves
+// to state next.
+type zstdFSEEncoder struct {
+	table  *zstdFSETable
+	states [][]uint16
+}
+
+var (
+	zstdPredefinedLiteralsLengthEncoder = newZstdFSEEncoder(zstdPredefinedLiteralsLength, 36)
+	zstdPredefinedMatchLengthEncoder    = newZstdFSEEncoder(zstdPredefinedMatchLength, 53)
+	zstdPredefinedOffsetEncoder         = newZstdFSEEncoder(zstdPredefinedOffset, 29)
+)
+
+func newZstdFSEEncoder(table *zstdFSETable, symbols int) *zstdFSEEncoder {
+	e := &zstdFSEEncoder{table: table, states: make([][]uint16, symbols)}
+	size := len(table.entries)
+	for s := range e.states {
+		e.states[s] = make([]uint16, size)
+	}
+	for state, entry := range table.entries {
+		lo := int(entry.baseline)
+		for next := lo; next < lo+1<<entry.nbBits; next++ {
+			e.states[entry.symbol][next] = uint16(state)
+		}
+	}
+	return e
+}
+
+// initial returns a state decoding to symbol, for the last symbol of a stream.
+func (e *zstdFSEEncoder) initial(symbol uint8) uint16 {
+	return e.states[symbol][0]
+}
+
+// encode writes the bits moving the decoder from the state of symbol to
+// next and returns that state.
+func (e *zstdFSEEncoder) encode(bw *zstdBitWriter, symbol uint8, next uint16) uint16 {
+	state := e.states[symbol][next]
+	entry := e.table.entries[state]
+	bw.write(uint64(next-entry.baseline), uint(entry.nbBits))
+	return state
+}
+
+// zstdBitWriter writes a little-endian bitstream that zstdBitReader reads
+// backward, ending with a marker bit.
+type zstdBitWriter struct {
+	out   []byte
+	value uint64
+	bits  uint
+}
+
+// write appends the low n bits of v (n <= 56).
+func (w *zstdBitWriter) write(v uint64, n uint) {
+	w.value |= v << w.bits
+	w.bits += n
+	for w.bits >= 8 {
+		w.out = append(w.out, byte(w.value))
+		w.value >>= 8
+		w.bits -= 8
+	}
+}
+
+func (w *zstdBitWriter) close() []byte {
+	w.write(1, 1)
+	if w.bits > 0 {
+		w.out = append(w.out, byte(w.value))
+	}
+	return w.out
+}
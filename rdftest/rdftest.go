@@ -0,0 +1,75 @@
+// Package rdftest provides test helpers for code that reads and writes RDF
+// with the rdf package. AssertRoundTrip guards against serializations that
+// lose statements, for instance under the options an application uses:
+//
+//	func TestExportRoundTrip(t *testing.T) {
+//		input, _ := os.ReadFile("testdata/catalog.ttl")
+//		rdftest.AssertRoundTrip(t, input, rdf.FormatTurtle, rdf.OptBaseIRI("http://example.org/"))
+//	}
+package rdftest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+	"github.com/geoknoesis/rdf-go/testsuite"
+)
+
+// AssertRoundTrip parses input in format, writes the statements back in
+// format, parses the output again and fails t unless both parses give the
+// same dataset up to blank node labels. opts configure the readers and
+// the writer alike. The failure message holds the serialized output and
+// both datasets in N-Quads.
+func AssertRoundTrip(t testing.TB, input []byte, format rdf.Format, opts ...rdf.Option) {
+	t.Helper()
+	before, output, after, err := RoundTrip(input, format, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !testsuite.Isomorphic(before, after) {
+		t.Fatalf("rdftest: %s round trip changed the dataset from %d to %d statements\noutput:\n%s\nbefore:\n%s\nafter:\n%s",
+			format, len(before), len(after), output, describe(before), describe(after))
+	}
+}
+
+// RoundTrip parses input in format, writes the statements back in format
+// and parses the output again, returning the statements of both parses and
+// the serialized output.
+func RoundTrip(input []byte, format rdf.Format, opts ...rdf.Option) (before []rdf.Statement, output []byte, after []rdf.Statement, err error) {
+	before, err = parse(input, format, opts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("rdftest: parse input: %w", err)
+	}
+	var buf bytes.Buffer
+	if _, err := rdf.EncodeAll(&buf, format, slices.Values(before), opts...); err != nil {
+		return before, nil, nil, fmt.Errorf("rdftest: write %s: %w", format, err)
+	}
+	after, err = parse(buf.Bytes(), format, opts)
+	if err != nil {
+		return before, buf.Bytes(), nil, fmt.Errorf("rdftest: parse output: %w\noutput:\n%s", err, buf.Bytes())
+	}
+	return before, buf.Bytes(), after, nil
+}
+
+func parse(input []byte, format rdf.Format, opts []rdf.Option) ([]rdf.Statement, error) {
+	var stmts []rdf.Statement
+	err := rdf.Parse(context.Background(), bytes.NewReader(input), format, func(s rdf.Statement) error {
+		stmts = append(stmts, s)
+		return nil
+	}, opts...)
+	return stmts, err
+}
+
+// describe returns stmts in N-Quads, or as Go values if they do not fit
+// in N-Quads.
+func describe(stmts []rdf.Statement) string {
+	var buf bytes.Buffer
+	if _, err := rdf.EncodeAll(&buf, rdf.FormatNQuads, slices.Values(stmts)); err != nil {
+		return fmt.Sprintf("%v", stmts)
+	}
+	return buf.String()
+}
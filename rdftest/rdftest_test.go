@@ -0,0 +1,102 @@
+package rdftest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+func TestAssertRoundTrip(t *testing.T) {
+	tests := []struct {
+		format rdf.Format
+		input  string
+	}{
+		{rdf.FormatTurtle, `@prefix ex: <http://example.org/> .
+ex:s ex:p "v"@en, "1"^^ex:t, [ ex:q ( 1 2.5 true ) ] ;
+  ex:r <<( ex:a ex:b "c" )>> .
+_:b ex:p "line\nbreak" .`},
+		{rdf.FormatTriG, `@prefix ex: <http://example.org/> .
+ex:g { ex:s ex:p _:o . _:o ex:q "x"@en--ltr . }
+ex:s ex:p ex:o .`},
+		{rdf.FormatNTriples, `<http://example.org/s> <http://example.org/p> _:o .
+_:o <http://example.org/p> "tab\there" .
+`},
+		{rdf.FormatNQuads, `<http://example.org/s> <http://example.org/p> "v" _:g .
+_:g <http://example.org/p> <http://example.org/o> <http://example.org/g> .
+`},
+		{rdf.FormatRDFXML, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.org/">
+<rdf:Description rdf:about="http://example.org/s"><ex:p rdf:nodeID="o"/><ex:q xml:lang="en">v</ex:q></rdf:Description>
+<rdf:Description rdf:nodeID="o"><ex:p rdf:datatype="http://example.org/t">1</ex:p></rdf:Description>
+</rdf:RDF>`},
+		{rdf.FormatJSONLD, `{"@context": {"ex": "http://example.org/"}, "@id": "ex:s",
+"ex:p": [{"@value": "v", "@language": "en"}, {"@id": "ex:n", "ex:q": {"@id": "ex:o"}}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			AssertRoundTrip(t, []byte(tt.input), tt.format)
+		})
+	}
+}
+
+// recorder is a testing.TB that records failures instead of failing.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatal(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+	runtime.Goexit()
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+// run calls assert with r in a goroutine, so that Fatal can stop it.
+func (r *recorder) run(assert func(testing.TB)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert(r)
+	}()
+	<-done
+}
+
+func TestAssertRoundTripFailures(t *testing.T) {
+	const tripleTerm = `<http://example.org/s> <http://example.org/p> <<( <http://example.org/a> <http://example.org/b> <http://example.org/c> )>> .`
+	tests := []struct {
+		name   string
+		input  string
+		format rdf.Format
+		opts   []rdf.Option
+		want   string
+	}{
+		{"lossy option", tripleTerm, rdf.FormatTurtle, []rdf.Option{rdf.OptReifyTripleTerms()}, "changed the dataset from 1 to 5 statements"},
+		{"invalid input", `<http://example.org/s> <http://example.org/p> .`, rdf.FormatTurtle, nil, "parse input"},
+	}
+	for _, tt := range tests {
+		r := &recorder{TB: t}
+		r.run(func(tb testing.TB) { AssertRoundTrip(tb, []byte(tt.input), tt.format, tt.opts...) })
+		if len(r.failures) != 1 || !strings.Contains(r.failures[0], tt.want) {
+			t.Errorf("%s: failures = %q, want one containing %q", tt.name, r.failures, tt.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"v\" .\n"
+	before, output, after, err := RoundTrip([]byte(input), rdf.FormatNTriples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 1 || len(after) != 1 || string(output) != input {
+		t.Errorf("RoundTrip = %v, %q, %v", before, output, after)
+	}
+}
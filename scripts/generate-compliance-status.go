@@ -88,7 +88,7 @@ func runFormatTests(format string) formatResult {
 	}
 
 	// Run the test for this format
-	cmd := exec.Command("go", "test", "./rdf", "-run", fmt.Sprintf("^TestW3CConformance$/%s$", format), "-v")
+	cmd := exec.Command("go", "test", "./rdf", "./testsuite", "-run", fmt.Sprintf("^TestW3CConformance$/%s$", format), "-v")
 	output, err := cmd.CombinedOutput()
 	outputStr := string(output)
 
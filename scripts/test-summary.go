@@ -29,7 +29,7 @@ func main() {
 
 	for _, format := range formats {
 		// Use -v flag to get verbose output that shows all test results
-		cmd := exec.Command("go", "test", "./rdf", "-run", fmt.Sprintf("TestW3CConformance/%s", format), "-v")
+		cmd := exec.Command("go", "test", "./rdf", "./testsuite", "-run", fmt.Sprintf("TestW3CConformance/%s", format), "-v")
 		output, _ := cmd.CombinedOutput()
 		outputStr := string(output)
 		passCount := strings.Count(outputStr, "--- PASS:")
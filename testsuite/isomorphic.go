@@ -0,0 +1,217 @@
+package testsuite
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+const xsdString = "http://www.w3.org/2001/XMLSchema#string"
+
+// Isomorphic reports whether a and b are the same dataset up to the labels
+// of their blank nodes, including blank nodes inside triple terms.
+// Duplicate statements count once, as in an RDF dataset.
+func Isomorphic(a, b []rdf.Statement) bool {
+	a, b = dedupe(a), dedupe(b)
+	if len(a) != len(b) {
+		return false
+	}
+	want := make(map[string]bool, len(b))
+	for _, s := range b {
+		key, _ := statementKey(s, sameLabels{})
+		want[key] = true
+	}
+	blanksA, blanksB := blankNodes(a), blankNodes(b)
+	if len(blanksA) != len(blanksB) {
+		return false
+	}
+
+	// Blank nodes of a may only map to blank nodes of b used in the same
+	// statements, ignoring the labels of other blank nodes.
+	sigA, sigB := signatures(a), signatures(b)
+	bySignature := map[string][]string{}
+	for _, id := range blanksB {
+		bySignature[sigB[id]] = append(bySignature[sigB[id]], id)
+	}
+	candidates := make(map[string][]string, len(blanksA))
+	for _, id := range blanksA {
+		candidates[id] = bySignature[sigA[id]]
+		if len(candidates[id]) == 0 {
+			return false
+		}
+	}
+	sort.SliceStable(blanksA, func(i, j int) bool {
+		return len(candidates[blanksA[i]]) < len(candidates[blanksA[j]])
+	})
+
+	mapping := map[string]string{}
+	used := map[string]bool{}
+	// consistent reports whether every statement of a whose blank nodes
+	// are all mapped is in b.
+	consistent := func() bool {
+		for _, s := range a {
+			if key, ok := statementKey(s, labelMap(mapping)); ok && !want[key] {
+				return false
+			}
+		}
+		return true
+	}
+	var match func(int) bool
+	match = func(i int) bool {
+		if i == len(blanksA) {
+			return true
+		}
+		id := blanksA[i]
+		for _, other := range candidates[id] {
+			if used[other] {
+				continue
+			}
+			mapping[id], used[other] = other, true
+			if consistent() && match(i+1) {
+				return true
+			}
+			delete(mapping, id)
+			used[other] = false
+		}
+		return false
+	}
+	return consistent() && match(0)
+}
+
+func dedupe(stmts []rdf.Statement) []rdf.Statement {
+	seen := make(map[string]bool, len(stmts))
+	out := make([]rdf.Statement, 0, len(stmts))
+	for _, s := range stmts {
+		key, _ := statementKey(s, sameLabels{})
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// blankNodes returns the sorted labels of the blank nodes in stmts.
+func blankNodes(stmts []rdf.Statement) []string {
+	set := map[string]bool{}
+	var visit func(rdf.Term)
+	visit = func(t rdf.Term) {
+		switch t := t.(type) {
+		case rdf.BlankNode:
+			set[t.ID] = true
+		case rdf.TripleTerm:
+			visit(t.S)
+			visit(t.O)
+		}
+	}
+	for _, s := range stmts {
+		visit(s.S)
+		visit(s.O)
+		visit(s.G)
+	}
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// signatures returns, for each blank node, the sorted keys of the
+// statements it occurs in with itself written "_:*" and other blank nodes
+// "_:".
+func signatures(stmts []rdf.Statement) map[string]string {
+	perNode := map[string][]string{}
+	for _, s := range stmts {
+		for _, id := range blankNodes([]rdf.Statement{s}) {
+			key, _ := statementKey(s, signatureMapping(id))
+			perNode[id] = append(perNode[id], key)
+		}
+	}
+	sigs := make(map[string]string, len(perNode))
+	for id, keys := range perNode {
+		sort.Strings(keys)
+		sigs[id] = strings.Join(keys, "\n")
+	}
+	return sigs
+}
+
+// signatureMapping marks id and hides the labels of other blank nodes.
+type signatureMapping string
+
+func (m signatureMapping) label(id string) (string, bool) {
+	if id == string(m) {
+		return "*", true
+	}
+	return "", true
+}
+
+// labelMap maps the blank node labels of one dataset to those of another.
+type labelMap map[string]string
+
+func (m labelMap) label(id string) (string, bool) {
+	mapped, ok := m[id]
+	return mapped, ok
+}
+
+// sameLabels keeps blank node labels unchanged.
+type sameLabels struct{}
+
+func (sameLabels) label(id string) (string, bool) { return id, true }
+
+type labeler interface {
+	label(id string) (string, bool)
+}
+
+// statementKey returns the N-Quads-like key of s with blank node labels
+// replaced through l, reporting false if l lacks one of them.
+func statementKey(s rdf.Statement, l labeler) (string, bool) {
+	var b strings.Builder
+	ok := writeTermKey(&b, s.S, l)
+	b.WriteByte(' ')
+	ok = writeTermKey(&b, s.P, l) && ok
+	b.WriteByte(' ')
+	ok = writeTermKey(&b, s.O, l) && ok
+	if s.G != nil {
+		b.WriteByte(' ')
+		ok = writeTermKey(&b, s.G, l) && ok
+	}
+	return b.String(), ok
+}
+
+func writeTermKey(b *strings.Builder, t rdf.Term, l labeler) bool {
+	switch t := t.(type) {
+	case rdf.IRI:
+		b.WriteString("<" + t.Value + ">")
+	case rdf.BlankNode:
+		label, ok := l.label(t.ID)
+		b.WriteString("_:" + label)
+		return ok
+	case rdf.Literal:
+		b.WriteString(strconv.Quote(t.Lexical))
+		if t.Lang != "" {
+			b.WriteString("@" + strings.ToLower(t.Lang))
+			if t.Direction != "" {
+				b.WriteString("--" + t.Direction)
+			}
+		} else if t.Datatype.Value != "" && t.Datatype.Value != xsdString {
+			b.WriteString("^^<" + t.Datatype.Value + ">")
+		}
+	case rdf.TripleTerm:
+		b.WriteString("<<( ")
+		ok := writeTermKey(b, t.S, l)
+		b.WriteByte(' ')
+		ok = writeTermKey(b, t.P, l) && ok
+		b.WriteByte(' ')
+		ok = writeTermKey(b, t.O, l) && ok
+		b.WriteString(" )>>")
+		return ok
+	case nil:
+		b.WriteString("-")
+	default:
+		b.WriteString(t.String())
+	}
+	return true
+}
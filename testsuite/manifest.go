@@ -0,0 +1,300 @@
+package testsuite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// W3CTestsBase is the IRI the W3C RDF test suites are published under.
+// Files of a manifest that are not found next to it are looked up under
+// Config.Root by their IRI relative to it.
+const W3CTestsBase = "https://w3c.github.io/rdf-tests/"
+
+const (
+	mf              = "http://www.w3.org/2001/sw/DataAccess/tests/test-manifest#"
+	mfEntries       = mf + "entries"
+	mfInclude       = mf + "include"
+	mfAction        = mf + "action"
+	mfResult        = mf + "result"
+	mfName          = mf + "name"
+	mfAssumedBase   = mf + "assumedTestBase"
+	rdfsComment     = "http://www.w3.org/2000/01/rdf-schema#comment"
+	rdfType         = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	rdfFirst        = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	rdfRest         = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	rdfNil          = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+	manifestFileIRI = "file:///"
+)
+
+// TestType is the kind of a manifest entry, derived from its rdf:type.
+type TestType int
+
+const (
+	// PositiveSyntax tests must be read without error.
+	PositiveSyntax TestType = iota
+	// NegativeSyntax tests must fail to be read.
+	NegativeSyntax
+	// Eval tests must be read without error into the dataset of their
+	// result file. Canonical N-Triples and N-Quads tests (PositiveC14N)
+	// are Eval tests.
+	Eval
+	// NegativeEval tests are syntactically valid but must fail to be read.
+	NegativeEval
+)
+
+// String returns the name of the test type.
+func (t TestType) String() string {
+	switch t {
+	case PositiveSyntax:
+		return "PositiveSyntax"
+	case NegativeSyntax:
+		return "NegativeSyntax"
+	case Eval:
+		return "Eval"
+	case NegativeEval:
+		return "NegativeEval"
+	}
+	return fmt.Sprintf("TestType(%d)", int(t))
+}
+
+// Negative reports whether tests of type t must fail to be read.
+func (t TestType) Negative() bool {
+	return t == NegativeSyntax || t == NegativeEval
+}
+
+// TestCase is an entry of a test manifest.
+type TestCase struct {
+	// Name is the mf:name of the entry, or the file name of its action.
+	Name string
+	// Comment is the rdfs:comment of the entry, if any.
+	Comment string
+	// Type is the kind of the test.
+	Type TestType
+	// Types are the rdf:type IRIs of the entry.
+	Types []string
+	// Action is the path of the document to read.
+	Action string
+	// Result is the path of the N-Triples or N-Quads file holding the
+	// expected statements, or "" if the entry has none.
+	Result string
+	// BaseIRI is the IRI of the action document, against which its
+	// relative IRIs are resolved, or "" if the manifest does not give an
+	// mf:assumedTestBase.
+	BaseIRI string
+	// Manifest is the path of the manifest that lists the entry.
+	Manifest string
+}
+
+// ParseManifest reads the entries of the Turtle test manifest at path, in
+// the vocabulary of the W3C RDF test suites, following mf:include lists to
+// other manifests. root is a local copy of the w3c/rdf-tests repository in
+// which files that do not exist relative to their manifest are looked up;
+// it may be empty.
+func ParseManifest(path, root string) ([]TestCase, error) {
+	p := manifestParser{root: root, seen: map[string]bool{}}
+	if err := p.parse(path); err != nil {
+		return nil, err
+	}
+	return p.tests, nil
+}
+
+type manifestParser struct {
+	root  string
+	seen  map[string]bool
+	tests []TestCase
+}
+
+// manifestGraph indexes the statements of a manifest by subject.
+type manifestGraph map[rdf.Term][]rdf.Statement
+
+func (g manifestGraph) object(subject rdf.Term, predicate string) rdf.Term {
+	for _, s := range g[subject] {
+		if s.P.Value == predicate {
+			return s.O
+		}
+	}
+	return nil
+}
+
+func (g manifestGraph) objects(subject rdf.Term, predicate string) []rdf.Term {
+	var out []rdf.Term
+	for _, s := range g[subject] {
+		if s.P.Value == predicate {
+			out = append(out, s.O)
+		}
+	}
+	return out
+}
+
+// list returns the items of the RDF list starting at head.
+func (g manifestGraph) list(head rdf.Term) []rdf.Term {
+	var items []rdf.Term
+	seen := map[rdf.Term]bool{}
+	for head != nil && head != (rdf.IRI{Value: rdfNil}) && !seen[head] {
+		seen[head] = true
+		if first := g.object(head, rdfFirst); first != nil {
+			items = append(items, first)
+		}
+		head = g.object(head, rdfRest)
+	}
+	return items
+}
+
+func (p *manifestParser) parse(path string) error {
+	path = filepath.Clean(path)
+	if p.seen[path] {
+		return nil
+	}
+	p.seen[path] = true
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	graph := manifestGraph{}
+	var manifests []rdf.Term
+	err = rdf.Parse(context.Background(), f, rdf.FormatTurtle, func(s rdf.Statement) error {
+		graph[s.S] = append(graph[s.S], s)
+		if s.P.Value == mfEntries || s.P.Value == mfInclude || s.P.Value == mfAssumedBase {
+			manifests = append(manifests, s.S)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("testsuite: manifest %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	done := map[rdf.Term]bool{}
+	for _, manifest := range manifests {
+		if done[manifest] {
+			continue
+		}
+		done[manifest] = true
+		base := iriValue(graph.object(manifest, mfAssumedBase))
+		for _, head := range graph.objects(manifest, mfEntries) {
+			for _, entry := range graph.list(head) {
+				if tc, ok := p.testCase(graph, entry, dir, base); ok {
+					tc.Manifest = path
+					p.tests = append(p.tests, tc)
+				}
+			}
+		}
+		for _, head := range graph.objects(manifest, mfInclude) {
+			for _, include := range graph.list(head) {
+				if file := p.resolve(dir, base, iriValue(include)); file != "" {
+					if err := p.parse(file); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p *manifestParser) testCase(graph manifestGraph, entry rdf.Term, dir, base string) (TestCase, bool) {
+	action := iriValue(graph.object(entry, mfAction))
+	if action == "" {
+		return TestCase{}, false
+	}
+	tc := TestCase{
+		Name:    literalValue(graph.object(entry, mfName)),
+		Comment: literalValue(graph.object(entry, rdfsComment)),
+		Action:  p.resolve(dir, base, action),
+	}
+	if tc.Action == "" {
+		return TestCase{}, false
+	}
+	if tc.Name == "" {
+		tc.Name = filepath.Base(tc.Action)
+	}
+	if result := iriValue(graph.object(entry, mfResult)); result != "" {
+		tc.Result = p.resolve(dir, base, result)
+	}
+	if base != "" {
+		tc.BaseIRI = rdf.ResolveIRI(base, action)
+	}
+	for _, typ := range graph.objects(entry, rdfType) {
+		if iri := iriValue(typ); iri != "" {
+			tc.Types = append(tc.Types, iri)
+		}
+	}
+	tc.Type = testType(tc.Types)
+	return tc, true
+}
+
+// testType classifies an entry by the local names of its types, such as
+// rdft:TestTurtleNegativeSyntax or rdft:TestXMLEval.
+func testType(types []string) TestType {
+	for _, typ := range types {
+		switch {
+		case strings.HasSuffix(typ, "NegativeEval"):
+			return NegativeEval
+		case strings.HasSuffix(typ, "NegativeSyntax"):
+			return NegativeSyntax
+		case strings.HasSuffix(typ, "Eval"), strings.HasSuffix(typ, "C14N"):
+			return Eval
+		}
+	}
+	return PositiveSyntax
+}
+
+// resolve returns the path of the file named by iri in a manifest in dir,
+// whose entries have the IRIs of base, or "" if iri names no file.
+func (p *manifestParser) resolve(dir, base, iri string) string {
+	if iri == "" || strings.HasPrefix(iri, "#") {
+		return ""
+	}
+	if i := strings.IndexByte(iri, '#'); i >= 0 {
+		iri = iri[:i]
+	}
+	if rel, ok := strings.CutPrefix(iri, manifestFileIRI); ok {
+		return filepath.FromSlash("/" + rel)
+	}
+	if !strings.Contains(iri, ":") {
+		if local := filepath.Join(dir, filepath.FromSlash(iri)); fileExists(local) || base == "" {
+			return local
+		}
+		iri = rdf.ResolveIRI(base, iri)
+	}
+	if rel, ok := strings.CutPrefix(iri, W3CTestsBase); ok && p.root != "" {
+		if local := filepath.Join(p.root, filepath.FromSlash(rel)); fileExists(local) {
+			return local
+		}
+	}
+	if base != "" {
+		if rel, ok := strings.CutPrefix(iri, base); ok {
+			return filepath.Join(dir, filepath.FromSlash(rel))
+		}
+	}
+	return ""
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func iriValue(term rdf.Term) string {
+	switch t := term.(type) {
+	case rdf.IRI:
+		return t.Value
+	case rdf.Literal:
+		return t.Lexical
+	}
+	return ""
+}
+
+func literalValue(term rdf.Term) string {
+	if lit, ok := term.(rdf.Literal); ok {
+		return lit.Lexical
+	}
+	return ""
+}
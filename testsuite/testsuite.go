@@ -0,0 +1,126 @@
+// Package testsuite runs manifest-driven conformance tests, in the format of
+// the W3C RDF test suites, against the readers of the rdf package:
+//
+//	func TestTurtleSuite(t *testing.T) {
+//		testsuite.RunManifest(t, "rdf-tests/rdf/rdf12/rdf-turtle/manifest.ttl",
+//			testsuite.Config{Format: rdf.FormatTurtle})
+//	}
+//
+// Each manifest entry runs as a subtest. Syntax tests check that the
+// action document is read without error, or fails to be read for negative
+// tests; evaluation tests also compare the statements read with those of
+// the result file, up to blank node labels. Formats plugged in with
+// rdf.RegisterFormat run through the same harness as the built-in ones.
+package testsuite
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// Config configures RunManifest.
+type Config struct {
+	// Format is the format of the action documents.
+	Format rdf.Format
+	// Options are passed to every reader, after OptBaseIRI with the base
+	// IRI of the test.
+	Options []rdf.Option
+	// Root is a local copy of the w3c/rdf-tests repository, in which files
+	// that do not exist relative to their manifest are looked up by their
+	// IRI under W3CTestsBase. It may be empty.
+	Root string
+	// Skip returns why a test is skipped, or "" to run it.
+	Skip func(TestCase) string
+	// SkipResults only checks that Eval tests are read without error,
+	// without comparing their statements with their result files.
+	SkipResults bool
+	// LenientNegative skips, instead of failing, negative tests whose
+	// action is read without error.
+	LenientNegative bool
+	// Check, if set, is called with the statements of every positive test
+	// read without error, to add checks of its own.
+	Check func(t *testing.T, tc TestCase, stmts []rdf.Statement)
+}
+
+// RunManifest runs the entries of the test manifest at path, and of the
+// manifests it includes, as subtests of t named after the entries. It
+// fails t if the manifest cannot be read or lists no tests.
+func RunManifest(t *testing.T, path string, cfg Config) {
+	t.Helper()
+	tests, err := ParseManifest(path, cfg.Root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) == 0 {
+		t.Fatalf("testsuite: manifest %s lists no tests", path)
+	}
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			if cfg.Skip != nil {
+				if reason := cfg.Skip(tc); reason != "" {
+					t.Skip(reason)
+				}
+			}
+			RunTest(t, tc, cfg)
+		})
+	}
+}
+
+// RunTest runs a single manifest entry, as RunManifest does for each of
+// them.
+func RunTest(t *testing.T, tc TestCase, cfg Config) {
+	t.Helper()
+	stmts, err := ReadFile(tc.Action, cfg.Format, cfg.options(tc)...)
+	if tc.Type.Negative() {
+		switch {
+		case err != nil:
+		case cfg.LenientNegative:
+			t.Skipf("%s test %s accepted by the reader", tc.Type, tc.Name)
+		default:
+			t.Errorf("%s test %s read without error", tc.Type, tc.Name)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("%s test %s: %v", tc.Type, tc.Name, err)
+	}
+	if cfg.Check != nil {
+		cfg.Check(t, tc, stmts)
+	}
+	if tc.Type != Eval || tc.Result == "" || cfg.SkipResults {
+		return
+	}
+	want, err := ReadFile(tc.Result, rdf.FormatNQuads)
+	if err != nil {
+		t.Fatalf("result of %s: %v", tc.Name, err)
+	}
+	if !Isomorphic(stmts, want) {
+		t.Errorf("%s read %d statements not isomorphic to the %d of %s", tc.Action, len(stmts), len(want), tc.Result)
+	}
+}
+
+func (cfg Config) options(tc TestCase) []rdf.Option {
+	opts := make([]rdf.Option, 0, len(cfg.Options)+1)
+	if tc.BaseIRI != "" {
+		opts = append(opts, rdf.OptBaseIRI(tc.BaseIRI))
+	}
+	return append(opts, cfg.Options...)
+}
+
+// ReadFile reads all statements of the file at path in format.
+func ReadFile(path string, format rdf.Format, opts ...rdf.Option) ([]rdf.Statement, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var stmts []rdf.Statement
+	err = rdf.Parse(context.Background(), f, format, func(s rdf.Statement) error {
+		stmts = append(stmts, s)
+		return nil
+	}, opts...)
+	return stmts, err
+}
@@ -0,0 +1,99 @@
+package testsuite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+func TestParseManifest(t *testing.T) {
+	tests, err := ParseManifest(filepath.Join("testdata", "manifest.ttl"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TestCase{
+		{Name: "positive", Comment: "A prefixed name", Type: PositiveSyntax,
+			Action: filepath.Join("testdata", "positive.ttl"), BaseIRI: "https://example.org/tests/positive.ttl"},
+		{Name: "negative", Type: NegativeSyntax,
+			Action: filepath.Join("testdata", "negative.ttl"), BaseIRI: "https://example.org/tests/negative.ttl"},
+		{Name: "blank-nodes", Type: Eval,
+			Action: filepath.Join("testdata", "eval", "blank-nodes.ttl"), Result: filepath.Join("testdata", "eval", "blank-nodes.nt"),
+			BaseIRI: "https://example.org/tests/eval/blank-nodes.ttl"},
+		{Name: "relative-iri", Type: Eval,
+			Action: filepath.Join("testdata", "eval", "relative-iri.ttl"), Result: filepath.Join("testdata", "eval", "relative-iri.nt"),
+			BaseIRI: "https://example.org/tests/eval/relative-iri.ttl"},
+	}
+	if len(tests) != len(want) {
+		t.Fatalf("ParseManifest returned %d tests, want %d", len(tests), len(want))
+	}
+	for i, tc := range tests {
+		w := want[i]
+		if tc.Name != w.Name || tc.Comment != w.Comment || tc.Type != w.Type || tc.Action != w.Action ||
+			tc.Result != w.Result || tc.BaseIRI != w.BaseIRI {
+			t.Errorf("test %d = %+v, want %+v", i, tc, w)
+		}
+		if len(tc.Types) != 1 {
+			t.Errorf("test %d has types %v", i, tc.Types)
+		}
+	}
+}
+
+func TestRunManifest(t *testing.T) {
+	var checked []string
+	RunManifest(t, filepath.Join("testdata", "manifest.ttl"), Config{
+		Format: rdf.FormatTurtle,
+		Check: func(t *testing.T, tc TestCase, stmts []rdf.Statement) {
+			checked = append(checked, tc.Name)
+		},
+	})
+	if len(checked) != 3 {
+		t.Errorf("Check called for %v, want the 3 positive tests", checked)
+	}
+}
+
+func TestRunManifestRDFXMLDirection(t *testing.T) {
+	var checked []string
+	RunManifest(t, filepath.Join("testdata", "rdfxml", "manifest.ttl"), Config{
+		Format: rdf.FormatRDFXML,
+		Check: func(t *testing.T, tc TestCase, stmts []rdf.Statement) {
+			checked = append(checked, tc.Name)
+		},
+	})
+	if len(checked) != 5 {
+		t.Errorf("Check called for %v, want the 5 direction tests", checked)
+	}
+}
+
+func TestIsomorphic(t *testing.T) {
+	ex := func(local string) rdf.IRI { return rdf.IRI{Value: "http://example.org/" + local} }
+	b := func(id string) rdf.BlankNode { return rdf.BlankNode{ID: id} }
+	p := ex("p")
+	tests := []struct {
+		name string
+		a, b []rdf.Statement
+		want bool
+	}{
+		{"relabelled", []rdf.Statement{{S: b("a"), P: p, O: b("b")}, {S: b("b"), P: p, O: ex("o")}},
+			[]rdf.Statement{{S: b("y"), P: p, O: ex("o")}, {S: b("x"), P: p, O: b("y")}}, true},
+		{"swapped roles", []rdf.Statement{{S: b("a"), P: p, O: b("b")}, {S: b("b"), P: p, O: ex("o")}},
+			[]rdf.Statement{{S: b("x"), P: p, O: b("y")}, {S: b("x"), P: p, O: ex("o")}}, false},
+		{"duplicates", []rdf.Statement{{S: ex("s"), P: p, O: ex("o")}, {S: ex("s"), P: p, O: ex("o")}},
+			[]rdf.Statement{{S: ex("s"), P: p, O: ex("o")}}, true},
+		{"graphs", []rdf.Statement{{S: ex("s"), P: p, O: ex("o"), G: b("g")}},
+			[]rdf.Statement{{S: ex("s"), P: p, O: ex("o")}}, false},
+		{"triple terms", []rdf.Statement{{S: b("r"), P: p, O: rdf.TripleTerm{S: b("a"), P: p, O: b("r")}}},
+			[]rdf.Statement{{S: b("x"), P: p, O: rdf.TripleTerm{S: b("y"), P: p, O: b("x")}}}, true},
+		{"simple literals", []rdf.Statement{{S: ex("s"), P: p, O: rdf.Literal{Lexical: "v"}}},
+			[]rdf.Statement{{S: ex("s"), P: p, O: rdf.Literal{Lexical: "v", Datatype: rdf.IRI{Value: xsdString}}}}, true},
+		{"symmetric cycle", []rdf.Statement{{S: b("a"), P: p, O: b("b")}, {S: b("b"), P: p, O: b("a")}},
+			[]rdf.Statement{{S: b("x"), P: p, O: b("y")}, {S: b("y"), P: p, O: b("x")}}, true},
+		{"two cycles", []rdf.Statement{{S: b("a"), P: p, O: b("b")}, {S: b("b"), P: p, O: b("a")}, {S: b("c"), P: p, O: b("d")}, {S: b("d"), P: p, O: b("c")}},
+			[]rdf.Statement{{S: b("w"), P: p, O: b("x")}, {S: b("x"), P: p, O: b("y")}, {S: b("y"), P: p, O: b("z")}, {S: b("z"), P: p, O: b("w")}}, false},
+	}
+	for _, tt := range tests {
+		if got := Isomorphic(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: Isomorphic = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package testsuite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// TestW3CConformance runs the W3C test suites of the line-based, Turtle and
+// RDF/XML formats; the JSON-LD suite runs in the rdf package. Set
+// W3C_TESTS_DIR to a directory holding a manifest.ttl in turtle, ntriples,
+// trig, nquads and rdfxml, and a copy of w3c/rdf-tests in rdf-tests.
+func TestW3CConformance(t *testing.T) {
+	root := os.Getenv("W3C_TESTS_DIR")
+	if root == "" {
+		t.Skip("W3C_TESTS_DIR not set; skipping W3C conformance tests")
+	}
+	suites := []struct {
+		dir string
+		cfg Config
+	}{
+		{"turtle", Config{Format: rdf.FormatTurtle, Check: checkNumericLiteralDatatypes}},
+		{"ntriples", Config{Format: rdf.FormatNTriples}},
+		{"trig", Config{Format: rdf.FormatTriG}},
+		{"nquads", Config{Format: rdf.FormatNQuads}},
+		{"rdfxml", Config{Format: rdf.FormatRDFXML, LenientNegative: true}},
+	}
+	for _, suite := range suites {
+		t.Run(suite.dir, func(t *testing.T) {
+			manifest := filepath.Join(root, suite.dir, "manifest.ttl")
+			if _, err := os.Stat(manifest); err != nil {
+				t.Skipf("no manifest %s", manifest)
+			}
+			suite.cfg.Root = filepath.Join(root, "rdf-tests")
+			// The suites only check syntax for now.
+			suite.cfg.SkipResults = true
+			RunManifest(t, manifest, suite.cfg)
+		})
+	}
+}
+
+// checkNumericLiteralDatatypes checks that numeric literals have the
+// datatype of their lexical form, which the Turtle eval tests only check
+// for the numbers they use: integers without a point or exponent, decimals
+// with a point and doubles with an exponent.
+func checkNumericLiteralDatatypes(t *testing.T, _ TestCase, stmts []rdf.Statement) {
+	const (
+		xsdInteger = "http://www.w3.org/2001/XMLSchema#integer"
+		xsdDecimal = "http://www.w3.org/2001/XMLSchema#decimal"
+		xsdDouble  = "http://www.w3.org/2001/XMLSchema#double"
+	)
+	for i, s := range stmts {
+		lit, ok := s.O.(rdf.Literal)
+		if !ok {
+			continue
+		}
+		switch lit.Datatype.Value {
+		case xsdInteger, xsdDecimal, xsdDouble:
+		default:
+			continue
+		}
+		want := xsdInteger
+		switch {
+		case strings.ContainsAny(lit.Lexical, "eE"):
+			want = xsdDouble
+		case strings.Contains(lit.Lexical, "."):
+			want = xsdDecimal
+		}
+		if lit.Datatype.Value != want {
+			t.Errorf("statement %d: numeric literal %q has datatype %s, want %s", i+1, lit.Lexical, lit.Datatype.Value, want)
+		}
+	}
+}
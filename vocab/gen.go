@@ -0,0 +1,240 @@
+//go:build ignore
+
+// gen.go writes vocabularies.go from the term lists below. Run it with
+// go generate in this directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+type vocabulary struct {
+	name      string // Go variable name
+	prefix    string // Conventional prefix, used in doc comments
+	title     string
+	namespace string
+	terms     []string
+}
+
+var vocabularies = []vocabulary{
+	{
+		name: "RDF", prefix: "rdf", title: "the RDF 1.2 vocabulary",
+		namespace: "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+		terms: []string{
+			"type", "Property", "Statement", "subject", "predicate", "object", "reifies",
+			"List", "first", "rest", "nil", "value", "Alt", "Bag", "Seq",
+			"langString", "dirLangString", "HTML", "XMLLiteral", "JSON",
+			"CompoundLiteral", "language", "direction",
+		},
+	},
+	{
+		name: "RDFS", prefix: "rdfs", title: "RDF Schema",
+		namespace: "http://www.w3.org/2000/01/rdf-schema#",
+		terms: []string{
+			"Resource", "Class", "Literal", "Datatype", "Container", "ContainerMembershipProperty",
+			"subClassOf", "subPropertyOf", "domain", "range", "label", "comment",
+			"seeAlso", "isDefinedBy", "member",
+		},
+	},
+	{
+		name: "OWL", prefix: "owl", title: "the OWL 2 vocabulary",
+		namespace: "http://www.w3.org/2002/07/owl#",
+		terms: []string{
+			"Class", "Thing", "Nothing", "Ontology", "Restriction", "NamedIndividual",
+			"ObjectProperty", "DatatypeProperty", "AnnotationProperty", "OntologyProperty",
+			"FunctionalProperty", "InverseFunctionalProperty", "TransitiveProperty",
+			"SymmetricProperty", "AsymmetricProperty", "ReflexiveProperty", "IrreflexiveProperty",
+			"AllDisjointClasses", "AllDisjointProperties", "AllDifferent", "Axiom",
+			"DeprecatedClass", "DeprecatedProperty", "NegativePropertyAssertion",
+			"sameAs", "differentFrom", "equivalentClass", "equivalentProperty", "inverseOf",
+			"disjointWith", "propertyDisjointWith", "disjointUnionOf", "onProperty", "onClass",
+			"onDataRange", "onDatatype", "someValuesFrom", "allValuesFrom", "hasValue", "hasSelf",
+			"cardinality", "minCardinality", "maxCardinality", "qualifiedCardinality",
+			"minQualifiedCardinality", "maxQualifiedCardinality", "unionOf", "intersectionOf",
+			"complementOf", "oneOf", "members", "distinctMembers", "propertyChainAxiom", "hasKey",
+			"withRestrictions", "imports", "versionIRI", "versionInfo", "priorVersion",
+			"backwardCompatibleWith", "incompatibleWith", "deprecated",
+			"annotatedSource", "annotatedProperty", "annotatedTarget",
+			"sourceIndividual", "assertionProperty", "targetIndividual", "targetValue",
+			"topObjectProperty", "bottomObjectProperty", "topDataProperty", "bottomDataProperty",
+			"rational", "real",
+		},
+	},
+	{
+		name: "XSD", prefix: "xsd", title: "the XML Schema datatypes",
+		namespace: "http://www.w3.org/2001/XMLSchema#",
+		terms: []string{
+			"string", "boolean", "decimal", "integer", "double", "float",
+			"date", "time", "dateTime", "dateTimeStamp",
+			"duration", "dayTimeDuration", "yearMonthDuration",
+			"gYear", "gYearMonth", "gMonth", "gMonthDay", "gDay",
+			"hexBinary", "base64Binary", "anyURI",
+			"normalizedString", "token", "language", "Name", "NCName", "NMTOKEN",
+			"long", "int", "short", "byte",
+			"nonNegativeInteger", "positiveInteger", "nonPositiveInteger", "negativeInteger",
+			"unsignedLong", "unsignedInt", "unsignedShort", "unsignedByte",
+		},
+	},
+	{
+		name: "SKOS", prefix: "skos", title: "the Simple Knowledge Organization System",
+		namespace: "http://www.w3.org/2004/02/skos/core#",
+		terms: []string{
+			"Concept", "ConceptScheme", "Collection", "OrderedCollection",
+			"prefLabel", "altLabel", "hiddenLabel", "notation",
+			"note", "changeNote", "definition", "editorialNote", "example", "historyNote", "scopeNote",
+			"semanticRelation", "broader", "narrower", "related", "broaderTransitive", "narrowerTransitive",
+			"inScheme", "hasTopConcept", "topConceptOf", "member", "memberList",
+			"mappingRelation", "exactMatch", "closeMatch", "broadMatch", "narrowMatch", "relatedMatch",
+		},
+	},
+	{
+		name: "DCTERMS", prefix: "dcterms", title: "the DCMI Metadata Terms",
+		namespace: "http://purl.org/dc/terms/",
+		terms: []string{
+			"Agent", "AgentClass", "BibliographicResource", "FileFormat", "Frequency", "Jurisdiction",
+			"LicenseDocument", "LinguisticSystem", "Location", "LocationPeriodOrJurisdiction",
+			"MediaType", "MediaTypeOrExtent", "PeriodOfTime", "PhysicalResource", "Policy",
+			"ProvenanceStatement", "RightsStatement", "SizeOrDuration", "Standard",
+			"title", "alternative", "creator", "contributor", "publisher", "subject", "description",
+			"abstract", "tableOfContents", "date", "created", "modified", "issued", "available",
+			"valid", "identifier", "language", "license", "rights", "rightsHolder", "accessRights",
+			"source", "type", "format", "extent", "medium", "relation", "isPartOf", "hasPart",
+			"isVersionOf", "hasVersion", "references", "isReferencedBy", "replaces", "isReplacedBy",
+			"requires", "isRequiredBy", "conformsTo", "coverage", "spatial", "temporal",
+			"audience", "mediator", "educationLevel", "provenance", "bibliographicCitation",
+			"accrualPeriodicity",
+		},
+	},
+	{
+		name: "FOAF", prefix: "foaf", title: "the Friend of a Friend vocabulary",
+		namespace: "http://xmlns.com/foaf/0.1/",
+		terms: []string{
+			"Agent", "Person", "Organization", "Group", "Document", "Image", "OnlineAccount", "Project",
+			"name", "givenName", "familyName", "nick", "title", "mbox", "mbox_sha1sum",
+			"homepage", "page", "weblog", "openid", "phone", "img", "depiction", "depicts", "logo",
+			"knows", "member", "made", "maker", "account", "accountName", "accountServiceHomepage",
+			"based_near", "topic", "primaryTopic", "isPrimaryTopicOf", "interest",
+			"age", "birthday", "gender", "workplaceHomepage", "schoolHomepage",
+		},
+	},
+	{
+		name: "GeoSPARQL", prefix: "geo", title: "the GeoSPARQL 1.1 ontology",
+		namespace: "http://www.opengis.net/ont/geosparql#",
+		terms: []string{
+			"SpatialObject", "Feature", "Geometry", "SpatialObjectCollection", "FeatureCollection",
+			"GeometryCollection",
+			"hasGeometry", "hasDefaultGeometry", "hasCentroid", "hasBoundingBox",
+			"hasSerialization", "asWKT", "asGML", "asGeoJSON", "asKML", "asDGGS",
+			"wktLiteral", "gmlLiteral", "geoJSONLiteral", "kmlLiteral", "dggsLiteral",
+			"dimension", "coordinateDimension", "spatialDimension", "isEmpty", "isSimple",
+			"hasSize", "hasLength", "hasMetricLength", "hasPerimeterLength", "hasMetricPerimeterLength",
+			"hasArea", "hasMetricArea", "hasVolume", "hasMetricVolume",
+			"hasSpatialResolution", "hasMetricSpatialResolution", "hasSpatialAccuracy", "hasMetricSpatialAccuracy",
+			"sfEquals", "sfDisjoint", "sfIntersects", "sfTouches", "sfCrosses", "sfWithin", "sfContains", "sfOverlaps",
+			"ehEquals", "ehDisjoint", "ehMeet", "ehOverlap", "ehCovers", "ehCoveredBy", "ehInside", "ehContains",
+			"rcc8eq", "rcc8dc", "rcc8ec", "rcc8po", "rcc8tppi", "rcc8tpp", "rcc8ntpp", "rcc8ntppi",
+		},
+	},
+	{
+		name: "PROV", prefix: "prov", title: "the PROV ontology",
+		namespace: "http://www.w3.org/ns/prov#",
+		terms: []string{
+			"Entity", "Activity", "Agent", "Person", "Organization", "SoftwareAgent",
+			"Plan", "Bundle", "Collection", "EmptyCollection", "Location", "Role",
+			"Influence", "EntityInfluence", "ActivityInfluence", "AgentInfluence",
+			"Usage", "Generation", "Invalidation", "Start", "End", "Communication",
+			"Association", "Attribution", "Delegation", "Derivation", "Revision", "Quotation",
+			"PrimarySource",
+			"wasGeneratedBy", "used", "wasInformedBy", "wasStartedBy", "wasEndedBy", "wasInvalidatedBy",
+			"wasDerivedFrom", "wasRevisionOf", "wasQuotedFrom", "hadPrimarySource",
+			"wasAttributedTo", "wasAssociatedWith", "actedOnBehalfOf", "wasInfluencedBy",
+			"alternateOf", "specializationOf", "hadMember", "atLocation", "value",
+			"generatedAtTime", "invalidatedAtTime", "startedAtTime", "endedAtTime", "atTime",
+			"generated", "invalidated", "influenced",
+			"qualifiedInfluence", "qualifiedGeneration", "qualifiedUsage", "qualifiedInvalidation",
+			"qualifiedStart", "qualifiedEnd", "qualifiedCommunication", "qualifiedAssociation",
+			"qualifiedAttribution", "qualifiedDelegation", "qualifiedDerivation", "qualifiedRevision",
+			"qualifiedQuotation", "qualifiedPrimarySource",
+			"entity", "activity", "agent", "influencer", "hadPlan", "hadRole", "hadActivity",
+			"hadGeneration", "hadUsage",
+		},
+	},
+}
+
+// fieldNames returns the Go field name of each term: the local name with
+// underscores removed and the following letters in upper case, and a
+// "Property" suffix for lower-case terms that would clash with a class.
+func fieldNames(terms []string) []string {
+	exported := func(local string) string {
+		var b strings.Builder
+		upper := true
+		for _, r := range local {
+			if r == '_' {
+				upper = true
+				continue
+			}
+			if upper {
+				b.WriteString(strings.ToUpper(string(r)))
+				upper = false
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}
+	classes := make(map[string]bool)
+	for _, term := range terms {
+		if term[0] >= 'A' && term[0] <= 'Z' {
+			classes[term] = true
+		}
+	}
+	names := make([]string, len(terms))
+	seen := make(map[string]string)
+	for i, term := range terms {
+		name := exported(term)
+		if !classes[term] && classes[name] {
+			name += "Property"
+		}
+		if other, ok := seen[name]; ok {
+			log.Fatalf("terms %q and %q both map to field %s", other, term, name)
+		}
+		if name == "Namespace" {
+			log.Fatalf("term %q clashes with the Namespace field", term)
+		}
+		seen[name] = term
+		names[i] = name
+	}
+	return names
+}
+
+func main() {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen.go; DO NOT EDIT.\n\npackage vocab\n\n")
+	b.WriteString("import \"github.com/geoknoesis/rdf-go/rdf\"\n")
+	for _, v := range vocabularies {
+		names := fieldNames(v.terms)
+		fmt.Fprintf(&b, "\n// %s holds the terms of %s, namespace %s.\n", v.name, v.title, v.namespace)
+		fmt.Fprintf(&b, "var %s = struct {\n", v.name)
+		fmt.Fprintf(&b, "\t// Namespace is the %s: namespace.\n\tNamespace Namespace\n\n", v.prefix)
+		for i, term := range v.terms {
+			fmt.Fprintf(&b, "\t%s rdf.IRI // %s:%s\n", names[i], v.prefix, term)
+		}
+		fmt.Fprintf(&b, "}{\n\tNamespace: %q,\n", v.namespace)
+		for i, term := range v.terms {
+			fmt.Fprintf(&b, "\t%s: rdf.IRI{Value: %q},\n", names[i], v.namespace+term)
+		}
+		b.WriteString("}\n")
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("vocabularies.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,45 @@
+// Package vocab provides the IRIs of well-known RDF vocabularies as
+// rdf.IRI values, so that calling code does not spell out raw IRI strings:
+//
+//	rdf.NewTriple(alice, vocab.RDF.Type, vocab.FOAF.Person)
+//	rdf.Literal{Lexical: "2024-05-01T12:00:00Z", Datatype: vocab.XSD.DateTime}
+//
+// Each vocabulary is a struct value with one field per term, named after the
+// term's local name with its first letter in upper case (vocab.RDFS.SubClassOf
+// for rdfs:subClassOf). Where a property differs from a class only in case,
+// the property field gets a "Property" suffix (vocab.PROV.EntityProperty for
+// prov:entity). Terms not listed are available through the vocabulary's
+// Namespace field, and Namespace describes any other vocabulary:
+//
+//	ex := vocab.Namespace("http://example.org/")
+//	alice := ex.Term("alice")
+//
+// The vocabularies are generated from the term lists in gen.go; run
+// go generate after changing them.
+package vocab
+
+//go:generate go run gen.go
+
+import (
+	"strings"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+// Namespace is the IRI prefix shared by the terms of a vocabulary, such as
+// "http://xmlns.com/foaf/0.1/".
+type Namespace string
+
+// Term returns the IRI of the term local in the namespace.
+func (ns Namespace) Term(local string) rdf.IRI {
+	return rdf.IRI{Value: string(ns) + local}
+}
+
+// Local returns the local name of iri in the namespace, and false if iri is
+// not in the namespace.
+func (ns Namespace) Local(iri rdf.IRI) (string, bool) {
+	if ns == "" || !strings.HasPrefix(iri.Value, string(ns)) {
+		return "", false
+	}
+	return iri.Value[len(ns):], true
+}
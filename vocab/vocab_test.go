@@ -0,0 +1,53 @@
+package vocab
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/geoknoesis/rdf-go/rdf"
+)
+
+func TestNamespace(t *testing.T) {
+	ex := Namespace("http://example.org/")
+	if got := ex.Term("alice"); got != (rdf.IRI{Value: "http://example.org/alice"}) {
+		t.Errorf("Term = %v", got)
+	}
+	if local, ok := ex.Local(rdf.IRI{Value: "http://example.org/bob"}); !ok || local != "bob" {
+		t.Errorf("Local = %q, %v", local, ok)
+	}
+	if _, ok := ex.Local(rdf.IRI{Value: "http://example.com/bob"}); ok {
+		t.Error("Local accepted an IRI outside the namespace")
+	}
+}
+
+func TestVocabularies(t *testing.T) {
+	if RDF.Type.Value != "http://www.w3.org/1999/02/22-rdf-syntax-ns#type" ||
+		XSD.DateTime.Value != "http://www.w3.org/2001/XMLSchema#dateTime" ||
+		PROV.Entity.Value != "http://www.w3.org/ns/prov#Entity" ||
+		PROV.EntityProperty.Value != "http://www.w3.org/ns/prov#entity" ||
+		FOAF.BasedNear.Value != "http://xmlns.com/foaf/0.1/based_near" {
+		t.Error("unexpected term IRI")
+	}
+	for _, v := range []any{RDF, RDFS, OWL, XSD, SKOS, DCTERMS, FOAF, GeoSPARQL, PROV} {
+		value := reflect.ValueOf(v)
+		ns := value.FieldByName("Namespace").Interface().(Namespace)
+		seen := make(map[string]bool)
+		for i := 0; i < value.NumField(); i++ {
+			iri, ok := value.Field(i).Interface().(rdf.IRI)
+			if !ok {
+				continue
+			}
+			local, inNamespace := ns.Local(iri)
+			name := value.Type().Field(i).Name
+			if !inNamespace || seen[local] {
+				t.Errorf("%s: %s = %s is outside %s or repeated", ns, name, iri.Value, ns)
+			}
+			seen[local] = true
+			if !strings.EqualFold(strings.TrimSuffix(name, "Property"), strings.ReplaceAll(local, "_", "")) &&
+				!strings.EqualFold(name, strings.ReplaceAll(local, "_", "")) {
+				t.Errorf("%s: field %s holds %s", ns, name, local)
+			}
+		}
+	}
+}
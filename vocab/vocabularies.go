@@ -0,0 +1,952 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package vocab
+
+import "github.com/geoknoesis/rdf-go/rdf"
+
+// RDF holds the terms of the RDF 1.2 vocabulary, namespace http://www.w3.org/1999/02/22-rdf-syntax-ns#.
+var RDF = struct {
+	// Namespace is the rdf: namespace.
+	Namespace Namespace
+
+	Type            rdf.IRI // rdf:type
+	Property        rdf.IRI // rdf:Property
+	Statement       rdf.IRI // rdf:Statement
+	Subject         rdf.IRI // rdf:subject
+	Predicate       rdf.IRI // rdf:predicate
+	Object          rdf.IRI // rdf:object
+	Reifies         rdf.IRI // rdf:reifies
+	List            rdf.IRI // rdf:List
+	First           rdf.IRI // rdf:first
+	Rest            rdf.IRI // rdf:rest
+	Nil             rdf.IRI // rdf:nil
+	Value           rdf.IRI // rdf:value
+	Alt             rdf.IRI // rdf:Alt
+	Bag             rdf.IRI // rdf:Bag
+	Seq             rdf.IRI // rdf:Seq
+	LangString      rdf.IRI // rdf:langString
+	DirLangString   rdf.IRI // rdf:dirLangString
+	HTML            rdf.IRI // rdf:HTML
+	XMLLiteral      rdf.IRI // rdf:XMLLiteral
+	JSON            rdf.IRI // rdf:JSON
+	CompoundLiteral rdf.IRI // rdf:CompoundLiteral
+	Language        rdf.IRI // rdf:language
+	Direction       rdf.IRI // rdf:direction
+}{
+	Namespace:       "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	Type:            rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"},
+	Property:        rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#Property"},
+	Statement:       rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#Statement"},
+	Subject:         rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#subject"},
+	Predicate:       rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#predicate"},
+	Object:          rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#object"},
+	Reifies:         rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#reifies"},
+	List:            rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#List"},
+	First:           rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"},
+	Rest:            rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"},
+	Nil:             rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"},
+	Value:           rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#value"},
+	Alt:             rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#Alt"},
+	Bag:             rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#Bag"},
+	Seq:             rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#Seq"},
+	LangString:      rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString"},
+	DirLangString:   rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#dirLangString"},
+	HTML:            rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#HTML"},
+	XMLLiteral:      rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#XMLLiteral"},
+	JSON:            rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#JSON"},
+	CompoundLiteral: rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#CompoundLiteral"},
+	Language:        rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#language"},
+	Direction:       rdf.IRI{Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#direction"},
+}
+
+// RDFS holds the terms of RDF Schema, namespace http://www.w3.org/2000/01/rdf-schema#.
+var RDFS = struct {
+	// Namespace is the rdfs: namespace.
+	Namespace Namespace
+
+	Resource                    rdf.IRI // rdfs:Resource
+	Class                       rdf.IRI // rdfs:Class
+	Literal                     rdf.IRI // rdfs:Literal
+	Datatype                    rdf.IRI // rdfs:Datatype
+	Container                   rdf.IRI // rdfs:Container
+	ContainerMembershipProperty rdf.IRI // rdfs:ContainerMembershipProperty
+	SubClassOf                  rdf.IRI // rdfs:subClassOf
+	SubPropertyOf               rdf.IRI // rdfs:subPropertyOf
+	Domain                      rdf.IRI // rdfs:domain
+	Range                       rdf.IRI // rdfs:range
+	Label                       rdf.IRI // rdfs:label
+	Comment                     rdf.IRI // rdfs:comment
+	SeeAlso                     rdf.IRI // rdfs:seeAlso
+	IsDefinedBy                 rdf.IRI // rdfs:isDefinedBy
+	Member                      rdf.IRI // rdfs:member
+}{
+	Namespace:                   "http://www.w3.org/2000/01/rdf-schema#",
+	Resource:                    rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#Resource"},
+	Class:                       rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#Class"},
+	Literal:                     rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#Literal"},
+	Datatype:                    rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#Datatype"},
+	Container:                   rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#Container"},
+	ContainerMembershipProperty: rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#ContainerMembershipProperty"},
+	SubClassOf:                  rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#subClassOf"},
+	SubPropertyOf:               rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#subPropertyOf"},
+	Domain:                      rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#domain"},
+	Range:                       rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#range"},
+	Label:                       rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#label"},
+	Comment:                     rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#comment"},
+	SeeAlso:                     rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#seeAlso"},
+	IsDefinedBy:                 rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#isDefinedBy"},
+	Member:                      rdf.IRI{Value: "http://www.w3.org/2000/01/rdf-schema#member"},
+}
+
+// OWL holds the terms of the OWL 2 vocabulary, namespace http://www.w3.org/2002/07/owl#.
+var OWL = struct {
+	// Namespace is the owl: namespace.
+	Namespace Namespace
+
+	Class                     rdf.IRI // owl:Class
+	Thing                     rdf.IRI // owl:Thing
+	Nothing                   rdf.IRI // owl:Nothing
+	Ontology                  rdf.IRI // owl:Ontology
+	Restriction               rdf.IRI // owl:Restriction
+	NamedIndividual           rdf.IRI // owl:NamedIndividual
+	ObjectProperty            rdf.IRI // owl:ObjectProperty
+	DatatypeProperty          rdf.IRI // owl:DatatypeProperty
+	AnnotationProperty        rdf.IRI // owl:AnnotationProperty
+	OntologyProperty          rdf.IRI // owl:OntologyProperty
+	FunctionalProperty        rdf.IRI // owl:FunctionalProperty
+	InverseFunctionalProperty rdf.IRI // owl:InverseFunctionalProperty
+	TransitiveProperty        rdf.IRI // owl:TransitiveProperty
+	SymmetricProperty         rdf.IRI // owl:SymmetricProperty
+	AsymmetricProperty        rdf.IRI // owl:AsymmetricProperty
+	ReflexiveProperty         rdf.IRI // owl:ReflexiveProperty
+	IrreflexiveProperty       rdf.IRI // owl:IrreflexiveProperty
+	AllDisjointClasses        rdf.IRI // owl:AllDisjointClasses
+	AllDisjointProperties     rdf.IRI // owl:AllDisjointProperties
+	AllDifferent              rdf.IRI // owl:AllDifferent
+	Axiom                     rdf.IRI // owl:Axiom
+	DeprecatedClass           rdf.IRI // owl:DeprecatedClass
+	DeprecatedProperty        rdf.IRI // owl:DeprecatedProperty
+	NegativePropertyAssertion rdf.IRI // owl:NegativePropertyAssertion
+	SameAs                    rdf.IRI // owl:sameAs
+	DifferentFrom             rdf.IRI // owl:differentFrom
+	EquivalentClass           rdf.IRI // owl:equivalentClass
+	EquivalentProperty        rdf.IRI // owl:equivalentProperty
+	InverseOf                 rdf.IRI // owl:inverseOf
+	DisjointWith              rdf.IRI // owl:disjointWith
+	PropertyDisjointWith      rdf.IRI // owl:propertyDisjointWith
+	DisjointUnionOf           rdf.IRI // owl:disjointUnionOf
+	OnProperty                rdf.IRI // owl:onProperty
+	OnClass                   rdf.IRI // owl:onClass
+	OnDataRange               rdf.IRI // owl:onDataRange
+	OnDatatype                rdf.IRI // owl:onDatatype
+	SomeValuesFrom            rdf.IRI // owl:someValuesFrom
+	AllValuesFrom             rdf.IRI // owl:allValuesFrom
+	HasValue                  rdf.IRI // owl:hasValue
+	HasSelf                   rdf.IRI // owl:hasSelf
+	Cardinality               rdf.IRI // owl:cardinality
+	MinCardinality            rdf.IRI // owl:minCardinality
+	MaxCardinality            rdf.IRI // owl:maxCardinality
+	QualifiedCardinality      rdf.IRI // owl:qualifiedCardinality
+	MinQualifiedCardinality   rdf.IRI // owl:minQualifiedCardinality
+	MaxQualifiedCardinality   rdf.IRI // owl:maxQualifiedCardinality
+	UnionOf                   rdf.IRI // owl:unionOf
+	IntersectionOf            rdf.IRI // owl:intersectionOf
+	ComplementOf              rdf.IRI // owl:complementOf
+	OneOf                     rdf.IRI // owl:oneOf
+	Members                   rdf.IRI // owl:members
+	DistinctMembers           rdf.IRI // owl:distinctMembers
+	PropertyChainAxiom        rdf.IRI // owl:propertyChainAxiom
+	HasKey                    rdf.IRI // owl:hasKey
+	WithRestrictions          rdf.IRI // owl:withRestrictions
+	Imports                   rdf.IRI // owl:imports
+	VersionIRI                rdf.IRI // owl:versionIRI
+	VersionInfo               rdf.IRI // owl:versionInfo
+	PriorVersion              rdf.IRI // owl:priorVersion
+	BackwardCompatibleWith    rdf.IRI // owl:backwardCompatibleWith
+	IncompatibleWith          rdf.IRI // owl:incompatibleWith
+	Deprecated                rdf.IRI // owl:deprecated
+	AnnotatedSource           rdf.IRI // owl:annotatedSource
+	AnnotatedProperty         rdf.IRI // owl:annotatedProperty
+	AnnotatedTarget           rdf.IRI // owl:annotatedTarget
+	SourceIndividual          rdf.IRI // owl:sourceIndividual
+	AssertionProperty         rdf.IRI // owl:assertionProperty
+	TargetIndividual          rdf.IRI // owl:targetIndividual
+	TargetValue               rdf.IRI // owl:targetValue
+	TopObjectProperty         rdf.IRI // owl:topObjectProperty
+	BottomObjectProperty      rdf.IRI // owl:bottomObjectProperty
+	TopDataProperty           rdf.IRI // owl:topDataProperty
+	BottomDataProperty        rdf.IRI // owl:bottomDataProperty
+	Rational                  rdf.IRI // owl:rational
+	Real                      rdf.IRI // owl:real
+}{
+	Namespace:                 "http://www.w3.org/2002/07/owl#",
+	Class:                     rdf.IRI{Value: "http://www.w3.org/2002/07/owl#Class"},
+	Thing:                     rdf.IRI{Value: "http://www.w3.org/2002/07/owl#Thing"},
+	Nothing:                   rdf.IRI{Value: "http://www.w3.org/2002/07/owl#Nothing"},
+	Ontology:                  rdf.IRI{Value: "http://www.w3.org/2002/07/owl#Ontology"},
+	Restriction:               rdf.IRI{Value: "http://www.w3.org/2002/07/owl#Restriction"},
+	NamedIndividual:           rdf.IRI{Value: "http://www.w3.org/2002/07/owl#NamedIndividual"},
+	ObjectProperty:            rdf.IRI{Value: "http://www.w3.org/2002/07/owl#ObjectProperty"},
+	DatatypeProperty:          rdf.IRI{Value: "http://www.w3.org/2002/07/owl#DatatypeProperty"},
+	AnnotationProperty:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#AnnotationProperty"},
+	OntologyProperty:          rdf.IRI{Value: "http://www.w3.org/2002/07/owl#OntologyProperty"},
+	FunctionalProperty:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#FunctionalProperty"},
+	InverseFunctionalProperty: rdf.IRI{Value: "http://www.w3.org/2002/07/owl#InverseFunctionalProperty"},
+	TransitiveProperty:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#TransitiveProperty"},
+	SymmetricProperty:         rdf.IRI{Value: "http://www.w3.org/2002/07/owl#SymmetricProperty"},
+	AsymmetricProperty:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#AsymmetricProperty"},
+	ReflexiveProperty:         rdf.IRI{Value: "http://www.w3.org/2002/07/owl#ReflexiveProperty"},
+	IrreflexiveProperty:       rdf.IRI{Value: "http://www.w3.org/2002/07/owl#IrreflexiveProperty"},
+	AllDisjointClasses:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#AllDisjointClasses"},
+	AllDisjointProperties:     rdf.IRI{Value: "http://www.w3.org/2002/07/owl#AllDisjointProperties"},
+	AllDifferent:              rdf.IRI{Value: "http://www.w3.org/2002/07/owl#AllDifferent"},
+	Axiom:                     rdf.IRI{Value: "http://www.w3.org/2002/07/owl#Axiom"},
+	DeprecatedClass:           rdf.IRI{Value: "http://www.w3.org/2002/07/owl#DeprecatedClass"},
+	DeprecatedProperty:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#DeprecatedProperty"},
+	NegativePropertyAssertion: rdf.IRI{Value: "http://www.w3.org/2002/07/owl#NegativePropertyAssertion"},
+	SameAs:                    rdf.IRI{Value: "http://www.w3.org/2002/07/owl#sameAs"},
+	DifferentFrom:             rdf.IRI{Value: "http://www.w3.org/2002/07/owl#differentFrom"},
+	EquivalentClass:           rdf.IRI{Value: "http://www.w3.org/2002/07/owl#equivalentClass"},
+	EquivalentProperty:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#equivalentProperty"},
+	InverseOf:                 rdf.IRI{Value: "http://www.w3.org/2002/07/owl#inverseOf"},
+	DisjointWith:              rdf.IRI{Value: "http://www.w3.org/2002/07/owl#disjointWith"},
+	PropertyDisjointWith:      rdf.IRI{Value: "http://www.w3.org/2002/07/owl#propertyDisjointWith"},
+	DisjointUnionOf:           rdf.IRI{Value: "http://www.w3.org/2002/07/owl#disjointUnionOf"},
+	OnProperty:                rdf.IRI{Value: "http://www.w3.org/2002/07/owl#onProperty"},
+	OnClass:                   rdf.IRI{Value: "http://www.w3.org/2002/07/owl#onClass"},
+	OnDataRange:               rdf.IRI{Value: "http://www.w3.org/2002/07/owl#onDataRange"},
+	OnDatatype:                rdf.IRI{Value: "http://www.w3.org/2002/07/owl#onDatatype"},
+	SomeValuesFrom:            rdf.IRI{Value: "http://www.w3.org/2002/07/owl#someValuesFrom"},
+	AllValuesFrom:             rdf.IRI{Value: "http://www.w3.org/2002/07/owl#allValuesFrom"},
+	HasValue:                  rdf.IRI{Value: "http://www.w3.org/2002/07/owl#hasValue"},
+	HasSelf:                   rdf.IRI{Value: "http://www.w3.org/2002/07/owl#hasSelf"},
+	Cardinality:               rdf.IRI{Value: "http://www.w3.org/2002/07/owl#cardinality"},
+	MinCardinality:            rdf.IRI{Value: "http://www.w3.org/2002/07/owl#minCardinality"},
+	MaxCardinality:            rdf.IRI{Value: "http://www.w3.org/2002/07/owl#maxCardinality"},
+	QualifiedCardinality:      rdf.IRI{Value: "http://www.w3.org/2002/07/owl#qualifiedCardinality"},
+	MinQualifiedCardinality:   rdf.IRI{Value: "http://www.w3.org/2002/07/owl#minQualifiedCardinality"},
+	MaxQualifiedCardinality:   rdf.IRI{Value: "http://www.w3.org/2002/07/owl#maxQualifiedCardinality"},
+	UnionOf:                   rdf.IRI{Value: "http://www.w3.org/2002/07/owl#unionOf"},
+	IntersectionOf:            rdf.IRI{Value: "http://www.w3.org/2002/07/owl#intersectionOf"},
+	ComplementOf:              rdf.IRI{Value: "http://www.w3.org/2002/07/owl#complementOf"},
+	OneOf:                     rdf.IRI{Value: "http://www.w3.org/2002/07/owl#oneOf"},
+	Members:                   rdf.IRI{Value: "http://www.w3.org/2002/07/owl#members"},
+	DistinctMembers:           rdf.IRI{Value: "http://www.w3.org/2002/07/owl#distinctMembers"},
+	PropertyChainAxiom:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#propertyChainAxiom"},
+	HasKey:                    rdf.IRI{Value: "http://www.w3.org/2002/07/owl#hasKey"},
+	WithRestrictions:          rdf.IRI{Value: "http://www.w3.org/2002/07/owl#withRestrictions"},
+	Imports:                   rdf.IRI{Value: "http://www.w3.org/2002/07/owl#imports"},
+	VersionIRI:                rdf.IRI{Value: "http://www.w3.org/2002/07/owl#versionIRI"},
+	VersionInfo:               rdf.IRI{Value: "http://www.w3.org/2002/07/owl#versionInfo"},
+	PriorVersion:              rdf.IRI{Value: "http://www.w3.org/2002/07/owl#priorVersion"},
+	BackwardCompatibleWith:    rdf.IRI{Value: "http://www.w3.org/2002/07/owl#backwardCompatibleWith"},
+	IncompatibleWith:          rdf.IRI{Value: "http://www.w3.org/2002/07/owl#incompatibleWith"},
+	Deprecated:                rdf.IRI{Value: "http://www.w3.org/2002/07/owl#deprecated"},
+	AnnotatedSource:           rdf.IRI{Value: "http://www.w3.org/2002/07/owl#annotatedSource"},
+	AnnotatedProperty:         rdf.IRI{Value: "http://www.w3.org/2002/07/owl#annotatedProperty"},
+	AnnotatedTarget:           rdf.IRI{Value: "http://www.w3.org/2002/07/owl#annotatedTarget"},
+	SourceIndividual:          rdf.IRI{Value: "http://www.w3.org/2002/07/owl#sourceIndividual"},
+	AssertionProperty:         rdf.IRI{Value: "http://www.w3.org/2002/07/owl#assertionProperty"},
+	TargetIndividual:          rdf.IRI{Value: "http://www.w3.org/2002/07/owl#targetIndividual"},
+	TargetValue:               rdf.IRI{Value: "http://www.w3.org/2002/07/owl#targetValue"},
+	TopObjectProperty:         rdf.IRI{Value: "http://www.w3.org/2002/07/owl#topObjectProperty"},
+	BottomObjectProperty:      rdf.IRI{Value: "http://www.w3.org/2002/07/owl#bottomObjectProperty"},
+	TopDataProperty:           rdf.IRI{Value: "http://www.w3.org/2002/07/owl#topDataProperty"},
+	BottomDataProperty:        rdf.IRI{Value: "http://www.w3.org/2002/07/owl#bottomDataProperty"},
+	Rational:                  rdf.IRI{Value: "http://www.w3.org/2002/07/owl#rational"},
+	Real:                      rdf.IRI{Value: "http://www.w3.org/2002/07/owl#real"},
+}
+
+// XSD holds the terms of the XML Schema datatypes, namespace http://www.w3.org/2001/XMLSchema#.
+var XSD = struct {
+	// Namespace is the xsd: namespace.
+	Namespace Namespace
+
+	String             rdf.IRI // xsd:string
+	Boolean            rdf.IRI // xsd:boolean
+	Decimal            rdf.IRI // xsd:decimal
+	Integer            rdf.IRI // xsd:integer
+	Double             rdf.IRI // xsd:double
+	Float              rdf.IRI // xsd:float
+	Date               rdf.IRI // xsd:date
+	Time               rdf.IRI // xsd:time
+	DateTime           rdf.IRI // xsd:dateTime
+	DateTimeStamp      rdf.IRI // xsd:dateTimeStamp
+	Duration           rdf.IRI // xsd:duration
+	DayTimeDuration    rdf.IRI // xsd:dayTimeDuration
+	YearMonthDuration  rdf.IRI // xsd:yearMonthDuration
+	GYear              rdf.IRI // xsd:gYear
+	GYearMonth         rdf.IRI // xsd:gYearMonth
+	GMonth             rdf.IRI // xsd:gMonth
+	GMonthDay          rdf.IRI // xsd:gMonthDay
+	GDay               rdf.IRI // xsd:gDay
+	HexBinary          rdf.IRI // xsd:hexBinary
+	Base64Binary       rdf.IRI // xsd:base64Binary
+	AnyURI             rdf.IRI // xsd:anyURI
+	NormalizedString   rdf.IRI // xsd:normalizedString
+	Token              rdf.IRI // xsd:token
+	Language           rdf.IRI // xsd:language
+	Name               rdf.IRI // xsd:Name
+	NCName             rdf.IRI // xsd:NCName
+	NMTOKEN            rdf.IRI // xsd:NMTOKEN
+	Long               rdf.IRI // xsd:long
+	Int                rdf.IRI // xsd:int
+	Short              rdf.IRI // xsd:short
+	Byte               rdf.IRI // xsd:byte
+	NonNegativeInteger rdf.IRI // xsd:nonNegativeInteger
+	PositiveInteger    rdf.IRI // xsd:positiveInteger
+	NonPositiveInteger rdf.IRI // xsd:nonPositiveInteger
+	NegativeInteger    rdf.IRI // xsd:negativeInteger
+	UnsignedLong       rdf.IRI // xsd:unsignedLong
+	UnsignedInt        rdf.IRI // xsd:unsignedInt
+	UnsignedShort      rdf.IRI // xsd:unsignedShort
+	UnsignedByte       rdf.IRI // xsd:unsignedByte
+}{
+	Namespace:          "http://www.w3.org/2001/XMLSchema#",
+	String:             rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#string"},
+	Boolean:            rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#boolean"},
+	Decimal:            rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#decimal"},
+	Integer:            rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#integer"},
+	Double:             rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#double"},
+	Float:              rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#float"},
+	Date:               rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#date"},
+	Time:               rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#time"},
+	DateTime:           rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#dateTime"},
+	DateTimeStamp:      rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#dateTimeStamp"},
+	Duration:           rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#duration"},
+	DayTimeDuration:    rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#dayTimeDuration"},
+	YearMonthDuration:  rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#yearMonthDuration"},
+	GYear:              rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#gYear"},
+	GYearMonth:         rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#gYearMonth"},
+	GMonth:             rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#gMonth"},
+	GMonthDay:          rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#gMonthDay"},
+	GDay:               rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#gDay"},
+	HexBinary:          rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#hexBinary"},
+	Base64Binary:       rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#base64Binary"},
+	AnyURI:             rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#anyURI"},
+	NormalizedString:   rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#normalizedString"},
+	Token:              rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#token"},
+	Language:           rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#language"},
+	Name:               rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#Name"},
+	NCName:             rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#NCName"},
+	NMTOKEN:            rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#NMTOKEN"},
+	Long:               rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#long"},
+	Int:                rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#int"},
+	Short:              rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#short"},
+	Byte:               rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#byte"},
+	NonNegativeInteger: rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#nonNegativeInteger"},
+	PositiveInteger:    rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#positiveInteger"},
+	NonPositiveInteger: rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#nonPositiveInteger"},
+	NegativeInteger:    rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#negativeInteger"},
+	UnsignedLong:       rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#unsignedLong"},
+	UnsignedInt:        rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#unsignedInt"},
+	UnsignedShort:      rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#unsignedShort"},
+	UnsignedByte:       rdf.IRI{Value: "http://www.w3.org/2001/XMLSchema#unsignedByte"},
+}
+
+// SKOS holds the terms of the Simple Knowledge Organization System, namespace http://www.w3.org/2004/02/skos/core#.
+var SKOS = struct {
+	// Namespace is the skos: namespace.
+	Namespace Namespace
+
+	Concept            rdf.IRI // skos:Concept
+	ConceptScheme      rdf.IRI // skos:ConceptScheme
+	Collection         rdf.IRI // skos:Collection
+	OrderedCollection  rdf.IRI // skos:OrderedCollection
+	PrefLabel          rdf.IRI // skos:prefLabel
+	AltLabel           rdf.IRI // skos:altLabel
+	HiddenLabel        rdf.IRI // skos:hiddenLabel
+	Notation           rdf.IRI // skos:notation
+	Note               rdf.IRI // skos:note
+	ChangeNote         rdf.IRI // skos:changeNote
+	Definition         rdf.IRI // skos:definition
+	EditorialNote      rdf.IRI // skos:editorialNote
+	Example            rdf.IRI // skos:example
+	HistoryNote        rdf.IRI // skos:historyNote
+	ScopeNote          rdf.IRI // skos:scopeNote
+	SemanticRelation   rdf.IRI // skos:semanticRelation
+	Broader            rdf.IRI // skos:broader
+	Narrower           rdf.IRI // skos:narrower
+	Related            rdf.IRI // skos:related
+	BroaderTransitive  rdf.IRI // skos:broaderTransitive
+	NarrowerTransitive rdf.IRI // skos:narrowerTransitive
+	InScheme           rdf.IRI // skos:inScheme
+	HasTopConcept      rdf.IRI // skos:hasTopConcept
+	TopConceptOf       rdf.IRI // skos:topConceptOf
+	Member             rdf.IRI // skos:member
+	MemberList         rdf.IRI // skos:memberList
+	MappingRelation    rdf.IRI // skos:mappingRelation
+	ExactMatch         rdf.IRI // skos:exactMatch
+	CloseMatch         rdf.IRI // skos:closeMatch
+	BroadMatch         rdf.IRI // skos:broadMatch
+	NarrowMatch        rdf.IRI // skos:narrowMatch
+	RelatedMatch       rdf.IRI // skos:relatedMatch
+}{
+	Namespace:          "http://www.w3.org/2004/02/skos/core#",
+	Concept:            rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#Concept"},
+	ConceptScheme:      rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#ConceptScheme"},
+	Collection:         rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#Collection"},
+	OrderedCollection:  rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#OrderedCollection"},
+	PrefLabel:          rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#prefLabel"},
+	AltLabel:           rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#altLabel"},
+	HiddenLabel:        rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#hiddenLabel"},
+	Notation:           rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#notation"},
+	Note:               rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#note"},
+	ChangeNote:         rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#changeNote"},
+	Definition:         rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#definition"},
+	EditorialNote:      rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#editorialNote"},
+	Example:            rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#example"},
+	HistoryNote:        rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#historyNote"},
+	ScopeNote:          rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#scopeNote"},
+	SemanticRelation:   rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#semanticRelation"},
+	Broader:            rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#broader"},
+	Narrower:           rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#narrower"},
+	Related:            rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#related"},
+	BroaderTransitive:  rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#broaderTransitive"},
+	NarrowerTransitive: rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#narrowerTransitive"},
+	InScheme:           rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#inScheme"},
+	HasTopConcept:      rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#hasTopConcept"},
+	TopConceptOf:       rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#topConceptOf"},
+	Member:             rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#member"},
+	MemberList:         rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#memberList"},
+	MappingRelation:    rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#mappingRelation"},
+	ExactMatch:         rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#exactMatch"},
+	CloseMatch:         rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#closeMatch"},
+	BroadMatch:         rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#broadMatch"},
+	NarrowMatch:        rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#narrowMatch"},
+	RelatedMatch:       rdf.IRI{Value: "http://www.w3.org/2004/02/skos/core#relatedMatch"},
+}
+
+// DCTERMS holds the terms of the DCMI Metadata Terms, namespace http://purl.org/dc/terms/.
+var DCTERMS = struct {
+	// Namespace is the dcterms: namespace.
+	Namespace Namespace
+
+	Agent                        rdf.IRI // dcterms:Agent
+	AgentClass                   rdf.IRI // dcterms:AgentClass
+	BibliographicResource        rdf.IRI // dcterms:BibliographicResource
+	FileFormat                   rdf.IRI // dcterms:FileFormat
+	Frequency                    rdf.IRI // dcterms:Frequency
+	Jurisdiction                 rdf.IRI // dcterms:Jurisdiction
+	LicenseDocument              rdf.IRI // dcterms:LicenseDocument
+	LinguisticSystem             rdf.IRI // dcterms:LinguisticSystem
+	Location                     rdf.IRI // dcterms:Location
+	LocationPeriodOrJurisdiction rdf.IRI // dcterms:LocationPeriodOrJurisdiction
+	MediaType                    rdf.IRI // dcterms:MediaType
+	MediaTypeOrExtent            rdf.IRI // dcterms:MediaTypeOrExtent
+	PeriodOfTime                 rdf.IRI // dcterms:PeriodOfTime
+	PhysicalResource             rdf.IRI // dcterms:PhysicalResource
+	Policy                       rdf.IRI // dcterms:Policy
+	ProvenanceStatement          rdf.IRI // dcterms:ProvenanceStatement
+	RightsStatement              rdf.IRI // dcterms:RightsStatement
+	SizeOrDuration               rdf.IRI // dcterms:SizeOrDuration
+	Standard                     rdf.IRI // dcterms:Standard
+	Title                        rdf.IRI // dcterms:title
+	Alternative                  rdf.IRI // dcterms:alternative
+	Creator                      rdf.IRI // dcterms:creator
+	Contributor                  rdf.IRI // dcterms:contributor
+	Publisher                    rdf.IRI // dcterms:publisher
+	Subject                      rdf.IRI // dcterms:subject
+	Description                  rdf.IRI // dcterms:description
+	Abstract                     rdf.IRI // dcterms:abstract
+	TableOfContents              rdf.IRI // dcterms:tableOfContents
+	Date                         rdf.IRI // dcterms:date
+	Created                      rdf.IRI // dcterms:created
+	Modified                     rdf.IRI // dcterms:modified
+	Issued                       rdf.IRI // dcterms:issued
+	Available                    rdf.IRI // dcterms:available
+	Valid                        rdf.IRI // dcterms:valid
+	Identifier                   rdf.IRI // dcterms:identifier
+	Language                     rdf.IRI // dcterms:language
+	License                      rdf.IRI // dcterms:license
+	Rights                       rdf.IRI // dcterms:rights
+	RightsHolder                 rdf.IRI // dcterms:rightsHolder
+	AccessRights                 rdf.IRI // dcterms:accessRights
+	Source                       rdf.IRI // dcterms:source
+	Type                         rdf.IRI // dcterms:type
+	Format                       rdf.IRI // dcterms:format
+	Extent                       rdf.IRI // dcterms:extent
+	Medium                       rdf.IRI // dcterms:medium
+	Relation                     rdf.IRI // dcterms:relation
+	IsPartOf                     rdf.IRI // dcterms:isPartOf
+	HasPart                      rdf.IRI // dcterms:hasPart
+	IsVersionOf                  rdf.IRI // dcterms:isVersionOf
+	HasVersion                   rdf.IRI // dcterms:hasVersion
+	References                   rdf.IRI // dcterms:references
+	IsReferencedBy               rdf.IRI // dcterms:isReferencedBy
+	Replaces                     rdf.IRI // dcterms:replaces
+	IsReplacedBy                 rdf.IRI // dcterms:isReplacedBy
+	Requires                     rdf.IRI // dcterms:requires
+	IsRequiredBy                 rdf.IRI // dcterms:isRequiredBy
+	ConformsTo                   rdf.IRI // dcterms:conformsTo
+	Coverage                     rdf.IRI // dcterms:coverage
+	Spatial                      rdf.IRI // dcterms:spatial
+	Temporal                     rdf.IRI // dcterms:temporal
+	Audience                     rdf.IRI // dcterms:audience
+	Mediator                     rdf.IRI // dcterms:mediator
+	EducationLevel               rdf.IRI // dcterms:educationLevel
+	Provenance                   rdf.IRI // dcterms:provenance
+	BibliographicCitation        rdf.IRI // dcterms:bibliographicCitation
+	AccrualPeriodicity           rdf.IRI // dcterms:accrualPeriodicity
+}{
+	Namespace:                    "http://purl.org/dc/terms/",
+	Agent:                        rdf.IRI{Value: "http://purl.org/dc/terms/Agent"},
+	AgentClass:                   rdf.IRI{Value: "http://purl.org/dc/terms/AgentClass"},
+	BibliographicResource:        rdf.IRI{Value: "http://purl.org/dc/terms/BibliographicResource"},
+	FileFormat:                   rdf.IRI{Value: "http://purl.org/dc/terms/FileFormat"},
+	Frequency:                    rdf.IRI{Value: "http://purl.org/dc/terms/Frequency"},
+	Jurisdiction:                 rdf.IRI{Value: "http://purl.org/dc/terms/Jurisdiction"},
+	LicenseDocument:              rdf.IRI{Value: "http://purl.org/dc/terms/LicenseDocument"},
+	LinguisticSystem:             rdf.IRI{Value: "http://purl.org/dc/terms/LinguisticSystem"},
+	Location:                     rdf.IRI{Value: "http://purl.org/dc/terms/Location"},
+	LocationPeriodOrJurisdiction: rdf.IRI{Value: "http://purl.org/dc/terms/LocationPeriodOrJurisdiction"},
+	MediaType:                    rdf.IRI{Value: "http://purl.org/dc/terms/MediaType"},
+	MediaTypeOrExtent:            rdf.IRI{Value: "http://purl.org/dc/terms/MediaTypeOrExtent"},
+	PeriodOfTime:                 rdf.IRI{Value: "http://purl.org/dc/terms/PeriodOfTime"},
+	PhysicalResource:             rdf.IRI{Value: "http://purl.org/dc/terms/PhysicalResource"},
+	Policy:                       rdf.IRI{Value: "http://purl.org/dc/terms/Policy"},
+	ProvenanceStatement:          rdf.IRI{Value: "http://purl.org/dc/terms/ProvenanceStatement"},
+	RightsStatement:              rdf.IRI{Value: "http://purl.org/dc/terms/RightsStatement"},
+	SizeOrDuration:               rdf.IRI{Value: "http://purl.org/dc/terms/SizeOrDuration"},
+	Standard:                     rdf.IRI{Value: "http://purl.org/dc/terms/Standard"},
+	Title:                        rdf.IRI{Value: "http://purl.org/dc/terms/title"},
+	Alternative:                  rdf.IRI{Value: "http://purl.org/dc/terms/alternative"},
+	Creator:                      rdf.IRI{Value: "http://purl.org/dc/terms/creator"},
+	Contributor:                  rdf.IRI{Value: "http://purl.org/dc/terms/contributor"},
+	Publisher:                    rdf.IRI{Value: "http://purl.org/dc/terms/publisher"},
+	Subject:                      rdf.IRI{Value: "http://purl.org/dc/terms/subject"},
+	Description:                  rdf.IRI{Value: "http://purl.org/dc/terms/description"},
+	Abstract:                     rdf.IRI{Value: "http://purl.org/dc/terms/abstract"},
+	TableOfContents:              rdf.IRI{Value: "http://purl.org/dc/terms/tableOfContents"},
+	Date:                         rdf.IRI{Value: "http://purl.org/dc/terms/date"},
+	Created:                      rdf.IRI{Value: "http://purl.org/dc/terms/created"},
+	Modified:                     rdf.IRI{Value: "http://purl.org/dc/terms/modified"},
+	Issued:                       rdf.IRI{Value: "http://purl.org/dc/terms/issued"},
+	Available:                    rdf.IRI{Value: "http://purl.org/dc/terms/available"},
+	Valid:                        rdf.IRI{Value: "http://purl.org/dc/terms/valid"},
+	Identifier:                   rdf.IRI{Value: "http://purl.org/dc/terms/identifier"},
+	Language:                     rdf.IRI{Value: "http://purl.org/dc/terms/language"},
+	License:                      rdf.IRI{Value: "http://purl.org/dc/terms/license"},
+	Rights:                       rdf.IRI{Value: "http://purl.org/dc/terms/rights"},
+	RightsHolder:                 rdf.IRI{Value: "http://purl.org/dc/terms/rightsHolder"},
+	AccessRights:                 rdf.IRI{Value: "http://purl.org/dc/terms/accessRights"},
+	Source:                       rdf.IRI{Value: "http://purl.org/dc/terms/source"},
+	Type:                         rdf.IRI{Value: "http://purl.org/dc/terms/type"},
+	Format:                       rdf.IRI{Value: "http://purl.org/dc/terms/format"},
+	Extent:                       rdf.IRI{Value: "http://purl.org/dc/terms/extent"},
+	Medium:                       rdf.IRI{Value: "http://purl.org/dc/terms/medium"},
+	Relation:                     rdf.IRI{Value: "http://purl.org/dc/terms/relation"},
+	IsPartOf:                     rdf.IRI{Value: "http://purl.org/dc/terms/isPartOf"},
+	HasPart:                      rdf.IRI{Value: "http://purl.org/dc/terms/hasPart"},
+	IsVersionOf:                  rdf.IRI{Value: "http://purl.org/dc/terms/isVersionOf"},
+	HasVersion:                   rdf.IRI{Value: "http://purl.org/dc/terms/hasVersion"},
+	References:                   rdf.IRI{Value: "http://purl.org/dc/terms/references"},
+	IsReferencedBy:               rdf.IRI{Value: "http://purl.org/dc/terms/isReferencedBy"},
+	Replaces:                     rdf.IRI{Value: "http://purl.org/dc/terms/replaces"},
+	IsReplacedBy:                 rdf.IRI{Value: "http://purl.org/dc/terms/isReplacedBy"},
+	Requires:                     rdf.IRI{Value: "http://purl.org/dc/terms/requires"},
+	IsRequiredBy:                 rdf.IRI{Value: "http://purl.org/dc/terms/isRequiredBy"},
+	ConformsTo:                   rdf.IRI{Value: "http://purl.org/dc/terms/conformsTo"},
+	Coverage:                     rdf.IRI{Value: "http://purl.org/dc/terms/coverage"},
+	Spatial:                      rdf.IRI{Value: "http://purl.org/dc/terms/spatial"},
+	Temporal:                     rdf.IRI{Value: "http://purl.org/dc/terms/temporal"},
+	Audience:                     rdf.IRI{Value: "http://purl.org/dc/terms/audience"},
+	Mediator:                     rdf.IRI{Value: "http://purl.org/dc/terms/mediator"},
+	EducationLevel:               rdf.IRI{Value: "http://purl.org/dc/terms/educationLevel"},
+	Provenance:                   rdf.IRI{Value: "http://purl.org/dc/terms/provenance"},
+	BibliographicCitation:        rdf.IRI{Value: "http://purl.org/dc/terms/bibliographicCitation"},
+	AccrualPeriodicity:           rdf.IRI{Value: "http://purl.org/dc/terms/accrualPeriodicity"},
+}
+
+// FOAF holds the terms of the Friend of a Friend vocabulary, namespace http://xmlns.com/foaf/0.1/.
+var FOAF = struct {
+	// Namespace is the foaf: namespace.
+	Namespace Namespace
+
+	Agent                  rdf.IRI // foaf:Agent
+	Person                 rdf.IRI // foaf:Person
+	Organization           rdf.IRI // foaf:Organization
+	Group                  rdf.IRI // foaf:Group
+	Document               rdf.IRI // foaf:Document
+	Image                  rdf.IRI // foaf:Image
+	OnlineAccount          rdf.IRI // foaf:OnlineAccount
+	Project                rdf.IRI // foaf:Project
+	Name                   rdf.IRI // foaf:name
+	GivenName              rdf.IRI // foaf:givenName
+	FamilyName             rdf.IRI // foaf:familyName
+	Nick                   rdf.IRI // foaf:nick
+	Title                  rdf.IRI // foaf:title
+	Mbox                   rdf.IRI // foaf:mbox
+	MboxSha1sum            rdf.IRI // foaf:mbox_sha1sum
+	Homepage               rdf.IRI // foaf:homepage
+	Page                   rdf.IRI // foaf:page
+	Weblog                 rdf.IRI // foaf:weblog
+	Openid                 rdf.IRI // foaf:openid
+	Phone                  rdf.IRI // foaf:phone
+	Img                    rdf.IRI // foaf:img
+	Depiction              rdf.IRI // foaf:depiction
+	Depicts                rdf.IRI // foaf:depicts
+	Logo                   rdf.IRI // foaf:logo
+	Knows                  rdf.IRI // foaf:knows
+	Member                 rdf.IRI // foaf:member
+	Made                   rdf.IRI // foaf:made
+	Maker                  rdf.IRI // foaf:maker
+	Account                rdf.IRI // foaf:account
+	AccountName            rdf.IRI // foaf:accountName
+	AccountServiceHomepage rdf.IRI // foaf:accountServiceHomepage
+	BasedNear              rdf.IRI // foaf:based_near
+	Topic                  rdf.IRI // foaf:topic
+	PrimaryTopic           rdf.IRI // foaf:primaryTopic
+	IsPrimaryTopicOf       rdf.IRI // foaf:isPrimaryTopicOf
+	Interest               rdf.IRI // foaf:interest
+	Age                    rdf.IRI // foaf:age
+	Birthday               rdf.IRI // foaf:birthday
+	Gender                 rdf.IRI // foaf:gender
+	WorkplaceHomepage      rdf.IRI // foaf:workplaceHomepage
+	SchoolHomepage         rdf.IRI // foaf:schoolHomepage
+}{
+	Namespace:              "http://xmlns.com/foaf/0.1/",
+	Agent:                  rdf.IRI{Value: "http://xmlns.com/foaf/0.1/Agent"},
+	Person:                 rdf.IRI{Value: "http://xmlns.com/foaf/0.1/Person"},
+	Organization:           rdf.IRI{Value: "http://xmlns.com/foaf/0.1/Organization"},
+	Group:                  rdf.IRI{Value: "http://xmlns.com/foaf/0.1/Group"},
+	Document:               rdf.IRI{Value: "http://xmlns.com/foaf/0.1/Document"},
+	Image:                  rdf.IRI{Value: "http://xmlns.com/foaf/0.1/Image"},
+	OnlineAccount:          rdf.IRI{Value: "http://xmlns.com/foaf/0.1/OnlineAccount"},
+	Project:                rdf.IRI{Value: "http://xmlns.com/foaf/0.1/Project"},
+	Name:                   rdf.IRI{Value: "http://xmlns.com/foaf/0.1/name"},
+	GivenName:              rdf.IRI{Value: "http://xmlns.com/foaf/0.1/givenName"},
+	FamilyName:             rdf.IRI{Value: "http://xmlns.com/foaf/0.1/familyName"},
+	Nick:                   rdf.IRI{Value: "http://xmlns.com/foaf/0.1/nick"},
+	Title:                  rdf.IRI{Value: "http://xmlns.com/foaf/0.1/title"},
+	Mbox:                   rdf.IRI{Value: "http://xmlns.com/foaf/0.1/mbox"},
+	MboxSha1sum:            rdf.IRI{Value: "http://xmlns.com/foaf/0.1/mbox_sha1sum"},
+	Homepage:               rdf.IRI{Value: "http://xmlns.com/foaf/0.1/homepage"},
+	Page:                   rdf.IRI{Value: "http://xmlns.com/foaf/0.1/page"},
+	Weblog:                 rdf.IRI{Value: "http://xmlns.com/foaf/0.1/weblog"},
+	Openid:                 rdf.IRI{Value: "http://xmlns.com/foaf/0.1/openid"},
+	Phone:                  rdf.IRI{Value: "http://xmlns.com/foaf/0.1/phone"},
+	Img:                    rdf.IRI{Value: "http://xmlns.com/foaf/0.1/img"},
+	Depiction:              rdf.IRI{Value: "http://xmlns.com/foaf/0.1/depiction"},
+	Depicts:                rdf.IRI{Value: "http://xmlns.com/foaf/0.1/depicts"},
+	Logo:                   rdf.IRI{Value: "http://xmlns.com/foaf/0.1/logo"},
+	Knows:                  rdf.IRI{Value: "http://xmlns.com/foaf/0.1/knows"},
+	Member:                 rdf.IRI{Value: "http://xmlns.com/foaf/0.1/member"},
+	Made:                   rdf.IRI{Value: "http://xmlns.com/foaf/0.1/made"},
+	Maker:                  rdf.IRI{Value: "http://xmlns.com/foaf/0.1/maker"},
+	Account:                rdf.IRI{Value: "http://xmlns.com/foaf/0.1/account"},
+	AccountName:            rdf.IRI{Value: "http://xmlns.com/foaf/0.1/accountName"},
+	AccountServiceHomepage: rdf.IRI{Value: "http://xmlns.com/foaf/0.1/accountServiceHomepage"},
+	BasedNear:              rdf.IRI{Value: "http://xmlns.com/foaf/0.1/based_near"},
+	Topic:                  rdf.IRI{Value: "http://xmlns.com/foaf/0.1/topic"},
+	PrimaryTopic:           rdf.IRI{Value: "http://xmlns.com/foaf/0.1/primaryTopic"},
+	IsPrimaryTopicOf:       rdf.IRI{Value: "http://xmlns.com/foaf/0.1/isPrimaryTopicOf"},
+	Interest:               rdf.IRI{Value: "http://xmlns.com/foaf/0.1/interest"},
+	Age:                    rdf.IRI{Value: "http://xmlns.com/foaf/0.1/age"},
+	Birthday:               rdf.IRI{Value: "http://xmlns.com/foaf/0.1/birthday"},
+	Gender:                 rdf.IRI{Value: "http://xmlns.com/foaf/0.1/gender"},
+	WorkplaceHomepage:      rdf.IRI{Value: "http://xmlns.com/foaf/0.1/workplaceHomepage"},
+	SchoolHomepage:         rdf.IRI{Value: "http://xmlns.com/foaf/0.1/schoolHomepage"},
+}
+
+// GeoSPARQL holds the terms of the GeoSPARQL 1.1 ontology, namespace http://www.opengis.net/ont/geosparql#.
+var GeoSPARQL = struct {
+	// Namespace is the geo: namespace.
+	Namespace Namespace
+
+	SpatialObject              rdf.IRI // geo:SpatialObject
+	Feature                    rdf.IRI // geo:Feature
+	Geometry                   rdf.IRI // geo:Geometry
+	SpatialObjectCollection    rdf.IRI // geo:SpatialObjectCollection
+	FeatureCollection          rdf.IRI // geo:FeatureCollection
+	GeometryCollection         rdf.IRI // geo:GeometryCollection
+	HasGeometry                rdf.IRI // geo:hasGeometry
+	HasDefaultGeometry         rdf.IRI // geo:hasDefaultGeometry
+	HasCentroid                rdf.IRI // geo:hasCentroid
+	HasBoundingBox             rdf.IRI // geo:hasBoundingBox
+	HasSerialization           rdf.IRI // geo:hasSerialization
+	AsWKT                      rdf.IRI // geo:asWKT
+	AsGML                      rdf.IRI // geo:asGML
+	AsGeoJSON                  rdf.IRI // geo:asGeoJSON
+	AsKML                      rdf.IRI // geo:asKML
+	AsDGGS                     rdf.IRI // geo:asDGGS
+	WktLiteral                 rdf.IRI // geo:wktLiteral
+	GmlLiteral                 rdf.IRI // geo:gmlLiteral
+	GeoJSONLiteral             rdf.IRI // geo:geoJSONLiteral
+	KmlLiteral                 rdf.IRI // geo:kmlLiteral
+	DggsLiteral                rdf.IRI // geo:dggsLiteral
+	Dimension                  rdf.IRI // geo:dimension
+	CoordinateDimension        rdf.IRI // geo:coordinateDimension
+	SpatialDimension           rdf.IRI // geo:spatialDimension
+	IsEmpty                    rdf.IRI // geo:isEmpty
+	IsSimple                   rdf.IRI // geo:isSimple
+	HasSize                    rdf.IRI // geo:hasSize
+	HasLength                  rdf.IRI // geo:hasLength
+	HasMetricLength            rdf.IRI // geo:hasMetricLength
+	HasPerimeterLength         rdf.IRI // geo:hasPerimeterLength
+	HasMetricPerimeterLength   rdf.IRI // geo:hasMetricPerimeterLength
+	HasArea                    rdf.IRI // geo:hasArea
+	HasMetricArea              rdf.IRI // geo:hasMetricArea
+	HasVolume                  rdf.IRI // geo:hasVolume
+	HasMetricVolume            rdf.IRI // geo:hasMetricVolume
+	HasSpatialResolution       rdf.IRI // geo:hasSpatialResolution
+	HasMetricSpatialResolution rdf.IRI // geo:hasMetricSpatialResolution
+	HasSpatialAccuracy         rdf.IRI // geo:hasSpatialAccuracy
+	HasMetricSpatialAccuracy   rdf.IRI // geo:hasMetricSpatialAccuracy
+	SfEquals                   rdf.IRI // geo:sfEquals
+	SfDisjoint                 rdf.IRI // geo:sfDisjoint
+	SfIntersects               rdf.IRI // geo:sfIntersects
+	SfTouches                  rdf.IRI // geo:sfTouches
+	SfCrosses                  rdf.IRI // geo:sfCrosses
+	SfWithin                   rdf.IRI // geo:sfWithin
+	SfContains                 rdf.IRI // geo:sfContains
+	SfOverlaps                 rdf.IRI // geo:sfOverlaps
+	EhEquals                   rdf.IRI // geo:ehEquals
+	EhDisjoint                 rdf.IRI // geo:ehDisjoint
+	EhMeet                     rdf.IRI // geo:ehMeet
+	EhOverlap                  rdf.IRI // geo:ehOverlap
+	EhCovers                   rdf.IRI // geo:ehCovers
+	EhCoveredBy                rdf.IRI // geo:ehCoveredBy
+	EhInside                   rdf.IRI // geo:ehInside
+	EhContains                 rdf.IRI // geo:ehContains
+	Rcc8eq                     rdf.IRI // geo:rcc8eq
+	Rcc8dc                     rdf.IRI // geo:rcc8dc
+	Rcc8ec                     rdf.IRI // geo:rcc8ec
+	Rcc8po                     rdf.IRI // geo:rcc8po
+	Rcc8tppi                   rdf.IRI // geo:rcc8tppi
+	Rcc8tpp                    rdf.IRI // geo:rcc8tpp
+	Rcc8ntpp                   rdf.IRI // geo:rcc8ntpp
+	Rcc8ntppi                  rdf.IRI // geo:rcc8ntppi
+}{
+	Namespace:                  "http://www.opengis.net/ont/geosparql#",
+	SpatialObject:              rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#SpatialObject"},
+	Feature:                    rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#Feature"},
+	Geometry:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#Geometry"},
+	SpatialObjectCollection:    rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#SpatialObjectCollection"},
+	FeatureCollection:          rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#FeatureCollection"},
+	GeometryCollection:         rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#GeometryCollection"},
+	HasGeometry:                rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasGeometry"},
+	HasDefaultGeometry:         rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasDefaultGeometry"},
+	HasCentroid:                rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasCentroid"},
+	HasBoundingBox:             rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasBoundingBox"},
+	HasSerialization:           rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasSerialization"},
+	AsWKT:                      rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#asWKT"},
+	AsGML:                      rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#asGML"},
+	AsGeoJSON:                  rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#asGeoJSON"},
+	AsKML:                      rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#asKML"},
+	AsDGGS:                     rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#asDGGS"},
+	WktLiteral:                 rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#wktLiteral"},
+	GmlLiteral:                 rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#gmlLiteral"},
+	GeoJSONLiteral:             rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#geoJSONLiteral"},
+	KmlLiteral:                 rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#kmlLiteral"},
+	DggsLiteral:                rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#dggsLiteral"},
+	Dimension:                  rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#dimension"},
+	CoordinateDimension:        rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#coordinateDimension"},
+	SpatialDimension:           rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#spatialDimension"},
+	IsEmpty:                    rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#isEmpty"},
+	IsSimple:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#isSimple"},
+	HasSize:                    rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasSize"},
+	HasLength:                  rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasLength"},
+	HasMetricLength:            rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasMetricLength"},
+	HasPerimeterLength:         rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasPerimeterLength"},
+	HasMetricPerimeterLength:   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasMetricPerimeterLength"},
+	HasArea:                    rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasArea"},
+	HasMetricArea:              rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasMetricArea"},
+	HasVolume:                  rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasVolume"},
+	HasMetricVolume:            rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasMetricVolume"},
+	HasSpatialResolution:       rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasSpatialResolution"},
+	HasMetricSpatialResolution: rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasMetricSpatialResolution"},
+	HasSpatialAccuracy:         rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasSpatialAccuracy"},
+	HasMetricSpatialAccuracy:   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#hasMetricSpatialAccuracy"},
+	SfEquals:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#sfEquals"},
+	SfDisjoint:                 rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#sfDisjoint"},
+	SfIntersects:               rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#sfIntersects"},
+	SfTouches:                  rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#sfTouches"},
+	SfCrosses:                  rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#sfCrosses"},
+	SfWithin:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#sfWithin"},
+	SfContains:                 rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#sfContains"},
+	SfOverlaps:                 rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#sfOverlaps"},
+	EhEquals:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#ehEquals"},
+	EhDisjoint:                 rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#ehDisjoint"},
+	EhMeet:                     rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#ehMeet"},
+	EhOverlap:                  rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#ehOverlap"},
+	EhCovers:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#ehCovers"},
+	EhCoveredBy:                rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#ehCoveredBy"},
+	EhInside:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#ehInside"},
+	EhContains:                 rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#ehContains"},
+	Rcc8eq:                     rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#rcc8eq"},
+	Rcc8dc:                     rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#rcc8dc"},
+	Rcc8ec:                     rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#rcc8ec"},
+	Rcc8po:                     rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#rcc8po"},
+	Rcc8tppi:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#rcc8tppi"},
+	Rcc8tpp:                    rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#rcc8tpp"},
+	Rcc8ntpp:                   rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#rcc8ntpp"},
+	Rcc8ntppi:                  rdf.IRI{Value: "http://www.opengis.net/ont/geosparql#rcc8ntppi"},
+}
+
+// PROV holds the terms of the PROV ontology, namespace http://www.w3.org/ns/prov#.
+var PROV = struct {
+	// Namespace is the prov: namespace.
+	Namespace Namespace
+
+	Entity                 rdf.IRI // prov:Entity
+	Activity               rdf.IRI // prov:Activity
+	Agent                  rdf.IRI // prov:Agent
+	Person                 rdf.IRI // prov:Person
+	Organization           rdf.IRI // prov:Organization
+	SoftwareAgent          rdf.IRI // prov:SoftwareAgent
+	Plan                   rdf.IRI // prov:Plan
+	Bundle                 rdf.IRI // prov:Bundle
+	Collection             rdf.IRI // prov:Collection
+	EmptyCollection        rdf.IRI // prov:EmptyCollection
+	Location               rdf.IRI // prov:Location
+	Role                   rdf.IRI // prov:Role
+	Influence              rdf.IRI // prov:Influence
+	EntityInfluence        rdf.IRI // prov:EntityInfluence
+	ActivityInfluence      rdf.IRI // prov:ActivityInfluence
+	AgentInfluence         rdf.IRI // prov:AgentInfluence
+	Usage                  rdf.IRI // prov:Usage
+	Generation             rdf.IRI // prov:Generation
+	Invalidation           rdf.IRI // prov:Invalidation
+	Start                  rdf.IRI // prov:Start
+	End                    rdf.IRI // prov:End
+	Communication          rdf.IRI // prov:Communication
+	Association            rdf.IRI // prov:Association
+	Attribution            rdf.IRI // prov:Attribution
+	Delegation             rdf.IRI // prov:Delegation
+	Derivation             rdf.IRI // prov:Derivation
+	Revision               rdf.IRI // prov:Revision
+	Quotation              rdf.IRI // prov:Quotation
+	PrimarySource          rdf.IRI // prov:PrimarySource
+	WasGeneratedBy         rdf.IRI // prov:wasGeneratedBy
+	Used                   rdf.IRI // prov:used
+	WasInformedBy          rdf.IRI // prov:wasInformedBy
+	WasStartedBy           rdf.IRI // prov:wasStartedBy
+	WasEndedBy             rdf.IRI // prov:wasEndedBy
+	WasInvalidatedBy       rdf.IRI // prov:wasInvalidatedBy
+	WasDerivedFrom         rdf.IRI // prov:wasDerivedFrom
+	WasRevisionOf          rdf.IRI // prov:wasRevisionOf
+	WasQuotedFrom          rdf.IRI // prov:wasQuotedFrom
+	HadPrimarySource       rdf.IRI // prov:hadPrimarySource
+	WasAttributedTo        rdf.IRI // prov:wasAttributedTo
+	WasAssociatedWith      rdf.IRI // prov:wasAssociatedWith
+	ActedOnBehalfOf        rdf.IRI // prov:actedOnBehalfOf
+	WasInfluencedBy        rdf.IRI // prov:wasInfluencedBy
+	AlternateOf            rdf.IRI // prov:alternateOf
+	SpecializationOf       rdf.IRI // prov:specializationOf
+	HadMember              rdf.IRI // prov:hadMember
+	AtLocation             rdf.IRI // prov:atLocation
+	Value                  rdf.IRI // prov:value
+	GeneratedAtTime        rdf.IRI // prov:generatedAtTime
+	InvalidatedAtTime      rdf.IRI // prov:invalidatedAtTime
+	StartedAtTime          rdf.IRI // prov:startedAtTime
+	EndedAtTime            rdf.IRI // prov:endedAtTime
+	AtTime                 rdf.IRI // prov:atTime
+	Generated              rdf.IRI // prov:generated
+	Invalidated            rdf.IRI // prov:invalidated
+	Influenced             rdf.IRI // prov:influenced
+	QualifiedInfluence     rdf.IRI // prov:qualifiedInfluence
+	QualifiedGeneration    rdf.IRI // prov:qualifiedGeneration
+	QualifiedUsage         rdf.IRI // prov:qualifiedUsage
+	QualifiedInvalidation  rdf.IRI // prov:qualifiedInvalidation
+	QualifiedStart         rdf.IRI // prov:qualifiedStart
+	QualifiedEnd           rdf.IRI // prov:qualifiedEnd
+	QualifiedCommunication rdf.IRI // prov:qualifiedCommunication
+	QualifiedAssociation   rdf.IRI // prov:qualifiedAssociation
+	QualifiedAttribution   rdf.IRI // prov:qualifiedAttribution
+	QualifiedDelegation    rdf.IRI // prov:qualifiedDelegation
+	QualifiedDerivation    rdf.IRI // prov:qualifiedDerivation
+	QualifiedRevision      rdf.IRI // prov:qualifiedRevision
+	QualifiedQuotation     rdf.IRI // prov:qualifiedQuotation
+	QualifiedPrimarySource rdf.IRI // prov:qualifiedPrimarySource
+	EntityProperty         rdf.IRI // prov:entity
+	ActivityProperty       rdf.IRI // prov:activity
+	AgentProperty          rdf.IRI // prov:agent
+	Influencer             rdf.IRI // prov:influencer
+	HadPlan                rdf.IRI // prov:hadPlan
+	HadRole                rdf.IRI // prov:hadRole
+	HadActivity            rdf.IRI // prov:hadActivity
+	HadGeneration          rdf.IRI // prov:hadGeneration
+	HadUsage               rdf.IRI // prov:hadUsage
+}{
+	Namespace:              "http://www.w3.org/ns/prov#",
+	Entity:                 rdf.IRI{Value: "http://www.w3.org/ns/prov#Entity"},
+	Activity:               rdf.IRI{Value: "http://www.w3.org/ns/prov#Activity"},
+	Agent:                  rdf.IRI{Value: "http://www.w3.org/ns/prov#Agent"},
+	Person:                 rdf.IRI{Value: "http://www.w3.org/ns/prov#Person"},
+	Organization:           rdf.IRI{Value: "http://www.w3.org/ns/prov#Organization"},
+	SoftwareAgent:          rdf.IRI{Value: "http://www.w3.org/ns/prov#SoftwareAgent"},
+	Plan:                   rdf.IRI{Value: "http://www.w3.org/ns/prov#Plan"},
+	Bundle:                 rdf.IRI{Value: "http://www.w3.org/ns/prov#Bundle"},
+	Collection:             rdf.IRI{Value: "http://www.w3.org/ns/prov#Collection"},
+	EmptyCollection:        rdf.IRI{Value: "http://www.w3.org/ns/prov#EmptyCollection"},
+	Location:               rdf.IRI{Value: "http://www.w3.org/ns/prov#Location"},
+	Role:                   rdf.IRI{Value: "http://www.w3.org/ns/prov#Role"},
+	Influence:              rdf.IRI{Value: "http://www.w3.org/ns/prov#Influence"},
+	EntityInfluence:        rdf.IRI{Value: "http://www.w3.org/ns/prov#EntityInfluence"},
+	ActivityInfluence:      rdf.IRI{Value: "http://www.w3.org/ns/prov#ActivityInfluence"},
+	AgentInfluence:         rdf.IRI{Value: "http://www.w3.org/ns/prov#AgentInfluence"},
+	Usage:                  rdf.IRI{Value: "http://www.w3.org/ns/prov#Usage"},
+	Generation:             rdf.IRI{Value: "http://www.w3.org/ns/prov#Generation"},
+	Invalidation:           rdf.IRI{Value: "http://www.w3.org/ns/prov#Invalidation"},
+	Start:                  rdf.IRI{Value: "http://www.w3.org/ns/prov#Start"},
+	End:                    rdf.IRI{Value: "http://www.w3.org/ns/prov#End"},
+	Communication:          rdf.IRI{Value: "http://www.w3.org/ns/prov#Communication"},
+	Association:            rdf.IRI{Value: "http://www.w3.org/ns/prov#Association"},
+	Attribution:            rdf.IRI{Value: "http://www.w3.org/ns/prov#Attribution"},
+	Delegation:             rdf.IRI{Value: "http://www.w3.org/ns/prov#Delegation"},
+	Derivation:             rdf.IRI{Value: "http://www.w3.org/ns/prov#Derivation"},
+	Revision:               rdf.IRI{Value: "http://www.w3.org/ns/prov#Revision"},
+	Quotation:              rdf.IRI{Value: "http://www.w3.org/ns/prov#Quotation"},
+	PrimarySource:          rdf.IRI{Value: "http://www.w3.org/ns/prov#PrimarySource"},
+	WasGeneratedBy:         rdf.IRI{Value: "http://www.w3.org/ns/prov#wasGeneratedBy"},
+	Used:                   rdf.IRI{Value: "http://www.w3.org/ns/prov#used"},
+	WasInformedBy:          rdf.IRI{Value: "http://www.w3.org/ns/prov#wasInformedBy"},
+	WasStartedBy:           rdf.IRI{Value: "http://www.w3.org/ns/prov#wasStartedBy"},
+	WasEndedBy:             rdf.IRI{Value: "http://www.w3.org/ns/prov#wasEndedBy"},
+	WasInvalidatedBy:       rdf.IRI{Value: "http://www.w3.org/ns/prov#wasInvalidatedBy"},
+	WasDerivedFrom:         rdf.IRI{Value: "http://www.w3.org/ns/prov#wasDerivedFrom"},
+	WasRevisionOf:          rdf.IRI{Value: "http://www.w3.org/ns/prov#wasRevisionOf"},
+	WasQuotedFrom:          rdf.IRI{Value: "http://www.w3.org/ns/prov#wasQuotedFrom"},
+	HadPrimarySource:       rdf.IRI{Value: "http://www.w3.org/ns/prov#hadPrimarySource"},
+	WasAttributedTo:        rdf.IRI{Value: "http://www.w3.org/ns/prov#wasAttributedTo"},
+	WasAssociatedWith:      rdf.IRI{Value: "http://www.w3.org/ns/prov#wasAssociatedWith"},
+	ActedOnBehalfOf:        rdf.IRI{Value: "http://www.w3.org/ns/prov#actedOnBehalfOf"},
+	WasInfluencedBy:        rdf.IRI{Value: "http://www.w3.org/ns/prov#wasInfluencedBy"},
+	AlternateOf:            rdf.IRI{Value: "http://www.w3.org/ns/prov#alternateOf"},
+	SpecializationOf:       rdf.IRI{Value: "http://www.w3.org/ns/prov#specializationOf"},
+	HadMember:              rdf.IRI{Value: "http://www.w3.org/ns/prov#hadMember"},
+	AtLocation:             rdf.IRI{Value: "http://www.w3.org/ns/prov#atLocation"},
+	Value:                  rdf.IRI{Value: "http://www.w3.org/ns/prov#value"},
+	GeneratedAtTime:        rdf.IRI{Value: "http://www.w3.org/ns/prov#generatedAtTime"},
+	InvalidatedAtTime:      rdf.IRI{Value: "http://www.w3.org/ns/prov#invalidatedAtTime"},
+	StartedAtTime:          rdf.IRI{Value: "http://www.w3.org/ns/prov#startedAtTime"},
+	EndedAtTime:            rdf.IRI{Value: "http://www.w3.org/ns/prov#endedAtTime"},
+	AtTime:                 rdf.IRI{Value: "http://www.w3.org/ns/prov#atTime"},
+	Generated:              rdf.IRI{Value: "http://www.w3.org/ns/prov#generated"},
+	Invalidated:            rdf.IRI{Value: "http://www.w3.org/ns/prov#invalidated"},
+	Influenced:             rdf.IRI{Value: "http://www.w3.org/ns/prov#influenced"},
+	QualifiedInfluence:     rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedInfluence"},
+	QualifiedGeneration:    rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedGeneration"},
+	QualifiedUsage:         rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedUsage"},
+	QualifiedInvalidation:  rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedInvalidation"},
+	QualifiedStart:         rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedStart"},
+	QualifiedEnd:           rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedEnd"},
+	QualifiedCommunication: rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedCommunication"},
+	QualifiedAssociation:   rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedAssociation"},
+	QualifiedAttribution:   rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedAttribution"},
+	QualifiedDelegation:    rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedDelegation"},
+	QualifiedDerivation:    rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedDerivation"},
+	QualifiedRevision:      rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedRevision"},
+	QualifiedQuotation:     rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedQuotation"},
+	QualifiedPrimarySource: rdf.IRI{Value: "http://www.w3.org/ns/prov#qualifiedPrimarySource"},
+	EntityProperty:         rdf.IRI{Value: "http://www.w3.org/ns/prov#entity"},
+	ActivityProperty:       rdf.IRI{Value: "http://www.w3.org/ns/prov#activity"},
+	AgentProperty:          rdf.IRI{Value: "http://www.w3.org/ns/prov#agent"},
+	Influencer:             rdf.IRI{Value: "http://www.w3.org/ns/prov#influencer"},
+	HadPlan:                rdf.IRI{Value: "http://www.w3.org/ns/prov#hadPlan"},
+	HadRole:                rdf.IRI{Value: "http://www.w3.org/ns/prov#hadRole"},
+	HadActivity:            rdf.IRI{Value: "http://www.w3.org/ns/prov#hadActivity"},
+	HadGeneration:          rdf.IRI{Value: "http://www.w3.org/ns/prov#hadGeneration"},
+	HadUsage:               rdf.IRI{Value: "http://www.w3.org/ns/prov#hadUsage"},
+}